@@ -6,9 +6,13 @@ package agent
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net/http"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -21,11 +25,13 @@ import (
 	"github.com/orb-community/orb/agent/config"
 	manager "github.com/orb-community/orb/agent/policyMgr"
 	"github.com/orb-community/orb/buildinfo"
+	"github.com/orb-community/orb/fleet"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrMqttConnection = errors.New("failed to connect to a broker")
+	ErrUnsupportedRPC = errors.New("unsupported RPC func")
 )
 
 type Agent interface {
@@ -48,9 +54,12 @@ type orbAgent struct {
 
 	asyncContext context.Context
 
-	hbTicker        *time.Ticker
+	hbTimer         *time.Timer
 	heartbeatCtx    context.Context
 	heartbeatCancel context.CancelFunc
+	// heartbeatPauses are the windows no heartbeat is published in, hbPaused is set while in one of them
+	heartbeatPauses []config.TimeWindow
+	hbPaused        bool
 
 	// Agent RPC channel, configured from command line
 	baseTopic         string
@@ -59,6 +68,7 @@ type orbAgent struct {
 	capabilitiesTopic string
 	heartbeatsTopic   string
 	logTopic          string
+	deadLetterTopic   string
 
 	// Retry Mechanism to ensure the Request is received
 	groupRequestTicker     *time.Ticker
@@ -68,8 +78,29 @@ type orbAgent struct {
 
 	// AgentGroup channels sent from core
 	groupsInfos map[string]GroupInfo
+	// groupsSynced is set once a full group membership list was handled since the last subscription reset
+	groupsSynced bool
 
 	policyManager manager.PolicyManager
+
+	// signingKey signs the messages to the control plane, nil when signing is disabled
+	signingKey []byte
+
+	// spool keeps the messages to the control plane published while disconnected, nil when disabled
+	spool *diskSpool
+
+	// metricsServer serves the agent metrics, nil when disabled
+	metricsServer *http.Server
+
+	// backendCapabilities is the map[string]fleet.BackendInfo last sent as capabilities, hashed on the heartbeats
+	backendCapabilities atomic.Value
+
+	// lastRequestID is the request id of the last RPC from core handled, echoed once on the next heartbeat
+	lastRequestID atomic.Value
+	// maintenance is set while core keeps the agent in maintenance, no policy is applied until it exits
+	maintenance atomic.Bool
+	// updateResult is the outcome of the last agent update RPC, reported once on the next heartbeat
+	updateResult atomic.Pointer[fleet.AgentUpdateResult]
 }
 
 const retryRequestDuration = time.Second
@@ -85,6 +116,23 @@ type GroupInfo struct {
 var _ Agent = (*orbAgent)(nil)
 
 func New(logger *zap.Logger, c config.Config) (Agent, error) {
+	if _, err := c.OrbAgent.TLS.MinTLSVersion(); err != nil {
+		logger.Error("invalid tls configuration", zap.Error(err))
+		return nil, err
+	}
+	if err := c.OrbAgent.Cloud.MQTT.QoS.Validate(); err != nil {
+		logger.Error("invalid mqtt configuration", zap.Error(err))
+		return nil, err
+	}
+	if err := c.OrbAgent.Cloud.MQTT.LogSampling.Validate(); err != nil {
+		logger.Error("invalid mqtt configuration", zap.Error(err))
+		return nil, err
+	}
+	heartbeatPauses, err := c.OrbAgent.Heartbeat.ParsePauseWindows()
+	if err != nil {
+		logger.Error("invalid heartbeat configuration", zap.Error(err))
+		return nil, err
+	}
 	logger.Info("using local config db", zap.String("filename", c.OrbAgent.DB.File))
 	db, err := sqlx.Connect("sqlite3", c.OrbAgent.DB.File)
 	if err != nil {
@@ -100,7 +148,34 @@ func New(logger *zap.Logger, c config.Config) (Agent, error) {
 		logger.Error("policy manager failed to get repository", zap.Error(err))
 		return nil, err
 	}
-	return &orbAgent{logger: logger, config: c, policyManager: pm, db: db, groupsInfos: make(map[string]GroupInfo)}, nil
+	agent := &orbAgent{logger: logger, config: c, policyManager: pm, db: db, groupsInfos: make(map[string]GroupInfo),
+		heartbeatPauses: heartbeatPauses}
+	if c.OrbAgent.Cloud.MQTT.SigningKey != "" {
+		agent.signingKey, err = hex.DecodeString(c.OrbAgent.Cloud.MQTT.SigningKey)
+		if err != nil {
+			logger.Error("invalid message signing key, a hex encoded key is expected", zap.Error(err))
+			return nil, err
+		}
+		logger.Info("signing the messages to the control plane")
+	}
+	if c.OrbAgent.Cloud.Spool.Enable {
+		agent.spool, err = newDiskSpool(c.OrbAgent.Cloud.Spool)
+		if err != nil {
+			logger.Error("failed to open the outgoing message spool", zap.Error(err))
+			return nil, err
+		}
+		logger.Info("using outgoing message spool", zap.String("dir", agent.spool.dir), zap.Int("spooled", agent.spool.len()))
+	}
+	return agent, nil
+}
+
+// backendType returns the type of a configured backend instance, which defaults to the instance name
+// so several instances of the same type can be set under distinct names
+func backendType(name string, configurationEntry map[string]string) string {
+	if beType, ok := configurationEntry[config.BackendTypeKey]; ok && beType != "" {
+		return beType
+	}
+	return name
 }
 
 func (a *orbAgent) startBackends(agentCtx context.Context) error {
@@ -112,12 +187,15 @@ func (a *orbAgent) startBackends(agentCtx context.Context) error {
 	a.backends = make(map[string]backend.Backend, len(a.config.OrbAgent.Backends))
 	a.backendState = make(map[string]*backend.State)
 	for name, configurationEntry := range a.config.OrbAgent.Backends {
-		if !backend.HaveBackend(name) {
-			return errors.New("specified backend does not exist: " + name)
+		beType := backendType(name, configurationEntry)
+		if !backend.HaveBackend(beType) {
+			return errors.New("specified backend does not exist: " + beType)
 		}
-		be := backend.GetBackend(name)
+		be := backend.NewBackend(beType)
 		configuration := structs.Map(a.config.OrbAgent.Otel)
 		configuration["agent_tags"] = a.config.OrbAgent.Tags
+		configuration["mqtt_log_qos"] = a.config.OrbAgent.Cloud.MQTT.QoS.Log
+		configuration["mqtt_log_sampling"] = a.config.OrbAgent.Cloud.MQTT.LogSampling
 		if err := be.Configure(a.logger, a.policyManager.GetRepo(), configurationEntry, configuration); err != nil {
 			a.logger.Info("failed to configure backend", zap.String("backend", name), zap.Error(err))
 			return err
@@ -129,6 +207,7 @@ func (a *orbAgent) startBackends(agentCtx context.Context) error {
 			backendCtx = context.WithValue(backendCtx, "agent_id", "auto-provisioning-without-id")
 		}
 		a.backends[name] = be
+		backend.AddInstance(name, be)
 		initialState := be.GetInitialState()
 		a.backendState[name] = &backend.State{
 			Status:        initialState,
@@ -170,6 +249,13 @@ func (a *orbAgent) Start(ctx context.Context, cancelFunc context.CancelFunc) err
 		mqtt.DEBUG = &agentLoggerDebug{a: a}
 	}
 
+	if a.config.OrbAgent.Metrics.Enable {
+		if err := a.startMetricsServer(); err != nil {
+			a.logger.Error("could not start the metrics server", zap.Error(err))
+			return err
+		}
+	}
+
 	ccm, err := cloud_config.New(a.logger, a.config, a.db)
 	if err != nil {
 		return err
@@ -195,7 +281,7 @@ func (a *orbAgent) Start(ctx context.Context, cancelFunc context.CancelFunc) err
 }
 
 func (a *orbAgent) logonWithHeartbeat() {
-	a.hbTicker = time.NewTicker(HeartbeatFreq)
+	a.hbTimer = time.NewTimer(heartbeatDelay(HeartbeatFreq, a.config.OrbAgent.Heartbeat, true, rand.Int63n))
 	a.heartbeatCtx, a.heartbeatCancel = a.extendContext("heartbeat")
 	go a.sendHeartbeats(a.heartbeatCtx, a.heartbeatCancel)
 	a.logger.Info("heartbeat routine started")
@@ -230,6 +316,7 @@ func (a *orbAgent) Stop(ctx context.Context) {
 	if a.client != nil && a.client.IsConnected() {
 		a.client.Disconnect(0)
 	}
+	a.stopMetricsServer(ctx)
 	a.logger.Debug("stopping agent with number of go routines and go calls", zap.Int("goroutines", runtime.NumGoroutine()), zap.Int64("gocalls", runtime.NumCgoCall()))
 	if a.policyRequestSucceeded != nil {
 		a.policyRequestSucceeded()
@@ -241,11 +328,11 @@ func (a *orbAgent) Stop(ctx context.Context) {
 }
 
 func (a *orbAgent) RestartBackend(ctx context.Context, name string, reason string) error {
-	if !backend.HaveBackend(name) {
+	be, ok := a.backends[name]
+	if !ok {
 		return errors.New("specified backend does not exist: " + name)
 	}
 
-	be := a.backends[name]
 	a.logger.Info("restarting backend", zap.String("backend", name), zap.String("reason", reason))
 	a.backendState[name].RestartCount += 1
 	a.backendState[name].LastRestartTS = time.Now()
@@ -261,16 +348,17 @@ func (a *orbAgent) RestartBackend(ctx context.Context, name string, reason strin
 	}
 	a.logger.Info("resetting backend", zap.String("backend", name))
 
-	if err := be.FullReset(ctx); err != nil {
-		a.backendState[name].LastError = fmt.Sprintf("failed to reset backend: %v", err)
-		a.logger.Error("failed to reset backend", zap.String("backend", name), zap.Error(err))
+	resetErr := be.FullReset(ctx)
+	if resetErr != nil {
+		a.backendState[name].LastError = fmt.Sprintf("failed to reset backend: %v", resetErr)
+		a.logger.Error("failed to reset backend", zap.String("backend", name), zap.Error(resetErr))
 	}
 	be.SetCommsClient(a.agent_id, &a.client, fmt.Sprintf("%s/?/%s", a.baseTopic, name))
 
 	if err := a.sendAgentPoliciesReq(); err != nil {
 		a.logger.Error("failed to send agent policies request", zap.Error(err))
 	}
-	return nil
+	return resetErr
 }
 
 func (a *orbAgent) restartComms(ctx context.Context) error {
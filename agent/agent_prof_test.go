@@ -2,7 +2,14 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func Test_orbAgent_startBackends(t *testing.T) {
@@ -27,3 +34,194 @@ func Test_orbAgent_startBackends(t *testing.T) {
 		})
 	}
 }
+
+func Test_backendType(t *testing.T) {
+	tests := []struct {
+		name               string
+		configurationEntry map[string]string
+		want               string
+	}{
+		{
+			name:               "pktvisor",
+			configurationEntry: map[string]string{"binary": "/usr/local/sbin/pktvisord"},
+			want:               "pktvisor",
+		},
+		{
+			name:               "pktvisor-eth1",
+			configurationEntry: map[string]string{"type": "pktvisor", "api_port": "10854"},
+			want:               "pktvisor",
+		},
+		{
+			name:               "otel",
+			configurationEntry: map[string]string{"type": ""},
+			want:               "otel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backendType(tt.name, tt.configurationEntry); got != tt.want {
+				t.Errorf("backendType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mqttClientID(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		suffix string
+		want   string
+	}{
+		{
+			name: "without suffix",
+			id:   "f2f8b6c4-3d0e-4b5a-9a1c-1e2d3c4b5a69",
+			want: "f2f8b6c4-3d0e-4b5a-9a1c-1e2d3c4b5a69",
+		},
+		{
+			name:   "with suffix",
+			id:     "f2f8b6c4-3d0e-4b5a-9a1c-1e2d3c4b5a69",
+			suffix: "standby",
+			want:   "f2f8b6c4-3d0e-4b5a-9a1c-1e2d3c4b5a69-standby",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mqttClientID(tt.id, tt.suffix); got != tt.want {
+				t.Errorf("mqttClientID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryWithBackoff(t *testing.T) {
+	policy := config.RetryConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+		Deadline:       time.Second,
+	}
+	publishErr := errors.New("publish failed")
+	tests := []struct {
+		name         string
+		failures     int
+		policy       config.RetryConfig
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds at first attempt",
+			policy:       policy,
+			wantAttempts: 1,
+		},
+		{
+			name:         "succeeds after retrying",
+			failures:     3,
+			policy:       policy,
+			wantAttempts: 4,
+		},
+		{
+			name:     "gives up when the next attempt would pass the deadline",
+			failures: 100,
+			policy: config.RetryConfig{
+				InitialBackoff: time.Minute,
+				MaxBackoff:     time.Minute,
+				Deadline:       time.Second,
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := retryWithBackoff(context.Background(), tt.policy, func(attempt int) error {
+				attempts = attempt
+				if attempt <= tt.failures {
+					return publishErr
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryWithBackoff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("retryWithBackoff() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func Test_heartbeatDelay(t *testing.T) {
+	freq := 50 * time.Second
+	maxRand := func(n int64) int64 { return n - 1 }
+	tests := []struct {
+		name  string
+		cfg   config.Heartbeat
+		first bool
+		randN func(int64) int64
+		want  time.Duration
+	}{
+		{
+			name:  "no jitter",
+			first: true,
+			randN: maxRand,
+			want:  freq,
+		},
+		{
+			name:  "phase offset on first heartbeat",
+			cfg:   config.Heartbeat{Jitter: 0.1},
+			first: true,
+			randN: maxRand,
+			want:  freq + 5*time.Second - 1,
+		},
+		{
+			name:  "no offset on following heartbeats",
+			cfg:   config.Heartbeat{Jitter: 0.1},
+			randN: maxRand,
+			want:  freq,
+		},
+		{
+			name:  "jitter on each heartbeat",
+			cfg:   config.Heartbeat{Jitter: 0.1, JitterEachBeat: true},
+			randN: func(int64) int64 { return 0 },
+			want:  freq - 5*time.Second,
+		},
+		{
+			name:  "jitter is capped",
+			cfg:   config.Heartbeat{Jitter: 2, JitterEachBeat: true},
+			randN: func(int64) int64 { return 0 },
+			want:  freq / 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := heartbeatDelay(freq, tt.cfg, tt.first, tt.randN); got != tt.want {
+				t.Errorf("heartbeatDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_heartbeatPaused(t *testing.T) {
+	cfg := config.Heartbeat{PauseWindows: []config.PauseWindow{{Start: "2024-03-02T22:00:00Z", End: "2024-03-03T02:00:00Z"}}}
+	windows, err := cfg.ParsePauseWindows()
+	require.NoError(t, err)
+	a := &orbAgent{logger: zap.NewNop(), heartbeatPauses: windows}
+
+	start := windows[0].Start
+	assert.False(t, a.heartbeatPaused(start.Add(-time.Second)))
+	assert.True(t, a.heartbeatPaused(start))
+	assert.True(t, a.hbPaused)
+	assert.True(t, a.heartbeatPaused(start.Add(time.Hour)))
+	assert.False(t, a.heartbeatPaused(windows[0].End))
+	assert.False(t, a.hbPaused)
+
+	for desc, window := range map[string]config.PauseWindow{
+		"invalid start":        {Start: "tonight", End: "2024-03-03T02:00:00Z"},
+		"invalid end":          {Start: "2024-03-02T22:00:00Z", End: "tomorrow"},
+		"end before the start": {Start: "2024-03-03T02:00:00Z", End: "2024-03-02T22:00:00Z"},
+	} {
+		_, err := config.Heartbeat{PauseWindows: []config.PauseWindow{window}}.ParsePauseWindows()
+		assert.Error(t, err, desc)
+	}
+}
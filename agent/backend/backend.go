@@ -6,6 +6,7 @@ package backend
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -48,6 +49,27 @@ type State struct {
 	LastError         string
 	LastRestartTS     time.Time
 	LastRestartReason string
+
+	// resetResult is the outcome of the last reset requested by core, until reported on a heartbeat. The RPC
+	// handler sets it while the heartbeat loop takes it, so it is guarded by resetMu
+	resetMu     sync.Mutex
+	resetResult string
+}
+
+// SetResetResult records the outcome of the last reset requested by core
+func (s *State) SetResetResult(result string) {
+	s.resetMu.Lock()
+	defer s.resetMu.Unlock()
+	s.resetResult = result
+}
+
+// TakeResetResult returns the outcome of the last reset requested by core and clears it, so it is reported once
+func (s *State) TakeResetResult() string {
+	s.resetMu.Lock()
+	defer s.resetMu.Unlock()
+	result := s.resetResult
+	s.resetResult = ""
+	return result
 }
 
 func (s RunningStatus) String() string {
@@ -71,10 +93,16 @@ type Backend interface {
 	RemovePolicy(data policies.PolicyData) error
 }
 
-var registry = make(map[string]Backend)
+// Factory creates a new, not yet configured, instance of a backend type
+type Factory func() Backend
+
+var registry = make(map[string]Factory)
 
-func Register(name string, b Backend) {
-	registry[name] = b
+// instances holds the backends configured on the agent, keyed by instance name
+var instances = make(map[string]Backend)
+
+func Register(name string, factory Factory) {
+	registry[name] = factory
 }
 
 func GetList() []string {
@@ -90,6 +118,25 @@ func HaveBackend(name string) bool {
 	return prs
 }
 
-func GetBackend(name string) Backend {
-	return registry[name]
+// NewBackend creates a new instance of the given backend type
+func NewBackend(name string) Backend {
+	factory, ok := registry[name]
+	if !ok {
+		return nil
+	}
+	return factory()
+}
+
+// AddInstance makes a configured backend available under its instance name, so policies can be routed to it
+func AddInstance(name string, be Backend) {
+	instances[name] = be
+}
+
+func HaveInstance(name string) bool {
+	_, prs := instances[name]
+	return prs
+}
+
+func GetInstance(name string) Backend {
+	return instances[name]
 }
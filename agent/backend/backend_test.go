@@ -0,0 +1,38 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateResetResult(t *testing.T) {
+	state := &State{Status: Running}
+	assert.Empty(t, state.TakeResetResult())
+
+	state.SetResetResult("succeeded")
+	assert.Equal(t, "succeeded", state.TakeResetResult())
+	assert.Empty(t, state.TakeResetResult(), "expected the reset result to be reported once")
+
+	// the RPC handler sets the result while the heartbeat loop takes it
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			state.SetResetResult("succeeded")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			state.TakeResetResult()
+		}
+	}()
+	wg.Wait()
+}
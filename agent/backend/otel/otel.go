@@ -47,6 +47,10 @@ type openTelemetryBackend struct {
 	otlpMetricsTopic string
 	otlpTracesTopic  string
 	otlpLogsTopic    string
+	// otlpLogsQoS is the level the logs are published with
+	otlpLogsQoS byte
+	// otlpLogSampling throttles the published logs
+	otlpLogSampling  otlpmqttexporter.LogSamplingSettings
 	otelReceiverTaps []string
 	otelCurrVersion  string
 
@@ -83,6 +87,11 @@ func (o *openTelemetryBackend) Configure(logger *zap.Logger, repo policies.Polic
 	if agentTags, ok := otelConfig["agent_tags"]; ok {
 		o.agentTags = agentTags.(map[string]string)
 	}
+	o.otlpLogsQoS = otlpmqttexporter.DefaultQoS
+	if qos, ok := otelConfig["mqtt_log_qos"].(byte); ok {
+		o.otlpLogsQoS = qos
+	}
+	o.otlpLogSampling = logSamplingSettings(otelConfig)
 	if otelPort, ok := config["otlp_port"]; ok {
 		o.otelReceiverPort, err = strconv.Atoi(otelPort)
 		if err != nil {
@@ -101,6 +110,12 @@ func (o *openTelemetryBackend) Configure(logger *zap.Logger, repo policies.Polic
 	return nil
 }
 
+// logSamplingSettings returns the sampling of the published logs set by the agent, disabled when unset
+func logSamplingSettings(otelConfig map[string]interface{}) otlpmqttexporter.LogSamplingSettings {
+	sampling, _ := otelConfig["mqtt_log_sampling"].(config.LogSampling)
+	return otlpmqttexporter.LogSamplingSettings{RateLimit: sampling.RateLimit, SampleRatio: sampling.SampleRatio}
+}
+
 func (o *openTelemetryBackend) GetInitialState() backend.RunningStatus {
 	return backend.Waiting
 }
@@ -158,6 +173,9 @@ func (o *openTelemetryBackend) Start(ctx context.Context, cancelFunc context.Can
 		return err
 	}
 	for _, policyData := range policiesData {
+		if backend.GetInstance(policyData.Backend) != backend.Backend(o) {
+			continue
+		}
 		if err := o.ApplyPolicy(policyData, true); err != nil {
 			o.logger.Error("failed to start otel backend, failed to apply policy", zap.Error(err))
 			cancelFunc()
@@ -189,7 +207,9 @@ func (o *openTelemetryBackend) FullReset(ctx context.Context) error {
 }
 
 func Register() bool {
-	backend.Register("otel", &openTelemetryBackend{})
+	backend.Register("otel", func() backend.Backend {
+		return &openTelemetryBackend{}
+	})
 	return true
 }
 
@@ -258,6 +278,8 @@ func (o *openTelemetryBackend) createOtlpLogsMqttExporter(ctx context.Context, c
 	bridgeService := otel.NewBridgeService(ctx, cancelFunc, &o.policyRepo, o.agentTags)
 	if o.mqttClient != nil {
 		cfg := otlpmqttexporter.CreateConfigClient(o.mqttClient, o.otlpLogsTopic, "", bridgeService)
+		cfg.(*otlpmqttexporter.Config).QoS = o.otlpLogsQoS
+		cfg.(*otlpmqttexporter.Config).LogSampling = o.otlpLogSampling
 		set := otlpmqttexporter.CreateDefaultSettings(o.logger)
 		// Create the OTLP metrics metricsExporter that'll receive and verify the metrics produced.
 		exporter, err := otlpmqttexporter.CreateLogsExporter(ctx, set, cfg)
@@ -268,6 +290,8 @@ func (o *openTelemetryBackend) createOtlpLogsMqttExporter(ctx context.Context, c
 	} else {
 		cfg := otlpmqttexporter.CreateConfig(o.mqttConfig.Address, o.mqttConfig.Id, o.mqttConfig.Key,
 			o.mqttConfig.ChannelID, "", o.otlpLogsTopic, bridgeService)
+		cfg.(*otlpmqttexporter.Config).QoS = o.otlpLogsQoS
+		cfg.(*otlpmqttexporter.Config).LogSampling = o.otlpLogSampling
 		set := otlpmqttexporter.CreateDefaultSettings(o.logger)
 		// Create the OTLP metrics exporter that'll receive and verify the metrics produced.
 		exporter, err := otlpmqttexporter.CreateLogsExporter(ctx, set, cfg)
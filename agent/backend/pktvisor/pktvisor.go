@@ -36,6 +36,8 @@ const (
 	VersionTimeout      = 2
 	ScrapeTimeout       = 5
 	TapsTimeout         = 5
+	TapsRetries         = 3
+	TapsRetryBackoff    = 2
 	DefaultConfigPath   = "/opt/orb/agent.yaml"
 	DefaultAPIHost      = "localhost"
 	DefaultAPIPort      = "10853"
@@ -318,17 +320,43 @@ func (p *pktvisorBackend) Configure(logger *zap.Logger, repo policies.PolicyRepo
 	return nil
 }
 
+// GetCapabilities reports the taps loaded by pktvisor, a slow starting pktvisor may not have loaded the taps
+// of its configuration file yet, so the query is retried a few times before reporting it without taps
 func (p *pktvisorBackend) GetCapabilities() (map[string]interface{}, error) {
 	var taps interface{}
-	err := p.request("taps", &taps, http.MethodGet, http.NoBody, "application/json", TapsTimeout)
-	if err != nil {
-		return nil, err
+	for attempt := 0; attempt <= TapsRetries; attempt++ {
+		if attempt > 0 {
+			p.logger.Info("pktvisor reported no taps yet, retrying", zap.Int("attempt", attempt), zap.Int("backoff_seconds", TapsRetryBackoff))
+			time.Sleep(TapsRetryBackoff * time.Second)
+		}
+		taps = nil
+		err := p.request("taps", &taps, http.MethodGet, http.NoBody, "application/json", TapsTimeout)
+		if err != nil {
+			return nil, err
+		}
+		if hasTaps(taps) {
+			break
+		}
+	}
+	if !hasTaps(taps) {
+		p.logger.Warn("pktvisor reported no taps after retrying, sending capabilities without taps")
 	}
 	jsonBody := make(map[string]interface{})
 	jsonBody["taps"] = taps
 	return jsonBody, nil
 }
 
+func hasTaps(taps interface{}) bool {
+	switch t := taps.(type) {
+	case map[string]interface{}:
+		return len(t) > 0
+	case []interface{}:
+		return len(t) > 0
+	default:
+		return false
+	}
+}
+
 func (p *pktvisorBackend) FullReset(ctx context.Context) error {
 
 	// force a stop, which stops scrape as well. if proc is dead, it no ops.
@@ -352,8 +380,10 @@ func (p *pktvisorBackend) FullReset(ctx context.Context) error {
 }
 
 func Register() bool {
-	backend.Register("pktvisor", &pktvisorBackend{
-		adminAPIProtocol: "http",
+	backend.Register("pktvisor", func() backend.Backend {
+		return &pktvisorBackend{
+			adminAPIProtocol: "http",
+		}
 	})
 	return true
 }
@@ -0,0 +1,136 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// decodeExposition reads the metric families of a scrape response, in text or protobuf format
+func decodeExposition(body io.Reader, header http.Header) ([]*dto.MetricFamily, error) {
+	decoder := expfmt.NewDecoder(body, expfmt.ResponseFormat(header))
+	var families []*dto.MetricFamily
+	for {
+		family := &dto.MetricFamily{}
+		if err := decoder.Decode(family); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		families = append(families, family)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+	return families, nil
+}
+
+// toMetrics converts the scraped families to OTLP metrics on a scope tagged with the policy name, the way the
+// otlp mqtt exporter expects them. Counters, histograms and summaries are cumulative since startTime
+func toMetrics(families []*dto.MetricFamily, policyName string, startTime time.Time, now time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	scope := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scope.Scope().SetName(BackendName)
+	scope.Scope().Attributes().PutStr("policy_name", policyName)
+
+	start := pcommon.NewTimestampFromTime(startTime)
+	for _, family := range families {
+		m := scope.Metrics().AppendEmpty()
+		m.SetName(family.GetName())
+		m.SetDescription(family.GetHelp())
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for _, metric := range family.GetMetric() {
+				dp := sum.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), metric)
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(timestamp(metric, now))
+				dp.SetDoubleValue(metric.GetCounter().GetValue())
+			}
+		case dto.MetricType_SUMMARY:
+			summary := m.SetEmptySummary()
+			for _, metric := range family.GetMetric() {
+				dp := summary.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), metric)
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(timestamp(metric, now))
+				dp.SetCount(metric.GetSummary().GetSampleCount())
+				dp.SetSum(metric.GetSummary().GetSampleSum())
+				for _, q := range metric.GetSummary().GetQuantile() {
+					qv := dp.QuantileValues().AppendEmpty()
+					qv.SetQuantile(q.GetQuantile())
+					qv.SetValue(q.GetValue())
+				}
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			histogram := m.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for _, metric := range family.GetMetric() {
+				dp := histogram.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), metric)
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(timestamp(metric, now))
+				setBuckets(dp, metric.GetHistogram())
+			}
+		default:
+			// gauges and untyped samples
+			gauge := m.SetEmptyGauge()
+			for _, metric := range family.GetMetric() {
+				dp := gauge.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), metric)
+				dp.SetTimestamp(timestamp(metric, now))
+				if metric.GetUntyped() != nil {
+					dp.SetDoubleValue(metric.GetUntyped().GetValue())
+				} else {
+					dp.SetDoubleValue(metric.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+	return md
+}
+
+// setBuckets turns the cumulative Prometheus buckets into the per bucket counts of OTLP, the +Inf bucket
+// is the implicit last one
+func setBuckets(dp pmetric.HistogramDataPoint, h *dto.Histogram) {
+	dp.SetCount(h.GetSampleCount())
+	dp.SetSum(h.GetSampleSum())
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		dp.ExplicitBounds().Append(bucket.GetUpperBound())
+		dp.BucketCounts().Append(bucket.GetCumulativeCount() - previous)
+		previous = bucket.GetCumulativeCount()
+	}
+	dp.BucketCounts().Append(h.GetSampleCount() - previous)
+}
+
+func setAttributes(attributes pcommon.Map, metric *dto.Metric) {
+	for _, label := range metric.GetLabel() {
+		attributes.PutStr(label.GetName(), label.GetValue())
+	}
+}
+
+// timestamp uses the sample timestamp when the target exposes one, the scrape time otherwise
+func timestamp(metric *dto.Metric, now time.Time) pcommon.Timestamp {
+	if metric.TimestampMs != nil {
+		return pcommon.NewTimestampFromTime(time.UnixMilli(metric.GetTimestampMs()))
+	}
+	return pcommon.NewTimestampFromTime(now)
+}
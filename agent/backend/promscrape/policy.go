@@ -0,0 +1,197 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/orb-community/orb/agent/policies"
+	"go.uber.org/zap"
+)
+
+// scrapeConfig is where and how often a policy scrapes
+type scrapeConfig struct {
+	target   string
+	interval time.Duration
+}
+
+// runningPolicy is the scrape loop of an applied policy
+type runningPolicy struct {
+	cancel     context.CancelFunc
+	policyName string
+	config     scrapeConfig
+	lastErr    error
+}
+
+// parseScrapeConfig reads the target and interval of the policy, falling back to the ones of the backend config
+func parseScrapeConfig(data interface{}, defaults scrapeConfig) (scrapeConfig, error) {
+	cfg := defaults
+	if data != nil {
+		policy, ok := data.(map[string]interface{})
+		if !ok {
+			return scrapeConfig{}, errors.New("malformed prometheus scrape policy")
+		}
+		if target, ok := policy["target"]; ok {
+			s, ok := target.(string)
+			if !ok {
+				return scrapeConfig{}, errors.New("target must be a string")
+			}
+			cfg.target = s
+		}
+		if interval, ok := policy["interval"]; ok {
+			d, err := parseInterval(interval)
+			if err != nil {
+				return scrapeConfig{}, err
+			}
+			cfg.interval = d
+		}
+	}
+	if err := cfg.validate(); err != nil {
+		return scrapeConfig{}, err
+	}
+	return cfg, nil
+}
+
+// parseInterval accepts a duration string such as "30s" or a number of seconds
+func parseInterval(v interface{}) (time.Duration, error) {
+	switch interval := v.(type) {
+	case string:
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q: %w", interval, err)
+		}
+		return d, nil
+	case int:
+		return time.Duration(interval) * time.Second, nil
+	case float64:
+		return time.Duration(interval * float64(time.Second)), nil
+	default:
+		return 0, fmt.Errorf("invalid interval %v", v)
+	}
+}
+
+func (c scrapeConfig) validate() error {
+	if c.target == "" {
+		return errors.New("a scrape target is required")
+	}
+	if c.target != SelfTarget {
+		u, err := url.Parse(c.target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid scrape target %q, an http(s) URL or %s is expected", c.target, SelfTarget)
+		}
+	}
+	if c.interval <= 0 {
+		return errors.New("the scrape interval must be positive")
+	}
+	return nil
+}
+
+func (p *promScrapeBackend) ApplyPolicy(data policies.PolicyData, updatePolicy bool) error {
+	cfg, err := parseScrapeConfig(data.Data, p.defaults)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, ok := p.running[data.ID]; ok {
+		current.cancel()
+		delete(p.running, data.ID)
+	}
+	policyCtx, cancel := context.WithCancel(context.WithValue(p.ctx, "policy_id", data.ID))
+	entry := &runningPolicy{cancel: cancel, policyName: data.Name, config: cfg}
+	p.running[data.ID] = entry
+	p.logger.Info("scraping prometheus target for policy", zap.String("policy_id", data.ID),
+		zap.String("target", cfg.target), zap.Duration("interval", cfg.interval))
+	go p.scrapeLoop(policyCtx, entry)
+	return nil
+}
+
+func (p *promScrapeBackend) RemovePolicy(data policies.PolicyData) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.running[data.ID]
+	if !ok {
+		p.logger.Warn("no policy was removed, policy not found", zap.String("policy_id", data.ID))
+		return nil
+	}
+	p.logger.Info("removing policy", zap.String("policy_id", data.ID))
+	entry.cancel()
+	delete(p.running, data.ID)
+	return nil
+}
+
+func (p *promScrapeBackend) scrapeLoop(ctx context.Context, entry *runningPolicy) {
+	ticker := time.NewTicker(entry.config.interval)
+	defer ticker.Stop()
+	for {
+		err := p.scrape(ctx, entry)
+		if err != nil && ctx.Err() == nil {
+			p.logger.Warn("prometheus scrape failed", zap.String("policy", entry.policyName),
+				zap.String("target", entry.config.target), zap.Error(err))
+		}
+		p.mu.Lock()
+		entry.lastErr = err
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *promScrapeBackend) scrape(ctx context.Context, entry *runningPolicy) error {
+	if entry.config.target == SelfTarget {
+		return p.scrapeSelf(ctx, entry)
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, entry.config.target, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("target answered with status %d", res.StatusCode)
+	}
+	families, err := decodeExposition(res.Body, res.Header)
+	if err != nil {
+		return err
+	}
+
+	exp := p.metricsExporter()
+	if exp == nil {
+		// nothing to forward the metrics to until the agent is connected
+		return nil
+	}
+	return exp.ConsumeMetrics(ctx, toMetrics(families, entry.policyName, p.startTime, time.Now()))
+}
+
+// scrapeSelf forwards the metrics of the agent, which are only meaningful once it is connected
+func (p *promScrapeBackend) scrapeSelf(ctx context.Context, entry *runningPolicy) error {
+	exp := p.metricsExporter()
+	if exp == nil {
+		return nil
+	}
+	p.mu.Lock()
+	agentID := p.agentID
+	p.mu.Unlock()
+	md, err := selfMetrics(selfGatherer, agentID, entry.policyName, p.startTime, time.Now())
+	if err != nil {
+		return err
+	}
+	return exp.ConsumeMetrics(ctx, md)
+}
@@ -0,0 +1,228 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/orb-community/orb/agent/backend"
+	"github.com/orb-community/orb/agent/otel"
+	"github.com/orb-community/orb/agent/otel/otlpmqttexporter"
+	"github.com/orb-community/orb/agent/policies"
+	"github.com/orb-community/orb/buildinfo"
+	"go.opentelemetry.io/collector/exporter"
+	"go.uber.org/zap"
+)
+
+var _ backend.Backend = (*promScrapeBackend)(nil)
+
+const (
+	BackendName     = "prometheus_scrape"
+	DefaultInterval = 60 * time.Second
+	DefaultTimeout  = 10 * time.Second
+	acceptHeader    = "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3"
+)
+
+// promScrapeBackend scrapes the Prometheus endpoints given by its policies and forwards the samples as OTLP metrics,
+// it runs inside the agent so there is no process to manage. The policies targeting SelfTarget forward the
+// metrics of the agent itself
+type promScrapeBackend struct {
+	logger     *zap.Logger
+	startTime  time.Time
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+
+	policyRepo policies.PolicyRepo
+	agentTags  map[string]string
+	defaults   scrapeConfig
+	timeout    time.Duration
+	httpClient *http.Client
+
+	agentID          string
+	mqttClient       *mqtt.Client
+	otlpMetricsTopic string
+
+	mu             sync.Mutex
+	running        map[string]*runningPolicy
+	exporter       exporter.Metrics
+	exporterCtx    context.Context
+	exporterCancel context.CancelCauseFunc
+}
+
+// Configure reads the default target and interval of the policies and the scrape timeout
+func (p *promScrapeBackend) Configure(logger *zap.Logger, repo policies.PolicyRepo,
+	config map[string]string, otelConfig map[string]interface{}) error {
+	p.logger = logger
+	p.logger.Info("configuring prometheus scrape backend")
+	p.policyRepo = repo
+	p.defaults = scrapeConfig{target: config["target"], interval: DefaultInterval}
+	p.timeout = DefaultTimeout
+	if interval, ok := config["interval"]; ok {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid prometheus scrape interval %q, a positive duration is expected", interval)
+		}
+		p.defaults.interval = d
+	}
+	if timeout, ok := config["timeout"]; ok {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid prometheus scrape timeout %q, a positive duration is expected", timeout)
+		}
+		p.timeout = d
+	}
+	if agentTags, ok := otelConfig["agent_tags"]; ok {
+		p.agentTags = agentTags.(map[string]string)
+	}
+	p.httpClient = &http.Client{}
+	return nil
+}
+
+func (p *promScrapeBackend) SetCommsClient(agentID string, client *mqtt.Client, baseTopic string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.agentID = agentID
+	p.mqttClient = client
+	otelBaseTopic := strings.Replace(baseTopic, "?", "otlp", 1)
+	p.otlpMetricsTopic = fmt.Sprintf("%s/m/%c", otelBaseTopic, agentID[0])
+	// the exporter of the previous client is recreated on the next scrape
+	p.shutdownExporter()
+}
+
+func (p *promScrapeBackend) Version() (string, error) {
+	return buildinfo.GetVersion(), nil
+}
+
+func (p *promScrapeBackend) Start(ctx context.Context, cancelFunc context.CancelFunc) error {
+	p.ctx = ctx
+	p.cancelFunc = cancelFunc
+	p.startTime = time.Now()
+	p.mu.Lock()
+	p.running = make(map[string]*runningPolicy)
+	p.mu.Unlock()
+
+	policiesData, err := p.policyRepo.GetAll()
+	if err != nil {
+		cancelFunc()
+		p.logger.Error("failed to start prometheus scrape backend, policies are absent")
+		return err
+	}
+	for _, policyData := range policiesData {
+		if backend.GetInstance(policyData.Backend) != backend.Backend(p) {
+			continue
+		}
+		if err := p.ApplyPolicy(policyData, true); err != nil {
+			p.logger.Warn("failed to apply policy on start", zap.String("policy_id", policyData.ID), zap.Error(err))
+		}
+	}
+	p.logger.Info("started prometheus scrape backend")
+	return nil
+}
+
+func (p *promScrapeBackend) Stop(_ context.Context) error {
+	p.logger.Info("stopping all prometheus scrapes")
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for policyID, entry := range p.running {
+		entry.cancel()
+		delete(p.running, policyID)
+	}
+	p.shutdownExporter()
+	if p.cancelFunc != nil {
+		p.cancelFunc()
+	}
+	return nil
+}
+
+func (p *promScrapeBackend) FullReset(ctx context.Context) error {
+	if err := p.Stop(ctx); err != nil {
+		return err
+	}
+	backendCtx, cancelFunc := context.WithCancel(context.WithValue(ctx, "routine", BackendName))
+	return p.Start(backendCtx, cancelFunc)
+}
+
+func (p *promScrapeBackend) GetStartTime() time.Time {
+	return p.startTime
+}
+
+func (p *promScrapeBackend) GetCapabilities() (map[string]interface{}, error) {
+	capabilities := make(map[string]interface{})
+	capabilities["default_interval"] = p.defaults.interval.String()
+	capabilities["timeout"] = p.timeout.String()
+	return capabilities, nil
+}
+
+// GetRunningStatus reports an error only when every scraped target is failing, a single unreachable target
+// is reported through the logs
+func (p *promScrapeBackend) GetRunningStatus() (backend.RunningStatus, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.running) == 0 {
+		return backend.Waiting, "prometheus scrape backend is waiting for policy to come to start running", nil
+	}
+	var lastErr error
+	for _, entry := range p.running {
+		if entry.lastErr == nil {
+			return backend.Running, fmt.Sprintf("prometheus scrape backend running with %d policies", len(p.running)), nil
+		}
+		lastErr = entry.lastErr
+	}
+	return backend.BackendError, "all prometheus scrape targets are failing", lastErr
+}
+
+func (p *promScrapeBackend) GetInitialState() backend.RunningStatus {
+	return backend.Waiting
+}
+
+// metricsExporter returns the otlp mqtt exporter, creating it once the agent is connected
+func (p *promScrapeBackend) metricsExporter() exporter.Metrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exporter != nil && p.exporterCtx.Err() == nil {
+		return p.exporter
+	}
+	p.shutdownExporter()
+	if p.mqttClient == nil || *p.mqttClient == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancelCause(p.ctx)
+	bridgeService := otel.NewBridgeService(ctx, cancel, &p.policyRepo, p.agentTags)
+	cfg := otlpmqttexporter.CreateConfigClient(p.mqttClient, p.otlpMetricsTopic, buildinfo.GetVersion(), bridgeService)
+	exp, err := otlpmqttexporter.CreateMetricsExporter(ctx, otlpmqttexporter.CreateDefaultSettings(p.logger), cfg)
+	if err == nil {
+		err = exp.Start(ctx, nil)
+	}
+	if err != nil {
+		cancel(err)
+		p.logger.Error("failed to create the otlp mqtt exporter", zap.Error(err))
+		return nil
+	}
+	p.exporter, p.exporterCtx, p.exporterCancel = exp, ctx, cancel
+	return exp
+}
+
+// shutdownExporter must be called with the lock held
+func (p *promScrapeBackend) shutdownExporter() {
+	if p.exporter == nil {
+		return
+	}
+	_ = p.exporter.Shutdown(context.Background())
+	p.exporterCancel(context.Canceled)
+	p.exporter = nil
+}
+
+func Register() bool {
+	backend.Register(BackendName, func() backend.Backend {
+		return &promScrapeBackend{}
+	})
+	return true
+}
@@ -0,0 +1,143 @@
+package promscrape
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const exposition = `# HELP http_requests_total Requests served.
+# TYPE http_requests_total counter
+http_requests_total{code="200"} 10
+http_requests_total{code="500"} 2
+# HELP temperature Current temperature.
+# TYPE temperature gauge
+temperature 21.5
+# HELP latency_seconds Request latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 3
+latency_seconds_bucket{le="1"} 5
+latency_seconds_bucket{le="+Inf"} 6
+latency_seconds_sum 4.2
+latency_seconds_count 6
+`
+
+func TestToMetrics(t *testing.T) {
+	families, err := decodeExposition(strings.NewReader(exposition), http.Header{"Content-Type": []string{"text/plain; version=0.0.4"}})
+	require.NoError(t, err)
+
+	md := toMetrics(families, "policy-1", time.Now().Add(-time.Minute), time.Now())
+	scope := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	policyName, ok := scope.Scope().Attributes().Get("policy_name")
+	require.True(t, ok)
+	assert.Equal(t, "policy-1", policyName.AsString())
+
+	metrics := make(map[string]pmetric.Metric)
+	for i := 0; i < scope.Metrics().Len(); i++ {
+		metrics[scope.Metrics().At(i).Name()] = scope.Metrics().At(i)
+	}
+	require.Len(t, metrics, 3)
+
+	counter := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, 2, counter.Sum().DataPoints().Len())
+	code, _ := counter.Sum().DataPoints().At(0).Attributes().Get("code")
+	assert.Equal(t, "200", code.AsString())
+	assert.Equal(t, float64(10), counter.Sum().DataPoints().At(0).DoubleValue())
+
+	gauge := metrics["temperature"]
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, 21.5, gauge.Gauge().DataPoints().At(0).DoubleValue())
+
+	histogram := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, histogram.Type())
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 2, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestSelfMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	applies := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "orb_agent_policy_applies_total"}, []string{"backend"})
+	registry.MustRegister(applies)
+	applies.With(prometheus.Labels{"backend": "pktvisor"}).Add(3)
+
+	md, err := selfMetrics(registry, "agent-1", "agent-health", time.Now().Add(-time.Minute), time.Now())
+	require.NoError(t, err)
+	scope := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, SelfMetricsScope, scope.Scope().Name())
+	policyName, ok := scope.Scope().Attributes().Get("policy_name")
+	require.True(t, ok, "the otlp mqtt exporter resolves the policy from its name")
+	assert.Equal(t, "agent-health", policyName.AsString())
+
+	require.Equal(t, 1, scope.Metrics().Len())
+	dp := scope.Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, float64(3), dp.DoubleValue())
+	agentID, ok := dp.Attributes().Get(AgentIDAttribute)
+	require.True(t, ok)
+	assert.Equal(t, "agent-1", agentID.AsString())
+	label, _ := dp.Attributes().Get("backend")
+	assert.Equal(t, "pktvisor", label.AsString())
+}
+
+func TestParseScrapeConfig(t *testing.T) {
+	defaults := scrapeConfig{interval: DefaultInterval}
+	cases := map[string]struct {
+		data interface{}
+		want scrapeConfig
+		err  bool
+	}{
+		"target with default interval": {
+			data: map[string]interface{}{"target": "http://localhost:9100/metrics"},
+			want: scrapeConfig{target: "http://localhost:9100/metrics", interval: DefaultInterval},
+		},
+		"duration interval": {
+			data: map[string]interface{}{"target": "http://localhost:9100/metrics", "interval": "15s"},
+			want: scrapeConfig{target: "http://localhost:9100/metrics", interval: 15 * time.Second},
+		},
+		"interval in seconds": {
+			data: map[string]interface{}{"target": "https://localhost/metrics", "interval": float64(30)},
+			want: scrapeConfig{target: "https://localhost/metrics", interval: 30 * time.Second},
+		},
+		"agent metrics": {
+			data: map[string]interface{}{"target": SelfTarget, "interval": "15s"},
+			want: scrapeConfig{target: SelfTarget, interval: 15 * time.Second},
+		},
+		"missing target": {
+			data: map[string]interface{}{"interval": "15s"},
+			err:  true,
+		},
+		"invalid target": {
+			data: map[string]interface{}{"target": "localhost:9100"},
+			err:  true,
+		},
+		"zero interval": {
+			data: map[string]interface{}{"target": "http://localhost:9100/metrics", "interval": "0s"},
+			err:  true,
+		},
+		"negative interval": {
+			data: map[string]interface{}{"target": "http://localhost:9100/metrics", "interval": float64(-5)},
+			err:  true,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			got, err := parseScrapeConfig(tc.data, defaults)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
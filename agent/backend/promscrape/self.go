@@ -0,0 +1,60 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// SelfTarget is the scrape target of the policies collecting the operational metrics of the agent itself,
+	// such as the policy applies and the reconnects, instead of an http endpoint
+	SelfTarget = "self"
+	// SelfMetricsScope names the scope of the agent metrics, the sinker tags them with the owner of the agent
+	SelfMetricsScope = "orb_agent"
+	// AgentIDAttribute holds the id of the agent on the data points of its metrics
+	AgentIDAttribute = "agent_id"
+)
+
+// selfGatherer is the registry the agent metrics are registered on
+var selfGatherer prometheus.Gatherer = prometheus.DefaultGatherer
+
+// selfMetrics gathers the agent metrics and converts them to OTLP metrics on the agent scope, with the agent
+// id on every data point so they can be aggregated across the fleet
+func selfMetrics(gatherer prometheus.Gatherer, agentID string, policyName string, startTime time.Time, now time.Time) (pmetric.Metrics, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	md := toMetrics(families, policyName, startTime, now)
+	scope := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	scope.Scope().SetName(SelfMetricsScope)
+	metrics := scope.Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			for j := 0; j < m.Sum().DataPoints().Len(); j++ {
+				m.Sum().DataPoints().At(j).Attributes().PutStr(AgentIDAttribute, agentID)
+			}
+		case pmetric.MetricTypeGauge:
+			for j := 0; j < m.Gauge().DataPoints().Len(); j++ {
+				m.Gauge().DataPoints().At(j).Attributes().PutStr(AgentIDAttribute, agentID)
+			}
+		case pmetric.MetricTypeHistogram:
+			for j := 0; j < m.Histogram().DataPoints().Len(); j++ {
+				m.Histogram().DataPoints().At(j).Attributes().PutStr(AgentIDAttribute, agentID)
+			}
+		case pmetric.MetricTypeSummary:
+			for j := 0; j < m.Summary().DataPoints().Len(); j++ {
+				m.Summary().DataPoints().At(j).Attributes().PutStr(AgentIDAttribute, agentID)
+			}
+		}
+	}
+	return md, nil
+}
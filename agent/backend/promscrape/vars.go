@@ -0,0 +1,8 @@
+package promscrape
+
+import "github.com/spf13/viper"
+
+func RegisterBackendSpecificVariables(v *viper.Viper) {
+	v.SetDefault("orb.backends.prometheus_scrape.interval", DefaultInterval.String())
+	v.SetDefault("orb.backends.prometheus_scrape.timeout", DefaultTimeout.String())
+}
@@ -65,7 +65,11 @@ func (cc *cloudConfigManager) migrateDB() error {
 }
 
 func (cc *cloudConfigManager) request(address string, token string, response interface{}, method string, body []byte) error {
-	tlsConfig := &tls.Config{InsecureSkipVerify: false}
+	minVersion, err := cc.config.OrbAgent.TLS.MinTLSVersion()
+	if err != nil {
+		return err
+	}
+	tlsConfig := &tls.Config{MinVersion: minVersion, InsecureSkipVerify: false}
 	if !cc.config.OrbAgent.TLS.Verify {
 		tlsConfig.InsecureSkipVerify = true
 	}
@@ -17,8 +17,65 @@ import (
 	"go.uber.org/zap"
 )
 
+// mqttClientID returns the agent id with the optional client id suffix, the username is always the agent id
+func mqttClientID(id string, suffix string) string {
+	if suffix == "" {
+		return id
+	}
+	return fmt.Sprintf("%s-%s", id, suffix)
+}
+
+const (
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = 30 * time.Second
+	defaultRetryDeadline       = 5 * time.Minute
+)
+
+// retryWithBackoff calls send until it succeeds, the next attempt would start after the deadline of
+// the policy or the context is done, returning the last error
+func retryWithBackoff(ctx context.Context, policy config.RetryConfig, send func(attempt int) error) error {
+	backoff := policy.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryInitialBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	deadlineAfter := policy.Deadline
+	if deadlineAfter <= 0 {
+		deadlineAfter = defaultRetryDeadline
+	}
+	deadline := time.Now().Add(deadlineAfter)
+	for attempt := 1; ; attempt++ {
+		err := send(attempt)
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// defaultConnectTimeout is used when no mqtt connect timeout is configured
+const defaultConnectTimeout = 30 * time.Second
+
 func (a *orbAgent) connect(ctx context.Context, config config.MQTTConfig) (mqtt.Client, error) {
-	opts := mqtt.NewClientOptions().AddBroker(config.Address).SetClientID(config.Id)
+	connectTimeout := a.config.OrbAgent.Cloud.MQTT.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = defaultConnectTimeout
+	}
+	opts := mqtt.NewClientOptions().AddBroker(config.Address).SetClientID(mqttClientID(config.Id, a.config.OrbAgent.Cloud.MQTT.ClientIDSuffix))
 	opts.SetUsername(config.Id)
 	opts.SetPassword(config.Key)
 	opts.SetKeepAlive(10 * time.Second)
@@ -33,7 +90,7 @@ func (a *orbAgent) connect(ctx context.Context, config config.MQTTConfig) (mqtt.
 	opts.SetPingTimeout(5 * time.Second)
 	opts.SetAutoReconnect(false)
 	opts.SetCleanSession(true)
-	opts.SetConnectTimeout(5 * time.Minute)
+	opts.SetConnectTimeout(connectTimeout)
 	opts.SetResumeSubs(true)
 	opts.SetReconnectingHandler(func(client mqtt.Client, options *mqtt.ClientOptions) {
 		go func() {
@@ -114,18 +171,95 @@ func (a *orbAgent) connect(ctx context.Context, config config.MQTTConfig) (mqtt.
 		}()
 	})
 
-	if !a.config.OrbAgent.TLS.Verify {
-		opts.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	minVersion, err := a.config.OrbAgent.TLS.MinTLSVersion()
+	if err != nil {
+		return nil, err
 	}
+	opts.TLSConfig = &tls.Config{MinVersion: minVersion, InsecureSkipVerify: !a.config.OrbAgent.TLS.Verify}
 
 	c := mqtt.NewClient(opts)
-	if token := c.Connect(); token.Wait() && token.Error() != nil {
+	start := time.Now()
+	token := c.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		a.logger.Error("timed out connecting to mqtt broker", zap.String("address", config.Address),
+			zap.Duration("elapsed", time.Since(start)))
+		return nil, ErrMqttConnection
+	}
+	if token.Error() != nil {
 		return nil, token.Error()
 	}
 
 	return c, nil
 }
 
+// publish sends the message to the control plane. When the client is not connected or the publish fails,
+// the message is kept in the spool, if enabled, to be sent on the next connection. The capabilities and
+// heartbeats are not spooled, they are sent again once connected and an old one would report a stale state.
+// The error is still returned so the callers keep handling the lost connection
+func (a *orbAgent) publish(topic string, body []byte) error {
+	err := ErrMqttConnection
+	if a.client != nil && a.client.IsConnected() {
+		var signed []byte
+		signed, err = a.sign(body)
+		if err != nil {
+			return err
+		}
+		token := a.client.Publish(topic, a.publishQoS(topic), false, signed)
+		if token.Wait() && token.Error() == nil {
+			return nil
+		}
+		err = token.Error()
+	}
+	if a.spool != nil && topic != a.capabilitiesTopic && topic != a.heartbeatsTopic {
+		if spoolErr := a.spool.push(topic, body); spoolErr != nil {
+			a.logger.Warn("failed to spool outgoing message", zap.String("topic", topic), zap.Error(spoolErr))
+		} else {
+			a.logger.Debug("spooled outgoing message", zap.String("topic", topic))
+		}
+	}
+	return err
+}
+
+// publishQoS returns the QoS level of a message to the control plane, the heartbeats have their own level
+// and every other message is an RPC
+func (a *orbAgent) publishQoS(topic string) byte {
+	if topic == a.heartbeatsTopic {
+		return a.config.OrbAgent.Cloud.MQTT.QoS.Heartbeat
+	}
+	return a.config.OrbAgent.Cloud.MQTT.QoS.RPC
+}
+
+// sign wraps a message to the control plane with its signature when a signing key is set. The messages are
+// signed when sent, so the spooled ones are not rejected as expired
+func (a *orbAgent) sign(body []byte) ([]byte, error) {
+	if a.signingKey == nil {
+		return body, nil
+	}
+	return fleet.SignMessage(a.signingKey, body, time.Now())
+}
+
+// drainSpool sends the messages spooled while the agent was disconnected, in the order they were published
+func (a *orbAgent) drainSpool(client mqtt.Client) {
+	if a.spool == nil || a.spool.len() == 0 {
+		return
+	}
+	sent, err := a.spool.drain(func(topic string, payload []byte) error {
+		signed, err := a.sign(payload)
+		if err != nil {
+			return err
+		}
+		if token := client.Publish(topic, a.publishQoS(topic), false, signed); token.Wait() && token.Error() != nil {
+			return token.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		a.logger.Warn("failed to drain outgoing message spool", zap.Int("sent", sent), zap.Int("left", a.spool.len()), zap.Error(err))
+		return
+	}
+	a.logger.Info("drained outgoing message spool", zap.Int("sent", sent))
+}
+
 func (a *orbAgent) requestReconnection(ctx context.Context, client mqtt.Client, config config.MQTTConfig) {
 	a.nameAgentRPCTopics(config.ChannelID)
 	for name, be := range a.backends {
@@ -133,16 +267,25 @@ func (a *orbAgent) requestReconnection(ctx context.Context, client mqtt.Client,
 	}
 	a.agent_id = config.Id
 
-	if token := client.Subscribe(a.rpcFromCoreTopic, 1, a.handleRPCFromCore); token.Wait() && token.Error() != nil {
+	if token := client.Subscribe(a.rpcFromCoreTopic, a.config.OrbAgent.Cloud.MQTT.QoS.RPC, a.handleRPCFromCore); token.Wait() && token.Error() != nil {
 		a.logger.Error("failed to subscribe to agent control plane RPC topic", zap.String("topic", a.rpcFromCoreTopic), zap.Error(token.Error()))
 		a.logger.Error("critical failure: unable to subscribe to control plane")
 		a.Stop(ctx)
 		return
 	}
 
-	err := a.sendCapabilities()
+	a.drainSpool(client)
+
+	err := retryWithBackoff(ctx, a.config.OrbAgent.Cloud.CapabilitiesRetry, func(attempt int) error {
+		a.logger.Info("sending agent capabilities", zap.String("agent_id", config.Id), zap.Int("attempt", attempt))
+		err := a.sendCapabilities()
+		if err != nil {
+			a.logger.Warn("failed to send agent capabilities", zap.String("agent_id", config.Id), zap.Int("attempt", attempt), zap.Error(err))
+		}
+		return err
+	})
 	if err != nil {
-		a.logger.Error("failed to send agent capabilities", zap.Error(err))
+		a.logger.Error("giving up sending agent capabilities", zap.String("agent_id", config.Id), zap.Error(err))
 	}
 
 	err = a.sendGroupMembershipReq()
@@ -159,6 +302,10 @@ func (a *orbAgent) nameAgentRPCTopics(channelId string) {
 	a.capabilitiesTopic = fmt.Sprintf("%s/%s", base, fleet.CapabilitiesTopic)
 	a.heartbeatsTopic = fmt.Sprintf("%s/%s", base, fleet.HeartbeatsTopic)
 	a.logTopic = fmt.Sprintf("%s/%s", base, fleet.LogTopic)
+	a.deadLetterTopic = ""
+	if subtopic := a.config.OrbAgent.Cloud.MQTT.DeadLetterTopic; subtopic != "" {
+		a.deadLetterTopic = fmt.Sprintf("%s/%s", base, subtopic)
+	}
 	a.baseTopic = base
 
 }
@@ -174,12 +321,13 @@ func (a *orbAgent) unsubscribeGroupChannels() {
 		a.logger.Info("completed RPC unsubscription to group", zap.String("group_id", id), zap.String("group_name", groupInfo.Name), zap.String("topic", rpcFromCoreTopic))
 	}
 	a.groupsInfos = make(map[string]GroupInfo)
+	a.groupsSynced = false
 }
 
 func (a *orbAgent) unsubscribeGroupChannel(channelID string, agentGroupID string) {
 	base := fmt.Sprintf("channels/%s/messages", channelID)
 	rpcFromCoreTopic := fmt.Sprintf("%s/%s", base, fleet.RPCFromCoreTopic)
-	if token := a.client.Unsubscribe(channelID); token.Wait() && token.Error() != nil {
+	if token := a.client.Unsubscribe(rpcFromCoreTopic); token.Wait() && token.Error() != nil {
 		a.logger.Warn("failed to unsubscribe to group channel", zap.String("topic", rpcFromCoreTopic), zap.Error(token.Error()))
 		return
 	}
@@ -188,9 +336,17 @@ func (a *orbAgent) unsubscribeGroupChannel(channelID string, agentGroupID string
 }
 
 func (a *orbAgent) removeDatasetFromPolicy(datasetID string, policyID string) {
-	for _, be := range a.backends {
-		a.policyManager.RemovePolicyDataset(policyID, datasetID, be)
+	policy, err := a.policyManager.GetRepo().Get(policyID)
+	if err != nil {
+		a.logger.Warn("failed to retrieve policy data", zap.String("policy_id", policyID), zap.Error(err))
+		return
 	}
+	be, ok := a.backends[policy.Backend]
+	if !ok {
+		a.logger.Warn("policy backend is not configured on this agent", zap.String("policy_id", policyID), zap.String("backend", policy.Backend))
+		return
+	}
+	a.policyManager.RemovePolicyDataset(policyID, datasetID, be)
 }
 
 func (a *orbAgent) startComms(ctx context.Context, config config.MQTTConfig) error {
@@ -221,7 +377,7 @@ func (a *orbAgent) subscribeGroupChannels(groups []fleet.GroupMembershipData) {
 		base := fmt.Sprintf("channels/%s/messages", groupData.ChannelID)
 		rpcFromCoreTopic := fmt.Sprintf("%s/%s", base, fleet.RPCFromCoreTopic)
 
-		token := a.client.Subscribe(rpcFromCoreTopic, 1, a.handleGroupRPCFromCore)
+		token := a.client.Subscribe(rpcFromCoreTopic, a.config.OrbAgent.Cloud.MQTT.QoS.RPC, a.handleGroupRPCFromCore)
 		if token.Error() != nil {
 			a.logger.Error("failed to subscribe to group channel/topic", zap.String("group_id", groupData.GroupID), zap.String("group_name", groupData.Name), zap.String("topic", rpcFromCoreTopic), zap.Error(token.Error()))
 			continue
@@ -236,6 +392,12 @@ func (a *orbAgent) subscribeGroupChannels(groups []fleet.GroupMembershipData) {
 			continue
 		}
 		a.logger.Info("completed RPC subscription to group", zap.String("group_id", groupData.GroupID), zap.String("group_name", groupData.Name), zap.String("topic", rpcFromCoreTopic))
+		// the channel of the group was rotated, the stale subscription is dropped once the new one is in place
+		if groupInfo, ok := a.groupsInfos[groupData.GroupID]; ok && groupInfo.ChannelID != groupData.ChannelID {
+			a.logger.Info("group channel changed, unsubscribing the previous channel", zap.String("group_id", groupData.GroupID),
+				zap.String("previous_channel", groupInfo.ChannelID), zap.String("channel", groupData.ChannelID))
+			a.unsubscribeGroupChannel(groupInfo.ChannelID, groupData.GroupID)
+		}
 		a.groupsInfos[groupData.GroupID] = GroupInfo{
 			Name:      groupData.Name,
 			ChannelID: groupData.ChannelID,
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_connectTimeout(t *testing.T) {
+	// the broker accepts the connection but never acknowledges it
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	var c config.Config
+	c.OrbAgent.Cloud.MQTT.ConnectTimeout = 200 * time.Millisecond
+	a := &orbAgent{logger: zap.NewNop(), config: c}
+
+	start := time.Now()
+	client, err := a.connect(context.Background(), config.MQTTConfig{Address: "tcp://" + ln.Addr().String(), Id: "agent"})
+	assert.Error(t, err)
+	assert.Nil(t, client)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func Test_sign(t *testing.T) {
+	body := []byte(`{"schema_version":"1.0","state":"online"}`)
+
+	a := &orbAgent{}
+	unsigned, err := a.sign(body)
+	require.NoError(t, err)
+	assert.Equal(t, body, unsigned)
+
+	a.signingKey = fleet.AgentSigningKey("signing-secret", "agent-id")
+	signed, err := a.sign(body)
+	require.NoError(t, err)
+	opened, err := fleet.NewMessageVerifier("signing-secret", true, 0, nil).Open("agent-id", signed, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, body, opened)
+}
+
+func Test_publishQoS(t *testing.T) {
+	var c config.Config
+	c.OrbAgent.Cloud.MQTT.QoS = config.MQTTQoS{RPC: 1, Heartbeat: 0, Log: 0}
+	a := &orbAgent{logger: zap.NewNop(), config: c}
+	a.nameAgentRPCTopics("channel-id")
+
+	assert.Equal(t, byte(0), a.publishQoS(a.heartbeatsTopic))
+	assert.Equal(t, byte(1), a.publishQoS(a.rpcToCoreTopic))
+	assert.Equal(t, byte(1), a.publishQoS(a.capabilitiesTopic))
+
+	c.OrbAgent.Cloud.MQTT.QoS.Heartbeat = 3
+	assert.Error(t, c.OrbAgent.Cloud.MQTT.QoS.Validate())
+}
@@ -0,0 +1,169 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EncryptedHeader is the first line of an encrypted config file, the files without it are loaded as plaintext
+	EncryptedHeader = "# orb-agent encrypted config v1"
+	// ConfigKeyEnv holds the base64 encoded 256 bits key of the encrypted config files
+	ConfigKeyEnv = "ORB_AGENT_CONFIG_KEY"
+	// ConfigKeyFileEnv holds the path of a file with the base64 encoded key, used when ConfigKeyEnv is not set
+	ConfigKeyFileEnv = "ORB_AGENT_CONFIG_KEY_FILE"
+)
+
+var (
+	// ErrConfigKeyMissing an encrypted config file was found while no key is set
+	ErrConfigKeyMissing = fmt.Errorf("encrypted config requires a key on %s or %s", ConfigKeyEnv, ConfigKeyFileEnv)
+	// ErrConfigKeyInvalid the key is not a base64 encoded 256 bits key
+	ErrConfigKeyInvalid = errors.New("config key must be a base64 encoded 32 bytes key")
+	// ErrConfigDecrypt the encrypted config is malformed or was encrypted with another key
+	ErrConfigDecrypt = errors.New("failed to decrypt config")
+)
+
+// IsEncrypted reports whether the config file content starts with the encrypted header
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(EncryptedHeader+"\n"))
+}
+
+// LoadKey reads the key of the encrypted config files from ConfigKeyEnv, or from the file on ConfigKeyFileEnv
+func LoadKey() ([]byte, error) {
+	encoded := os.Getenv(ConfigKeyEnv)
+	if encoded == "" {
+		path := os.Getenv(ConfigKeyFileEnv)
+		if path == "" {
+			return nil, ErrConfigKeyMissing
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config key file: %w", err)
+		}
+		encoded = string(data)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != 32 {
+		return nil, ErrConfigKeyInvalid
+	}
+	return key, nil
+}
+
+// Encrypt seals a plaintext config with AES-256-GCM, the result is the header followed by the base64 encoded
+// nonce and ciphertext
+func Encrypt(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(EncryptedHeader))
+	var out bytes.Buffer
+	out.WriteString(EncryptedHeader + "\n")
+	out.WriteString(base64.StdEncoding.EncodeToString(sealed))
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// Decrypt opens a config sealed by Encrypt
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, ErrConfigDecrypt
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data[len(EncryptedHeader)+1:])))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return nil, ErrConfigDecrypt
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(EncryptedHeader))
+	if err != nil {
+		return nil, ErrConfigDecrypt
+	}
+	return plaintext, nil
+}
+
+// ReadFile returns the content of a config file, decrypted with the key from the environment when it is encrypted
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	key, err := LoadKey()
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(key, data)
+}
+
+// WriteDecryptedSections writes the top level sections of a config file, decrypted when it is encrypted, to a new
+// file in dir only readable by its owner, for the backends reading their own sections of the agent config which
+// cannot decrypt it. The other sections, holding the agent credentials, are left out. It returns the new file path
+func WriteDecryptedSections(path string, dir string, sections ...string) (string, error) {
+	data, err := ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	kept := make(map[string]interface{}, len(sections))
+	for _, section := range sections {
+		if value, ok := doc[section]; ok {
+			kept[section] = value
+		}
+	}
+	out, err := yaml.Marshal(kept)
+	if err != nil {
+		return "", err
+	}
+	// the file is created with 0600
+	file, err := os.CreateTemp(dir, "orb-agent-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(out); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrConfigKeyInvalid
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
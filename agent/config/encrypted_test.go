@@ -0,0 +1,111 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	plaintext := []byte("orb:\n  cloud:\n    mqtt:\n      key: secret\n")
+
+	encrypted, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := Decrypt(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = Decrypt(bytes.Repeat([]byte{2}, 32), encrypted)
+	assert.ErrorIs(t, err, ErrConfigDecrypt, "another key must not open the config")
+	_, err = Decrypt(key, plaintext)
+	assert.ErrorIs(t, err, ErrConfigDecrypt)
+	_, err = Encrypt([]byte("short"), plaintext)
+	assert.ErrorIs(t, err, ErrConfigKeyInvalid)
+}
+
+func TestReadFile(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	dir := t.TempDir()
+	plaintext := []byte("version: \"1.0\"\n")
+	plainPath := filepath.Join(dir, "plain.yaml")
+	require.NoError(t, os.WriteFile(plainPath, plaintext, 0600))
+	encrypted, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	encryptedPath := filepath.Join(dir, "encrypted.yaml")
+	require.NoError(t, os.WriteFile(encryptedPath, encrypted, 0600))
+
+	t.Setenv(ConfigKeyEnv, "")
+	t.Setenv(ConfigKeyFileEnv, "")
+	data, err := ReadFile(plainPath)
+	require.NoError(t, err, "plaintext configs must load without a key")
+	assert.Equal(t, plaintext, data)
+	_, err = ReadFile(encryptedPath)
+	assert.ErrorIs(t, err, ErrConfigKeyMissing)
+
+	keyPath := filepath.Join(dir, "config.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	t.Setenv(ConfigKeyFileEnv, keyPath)
+	data, err = ReadFile(encryptedPath)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	t.Setenv(ConfigKeyEnv, "not a key")
+	_, err = ReadFile(encryptedPath)
+	assert.ErrorIs(t, err, ErrConfigKeyInvalid, "the key on the environment takes precedence over the key file")
+}
+
+func TestWriteDecryptedSections(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	dir := t.TempDir()
+	plaintext := []byte(`version: "1.0"
+visor:
+  taps:
+    default_pcap:
+      input_type: pcap
+      config:
+        iface: eth0
+orb:
+  cloud:
+    mqtt:
+      key: secret
+`)
+	encrypted, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	encryptedPath := filepath.Join(dir, "encrypted.yaml")
+	require.NoError(t, os.WriteFile(encryptedPath, encrypted, 0600))
+	t.Setenv(ConfigKeyEnv, base64.StdEncoding.EncodeToString(key))
+
+	path, err := WriteDecryptedSections(encryptedPath, dir, "version", "visor")
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "the decrypted sections must only be readable by the agent user")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &doc))
+	assert.Equal(t, "1.0", doc["version"])
+	taps := doc["visor"].(map[string]interface{})["taps"].(map[string]interface{})
+	assert.Contains(t, taps, "default_pcap", "the taps must be handed to pktvisord")
+	assert.NotContains(t, doc, "orb", "the agent credentials must be left out")
+	assert.NotContains(t, string(data), "secret")
+
+	t.Setenv(ConfigKeyEnv, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))
+	_, err = WriteDecryptedSections(encryptedPath, dir, "visor")
+	assert.ErrorIs(t, err, ErrConfigDecrypt)
+}
@@ -4,8 +4,21 @@
 
 package config
 
+import (
+	"fmt"
+	"time"
+
+	pkgconfig "github.com/orb-community/orb/pkg/config"
+)
+
 type TLS struct {
-	Verify bool `mapstructure:"verify"`
+	Verify     bool   `mapstructure:"verify"`
+	MinVersion string `mapstructure:"min_version"`
+}
+
+// MinTLSVersion returns the crypto/tls constant of the configured minimum TLS version
+func (t TLS) MinTLSVersion() (uint16, error) {
+	return pkgconfig.ParseTLSMinVersion(t.MinVersion)
 }
 
 type APIConfig struct {
@@ -22,6 +35,58 @@ type MQTTConfig struct {
 	Id        string `mapstructure:"id"`
 	Key       string `mapstructure:"key"`
 	ChannelID string `mapstructure:"channel_id"`
+	// ClientIDSuffix is appended to the MQTT client id, so processes sharing the agent credentials
+	// (e.g. a warm-standby pair) keep distinct sessions. Both processes subscribe to the same topics
+	// and receive every RPC, so only one of them should be running its backends at a time.
+	ClientIDSuffix string `mapstructure:"client_id_suffix"`
+	// DeadLetterTopic is the subtopic of the agent channel the RPCs from core which could not be handled are
+	// published to, empty disables it
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
+	// ConnectTimeout bounds the wait for the broker to accept the connection, the agent startup fails once it elapses
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	// SigningKey is the hex encoded key the messages to the control plane are signed with, empty disables signing.
+	// It is derived by the control plane from its signing secret and the agent id
+	SigningKey string `mapstructure:"signing_key"`
+	// MaxRPCPayloadSize is the size in bytes above which the RPCs from core are rejected before being decoded
+	MaxRPCPayloadSize int `mapstructure:"max_rpc_payload_size"`
+	// QoS sets the QoS level of each class of messages exchanged with the control plane
+	QoS MQTTQoS `mapstructure:"qos"`
+	// LogSampling throttles the logs exported by the backends
+	LogSampling LogSampling `mapstructure:"log_sampling"`
+}
+
+// LogSampling throttles the logs exported by the backends so a noisy agent does not saturate the broker: above
+// RateLimit log records per second, the errors are still published but only a SampleRatio fraction of the
+// others. The number of dropped records is reported periodically along with the logs. A zero RateLimit disables it
+type LogSampling struct {
+	RateLimit   int     `mapstructure:"rate_limit"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// Validate checks that the sample ratio is a fraction
+func (l LogSampling) Validate() error {
+	if l.SampleRatio < 0 || l.SampleRatio > 1 {
+		return fmt.Errorf("invalid log sample ratio %v, expected a value from 0 to 1", l.SampleRatio)
+	}
+	return nil
+}
+
+// MQTTQoS holds the QoS level, from 0 to 2, of the RPCs (the subscriptions to the RPCs from core and the
+// capabilities and RPCs sent to core), of the heartbeats and of the logs exported by the backends
+type MQTTQoS struct {
+	RPC       byte `mapstructure:"rpc"`
+	Heartbeat byte `mapstructure:"heartbeat"`
+	Log       byte `mapstructure:"log"`
+}
+
+// Validate checks that every QoS level is 0, 1 or 2
+func (q MQTTQoS) Validate() error {
+	for class, qos := range map[string]byte{"rpc": q.RPC, "heartbeat": q.Heartbeat, "log": q.Log} {
+		if qos > 2 {
+			return fmt.Errorf("invalid mqtt %s qos %d, expected 0, 1 or 2", class, qos)
+		}
+	}
+	return nil
 }
 
 type CloudConfig struct {
@@ -29,10 +94,36 @@ type CloudConfig struct {
 	AutoProvision bool   `mapstructure:"auto_provision"`
 }
 
+// RetryConfig bounds the retries of a publish to the control plane, the backoff doubles after
+// each failed attempt up to MaxBackoff and no attempt is made once Deadline has passed
+type RetryConfig struct {
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	Deadline       time.Duration `mapstructure:"deadline"`
+}
+
+// SpoolOverflowDropOldest and SpoolOverflowDropNewest are the spool overflow policies, either the oldest
+// spooled messages or the incoming one are dropped once the spool is full
+const (
+	SpoolOverflowDropOldest = "drop_oldest"
+	SpoolOverflowDropNewest = "drop_newest"
+)
+
+// SpoolConfig sets the disk-backed queue keeping the messages to the control plane published while the
+// agent is not connected, they are sent on the next connection
+type SpoolConfig struct {
+	Enable   bool   `mapstructure:"enable"`
+	Dir      string `mapstructure:"dir"`
+	MaxSize  int64  `mapstructure:"max_size"`
+	Overflow string `mapstructure:"overflow"`
+}
+
 type Cloud struct {
-	Config CloudConfig `mapstructure:"config"`
-	API    APIConfig   `mapstructure:"api"`
-	MQTT   MQTTConfig  `mapstructure:"mqtt"`
+	Config            CloudConfig `mapstructure:"config"`
+	API               APIConfig   `mapstructure:"api"`
+	MQTT              MQTTConfig  `mapstructure:"mqtt"`
+	CapabilitiesRetry RetryConfig `mapstructure:"capabilities_retry"`
+	Spool             SpoolConfig `mapstructure:"spool"`
 }
 
 type Opentelemetry struct {
@@ -40,18 +131,97 @@ type Opentelemetry struct {
 	Port int    `mapstructure:"port"`
 }
 
+type Heartbeat struct {
+	// Jitter is the fraction of the heartbeat interval used to spread the heartbeats of a fleet, the first
+	// periodic heartbeat is delayed by a random offset up to it. Zero disables it, values are capped at 0.5
+	Jitter float64 `mapstructure:"jitter"`
+	// JitterEachBeat shifts every heartbeat by a random amount within the jitter, not only the first one
+	JitterEachBeat bool `mapstructure:"jitter_each_beat"`
+	// PauseWindows are the planned control plane maintenance windows, no heartbeat is published during them
+	// while the MQTT session is kept
+	PauseWindows []PauseWindow `mapstructure:"pause_windows"`
+}
+
+// PauseWindow is a time window given by its RFC 3339 start and end
+type PauseWindow struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// TimeWindow is a parsed PauseWindow
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls in the window, the end excluded
+func (w TimeWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// ParsePauseWindows returns the heartbeat pause windows, each one must end after it starts
+func (h Heartbeat) ParsePauseWindows() ([]TimeWindow, error) {
+	windows := make([]TimeWindow, 0, len(h.PauseWindows))
+	for _, pw := range h.PauseWindows {
+		start, err := time.Parse(time.RFC3339, pw.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid heartbeat pause window start %q: %w", pw.Start, err)
+		}
+		end, err := time.Parse(time.RFC3339, pw.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid heartbeat pause window end %q: %w", pw.End, err)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("heartbeat pause window ending at %s does not end after its start", pw.End)
+		}
+		windows = append(windows, TimeWindow{Start: start, End: end})
+	}
+	return windows, nil
+}
+
+// Update opts the agent in the self-update RPC from core. The versions available are the entries of Dir named
+// <target>/<version>, where the target is "agent" or a backend name. Command switches to a version, it runs
+// with the target and the version as arguments and the agent stops once it succeeds so its supervisor restarts
+// it on the new version. Without Enable or Command the agent only reports the availability of the versions
+type Update struct {
+	Enable  bool          `mapstructure:"enable"`
+	Dir     string        `mapstructure:"dir"`
+	Command string        `mapstructure:"command"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Policies sets how the policies from core are applied. Concurrency is the number of independent policies of a
+// batch applied at once, the policy manager default is used when it is not set
+type Policies struct {
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+// Metrics serves the agent Prometheus metrics on Address under /metrics
+type Metrics struct {
+	Enable  bool   `mapstructure:"enable"`
+	Address string `mapstructure:"address"`
+}
+
 type Debug struct {
 	Enable bool `mapstructure:"enable"`
 }
 
+// BackendTypeKey is the optional backend entry field setting the type of a named backend instance,
+// when absent the entry name is the backend type
+const BackendTypeKey = "type"
+
 type OrbAgent struct {
-	Backends map[string]map[string]string `mapstructure:"backends"`
-	Tags     map[string]string            `mapstructure:"tags"`
-	Cloud    Cloud                        `mapstructure:"cloud"`
-	TLS      TLS                          `mapstructure:"tls"`
-	DB       DBConfig                     `mapstructure:"db"`
-	Otel     Opentelemetry                `mapstructure:"otel"`
-	Debug    Debug                        `mapstructure:"debug"`
+	Backends  map[string]map[string]string `mapstructure:"backends"`
+	Tags      map[string]string            `mapstructure:"tags"`
+	Cloud     Cloud                        `mapstructure:"cloud"`
+	TLS       TLS                          `mapstructure:"tls"`
+	DB        DBConfig                     `mapstructure:"db"`
+	Otel      Opentelemetry                `mapstructure:"otel"`
+	Debug     Debug                        `mapstructure:"debug"`
+	Heartbeat Heartbeat                    `mapstructure:"heartbeat"`
+	Update    Update                       `mapstructure:"update"`
+	Policies  Policies                     `mapstructure:"policies"`
+	Metrics   Metrics                      `mapstructure:"metrics"`
 }
 
 type Config struct {
@@ -0,0 +1,51 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/orb-community/orb/agent/policies"
+	"github.com/orb-community/orb/fleet"
+)
+
+// appliedConfig is the canonical form of the agent config reported as a hash on the heartbeats. It is encoded
+// as JSON, which sorts the map keys, and the policies are sorted by id so the hash is stable across restarts
+type appliedConfig struct {
+	Backends     map[string]map[string]string `json:"backends"`
+	Capabilities map[string]fleet.BackendInfo `json:"capabilities"`
+	Policies     []appliedPolicy              `json:"policies"`
+}
+
+type appliedPolicy struct {
+	ID      string `json:"id"`
+	Backend string `json:"backend"`
+	Version int32  `json:"version"`
+}
+
+// configHash returns the hex encoded SHA-256 of the canonical form of the backends config, the backend
+// capabilities, which hold the pktvisor taps, and the versions of the applied policies
+func configHash(backends map[string]map[string]string, capabilities map[string]fleet.BackendInfo, pdata []policies.PolicyData) (string, error) {
+	cfg := appliedConfig{
+		Backends:     backends,
+		Capabilities: capabilities,
+		Policies:     make([]appliedPolicy, 0, len(pdata)),
+	}
+	for _, pd := range pdata {
+		cfg.Policies = append(cfg.Policies, appliedPolicy{ID: pd.ID, Backend: pd.Backend, Version: pd.Version})
+	}
+	sort.Slice(cfg.Policies, func(i, j int) bool {
+		return cfg.Policies[i].ID < cfg.Policies[j].ID
+	})
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,46 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/orb-community/orb/agent/policies"
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configHash(t *testing.T) {
+	backends := map[string]map[string]string{
+		"pktvisor": {"binary": "/usr/local/sbin/pktvisord", "api_port": "10853"},
+		"otel":     {"otlp_port": "4316"},
+	}
+	capabilities := map[string]fleet.BackendInfo{
+		"pktvisor": {Version: "4.4.0", Data: map[string]interface{}{"taps": map[string]interface{}{"default_pcap": map[string]interface{}{"input_type": "pcap"}}}},
+	}
+	pdata := []policies.PolicyData{
+		{ID: "policy-b", Backend: "pktvisor", Version: 2, State: policies.Running},
+		{ID: "policy-a", Backend: "otel", Version: 1, State: policies.Running},
+	}
+
+	hash, err := configHash(backends, capabilities, pdata)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// the order of the policies and their state do not change the hash
+	reordered := []policies.PolicyData{
+		{ID: "policy-a", Backend: "otel", Version: 1, State: policies.FailedToApply},
+		{ID: "policy-b", Backend: "pktvisor", Version: 2, State: policies.Running},
+	}
+	same, err := configHash(backends, capabilities, reordered)
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	reordered[1].Version = 3
+	changed, err := configHash(backends, capabilities, reordered)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed, "a new policy version must change the hash")
+
+	changed, err = configHash(map[string]map[string]string{"otel": {"otlp_port": "4317"}}, capabilities, pdata)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed, "a new backend config must change the hash")
+}
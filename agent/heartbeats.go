@@ -9,9 +9,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/orb-community/orb/agent/backend"
+	"github.com/orb-community/orb/agent/config"
 	"github.com/orb-community/orb/agent/policies"
 	"github.com/orb-community/orb/fleet"
 	"go.uber.org/zap"
+	"math/rand"
 	"time"
 )
 
@@ -21,6 +23,31 @@ const HeartbeatFreq = 50 * time.Second
 // RestartTimeMin minimum time to wait between restarts
 const RestartTimeMin = 5 * time.Minute
 
+// maxHeartbeatJitter caps the jitter fraction of the heartbeat interval
+const maxHeartbeatJitter = 0.5
+
+// heartbeatDelay returns the wait until the next heartbeat. The first one is delayed by a random
+// phase offset within the jitter, the following ones are shifted around the interval only when
+// jittering each beat
+func heartbeatDelay(freq time.Duration, cfg config.Heartbeat, first bool, randN func(int64) int64) time.Duration {
+	jitter := cfg.Jitter
+	if jitter > maxHeartbeatJitter {
+		jitter = maxHeartbeatJitter
+	}
+	spread := int64(jitter * float64(freq))
+	if spread <= 0 {
+		return freq
+	}
+	switch {
+	case first:
+		return freq + time.Duration(randN(spread))
+	case cfg.JitterEachBeat:
+		return freq - time.Duration(spread) + time.Duration(randN(2*spread))
+	default:
+		return freq
+	}
+}
+
 func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsState fleet.State) {
 
 	if a.heartbeatsTopic == "" {
@@ -79,12 +106,15 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 		if a.backendState[name].LastRestartReason != "" {
 			besi.LastRestartReason = a.backendState[name].LastRestartReason
 		}
+		besi.ResetResult = a.backendState[name].TakeResetResult()
 		bes[name] = besi
 	}
 
 	ps := make(map[string]fleet.PolicyStateInfo)
+	var appliedHash string
 	pdata, err := a.policyManager.GetPolicyState()
 	if err == nil {
+		appliedHash = a.appliedConfigHash(pdata)
 		for _, pd := range pdata {
 			pstate := policies.Offline.String()
 			// if agent is not offline, default to status that policy manager believes we should be in
@@ -105,6 +135,7 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 				LastScrapeTS:    pd.LastScrapeTS,
 				LastScrapeBytes: pd.LastScrapeBytes,
 				Backend:         pd.Backend,
+				RequestID:       pd.RequestID,
 			}
 		}
 	} else {
@@ -119,6 +150,11 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 		}
 	}
 
+	// the backends are still checked while paused, only the publish is skipped
+	if a.heartbeatPaused(t) {
+		return
+	}
+
 	hbData := fleet.Heartbeat{
 		SchemaVersion: fleet.CurrentHeartbeatSchemaVersion,
 		State:         agentsState,
@@ -126,7 +162,13 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 		BackendState:  bes,
 		PolicyState:   ps,
 		GroupState:    ag,
+		ConfigHash:    appliedHash,
 	}
+	// the request id is reported once, on the heartbeat following the RPC
+	if requestID, ok := a.lastRequestID.Swap("").(string); ok {
+		hbData.RequestID = requestID
+	}
+	hbData.UpdateResult = a.updateResult.Swap(nil)
 
 	body, err := json.Marshal(hbData)
 	if err != nil {
@@ -134,8 +176,8 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 		return
 	}
 
-	if token := a.client.Publish(a.heartbeatsTopic, 1, false, body); token.Wait() && token.Error() != nil {
-		a.logger.Error("error sending heartbeat", zap.Error(token.Error()))
+	if err := a.publish(a.heartbeatsTopic, body); err != nil {
+		a.logger.Error("error sending heartbeat", zap.Error(err))
 		err = a.restartComms(ctx)
 		if err != nil {
 			a.logger.Error("error reconnecting with MQTT, stopping agent")
@@ -144,9 +186,47 @@ func (a *orbAgent) sendSingleHeartbeat(ctx context.Context, t time.Time, agentsS
 	}
 }
 
+// appliedConfigHash returns the hash of the applied config reported on the heartbeats, empty when it fails
+func (a *orbAgent) appliedConfigHash(pdata []policies.PolicyData) string {
+	capabilities, _ := a.backendCapabilities.Load().(map[string]fleet.BackendInfo)
+	hash, err := configHash(a.config.OrbAgent.Backends, capabilities, pdata)
+	if err != nil {
+		a.logger.Warn("failed to hash the applied config", zap.Error(err))
+		return ""
+	}
+	return hash
+}
+
+// heartbeatPaused reports whether t falls in a heartbeat pause window, logging the entry and the exit of the windows
+func (a *orbAgent) heartbeatPaused(t time.Time) bool {
+	for _, window := range a.heartbeatPauses {
+		if window.Contains(t) {
+			if !a.hbPaused {
+				a.logger.Info("pausing heartbeats for the control plane maintenance window",
+					zap.Time("start", window.Start), zap.Time("end", window.End))
+				a.hbPaused = true
+			}
+			return true
+		}
+	}
+	if a.hbPaused {
+		a.logger.Info("resuming heartbeats after the control plane maintenance window")
+		a.hbPaused = false
+	}
+	return false
+}
+
+// onlineState is the agent state reported while the heartbeats routine runs
+func (a *orbAgent) onlineState() fleet.State {
+	if a.maintenance.Load() {
+		return fleet.Maintenance
+	}
+	return fleet.Online
+}
+
 func (a *orbAgent) sendHeartbeats(ctx context.Context, cancelFunc context.CancelFunc) {
 	a.logger.Debug("start heartbeats routine", zap.Any("routine", ctx.Value("routine")))
-	a.sendSingleHeartbeat(ctx, time.Now(), fleet.Online)
+	a.sendSingleHeartbeat(ctx, time.Now(), a.onlineState())
 	defer func() {
 		cancelFunc()
 	}()
@@ -157,8 +237,11 @@ func (a *orbAgent) sendHeartbeats(ctx context.Context, cancelFunc context.Cancel
 			a.sendSingleHeartbeat(ctx, time.Now(), fleet.Offline)
 			a.heartbeatCtx = nil
 			return
-		case t := <-a.hbTicker.C:
-			a.sendSingleHeartbeat(ctx, t, fleet.Online)
+		case t := <-a.hbTimer.C:
+			a.sendSingleHeartbeat(ctx, t, a.onlineState())
+			// keep the schedule from drifting by the time spent sending
+			next := heartbeatDelay(HeartbeatFreq, a.config.OrbAgent.Heartbeat, false, rand.Int63n) - time.Since(t)
+			a.hbTimer.Reset(next)
 		}
 	}
 }
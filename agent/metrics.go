@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// rpcHandleSeconds goes up to about a minute, applying policies waits on the backends which can be slow
+var rpcHandleSeconds metrics.Histogram = kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+	Namespace: "orb_agent",
+	Subsystem: "rpc",
+	Name:      "handle_seconds",
+	Help:      "Time from the receipt of an RPC message from core to the end of its handling",
+	Buckets:   stdprometheus.ExponentialBuckets(0.005, 2, 14),
+}, []string{"func"})
+
+var rpcRejected metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	Namespace: "orb_agent",
+	Subsystem: "rpc",
+	Name:      "rejected_total",
+	Help:      "Number of RPC messages from core rejected before being decoded",
+}, []string{"reason"})
+
+// observeRPCHandling records the time spent on an RPC message from core since it was received
+func observeRPCHandling(rpcFunc string, received time.Time) {
+	rpcHandleSeconds.With("func", rpcFunc).Observe(time.Since(received).Seconds())
+}
+
+// startMetricsServer serves the metrics of the agent, its policies and its RPC handling on /metrics
+func (a *orbAgent) startMetricsServer() error {
+	listener, err := net.Listen("tcp", a.config.OrbAgent.Metrics.Address)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	a.metricsServer = &http.Server{Addr: listener.Addr().String(), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Error("metrics server stopped", zap.Error(err))
+		}
+	}(a.metricsServer)
+	a.logger.Info("serving agent metrics", zap.String("address", a.metricsServer.Addr))
+	return nil
+}
+
+func (a *orbAgent) stopMetricsServer(ctx context.Context) {
+	if a.metricsServer == nil {
+		return
+	}
+	if err := a.metricsServer.Shutdown(ctx); err != nil {
+		a.logger.Warn("failed to stop the metrics server", zap.Error(err))
+	}
+	a.metricsServer = nil
+}
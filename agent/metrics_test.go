@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func scrapeAgentMetrics(t *testing.T, a *orbAgent) string {
+	resp, err := http.Get("http://" + a.metricsServer.Addr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsServer(t *testing.T) {
+	a := &orbAgent{logger: zap.NewNop(), config: config.Config{OrbAgent: config.OrbAgent{
+		Metrics: config.Metrics{Enable: true, Address: "127.0.0.1:0"},
+		Cloud:   config.Cloud{MQTT: config.MQTTConfig{MaxRPCPayloadSize: 8}},
+	}}}
+	require.NoError(t, a.startMetricsServer())
+	server := a.metricsServer
+
+	observeRPCHandling("agent_policies", time.Now())
+	require.True(t, a.rejectOversizedRPC(rpcMessage{payload: []byte(`{"func":"agent_policies"}`)}))
+	scraped := scrapeAgentMetrics(t, a)
+	assert.Contains(t, scraped, "go_goroutines")
+	assert.Contains(t, scraped, `orb_agent_rpc_handle_seconds_count{func="agent_policies"}`)
+	assert.Contains(t, scraped, `orb_agent_rpc_rejected_total{reason="payload_too_large"}`)
+
+	a.stopMetricsServer(context.Background())
+	assert.Nil(t, a.metricsServer)
+	_, err := http.Get("http://" + server.Addr + "/metrics")
+	assert.Error(t, err)
+}
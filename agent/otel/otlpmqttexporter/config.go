@@ -25,6 +25,10 @@ type Config struct {
 	ChannelID string `mapstructure:"channel_id"`
 	TLS       bool   `mapstructure:"enable_tls"`
 	Topic     string `mapstructure:"topic"`
+	// QoS is the level the telemetry is published with, DefaultQoS unless set
+	QoS byte `mapstructure:"qos"`
+	// LogSampling throttles the published logs, it is disabled unless set
+	LogSampling LogSamplingSettings `mapstructure:"log_sampling"`
 
 	// Specific for ORB Agent
 	PktVisorVersion string `mapstructure:"pktvisor_version"`
@@ -37,6 +41,9 @@ var _ component.Config = (*Config)(nil)
 func (cfg *Config) Validate() error {
 	if ((cfg.Address != "" && cfg.Id != "" && cfg.Key != "" && cfg.ChannelID != "") ||
 		cfg.Client != nil) && cfg.Topic != "" {
+		if cfg.LogSampling.SampleRatio < 0 || cfg.LogSampling.SampleRatio > 1 {
+			return fmt.Errorf("invalid log sample ratio %v, expected a value from 0 to 1", cfg.LogSampling.SampleRatio)
+		}
 		return nil
 	}
 	return fmt.Errorf("invalid mqtt configuration")
@@ -25,6 +25,8 @@ const (
 	defaultName     = "pktvisor"
 	// For testing will disable  TLS
 	defaultTLS = false
+	// DefaultQoS is the level the telemetry is published with
+	DefaultQoS byte = 1
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -42,6 +44,7 @@ func CreateConfig(addr, id, key, channel, pktvisor, topic string, bridgeService
 		QueueSettings:   exporterhelper.NewDefaultQueueSettings(),
 		RetrySettings:   exporterhelper.NewDefaultRetrySettings(),
 		Topic:           topic,
+		QoS:             DefaultQoS,
 		Address:         addr,
 		Id:              id,
 		Key:             key,
@@ -75,6 +78,7 @@ func CreateDefaultConfig() component.Config {
 		ChannelID:       base,
 		TLS:             defaultTLS,
 		Topic:           topic,
+		QoS:             DefaultQoS,
 	}
 }
 
@@ -85,6 +89,7 @@ func CreateConfigClient(client *mqtt.Client, topic, pktvisor string, bridgeServi
 		RetrySettings:   exporterhelper.NewDefaultRetrySettings(),
 		Client:          client,
 		Topic:           topic,
+		QoS:             DefaultQoS,
 		PktVisorVersion: pktvisor,
 		OrbAgentService: bridgeService,
 	}
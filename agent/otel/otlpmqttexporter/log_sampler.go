@@ -0,0 +1,86 @@
+package otlpmqttexporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// droppedLogsAttribute is the resource attribute carrying the number of log records dropped by the sampling
+// since it was last reported
+const droppedLogsAttribute = "orb_dropped_log_records"
+
+// droppedLogsReportInterval is the minimum time between two reports of the dropped log records
+const droppedLogsReportInterval = time.Minute
+
+var logsDropped metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	Namespace: "orb_agent",
+	Subsystem: "logs",
+	Name:      "dropped_total",
+	Help:      "Number of log records dropped by the log sampling instead of being published",
+}, []string{})
+
+// LogSamplingSettings throttles the published logs: above RateLimit log records per second, the error records
+// are still published but only a SampleRatio fraction of the others. A zero RateLimit disables the sampling
+type LogSamplingSettings struct {
+	RateLimit   int     `mapstructure:"rate_limit"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type logSampler struct {
+	settings LogSamplingSettings
+
+	mu          sync.Mutex
+	windowStart time.Time
+	inWindow    int
+	// credit accumulates the sample ratio, a record above the rate limit is kept each time it reaches one
+	credit     float64
+	dropped    int64
+	lastReport time.Time
+}
+
+func newLogSampler(settings LogSamplingSettings, now time.Time) *logSampler {
+	return &logSampler{settings: settings, windowStart: now, lastReport: now}
+}
+
+// keep reports whether the log record is published
+func (s *logSampler) keep(severity plog.SeverityNumber, now time.Time) bool {
+	if s.settings.RateLimit <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		s.inWindow = 0
+	}
+	s.inWindow++
+	if s.inWindow <= s.settings.RateLimit || severity >= plog.SeverityNumberError {
+		return true
+	}
+	s.credit += s.settings.SampleRatio
+	if s.credit >= 1 {
+		s.credit--
+		return true
+	}
+	s.dropped++
+	logsDropped.Add(1)
+	return false
+}
+
+// report returns the number of log records dropped since the last report, once per droppedLogsReportInterval
+func (s *logSampler) report(now time.Time) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dropped == 0 || now.Sub(s.lastReport) < droppedLogsReportInterval {
+		return 0, false
+	}
+	dropped := s.dropped
+	s.dropped = 0
+	s.lastReport = now
+	return dropped, true
+}
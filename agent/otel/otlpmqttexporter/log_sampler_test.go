@@ -0,0 +1,43 @@
+package otlpmqttexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogSampler(t *testing.T) {
+	start := time.Now()
+	s := newLogSampler(LogSamplingSettings{RateLimit: 2, SampleRatio: 0.5}, start)
+
+	var kept []bool
+	for i := 0; i < 6; i++ {
+		kept = append(kept, s.keep(plog.SeverityNumberInfo, start))
+	}
+	// the records within the rate limit are kept, then one in two
+	assert.Equal(t, []bool{true, true, false, true, false, true}, kept)
+	// the errors are kept above the rate limit
+	assert.True(t, s.keep(plog.SeverityNumberError, start))
+	assert.False(t, s.keep(plog.SeverityNumberWarn, start))
+
+	// the dropped records are reported once per interval
+	_, ok := s.report(start.Add(time.Second))
+	assert.False(t, ok)
+	dropped, ok := s.report(start.Add(droppedLogsReportInterval))
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), dropped)
+	_, ok = s.report(start.Add(2 * droppedLogsReportInterval))
+	assert.False(t, ok)
+
+	// the rate limit applies per second
+	assert.True(t, s.keep(plog.SeverityNumberInfo, start.Add(time.Second)))
+}
+
+func TestLogSamplerDisabled(t *testing.T) {
+	s := newLogSampler(LogSamplingSettings{}, time.Now())
+	for i := 0; i < 100; i++ {
+		assert.True(t, s.keep(plog.SeverityNumberDebug, time.Now()))
+	}
+}
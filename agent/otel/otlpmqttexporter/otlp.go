@@ -36,6 +36,8 @@ type baseExporter struct {
 	settings component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
+	// logSampler throttles the published logs
+	logSampler *logSampler
 }
 
 func (e *baseExporter) compressBrotli(data []byte) []byte {
@@ -67,10 +69,11 @@ func newExporter(cfg component.Config, set exporter.CreateSettings, ctx context.
 
 	// Client construction is deferred to start
 	return &baseExporter{
-		config:    oCfg,
-		logger:    set.Logger,
-		userAgent: userAgent,
-		settings:  set.TelemetrySettings,
+		config:     oCfg,
+		logger:     set.Logger,
+		userAgent:  userAgent,
+		settings:   set.TelemetrySettings,
+		logSampler: newLogSampler(oCfg.LogSampling, time.Now()),
 	}, nil
 }
 
@@ -197,6 +200,7 @@ func (e *baseExporter) injectScopeLogsAttribute(logsScope plog.ScopeLogs, attrib
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	now := time.Now()
 	tr := plogotlp.NewExportRequest()
 	ref := tr.Logs().ResourceLogs().AppendEmpty()
 	scopes := plogotlp.NewExportRequestFromLogs(ld).Logs().ResourceLogs().At(0).ScopeLogs()
@@ -221,9 +225,16 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		// injecting policyID and datasetIDs attributes
 		scope.Scope().Attributes().PutStr("policy_id", agentData.PolicyID)
 		scope.Scope().Attributes().PutStr("dataset_ids", datasets)
+		scope.LogRecords().RemoveIf(func(record plog.LogRecord) bool {
+			return !e.logSampler.keep(record.SeverityNumber(), now)
+		})
 		scope.CopyTo(ref.ScopeLogs().AppendEmpty())
 		e.logger.Info("scraped logs for policy", zap.String("policy", policyName), zap.String("policy_id", agentData.PolicyID))
 	}
+	if dropped, ok := e.logSampler.report(now); ok {
+		e.logger.Warn("log records dropped by the log sampling", zap.Int64("dropped", dropped))
+		ref.Resource().Attributes().PutInt(droppedLogsAttribute, dropped)
+	}
 
 	request, err := tr.MarshalProto()
 	if err != nil {
@@ -297,7 +308,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 func (e *baseExporter) export(ctx context.Context, topic string, request []byte) error {
 	compressedPayload := e.compressBrotli(request)
 	c := *e.config.Client
-	if token := c.Publish(topic, 1, false, compressedPayload); token.Wait() && token.Error() != nil {
+	if token := c.Publish(topic, e.config.QoS, false, compressedPayload); token.Wait() && token.Error() != nil {
 		e.logger.Error("error sending metrics RPC", zap.String("topic", topic), zap.Error(token.Error()))
 		e.config.OrbAgentService.NotifyAgentDisconnection(ctx, token.Error())
 		return token.Error()
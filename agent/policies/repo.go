@@ -6,6 +6,8 @@ package policies
 
 import (
 	"errors"
+	"sync"
+
 	"go.uber.org/zap"
 )
 
@@ -21,18 +23,22 @@ type PolicyRepo interface {
 	EnsureGroupID(policyID string, agentGroupID string) error
 }
 
+// policyMemRepo is safe for concurrent use, as the policies of a batch are applied concurrently
 type policyMemRepo struct {
 	logger *zap.Logger
 
+	mu      sync.RWMutex
 	db      map[string]PolicyData
 	nameMap map[string]string
 }
 
 var _ PolicyRepo = (*policyMemRepo)(nil)
 
-func (p policyMemRepo) GetByName(policyName string) (PolicyData, error) {
+func (p *policyMemRepo) GetByName(policyName string) (PolicyData, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	if id, ok := p.nameMap[policyName]; ok {
-		return p.Get(id)
+		return p.get(id)
 	} else {
 		return PolicyData{}, errors.New("policy name not found")
 	}
@@ -47,7 +53,9 @@ func NewMemRepo(logger *zap.Logger) (PolicyRepo, error) {
 	return r, nil
 }
 
-func (p policyMemRepo) EnsureDataset(policyID string, datasetID string) error {
+func (p *policyMemRepo) EnsureDataset(policyID string, datasetID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	policy, ok := p.db[policyID]
 	if !ok {
 		return errors.New("unknown policy ID")
@@ -56,7 +64,9 @@ func (p policyMemRepo) EnsureDataset(policyID string, datasetID string) error {
 	return nil
 }
 
-func (p policyMemRepo) RemoveDataset(policyID string, datasetID string) (bool, error) {
+func (p *policyMemRepo) RemoveDataset(policyID string, datasetID string) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	policy, ok := p.db[policyID]
 	if !ok {
 		return false, errors.New("unknown policy ID")
@@ -73,12 +83,20 @@ func (p policyMemRepo) RemoveDataset(policyID string, datasetID string) (bool, e
 	}
 }
 
-func (p policyMemRepo) Exists(policyID string) bool {
+func (p *policyMemRepo) Exists(policyID string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	_, ok := p.db[policyID]
 	return ok
 }
 
-func (p policyMemRepo) Get(policyID string) (PolicyData, error) {
+func (p *policyMemRepo) Get(policyID string) (PolicyData, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.get(policyID)
+}
+
+func (p *policyMemRepo) get(policyID string) (PolicyData, error) {
 	policy, ok := p.db[policyID]
 	if !ok {
 		return PolicyData{}, errors.New("unknown policy ID")
@@ -86,8 +104,10 @@ func (p policyMemRepo) Get(policyID string) (PolicyData, error) {
 	return policy, nil
 }
 
-func (p policyMemRepo) Remove(policyID string) error {
-	v, err := p.Get(policyID)
+func (p *policyMemRepo) Remove(policyID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v, err := p.get(policyID)
 	if err != nil {
 		return err
 	}
@@ -96,7 +116,9 @@ func (p policyMemRepo) Remove(policyID string) error {
 	return nil
 }
 
-func (p policyMemRepo) Update(data PolicyData) error {
+func (p *policyMemRepo) Update(data PolicyData) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	policy, ok := p.db[data.ID]
 	if ok {
 		// existed, clear old map
@@ -107,7 +129,9 @@ func (p policyMemRepo) Update(data PolicyData) error {
 	return nil
 }
 
-func (p policyMemRepo) GetAll() (ret []PolicyData, err error) {
+func (p *policyMemRepo) GetAll() (ret []PolicyData, err error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	ret = make([]PolicyData, len(p.db))
 	i := 0
 	for _, v := range p.db {
@@ -118,7 +142,9 @@ func (p policyMemRepo) GetAll() (ret []PolicyData, err error) {
 	return ret, err
 }
 
-func (p policyMemRepo) EnsureGroupID(policyID string, agentGroupID string) error {
+func (p *policyMemRepo) EnsureGroupID(policyID string, agentGroupID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	policy, ok := p.db[policyID]
 	if !ok {
 		return errors.New("unknown policy ID")
@@ -23,6 +23,8 @@ type PolicyData struct {
 	LastScrapeBytes    int64
 	LastScrapeTS       time.Time
 	PreviousPolicyData *PolicyData
+	// RequestID is the id of the RPC from core which last managed the policy
+	RequestID string
 }
 
 func (d *PolicyData) GetDatasetIDs() []string {
@@ -42,6 +44,7 @@ const (
 	FailedToApply
 	Offline
 	NoTapMatch
+	TapNotFound
 )
 
 type PolicyState int
@@ -52,6 +55,7 @@ var policyStateMap = [...]string{
 	"failed_to_apply",
 	"offline",
 	"no_tap_match",
+	"tap_not_found",
 }
 
 var policyStateRevMap = map[string]PolicyState{
@@ -60,6 +64,7 @@ var policyStateRevMap = map[string]PolicyState{
 	"failed_to_apply": FailedToApply,
 	"offline":         Offline,
 	"no_tap_match":    NoTapMatch,
+	"tap_not_found":   TapNotFound,
 }
 
 func (s PolicyState) String() string {
@@ -0,0 +1,118 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/orb-community/orb/fleet"
+	"go.uber.org/zap"
+)
+
+// DefaultPolicyConcurrency is the number of independent policies of a batch applied at once when it is not configured
+const DefaultPolicyConcurrency = 4
+
+// policyUnit holds the payloads of a policy in a batch, applied in order, and the policies of the batch it waits for
+type policyUnit struct {
+	id       string
+	payloads []fleet.AgentPolicyRPCPayload
+	deps     []string
+}
+
+// ManagePolicies applies a batch of policies from core, up to the configured concurrency at once, and returns once
+// all of them settled. The payloads of the same policy are applied in order and a policy waits for the policies
+// of the batch it depends on, whatever their outcome
+func (a *policyManager) ManagePolicies(payloads []fleet.AgentPolicyRPCPayload, requestID string) {
+	units := a.policyUnits(payloads)
+	limit := a.config.OrbAgent.Policies.Concurrency
+	if limit <= 0 {
+		limit = DefaultPolicyConcurrency
+	}
+	done := make(map[string]chan struct{}, len(units))
+	for _, u := range units {
+		done[u.id] = make(chan struct{})
+	}
+	slots := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, u := range units {
+		wg.Add(1)
+		go func(u policyUnit) {
+			defer wg.Done()
+			defer close(done[u.id])
+			for _, dep := range u.deps {
+				<-done[dep]
+			}
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			a.manageUnit(u, requestID)
+		}(u)
+	}
+	wg.Wait()
+}
+
+// manageUnit applies the payloads of a policy, a panic is logged so it does not abort the rest of the batch
+func (a *policyManager) manageUnit(u policyUnit, requestID string) {
+	defer func() {
+		if r := recover(); r != nil {
+			a.logger.Error("policy application panicked", zap.String("policy_id", u.id), zap.String("request_id", requestID), zap.Error(fmt.Errorf("%v", r)))
+		}
+	}()
+	for _, payload := range u.payloads {
+		a.ManagePolicy(payload, requestID)
+	}
+}
+
+// policyUnits groups the payloads by policy in the order of the batch. The dependencies on policies missing from
+// the batch are already settled, and a dependency closing a cycle is dropped so the batch always settles
+func (a *policyManager) policyUnits(payloads []fleet.AgentPolicyRPCPayload) []policyUnit {
+	var units []policyUnit
+	index := make(map[string]int)
+	for _, payload := range payloads {
+		i, ok := index[payload.ID]
+		if !ok {
+			i = len(units)
+			index[payload.ID] = i
+			units = append(units, policyUnit{id: payload.ID})
+		}
+		units[i].payloads = append(units[i].payloads, payload)
+	}
+	for i := range units {
+		seen := make(map[string]bool)
+		for _, payload := range units[i].payloads {
+			for _, dep := range payload.DependsOn {
+				if _, ok := index[dep]; !ok || dep == units[i].id || seen[dep] {
+					continue
+				}
+				seen[dep] = true
+				if dependsOn(units, index, dep, units[i].id) {
+					a.logger.Warn("policy dependency closes a cycle, ignoring it", zap.String("policy_id", units[i].id), zap.String("depends_on", dep))
+					continue
+				}
+				units[i].deps = append(units[i].deps, dep)
+			}
+		}
+	}
+	return units
+}
+
+// dependsOn reports whether the policy from waits, directly or not, for the policy to
+func dependsOn(units []policyUnit, index map[string]int, from string, to string) bool {
+	visited := make(map[string]bool)
+	pending := []string{from}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id == to {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		pending = append(pending, units[index[id]].deps...)
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/orb-community/orb/agent/policies"
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPolicyUnits(t *testing.T) {
+	a := &policyManager{logger: zap.NewNop()}
+	units := a.policyUnits([]fleet.AgentPolicyRPCPayload{
+		{ID: "p1", DatasetID: "d1", DependsOn: []string{"p3"}},
+		{ID: "p2", DatasetID: "d2", DependsOn: []string{"p1", "missing", "p2"}},
+		{ID: "p1", DatasetID: "d3"},
+		{ID: "p3", DatasetID: "d4", DependsOn: []string{"p2"}},
+	})
+
+	require.Len(t, units, 3)
+	assert.Equal(t, "p1", units[0].id)
+	assert.Len(t, units[0].payloads, 2, "payloads of the same policy must be grouped")
+	assert.Equal(t, []string{"p3"}, units[0].deps)
+	assert.Equal(t, []string{"p1"}, units[1].deps, "dependencies outside the batch or on itself must be ignored")
+	assert.Empty(t, units[2].deps, "dependency closing a cycle must be dropped")
+}
+
+func TestManagePolicies(t *testing.T) {
+	repo, err := policies.NewMemRepo(zap.NewNop())
+	require.NoError(t, err)
+	c := config.Config{}
+	c.OrbAgent.Policies.Concurrency = 2
+	a := &policyManager{logger: zap.NewNop(), config: c, repo: repo}
+
+	var payloads []fleet.AgentPolicyRPCPayload
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		payloads = append(payloads, fleet.AgentPolicyRPCPayload{Action: "manage", ID: id, Name: id, DatasetID: "d-" + id, Backend: "unavailable", Version: 1})
+	}
+	payloads[0].DependsOn = []string{"p2"}
+	payloads[1].DependsOn = []string{"p1"}
+	// the policy without dataset fails, the others of the batch are still applied
+	payloads = append(payloads, fleet.AgentPolicyRPCPayload{Action: "manage", ID: "p6", Name: "p6", Backend: "unavailable"})
+	a.ManagePolicies(payloads, "req-1")
+
+	all, err := repo.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, all, 5)
+	for _, p := range all {
+		assert.Equal(t, policies.FailedToApply, p.State, p.ID)
+		assert.Equal(t, "req-1", p.RequestID, p.ID)
+	}
+}
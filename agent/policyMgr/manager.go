@@ -6,6 +6,7 @@ package manager
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -18,9 +19,11 @@ import (
 )
 
 type PolicyManager interface {
-	ManagePolicy(payload fleet.AgentPolicyRPCPayload)
+	ManagePolicy(payload fleet.AgentPolicyRPCPayload, requestID string)
+	ManagePolicies(payloads []fleet.AgentPolicyRPCPayload, requestID string)
 	RemovePolicyDataset(policyID string, datasetID string, be backend.Backend)
 	GetPolicyState() ([]policies.PolicyData, error)
+	GetPolicyInventory() ([]fleet.AgentPolicyInventoryRPCPayload, error)
 	GetRepo() policies.PolicyRepo
 	ApplyBackendPolicies(be backend.Backend) error
 	RemoveBackendPolicies(be backend.Backend, permanently bool) error
@@ -44,6 +47,33 @@ func (a *policyManager) GetPolicyState() ([]policies.PolicyData, error) {
 	return a.repo.GetAll()
 }
 
+// GetPolicyInventory lists the policies currently applied on the agent with their version and state, ordered by id
+func (a *policyManager) GetPolicyInventory() ([]fleet.AgentPolicyInventoryRPCPayload, error) {
+	applied, err := a.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return policyInventory(applied), nil
+}
+
+func policyInventory(applied []policies.PolicyData) []fleet.AgentPolicyInventoryRPCPayload {
+	inventory := make([]fleet.AgentPolicyInventoryRPCPayload, 0, len(applied))
+	for _, p := range applied {
+		inventory = append(inventory, fleet.AgentPolicyInventoryRPCPayload{
+			ID:         p.ID,
+			Name:       p.Name,
+			Backend:    p.Backend,
+			Version:    p.Version,
+			State:      p.State.String(),
+			BackendErr: p.BackendErr,
+		})
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].ID < inventory[j].ID
+	})
+	return inventory
+}
+
 func New(logger *zap.Logger, c config.Config, db *sqlx.DB) (PolicyManager, error) {
 	repo, err := policies.NewMemRepo(logger)
 	if err != nil {
@@ -52,9 +82,10 @@ func New(logger *zap.Logger, c config.Config, db *sqlx.DB) (PolicyManager, error
 	return &policyManager{logger: logger, config: c, repo: repo}, nil
 }
 
-func (a *policyManager) ManagePolicy(payload fleet.AgentPolicyRPCPayload) {
+func (a *policyManager) ManagePolicy(payload fleet.AgentPolicyRPCPayload, requestID string) {
 
 	a.logger.Info("managing agent policy from core",
+		zap.String("request_id", requestID),
 		zap.String("action", payload.Action),
 		zap.String("name", payload.Name),
 		zap.String("dataset", payload.DatasetID),
@@ -65,12 +96,13 @@ func (a *policyManager) ManagePolicy(payload fleet.AgentPolicyRPCPayload) {
 	switch payload.Action {
 	case "manage":
 		var pd = policies.PolicyData{
-			ID:      payload.ID,
-			Name:    payload.Name,
-			Backend: payload.Backend,
-			Version: payload.Version,
-			Data:    payload.Data,
-			State:   policies.Unknown,
+			ID:        payload.ID,
+			Name:      payload.Name,
+			Backend:   payload.Backend,
+			Version:   payload.Version,
+			Data:      payload.Data,
+			State:     policies.Unknown,
+			RequestID: requestID,
 		}
 		var updatePolicy bool
 		if a.repo.Exists(payload.ID) {
@@ -122,13 +154,14 @@ func (a *policyManager) ManagePolicy(payload fleet.AgentPolicyRPCPayload) {
 			}
 
 		}
-		if !backend.HaveBackend(payload.Backend) {
+		if !backend.HaveInstance(payload.Backend) {
 			a.logger.Warn("policy failed to apply because backend is not available", zap.String("policy_id", payload.ID), zap.String("policy_name", payload.Name))
 			pd.State = policies.FailedToApply
 			pd.BackendErr = "backend not available"
+			policyFailed.With("policy_id", payload.ID, "backend", payload.Backend).Add(1)
 		} else {
 			// attempt to apply the policy to the backend. status of policy application (running/failed) is maintained there.
-			be := backend.GetBackend(payload.Backend)
+			be := backend.GetInstance(payload.Backend)
 			a.applyPolicy(payload, be, &pd, updatePolicy)
 		}
 		// save policy (with latest status) to local policy db
@@ -154,10 +187,10 @@ func (a *policyManager) RemovePolicy(policyID string, policyName string, beName
 		ID:   policyID,
 		Name: policyName,
 	}
-	if !backend.HaveBackend(beName) {
+	if !backend.HaveInstance(beName) {
 		return errors.New("policy remove for a backend we do not have, ignoring")
 	}
-	be := backend.GetBackend(beName)
+	be := backend.GetInstance(beName)
 	err := be.RemovePolicy(pd)
 	if err != nil {
 		a.logger.Error("backend remove policy failed: will still remove from PolicyManager", zap.String("policy_id", policyID), zap.Error(err))
@@ -167,6 +200,7 @@ func (a *policyManager) RemovePolicy(policyID string, policyName string, beName
 	if err != nil {
 		return err
 	}
+	policyRemoved.With("policy_id", policyID, "backend", beName).Add(1)
 	return nil
 }
 
@@ -192,10 +226,21 @@ func (a *policyManager) RemovePolicyDataset(policyID string, datasetID string, b
 		if err != nil {
 			a.logger.Warn("policy failed to remove local", zap.String("policy_id", policyData.ID), zap.String("policy_name", policyData.Name), zap.Error(err))
 		}
+		policyRemoved.With("policy_id", policyData.ID, "backend", policyData.Backend).Add(1)
 	}
 }
 
 func (a *policyManager) applyPolicy(payload fleet.AgentPolicyRPCPayload, be backend.Backend, pd *policies.PolicyData, updatePolicy bool) {
+	// fail fast when the policy references taps the backend does not know, instead of sending it to the backend
+	if known, ok := a.knownTaps(be); ok {
+		if missing := missingTaps(pd.Data, known); len(missing) > 0 {
+			a.logger.Warn("policy references taps not found on backend", zap.String("policy_id", payload.ID), zap.String("policy_name", payload.Name), zap.Strings("taps", missing))
+			pd.State = policies.TapNotFound
+			pd.BackendErr = tapNotFoundErr(missing)
+			policyFailed.With("policy_id", payload.ID, "backend", payload.Backend).Add(1)
+			return
+		}
+	}
 	err := be.ApplyPolicy(*pd, updatePolicy)
 	if err != nil {
 		a.logger.Warn("policy failed to apply", zap.String("policy_id", payload.ID), zap.String("policy_name", payload.Name), zap.Error(err))
@@ -206,26 +251,33 @@ func (a *policyManager) applyPolicy(payload fleet.AgentPolicyRPCPayload, be back
 			pd.State = policies.FailedToApply
 		}
 		pd.BackendErr = err.Error()
+		policyFailed.With("policy_id", payload.ID, "backend", payload.Backend).Add(1)
 	} else {
 		a.logger.Info("policy applied successfully", zap.String("policy_id", payload.ID), zap.String("policy_name", payload.Name))
 		pd.State = policies.Running
 		pd.BackendErr = ""
+		policyApplied.With("policy_id", payload.ID, "backend", payload.Backend).Add(1)
 	}
 }
 
 func (a *policyManager) RemoveBackendPolicies(be backend.Backend, permanently bool) error {
-	plcies, err := a.repo.GetAll()
+	applied, err := a.repo.GetAll()
 	if err != nil {
 		a.logger.Error("failed to retrieve list of policies", zap.Error(err))
 		return err
 	}
 
-	for _, plcy := range plcies {
+	for _, plcy := range applied {
+		// policies are namespaced by backend instance, leave the ones of other instances untouched
+		if backend.GetInstance(plcy.Backend) != be {
+			continue
+		}
 		err := be.RemovePolicy(plcy)
 		if err != nil {
 			a.logger.Error("failed to remove policy from backend", zap.String("policy_id", plcy.ID), zap.String("policy_name", plcy.Name), zap.Error(err))
 			// note we continue here: even if the backend failed to remove, we update our policy repo to remove it
 		}
+		policyRemoved.With("policy_id", plcy.ID, "backend", plcy.Backend).Add(1)
 		if permanently {
 			err = a.repo.Remove(plcy.ID)
 			if err != nil {
@@ -243,22 +295,44 @@ func (a *policyManager) RemoveBackendPolicies(be backend.Backend, permanently bo
 }
 
 func (a *policyManager) ApplyBackendPolicies(be backend.Backend) error {
-	plcies, err := a.repo.GetAll()
+	applied, err := a.repo.GetAll()
 	if err != nil {
 		a.logger.Error("failed to retrieve list of policies", zap.Error(err))
 		return err
 	}
 
-	for _, policy := range plcies {
+	// the taps are only retrieved once the backend has a policy to apply
+	var known map[string]bool
+	var fetched, checkTaps bool
+	for _, policy := range applied {
+		if backend.GetInstance(policy.Backend) != be {
+			continue
+		}
+		if !fetched {
+			known, checkTaps = a.knownTaps(be)
+			fetched = true
+		}
+		if missing := missingTaps(policy.Data, known); checkTaps && len(missing) > 0 {
+			a.logger.Warn("policy references taps not found on backend", zap.String("policy_id", policy.ID), zap.String("policy_name", policy.Name), zap.Strings("taps", missing))
+			policy.State = policies.TapNotFound
+			policy.BackendErr = tapNotFoundErr(missing)
+			policyFailed.With("policy_id", policy.ID, "backend", policy.Backend).Add(1)
+			if err := a.repo.Update(policy); err != nil {
+				return err
+			}
+			continue
+		}
 		err := be.ApplyPolicy(policy, false)
 		if err != nil {
 			a.logger.Warn("policy failed to apply", zap.String("policy_id", policy.ID), zap.String("policy_name", policy.Name), zap.Error(err))
 			policy.State = policies.FailedToApply
 			policy.BackendErr = err.Error()
+			policyFailed.With("policy_id", policy.ID, "backend", policy.Backend).Add(1)
 		} else {
 			a.logger.Info("policy applied successfully", zap.String("policy_id", policy.ID), zap.String("policy_name", policy.Name))
 			policy.State = policies.Running
 			policy.BackendErr = ""
+			policyApplied.With("policy_id", policy.ID, "backend", policy.Backend).Add(1)
 		}
 		err = a.repo.Update(policy)
 		if err != nil {
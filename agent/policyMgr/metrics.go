@@ -0,0 +1,34 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	policyApplied metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "orb_agent",
+		Subsystem: "policy",
+		Name:      "applied_total",
+		Help:      "Number of times a policy was applied to its backend",
+	}, []string{"policy_id", "backend"})
+
+	policyFailed metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "orb_agent",
+		Subsystem: "policy",
+		Name:      "failed_total",
+		Help:      "Number of times a policy failed to be applied to its backend",
+	}, []string{"policy_id", "backend"})
+
+	policyRemoved metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "orb_agent",
+		Subsystem: "policy",
+		Name:      "removed_total",
+		Help:      "Number of times a policy was removed from its backend",
+	}, []string{"policy_id", "backend"})
+)
@@ -0,0 +1,83 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/orb-community/orb/agent/backend"
+	"go.uber.org/zap"
+)
+
+// knownTaps returns the taps reported on the capabilities of a backend, ok is false when the backend
+// does not report taps, so the policies sent to it are not checked
+func (a *policyManager) knownTaps(be backend.Backend) (taps map[string]bool, ok bool) {
+	capabilities, err := be.GetCapabilities()
+	if err != nil {
+		a.logger.Warn("failed to retrieve backend capabilities, policy taps are not checked", zap.Error(err))
+		return nil, false
+	}
+	return tapsFromCapabilities(capabilities)
+}
+
+func tapsFromCapabilities(capabilities map[string]interface{}) (map[string]bool, bool) {
+	reported, ok := capabilities["taps"]
+	if !ok {
+		return nil, false
+	}
+	taps := make(map[string]bool)
+	switch t := reported.(type) {
+	case map[string]interface{}:
+		for name := range t {
+			taps[name] = true
+		}
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				taps[s] = true
+			}
+		}
+	case []string:
+		for _, name := range t {
+			taps[name] = true
+		}
+	}
+	return taps, true
+}
+
+// policyTaps returns the taps referenced by the input of a policy
+func policyTaps(data interface{}) []string {
+	policy, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	input, ok := policy["input"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	tap, ok := input["tap"].(string)
+	if !ok || tap == "" {
+		return nil
+	}
+	return []string{tap}
+}
+
+// missingTaps lists the taps referenced by a policy which are not known by the backend
+func missingTaps(data interface{}, known map[string]bool) []string {
+	var missing []string
+	for _, tap := range policyTaps(data) {
+		if !known[tap] {
+			missing = append(missing, tap)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func tapNotFoundErr(missing []string) string {
+	return fmt.Sprintf("tap not found on backend: %s", strings.Join(missing, ", "))
+}
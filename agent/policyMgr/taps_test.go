@@ -0,0 +1,83 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingTaps(t *testing.T) {
+	policy := func(tap string) interface{} {
+		return map[string]interface{}{
+			"kind": "collection",
+			"input": map[string]interface{}{
+				"tap":        tap,
+				"input_type": "pcap",
+			},
+		}
+	}
+	pktvisorCapabilities := map[string]interface{}{
+		"taps": map[string]interface{}{
+			"default_pcap": map[string]interface{}{"input_type": "pcap"},
+		},
+	}
+
+	cases := map[string]struct {
+		capabilities map[string]interface{}
+		data         interface{}
+		checked      bool
+		missing      []string
+	}{
+		"tap known by the backend": {
+			capabilities: pktvisorCapabilities,
+			data:         policy("default_pcap"),
+			checked:      true,
+		},
+		"tap not known by the backend": {
+			capabilities: pktvisorCapabilities,
+			data:         policy("eth0_tap"),
+			checked:      true,
+			missing:      []string{"eth0_tap"},
+		},
+		"backend reporting null taps": {
+			capabilities: map[string]interface{}{"taps": nil},
+			data:         policy("default_pcap"),
+			checked:      true,
+			missing:      []string{"default_pcap"},
+		},
+		"tap listed by name": {
+			capabilities: map[string]interface{}{"taps": []interface{}{"default_pcap"}},
+			data:         policy("default_pcap"),
+			checked:      true,
+		},
+		"policy selecting taps by tags": {
+			capabilities: pktvisorCapabilities,
+			data: map[string]interface{}{
+				"input": map[string]interface{}{
+					"tap_selector": map[string]interface{}{"any": []interface{}{map[string]interface{}{"vhost": "1"}}},
+				},
+			},
+			checked: true,
+		},
+		"backend without taps": {
+			capabilities: map[string]interface{}{"default_interval": "1m"},
+			data:         policy("default_pcap"),
+			checked:      false,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			known, checked := tapsFromCapabilities(tc.capabilities)
+			assert.Equal(t, tc.checked, checked, "%s: expected taps checked %t got %t", desc, tc.checked, checked)
+			if !checked {
+				return
+			}
+			assert.Equal(t, tc.missing, missingTaps(tc.data, known), "%s: unexpected missing taps", desc)
+		})
+	}
+}
@@ -6,19 +6,29 @@ package agent
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/eclipse/paho.mqtt.golang"
 	"github.com/orb-community/orb/fleet"
 	"go.uber.org/zap"
 )
 
 func (a *orbAgent) handleGroupMembership(rpc fleet.GroupMembershipRPCPayload) {
-	// if this is the full list, reset all group subscriptions and subscribed to this list
+	// if this is the full list, only change the subscriptions of groups that were added or removed
 	_, _ = a.extendContext("handleGroupMembership")
 	if rpc.FullList {
-		a.unsubscribeGroupChannels()
-		a.subscribeGroupChannels(rpc.Groups)
+		added, removed := diffGroupMembership(a.groupsInfos, rpc.Groups)
+		if a.groupsSynced && len(added) == 0 && len(removed) == 0 {
+			a.logger.Debug("group membership did not change, keeping current subscriptions")
+			return
+		}
+		for id, groupInfo := range removed {
+			a.unsubscribeGroupChannel(groupInfo.ChannelID, id)
+		}
+		a.subscribeGroupChannels(added)
+		a.groupsSynced = true
+		// the full policy list removes only the policies not reachable through the remaining groups
 		err := a.sendAgentPoliciesReq()
 		if err != nil {
 			a.logger.Error("failed to send agent policies request", zap.Error(err))
@@ -29,8 +39,34 @@ func (a *orbAgent) handleGroupMembership(rpc fleet.GroupMembershipRPCPayload) {
 	}
 }
 
-func (a *orbAgent) handleAgentPolicies(ctx context.Context, rpc []fleet.AgentPolicyRPCPayload, fullList bool) {
+// diffGroupMembership returns the groups of the full list which are not subscribed yet,
+// and the subscribed groups which are missing or have a different channel in the full list
+func diffGroupMembership(current map[string]GroupInfo, groups []fleet.GroupMembershipData) ([]fleet.GroupMembershipData, map[string]GroupInfo) {
+	incoming := make(map[string]fleet.GroupMembershipData, len(groups))
+	for _, groupData := range groups {
+		incoming[groupData.GroupID] = groupData
+	}
+	removed := make(map[string]GroupInfo)
+	for id, groupInfo := range current {
+		if groupData, ok := incoming[id]; !ok || groupData.ChannelID != groupInfo.ChannelID {
+			removed[id] = groupInfo
+		}
+	}
+	var added []fleet.GroupMembershipData
+	for _, groupData := range groups {
+		if groupInfo, ok := current[groupData.GroupID]; !ok || groupInfo.ChannelID != groupData.ChannelID {
+			added = append(added, groupData)
+		}
+	}
+	return added, removed
+}
+
+func (a *orbAgent) handleAgentPolicies(ctx context.Context, rpc []fleet.AgentPolicyRPCPayload, fullList bool, requestID string) {
 	ctx, _ = a.extendContext("handleAgentPolicies")
+	if a.maintenance.Load() {
+		a.logger.Info("agent in maintenance, ignoring policies", zap.Int("policies", len(rpc)))
+		return
+	}
 	if fullList {
 		policies, err := a.policyManager.GetRepo().GetAll()
 		if err != nil {
@@ -64,11 +100,13 @@ func (a *orbAgent) handleAgentPolicies(ctx context.Context, rpc []fleet.AgentPol
 		}
 	}
 
+	payloads := make([]fleet.AgentPolicyRPCPayload, 0, len(rpc))
 	for _, payload := range rpc {
 		if payload.Action != "sanitize" {
-			a.policyManager.ManagePolicy(payload)
+			payloads = append(payloads, payload)
 		}
 	}
+	a.policyManager.ManagePolicies(payloads, requestID)
 
 	// heart beat with new policy status after application
 	if a.heartbeatCtx == nil {
@@ -76,56 +114,88 @@ func (a *orbAgent) handleAgentPolicies(ctx context.Context, rpc []fleet.AgentPol
 	}
 }
 
+// supportedRPCFuncs are the RPC funcs from core handled by the agent, advertised on its capabilities so core
+// can tell which agents understand a new RPC
+var supportedRPCFuncs = []string{
+	fleet.GroupMembershipRPCFunc,
+	fleet.AgentPolicyRPCFunc,
+	fleet.GroupRemovedRPCFunc,
+	fleet.DatasetRemovedRPCFunc,
+	fleet.AgentStopRPCFunc,
+	fleet.AgentResetRPCFunc,
+	fleet.AgentBackendResetRPCFunc,
+	fleet.AgentMaintenanceEnterRPCFunc,
+	fleet.AgentMaintenanceExitRPCFunc,
+	fleet.AgentUpdateRPCFunc,
+	fleet.AgentPolicyInventoryReqRPCFunc,
+	fleet.AgentEffectiveConfigReqRPCFunc,
+}
+
+// supportedSignals are the telemetry signals the agent exports to core, advertised on its capabilities
+var supportedSignals = []string{"metrics", "logs", "traces"}
+
+// trackRPC logs the receipt of an RPC from core with its request id, which is kept to be echoed on the next heartbeat
+func (a *orbAgent) trackRPC(rpc fleet.RPC, topic string) {
+	a.logger.Info("received RPC from core", zap.String("func", rpc.Func),
+		zap.String("request_id", rpc.RequestID), zap.String("topic", topic))
+	if rpc.RequestID != "" {
+		a.lastRequestID.Store(rpc.RequestID)
+	}
+}
+
 func (a *orbAgent) handleGroupRPCFromCore(_ mqtt.Client, message mqtt.Message) {
+	if a.rejectOversizedRPC(message) {
+		return
+	}
+	received := time.Now()
 	handleMsgCtx, handleMsgCtxCancelFunc := a.extendContext("handleGroupRPCFromCore")
 	go func(ctx context.Context, cancelFunc context.CancelFunc) {
 		defer cancelFunc()
 		a.logger.Debug("Group RPC message from core", zap.String("topic", message.Topic()), zap.ByteString("payload", message.Payload()))
-		var rpc fleet.RPC
-		if err := json.Unmarshal(message.Payload(), &rpc); err != nil {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaMalformed))
-			return
-		}
-		if rpc.SchemaVersion != fleet.CurrentRPCSchemaVersion {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaVersion))
-			return
-		}
-		if rpc.Func == "" || rpc.Payload == nil {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaMalformed))
+		rpc, err := a.decodeRPC(message.Payload())
+		if err != nil {
+			a.logger.Error("error decoding RPC message from core", zap.Error(err))
+			a.publishDeadLetter(message, rpc.envelope(), err)
 			return
 		}
+		a.trackRPC(rpc.envelope(), message.Topic())
+		defer observeRPCHandling(rpc.Func, received)
 
 		// dispatch
 		switch rpc.Func {
 		case fleet.AgentPolicyRPCFunc:
-			var r fleet.AgentPolicyRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p []fleet.AgentPolicyRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding agent policy message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleAgentPolicies(ctx, r.Payload, r.FullList)
+			a.handleAgentPolicies(ctx, p, rpc.FullList, rpc.RequestID)
 			a.logger.Debug("received agent policies, marking success")
 			if a.policyRequestSucceeded != nil {
 				a.policyRequestSucceeded()
 			}
 		case fleet.GroupRemovedRPCFunc:
-			var r fleet.GroupRemovedRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p fleet.GroupRemovedRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding agent group removal message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleAgentGroupRemoval(r.Payload)
+			a.handleAgentGroupRemoval(p)
 		case fleet.DatasetRemovedRPCFunc:
-			var r fleet.DatasetRemovedRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p fleet.DatasetRemovedRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding dataset removal message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleDatasetRemoval(r.Payload)
+			a.handleDatasetRemoval(p)
 		default:
 			a.logger.Warn("unsupported/unhandled core RPC, ignoring",
 				zap.String("func", rpc.Func),
-				zap.Any("payload", rpc.Payload))
+				zap.ByteString("payload", rpc.Payload))
+			a.publishDeadLetter(message, rpc.envelope(), ErrUnsupportedRPC)
 		}
 	}(handleMsgCtx, handleMsgCtxCancelFunc)
 }
@@ -178,66 +248,171 @@ func (a *orbAgent) handleAgentReset(ctx context.Context, payload fleet.AgentRese
 	}
 }
 
+// backendResetSucceeded is the reset result reported on the heartbeat when the backend restarted
+const backendResetSucceeded = "succeeded"
+
+// handleAgentBackendReset restarts a single backend and re-applies its policies, the result is
+// reported on the next heartbeat
+func (a *orbAgent) handleAgentBackendReset(ctx context.Context, payload fleet.AgentBackendResetRPCPayload) {
+	state, ok := a.backendState[payload.Backend]
+	if !ok {
+		a.logger.Error("backend reset requested for a unknown backend", zap.String("backend", payload.Backend))
+		return
+	}
+	if err := a.RestartBackend(ctx, payload.Backend, payload.Reason); err != nil {
+		a.logger.Error("failed to reset backend", zap.String("backend", payload.Backend), zap.Error(err))
+		state.SetResetResult(fmt.Sprintf("failed: %v", err))
+		return
+	}
+	state.SetResetResult(backendResetSucceeded)
+}
+
+// handleAgentMaintenance removes the policies of all backends when entering maintenance, keeping the group
+// subscriptions, and requests the policies again from core when exiting it
+func (a *orbAgent) handleAgentMaintenance(enable bool, payload fleet.AgentMaintenanceRPCPayload) {
+	if !enable {
+		if !a.maintenance.Swap(false) {
+			a.logger.Debug("agent not in maintenance, ignoring", zap.String("reason", payload.Reason))
+			return
+		}
+		a.logger.Info("exiting maintenance, requesting policies", zap.String("reason", payload.Reason))
+		if err := a.sendAgentPoliciesReq(); err != nil {
+			a.logger.Error("failed to send agent policies request", zap.Error(err))
+		}
+		return
+	}
+	a.maintenance.Store(true)
+	a.logger.Info("entering maintenance, removing all policies", zap.String("reason", payload.Reason))
+	for name, be := range a.backends {
+		if err := a.policyManager.RemoveBackendPolicies(be, true); err != nil {
+			a.logger.Error("failed to remove policies", zap.String("backend", name), zap.Error(err))
+		}
+	}
+}
+
+// defaultMaxRPCPayloadSize is the size limit of the RPCs from core when none is configured, the full policy
+// list of an agent with many large policies stays well below it
+const defaultMaxRPCPayloadSize = 4 * 1024 * 1024
+
+// maxRPCPayloadSize returns the configured size limit of the RPCs from core
+func (a *orbAgent) maxRPCPayloadSize() int {
+	if size := a.config.OrbAgent.Cloud.MQTT.MaxRPCPayloadSize; size > 0 {
+		return size
+	}
+	return defaultMaxRPCPayloadSize
+}
+
+// rejectOversizedRPC reports whether the RPC message from core is above the max payload size, such messages are
+// not decoded nor sent as dead letters, which would carry them back
+func (a *orbAgent) rejectOversizedRPC(message mqtt.Message) bool {
+	size, limit := len(message.Payload()), a.maxRPCPayloadSize()
+	if size <= limit {
+		return false
+	}
+	a.logger.Error("rejecting RPC message from core above the max payload size", zap.String("topic", message.Topic()),
+		zap.Int("payload_size", size), zap.Int("limit", limit))
+	rpcRejected.With("reason", "payload_too_large").Add(1)
+	return true
+}
+
 func (a *orbAgent) handleRPCFromCore(client mqtt.Client, message mqtt.Message) {
+	if a.rejectOversizedRPC(message) {
+		return
+	}
+	received := time.Now()
 	handleMsgCtx, handleMsgCtxCancelFunc := a.extendContext("handleRPCFromCore")
 	go func(ctx context.Context, cancelFunc context.CancelFunc) {
+		defer cancelFunc()
 		a.logger.Debug("RPC message from core", zap.String("topic", message.Topic()), zap.ByteString("payload", message.Payload()))
 
-		var rpc fleet.RPC
-		if err := json.Unmarshal(message.Payload(), &rpc); err != nil {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaMalformed))
-			return
-		}
-		if rpc.SchemaVersion != fleet.CurrentRPCSchemaVersion {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaVersion))
-			return
-		}
-		if rpc.Func == "" || rpc.Payload == nil {
-			a.logger.Error("error decoding RPC message from core", zap.Error(fleet.ErrSchemaMalformed))
+		rpc, err := a.decodeRPC(message.Payload())
+		if err != nil {
+			a.logger.Error("error decoding RPC message from core", zap.Error(err))
+			a.publishDeadLetter(message, rpc.envelope(), err)
 			return
 		}
+		a.trackRPC(rpc.envelope(), message.Topic())
+		defer observeRPCHandling(rpc.Func, received)
 		// dispatch
 		switch rpc.Func {
 		case fleet.GroupMembershipRPCFunc:
-			var r fleet.GroupMembershipRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p fleet.GroupMembershipRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding group membership message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleGroupMembership(r.Payload)
+			a.handleGroupMembership(p)
 			a.logger.Debug("received group membership, marking success")
 			if a.groupRequestSucceeded != nil {
 				a.groupRequestSucceeded()
 			}
 		case fleet.AgentPolicyRPCFunc:
-			var r fleet.AgentPolicyRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p []fleet.AgentPolicyRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding agent policy message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleAgentPolicies(ctx, r.Payload, r.FullList)
+			a.handleAgentPolicies(ctx, p, rpc.FullList, rpc.RequestID)
 			a.logger.Debug("received agent policies, marking success")
 			if a.policyRequestSucceeded != nil {
 				a.policyRequestSucceeded()
 			}
 		case fleet.AgentStopRPCFunc:
-			var r fleet.AgentStopRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p fleet.AgentStopRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding agent stop message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleAgentStop(r.Payload)
+			a.handleAgentStop(p)
 		case fleet.AgentResetRPCFunc:
-			var r fleet.AgentResetRPC
-			if err := json.Unmarshal(message.Payload(), &r); err != nil {
+			var p fleet.AgentResetRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
 				a.logger.Error("error decoding agent reset message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
 				return
 			}
-			a.handleAgentReset(ctx, r.Payload)
+			// the restarted comms and backends outlive the handling of the message
+			a.handleAgentReset(context.WithoutCancel(ctx), p)
+		case fleet.AgentBackendResetRPCFunc:
+			var p fleet.AgentBackendResetRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
+				a.logger.Error("error decoding agent backend reset message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
+				return
+			}
+			a.handleAgentBackendReset(context.WithoutCancel(ctx), p)
+		case fleet.AgentMaintenanceEnterRPCFunc, fleet.AgentMaintenanceExitRPCFunc:
+			var p fleet.AgentMaintenanceRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
+				a.logger.Error("error decoding agent maintenance message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
+				return
+			}
+			a.handleAgentMaintenance(rpc.Func == fleet.AgentMaintenanceEnterRPCFunc, p)
+		case fleet.AgentUpdateRPCFunc:
+			var p fleet.AgentUpdateRPCPayload
+			if err := rpc.decodePayload(&p); err != nil {
+				a.logger.Error("error decoding agent update message from core", zap.Error(fleet.ErrSchemaMalformed))
+				a.publishDeadLetter(message, rpc.envelope(), fleet.ErrSchemaMalformed)
+				return
+			}
+			a.handleAgentUpdate(ctx, p)
+		case fleet.AgentPolicyInventoryReqRPCFunc:
+			if err := a.sendPolicyInventory(rpc.RequestID); err != nil {
+				a.logger.Error("failed to send agent policy inventory", zap.Error(err))
+			}
+		case fleet.AgentEffectiveConfigReqRPCFunc:
+			if err := a.sendEffectiveConfig(rpc.RequestID); err != nil {
+				a.logger.Error("failed to send agent effective config", zap.Error(err))
+			}
 		default:
 			a.logger.Warn("unsupported/unhandled core RPC, ignoring",
 				zap.String("func", rpc.Func),
-				zap.Any("payload", rpc.Payload))
+				zap.ByteString("payload", rpc.Payload))
+			a.publishDeadLetter(message, rpc.envelope(), ErrUnsupportedRPC)
 		}
 	}(handleMsgCtx, handleMsgCtxCancelFunc)
 }
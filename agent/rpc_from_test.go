@@ -0,0 +1,236 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/orb-community/orb/agent/backend"
+	"github.com/orb-community/orb/agent/config"
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func Test_diffGroupMembership(t *testing.T) {
+	current := map[string]GroupInfo{
+		"g1": {Name: "group1", ChannelID: "c1"},
+		"g2": {Name: "group2", ChannelID: "c2"},
+	}
+
+	tests := []struct {
+		name        string
+		groups      []fleet.GroupMembershipData
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name: "identical full list",
+			groups: []fleet.GroupMembershipData{
+				{GroupID: "g2", Name: "group2", ChannelID: "c2"},
+				{GroupID: "g1", Name: "group1", ChannelID: "c1"},
+			},
+		},
+		{
+			name: "group added",
+			groups: []fleet.GroupMembershipData{
+				{GroupID: "g1", Name: "group1", ChannelID: "c1"},
+				{GroupID: "g2", Name: "group2", ChannelID: "c2"},
+				{GroupID: "g3", Name: "group3", ChannelID: "c3"},
+			},
+			wantAdded: []string{"g3"},
+		},
+		{
+			name: "group removed",
+			groups: []fleet.GroupMembershipData{
+				{GroupID: "g1", Name: "group1", ChannelID: "c1"},
+			},
+			wantRemoved: []string{"g2"},
+		},
+		{
+			name: "group channel changed",
+			groups: []fleet.GroupMembershipData{
+				{GroupID: "g1", Name: "group1", ChannelID: "c1"},
+				{GroupID: "g2", Name: "group2", ChannelID: "c4"},
+			},
+			wantAdded:   []string{"g2"},
+			wantRemoved: []string{"g2"},
+		},
+		{
+			name:        "empty full list",
+			wantRemoved: []string{"g1", "g2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffGroupMembership(current, tt.groups)
+			var addedIDs []string
+			for _, groupData := range added {
+				addedIDs = append(addedIDs, groupData.GroupID)
+			}
+			var removedIDs []string
+			for id := range removed {
+				removedIDs = append(removedIDs, id)
+			}
+			assert.Equal(t, tt.wantAdded, addedIDs)
+			assert.ElementsMatch(t, tt.wantRemoved, removedIDs)
+		})
+	}
+}
+
+// subscriptionsClient records the topics subscribed and unsubscribed by the agent
+type subscriptionsClient struct {
+	mqtt.Client
+	subscribed   []string
+	unsubscribed []string
+}
+
+func (c *subscriptionsClient) Subscribe(topic string, _ byte, _ mqtt.MessageHandler) mqtt.Token {
+	c.subscribed = append(c.subscribed, topic)
+	return doneToken{}
+}
+
+func (c *subscriptionsClient) Unsubscribe(topics ...string) mqtt.Token {
+	c.unsubscribed = append(c.unsubscribed, topics...)
+	return doneToken{}
+}
+
+type doneToken struct{}
+
+func (doneToken) Wait() bool                     { return true }
+func (doneToken) WaitTimeout(time.Duration) bool { return true }
+func (doneToken) Error() error                   { return nil }
+func (doneToken) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func Test_handleGroupMembershipChannelRotation(t *testing.T) {
+	client := &subscriptionsClient{}
+	a := &orbAgent{
+		logger:       zap.NewNop(),
+		client:       client,
+		asyncContext: context.Background(),
+		groupsInfos: map[string]GroupInfo{
+			"g1": {Name: "group1", ChannelID: "c1"},
+			"g2": {Name: "group2", ChannelID: "c2"},
+		},
+	}
+
+	a.handleGroupMembership(fleet.GroupMembershipRPCPayload{
+		Groups: []fleet.GroupMembershipData{
+			{GroupID: "g2", Name: "group2", ChannelID: "c3"},
+		},
+	})
+
+	assert.Equal(t, []string{"channels/c3/messages/" + fleet.RPCFromCoreTopic}, client.subscribed)
+	assert.Equal(t, []string{"channels/c2/messages/" + fleet.RPCFromCoreTopic}, client.unsubscribed, "stale subscription must be dropped")
+	assert.Equal(t, map[string]GroupInfo{
+		"g1": {Name: "group1", ChannelID: "c1"},
+		"g2": {Name: "group2", ChannelID: "c3"},
+	}, a.groupsInfos)
+}
+
+func Test_handleAgentMaintenance(t *testing.T) {
+	a := &orbAgent{
+		logger:       zap.NewNop(),
+		asyncContext: context.Background(),
+		backends:     map[string]backend.Backend{},
+	}
+
+	a.handleAgentMaintenance(true, fleet.AgentMaintenanceRPCPayload{Reason: "test"})
+	assert.Equal(t, fleet.Maintenance, a.onlineState())
+
+	// the policy manager is never reached while in maintenance
+	a.handleAgentPolicies(context.Background(), []fleet.AgentPolicyRPCPayload{{ID: "p1"}}, true, "")
+
+	a.handleAgentMaintenance(false, fleet.AgentMaintenanceRPCPayload{Reason: "test"})
+	assert.Equal(t, fleet.Online, a.onlineState())
+}
+
+func Test_newDeadLetter(t *testing.T) {
+	message := []byte(`{"schema_version":"1.0","func":"new_func","request_id":"req-1","payload":{}}`)
+	rpc := fleet.RPC{SchemaVersion: "1.0", Func: "new_func", RequestID: "req-1", Payload: map[string]interface{}{}}
+
+	got := newDeadLetter("channels/c1/messages/fromcore", message, rpc, ErrUnsupportedRPC)
+	assert.Equal(t, fleet.DeadLetterRPCFunc, got.Func)
+	assert.Equal(t, "req-1", got.RequestID)
+	assert.Equal(t, "new_func", got.Payload.Func)
+	assert.Equal(t, ErrUnsupportedRPC.Error(), got.Payload.Error)
+	assert.Equal(t, "channels/c1/messages/fromcore", got.Payload.Topic)
+	assert.Equal(t, message, got.Payload.Message)
+
+	undecodable := newDeadLetter("channels/c1/messages/fromcore", []byte("{"), fleet.RPC{}, fleet.ErrSchemaMalformed)
+	assert.Empty(t, undecodable.RequestID)
+	assert.Empty(t, undecodable.Payload.Func)
+	assert.Equal(t, fleet.ErrSchemaMalformed.Error(), undecodable.Payload.Error)
+}
+
+func Test_effectiveConfig(t *testing.T) {
+	c := config.Config{Version: 1.0}
+	c.OrbAgent.Tags = map[string]string{"region": "us-east"}
+	c.OrbAgent.Backends = map[string]map[string]string{"pktvisor": {"binary": "/usr/local/sbin/pktvisord"}}
+	c.OrbAgent.Cloud.API = config.APIConfig{Address: "https://orb.live", Token: "api-token"}
+	c.OrbAgent.Cloud.MQTT = config.MQTTConfig{Address: "tls://orb.live:8883", Id: "agent-id", Key: "mqtt-key", ChannelID: "channel-id"}
+	c.OrbAgent.Cloud.CapabilitiesRetry.Deadline = time.Minute
+
+	got := effectiveConfig(c)
+	body, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "api-token", "api token must be left out")
+	assert.NotContains(t, string(body), "mqtt-key", "mqtt key must be left out")
+	assert.NotContains(t, got, "backends", "backends are reported apart")
+	assert.Equal(t, c.OrbAgent.Tags, got["tags"])
+
+	cloud := got["cloud"].(map[string]interface{})
+	assert.Equal(t, "https://orb.live", cloud["api"].(map[string]interface{})["address"])
+	assert.Equal(t, "agent-id", cloud["mqtt"].(map[string]interface{})["id"])
+	assert.Equal(t, "1m0s", cloud["capabilities_retry"].(map[string]interface{})["deadline"])
+}
+
+// rpcMessage is an RPC message from core received on the agent topic
+type rpcMessage struct {
+	mqtt.Message
+	payload []byte
+}
+
+func (m rpcMessage) Topic() string {
+	return "channels/channel-id/messages/fromcore"
+}
+
+func (m rpcMessage) Payload() []byte {
+	return m.payload
+}
+
+func Test_handleRPCFromCoreMaxPayloadSize(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	var c config.Config
+	c.OrbAgent.Cloud.MQTT.MaxRPCPayloadSize = 64
+	a := &orbAgent{logger: zap.New(core), config: c}
+
+	payload := []byte(`{"schema_version":"1.0","func":"agent_policies","payload":[{"id":"` + strings.Repeat("a", 64) + `"}]}`)
+	a.handleRPCFromCore(nil, rpcMessage{payload: payload})
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "rejecting RPC message from core above the max payload size", logs.All()[0].Message)
+
+	a.config.OrbAgent.Cloud.MQTT.MaxRPCPayloadSize = 0
+	assert.Equal(t, defaultMaxRPCPayloadSize, a.maxRPCPayloadSize())
+}
+
+func Test_handleGroupRPCFromCoreMaxPayloadSize(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	var c config.Config
+	c.OrbAgent.Cloud.MQTT.MaxRPCPayloadSize = 64
+	a := &orbAgent{logger: zap.New(core), config: c}
+
+	payload := []byte(`{"schema_version":"1.0","func":"agent_policies","payload":[{"id":"` + strings.Repeat("a", 64) + `"}]}`)
+	a.handleGroupRPCFromCore(nil, rpcMessage{payload: payload})
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "rejecting RPC message from core above the max payload size", logs.All()[0].Message)
+}
@@ -0,0 +1,141 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/orb-community/orb/fleet"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// rpcUpconverter rewrites a raw RPC message from an older schema version into the version it points to
+type rpcUpconverter struct {
+	to      string
+	convert func(data []byte) ([]byte, error)
+}
+
+// rpcUpconverters holds the upconversion steps of known older RPC schema versions, keyed by the version they convert from
+var rpcUpconverters = map[string]rpcUpconverter{}
+
+var minRPCSchemaVersion = fleet.MinRPCSchemaVersion
+
+var rpcSchemaVersionDropped metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	Namespace: "orb_agent",
+	Subsystem: "rpc",
+	Name:      "schema_version_dropped_total",
+	Help:      "Number of RPC messages from core dropped due to an incompatible schema version",
+}, []string{"schema_version"})
+
+func parseSchemaVersion(version string) (major int, minor int, err error) {
+	majorStr, minorStr, _ := strings.Cut(version, ".")
+	if major, err = strconv.Atoi(majorStr); err != nil {
+		return 0, 0, err
+	}
+	if minorStr == "" {
+		return major, 0, nil
+	}
+	if minor, err = strconv.Atoi(minorStr); err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
+
+// upconvertRPC returns the RPC message in the current schema version. Newer minor versions of the current major
+// version are accepted as they are, known older versions go through the upconverters and anything else is
+// incompatible
+func upconvertRPC(version string, data []byte) ([]byte, error) {
+	major, minor, err := parseSchemaVersion(version)
+	if err != nil {
+		return nil, fleet.ErrSchemaVersion
+	}
+	currentMajor, currentMinor, _ := parseSchemaVersion(fleet.CurrentRPCSchemaVersion)
+	if major == currentMajor && minor >= currentMinor {
+		return data, nil
+	}
+	minMajor, minMinor, _ := parseSchemaVersion(minRPCSchemaVersion)
+	if major > currentMajor || major < minMajor || (major == minMajor && minor < minMinor) {
+		return nil, fleet.ErrSchemaVersion
+	}
+
+	for steps := 0; version != fleet.CurrentRPCSchemaVersion; steps++ {
+		step, ok := rpcUpconverters[version]
+		if !ok || steps >= len(rpcUpconverters) {
+			return nil, fleet.ErrSchemaVersion
+		}
+		if data, err = step.convert(data); err != nil {
+			return nil, fleet.ErrSchemaMalformed
+		}
+		version = step.to
+	}
+	return data, nil
+}
+
+// rpcFromCore is an RPC message from core with its payload left undecoded, so the payload is parsed only once,
+// straight into the type expected by its func
+type rpcFromCore struct {
+	SchemaVersion string          `json:"schema_version"`
+	Func          string          `json:"func"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	// FullList is only sent along the agent_policy RPCs
+	FullList bool `json:"full_list"`
+}
+
+// envelope returns the RPC fields of the message, with the payload still undecoded
+func (m rpcFromCore) envelope() fleet.RPC {
+	return fleet.RPC{
+		SchemaVersion: m.SchemaVersion,
+		Func:          m.Func,
+		RequestID:     m.RequestID,
+		Payload:       m.Payload,
+	}
+}
+
+// decodePayload decodes the payload of the message into v
+func (m rpcFromCore) decodePayload(v interface{}) error {
+	if err := json.Unmarshal(m.Payload, v); err != nil {
+		return fleet.ErrSchemaMalformed
+	}
+	return nil
+}
+
+// decodeRPC decodes the envelope of an RPC message from core in the current schema version, the payload is
+// kept raw for the handler of the func
+func (a *orbAgent) decodeRPC(data []byte) (rpcFromCore, error) {
+	var rpc rpcFromCore
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return rpcFromCore{}, fleet.ErrSchemaMalformed
+	}
+	converted, err := upconvertRPC(rpc.SchemaVersion, data)
+	if err != nil {
+		if err == fleet.ErrSchemaVersion {
+			rpcSchemaVersionDropped.With("schema_version", rpc.SchemaVersion).Add(1)
+			a.logger.Warn("dropping RPC message from core with incompatible schema version",
+				zap.String("schema_version", rpc.SchemaVersion),
+				zap.String("current_schema_version", fleet.CurrentRPCSchemaVersion))
+		}
+		return rpcFromCore{}, err
+	}
+	if rpc.SchemaVersion != fleet.CurrentRPCSchemaVersion {
+		a.logger.Debug("upconverted RPC message from core",
+			zap.String("schema_version", rpc.SchemaVersion),
+			zap.String("current_schema_version", fleet.CurrentRPCSchemaVersion))
+		rpc = rpcFromCore{}
+		if err := json.Unmarshal(converted, &rpc); err != nil {
+			return rpcFromCore{}, fleet.ErrSchemaMalformed
+		}
+	}
+	if rpc.Func == "" || len(rpc.Payload) == 0 || bytes.Equal(rpc.Payload, []byte("null")) {
+		return rpcFromCore{}, fleet.ErrSchemaMalformed
+	}
+	return rpc, nil
+}
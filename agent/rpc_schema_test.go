@@ -0,0 +1,126 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDecodeRPC(t *testing.T) {
+	// pretend "0.9" is a known older version which named the function field "function"
+	minRPCSchemaVersion = "0.9"
+	rpcUpconverters["0.9"] = rpcUpconverter{
+		to: fleet.CurrentRPCSchemaVersion,
+		convert: func(data []byte) ([]byte, error) {
+			var old map[string]interface{}
+			if err := json.Unmarshal(data, &old); err != nil {
+				return nil, err
+			}
+			return json.Marshal(fleet.RPC{
+				SchemaVersion: fleet.CurrentRPCSchemaVersion,
+				Func:          old["function"].(string),
+				Payload:       old["payload"],
+			})
+		},
+	}
+	defer func() {
+		minRPCSchemaVersion = fleet.MinRPCSchemaVersion
+		delete(rpcUpconverters, "0.9")
+	}()
+
+	a := orbAgent{logger: zap.NewNop()}
+	cases := map[string]struct {
+		data      string
+		fn        string
+		requestID string
+		err       error
+	}{
+		"current version": {
+			data: `{"schema_version":"1.0","func":"agent_stop","payload":{"reason":"test"}}`,
+			fn:   fleet.AgentStopRPCFunc,
+		},
+		"with request id": {
+			data:      `{"schema_version":"1.0","func":"agent_stop","request_id":"req-1","payload":{"reason":"test"}}`,
+			fn:        fleet.AgentStopRPCFunc,
+			requestID: "req-1",
+		},
+		"newer minor version": {
+			data: `{"schema_version":"1.3","func":"agent_stop","payload":{"reason":"test"}}`,
+			fn:   fleet.AgentStopRPCFunc,
+		},
+		"known older version": {
+			data: `{"schema_version":"0.9","function":"agent_stop","payload":{"reason":"test"}}`,
+			fn:   fleet.AgentStopRPCFunc,
+		},
+		"older version without upconverter": {
+			data: `{"schema_version":"0.8","func":"agent_stop","payload":{"reason":"test"}}`,
+			err:  fleet.ErrSchemaVersion,
+		},
+		"newer major version": {
+			data: `{"schema_version":"2.0","func":"agent_stop","payload":{"reason":"test"}}`,
+			err:  fleet.ErrSchemaVersion,
+		},
+		"invalid version": {
+			data: `{"schema_version":"latest","func":"agent_stop","payload":{"reason":"test"}}`,
+			err:  fleet.ErrSchemaVersion,
+		},
+		"missing func": {
+			data: `{"schema_version":"1.0","payload":{"reason":"test"}}`,
+			err:  fleet.ErrSchemaMalformed,
+		},
+		"missing payload": {
+			data: `{"schema_version":"1.0","func":"agent_stop"}`,
+			err:  fleet.ErrSchemaMalformed,
+		},
+		"null payload": {
+			data: `{"schema_version":"1.0","func":"agent_stop","payload":null}`,
+			err:  fleet.ErrSchemaMalformed,
+		},
+		"malformed envelope": {
+			data: `{"schema_version":"1.0","func":"agent_stop","payload":`,
+			err:  fleet.ErrSchemaMalformed,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			rpc, err := a.decodeRPC([]byte(tc.data))
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.fn, rpc.Func)
+			assert.Equal(t, tc.requestID, rpc.RequestID)
+			var p fleet.AgentStopRPCPayload
+			require.Nil(t, rpc.decodePayload(&p))
+			assert.Equal(t, "test", p.Reason)
+		})
+	}
+}
+
+func TestDecodeRPCPayload(t *testing.T) {
+	a := orbAgent{logger: zap.NewNop()}
+	rpc, err := a.decodeRPC([]byte(`{"schema_version":"1.0","func":"agent_policy","request_id":"req-1","full_list":true,` +
+		`"payload":[{"action":"manage","id":"p1","name":"policy","backend":"pktvisor","version":2,"data":{"kind":"collection"}}]}`))
+	require.Nil(t, err)
+	assert.True(t, rpc.FullList)
+
+	var policies []fleet.AgentPolicyRPCPayload
+	require.Nil(t, rpc.decodePayload(&policies))
+	require.Len(t, policies, 1)
+	assert.Equal(t, "p1", policies[0].ID)
+	assert.Equal(t, int32(2), policies[0].Version)
+	assert.Equal(t, map[string]interface{}{"kind": "collection"}, policies[0].Data)
+
+	var wrongType fleet.AgentStopRPCPayload
+	assert.Equal(t, fleet.ErrSchemaMalformed, rpc.decodePayload(&wrongType), "expected a payload of another func to be malformed")
+}
@@ -8,6 +8,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/eclipse/paho.mqtt.golang"
+	"github.com/orb-community/orb/agent/config"
 	"github.com/orb-community/orb/buildinfo"
 	"github.com/orb-community/orb/fleet"
 	"go.uber.org/zap"
@@ -22,6 +24,8 @@ func (a *orbAgent) sendCapabilities() error {
 		OrbAgent: fleet.OrbAgentInfo{
 			Version: buildinfo.GetVersion(),
 		},
+		RPCFuncs: supportedRPCFuncs,
+		Signals:  supportedSignals,
 	}
 
 	capabilities.Backends = make(map[string]fleet.BackendInfo)
@@ -47,10 +51,11 @@ func (a *orbAgent) sendCapabilities() error {
 		a.logger.Error("backend failed to marshal capabilities, skipping", zap.Error(err))
 		return err
 	}
+	a.backendCapabilities.Store(capabilities.Backends)
 
 	a.logger.Info("sending capabilities", zap.ByteString("value", body))
-	if token := a.client.Publish(a.capabilitiesTopic, 1, false, body); token.Wait() && token.Error() != nil {
-		return token.Error()
+	if err := a.publish(a.capabilitiesTopic, body); err != nil {
+		return err
 	}
 
 	return nil
@@ -70,8 +75,8 @@ func (a *orbAgent) sendGroupMembershipRequest() error {
 		return err
 	}
 
-	if token := a.client.Publish(a.rpcToCoreTopic, 1, false, body); token.Wait() && token.Error() != nil {
-		return token.Error()
+	if err := a.publish(a.rpcToCoreTopic, body); err != nil {
+		return err
 	}
 	return nil
 }
@@ -134,13 +139,149 @@ func (a *orbAgent) sendAgentPoliciesRequest() error {
 		return err
 	}
 
-	if token := a.client.Publish(a.rpcToCoreTopic, 1, false, body); token.Wait() && token.Error() != nil {
-		return token.Error()
+	if err := a.publish(a.rpcToCoreTopic, body); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// sendPolicyInventory answers an inventory request from core, echoing its request id
+func (a *orbAgent) sendPolicyInventory(requestID string) error {
+	inventory, err := a.policyManager.GetPolicyInventory()
+	if err != nil {
+		return err
+	}
+	a.logger.Debug("sending agent policy inventory", zap.Int("policies", len(inventory)))
+
+	data := fleet.AgentPolicyInventoryRPC{
+		SchemaVersion: fleet.CurrentRPCSchemaVersion,
+		Func:          fleet.AgentPolicyInventoryRPCFunc,
+		RequestID:     requestID,
+		Payload:       inventory,
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if err := a.publish(a.rpcToCoreTopic, body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// sendEffectiveConfig answers an effective config request from core, echoing its request id
+func (a *orbAgent) sendEffectiveConfig(requestID string) error {
+	inventory, err := a.policyManager.GetPolicyInventory()
+	if err != nil {
+		return err
+	}
+
+	backends := make(map[string]fleet.EffectiveBackendInfo, len(a.backends))
+	for name, be := range a.backends {
+		info := fleet.EffectiveBackendInfo{Config: a.config.OrbAgent.Backends[name]}
+		if state, ok := a.backendState[name]; ok {
+			info.State = state.Status.String()
+		}
+		if info.Version, err = be.Version(); err != nil {
+			a.logger.Warn("backend failed to retrieve version", zap.String("backend", name), zap.Error(err))
+		}
+		if info.Data, err = be.GetCapabilities(); err != nil {
+			a.logger.Warn("backend failed to retrieve capabilities", zap.String("backend", name), zap.Error(err))
+		}
+		backends[name] = info
+	}
+	a.logger.Debug("sending agent effective config", zap.Int("backends", len(backends)), zap.Int("policies", len(inventory)))
+
+	data := fleet.AgentEffectiveConfigRPC{
+		SchemaVersion: fleet.CurrentRPCSchemaVersion,
+		Func:          fleet.AgentEffectiveConfigRPCFunc,
+		RequestID:     requestID,
+		Payload: fleet.AgentEffectiveConfigRPCPayload{
+			Version:  buildinfo.GetVersion(),
+			Config:   effectiveConfig(a.config),
+			Backends: backends,
+			Policies: inventory,
+		},
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if err := a.publish(a.rpcToCoreTopic, body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// effectiveConfig returns the agent configuration resolved from its config file and command line, keyed as in
+// the config file. The backends are reported apart, the api token and the mqtt and signing keys are left out
+func effectiveConfig(c config.Config) map[string]interface{} {
+	o := c.OrbAgent
+	return map[string]interface{}{
+		"version": c.Version,
+		"tags":    o.Tags,
+		"cloud": map[string]interface{}{
+			"config": map[string]interface{}{
+				"agent_name":     o.Cloud.Config.AgentName,
+				"auto_provision": o.Cloud.Config.AutoProvision,
+			},
+			"api": map[string]interface{}{
+				"address": o.Cloud.API.Address,
+			},
+			"mqtt": map[string]interface{}{
+				"address":              o.Cloud.MQTT.Address,
+				"id":                   o.Cloud.MQTT.Id,
+				"channel_id":           o.Cloud.MQTT.ChannelID,
+				"client_id_suffix":     o.Cloud.MQTT.ClientIDSuffix,
+				"dead_letter_topic":    o.Cloud.MQTT.DeadLetterTopic,
+				"connect_timeout":      o.Cloud.MQTT.ConnectTimeout.String(),
+				"signing":              o.Cloud.MQTT.SigningKey != "",
+				"max_rpc_payload_size": o.Cloud.MQTT.MaxRPCPayloadSize,
+				"qos": map[string]interface{}{
+					"rpc":       o.Cloud.MQTT.QoS.RPC,
+					"heartbeat": o.Cloud.MQTT.QoS.Heartbeat,
+					"log":       o.Cloud.MQTT.QoS.Log,
+				},
+			},
+			"capabilities_retry": map[string]interface{}{
+				"initial_backoff": o.Cloud.CapabilitiesRetry.InitialBackoff.String(),
+				"max_backoff":     o.Cloud.CapabilitiesRetry.MaxBackoff.String(),
+				"deadline":        o.Cloud.CapabilitiesRetry.Deadline.String(),
+			},
+			"spool": map[string]interface{}{
+				"enable":   o.Cloud.Spool.Enable,
+				"dir":      o.Cloud.Spool.Dir,
+				"max_size": o.Cloud.Spool.MaxSize,
+				"overflow": o.Cloud.Spool.Overflow,
+			},
+		},
+		"tls": map[string]interface{}{
+			"verify":      o.TLS.Verify,
+			"min_version": o.TLS.MinVersion,
+		},
+		"db": map[string]interface{}{
+			"file": o.DB.File,
+		},
+		"otel": map[string]interface{}{
+			"host": o.Otel.Host,
+			"port": o.Otel.Port,
+		},
+		"debug": map[string]interface{}{
+			"enable": o.Debug.Enable,
+		},
+		"heartbeat": map[string]interface{}{
+			"jitter":           o.Heartbeat.Jitter,
+			"jitter_each_beat": o.Heartbeat.JitterEachBeat,
+			"pause_windows":    o.Heartbeat.PauseWindows,
+		},
+	}
+}
+
 func (a *orbAgent) retryAgentPolicyResponse() {
 	if a.policyRequestTicker == nil {
 		a.policyRequestTicker = time.NewTicker(retryRequestFixedTime * retryRequestDuration)
@@ -174,3 +315,51 @@ func (a *orbAgent) retryAgentPolicyResponse() {
 		return
 	}(ctx)
 }
+
+// deadLetterTimeout bounds the wait on the publish of a dead letter
+const deadLetterTimeout = 5 * time.Second
+
+// newDeadLetter wraps the original message of an RPC from core which could not be handled, rpc is empty when
+// the message could not be decoded
+func newDeadLetter(topic string, message []byte, rpc fleet.RPC, reason error) fleet.DeadLetterRPC {
+	return fleet.DeadLetterRPC{
+		SchemaVersion: fleet.CurrentRPCSchemaVersion,
+		Func:          fleet.DeadLetterRPCFunc,
+		RequestID:     rpc.RequestID,
+		Payload: fleet.DeadLetterRPCPayload{
+			Topic:   topic,
+			Func:    rpc.Func,
+			Error:   reason.Error(),
+			Message: message,
+		},
+	}
+}
+
+// publishDeadLetter sends an RPC from core which could not be handled to the dead letter topic, when one is
+// configured. It is best effort, the publish is neither retried nor spooled and never blocks the dispatch
+func (a *orbAgent) publishDeadLetter(message mqtt.Message, rpc fleet.RPC, reason error) {
+	if a.deadLetterTopic == "" {
+		return
+	}
+	body, err := json.Marshal(newDeadLetter(message.Topic(), message.Payload(), rpc, reason))
+	if err == nil {
+		body, err = a.sign(body)
+	}
+	if err != nil {
+		a.logger.Warn("failed to encode dead letter", zap.Error(err))
+		return
+	}
+	client, topic := a.client, a.deadLetterTopic
+	go func() {
+		if client == nil || !client.IsConnected() {
+			a.logger.Debug("dropping dead letter, not connected", zap.String("topic", topic))
+			return
+		}
+		token := client.Publish(topic, a.config.OrbAgent.Cloud.MQTT.QoS.RPC, false, body)
+		if !token.WaitTimeout(deadLetterTimeout) {
+			a.logger.Warn("timed out publishing dead letter", zap.String("topic", topic))
+		} else if token.Error() != nil {
+			a.logger.Warn("failed to publish dead letter", zap.String("topic", topic), zap.Error(token.Error()))
+		}
+	}()
+}
@@ -0,0 +1,225 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/orb-community/orb/agent/config"
+)
+
+const (
+	defaultSpoolDir     = "./orb-agent-spool"
+	defaultSpoolMaxSize = 10 * 1024 * 1024
+	spoolFileExt        = ".msg"
+)
+
+var ErrSpoolFull = errors.New("spool is full, message dropped")
+
+// spooledMessage is a publish to the control plane kept on disk until the agent is connected
+type spooledMessage struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+}
+
+type spoolEntry struct {
+	seq  uint64
+	size int64
+}
+
+// diskSpool is a FIFO of outgoing messages, one file per message named after its sequence so the
+// order survives a restart of the agent
+type diskSpool struct {
+	mu       sync.Mutex
+	dir      string
+	maxSize  int64
+	overflow string
+	entries  []spoolEntry
+	size     int64
+	nextSeq  uint64
+	// draining is set while a drain publishes, so a concurrent drain does not send the same messages again
+	draining bool
+}
+
+func newDiskSpool(c config.SpoolConfig) (*diskSpool, error) {
+	s := &diskSpool{
+		dir:      c.Dir,
+		maxSize:  c.MaxSize,
+		overflow: c.Overflow,
+	}
+	if s.dir == "" {
+		s.dir = defaultSpoolDir
+	}
+	if s.maxSize <= 0 {
+		s.maxSize = defaultSpoolMaxSize
+	}
+	switch s.overflow {
+	case "":
+		s.overflow = config.SpoolOverflowDropOldest
+	case config.SpoolOverflowDropOldest, config.SpoolOverflowDropNewest:
+	default:
+		return nil, fmt.Errorf("invalid spool overflow policy %q", c.Overflow)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, err
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load picks up the messages spooled before the agent was restarted
+func (s *diskSpool) load() error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), spoolFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), spoolFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		s.entries = append(s.entries, spoolEntry{seq: seq, size: info.Size()})
+		s.size += info.Size()
+		if seq >= s.nextSeq {
+			s.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(s.entries, func(i, j int) bool { return s.entries[i].seq < s.entries[j].seq })
+	return nil
+}
+
+func (s *diskSpool) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolFileExt))
+}
+
+// push appends the message to the spool, making room for it according to the overflow policy
+func (s *diskSpool) push(topic string, payload []byte) error {
+	data, err := json.Marshal(spooledMessage{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+	size := int64(len(data))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if size > s.maxSize {
+		return ErrSpoolFull
+	}
+	for s.size+size > s.maxSize {
+		if s.overflow == config.SpoolOverflowDropNewest {
+			return ErrSpoolFull
+		}
+		if err := s.removeOldest(); err != nil {
+			return err
+		}
+	}
+	seq := s.nextSeq
+	if err := os.WriteFile(s.path(seq), data, 0600); err != nil {
+		return err
+	}
+	s.nextSeq++
+	s.entries = append(s.entries, spoolEntry{seq: seq, size: size})
+	s.size += size
+	return nil
+}
+
+func (s *diskSpool) removeOldest() error {
+	oldest := s.entries[0]
+	if err := os.Remove(s.path(oldest.seq)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.entries = s.entries[1:]
+	s.size -= oldest.size
+	return nil
+}
+
+// removeEntry removes the message from the spool, unless it was already dropped to make room for newer ones
+func (s *diskSpool) removeEntry(seq uint64) error {
+	for i, entry := range s.entries {
+		if entry.seq != seq {
+			continue
+		}
+		if err := os.Remove(s.path(seq)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		s.entries = append(s.entries[:i], s.entries[i+1:]...)
+		s.size -= entry.size
+		return nil
+	}
+	return nil
+}
+
+// drain publishes the spooled messages in order, removing each one once published. It stops on the
+// first failed publish, leaving it and the following messages for the next drain. The spool is not locked
+// while publishing, so the messages pushed meanwhile are not held up, and a drain already running is not joined
+func (s *diskSpool) drain(publish func(topic string, payload []byte) error) (int, error) {
+	s.mu.Lock()
+	if s.draining {
+		s.mu.Unlock()
+		return 0, nil
+	}
+	s.draining = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.draining = false
+		s.mu.Unlock()
+	}()
+
+	sent := 0
+	for {
+		s.mu.Lock()
+		if len(s.entries) == 0 {
+			s.mu.Unlock()
+			return sent, nil
+		}
+		seq := s.entries[0].seq
+		data, err := os.ReadFile(s.path(seq))
+		s.mu.Unlock()
+		if err != nil && !os.IsNotExist(err) {
+			return sent, err
+		}
+		if err == nil {
+			var msg spooledMessage
+			// an unreadable message would block the spool forever, it is dropped
+			if json.Unmarshal(data, &msg) == nil {
+				if err := publish(msg.Topic, msg.Payload); err != nil {
+					return sent, err
+				}
+				sent++
+			}
+		}
+		s.mu.Lock()
+		err = s.removeEntry(seq)
+		s.mu.Unlock()
+		if err != nil {
+			return sent, err
+		}
+	}
+}
+
+// len returns the number of spooled messages
+func (s *diskSpool) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
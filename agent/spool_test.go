@@ -0,0 +1,129 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	topic   string
+	payload string
+}
+
+func drainAll(t *testing.T, s *diskSpool) []published {
+	var got []published
+	_, err := s.drain(func(topic string, payload []byte) error {
+		got = append(got, published{topic: topic, payload: string(payload)})
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func TestDiskSpool_DrainInOrder(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newDiskSpool(config.SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+
+	require.NoError(t, s.push("heartbeats", []byte("hb1")))
+	require.NoError(t, s.push("capabilities", []byte("cap")))
+	require.NoError(t, s.push("heartbeats", []byte("hb2")))
+
+	// a failed publish keeps the message and the following ones for the next drain
+	sent, err := s.drain(func(topic string, payload []byte) error {
+		if string(payload) == "cap" {
+			return errors.New("not connected")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 2, s.len())
+
+	// spooled messages survive a restart of the agent
+	reopened, err := newDiskSpool(config.SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	require.NoError(t, reopened.push("heartbeats", []byte("hb3")))
+	assert.Equal(t, []published{
+		{topic: "capabilities", payload: "cap"},
+		{topic: "heartbeats", payload: "hb2"},
+		{topic: "heartbeats", payload: "hb3"},
+	}, drainAll(t, reopened))
+	assert.Equal(t, 0, reopened.len())
+	assert.Equal(t, int64(0), reopened.size)
+}
+
+func TestDiskSpool_Overflow(t *testing.T) {
+	// each spooled message takes 34 bytes on disk, two of them fit in the spool
+	cases := map[string]struct {
+		overflow string
+		want     []string
+		err      error
+	}{
+		"drop oldest": {
+			overflow: config.SpoolOverflowDropOldest,
+			want:     []string{"msg2", "msg3"},
+		},
+		"drop newest": {
+			overflow: config.SpoolOverflowDropNewest,
+			want:     []string{"msg1", "msg2"},
+			err:      ErrSpoolFull,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			s, err := newDiskSpool(config.SpoolConfig{Dir: t.TempDir(), MaxSize: 100, Overflow: tc.overflow})
+			require.NoError(t, err)
+			for i := 1; i <= 2; i++ {
+				require.NoError(t, s.push("t", []byte(fmt.Sprintf("msg%d", i))))
+			}
+			assert.Equal(t, tc.err, s.push("t", []byte("msg3")))
+
+			var got []string
+			for _, msg := range drainAll(t, s) {
+				got = append(got, msg.payload)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDiskSpool_InvalidOverflow(t *testing.T) {
+	_, err := newDiskSpool(config.SpoolConfig{Dir: t.TempDir(), Overflow: "drop_all"})
+	assert.Error(t, err)
+}
+
+func TestDiskSpool_DrainUnlocked(t *testing.T) {
+	s, err := newDiskSpool(config.SpoolConfig{Dir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, s.push("logs", []byte("log1")))
+
+	// the spool is usable while a message is published, and a second drain does not send it again
+	var got []published
+	sent, err := s.drain(func(topic string, payload []byte) error {
+		got = append(got, published{topic: topic, payload: string(payload)})
+		if string(payload) == "log1" {
+			require.NoError(t, s.push("logs", []byte("log2")))
+			nested, err := s.drain(func(string, []byte) error {
+				t.Fatal("concurrent drain published a message")
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 0, nested)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []published{
+		{topic: "logs", payload: "log1"},
+		{topic: "logs", payload: "log2"},
+	}, got)
+	assert.Equal(t, 0, s.len())
+}
@@ -0,0 +1,127 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/orb-community/orb/buildinfo"
+	"github.com/orb-community/orb/fleet"
+	"go.uber.org/zap"
+)
+
+// results of an agent update RPC reported on the heartbeat, a failure is reported as "failed: <error>"
+const (
+	updateResultCurrent     = "current"
+	updateResultAvailable   = "available"
+	updateResultUnavailable = "unavailable"
+	updateResultUpdated     = "updated"
+)
+
+// defaultUpdateTimeout bounds the update command when no timeout is configured
+const defaultUpdateTimeout = 5 * time.Minute
+
+// handleAgentUpdate checks whether the requested version is available and, when the self-update is configured,
+// switches to it and stops the agent so it is restarted on the new version. The result is reported on the heartbeat
+func (a *orbAgent) handleAgentUpdate(ctx context.Context, payload fleet.AgentUpdateRPCPayload) {
+	result := a.checkUpdate(payload)
+	if result.Result == updateResultAvailable && a.updateConfigured() {
+		if err := a.runUpdate(ctx, payload.Target, payload.Version); err != nil {
+			a.logger.Error("agent update failed", zap.String("target", payload.Target), zap.String("version", payload.Version), zap.Error(err))
+			result.Result = fmt.Sprintf("failed: %v", err)
+		} else {
+			result.Result = updateResultUpdated
+		}
+	}
+	a.updateResult.Store(result)
+	if result.Result != updateResultUpdated {
+		a.logger.Info("agent update checked", zap.String("target", payload.Target), zap.String("version", payload.Version),
+			zap.String("result", result.Result), zap.String("reason", payload.Reason))
+		return
+	}
+	a.logger.Info("agent updated, stopping to restart on the new version", zap.String("target", payload.Target),
+		zap.String("version", payload.Version), zap.String("reason", payload.Reason))
+	// the result is reported before stopping, the agent comes back on the new version
+	a.sendSingleHeartbeat(ctx, time.Now(), a.onlineState())
+	a.Stop(ctx)
+}
+
+// checkUpdate reports whether the version of the update target is the running one or is available in the update dir
+func (a *orbAgent) checkUpdate(payload fleet.AgentUpdateRPCPayload) *fleet.AgentUpdateResult {
+	result := &fleet.AgentUpdateResult{Target: payload.Target, Version: payload.Version}
+	if !validUpdateName(payload.Target) || !validUpdateName(payload.Version) {
+		result.Result = "failed: invalid update target or version"
+		return result
+	}
+	running, err := a.runningVersion(payload.Target)
+	if err != nil {
+		result.Result = fmt.Sprintf("failed: %v", err)
+		return result
+	}
+	switch {
+	case running == payload.Version:
+		result.Available = true
+		result.Result = updateResultCurrent
+	case a.versionInstalled(payload.Target, payload.Version):
+		result.Available = true
+		result.Result = updateResultAvailable
+	default:
+		result.Result = updateResultUnavailable
+	}
+	return result
+}
+
+// runningVersion returns the version of the agent or of the backend named by the update target
+func (a *orbAgent) runningVersion(target string) (string, error) {
+	if target == fleet.AgentUpdateTargetAgent {
+		return buildinfo.GetVersion(), nil
+	}
+	be, ok := a.backends[target]
+	if !ok {
+		return "", errors.New("unknown update target: " + target)
+	}
+	return be.Version()
+}
+
+func (a *orbAgent) versionInstalled(target string, version string) bool {
+	dir := a.config.OrbAgent.Update.Dir
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, target, version))
+	return err == nil
+}
+
+// updateConfigured reports whether the agent opted in the self-update
+func (a *orbAgent) updateConfigured() bool {
+	return a.config.OrbAgent.Update.Enable && a.config.OrbAgent.Update.Command != ""
+}
+
+// runUpdate runs the configured update command, which switches the target to the version
+func (a *orbAgent) runUpdate(ctx context.Context, target string, version string) error {
+	timeout := a.config.OrbAgent.Update.Timeout
+	if timeout <= 0 {
+		timeout = defaultUpdateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, a.config.OrbAgent.Update.Command, target, version).CombinedOutput()
+	if err != nil {
+		a.logger.Debug("agent update command output", zap.ByteString("output", out))
+		return err
+	}
+	return nil
+}
+
+// validUpdateName rejects the targets and versions which would escape the update dir
+func validUpdateName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
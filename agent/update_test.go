@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orb-community/orb/agent/config"
+	"github.com/orb-community/orb/buildinfo"
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckUpdate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, fleet.AgentUpdateTargetAgent, "9.9.9"), 0o755))
+
+	a := &orbAgent{logger: zap.NewNop()}
+	a.config.OrbAgent.Update = config.Update{Dir: dir}
+
+	tests := []struct {
+		name      string
+		payload   fleet.AgentUpdateRPCPayload
+		available bool
+		result    string
+	}{
+		{"running version", fleet.AgentUpdateRPCPayload{Target: "agent", Version: buildinfo.GetVersion()}, true, updateResultCurrent},
+		{"installed version", fleet.AgentUpdateRPCPayload{Target: "agent", Version: "9.9.9"}, true, updateResultAvailable},
+		{"missing version", fleet.AgentUpdateRPCPayload{Target: "agent", Version: "8.8.8"}, false, updateResultUnavailable},
+		{"unknown backend", fleet.AgentUpdateRPCPayload{Target: "pktvisor", Version: "4.2.0"}, false, "failed: unknown update target: pktvisor"},
+		{"version escaping the update dir", fleet.AgentUpdateRPCPayload{Target: "agent", Version: "../agent"}, false, "failed: invalid update target or version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := a.checkUpdate(tt.payload)
+			assert.Equal(t, tt.available, result.Available)
+			assert.Equal(t, tt.result, result.Result)
+		})
+	}
+}
+
+func TestHandleAgentUpdate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, fleet.AgentUpdateTargetAgent, "9.9.9"), 0o755))
+	payload := fleet.AgentUpdateRPCPayload{Target: "agent", Version: "9.9.9"}
+
+	a := &orbAgent{logger: zap.NewNop()}
+	a.config.OrbAgent.Update = config.Update{Dir: dir, Command: "false"}
+
+	// without opting in, only the availability is reported
+	a.handleAgentUpdate(context.Background(), payload)
+	result := a.updateResult.Swap(nil)
+	require.NotNil(t, result)
+	assert.Equal(t, updateResultAvailable, result.Result)
+
+	a.config.OrbAgent.Update.Enable = true
+	a.handleAgentUpdate(context.Background(), payload)
+	result = a.updateResult.Swap(nil)
+	require.NotNil(t, result)
+	assert.True(t, result.Available)
+	assert.Equal(t, "failed: exit status 1", result.Result)
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/orb-community/orb/agent/backend/otel"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/orb-community/orb/agent"
 	"github.com/orb-community/orb/agent/backend/pktvisor"
+	"github.com/orb-community/orb/agent/backend/promscrape"
 	"github.com/orb-community/orb/agent/config"
 	"github.com/orb-community/orb/buildinfo"
 	"github.com/spf13/cobra"
@@ -35,6 +37,7 @@ var (
 func init() {
 	pktvisor.Register()
 	otel.Register()
+	promscrape.Register()
 }
 
 func Version(_ *cobra.Command, _ []string) {
@@ -91,6 +94,26 @@ func Run(_ *cobra.Command, _ []string) {
 		}
 	}
 
+	// pktvisord reads its own sections of the config file, which it cannot do once the file is encrypted, so
+	// they are handed to it decrypted on a file only readable by the agent user
+	decrypted := make(map[string]string)
+	for name, be := range configData.OrbAgent.Backends {
+		file, ok := be["config_file"]
+		if !ok || !isEncryptedFile(file) {
+			continue
+		}
+		if _, ok := decrypted[file]; !ok {
+			plain, err := config.WriteDecryptedSections(file, "", pktvisorConfigSections...)
+			if err != nil {
+				logger.Error("failed to decrypt backend config file", zap.String("backend", name), zap.Error(err))
+				os.Exit(1)
+			}
+			defer os.Remove(plain)
+			decrypted[file] = plain
+		}
+		be["config_file"] = decrypted[file]
+	}
+
 	// new agent
 	a, err := agent.New(logger, configData)
 	if err != nil {
@@ -131,7 +154,6 @@ func mergeOrError(path string) {
 
 	v := viper.New()
 	if len(path) > 0 {
-		v.SetConfigFile(path)
 		v.SetConfigType("yaml")
 	}
 
@@ -148,14 +170,39 @@ func mergeOrError(path string) {
 	v.SetDefault("orb.cloud.mqtt.id", "")
 	v.SetDefault("orb.cloud.mqtt.key", "")
 	v.SetDefault("orb.cloud.mqtt.channel_id", "")
+	v.SetDefault("orb.cloud.mqtt.client_id_suffix", "")
+	v.SetDefault("orb.cloud.mqtt.dead_letter_topic", "")
+	v.SetDefault("orb.cloud.mqtt.connect_timeout", "30s")
+	v.SetDefault("orb.cloud.mqtt.signing_key", "")
+	v.SetDefault("orb.cloud.mqtt.max_rpc_payload_size", 4*1024*1024)
+	v.SetDefault("orb.cloud.mqtt.qos.rpc", 1)
+	v.SetDefault("orb.cloud.mqtt.qos.heartbeat", 1)
+	v.SetDefault("orb.cloud.mqtt.qos.log", 1)
+	v.SetDefault("orb.cloud.mqtt.log_sampling.rate_limit", 0)
+	v.SetDefault("orb.cloud.mqtt.log_sampling.sample_ratio", 0.1)
+	v.SetDefault("orb.cloud.capabilities_retry.initial_backoff", "1s")
+	v.SetDefault("orb.cloud.capabilities_retry.max_backoff", "30s")
+	v.SetDefault("orb.cloud.capabilities_retry.deadline", "5m")
+	v.SetDefault("orb.cloud.spool.enable", false)
+	v.SetDefault("orb.cloud.spool.dir", "./orb-agent-spool")
+	v.SetDefault("orb.cloud.spool.max_size", 10485760)
+	v.SetDefault("orb.cloud.spool.overflow", "drop_oldest")
 	v.SetDefault("orb.db.file", "./orb-agent.db")
 	v.SetDefault("orb.tls.verify", true)
+	v.SetDefault("orb.tls.min_version", "1.2")
 	v.SetDefault("orb.otel.host", "localhost")
 	v.SetDefault("orb.otel.port", 0)
 	v.SetDefault("orb.debug.enable", Debug)
+	v.SetDefault("orb.heartbeat.jitter", 0.1)
+	v.SetDefault("orb.heartbeat.jitter_each_beat", false)
+	v.SetDefault("orb.metrics.enable", false)
+	v.SetDefault("orb.metrics.address", "localhost:10870")
 
 	if len(path) > 0 {
-		cobra.CheckErr(v.ReadInConfig())
+		// encrypted config files are decrypted in memory, the plaintext ones are still loaded as they are
+		data, err := config.ReadFile(path)
+		cobra.CheckErr(err)
+		cobra.CheckErr(v.ReadConfig(bytes.NewReader(data)))
 	}
 
 	var fZero float64
@@ -175,6 +222,7 @@ func mergeOrError(path string) {
 	backendVarsFunction := make(map[string]func(*viper.Viper))
 	backendVarsFunction["pktvisor"] = pktvisor.RegisterBackendSpecificVariables
 	backendVarsFunction["otel"] = otel.RegisterBackendSpecificVariables
+	backendVarsFunction[promscrape.BackendName] = promscrape.RegisterBackendSpecificVariables
 
 	// check if backends are configured
 	// if not then add pktvisor as default
@@ -191,6 +239,29 @@ func mergeOrError(path string) {
 	cobra.CheckErr(viper.MergeConfigMap(v.AllSettings()))
 }
 
+// pktvisorConfigSections are the sections of the agent config read by pktvisord
+var pktvisorConfigSections = []string{"version", "visor"}
+
+func isEncryptedFile(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && config.IsEncrypted(data)
+}
+
+// EncryptConfig writes to stdout the config file encrypted with the key from the environment
+func EncryptConfig(_ *cobra.Command, args []string) {
+	data, err := os.ReadFile(args[0])
+	cobra.CheckErr(err)
+	if config.IsEncrypted(data) {
+		cobra.CheckErr("config file is already encrypted: " + args[0])
+	}
+	key, err := config.LoadKey()
+	cobra.CheckErr(err)
+	encrypted, err := config.Encrypt(key, data)
+	cobra.CheckErr(err)
+	_, err = os.Stdout.Write(encrypted)
+	cobra.CheckErr(err)
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	// set defaults first
@@ -225,10 +296,21 @@ func main() {
 		Run:   Run,
 	}
 
+	encryptConfigCmd := &cobra.Command{
+		Use:   "encrypt-config [file]",
+		Short: "Encrypt a config file",
+		Long: `Encrypt a config file with the base64 encoded 256 bits key set on ` + config.ConfigKeyEnv + ` or on the file
+named by ` + config.ConfigKeyFileEnv + `, e.g. generated by "openssl rand -base64 32". The encrypted config is
+written to stdout, and the agent decrypts it at startup with the same key`,
+		Args: cobra.ExactArgs(1),
+		Run:  EncryptConfig,
+	}
+
 	runCmd.Flags().StringSliceVarP(&cfgFiles, "config", "c", []string{}, "Path to config files (may be specified multiple times)")
 	runCmd.PersistentFlags().BoolVarP(&Debug, "debug", "d", false, "Enable verbose (debug level) output")
 
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(encryptConfigCmd)
 	_ = rootCmd.Execute()
 }
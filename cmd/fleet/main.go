@@ -31,6 +31,7 @@ import (
 	fleetgrpc "github.com/orb-community/orb/fleet/api/grpc"
 	fleethttp "github.com/orb-community/orb/fleet/api/http"
 	"github.com/orb-community/orb/fleet/backend/pktvisor"
+	"github.com/orb-community/orb/fleet/backend/promscrape"
 	"github.com/orb-community/orb/fleet/pb"
 	"github.com/orb-community/orb/fleet/postgres"
 	rediscons "github.com/orb-community/orb/fleet/redis/consumer"
@@ -50,6 +51,7 @@ import (
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
@@ -71,6 +73,7 @@ func main() {
 	jCfg := config.LoadJaegerConfig(envPrefix)
 	policiesGRPCCfg := config.LoadGRPCConfig("orb", "policies")
 	fleetGRPCCfg := config.LoadGRPCConfig("orb", "fleet")
+	signingCfg := config.LoadMessageSigningConfig(envPrefix)
 
 	// logger
 	var logger *zap.Logger
@@ -140,7 +143,15 @@ func main() {
 	agentRepo := postgres.NewAgentRepository(db, logger)
 	agentGroupRepo := postgres.NewAgentGroupRepository(db, logger)
 
-	commsSvc := fleet.NewFleetCommsService(logger, policiesGRPCClient, agentRepo, agentGroupRepo, pubSub)
+	if signingCfg.Require && signingCfg.Secret == "" {
+		logger.Error("agent message signing is required but no secret is set")
+		os.Exit(1)
+	}
+	// the agent messages are consumed by a queue group as well, so the nonces and the signers are shared over redis
+	verifier := fleet.NewMessageVerifier(signingCfg.Secret, signingCfg.Require, signingCfg.MaxSkew, redisprod.NewSigningStore(esClient))
+	// the heartbeats are consumed by a queue group, so the replicas share the events of their subscribers over redis
+	heartbeatRelay := redisprod.NewHeartbeatRelay(esClient, logger)
+	commsSvc := fleet.NewFleetCommsService(logger, policiesGRPCClient, agentRepo, agentGroupRepo, pubSub, verifier, heartbeatRelay)
 	commsSvc = fleet.CommsMetricsMiddleware(
 		commsSvc,
 		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
@@ -158,7 +169,7 @@ func main() {
 
 	aDone := make(chan bool)
 
-	svc := newFleetService(authGRPCClient, db, logger, esClient, sdkCfg, agentRepo, agentGroupRepo, commsSvc, aDone)
+	svc := newFleetService(authGRPCClient, db, logger, esClient, sdkCfg, agentRepo, agentGroupRepo, commsSvc, signingCfg.Secret, aDone)
 	defer commsSvc.Stop()
 
 	errs := make(chan error, 2)
@@ -231,7 +242,7 @@ func initJaeger(svcName, url string, logger *zap.Logger) (opentracing.Tracer, io
 	return tracer, closer
 }
 
-func newFleetService(auth mainflux.AuthServiceClient, db *sqlx.DB, logger *zap.Logger, esClient *r.Client, sdkCfg config.MFSDKConfig, agentRepo fleet.AgentRepository, agentGroupRepo fleet.AgentGroupRepository, agentComms fleet.AgentCommsService, aDone chan bool) fleet.Service {
+func newFleetService(auth mainflux.AuthServiceClient, db *sqlx.DB, logger *zap.Logger, esClient *r.Client, sdkCfg config.MFSDKConfig, agentRepo fleet.AgentRepository, agentGroupRepo fleet.AgentGroupRepository, agentComms fleet.AgentCommsService, signingSecret string, aDone chan bool) fleet.Service {
 
 	config := mfsdk.Config{
 		ThingsURL: sdkCfg.ThingsURL,
@@ -241,8 +252,9 @@ func newFleetService(auth mainflux.AuthServiceClient, db *sqlx.DB, logger *zap.L
 
 	pktvisor.Register(auth, agentRepo)
 	otel.Register(auth, agentRepo)
+	promscrape.Register()
 
-	svc := fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, aDone)
+	svc := fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, signingSecret, aDone)
 	svc = redisprod.NewEventStoreMiddleware(svc, esClient, logger)
 	svc = fleethttp.NewLoggingMiddleware(svc, logger)
 	svc = fleethttp.MetricsMiddleware(
@@ -321,6 +333,8 @@ func startGRPCServer(svc fleet.Service, tracer opentracing.Tracer, cfg config.GR
 		os.Exit(1)
 	}
 
+	// the connections are pinged so the heartbeat streams of the clients gone away are closed
+	keepaliveOpt := grpc.KeepaliveParams(keepalive.ServerParameters{Time: fleetgrpc.HeartbeatStreamKeepalive})
 	var server *grpc.Server
 	if cfg.ServerCert != "" || cfg.ServerKey != "" {
 		creds, err := credentials.NewServerTLSFromFile(cfg.ServerCert, cfg.ServerKey)
@@ -330,10 +344,10 @@ func startGRPCServer(svc fleet.Service, tracer opentracing.Tracer, cfg config.GR
 		}
 		logger.Info(fmt.Sprintf("gRPC service started using https on port %s with cert %s key %s",
 			cfg.Port, cfg.ServerCert, cfg.ServerKey))
-		server = grpc.NewServer(grpc.Creds(creds))
+		server = grpc.NewServer(grpc.Creds(creds), keepaliveOpt)
 	} else {
 		logger.Info(fmt.Sprintf("gRPC service started using http on port %s", cfg.Port))
-		server = grpc.NewServer()
+		server = grpc.NewServer(keepaliveOpt)
 	}
 
 	pb.RegisterFleetServiceServer(server, fleetgrpc.NewServer(tracer, svc))
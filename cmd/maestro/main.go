@@ -53,6 +53,10 @@ func main() {
 	sinksGRPCCfg := config.LoadGRPCConfig("orb", "sinks")
 	dbCfg := config.LoadPostgresConfig(envPrefix, svcName)
 	encryptionKey := config.LoadEncryptionKey(sinkPrefix)
+	sinkErrorCfg := config.LoadSinkErrorConfig(envPrefix)
+	sinkWriteCfg := config.LoadSinkWriteConfig(envPrefix)
+	sinkConnPoolCfg := config.LoadSinkConnPoolConfig(envPrefix)
+	tlsCfg := config.LoadTLSConfig(envPrefix)
 	svcCfg.EncryptionKey = encryptionKey.Key
 
 	// logger
@@ -116,10 +120,14 @@ func main() {
 	}
 	sinksGRPCClient := sinksgrpc.NewClient(tracer, sinksGRPCConn, sinksGRPCTimeout, logger)
 	otelCfg := config.LoadOtelConfig(envPrefix)
+	if _, err := config.ParseTLSMinVersion(tlsCfg.MinVersion); err != nil {
+		logger.Error("Invalid TLS configuration", zap.Error(err))
+		os.Exit(1)
+	}
 	db := connectToDB(dbCfg, logger)
 	defer db.Close()
 
-	svc := maestro.NewMaestroService(logger, streamEsClient, sinkerEsClient, sinksGRPCClient, otelCfg, db, svcCfg)
+	svc := maestro.NewMaestroService(logger, streamEsClient, sinkerEsClient, sinksGRPCClient, otelCfg, db, svcCfg, sinkErrorCfg, sinkWriteCfg, sinkConnPoolCfg, tlsCfg)
 	errs := make(chan error, 2)
 
 	mainContext, mainCancelFunction := context.WithCancel(context.Background())
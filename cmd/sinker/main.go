@@ -9,6 +9,8 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-redis/redis/v8"
@@ -19,6 +21,7 @@ import (
 	"github.com/orb-community/orb/pkg/config"
 	policiesgrpc "github.com/orb-community/orb/policies/api/grpc"
 	"github.com/orb-community/orb/sinker"
+	"github.com/orb-community/orb/sinker/redis/producer"
 	sinksgrpc "github.com/orb-community/orb/sinks/api/grpc"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -60,6 +63,8 @@ func main() {
 	sinksGRPCCfg := config.LoadGRPCConfig("orb", "sinks")
 	otelCfg := config.LoadOtelConfig(envPrefix)
 	inMemoryCacheConfig := config.LoadInMemoryCacheConfig(envPrefix)
+	tlsCfg := config.LoadTLSConfig(envPrefix)
+	sinkWriteCfg := config.LoadSinkWriteConfig(envPrefix)
 
 	// main logger
 	var logger *zap.Logger
@@ -95,6 +100,12 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	tlsMinVersion, err := config.ParseTLSMinVersion(tlsCfg.MinVersion)
+	if err != nil {
+		logger.Error("Invalid TLS configuration", zap.Error(err))
+		os.Exit(1)
+	}
+
 	cacheClient := connectToRedis(cacheCfg.URL, cacheCfg.Pass, cacheCfg.DB, logger)
 	defer func(client *redis.Client) {
 		err := client.Close()
@@ -126,7 +137,7 @@ func main() {
 	}
 	defer pubSub.Close()
 
-	policiesGRPCConn := connectToGRPC(policiesGRPCCfg, logger)
+	policiesGRPCConn := connectToGRPC(policiesGRPCCfg, tlsMinVersion, logger)
 	defer func(policiesGRPCConn *grpc.ClientConn) {
 		err := policiesGRPCConn.Close()
 		if err != nil {
@@ -140,7 +151,7 @@ func main() {
 	}
 	policiesGRPCClient := policiesgrpc.NewClient(tracer, policiesGRPCConn, policiesGRPCTimeout)
 
-	fleetGRPCConn := connectToGRPC(fleetGRPCCfg, logger)
+	fleetGRPCConn := connectToGRPC(fleetGRPCCfg, tlsMinVersion, logger)
 	defer func(fleetGRPCConn *grpc.ClientConn) {
 		err := fleetGRPCConn.Close()
 		if err != nil {
@@ -154,7 +165,7 @@ func main() {
 	}
 	fleetGRPCClient := fleetgrpc.NewClient(tracer, fleetGRPCConn, fleetGRPCTimeout)
 
-	sinksGRPCConn := connectToGRPC(sinksGRPCCfg, logger)
+	sinksGRPCConn := connectToGRPC(sinksGRPCCfg, tlsMinVersion, logger)
 	defer func(sinksGRPCConn *grpc.ClientConn) {
 		err := sinksGRPCConn.Close()
 		if err != nil {
@@ -187,11 +198,33 @@ func main() {
 		Help:      "Number of messages received",
 	}, []string{"method", "agent_id", "subtopic", "channel", "protocol"})
 
+	cacheCounter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "sinker",
+		Subsystem: "bridge",
+		Name:      "cache_lookups",
+		Help:      "Number of lookups in the in memory cache by result, hit or miss",
+	}, []string{"method", "result"})
+
+	droppedWritesCounter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "sinker",
+		Subsystem: "sink",
+		Name:      "dropped_writes",
+		Help:      "Number of writes dropped as the write queue of the sink was full",
+	}, []string{"owner_id", "sink_id"})
+
 	otelEnabled := otelCfg.Enable == "true"
 	otelKafkaUrl := otelCfg.KafkaUrl
+	var otelKafkaTLSMinVersion string
+	if otelCfg.KafkaTLS == "true" {
+		otelKafkaTLSMinVersion = tlsCfg.MinVersion
+		if otelKafkaTLSMinVersion == "" {
+			otelKafkaTLSMinVersion = config.DefaultTLSMinVersion
+		}
+	}
 
 	svc := sinker.New(logger, pubSub, esClient, cacheClient, policiesGRPCClient, fleetGRPCClient, sinksGRPCClient,
-		otelKafkaUrl, otelEnabled, gauge, counter, inputCounter, inMemoryCacheConfig.DefaultExpiration)
+		otelKafkaUrl, otelKafkaTLSMinVersion, otelEnabled, gauge, counter, inputCounter, cacheCounter, inMemoryCacheConfig.DefaultExpiration,
+		sinkWriteCfg, droppedWritesCounter)
 	defer func(svc sinker.Service) {
 		err := svc.Stop()
 		if err != nil {
@@ -201,7 +234,10 @@ func main() {
 
 	errs := make(chan error, 2)
 
-	go startHTTPServer(svcCfg, errs, logger)
+	sinkerKeys := producer.NewSinkerKeyService(logger, cacheClient)
+	stdprometheus.MustRegister(sinker.NewCacheCollector(logger, sinkerKeys))
+
+	go startHTTPServer(svcCfg, errs, logger, sinkerKeys)
 
 	err = svc.Start()
 	if err != nil {
@@ -219,23 +255,24 @@ func main() {
 	logger.Error("sinker service terminated", zap.Error(err))
 }
 
-func makeHandler(svcName string) http.Handler {
+func makeHandler(svcName string, logger *zap.Logger, sinkerKeys producer.SinkerKeyService) http.Handler {
 	r := bone.New()
 	r.GetFunc("/version", buildinfo.Version(svcName))
 	r.Handle("/metrics", promhttp.Handler())
+	r.GetFunc("/admin/cache", sinker.CacheHandler(logger, sinkerKeys))
 	return r
 }
 
-func startHTTPServer(cfg config.BaseSvcConfig, errs chan error, logger *zap.Logger) {
+func startHTTPServer(cfg config.BaseSvcConfig, errs chan error, logger *zap.Logger, sinkerKeys producer.SinkerKeyService) {
 	p := fmt.Sprintf(":%s", cfg.HttpPort)
 	if cfg.HttpServerCert != "" || cfg.HttpServerKey != "" {
 		logger.Info(fmt.Sprintf("Sinker service started using https on port %s with cert %s key %s",
 			cfg.HttpPort, cfg.HttpServerCert, cfg.HttpServerKey))
-		errs <- http.ListenAndServeTLS(p, cfg.HttpServerCert, cfg.HttpServerKey, makeHandler(svcName))
+		errs <- http.ListenAndServeTLS(p, cfg.HttpServerCert, cfg.HttpServerKey, makeHandler(svcName, logger, sinkerKeys))
 		return
 	}
 	logger.Info(fmt.Sprintf("Sinker service started using http on port %s", cfg.HttpPort))
-	errs <- http.ListenAndServe(p, makeHandler(svcName))
+	errs <- http.ListenAndServe(p, makeHandler(svcName, logger, sinkerKeys))
 }
 
 func connectToRedis(URL, pass string, cacheDB string, logger *zap.Logger) *redis.Client {
@@ -252,21 +289,29 @@ func connectToRedis(URL, pass string, cacheDB string, logger *zap.Logger) *redis
 	})
 }
 
-func connectToGRPC(cfg config.GRPCConfig, logger *zap.Logger) *grpc.ClientConn {
+func connectToGRPC(cfg config.GRPCConfig, tlsMinVersion uint16, logger *zap.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
-	tls, err := strconv.ParseBool(cfg.ClientTLS)
+	clientTLS, err := strconv.ParseBool(cfg.ClientTLS)
 	if err != nil {
-		tls = false
+		clientTLS = false
 	}
-	if tls {
+	if clientTLS {
+		// the system roots are trusted unless a CA is given, the minimum version applies to both
+		tlsConfig := &tls.Config{MinVersion: tlsMinVersion}
 		if cfg.CaCerts != "" {
-			tpc, err := credentials.NewClientTLSFromFile(cfg.CaCerts, "")
+			caCerts, err := os.ReadFile(cfg.CaCerts)
 			if err != nil {
 				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
 				os.Exit(1)
 			}
-			opts = append(opts, grpc.WithTransportCredentials(tpc))
+			rootCAs := x509.NewCertPool()
+			if !rootCAs.AppendCertsFromPEM(caCerts) {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: no certificates found in %s", cfg.CaCerts))
+				os.Exit(1)
+			}
+			tlsConfig.RootCAs = rootCAs
 		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
@@ -276,7 +321,7 @@ func connectToGRPC(cfg config.GRPCConfig, logger *zap.Logger) *grpc.ClientConn {
 		logger.Error(fmt.Sprintf("Failed to dial to gRPC service %s: %s", cfg.URL, err))
 		os.Exit(1)
 	}
-	logger.Info(fmt.Sprintf("Dialed to gRPC service %s at %s, TLS? %t", cfg.Service, cfg.URL, tls))
+	logger.Info(fmt.Sprintf("Dialed to gRPC service %s at %s, TLS? %t", cfg.Service, cfg.URL, clientTLS))
 
 	return conn
 }
@@ -26,19 +26,25 @@ import (
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/orb-community/orb/pkg/config"
+	policiesgrpc "github.com/orb-community/orb/policies/api/grpc"
+	policiespb "github.com/orb-community/orb/policies/pb"
 	"github.com/orb-community/orb/sinks"
 	sinksgrpc "github.com/orb-community/orb/sinks/api/grpc"
 	sinkshttp "github.com/orb-community/orb/sinks/api/http"
 	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
 	"github.com/orb-community/orb/sinks/migrate"
 	"github.com/orb-community/orb/sinks/pb"
 	"github.com/orb-community/orb/sinks/postgres"
 	rediscons "github.com/orb-community/orb/sinks/redis/consumer"
 	redisprod "github.com/orb-community/orb/sinks/redis/producer"
+	"github.com/orb-community/orb/sinks/secretstore"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/go-kit/kit/metrics"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	r "github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
@@ -66,7 +72,13 @@ func main() {
 	dbCfg := config.LoadPostgresConfig(envPrefix, svcName)
 	jCfg := config.LoadJaegerConfig(envPrefix)
 	encryptionKey := config.LoadEncryptionKey(envPrefix)
+	secretStoreCfg := config.LoadSecretStoreConfig(envPrefix)
+	backendsCfg := config.LoadBackendsConfig(envPrefix)
+	tagLimitsCfg := config.LoadTagLimitsConfig(envPrefix)
+	rateLimitCfg := config.LoadRateLimitConfig(envPrefix)
+	outboxCfg := config.LoadEventOutboxConfig(envPrefix)
 	sinksGRPCCfg := config.LoadGRPCConfig("orb", "sinks")
+	deleteProtectionCfg := config.LoadDeleteProtectionConfig(envPrefix)
 
 	// logger
 	var logger *zap.Logger
@@ -111,9 +123,46 @@ func main() {
 	}
 	auth := authapi.NewClient(tracer, authConn, authTimeout)
 
-	sinkRepo := postgres.NewSinksRepository(db, logger)
+	// the datasets using a sink are only looked up when the delete protection is on
+	var policiesClient policiespb.PolicyServiceClient
+	if deleteProtectionCfg.Enable {
+		policiesGRPCCfg := config.LoadGRPCConfig("orb", "policies")
+		policiesConn := connectToPolicies(policiesGRPCCfg, logger)
+		defer policiesConn.Close()
+		policiesTimeout, err := time.ParseDuration(policiesGRPCCfg.Timeout)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", policiesGRPCCfg.Timeout, err.Error())
+		}
+		policiesClient = policiesgrpc.NewClient(tracer, policiesConn, policiesTimeout)
+	}
+
+	dbCounter, dbDuration := newDatabaseMetrics()
+	primaryDB := postgres.NewDatabaseMetricsMiddleware(postgres.NewDatabase(db), dbCounter.With("db", "primary"), dbDuration.With("db", "primary"))
+	sinkRepo := postgres.NewSinksRepository(primaryDB, logger)
+	if dbCfg.ReplicaURL != "" {
+		replica := connectToReplicaDB(dbCfg.ReplicaURL, logger)
+		defer replica.Close()
+		replicaDB := postgres.NewDatabaseMetricsMiddleware(postgres.NewDatabase(replica), dbCounter.With("db", "replica"), dbDuration.With("db", "replica"))
+		sinkRepo = postgres.NewSinksRepositoryWithReplica(primaryDB, replicaDB, logger)
+	}
+	if encryptionKey.PerOwner && secretStoreCfg.Type != "" {
+		log.Fatalf("per owner encryption keys can't be enabled along with the %s secret store, the secrets are kept out of the database", secretStoreCfg.Type)
+	}
 	pwdSvc := authentication_type.NewPasswordService(logger, encryptionKey.Key)
-	svc := newSinkService(auth, logger, esClient, sdkCfg, sinkRepo, pwdSvc)
+	if encryptionKey.PerOwner {
+		pwdSvc = authentication_type.NewPerOwnerPasswordService(logger, encryptionKey.Key)
+	}
+	if secretStoreCfg.Type != "" {
+		pwdSvc = authentication_type.NewExternalPasswordService(logger, encryptionKey.Key, newSecretStore(secretStoreCfg, logger))
+	}
+	streamCfg := redisprod.StreamConfig{MaxLen: esCfg.StreamLen, Approx: esCfg.StreamApprox}
+	var outbox sinks.EventOutbox
+	if outboxCfg.Enable {
+		// the outbox keeps the decrypted sink configs of the events, encrypted with the deployment key
+		outbox = postgres.NewEventOutbox(db, authentication_type.NewPasswordService(logger, encryptionKey.Key))
+		go redisprod.ReplayOutbox(context.Background(), esClient, streamCfg, outbox, outboxCfg.ReplayInterval, logger)
+	}
+	svc := newSinkService(auth, logger, esClient, sdkCfg, backendsCfg, tagLimitsCfg, sinkRepo, streamCfg, outbox, pwdSvc, policiesClient)
 	errs := make(chan error, 2)
 
 	plan1 := migrate.NewPlan1(logger, svc, sinkRepo, pwdSvc)
@@ -122,8 +171,13 @@ func main() {
 	if err != nil {
 		log.Fatalf("Migration failed with error %e", err)
 	}
+	if encryptionKey.PerOwner {
+		if err := migrate.NewOwnerCredentials(logger, sinkRepo, pwdSvc).Up(context.Background()); err != nil {
+			logger.Error("failed to re-encrypt some sink credentials with the keys of their owners", zap.Error(err))
+		}
+	}
 
-	go startHTTPServer(tracer, svc, svcCfg, logger, errs)
+	go startHTTPServer(tracer, svc, sinkshttp.NewRateLimiter(auth, rateLimitCfg), svcCfg, logger, errs)
 	go startGRPCServer(svc, tracer, sinksGRPCCfg, logger, errs)
 	go subscribeToSinkerES(svc, esClient, esCfg, logger)
 	go subscribeToMaestroStatusES(svc, esClient, esCfg, logger)
@@ -147,6 +201,16 @@ func connectToDB(cfg config.PostgresConfig, logger *zap.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToReplicaDB(url string, logger *zap.Logger) *sqlx.DB {
+	db, err := postgres.ConnectReplica(url)
+	if err != nil {
+		logger.Error("Failed to connect to postgres read replica", zap.Error(err))
+		os.Exit(1)
+	}
+	logger.Info("Sink listings are served by the postgres read replica")
+	return db
+}
+
 func connectToRedis(redisURL, redisPass, redisDB string, logger *zap.Logger) *r.Client {
 	db, err := strconv.Atoi(redisDB)
 	if err != nil {
@@ -185,7 +249,7 @@ func initJaeger(svcName, url string, logger *zap.Logger) (opentracing.Tracer, io
 	return tracer, closer
 }
 
-func newSinkService(auth mainflux.AuthServiceClient, logger *zap.Logger, esClient *r.Client, sdkCfg config.MFSDKConfig, repoSink sinks.SinkRepository, passwordService authentication_type.PasswordService) sinks.SinkService {
+func newSinkService(auth mainflux.AuthServiceClient, logger *zap.Logger, esClient *r.Client, sdkCfg config.MFSDKConfig, backendsCfg config.BackendsConfig, tagLimitsCfg config.TagLimitsConfig, repoSink sinks.SinkRepository, streamCfg redisprod.StreamConfig, outbox sinks.EventOutbox, passwordService authentication_type.PasswordService, policiesClient policiespb.PolicyServiceClient) sinks.SinkService {
 
 	config := mfsdk.Config{
 		ThingsURL: sdkCfg.ThingsURL,
@@ -193,8 +257,28 @@ func newSinkService(auth mainflux.AuthServiceClient, logger *zap.Logger, esClien
 
 	mfsdk := mfsdk.NewSDK(config)
 
-	svc := sinks.NewSinkService(logger, auth, repoSink, mfsdk, passwordService)
-	svc = redisprod.NewSinkStreamProducerMiddleware(svc, esClient)
+	var enabledBackends []string
+	if backendsCfg.Enabled != "" {
+		enabledBackends = strings.Split(backendsCfg.Enabled, ",")
+	}
+	if backendsCfg.SecretHeaders != "" {
+		otlphttpexporter.SetSecretHeaders(strings.Split(backendsCfg.SecretHeaders, ","))
+	}
+	backend.SetRequireHTTPS(backendsCfg.RequireHTTPS, backendsCfg.AllowInsecureOverride)
+	backend.SetProbeAllowPrivate(backendsCfg.ProbeAllowPrivate)
+	if backendsCfg.NonStable != "" {
+		backend.SetNonStableOptIn(strings.Split(backendsCfg.NonStable, ","))
+	}
+	if err := sinks.SetNamePattern(backendsCfg.NamePattern); err != nil {
+		logger.Fatal("failed to set the sink name pattern", zap.Error(err))
+	}
+	tagLimits := sinks.TagLimits{
+		MaxKeys:        tagLimitsCfg.MaxKeys,
+		MaxKeyLength:   tagLimitsCfg.MaxKeyLength,
+		MaxValueLength: tagLimitsCfg.MaxValueLength,
+	}
+	svc := sinks.NewSinkService(logger, auth, repoSink, mfsdk, passwordService, enabledBackends, tagLimits, rediscons.NewStateChangeStream(esClient), policiesClient)
+	svc = redisprod.NewSinkStreamProducerMiddleware(svc, esClient, streamCfg, outbox, logger)
 	svc = sinkshttp.NewLoggingMiddleware(svc, logger)
 	svc = sinkshttp.MetricsMiddleware(
 		auth,
@@ -215,6 +299,41 @@ func newSinkService(auth mainflux.AuthServiceClient, logger *zap.Logger, esClien
 	return svc
 }
 
+func newDatabaseMetrics() (metrics.Counter, metrics.Histogram) {
+	counter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "orb",
+		Subsystem: "sinks",
+		Name:      "db_operations_total",
+		Help:      "Number of database operations.",
+	}, []string{"db", "operation", "status"})
+	duration := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "orb",
+		Subsystem: "sinks",
+		Name:      "db_operation_duration_seconds",
+		Help:      "Duration of the database operations in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"db", "operation"})
+	return counter, duration
+}
+
+func newHTTPMetrics() *sinkshttp.HTTPMetrics {
+	return sinkshttp.NewHTTPMetrics(
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "orb",
+			Subsystem: "sinks",
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests served.",
+		}, []string{"method", "route", "code"}),
+		kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "orb",
+			Subsystem: "sinks",
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of the HTTP requests in seconds.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	)
+}
+
 func connectToAuth(cfg config.GRPCConfig, logger *zap.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	tls, err := strconv.ParseBool(cfg.ClientTLS)
@@ -244,16 +363,45 @@ func connectToAuth(cfg config.GRPCConfig, logger *zap.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func startHTTPServer(tracer opentracing.Tracer, svc sinks.SinkService, cfg config.BaseSvcConfig, logger *zap.Logger, errs chan error) {
+func connectToPolicies(cfg config.GRPCConfig, logger *zap.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	tls, err := strconv.ParseBool(cfg.ClientTLS)
+	if err != nil {
+		tls = false
+	}
+	if tls {
+		if cfg.CaCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.CaCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.URL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to policies service: %s", err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func startHTTPServer(tracer opentracing.Tracer, svc sinks.SinkService, limiter *sinkshttp.RateLimiter, cfg config.BaseSvcConfig, logger *zap.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.HttpPort)
+	handler := sinkshttp.MakeHandler(tracer, svcName, svc, limiter, newHTTPMetrics())
 	if cfg.HttpServerCert != "" || cfg.HttpServerKey != "" {
 		logger.Info(fmt.Sprintf("Sink service started using https on port %s with cert %s key %s",
 			cfg.HttpPort, cfg.HttpServerCert, cfg.HttpServerKey))
-		errs <- http.ListenAndServeTLS(p, cfg.HttpServerCert, cfg.HttpServerKey, sinkshttp.MakeHandler(tracer, svcName, svc))
+		errs <- http.ListenAndServeTLS(p, cfg.HttpServerCert, cfg.HttpServerKey, handler)
 		return
 	}
 	logger.Info(fmt.Sprintf("Sink service started using http on port %s", cfg.HttpPort))
-	errs <- http.ListenAndServe(p, sinkshttp.MakeHandler(tracer, svcName, svc))
+	errs <- http.ListenAndServe(p, handler)
 }
 
 func startGRPCServer(svc sinks.SinkService, tracer opentracing.Tracer, cfg config.GRPCConfig, logger *zap.Logger, errs chan error) {
@@ -298,3 +446,23 @@ func subscribeToMaestroStatusES(svc sinks.SinkService, client *r.Client, cfg con
 		logger.Error("Bootstrap service failed to subscribe to maestro event sourcing", zap.Error(err))
 	}
 }
+
+func newSecretStore(cfg config.SecretStoreConfig, logger *zap.Logger) authentication_type.SecretStore {
+	switch cfg.Type {
+	case "vault":
+		logger.Info("keeping sink secrets on vault", zap.String("address", cfg.VaultAddress), zap.String("mount", cfg.VaultMount))
+		return secretstore.NewVaultStore(cfg.VaultAddress, cfg.VaultToken, cfg.VaultMount, cfg.Prefix)
+	case "aws":
+		logger.Info("keeping sink secrets on aws secrets manager", zap.String("region", cfg.AWSRegion))
+		store, err := secretstore.NewAWSStore(cfg.AWSRegion, cfg.Prefix)
+		if err != nil {
+			logger.Error("failed to create aws secrets manager client", zap.Error(err))
+			os.Exit(1)
+		}
+		return store
+	default:
+		logger.Error("unknown secret store type, expected vault or aws", zap.String("type", cfg.Type))
+		os.Exit(1)
+		return nil
+	}
+}
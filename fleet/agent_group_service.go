@@ -295,6 +295,20 @@ func (svc fleetService) RemoveAgentGroup(ctx context.Context, token, groupId str
 	return nil
 }
 
+func (svc fleetService) PreviewAgentGroupRemoval(ctx context.Context, token, groupId string) (GroupRemovalPreview, error) {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return GroupRemovalPreview{}, err
+	}
+
+	group, err := svc.agentGroupRepository.RetrieveByID(ctx, groupId, ownerID)
+	if err != nil {
+		return GroupRemovalPreview{}, err
+	}
+
+	return svc.agentComms.PreviewGroupRemoval(ctx, group)
+}
+
 func (svc fleetService) ValidateAgentGroup(ctx context.Context, token string, ag AgentGroup) (AgentGroup, error) {
 	mfOwnerID, err := svc.identify(token)
 	if err != nil {
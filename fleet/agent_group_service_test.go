@@ -94,7 +94,7 @@ func newService(auth mainflux.AuthServiceClient, url string) fleet.Service {
 	mfsdk := mfsdk.NewSDK(config)
 	pktvisor.Register(auth, agentRepo)
 	aDone := make(chan bool)
-	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, aDone)
+	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, "", aDone)
 }
 
 func TestCreateAgentGroup(t *testing.T) {
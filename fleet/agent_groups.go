@@ -22,6 +22,24 @@ type AgentGroup struct {
 	MatchingAgents types.Metadata
 }
 
+// GroupRemovalPreview reports the effects of removing an agent group, computed without removing it
+type GroupRemovalPreview struct {
+	AgentGroupID string
+	// Agents are unsubscribed from the group channel
+	Agents   []Agent
+	Policies []GroupRemovalPolicy
+}
+
+// GroupRemovalPolicy is a policy applied through a removed agent group
+type GroupRemovalPolicy struct {
+	ID        string
+	Name      string
+	DatasetID string
+	// StoppedAgentIDs are the agents which stop running the policy, agents also applying it
+	// through another group keep running it
+	StoppedAgentIDs []string
+}
+
 type PageAgentGroup struct {
 	PageMetadata
 	AgentGroups []AgentGroup
@@ -62,6 +80,8 @@ type AgentGroupService interface {
 	EditAgentGroup(ctx context.Context, token string, ag AgentGroup) (AgentGroup, error)
 	// RemoveAgentGroup Remove a existing agent group by owner an id
 	RemoveAgentGroup(ctx context.Context, token string, id string) error
+	// PreviewAgentGroupRemoval reports the agents and policies affected by removing an agent group, without removing it
+	PreviewAgentGroupRemoval(ctx context.Context, token string, id string) (GroupRemovalPreview, error)
 	// ValidateAgentGroup validate AgentGroup
 	ValidateAgentGroup(ctx context.Context, token string, s AgentGroup) (AgentGroup, error)
 }
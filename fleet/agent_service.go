@@ -10,15 +10,25 @@ package fleet
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"github.com/mainflux/mainflux"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/orb-community/orb/fleet/backend"
 	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
 	"go.uber.org/zap"
 	"strings"
 )
 
+const (
+	// DefaultAgentsPageSize is the page size used by ListAgentsInternal when none is given
+	DefaultAgentsPageSize = 100
+	// MaxAgentsPageSize is the largest page size accepted by ListAgentsInternal
+	MaxAgentsPageSize = 1000
+)
+
 var (
 	ErrCreateAgent = errors.New("failed to create agent")
 
@@ -26,6 +36,9 @@ var (
 	// It can be due to networking error or invalid/unauthorized request.
 	ErrThings = errors.New("failed to receive response from Things service")
 
+	// ErrRPCNotSupported indicates the agent did not advertise the RPC on its capabilities
+	ErrRPCNotSupported = errors.New("agent does not support the requested rpc")
+
 	errCreateThing   = errors.New("failed to create thing")
 	errThingNotFound = errors.New("thing not found")
 )
@@ -96,10 +109,129 @@ func (svc fleetService) ResetAgent(ctx context.Context, token string, agentID st
 	return svc.agentComms.NotifyAgentReset(ctx, agent, true, "Reset initiated from control plane")
 }
 
+func (svc fleetService) ResetAgentBackend(ctx context.Context, token string, agentID string, backendName string) error {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+
+	agent, err := svc.agentRepo.RetrieveByID(ctx, ownerID, agentID)
+	if err != nil {
+		return err
+	}
+	// the backends are named after their instance on the agent, the known types are only checked
+	// for the agents which did not report their backends yet
+	if reported := agent.ReportedBackends(); len(reported) > 0 {
+		if !reported[backendName] {
+			return errors.ErrNotFound
+		}
+	} else if !backend.HaveBackend(backendName) {
+		return errors.ErrNotFound
+	}
+
+	return svc.agentComms.NotifyAgentBackendReset(ctx, agent, backendName, "Backend reset initiated from control plane")
+}
+
+func (svc fleetService) SetAgentMaintenance(ctx context.Context, token string, agentID string, enable bool) error {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+
+	agent, err := svc.agentRepo.RetrieveByID(ctx, ownerID, agentID)
+	if err != nil {
+		return err
+	}
+
+	if enable {
+		return svc.agentComms.NotifyAgentMaintenance(ctx, agent, true, "Maintenance initiated from control plane")
+	}
+	return svc.agentComms.NotifyAgentMaintenance(ctx, agent, false, "Maintenance ended from control plane")
+}
+
+func (svc fleetService) UpdateAgentVersion(ctx context.Context, token string, agentID string, target string, version string) error {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+	if target != AgentUpdateTargetAgent && !backend.HaveBackend(target) {
+		return errors.ErrNotFound
+	}
+
+	agent, err := svc.agentRepo.RetrieveByID(ctx, ownerID, agentID)
+	if err != nil {
+		return err
+	}
+	if !agent.SupportsRPC(AgentUpdateRPCFunc) {
+		return ErrRPCNotSupported
+	}
+
+	return svc.agentComms.NotifyAgentUpdate(ctx, agent, target, version, "Update initiated from control plane")
+}
+
+func (svc fleetService) RequestAgentPolicyInventory(ctx context.Context, token string, agentID string) error {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+
+	agent, err := svc.agentRepo.RetrieveByID(ctx, ownerID, agentID)
+	if err != nil {
+		return err
+	}
+
+	return svc.agentComms.NotifyAgentPolicyInventoryReq(ctx, agent)
+}
+
+func (svc fleetService) RequestAgentEffectiveConfig(ctx context.Context, token string, agentID string) error {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+
+	agent, err := svc.agentRepo.RetrieveByID(ctx, ownerID, agentID)
+	if err != nil {
+		return err
+	}
+
+	return svc.agentComms.NotifyAgentEffectiveConfigReq(ctx, agent)
+}
+
 func (svc fleetService) ViewAgentByIDInternal(ctx context.Context, ownerID string, id string) (Agent, error) {
 	return svc.agentRepo.RetrieveByID(ctx, ownerID, id)
 }
 
+func (svc fleetService) ListAgentsInternal(ctx context.Context, ownerID string, pageToken string, pageSize uint64, tags types.Tags) ([]Agent, string, error) {
+	if ownerID == "" || pageSize > MaxAgentsPageSize {
+		return nil, "", ErrMalformedEntity
+	}
+	if pageSize == 0 {
+		pageSize = DefaultAgentsPageSize
+	}
+
+	afterID, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, "", ErrMalformedEntity
+	}
+
+	// fetch one extra agent to know if there is a next page
+	agents, err := svc.agentRepo.RetrieveAllAfterID(ctx, ownerID, string(afterID), pageSize+1, tags)
+	if err != nil {
+		if errors.Contains(err, errors.ErrMalformedEntity) {
+			return nil, "", ErrMalformedEntity
+		}
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if uint64(len(agents)) > pageSize {
+		agents = agents[:pageSize]
+		nextPageToken = base64.RawURLEncoding.EncodeToString([]byte(agents[pageSize-1].MFThingID))
+	}
+
+	return agents, nextPageToken, nil
+}
+
 func (svc fleetService) ListAgents(ctx context.Context, token string, pm PageMetadata) (Page, error) {
 	res, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -127,6 +259,9 @@ func (svc fleetService) CreateAgent(ctx context.Context, token string, a Agent)
 
 	a.MFThingID = mfThing.ID
 	a.MFKeyID = mfThing.Key
+	if svc.signingSecret != "" {
+		a.SigningKey = hex.EncodeToString(AgentSigningKey(svc.signingSecret, mfThing.ID))
+	}
 
 	// create main Agent RPC Channel
 	mfChannelID, err := svc.mfsdk.CreateChannel(mfsdk.Channel{
@@ -287,6 +422,40 @@ func (svc fleetService) ViewAgentInfoByChannelIDInternal(ctx context.Context, ch
 	return res, nil
 }
 
+func (svc fleetService) ViewAgentCapabilitiesByChannelIDInternal(ctx context.Context, ownerID string, channelID string) (Agent, error) {
+	info, err := svc.agentRepo.RetrieveAgentInfoByChannelID(ctx, channelID)
+	if err != nil {
+		return Agent{}, err
+	}
+	// the agent info holds no metadata, the capabilities are retrieved along with the agent of the owner
+	if info.MFThingID == "" || info.MFOwnerID != ownerID {
+		return Agent{}, errors.ErrNotFound
+	}
+	return svc.agentRepo.RetrieveByID(ctx, ownerID, info.MFThingID)
+}
+
+func (svc fleetService) SubscribeAgentHeartbeatsInternal(_ context.Context, ownerID string, agentIDs []string) (*HeartbeatSubscription, error) {
+	if ownerID == "" || len(agentIDs) > MaxAgentsPageSize {
+		return nil, ErrMalformedEntity
+	}
+	return svc.agentComms.SubscribeHeartbeats(ownerID, agentIDs), nil
+}
+
+func (svc fleetService) ViewAgentsInfoByChannelIDsInternal(ctx context.Context, channelIDs []string) (map[string]Agent, error) {
+	if len(channelIDs) == 0 || len(channelIDs) > MaxAgentsPageSize {
+		return nil, ErrMalformedEntity
+	}
+	agents, err := svc.agentRepo.RetrieveAgentInfoByChannelIDs(ctx, channelIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]Agent, len(agents))
+	for _, agent := range agents {
+		res[agent.MFChannelID] = agent
+	}
+	return res, nil
+}
+
 func (svc fleetService) GetPolicyState(ctx context.Context, agent Agent) (map[string]interface{}, error) {
 
 	jsonHb, err := json.Marshal(agent.LastHBData)
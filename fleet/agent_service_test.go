@@ -234,6 +234,104 @@ func TestListAgents(t *testing.T) {
 	}
 }
 
+func TestListAgentsInternal(t *testing.T) {
+	users := flmocks.NewAuthService(map[string]string{token: email})
+
+	thingsServer := newThingsServer(newThingsService(users))
+	fleetService := newService(users, thingsServer.URL)
+
+	var ownerID string
+	for i := 0; i < limit; i++ {
+		ag, err := createAgent(t, fmt.Sprintf("my-agent-%d", i), fleetService)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ownerID = ag.MFOwnerID
+	}
+
+	cases := map[string]struct {
+		ownerID   string
+		pageToken string
+		pageSize  uint64
+		tags      types.Tags
+		size      int
+		err       error
+	}{
+		"list first page of agents": {
+			ownerID:  ownerID,
+			pageSize: limit / 2,
+			size:     limit / 2,
+			err:      nil,
+		},
+		"list agents with default page size": {
+			ownerID:  ownerID,
+			pageSize: 0,
+			size:     limit,
+			err:      nil,
+		},
+		"list agents filtered by matching tags": {
+			ownerID:  ownerID,
+			pageSize: limit,
+			tags:     types.Tags{"testkey": "testvalue"},
+			size:     limit,
+			err:      nil,
+		},
+		"list agents filtered by non matching tags": {
+			ownerID:  ownerID,
+			pageSize: limit,
+			tags:     types.Tags{"testkey": "othervalue"},
+			size:     0,
+			err:      nil,
+		},
+		"list agents with page size over the max": {
+			ownerID:  ownerID,
+			pageSize: fleet.MaxAgentsPageSize + 1,
+			size:     0,
+			err:      fleet.ErrMalformedEntity,
+		},
+		"list agents with invalid page token": {
+			ownerID:   ownerID,
+			pageToken: "not a token",
+			pageSize:  limit,
+			size:      0,
+			err:       fleet.ErrMalformedEntity,
+		},
+		"list agents without owner": {
+			ownerID:  "",
+			pageSize: limit,
+			size:     0,
+			err:      fleet.ErrMalformedEntity,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			agents, _, err := fleetService.ListAgentsInternal(context.Background(), tc.ownerID, tc.pageToken, tc.pageSize, tc.tags)
+			assert.Equal(t, tc.size, len(agents), fmt.Sprintf("%s: expected %d got %d", desc, tc.size, len(agents)))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+		})
+	}
+
+	t.Run("walk through every page", func(t *testing.T) {
+		seen := make(map[string]bool)
+		pageToken := ""
+		pages := 0
+		for {
+			agents, next, err := fleetService.ListAgentsInternal(context.Background(), ownerID, pageToken, 3, nil)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			pages++
+			for _, ag := range agents {
+				assert.False(t, seen[ag.MFThingID], fmt.Sprintf("agent %s listed twice", ag.MFThingID))
+				seen[ag.MFThingID] = true
+			}
+			if next == "" {
+				break
+			}
+			pageToken = next
+		}
+		assert.Equal(t, limit, len(seen), fmt.Sprintf("expected %d agents got %d", limit, len(seen)))
+		assert.Equal(t, 4, pages, fmt.Sprintf("expected 4 pages got %d", pages))
+	})
+}
+
 func TestUpdateAgent(t *testing.T) {
 	users := flmocks.NewAuthService(map[string]string{token: email})
 
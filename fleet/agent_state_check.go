@@ -14,12 +14,13 @@ const (
 
 func (svc *fleetService) checkState(t time.Time) {
 	svc.logger.Info("checking for stale agents")
-	count, err := svc.agentRepo.SetStaleStatus(context.Background(), DefaultTimeout)
+	stale, err := svc.agentRepo.SetStaleStatus(context.Background(), DefaultTimeout)
 	if err != nil {
 		svc.logger.Error("failed to change agents status to stale", zap.Error(err))
 	}
-	if count > 0 {
-		svc.logger.Info(fmt.Sprintf("%d agents with more than %v without heartbeats had their state changed to stale", count, DefaultTimeout))
+	if len(stale) > 0 {
+		svc.logger.Info(fmt.Sprintf("%d agents with more than %v without heartbeats had their state changed to stale", len(stale), DefaultTimeout))
+		svc.agentComms.PublishStateChanges(stale, Stale, t)
 	}
 }
 
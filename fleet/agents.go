@@ -18,6 +18,7 @@ const (
 	Stale
 	Removed
 	UpgradeRequired
+	Maintenance
 )
 
 type State int
@@ -29,6 +30,7 @@ var stateMap = [...]string{
 	"stale",
 	"removed",
 	"upgrade_required",
+	"maintenance",
 }
 
 var stateRevMap = map[string]State{
@@ -38,6 +40,7 @@ var stateRevMap = map[string]State{
 	"stale":            Stale,
 	"removed":          Removed,
 	"upgrade_required": UpgradeRequired,
+	"maintenance":      Maintenance,
 }
 
 func (s State) String() string {
@@ -48,10 +51,12 @@ func (s *State) Scan(value interface{}) error { *s = stateRevMap[string(value.([
 func (s State) Value() (driver.Value, error)  { return s.String(), nil }
 
 type Agent struct {
-	Name           types.Identifier
-	MFOwnerID      string
-	MFThingID      string
-	MFKeyID        string
+	Name      types.Identifier
+	MFOwnerID string
+	MFThingID string
+	MFKeyID   string
+	// SigningKey is the hex encoded key the agent signs its messages with, it is only set on creation
+	SigningKey     string
 	MFChannelID    string
 	Created        time.Time
 	OrbTags        *types.Tags
@@ -63,6 +68,56 @@ type Agent struct {
 	MatchingGroups types.Metadata
 }
 
+// RPCFuncsMetadataKey is the agent metadata holding the RPC funcs advertised on the agent capabilities
+const RPCFuncsMetadataKey = "rpc_funcs"
+
+// SignalsMetadataKey is the agent metadata holding the telemetry signals advertised on the agent capabilities
+const SignalsMetadataKey = "signals"
+
+// SupportsRPC reports whether the agent advertised the RPC func on its capabilities. The agents that do not
+// advertise their funcs are older than the field, so the RPCs added after it must not be sent to them
+func (a Agent) SupportsRPC(fn string) bool {
+	switch funcs := a.AgentMetadata[RPCFuncsMetadataKey].(type) {
+	case []string:
+		for _, f := range funcs {
+			if f == fn {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, f := range funcs {
+			if f == fn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReportedBackends returns the names of the backend instances the agent reported on its capabilities and
+// heartbeats, several instances of a backend type are reported under their own names. It is empty for the
+// agents which reported none yet
+func (a Agent) ReportedBackends() map[string]bool {
+	names := make(map[string]bool)
+	for _, reported := range []interface{}{a.AgentMetadata["backends"], a.LastHBData["backend_state"]} {
+		switch backends := reported.(type) {
+		case map[string]BackendInfo:
+			for name := range backends {
+				names[name] = true
+			}
+		case map[string]BackendStateInfo:
+			for name := range backends {
+				names[name] = true
+			}
+		case map[string]interface{}:
+			for name := range backends {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
 // Page contains page related metadata as well as list of agents that
 // belong to this page.
 type Page struct {
@@ -105,12 +160,34 @@ type AgentService interface {
 	ViewAgentBackend(ctx context.Context, token string, name string) (interface{}, error)
 	//ViewAgentInfoByChannelIDInternal return a correspondent ownerID, name and agent tags by a provided channel id
 	ViewAgentInfoByChannelIDInternal(ctx context.Context, channelID string) (Agent, error)
+	// ViewAgentCapabilitiesByChannelIDInternal returns the agent of the owner on the provided channel id, along with its stored capabilities
+	ViewAgentCapabilitiesByChannelIDInternal(ctx context.Context, ownerID string, channelID string) (Agent, error)
+	// SubscribeAgentHeartbeatsInternal subscribes to the heartbeats of the agents of the owner as fleet consumes them, limited to the agentIDs when set
+	SubscribeAgentHeartbeatsInternal(ctx context.Context, ownerID string, agentIDs []string) (*HeartbeatSubscription, error)
+	// ViewAgentsInfoByChannelIDsInternal return the agents of the provided channel ids keyed by channel id, unknown channels are omitted
+	ViewAgentsInfoByChannelIDsInternal(ctx context.Context, channelIDs []string) (map[string]Agent, error)
 	// ResetAgent reset a agent on edge by a provided agent
 	ResetAgent(ct context.Context, token string, agentID string) error
+	// ResetAgentBackend reset a single backend of a agent on edge, keeping its comms and other backends running
+	ResetAgentBackend(ctx context.Context, token string, agentID string, backendName string) error
+	// SetAgentMaintenance puts a agent on edge in maintenance, dropping all its policies while keeping its group
+	// subscriptions, or takes it out of maintenance reloading its policies
+	SetAgentMaintenance(ctx context.Context, token string, agentID string, enable bool) error
+	// UpdateAgentVersion requests a agent on edge to check the availability of a version of itself or of one of its
+	// backends, the agents which opted in the self-update switch to it and restart
+	UpdateAgentVersion(ctx context.Context, token string, agentID string, target string, version string) error
+	// RequestAgentPolicyInventory requests a agent on edge to publish the policies it currently has applied
+	RequestAgentPolicyInventory(ctx context.Context, token string, agentID string) error
+	// RequestAgentEffectiveConfig requests a agent on edge to publish the configuration it is running, its config
+	// file merged with the provisioning from core
+	RequestAgentEffectiveConfig(ctx context.Context, token string, agentID string) error
 	// GetPolicyState get all policies state per agent in a formatted way from a given existent agent
 	GetPolicyState(ctx context.Context, agent Agent) (map[string]interface{}, error)
 	// ViewAgentMatchingGroupsByIDInternal Groups this Agent currently belongs to, according to matching agent and group tags
 	ViewAgentMatchingGroupsByIDInternal(ctx context.Context, agentID string, ownerID string) (MatchingGroups, error)
+	// ListAgentsInternal retrieves a page of the owner agents matching the tags, starting after the page token,
+	// along with the token of the next page (empty on the last page)
+	ListAgentsInternal(ctx context.Context, ownerID string, pageToken string, pageSize uint64, tags types.Tags) ([]Agent, string, error)
 }
 
 type AgentRepository interface {
@@ -137,10 +214,15 @@ type AgentRepository interface {
 	Delete(ctx context.Context, ownerID string, thingID string) error
 	// RetrieveAgentMetadataByOwner retrieves the Metadata having the OwnerID
 	RetrieveAgentMetadataByOwner(ctx context.Context, ownerID string) ([]types.Metadata, error)
-	// SetStaleStatus change status to stale according provided duration without heartbeats
-	SetStaleStatus(ctx context.Context, minutes time.Duration) (int64, error)
+	// SetStaleStatus change status to stale according provided duration without heartbeats, it returns the agents
+	// changed with the state they had before
+	SetStaleStatus(ctx context.Context, minutes time.Duration) ([]Agent, error)
 	// RetrieveAgentInfoByChannelID gRPC version to retrieve ownerID, name and agent tags by a provided channelID
 	RetrieveAgentInfoByChannelID(ctx context.Context, channelID string) (Agent, error)
+	// RetrieveAgentInfoByChannelIDs gRPC version to retrieve ownerID, name and agent tags of the agents having the provided channelIDs
+	RetrieveAgentInfoByChannelIDs(ctx context.Context, channelIDs []string) ([]Agent, error)
+	// RetrieveAllAfterID retrieves up to limit Agents of the owner ordered by ID, having an ID greater than afterID
+	RetrieveAllAfterID(ctx context.Context, owner string, afterID string, limit uint64, tags types.Tags) ([]Agent, error)
 }
 
 type AgentHeartbeatRepository interface {
@@ -0,0 +1,104 @@
+package fleet_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentSupportsRPC(t *testing.T) {
+	// the metadata read back from the database holds the funcs as a generic list
+	var stored types.Metadata
+	err := json.Unmarshal([]byte(`{"rpc_funcs":["agent_reset","agent_backend_reset"]}`), &stored)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		metadata types.Metadata
+		fn       string
+		expected bool
+	}{
+		{
+			desc:     "advertised func",
+			metadata: types.Metadata{fleet.RPCFuncsMetadataKey: []string{fleet.AgentResetRPCFunc, fleet.AgentBackendResetRPCFunc}},
+			fn:       fleet.AgentBackendResetRPCFunc,
+			expected: true,
+		},
+		{
+			desc:     "not advertised func",
+			metadata: types.Metadata{fleet.RPCFuncsMetadataKey: []string{fleet.AgentResetRPCFunc}},
+			fn:       fleet.AgentBackendResetRPCFunc,
+			expected: false,
+		},
+		{
+			desc:     "advertised func read back from the database",
+			metadata: stored,
+			fn:       fleet.AgentBackendResetRPCFunc,
+			expected: true,
+		},
+		{
+			desc:     "agent without advertised funcs",
+			metadata: types.Metadata{"orb_agent": map[string]interface{}{"version": "0.20.0"}},
+			fn:       fleet.AgentResetRPCFunc,
+			expected: false,
+		},
+		{
+			desc:     "agent without metadata",
+			metadata: nil,
+			fn:       fleet.AgentResetRPCFunc,
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			agent := fleet.Agent{AgentMetadata: tc.metadata}
+			assert.Equal(t, tc.expected, agent.SupportsRPC(tc.fn), fmt.Sprintf("%s: expected %t", tc.desc, tc.expected))
+		})
+	}
+}
+
+func TestAgentReportedBackends(t *testing.T) {
+	// the metadata and heartbeats read back from the database hold the backends as generic maps
+	var metadata, heartbeat types.Metadata
+	err := json.Unmarshal([]byte(`{"backends":{"pktvisor-edge":{"version":"4.2.0"}}}`), &metadata)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = json.Unmarshal([]byte(`{"backend_state":{"pktvisor-core":{"state":"running"}}}`), &heartbeat)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		agent    fleet.Agent
+		expected map[string]bool
+	}{
+		{
+			desc: "backends of the capabilities and heartbeats",
+			agent: fleet.Agent{
+				AgentMetadata: types.Metadata{"backends": map[string]fleet.BackendInfo{"otel": {Version: "0.1.0"}}},
+				LastHBData:    types.Metadata{"backend_state": map[string]fleet.BackendStateInfo{"pktvisor": {State: "running"}}},
+			},
+			expected: map[string]bool{"otel": true, "pktvisor": true},
+		},
+		{
+			desc:     "named instances read back from the database",
+			agent:    fleet.Agent{AgentMetadata: metadata, LastHBData: heartbeat},
+			expected: map[string]bool{"pktvisor-edge": true, "pktvisor-core": true},
+		},
+		{
+			desc:     "agent without reported backends",
+			agent:    fleet.Agent{},
+			expected: map[string]bool{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.agent.ReportedBackends(), tc.desc)
+		})
+	}
+}
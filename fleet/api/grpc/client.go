@@ -27,6 +27,12 @@ type grpcClient struct {
 	retrieveAgentGroup           endpoint.Endpoint
 	retrieveOwnerByChannelID     endpoint.Endpoint
 	retrieveAgentInfoByChannelID endpoint.Endpoint
+	listAgents                   endpoint.Endpoint
+	retrieveAgentInfoByChannels  endpoint.Endpoint
+	retrieveAgentPolicies        endpoint.Endpoint
+	retrieveAgentCapabilities    endpoint.Endpoint
+	// the heartbeats are streamed with the generated client, go-kit only serves unary calls
+	streams pb.FleetServiceClient
 }
 
 func (g grpcClient) RetrieveAgent(ctx context.Context, in *pb.AgentByIDReq, opts ...grpc.CallOption) (*pb.AgentRes, error) {
@@ -93,12 +99,90 @@ func (g grpcClient) RetrieveAgentInfoByChannelID(ctx context.Context, in *pb.Age
 	return &pb.AgentInfoRes{OwnerID: ir.ownerID, AgentName: ir.agentName, AgentTags: ir.agentTags, OrbTags: ir.orbTags, AgentGroupIDs: ir.agentGroupIDs}, nil
 }
 
+func (g grpcClient) ListAgents(ctx context.Context, in *pb.ListAgentsReq, opts ...grpc.CallOption) (*pb.ListAgentsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	ar := listAgentsReq{
+		OwnerID:   in.OwnerID,
+		PageToken: in.PageToken,
+		PageSize:  in.PageSize,
+		Tags:      in.Tags,
+	}
+	res, err := g.listAgents(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(listAgentsRes)
+	agents := make([]*pb.AgentRes, 0, len(ir.agents))
+	for _, agent := range ir.agents {
+		agents = append(agents, &pb.AgentRes{Id: agent.id, Name: agent.name, Channel: agent.channel})
+	}
+	return &pb.ListAgentsRes{Agents: agents, NextPageToken: ir.nextPageToken}, nil
+}
+
+func (g grpcClient) RetrieveAgentInfoByChannelIDs(ctx context.Context, in *pb.AgentInfoByChannelIDsReq, opts ...grpc.CallOption) (*pb.AgentInfoByChannelIDsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	ar := accessAgentInfoByChannelIDsReq{ChannelIDs: in.Channels}
+
+	res, err := g.retrieveAgentInfoByChannels(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(agentsInfoRes)
+	return &pb.AgentInfoByChannelIDsRes{Agents: toAgentInfoByChannelPb(ir.agents)}, nil
+}
+
+func (g grpcClient) RetrieveAgentPolicies(ctx context.Context, in *pb.AgentByIDReq, opts ...grpc.CallOption) (*pb.AgentPoliciesRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	ar := accessByIDReq{
+		AgentID: in.AgentID,
+		OwnerID: in.OwnerID,
+	}
+	res, err := g.retrieveAgentPolicies(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(agentPoliciesRes)
+	return &pb.AgentPoliciesRes{Policies: toAgentPoliciesPb(ir.policies)}, nil
+}
+
+func (g grpcClient) RetrieveAgentCapabilities(ctx context.Context, in *pb.AgentCapabilitiesReq, opts ...grpc.CallOption) (*pb.AgentCapabilitiesRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	ar := accessAgentCapabilitiesReq{
+		ChannelID: in.Channel,
+		OwnerID:   in.OwnerID,
+	}
+	res, err := g.retrieveAgentCapabilities(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(agentCapabilitiesRes)
+	return &pb.AgentCapabilitiesRes{AgentID: ir.agentID, Capabilities: ir.capabilities}, nil
+}
+
+// StreamAgentHeartbeats is not bound by the client timeout, the stream lasts until ctx is done
+func (g grpcClient) StreamAgentHeartbeats(ctx context.Context, in *pb.AgentHeartbeatsReq, opts ...grpc.CallOption) (pb.FleetService_StreamAgentHeartbeatsClient, error) {
+	return g.streams.StreamAgentHeartbeats(ctx, in, opts...)
+}
+
 // NewClient returns new gRPC client instance.
 func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Duration) pb.FleetServiceClient {
 	svcName := "fleet.FleetService"
 
 	return &grpcClient{
 		timeout: timeout,
+		streams: pb.NewFleetServiceClient(conn),
 		retrieveAgent: kitot.TraceClient(tracer, "retrieve_agent_by_id")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -131,6 +215,38 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeAgentInfoResponse,
 			pb.AgentInfoRes{},
 		).Endpoint()),
+		listAgents: kitot.TraceClient(tracer, "list_agents")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ListAgents",
+			encodeListAgentsRequest,
+			decodeListAgentsResponse,
+			pb.ListAgentsRes{},
+		).Endpoint()),
+		retrieveAgentInfoByChannels: kitot.TraceClient(tracer, "retrieve_agent_info_by_channel_ids")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveAgentInfoByChannelIDs",
+			encodeRetrieveAgentInfoByChannelIDsRequest,
+			decodeAgentInfoByChannelIDsResponse,
+			pb.AgentInfoByChannelIDsRes{},
+		).Endpoint()),
+		retrieveAgentPolicies: kitot.TraceClient(tracer, "retrieve_agent_policies")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveAgentPolicies",
+			encodeRetrieveAgentRequest,
+			decodeAgentPoliciesResponse,
+			pb.AgentPoliciesRes{},
+		).Endpoint()),
+		retrieveAgentCapabilities: kitot.TraceClient(tracer, "retrieve_agent_capabilities")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveAgentCapabilities",
+			encodeRetrieveAgentCapabilitiesRequest,
+			decodeAgentCapabilitiesResponse,
+			pb.AgentCapabilitiesRes{},
+		).Endpoint()),
 	}
 }
 
@@ -200,3 +316,82 @@ func decodeAgentInfoResponse(ctx context.Context, grpcRes interface{}) (interfac
 		agentGroupIDs: res.GetAgentGroupIDs(),
 	}, nil
 }
+
+func encodeListAgentsRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listAgentsReq)
+	return &pb.ListAgentsReq{
+		OwnerID:   req.OwnerID,
+		PageToken: req.PageToken,
+		PageSize:  req.PageSize,
+		Tags:      req.Tags,
+	}, nil
+}
+
+func decodeListAgentsResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.ListAgentsRes)
+	agents := make([]agentRes, 0, len(res.GetAgents()))
+	for _, agent := range res.GetAgents() {
+		agents = append(agents, agentRes{
+			id:      agent.GetId(),
+			name:    agent.GetName(),
+			channel: agent.GetChannel(),
+		})
+	}
+	return listAgentsRes{
+		agents:        agents,
+		nextPageToken: res.GetNextPageToken(),
+	}, nil
+}
+
+func encodeRetrieveAgentInfoByChannelIDsRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessAgentInfoByChannelIDsReq)
+	return &pb.AgentInfoByChannelIDsReq{
+		Channels: req.ChannelIDs,
+	}, nil
+}
+
+func decodeAgentInfoByChannelIDsResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.AgentInfoByChannelIDsRes)
+	agents := make(map[string]agentInfoRes, len(res.GetAgents()))
+	for channelID, agent := range res.GetAgents() {
+		agents[channelID] = agentInfoRes{
+			ownerID:       agent.GetOwnerID(),
+			agentName:     agent.GetAgentName(),
+			agentTags:     agent.GetAgentTags(),
+			orbTags:       agent.GetOrbTags(),
+			agentGroupIDs: agent.GetAgentGroupIDs(),
+		}
+	}
+	return agentsInfoRes{agents: agents}, nil
+}
+
+func decodeAgentPoliciesResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.AgentPoliciesRes)
+	policies := make([]agentPolicyRes, 0, len(res.GetPolicies()))
+	for _, policy := range res.GetPolicies() {
+		policies = append(policies, agentPolicyRes{
+			id:      policy.GetId(),
+			name:    policy.GetName(),
+			version: policy.GetVersion(),
+			state:   policy.GetState(),
+			backend: policy.GetBackend(),
+		})
+	}
+	return agentPoliciesRes{policies: policies}, nil
+}
+
+func encodeRetrieveAgentCapabilitiesRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessAgentCapabilitiesReq)
+	return &pb.AgentCapabilitiesReq{
+		Channel: req.ChannelID,
+		OwnerID: req.OwnerID,
+	}, nil
+}
+
+func decodeAgentCapabilitiesResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.AgentCapabilitiesRes)
+	return agentCapabilitiesRes{
+		agentID:      res.GetAgentID(),
+		capabilities: res.GetCapabilities(),
+	}, nil
+}
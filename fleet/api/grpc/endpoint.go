@@ -2,6 +2,9 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+
 	"github.com/go-kit/kit/endpoint"
 	"github.com/orb-community/orb/fleet"
 )
@@ -89,3 +92,119 @@ func retrieveAgentInfoByChannelIDEndpoint(svc fleet.Service) endpoint.Endpoint {
 		return res, nil
 	}
 }
+
+func retrieveAgentInfoByChannelIDsEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(accessAgentInfoByChannelIDsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		agents, err := svc.ViewAgentsInfoByChannelIDsInternal(ctx, req.ChannelIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		res := agentsInfoRes{agents: make(map[string]agentInfoRes, len(agents))}
+		for channelID, agent := range agents {
+			matchingGroups, err := svc.ViewAgentMatchingGroupsByIDInternal(ctx, agent.MFThingID, agent.MFOwnerID)
+			if err != nil {
+				return nil, err
+			}
+
+			var groupIDs []string
+			for _, group := range matchingGroups.Groups {
+				groupIDs = append(groupIDs, group.GroupID)
+			}
+
+			info := agentInfoRes{
+				ownerID:       agent.MFOwnerID,
+				agentName:     agent.Name.String(),
+				agentTags:     agent.AgentTags,
+				agentGroupIDs: groupIDs,
+			}
+			if agent.OrbTags != nil {
+				info.orbTags = *agent.OrbTags
+			}
+			res.agents[channelID] = info
+		}
+		return res, nil
+	}
+}
+
+func listAgentsEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(listAgentsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		agents, nextPageToken, err := svc.ListAgentsInternal(ctx, req.OwnerID, req.PageToken, req.PageSize, req.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listAgentsRes{nextPageToken: nextPageToken}
+		for _, agent := range agents {
+			res.agents = append(res.agents, agentRes{
+				id:      agent.MFThingID,
+				name:    agent.Name.String(),
+				channel: agent.MFChannelID,
+			})
+		}
+		return res, nil
+	}
+}
+
+// retrieveAgentPoliciesEndpoint returns the policies the agent reported as applied in its last heartbeat
+func retrieveAgentPoliciesEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(accessByIDReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		agent, err := svc.ViewAgentByIDInternal(ctx, req.OwnerID, req.AgentID)
+		if err != nil {
+			return nil, err
+		}
+		policyState, err := svc.GetPolicyState(ctx, agent)
+		if err != nil {
+			return nil, err
+		}
+		res := agentPoliciesRes{policies: make([]agentPolicyRes, 0, len(policyState))}
+		for policyID, state := range policyState {
+			info, ok := state.(fleet.PolicyStateInfo)
+			if !ok {
+				continue
+			}
+			res.policies = append(res.policies, agentPolicyRes{
+				id:      policyID,
+				name:    info.Name,
+				version: info.Version,
+				state:   info.State,
+				backend: info.Backend,
+			})
+		}
+		sort.Slice(res.policies, func(i, j int) bool {
+			return res.policies[i].id < res.policies[j].id
+		})
+		return res, nil
+	}
+}
+
+// retrieveAgentCapabilitiesEndpoint returns the capabilities last advertised by the agent of the owner on the channel, as JSON
+func retrieveAgentCapabilitiesEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(accessAgentCapabilitiesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		agent, err := svc.ViewAgentCapabilitiesByChannelIDInternal(ctx, req.OwnerID, req.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+		capabilities, err := json.Marshal(agent.AgentMetadata)
+		if err != nil {
+			return nil, err
+		}
+		return agentCapabilitiesRes{agentID: agent.MFThingID, capabilities: capabilities}, nil
+	}
+}
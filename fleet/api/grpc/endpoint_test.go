@@ -0,0 +1,333 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Adapted for Orb project, modifications licensed under MPL v. 2.0:
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/fleet/mocks"
+	"github.com/orb-community/orb/fleet/pb"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	fleetgrpc "github.com/orb-community/orb/fleet/api/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetrieveAgent(t *testing.T) {
+
+	fleetAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(fleetAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := fleetgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	missingID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	otherOwnerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		agentID string
+		ownerID string
+		id      string
+		code    codes.Code
+	}{
+		"retrieve existing agent": {
+			agentID: agent.MFThingID,
+			ownerID: agent.MFOwnerID,
+			id:      agent.MFThingID,
+			code:    codes.OK,
+		},
+		"retrieve non-existent agent": {
+			agentID: missingID.String(),
+			ownerID: agent.MFOwnerID,
+			id:      "",
+			code:    codes.NotFound,
+		},
+		"retrieve agent with malformed owner": {
+			agentID: agent.MFThingID,
+			ownerID: "invalid",
+			id:      "",
+			code:    codes.InvalidArgument,
+		},
+		"retrieve agent of another owner": {
+			agentID: agent.MFThingID,
+			ownerID: otherOwnerID.String(),
+			id:      "",
+			code:    codes.NotFound,
+		},
+		"retrieve agent without owner": {
+			agentID: agent.MFThingID,
+			ownerID: "",
+			id:      "",
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := cli.RetrieveAgent(ctx, &pb.AgentByIDReq{
+				AgentID: tc.agentID,
+				OwnerID: tc.ownerID,
+			})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.id, res.GetId(), fmt.Sprintf("%s: expected %s got %s", desc, tc.id, res.GetId()))
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+		})
+	}
+}
+
+func TestRetrieveAgentInfoByChannelIDs(t *testing.T) {
+
+	fleetAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(fleetAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := fleetgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	missingID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		channels []string
+		agents   map[string]string
+		code     codes.Code
+	}{
+		"retrieve agent info of existing channel": {
+			channels: []string{agent.MFChannelID},
+			agents:   map[string]string{agent.MFChannelID: agent.MFOwnerID},
+			code:     codes.OK,
+		},
+		"retrieve agent info omitting unknown channels": {
+			channels: []string{agent.MFChannelID, missingID.String()},
+			agents:   map[string]string{agent.MFChannelID: agent.MFOwnerID},
+			code:     codes.OK,
+		},
+		"retrieve agent info of unknown channels": {
+			channels: []string{missingID.String()},
+			agents:   map[string]string{},
+			code:     codes.OK,
+		},
+		"retrieve agent info without channels": {
+			channels: []string{},
+			agents:   map[string]string{},
+			code:     codes.InvalidArgument,
+		},
+		"retrieve agent info with empty channel": {
+			channels: []string{agent.MFChannelID, ""},
+			agents:   map[string]string{},
+			code:     codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := cli.RetrieveAgentInfoByChannelIDs(ctx, &pb.AgentInfoByChannelIDsReq{Channels: tc.channels})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+			owners := make(map[string]string)
+			for channelID, info := range res.GetAgents() {
+				owners[channelID] = info.GetOwnerID()
+			}
+			assert.Equal(t, tc.agents, owners, fmt.Sprintf("%s: expected %v got %v", desc, tc.agents, owners))
+		})
+	}
+}
+
+func TestRetrieveAgentPolicies(t *testing.T) {
+
+	fleetAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(fleetAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := fleetgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	otherOwnerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		agentID  string
+		ownerID  string
+		policies []*pb.AgentPolicyRes
+		code     codes.Code
+	}{
+		"retrieve policies of agent with applied policies": {
+			agentID: policyAgent.MFThingID,
+			ownerID: policyAgent.MFOwnerID,
+			policies: []*pb.AgentPolicyRes{
+				{Id: "policy-1", Name: "first", Version: 3, State: "running", Backend: "pktvisor"},
+				{Id: "policy-2", Name: "second", Version: 1, State: "failed_to_apply", Backend: "otel"},
+			},
+			code: codes.OK,
+		},
+		"retrieve policies of agent without policies": {
+			agentID:  agent.MFThingID,
+			ownerID:  agent.MFOwnerID,
+			policies: nil,
+			code:     codes.OK,
+		},
+		"retrieve policies of agent from another owner": {
+			agentID:  policyAgent.MFThingID,
+			ownerID:  otherOwnerID.String(),
+			policies: nil,
+			code:     codes.NotFound,
+		},
+		"retrieve policies of agent without owner": {
+			agentID:  policyAgent.MFThingID,
+			ownerID:  "",
+			policies: nil,
+			code:     codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := cli.RetrieveAgentPolicies(ctx, &pb.AgentByIDReq{
+				AgentID: tc.agentID,
+				OwnerID: tc.ownerID,
+			})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+			require.Len(t, res.GetPolicies(), len(tc.policies))
+			for i, policy := range tc.policies {
+				got := res.GetPolicies()[i]
+				assert.Equal(t, policy.Id, got.GetId(), fmt.Sprintf("%s: expected %s got %s", desc, policy.Id, got.GetId()))
+				assert.Equal(t, policy.Name, got.GetName())
+				assert.Equal(t, policy.Version, got.GetVersion())
+				assert.Equal(t, policy.State, got.GetState())
+				assert.Equal(t, policy.Backend, got.GetBackend())
+			}
+		})
+	}
+}
+
+func TestRetrieveAgentCapabilities(t *testing.T) {
+
+	fleetAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(fleetAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := fleetgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	otherOwnerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	unknownChannelID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		channelID string
+		ownerID   string
+		agentID   string
+		code      codes.Code
+	}{
+		"retrieve capabilities of agent": {
+			channelID: agent.MFChannelID,
+			ownerID:   agent.MFOwnerID,
+			agentID:   agent.MFThingID,
+			code:      codes.OK,
+		},
+		"retrieve capabilities of agent from another owner": {
+			channelID: agent.MFChannelID,
+			ownerID:   otherOwnerID.String(),
+			code:      codes.NotFound,
+		},
+		"retrieve capabilities of unknown channel": {
+			channelID: unknownChannelID.String(),
+			ownerID:   agent.MFOwnerID,
+			code:      codes.NotFound,
+		},
+		"retrieve capabilities without channel": {
+			channelID: "",
+			ownerID:   agent.MFOwnerID,
+			code:      codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := cli.RetrieveAgentCapabilities(ctx, &pb.AgentCapabilitiesReq{
+				Channel: tc.channelID,
+				OwnerID: tc.ownerID,
+			})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+			if tc.code != codes.OK {
+				return
+			}
+			assert.Equal(t, tc.agentID, res.GetAgentID())
+			var capabilities map[string]interface{}
+			require.Nil(t, json.Unmarshal(res.GetCapabilities(), &capabilities))
+			assert.Equal(t, []interface{}{"metrics", "logs", "traces"}, capabilities["signals"])
+			assert.Equal(t, "0.27.0", capabilities["orb_agent"].(map[string]interface{})["version"])
+			assert.Contains(t, capabilities["backends"], "otel")
+		})
+	}
+}
+
+func TestStreamAgentHeartbeats(t *testing.T) {
+	fleetAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(fleetAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := fleetgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	stream, err := cli.StreamAgentHeartbeats(ctx, &pb.AgentHeartbeatsReq{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "stream without owner should be refused")
+
+	stream, err = cli.StreamAgentHeartbeats(ctx, &pb.AgentHeartbeatsReq{OwnerID: agent.MFOwnerID, AgentIDs: []string{agent.MFThingID}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// the events are published until the subscription of the stream receives them
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				mocks.PublishHeartbeat(agentComms, fleet.AgentHeartbeatEvent{Type: fleet.HeartbeatEventHeartbeat, AgentID: policyAgent.MFThingID,
+					OwnerID: policyAgent.MFOwnerID, State: fleet.Online, PreviousState: fleet.Online, Timestamp: time.Now()})
+				mocks.PublishHeartbeat(agentComms, fleet.AgentHeartbeatEvent{Type: fleet.HeartbeatEventStateChange, AgentID: agent.MFThingID,
+					OwnerID: agent.MFOwnerID, ChannelID: agent.MFChannelID, State: fleet.Offline, PreviousState: fleet.Online, Timestamp: time.Now()})
+			}
+		}
+	}()
+
+	event, err := stream.Recv()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, fleet.HeartbeatEventStateChange, event.GetType())
+	assert.Equal(t, agent.MFThingID, event.GetAgentID(), "only the heartbeats of the requested agents should be streamed")
+	assert.Equal(t, agent.MFChannelID, event.GetChannel())
+	assert.Equal(t, "offline", event.GetState())
+	assert.Equal(t, "online", event.GetPreviousState())
+}
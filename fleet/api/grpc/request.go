@@ -1,7 +1,9 @@
 package grpc
 
 import (
+	"github.com/gofrs/uuid"
 	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/pkg/errors"
 )
 
 type accessByIDReq struct {
@@ -14,6 +16,12 @@ func (req accessByIDReq) validate() error {
 		return fleet.ErrMalformedEntity
 	}
 
+	// owners are identified by their UUID, anything else is a malformed request. A well formed owner which does
+	// not own the agent gets the same not found error as for a missing agent, so the agents of others are not disclosed
+	if _, err := uuid.FromString(req.OwnerID); err != nil {
+		return errors.Wrap(fleet.ErrMalformedEntity, err)
+	}
+
 	return nil
 }
 
@@ -51,3 +59,45 @@ func (req accessAgentInfoByChannelIDReq) validate() error {
 	}
 	return nil
 }
+
+type accessAgentInfoByChannelIDsReq struct {
+	ChannelIDs []string
+}
+
+func (req accessAgentInfoByChannelIDsReq) validate() error {
+	if len(req.ChannelIDs) == 0 || len(req.ChannelIDs) > fleet.MaxAgentsPageSize {
+		return fleet.ErrMalformedEntity
+	}
+	for _, channelID := range req.ChannelIDs {
+		if channelID == "" {
+			return fleet.ErrMalformedEntity
+		}
+	}
+	return nil
+}
+
+type listAgentsReq struct {
+	OwnerID   string
+	PageToken string
+	PageSize  uint64
+	Tags      map[string]string
+}
+
+func (req listAgentsReq) validate() error {
+	if req.OwnerID == "" || req.PageSize > fleet.MaxAgentsPageSize {
+		return fleet.ErrMalformedEntity
+	}
+	return nil
+}
+
+type accessAgentCapabilitiesReq struct {
+	ChannelID string
+	OwnerID   string
+}
+
+func (req accessAgentCapabilitiesReq) validate() error {
+	if req.ChannelID == "" || req.OwnerID == "" {
+		return fleet.ErrMalformedEntity
+	}
+	return nil
+}
@@ -25,6 +25,32 @@ type agentInfoRes struct {
 	agentGroupIDs []string
 }
 
+type agentsInfoRes struct {
+	agents map[string]agentInfoRes
+}
+
+type listAgentsRes struct {
+	agents        []agentRes
+	nextPageToken string
+}
+
+type agentPolicyRes struct {
+	id      string
+	name    string
+	version int32
+	state   string
+	backend string
+}
+
+type agentPoliciesRes struct {
+	policies []agentPolicyRes
+}
+
+type agentCapabilitiesRes struct {
+	agentID      string
+	capabilities []byte
+}
+
 type emptyRes struct {
 	err error
 }
@@ -7,22 +7,40 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/orb-community/orb/fleet"
 	"github.com/orb-community/orb/fleet/pb"
+	"github.com/orb-community/orb/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"time"
 )
 
 var _ pb.FleetServiceServer = (*grpcServer)(nil)
 
+// HeartbeatStreamKeepalive is how long a heartbeat stream stays idle before a keepalive event is sent on it, so
+// the consumers tell a quiet fleet from a broken stream
+const HeartbeatStreamKeepalive = 30 * time.Second
+
+// HeartbeatEventKeepalive is the type of the events sent on an idle heartbeat stream
+const HeartbeatEventKeepalive = "keepalive"
+
 type grpcServer struct {
 	pb.UnimplementedFleetServiceServer
 	retrieveAgent                kitgrpc.Handler
 	retrieveAgentGroup           kitgrpc.Handler
 	retrieveOwnerByChannelID     kitgrpc.Handler
 	retrieveAgentInfoByChannelID kitgrpc.Handler
+	listAgents                   kitgrpc.Handler
+	retrieveAgentInfoByChannels  kitgrpc.Handler
+	retrieveAgentPolicies        kitgrpc.Handler
+	retrieveAgentCapabilities    kitgrpc.Handler
+	// the heartbeats are streamed from the service, the go-kit handlers only serve unary calls
+	svc       fleet.Service
+	keepalive time.Duration
 }
 
 func NewServer(tracer opentracing.Tracer, svc fleet.Service) pb.FleetServiceServer {
 	return &grpcServer{
+		svc:       svc,
+		keepalive: HeartbeatStreamKeepalive,
 		retrieveAgent: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "retrieve_agent")(retrieveAgentEndpoint(svc)),
 			decodeRetrieveAgentRequest,
@@ -43,6 +61,26 @@ func NewServer(tracer opentracing.Tracer, svc fleet.Service) pb.FleetServiceServ
 			decodeRetrieveAgentInfoByChannelIDRequest,
 			encodeAgentInfoResponse,
 		),
+		listAgents: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "list_agents")(listAgentsEndpoint(svc)),
+			decodeListAgentsRequest,
+			encodeListAgentsResponse,
+		),
+		retrieveAgentInfoByChannels: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_agent_info_by_channel_ids")(retrieveAgentInfoByChannelIDsEndpoint(svc)),
+			decodeRetrieveAgentInfoByChannelIDsRequest,
+			encodeAgentInfoByChannelIDsResponse,
+		),
+		retrieveAgentPolicies: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_agent_policies")(retrieveAgentPoliciesEndpoint(svc)),
+			decodeRetrieveAgentRequest,
+			encodeAgentPoliciesResponse,
+		),
+		retrieveAgentCapabilities: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_agent_capabilities")(retrieveAgentCapabilitiesEndpoint(svc)),
+			decodeRetrieveAgentCapabilitiesRequest,
+			encodeAgentCapabilitiesResponse,
+		),
 	}
 }
 
@@ -81,6 +119,87 @@ func (gs *grpcServer) RetrieveAgentInfoByChannelID(ctx context.Context, req *pb.
 	return res.(*pb.AgentInfoRes), nil
 }
 
+func (gs *grpcServer) ListAgents(ctx context.Context, req *pb.ListAgentsReq) (*pb.ListAgentsRes, error) {
+	_, res, err := gs.listAgents.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.ListAgentsRes), nil
+}
+
+func (gs *grpcServer) RetrieveAgentInfoByChannelIDs(ctx context.Context, req *pb.AgentInfoByChannelIDsReq) (*pb.AgentInfoByChannelIDsRes, error) {
+	_, res, err := gs.retrieveAgentInfoByChannels.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.AgentInfoByChannelIDsRes), nil
+}
+
+func (gs *grpcServer) RetrieveAgentPolicies(ctx context.Context, req *pb.AgentByIDReq) (*pb.AgentPoliciesRes, error) {
+	_, res, err := gs.retrieveAgentPolicies.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.AgentPoliciesRes), nil
+}
+
+func (gs *grpcServer) RetrieveAgentCapabilities(ctx context.Context, req *pb.AgentCapabilitiesReq) (*pb.AgentCapabilitiesRes, error) {
+	_, res, err := gs.retrieveAgentCapabilities.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.AgentCapabilitiesRes), nil
+}
+
+// StreamAgentHeartbeats sends the heartbeats of the agents of the owner until the client goes away. A client not
+// keeping up with the heartbeats gets ResourceExhausted and has to stream again
+func (gs *grpcServer) StreamAgentHeartbeats(req *pb.AgentHeartbeatsReq, stream pb.FleetService_StreamAgentHeartbeatsServer) error {
+	sub, err := gs.svc.SubscribeAgentHeartbeatsInternal(stream.Context(), req.GetOwnerID(), req.GetAgentIDs())
+	if err != nil {
+		return encodeError(err)
+	}
+	defer sub.Close()
+	keepalive := time.NewTicker(gs.keepalive)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				if errors.Contains(sub.Err(), fleet.ErrHeartbeatSubscriberLagging) {
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+				return nil
+			}
+			if err := stream.Send(toAgentHeartbeatEventPb(event)); err != nil {
+				return err
+			}
+			keepalive.Reset(gs.keepalive)
+		case t := <-keepalive.C:
+			if err := stream.Send(&pb.AgentHeartbeatEvent{Type: HeartbeatEventKeepalive, OwnerID: req.GetOwnerID(), Timestamp: t.Unix()}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toAgentHeartbeatEventPb(event fleet.AgentHeartbeatEvent) *pb.AgentHeartbeatEvent {
+	return &pb.AgentHeartbeatEvent{
+		Type:          event.Type,
+		AgentID:       event.AgentID,
+		OwnerID:       event.OwnerID,
+		Channel:       event.ChannelID,
+		State:         event.State.String(),
+		PreviousState: event.PreviousState.String(),
+		Timestamp:     event.Timestamp.Unix(),
+	}
+}
+
 func decodeRetrieveAgentRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*pb.AgentByIDReq)
 	return accessByIDReq{AgentID: req.AgentID, OwnerID: req.OwnerID}, nil
@@ -138,13 +257,104 @@ func encodeAgentInfoResponse(_ context.Context, grpcRes interface{}) (interface{
 	}, nil
 }
 
+func decodeListAgentsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListAgentsReq)
+	return listAgentsReq{
+		OwnerID:   req.GetOwnerID(),
+		PageToken: req.GetPageToken(),
+		PageSize:  req.GetPageSize(),
+		Tags:      req.GetTags(),
+	}, nil
+}
+
+func encodeListAgentsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(listAgentsRes)
+	agents := make([]*pb.AgentRes, 0, len(res.agents))
+	for _, agent := range res.agents {
+		agents = append(agents, &pb.AgentRes{
+			Id:      agent.id,
+			Name:    agent.name,
+			Channel: agent.channel,
+		})
+	}
+	return &pb.ListAgentsRes{
+		Agents:        agents,
+		NextPageToken: res.nextPageToken,
+	}, nil
+}
+
+func decodeRetrieveAgentInfoByChannelIDsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.AgentInfoByChannelIDsReq)
+	return accessAgentInfoByChannelIDsReq{ChannelIDs: req.GetChannels()}, nil
+}
+
+func encodeAgentInfoByChannelIDsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(agentsInfoRes)
+	return &pb.AgentInfoByChannelIDsRes{
+		Agents: toAgentInfoByChannelPb(res.agents),
+	}, nil
+}
+
+func toAgentInfoByChannelPb(agents map[string]agentInfoRes) map[string]*pb.AgentInfoRes {
+	res := make(map[string]*pb.AgentInfoRes, len(agents))
+	for channelID, agent := range agents {
+		res[channelID] = &pb.AgentInfoRes{
+			OwnerID:       agent.ownerID,
+			AgentName:     agent.agentName,
+			AgentTags:     agent.agentTags,
+			OrbTags:       agent.orbTags,
+			AgentGroupIDs: agent.agentGroupIDs,
+		}
+	}
+	return res
+}
+
+func encodeAgentPoliciesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(agentPoliciesRes)
+	return &pb.AgentPoliciesRes{
+		Policies: toAgentPoliciesPb(res.policies),
+	}, nil
+}
+
+func toAgentPoliciesPb(policies []agentPolicyRes) []*pb.AgentPolicyRes {
+	res := make([]*pb.AgentPolicyRes, 0, len(policies))
+	for _, policy := range policies {
+		res = append(res, &pb.AgentPolicyRes{
+			Id:      policy.id,
+			Name:    policy.name,
+			Version: policy.version,
+			State:   policy.state,
+			Backend: policy.backend,
+		})
+	}
+	return res
+}
+
+func decodeRetrieveAgentCapabilitiesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.AgentCapabilitiesReq)
+	return accessAgentCapabilitiesReq{ChannelID: req.GetChannel(), OwnerID: req.GetOwnerID()}, nil
+}
+
+func encodeAgentCapabilitiesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(agentCapabilitiesRes)
+	return &pb.AgentCapabilitiesRes{
+		AgentID:      res.agentID,
+		Capabilities: res.capabilities,
+	}, nil
+}
+
 func encodeError(err error) error {
-	switch err {
-	case nil:
+	switch {
+	case err == nil:
 		return nil
-	case fleet.ErrMalformedEntity:
+	case errors.Contains(err, fleet.ErrMalformedEntity),
+		errors.Contains(err, errors.ErrMalformedEntity):
 		return status.Error(codes.InvalidArgument, "received invalid can access request")
-	case fleet.ErrNotFound:
+	case errors.Contains(err, fleet.ErrUnauthorizedAccess),
+		errors.Contains(err, errors.ErrUnauthorizedAccess):
+		return status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
+	case errors.Contains(err, fleet.ErrNotFound),
+		errors.Contains(err, errors.ErrNotFound):
 		return status.Error(codes.NotFound, "not found")
 	default:
 		return status.Error(codes.Internal, "internal server error")
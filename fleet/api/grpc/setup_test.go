@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Adapted for Orb project, modifications licensed under MPL v. 2.0:
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	thmocks "github.com/mainflux/mainflux/things/mocks"
+	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/fleet/mocks"
+	"github.com/orb-community/orb/fleet/pb"
+	"github.com/orb-community/orb/pkg/types"
+	"go.uber.org/zap"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+	fleetgrpc "github.com/orb-community/orb/fleet/api/grpc"
+	"google.golang.org/grpc"
+)
+
+const (
+	port  = 18081
+	token = "token"
+	email = "john.doe@email.com"
+)
+
+var (
+	svc         fleet.Service
+	agentComms  fleet.AgentCommsService
+	agent       fleet.Agent
+	policyAgent fleet.Agent
+)
+
+func TestMain(m *testing.M) {
+	startServer()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func startServer() {
+	svc = newService(map[string]string{token: email})
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		panic(err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterFleetServiceServer(server, fleetgrpc.NewServer(mocktracer.New(), svc))
+	go server.Serve(listener)
+}
+
+func newService(tokens map[string]string) fleet.Service {
+	auth := thmocks.NewAuthService(tokens, make(map[string][]thmocks.MockSubjectSet))
+	agentGroupRepo := mocks.NewAgentGroupRepository()
+	agentRepo := mocks.NewAgentRepositoryMock()
+	agentComms = mocks.NewFleetCommService(agentRepo, agentGroupRepo)
+
+	oID, _ := uuid.NewV4()
+	thingID, _ := uuid.NewV4()
+	channelID, _ := uuid.NewV4()
+	aname, _ := types.NewIdentifier("testagent")
+
+	agent = fleet.Agent{
+		Name:        aname,
+		MFOwnerID:   oID.String(),
+		MFThingID:   thingID.String(),
+		MFChannelID: channelID.String(),
+		AgentMetadata: types.Metadata{
+			"orb_agent":               map[string]interface{}{"version": "0.27.0"},
+			"backends":                map[string]interface{}{"otel": map[string]interface{}{"version": "0.88.0"}},
+			fleet.RPCFuncsMetadataKey: []interface{}{fleet.AgentPolicyRPCFunc},
+			fleet.SignalsMetadataKey:  []interface{}{"metrics", "logs", "traces"},
+		},
+	}
+	_ = agentRepo.Save(context.Background(), agent)
+
+	policyThingID, _ := uuid.NewV4()
+	policyChannelID, _ := uuid.NewV4()
+	pname, _ := types.NewIdentifier("policyagent")
+	policyAgent = fleet.Agent{
+		Name:        pname,
+		MFOwnerID:   oID.String(),
+		MFThingID:   policyThingID.String(),
+		MFChannelID: policyChannelID.String(),
+		LastHBData: types.Metadata{
+			"policy_state": map[string]interface{}{
+				"policy-2": map[string]interface{}{"name": "second", "state": "failed_to_apply", "version": 1, "backend": "otel"},
+				"policy-1": map[string]interface{}{"name": "first", "state": "running", "version": 3, "backend": "pktvisor"},
+			},
+		},
+	}
+	_ = agentRepo.Save(context.Background(), policyAgent)
+
+	logger := zap.NewNop()
+	sdk := mfsdk.NewSDK(mfsdk.Config{})
+	aDone := make(chan bool)
+
+	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, sdk, "", aDone)
+}
@@ -156,12 +156,36 @@ func editAgentGroupEndpoint(svc fleet.Service) endpoint.Endpoint {
 
 func removeAgentGroupEndpoint(svc fleet.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
-		req := request.(viewResourceReq)
+		req := request.(removeAgentGroupReq)
 
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
 
+		if req.dryRun {
+			preview, err := svc.PreviewAgentGroupRemoval(ctx, req.token, req.id)
+			if err != nil {
+				return nil, err
+			}
+			res := groupRemovalPreviewRes{
+				AgentGroupID: preview.AgentGroupID,
+				Agents:       []groupRemovalAgentRes{},
+				Policies:     []groupRemovalPolicyRes{},
+			}
+			for _, agent := range preview.Agents {
+				res.Agents = append(res.Agents, groupRemovalAgentRes{ID: agent.MFThingID, Name: agent.Name.String()})
+			}
+			for _, policy := range preview.Policies {
+				res.Policies = append(res.Policies, groupRemovalPolicyRes{
+					ID:              policy.ID,
+					Name:            policy.Name,
+					DatasetID:       policy.DatasetID,
+					StoppedAgentIDs: policy.StoppedAgentIDs,
+				})
+			}
+			return res, nil
+		}
+
 		if err := svc.RemoveAgentGroup(ctx, req.token, req.id); err != nil {
 			return nil, err
 		}
@@ -196,6 +220,7 @@ func addAgentEndpoint(svc fleet.Service) endpoint.Endpoint {
 			ID:            saved.MFThingID,
 			State:         saved.State.String(),
 			Key:           saved.MFKeyID,
+			SigningKey:    saved.SigningKey,
 			OrbTags:       *saved.OrbTags,
 			AgentTags:     saved.AgentTags,
 			AgentMetadata: saved.AgentMetadata,
@@ -275,6 +300,71 @@ func resetAgentEndpoint(svc fleet.Service) endpoint.Endpoint {
 	}
 }
 
+func resetAgentBackendEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(resetAgentBackendReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.ResetAgentBackend(ctx, req.token, req.id, req.backend); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func updateAgentVersionEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(updateAgentVersionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.UpdateAgentVersion(ctx, req.token, req.id, req.Target, req.Version); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func requestAgentPolicyInventoryEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.RequestAgentPolicyInventory(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func agentMaintenanceEndpoint(svc fleet.Service, enable bool) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.SetAgentMaintenance(ctx, req.token, req.id, enable); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func requestAgentEffectiveConfigEndpoint(svc fleet.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.RequestAgentEffectiveConfig(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
 func listAgentsEndpoint(svc fleet.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listResourcesReq)
@@ -142,7 +142,7 @@ func newService(auth mainflux.AuthServiceClient, url string) fleet.Service {
 	mfsdk := mfsdk.NewSDK(config)
 	pktvisor.Register(auth, agentRepo)
 	aDone := make(chan bool)
-	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, aDone)
+	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, "", aDone)
 }
 
 func newServer(svc fleet.Service) *httptest.Server {
@@ -708,6 +708,73 @@ func TestDeleteAgentGroup(t *testing.T) {
 	}
 }
 
+func TestDeleteAgentGroupDryRun(t *testing.T) {
+	cli := newClientServer(t)
+	defer cli.server.Close()
+
+	a, err := createAgent(t, "agent", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	ag, err := createAgentGroup(t, "ue-agent-group", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id     string
+		auth   string
+		query  string
+		status int
+		res    string
+	}{
+		"dry run delete of existing agent group": {
+			id:     ag.ID,
+			auth:   token,
+			query:  "?dry_run=true",
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`{"agent_group_id":"%s","agents":[{"id":"%s","name":"%s"}],"policies":[]}`, ag.ID, a.MFThingID, a.Name.String()),
+		},
+		"dry run delete of non-existent agent group": {
+			id:     wrongID,
+			auth:   token,
+			query:  "?dry_run=true",
+			status: http.StatusNotFound,
+		},
+		"dry run delete with invalid dry run value": {
+			id:     ag.ID,
+			auth:   token,
+			query:  "?dry_run=maybe",
+			status: http.StatusBadRequest,
+		},
+		"dry run delete with invalid token": {
+			id:     ag.ID,
+			auth:   invalidToken,
+			query:  "?dry_run=true",
+			status: http.StatusUnauthorized,
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      cli.server.Client(),
+				method:      http.MethodDelete,
+				contentType: contentType,
+				url:         fmt.Sprintf("%s/agent_groups/%s%s", cli.server.URL, tc.id, tc.query),
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if tc.res != "" {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+				assert.Equal(t, tc.res, strings.Trim(string(body), "\n"), fmt.Sprintf("%s: unexpected body", desc))
+			}
+		})
+	}
+
+	_, err = cli.service.ViewAgentGroupByID(context.Background(), token, ag.ID)
+	assert.Nil(t, err, fmt.Sprintf("agent group should not be removed by a dry run: %s", err))
+}
+
 func TestValidateAgentGroup(t *testing.T) {
 	cli := newClientServer(t)
 	defer cli.server.Close()
@@ -1513,6 +1580,281 @@ func TestDeleteAgent(t *testing.T) {
 	}
 }
 
+func TestRequestAgentPolicyInventory(t *testing.T) {
+	cli := newClientServer(t)
+
+	ag, err := createAgent(t, "my-agent1", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id     string
+		auth   string
+		status int
+	}{
+		"request the policy inventory of a existing agent": {
+			id:     ag.MFThingID,
+			auth:   token,
+			status: http.StatusOK,
+		},
+		"request the policy inventory of a non-existing agent": {
+			id:     wrongID,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"request the policy inventory of a agent with a invalid token": {
+			id:     ag.MFThingID,
+			auth:   invalidToken,
+			status: http.StatusUnauthorized,
+		},
+		"request the policy inventory of a agent with a empty token": {
+			id:     ag.MFThingID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: cli.server.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/agents/%s/rpc/inventory", cli.server.URL, tc.id),
+				token:  fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected erro %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
+func TestRequestAgentEffectiveConfig(t *testing.T) {
+	cli := newClientServer(t)
+
+	ag, err := createAgent(t, "my-agent1", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id     string
+		auth   string
+		status int
+	}{
+		"request the effective config of a existing agent": {
+			id:     ag.MFThingID,
+			auth:   token,
+			status: http.StatusOK,
+		},
+		"request the effective config of a non-existing agent": {
+			id:     wrongID,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"request the effective config of a agent with a invalid token": {
+			id:     ag.MFThingID,
+			auth:   invalidToken,
+			status: http.StatusUnauthorized,
+		},
+		"request the effective config of a agent with a empty token": {
+			id:     ag.MFThingID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: cli.server.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/agents/%s/rpc/config", cli.server.URL, tc.id),
+				token:  fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected erro %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
+func TestAgentMaintenance(t *testing.T) {
+	cli := newClientServer(t)
+
+	ag, err := createAgent(t, "my-agent1", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id     string
+		method string
+		auth   string
+		status int
+	}{
+		"put a existing agent in maintenance": {
+			id:     ag.MFThingID,
+			method: http.MethodPost,
+			auth:   token,
+			status: http.StatusOK,
+		},
+		"take a existing agent out of maintenance": {
+			id:     ag.MFThingID,
+			method: http.MethodDelete,
+			auth:   token,
+			status: http.StatusOK,
+		},
+		"put a non-existing agent in maintenance": {
+			id:     wrongID,
+			method: http.MethodPost,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"take a non-existing agent out of maintenance": {
+			id:     wrongID,
+			method: http.MethodDelete,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"put a agent in maintenance with a invalid token": {
+			id:     ag.MFThingID,
+			method: http.MethodPost,
+			auth:   invalidToken,
+			status: http.StatusUnauthorized,
+		},
+		"take a agent out of maintenance with a empty token": {
+			id:     ag.MFThingID,
+			method: http.MethodDelete,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: cli.server.Client(),
+				method: tc.method,
+				url:    fmt.Sprintf("%s/agents/%s/rpc/maintenance", cli.server.URL, tc.id),
+				token:  fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected erro %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
+func TestResetAgentBackend(t *testing.T) {
+	cli := newClientServer(t)
+
+	ag, err := createAgent(t, "my-agent1", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id      string
+		backend string
+		auth    string
+		status  int
+	}{
+		"reset a backend of a existing agent": {
+			id:      ag.MFThingID,
+			backend: "pktvisor",
+			auth:    token,
+			status:  http.StatusOK,
+		},
+		"reset a unknown backend of a existing agent": {
+			id:      ag.MFThingID,
+			backend: "unknown",
+			auth:    token,
+			status:  http.StatusNotFound,
+		},
+		"reset a backend of a non-existing agent": {
+			id:      wrongID,
+			backend: "pktvisor",
+			auth:    token,
+			status:  http.StatusNotFound,
+		},
+		"reset a backend of a agent with a invalid token": {
+			id:      ag.MFThingID,
+			backend: "pktvisor",
+			auth:    invalidToken,
+			status:  http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: cli.server.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/agents/%s/rpc/reset/%s", cli.server.URL, tc.id, tc.backend),
+				token:  fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected erro %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
+func TestUpdateAgentVersion(t *testing.T) {
+	cli := newClientServer(t)
+
+	ag, err := createAgent(t, "my-agent1", &cli)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id     string
+		req    string
+		auth   string
+		status int
+	}{
+		"update a agent not advertising the update rpc": {
+			id:     ag.MFThingID,
+			req:    toJSON(map[string]string{"target": "agent", "version": "1.0.0"}),
+			auth:   token,
+			status: http.StatusUnprocessableEntity,
+		},
+		"update a unknown backend of a existing agent": {
+			id:     ag.MFThingID,
+			req:    toJSON(map[string]string{"target": "unknown", "version": "1.0.0"}),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"update a non-existing agent": {
+			id:     wrongID,
+			req:    toJSON(map[string]string{"target": "pktvisor", "version": "4.2.0"}),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		"update a agent without a version": {
+			id:     ag.MFThingID,
+			req:    toJSON(map[string]string{"target": "agent"}),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		"update a agent with a invalid token": {
+			id:     ag.MFThingID,
+			req:    toJSON(map[string]string{"target": "agent", "version": "1.0.0"}),
+			auth:   invalidToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      cli.server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/agents/%s/rpc/update", cli.server.URL, tc.id),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected erro %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
 func TestAgentBackends(t *testing.T) {
 	cli := newClientServer(t)
 
@@ -7,6 +7,7 @@ package http
 import (
 	"context"
 	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/pkg/types"
 	"go.uber.org/zap"
 	"time"
 )
@@ -32,6 +33,20 @@ func (l loggingMiddleware) ViewAgentMatchingGroupsByIDInternal(ctx context.Conte
 	return l.svc.ViewAgentMatchingGroupsByIDInternal(ctx, agentID, ownerID)
 }
 
+func (l loggingMiddleware) ListAgentsInternal(ctx context.Context, ownerID string, pageToken string, pageSize uint64, tags types.Tags) (_ []fleet.Agent, _ string, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: list_agents_internal",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: list_agents_internal",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ListAgentsInternal(ctx, ownerID, pageToken, pageSize, tags)
+}
+
 func (l loggingMiddleware) ResetAgent(ct context.Context, token string, agentID string) (err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -46,6 +61,84 @@ func (l loggingMiddleware) ResetAgent(ct context.Context, token string, agentID
 	return l.svc.ResetAgent(ct, token, agentID)
 }
 
+func (l loggingMiddleware) ResetAgentBackend(ctx context.Context, token string, agentID string, backendName string) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: reset_agent_backend",
+				zap.String("backend", backendName),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: reset_agent_backend",
+				zap.String("backend", backendName),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ResetAgentBackend(ctx, token, agentID, backendName)
+}
+
+func (l loggingMiddleware) UpdateAgentVersion(ctx context.Context, token string, agentID string, target string, version string) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: update_agent_version",
+				zap.String("target", target),
+				zap.String("version", version),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: update_agent_version",
+				zap.String("target", target),
+				zap.String("version", version),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.UpdateAgentVersion(ctx, token, agentID, target, version)
+}
+
+func (l loggingMiddleware) SetAgentMaintenance(ctx context.Context, token string, agentID string, enable bool) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: set_agent_maintenance",
+				zap.Bool("enable", enable),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: set_agent_maintenance",
+				zap.Bool("enable", enable),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.SetAgentMaintenance(ctx, token, agentID, enable)
+}
+
+func (l loggingMiddleware) RequestAgentPolicyInventory(ctx context.Context, token string, agentID string) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: request_agent_policy_inventory",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: request_agent_policy_inventory",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.RequestAgentPolicyInventory(ctx, token, agentID)
+}
+
+func (l loggingMiddleware) RequestAgentEffectiveConfig(ctx context.Context, token string, agentID string) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: request_agent_effective_config",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: request_agent_effective_config",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.RequestAgentEffectiveConfig(ctx, token, agentID)
+}
+
 func (l loggingMiddleware) ViewAgentInfoByChannelIDInternal(ctx context.Context, channelID string) (_ fleet.Agent, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -60,6 +153,48 @@ func (l loggingMiddleware) ViewAgentInfoByChannelIDInternal(ctx context.Context,
 	return l.svc.ViewAgentInfoByChannelIDInternal(ctx, channelID)
 }
 
+func (l loggingMiddleware) ViewAgentCapabilitiesByChannelIDInternal(ctx context.Context, ownerID string, channelID string) (_ fleet.Agent, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: view_agent_capabilities_by_channel_id",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: view_agent_capabilities_by_channel_id",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ViewAgentCapabilitiesByChannelIDInternal(ctx, ownerID, channelID)
+}
+
+func (l loggingMiddleware) SubscribeAgentHeartbeatsInternal(ctx context.Context, ownerID string, agentIDs []string) (_ *fleet.HeartbeatSubscription, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: subscribe_agent_heartbeats",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: subscribe_agent_heartbeats",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.SubscribeAgentHeartbeatsInternal(ctx, ownerID, agentIDs)
+}
+
+func (l loggingMiddleware) ViewAgentsInfoByChannelIDsInternal(ctx context.Context, channelIDs []string) (_ map[string]fleet.Agent, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: view_agents_info_by_channel_ids",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: view_agents_info_by_channel_ids",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ViewAgentsInfoByChannelIDsInternal(ctx, channelIDs)
+}
+
 func (l loggingMiddleware) ViewAgentBackend(ctx context.Context, token string, name string) (_ interface{}, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -260,6 +395,20 @@ func (l loggingMiddleware) RemoveAgentGroup(ctx context.Context, token, groupID
 	return l.svc.RemoveAgentGroup(ctx, token, groupID)
 }
 
+func (l loggingMiddleware) PreviewAgentGroupRemoval(ctx context.Context, token, groupID string) (_ fleet.GroupRemovalPreview, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: preview_delete_agent_groups",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: preview_delete_agent_groups",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.PreviewAgentGroupRemoval(ctx, token, groupID)
+}
+
 func (l loggingMiddleware) ValidateAgentGroup(ctx context.Context, token string, s fleet.AgentGroup) (_ fleet.AgentGroup, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
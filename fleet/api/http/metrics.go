@@ -10,6 +10,7 @@ import (
 	"github.com/mainflux/mainflux"
 	"github.com/orb-community/orb/fleet"
 	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
 	"time"
 )
 
@@ -39,6 +40,23 @@ func (m metricsMiddleware) ViewAgentMatchingGroupsByIDInternal(ctx context.Conte
 	return m.svc.ViewAgentMatchingGroupsByIDInternal(ctx, agentID, ownerID)
 }
 
+func (m metricsMiddleware) ListAgentsInternal(ctx context.Context, ownerID string, pageToken string, pageSize uint64, tags types.Tags) ([]fleet.Agent, string, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "listAgentsInternal",
+			"owner_id", ownerID,
+			"agent_id", "",
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ListAgentsInternal(ctx, ownerID, pageToken, pageSize, tags)
+}
+
 func (m metricsMiddleware) ResetAgent(ct context.Context, token string, agentID string) error {
 	ownerID, err := m.identify(token)
 	if err != nil {
@@ -61,6 +79,150 @@ func (m metricsMiddleware) ResetAgent(ct context.Context, token string, agentID
 	return m.svc.ResetAgent(ct, token, agentID)
 }
 
+func (m metricsMiddleware) ResetAgentBackend(ctx context.Context, token string, agentID string, backendName string) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "resetAgentBackend",
+			"owner_id", ownerID,
+			"agent_id", agentID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ResetAgentBackend(ctx, token, agentID, backendName)
+}
+
+func (m metricsMiddleware) UpdateAgentVersion(ctx context.Context, token string, agentID string, target string, version string) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "updateAgentVersion",
+			"owner_id", ownerID,
+			"agent_id", agentID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.UpdateAgentVersion(ctx, token, agentID, target, version)
+}
+
+func (m metricsMiddleware) SetAgentMaintenance(ctx context.Context, token string, agentID string, enable bool) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "setAgentMaintenance",
+			"owner_id", ownerID,
+			"agent_id", agentID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.SetAgentMaintenance(ctx, token, agentID, enable)
+}
+
+func (m metricsMiddleware) RequestAgentPolicyInventory(ctx context.Context, token string, agentID string) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "requestAgentPolicyInventory",
+			"owner_id", ownerID,
+			"agent_id", agentID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.RequestAgentPolicyInventory(ctx, token, agentID)
+}
+
+func (m metricsMiddleware) RequestAgentEffectiveConfig(ctx context.Context, token string, agentID string) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "requestAgentEffectiveConfig",
+			"owner_id", ownerID,
+			"agent_id", agentID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.RequestAgentEffectiveConfig(ctx, token, agentID)
+}
+
+func (m metricsMiddleware) ViewAgentCapabilitiesByChannelIDInternal(ctx context.Context, ownerID string, channelID string) (agent fleet.Agent, _ error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "viewAgentCapabilitiesByChannelIDInternal",
+			"owner_id", ownerID,
+			"agent_id", agent.MFThingID,
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ViewAgentCapabilitiesByChannelIDInternal(ctx, ownerID, channelID)
+}
+
+func (m metricsMiddleware) SubscribeAgentHeartbeatsInternal(ctx context.Context, ownerID string, agentIDs []string) (*fleet.HeartbeatSubscription, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "subscribeAgentHeartbeatsInternal",
+			"owner_id", ownerID,
+			"agent_id", "",
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.SubscribeAgentHeartbeatsInternal(ctx, ownerID, agentIDs)
+}
+
 func (m metricsMiddleware) ViewAgentInfoByChannelIDInternal(ctx context.Context, channelID string) (agent fleet.Agent, _ error) {
 	defer func(begin time.Time) {
 		labels := []string{
@@ -78,6 +240,23 @@ func (m metricsMiddleware) ViewAgentInfoByChannelIDInternal(ctx context.Context,
 	return m.svc.ViewAgentInfoByChannelIDInternal(ctx, channelID)
 }
 
+func (m metricsMiddleware) ViewAgentsInfoByChannelIDsInternal(ctx context.Context, channelIDs []string) (map[string]fleet.Agent, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "viewAgentsInfoByChannelIDsInternal",
+			"owner_id", "",
+			"agent_id", "",
+			"group_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ViewAgentsInfoByChannelIDsInternal(ctx, channelIDs)
+}
+
 func (m metricsMiddleware) ViewAgentBackend(ctx context.Context, token string, name string) (interface{}, error) {
 	ownerID, err := m.identify(token)
 	if err != nil {
@@ -341,6 +520,28 @@ func (m metricsMiddleware) RemoveAgentGroup(ctx context.Context, token string, g
 	return m.svc.RemoveAgentGroup(ctx, token, groupID)
 }
 
+func (m metricsMiddleware) PreviewAgentGroupRemoval(ctx context.Context, token string, groupID string) (fleet.GroupRemovalPreview, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return fleet.GroupRemovalPreview{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "previewAgentGroupRemoval",
+			"owner_id", ownerID,
+			"agent_id", "",
+			"group_id", groupID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.PreviewAgentGroupRemoval(ctx, token, groupID)
+}
+
 func (m metricsMiddleware) ValidateAgentGroup(ctx context.Context, token string, s fleet.AgentGroup) (group fleet.AgentGroup, _ error) {
 	defer func(begin time.Time) {
 		labels := []string{
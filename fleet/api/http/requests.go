@@ -142,6 +142,55 @@ func (req viewResourceReq) validate() error {
 	return nil
 }
 
+type resetAgentBackendReq struct {
+	token   string
+	id      string
+	backend string
+}
+
+func (req resetAgentBackendReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.id == "" || req.backend == "" {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
+
+type updateAgentVersionReq struct {
+	token   string
+	id      string
+	Target  string `json:"target"`
+	Version string `json:"version"`
+}
+
+func (req updateAgentVersionReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.id == "" || req.Target == "" || req.Version == "" {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
+
+type removeAgentGroupReq struct {
+	token  string
+	id     string
+	dryRun bool
+}
+
+func (req removeAgentGroupReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
+
 type listResourcesReq struct {
 	token        string
 	pageMetadata fleet.PageMetadata
@@ -63,6 +63,7 @@ type agentRes struct {
 	Name          string         `json:"name"`
 	State         string         `json:"state"`
 	Key           string         `json:"key,omitempty"`
+	SigningKey    string         `json:"signing_key,omitempty"`
 	ChannelID     string         `json:"channel_id,omitempty"`
 	AgentTags     types.Tags     `json:"agent_tags"`
 	OrbTags       types.Tags     `json:"orb_tags"`
@@ -129,6 +130,36 @@ func (r removeRes) Empty() bool {
 	return true
 }
 
+type groupRemovalAgentRes struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type groupRemovalPolicyRes struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	DatasetID       string   `json:"dataset_id"`
+	StoppedAgentIDs []string `json:"stopped_agent_ids"`
+}
+
+type groupRemovalPreviewRes struct {
+	AgentGroupID string                  `json:"agent_group_id"`
+	Agents       []groupRemovalAgentRes  `json:"agents"`
+	Policies     []groupRemovalPolicyRes `json:"policies"`
+}
+
+func (r groupRemovalPreviewRes) Code() int {
+	return http.StatusOK
+}
+
+func (r groupRemovalPreviewRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (r groupRemovalPreviewRes) Empty() bool {
+	return false
+}
+
 type validateAgentGroupRes struct {
 	ID             string         `json:"id,omitempty"`
 	Name           string         `json:"name"`
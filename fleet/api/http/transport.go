@@ -34,6 +34,7 @@ const (
 	dirKey      = "dir"
 	metadataKey = "metadata"
 	tagsKey     = "tags"
+	dryRunKey   = "dry_run"
 	defOffset   = 0
 	defLimit    = 10
 )
@@ -67,7 +68,7 @@ func MakeHandler(tracer opentracing.Tracer, svcName string, svc fleet.Service) h
 		opts...))
 	r.Delete("/agent_groups/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "delete_agent_group")(removeAgentGroupEndpoint(svc)),
-		decodeView,
+		decodeRemoveAgentGroup,
 		types.EncodeResponse,
 		opts...))
 	r.Post("/agent_groups/validate", kithttp.NewServer(
@@ -96,6 +97,36 @@ func MakeHandler(tracer opentracing.Tracer, svcName string, svc fleet.Service) h
 		decodeView,
 		types.EncodeResponse,
 		opts...))
+	r.Post("/agents/:id/rpc/reset/:backend", kithttp.NewServer(
+		kitot.TraceServer(tracer, "reset_agent_backend")(resetAgentBackendEndpoint(svc)),
+		decodeResetAgentBackend,
+		types.EncodeResponse,
+		opts...))
+	r.Post("/agents/:id/rpc/update", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_agent_version")(updateAgentVersionEndpoint(svc)),
+		decodeUpdateAgentVersion,
+		types.EncodeResponse,
+		opts...))
+	r.Post("/agents/:id/rpc/inventory", kithttp.NewServer(
+		kitot.TraceServer(tracer, "request_agent_policy_inventory")(requestAgentPolicyInventoryEndpoint(svc)),
+		decodeView,
+		types.EncodeResponse,
+		opts...))
+	r.Post("/agents/:id/rpc/maintenance", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enter_agent_maintenance")(agentMaintenanceEndpoint(svc, true)),
+		decodeView,
+		types.EncodeResponse,
+		opts...))
+	r.Delete("/agents/:id/rpc/maintenance", kithttp.NewServer(
+		kitot.TraceServer(tracer, "exit_agent_maintenance")(agentMaintenanceEndpoint(svc, false)),
+		decodeView,
+		types.EncodeResponse,
+		opts...))
+	r.Post("/agents/:id/rpc/config", kithttp.NewServer(
+		kitot.TraceServer(tracer, "request_agent_effective_config")(requestAgentEffectiveConfigEndpoint(svc)),
+		decodeView,
+		types.EncodeResponse,
+		opts...))
 	r.Get("/agents/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "edit_agent")(viewAgentEndpoint(svc)),
 		decodeView,
@@ -156,6 +187,45 @@ func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeResetAgentBackend(_ context.Context, r *http.Request) (interface{}, error) {
+	req := resetAgentBackendReq{
+		token:   parseJwt(r),
+		id:      bone.GetValue(r, "id"),
+		backend: bone.GetValue(r, "backend"),
+	}
+	return req, nil
+}
+
+func decodeUpdateAgentVersion(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := updateAgentVersionReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeRemoveAgentGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	dryRun, err := httputil.ReadBoolQuery(r, dryRunKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	req := removeAgentGroupReq{
+		token:  parseJwt(r),
+		id:     bone.GetValue(r, "id"),
+		dryRun: dryRun,
+	}
+	return req, nil
+}
+
 func decodeAgentGroupUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errors.ErrUnsupportedContentType
@@ -295,6 +365,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 		case errors.Contains(errorVal, fleet.ErrCreateAgentGroup):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, fleet.ErrRPCNotSupported):
+			w.WriteHeader(http.StatusUnprocessableEntity)
 
 		case errors.Contains(errorVal, io.ErrUnexpectedEOF),
 			errors.Contains(errorVal, io.EOF):
@@ -5,6 +5,8 @@
 package backend
 
 import (
+	"sort"
+
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/opentracing/opentracing-go"
@@ -29,11 +31,13 @@ func Register(name string, b Backend) {
 	registry[name] = b
 }
 
+// GetList returns the names of the registered backends in alphabetical order
 func GetList() []string {
 	keys := make([]string, 0, len(registry))
 	for k := range registry {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
@@ -0,0 +1,45 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/opentracing/opentracing-go"
+	"github.com/orb-community/orb/fleet/backend"
+)
+
+var _ backend.Backend = (*promScrapeBackend)(nil)
+
+const CurrentSchemaVersion = "1.0"
+
+type promScrapeBackend struct {
+	Backend     string
+	Description string
+}
+
+func (p promScrapeBackend) Metadata() interface{} {
+	return struct {
+		Backend       string `json:"backend"`
+		Description   string `json:"description"`
+		SchemaVersion string `json:"schema_version"`
+	}{
+		Backend:       p.Backend,
+		Description:   p.Description,
+		SchemaVersion: CurrentSchemaVersion,
+	}
+}
+
+// MakeHandler has nothing to register, the scrape backend has no taps, inputs or handlers to list
+func (p promScrapeBackend) MakeHandler(_ opentracing.Tracer, _ []kithttp.ServerOption, _ *bone.Mux) {
+}
+
+func Register() bool {
+	backend.Register("prometheus_scrape", &promScrapeBackend{
+		Backend:     "prometheus_scrape",
+		Description: "Prometheus endpoint scrape target and interval",
+	})
+	return true
+}
@@ -43,6 +43,8 @@ type AgentCommsService interface {
 	NotifyGroupNewDataset(ctx context.Context, ag AgentGroup, datasetID string, policyID string, ownerID string) error
 	// NotifyGroupRemoval RPC core -> Agent: Notify AgentGroup that the group has been removed
 	NotifyGroupRemoval(ctx context.Context, ag AgentGroup) error
+	// PreviewGroupRemoval computes what NotifyGroupRemoval makes the agents of the AgentGroup do, without notifying them
+	PreviewGroupRemoval(ctx context.Context, ag AgentGroup) (GroupRemovalPreview, error)
 	// NotifyGroupPolicyRemoval RPC core -> Agent: Notify AgentGroup that a Policy has been removed
 	NotifyGroupPolicyRemoval(ctx context.Context, ag AgentGroup, policyID string, policyName string, backend string) error
 	// NotifyGroupDatasetRemoval RPC core -> Agent: Notify AgentGroup that a Dataset has been removed
@@ -51,8 +53,24 @@ type AgentCommsService interface {
 	NotifyGroupPolicyUpdate(ctx context.Context, ag AgentGroup, policyID string, ownerID string) error
 	//NotifyAgentReset RPC core -> Agent: Notify Agent to reset the backend
 	NotifyAgentReset(ctx context.Context, agent Agent, fullReset bool, reason string) error
+	// NotifyAgentBackendReset RPC core -> Agent: Notify Agent to reset a single backend, leaving comms and the other backends running
+	NotifyAgentBackendReset(ctx context.Context, agent Agent, backend string, reason string) error
+	// NotifyAgentMaintenance RPC core -> Agent: Notify Agent to enter or exit maintenance
+	NotifyAgentMaintenance(ctx context.Context, agent Agent, enable bool, reason string) error
+	// NotifyAgentUpdate RPC core -> Agent: Request Agent to check the availability of a version of itself or of a backend,
+	// switching to it when its self-update is configured
+	NotifyAgentUpdate(ctx context.Context, agent Agent, target string, version string, reason string) error
+	// NotifyAgentPolicyInventoryReq RPC core -> Agent: Request Agent to publish the policies it currently has applied
+	NotifyAgentPolicyInventoryReq(ctx context.Context, agent Agent) error
+	// NotifyAgentEffectiveConfigReq RPC core -> Agent: Request Agent to publish the configuration it is running
+	NotifyAgentEffectiveConfigReq(ctx context.Context, agent Agent) error
 	// NotifyGroupDatasetEdit RPC core -> Agent: Notify Agent an already created Dataset goes invalid or valid
 	NotifyGroupDatasetEdit(ctx context.Context, ag AgentGroup, datasetID, policyID, ownerID string, valid bool) error
+	// SubscribeHeartbeats Agent -> Core: Subscribe to the heartbeats of the agents of the owner as they are consumed, limited to agentIDs when set
+	SubscribeHeartbeats(ownerID string, agentIDs []string) *HeartbeatSubscription
+	// PublishStateChanges Core: Hand the state changes of agents made by fleet itself, as going stale, to the heartbeat
+	// subscribers, the agents hold their previous state
+	PublishStateChanges(previous []Agent, state State, ts time.Time)
 }
 
 var _ AgentCommsService = (*fleetCommsService)(nil)
@@ -73,6 +91,10 @@ type fleetCommsService struct {
 
 	// agent comms
 	agentPubSub mfnats.PubSub
+	verifier    *MessageVerifier
+	heartbeats  *HeartbeatBroker
+	// heartbeatRelay shares the heartbeat events with the other fleet replicas, nil when fleet runs alone
+	heartbeatRelay HeartbeatRelay
 }
 
 func (svc fleetCommsService) NotifyGroupDatasetEdit(ctx context.Context, ag AgentGroup, datasetID, policyID, ownerID string, valid bool) error {
@@ -113,6 +135,7 @@ func (svc fleetCommsService) NotifyGroupDatasetEdit(ctx context.Context, ag Agen
 	data := AgentPolicyRPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentPolicyRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyRPCFunc),
 		Payload:       payload,
 		FullList:      false,
 	}
@@ -167,6 +190,7 @@ func (svc fleetCommsService) NotifyGroupNewDataset(ctx context.Context, ag Agent
 	data := AgentPolicyRPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentPolicyRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyRPCFunc),
 		Payload:       payload,
 		FullList:      false,
 	}
@@ -199,6 +223,7 @@ func (svc fleetCommsService) NotifyAgentNewGroupMembership(ctx context.Context,
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          GroupMembershipRPCFunc,
+		RequestID:     svc.newRequestID(GroupMembershipRPCFunc),
 		Payload:       payload,
 	}
 
@@ -274,6 +299,7 @@ func (svc fleetCommsService) NotifyAgentAllDatasets(ctx context.Context, a Agent
 				Data:         pdata,
 				DatasetID:    policy.DatasetId,
 				AgentGroupID: policy.AgentGroupId,
+				DependsOn:    policy.DependsOn,
 			}
 
 		}
@@ -288,6 +314,7 @@ func (svc fleetCommsService) NotifyAgentAllDatasets(ctx context.Context, a Agent
 	data := AgentPolicyRPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentPolicyRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyRPCFunc),
 		Payload:       payload,
 		FullList:      true,
 	}
@@ -333,6 +360,7 @@ func (svc fleetCommsService) NotifyAgentGroupMemberships(ctx context.Context, a
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          GroupMembershipRPCFunc,
+		RequestID:     svc.newRequestID(GroupMembershipRPCFunc),
 		Payload:       payload,
 	}
 
@@ -357,8 +385,7 @@ func (svc fleetCommsService) NotifyAgentGroupMemberships(ctx context.Context, a
 }
 
 func (svc fleetCommsService) NotifyGroupRemoval(ctx context.Context, ag AgentGroup) error {
-	groupID := []string{ag.ID}
-	policies, err := svc.policyClient.RetrievePoliciesByGroups(ctx, &pb.PoliciesByGroupsReq{GroupIDs: groupID, OwnerID: ag.MFOwnerID})
+	policies, err := svc.retrieveGroupPolicies(ctx, ag.MFOwnerID, ag.ID)
 	if err != nil {
 		return err
 	}
@@ -377,6 +404,7 @@ func (svc fleetCommsService) NotifyGroupRemoval(ctx context.Context, ag AgentGro
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          GroupRemovedRPCFunc,
+		RequestID:     svc.newRequestID(GroupRemovedRPCFunc),
 		Payload:       payload,
 	}
 
@@ -398,6 +426,67 @@ func (svc fleetCommsService) NotifyGroupRemoval(ctx context.Context, ag AgentGro
 	return nil
 }
 
+func (svc fleetCommsService) retrieveGroupPolicies(ctx context.Context, ownerID string, groupIDs ...string) (*pb.PolicyInDSListRes, error) {
+	return svc.policyClient.RetrievePoliciesByGroups(ctx, &pb.PoliciesByGroupsReq{GroupIDs: groupIDs, OwnerID: ownerID})
+}
+
+func (svc fleetCommsService) PreviewGroupRemoval(ctx context.Context, ag AgentGroup) (GroupRemovalPreview, error) {
+	policies, err := svc.retrieveGroupPolicies(ctx, ag.MFOwnerID, ag.ID)
+	if err != nil {
+		return GroupRemovalPreview{}, err
+	}
+
+	agents, err := svc.agentRepo.RetrieveAllByAgentGroupID(ctx, ag.MFOwnerID, ag.ID, false)
+	if err != nil {
+		return GroupRemovalPreview{}, err
+	}
+
+	preview := GroupRemovalPreview{
+		AgentGroupID: ag.ID,
+		Agents:       agents,
+		Policies:     make([]GroupRemovalPolicy, 0, len(policies.Policies)),
+	}
+	for _, policy := range policies.Policies {
+		preview.Policies = append(preview.Policies, GroupRemovalPolicy{
+			ID:              policy.Id,
+			Name:            policy.Name,
+			DatasetID:       policy.DatasetId,
+			StoppedAgentIDs: []string{},
+		})
+	}
+
+	// as the agent does on removal, a policy stops on an agent when no other group of the agent applies it
+	for _, agent := range agents {
+		groups, err := svc.agentGroupRepo.RetrieveAllByAgent(ctx, agent)
+		if err != nil {
+			return GroupRemovalPreview{}, err
+		}
+		otherGroupIDs := make([]string, 0, len(groups))
+		for _, group := range groups {
+			if group.ID != ag.ID {
+				otherGroupIDs = append(otherGroupIDs, group.ID)
+			}
+		}
+		kept := make(map[string]bool)
+		if len(otherGroupIDs) > 0 {
+			otherPolicies, err := svc.retrieveGroupPolicies(ctx, ag.MFOwnerID, otherGroupIDs...)
+			if err != nil {
+				return GroupRemovalPreview{}, err
+			}
+			for _, policy := range otherPolicies.Policies {
+				kept[policy.Id] = true
+			}
+		}
+		for i, policy := range preview.Policies {
+			if !kept[policy.ID] {
+				preview.Policies[i].StoppedAgentIDs = append(preview.Policies[i].StoppedAgentIDs, agent.MFThingID)
+			}
+		}
+	}
+
+	return preview, nil
+}
+
 func (svc fleetCommsService) NotifyGroupPolicyUpdate(ctx context.Context, ag AgentGroup, policyID string, ownerID string) error {
 	p, err := svc.policyClient.RetrievePolicy(ctx, &pb.PolicyByIDReq{PolicyID: policyID, OwnerID: ownerID})
 	if err != nil {
@@ -429,6 +518,7 @@ func (svc fleetCommsService) NotifyGroupPolicyUpdate(ctx context.Context, ag Age
 	data := AgentPolicyRPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentPolicyRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyRPCFunc),
 		Payload:       payload,
 		FullList:      false,
 	}
@@ -468,6 +558,7 @@ func (svc fleetCommsService) NotifyGroupPolicyRemoval(ctx context.Context, ag Ag
 	data := AgentPolicyRPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentPolicyRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyRPCFunc),
 		Payload:       payloads,
 		FullList:      false,
 	}
@@ -500,6 +591,7 @@ func (svc fleetCommsService) NotifyGroupDatasetRemoval(ctx context.Context, ag A
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          DatasetRemovedRPCFunc,
+		RequestID:     svc.newRequestID(DatasetRemovedRPCFunc),
 		Payload:       payload,
 	}
 
@@ -526,6 +618,7 @@ func (svc fleetCommsService) NotifyAgentStop(ctx context.Context, agent Agent, r
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentStopRPCFunc,
+		RequestID:     svc.newRequestID(AgentStopRPCFunc),
 		Payload:       payload,
 	}
 
@@ -555,6 +648,7 @@ func (svc fleetCommsService) NotifyAgentReset(ctx context.Context, agent Agent,
 	data := RPC{
 		SchemaVersion: CurrentRPCSchemaVersion,
 		Func:          AgentResetRPCFunc,
+		RequestID:     svc.newRequestID(AgentResetRPCFunc),
 		Payload:       payload,
 	}
 
@@ -576,14 +670,194 @@ func (svc fleetCommsService) NotifyAgentReset(ctx context.Context, agent Agent,
 	return nil
 }
 
-func NewFleetCommsService(logger *zap.Logger, policyClient pb.PolicyServiceClient, agentRepo AgentRepository, agentGroupRepo AgentGroupRepository, agentPubSub mfnats.PubSub) AgentCommsService {
+func (svc fleetCommsService) NotifyAgentBackendReset(ctx context.Context, agent Agent, backend string, reason string) error {
+	payload := AgentBackendResetRPCPayload{
+		Backend: backend,
+		Reason:  reason,
+	}
+	data := RPC{
+		SchemaVersion: CurrentRPCSchemaVersion,
+		Func:          AgentBackendResetRPCFunc,
+		RequestID:     svc.newRequestID(AgentBackendResetRPCFunc),
+		Payload:       payload,
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Channel:   agent.MFChannelID,
+		Subtopic:  RPCFromCoreTopic,
+		Publisher: publisher,
+		Payload:   body,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.agentPubSub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc fleetCommsService) NotifyAgentMaintenance(ctx context.Context, agent Agent, enable bool, reason string) error {
+	function := AgentMaintenanceExitRPCFunc
+	if enable {
+		function = AgentMaintenanceEnterRPCFunc
+	}
+	data := RPC{
+		SchemaVersion: CurrentRPCSchemaVersion,
+		Func:          function,
+		RequestID:     svc.newRequestID(function),
+		Payload:       AgentMaintenanceRPCPayload{Reason: reason},
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Channel:   agent.MFChannelID,
+		Subtopic:  RPCFromCoreTopic,
+		Publisher: publisher,
+		Payload:   body,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.agentPubSub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc fleetCommsService) NotifyAgentUpdate(ctx context.Context, agent Agent, target string, version string, reason string) error {
+	data := RPC{
+		SchemaVersion: CurrentRPCSchemaVersion,
+		Func:          AgentUpdateRPCFunc,
+		RequestID:     svc.newRequestID(AgentUpdateRPCFunc),
+		Payload: AgentUpdateRPCPayload{
+			Target:  target,
+			Version: version,
+			Reason:  reason,
+		},
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Channel:   agent.MFChannelID,
+		Subtopic:  RPCFromCoreTopic,
+		Publisher: publisher,
+		Payload:   body,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.agentPubSub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc fleetCommsService) NotifyAgentPolicyInventoryReq(ctx context.Context, agent Agent) error {
+	data := RPC{
+		SchemaVersion: CurrentRPCSchemaVersion,
+		Func:          AgentPolicyInventoryReqRPCFunc,
+		RequestID:     svc.newRequestID(AgentPolicyInventoryReqRPCFunc),
+		Payload:       AgentPolicyInventoryReqRPCPayload{},
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Channel:   agent.MFChannelID,
+		Subtopic:  RPCFromCoreTopic,
+		Publisher: publisher,
+		Payload:   body,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.agentPubSub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc fleetCommsService) NotifyAgentEffectiveConfigReq(ctx context.Context, agent Agent) error {
+	data := RPC{
+		SchemaVersion: CurrentRPCSchemaVersion,
+		Func:          AgentEffectiveConfigReqRPCFunc,
+		RequestID:     svc.newRequestID(AgentEffectiveConfigReqRPCFunc),
+		Payload:       AgentEffectiveConfigReqRPCPayload{},
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Channel:   agent.MFChannelID,
+		Subtopic:  RPCFromCoreTopic,
+		Publisher: publisher,
+		Payload:   body,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.agentPubSub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewFleetCommsService returns the agent comms service, a nil verifier accepts the signed messages of the agents
+// without checking them. The heartbeat events are shared with the other replicas through the relay, a nil relay
+// only hands them to the subscribers of this replica
+func NewFleetCommsService(logger *zap.Logger, policyClient pb.PolicyServiceClient, agentRepo AgentRepository, agentGroupRepo AgentGroupRepository, agentPubSub mfnats.PubSub, verifier *MessageVerifier, heartbeatRelay HeartbeatRelay) AgentCommsService {
 	return &fleetCommsService{
 		logger:         logger,
 		agentRepo:      agentRepo,
 		agentGroupRepo: agentGroupRepo,
 		agentPubSub:    agentPubSub,
 		policyClient:   policyClient,
+		verifier:       verifier,
+		heartbeats:     NewHeartbeatBroker(HeartbeatSubscriptionBuffer),
+		heartbeatRelay: heartbeatRelay,
+	}
+}
+
+func (svc fleetCommsService) SubscribeHeartbeats(ownerID string, agentIDs []string) *HeartbeatSubscription {
+	return svc.heartbeats.Subscribe(ownerID, agentIDs)
+}
+
+func (svc fleetCommsService) PublishStateChanges(previous []Agent, state State, ts time.Time) {
+	if !svc.hasHeartbeatSubscribers() {
+		return
 	}
+	for _, agent := range previous {
+		svc.publishHeartbeat(heartbeatEvent(agent, state, ts))
+	}
+}
+
+// hasHeartbeatSubscribers reports whether the heartbeat events have to be built, the subscribers of the other
+// replicas are not known so the events are always relayed
+func (svc fleetCommsService) hasHeartbeatSubscribers() bool {
+	return svc.heartbeatRelay != nil || svc.heartbeats.HasSubscribers()
+}
+
+// publishHeartbeat hands the event to the subscribers of every replica through the relay, or only to the ones of
+// this replica when the relay is not set or fails
+func (svc fleetCommsService) publishHeartbeat(event AgentHeartbeatEvent) {
+	if svc.heartbeatRelay != nil {
+		err := svc.heartbeatRelay.Publish(event)
+		if err == nil {
+			return
+		}
+		svc.logger.Warn("failed to relay the heartbeat event to the fleet replicas", zap.String("agent_id", event.AgentID), zap.Error(err))
+	}
+	svc.heartbeats.Publish(event)
 }
 
 func (svc fleetCommsService) handleCapabilities(ctx context.Context, thingID string, channelID string, payload []byte) error {
@@ -606,6 +880,12 @@ func (svc fleetCommsService) handleCapabilities(ctx context.Context, thingID str
 	agent.AgentMetadata = make(map[string]interface{})
 	agent.AgentMetadata["backends"] = capabilities.Backends
 	agent.AgentMetadata["orb_agent"] = capabilities.OrbAgent
+	if capabilities.RPCFuncs != nil {
+		agent.AgentMetadata[RPCFuncsMetadataKey] = capabilities.RPCFuncs
+	}
+	if capabilities.Signals != nil {
+		agent.AgentMetadata[SignalsMetadataKey] = capabilities.Signals
+	}
 	agent.AgentTags = capabilities.AgentTags
 
 	err = svc.checkVersion(ctx, buildinfo.GetMinAgentVersion(), capabilities.OrbAgent.Version, &agent)
@@ -653,6 +933,10 @@ func (svc fleetCommsService) handleHeartbeat(ctx context.Context, thingID string
 	if err := json.Unmarshal(payload, &hb); err != nil {
 		return ErrSchemaMalformed
 	}
+	if hb.RequestID != "" {
+		svc.logger.Info("agent heartbeat after handling RPC", zap.String("request_id", hb.RequestID),
+			zap.String("thing_id", thingID), zap.String("channel_id", channelID))
+	}
 	agent := Agent{MFThingID: thingID, MFChannelID: channelID}
 	agent.LastHBData = make(map[string]interface{})
 	// accept "offline" state request to indicate agent is going offline
@@ -661,6 +945,12 @@ func (svc fleetCommsService) handleHeartbeat(ctx context.Context, thingID string
 		agent.LastHBData["backend_state"] = hb.BackendState
 		agent.LastHBData["policy_state"] = hb.PolicyState
 		agent.LastHBData["group_state"] = hb.GroupState
+	} else if hb.State == Maintenance {
+		// the agent is connected but runs no policy until it exits maintenance
+		agent.State = Maintenance
+		agent.LastHBData["backend_state"] = hb.BackendState
+		agent.LastHBData["policy_state"] = hb.PolicyState
+		agent.LastHBData["group_state"] = hb.GroupState
 	} else {
 		// otherwise, state is always "online"
 		agent.State = Online
@@ -668,13 +958,54 @@ func (svc fleetCommsService) handleHeartbeat(ctx context.Context, thingID string
 		agent.LastHBData["policy_state"] = hb.PolicyState
 		agent.LastHBData["group_state"] = hb.GroupState
 	}
+	if hb.ConfigHash != "" {
+		agent.LastHBData["config_hash"] = hb.ConfigHash
+	}
+	if hb.UpdateResult != nil {
+		svc.logger.Info("agent update result", zap.String("thing_id", thingID), zap.String("target", hb.UpdateResult.Target),
+			zap.String("version", hb.UpdateResult.Version), zap.String("result", hb.UpdateResult.Result))
+		agent.LastHBData["update_result"] = hb.UpdateResult
+	}
+	var previous Agent
+	subscribed := svc.hasHeartbeatSubscribers()
+	if subscribed {
+		// the owner and the previous state of the agent are only needed by the heartbeat subscribers
+		var err error
+		if previous, err = svc.agentRepo.RetrieveByIDWithChannel(context.Background(), thingID, channelID); err != nil {
+			svc.logger.Warn("failed to retrieve the agent of the heartbeat for its subscribers", zap.String("thing_id", thingID), zap.Error(err))
+			subscribed = false
+		}
+	}
 	err := svc.agentRepo.UpdateHeartbeatByIDWithChannel(context.Background(), agent)
 	if err != nil {
 		return err
 	}
+	if subscribed {
+		svc.publishHeartbeat(heartbeatEvent(previous, agent.State, hb.TimeStamp))
+	}
 	return nil
 }
 
+// heartbeatEvent describes the heartbeat of the agent moving it from its stored state to the new one
+func heartbeatEvent(previous Agent, state State, ts time.Time) AgentHeartbeatEvent {
+	event := AgentHeartbeatEvent{
+		Type:          HeartbeatEventHeartbeat,
+		AgentID:       previous.MFThingID,
+		OwnerID:       previous.MFOwnerID,
+		ChannelID:     previous.MFChannelID,
+		State:         state,
+		PreviousState: previous.State,
+		Timestamp:     ts,
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if state != previous.State {
+		event.Type = HeartbeatEventStateChange
+	}
+	return event
+}
+
 func (svc fleetCommsService) handleRPCToCore(ctx context.Context, thingID string, channelID string, payload []byte) error {
 	var versionCheck SchemaVersionCheck
 	if err := json.Unmarshal(payload, &versionCheck); err != nil {
@@ -700,6 +1031,40 @@ func (svc fleetCommsService) handleRPCToCore(ctx context.Context, thingID string
 			svc.logger.Error("notify agent policies failure", zap.Error(err))
 			return nil
 		}
+	case AgentPolicyInventoryRPCFunc:
+		var r AgentPolicyInventoryRPC
+		if err := json.Unmarshal(payload, &r); err != nil {
+			return ErrSchemaMalformed
+		}
+		svc.logger.Info("agent policy inventory",
+			zap.String("agent_id", thingID),
+			zap.String("request_id", r.RequestID),
+			zap.Int("policies", len(r.Payload)),
+			zap.Any("inventory", r.Payload))
+	case AgentEffectiveConfigRPCFunc:
+		var r AgentEffectiveConfigRPC
+		if err := json.Unmarshal(payload, &r); err != nil {
+			return ErrSchemaMalformed
+		}
+		svc.logger.Info("agent effective config",
+			zap.String("agent_id", thingID),
+			zap.String("request_id", r.RequestID),
+			zap.String("version", r.Payload.Version),
+			zap.Any("config", r.Payload.Config),
+			zap.Any("backends", r.Payload.Backends),
+			zap.Any("policies", r.Payload.Policies))
+	case DeadLetterRPCFunc:
+		var r DeadLetterRPC
+		if err := json.Unmarshal(payload, &r); err != nil {
+			return ErrSchemaMalformed
+		}
+		// agents older than core drop the RPCs they do not know, which are only visible here
+		svc.logger.Warn("agent could not handle RPC from core",
+			zap.String("agent_id", thingID),
+			zap.String("request_id", r.RequestID),
+			zap.String("func", r.Payload.Func),
+			zap.String("topic", r.Payload.Topic),
+			zap.String("error", r.Payload.Error))
 	default:
 		svc.logger.Warn("unsupported/unhandled agent RPC, ignoring",
 			zap.String("func", rpc.Func),
@@ -718,6 +1083,15 @@ func (svc fleetCommsService) handleMsgFromAgent(msg messaging.Message) error {
 	// channelID is globally unique across all owners and things, and can therefore substitute for an ownerID (which we do not have here)
 	// mainflux will not allow a thing to communicate on a channelID it does not belong to - thus it is not possible
 	// to brute force a channelID from another tenant without brute forcing all three UUIDs which is a lot of entropy
+	// Agents configured with a signing key also wrap their payload with a HMAC, see MessageVerifier
+	opened, err := svc.verifier.Open(msg.Publisher, msg.Payload, time.Now())
+	if err != nil {
+		cancelFunc()
+		svc.logger.Warn("rejected agent message", zap.String("subtopic", msg.Subtopic),
+			zap.String("thing_id", msg.Publisher), zap.String("channel_id", msg.Channel), zap.Error(err))
+		return err
+	}
+	msg.Payload = opened
 	var payload map[string]interface{}
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return err
@@ -769,6 +1143,13 @@ func (svc fleetCommsService) handleMsgFromAgent(msg messaging.Message) error {
 	return nil
 }
 
+// newRequestID creates the correlation id of an RPC to the agents, the agents log it on receipt and echo it back
+func (svc fleetCommsService) newRequestID(rpcFunc string) string {
+	requestID := uuid.NewString()
+	svc.logger.Debug("sending RPC to agents", zap.String("func", rpcFunc), zap.String("request_id", requestID))
+	return requestID
+}
+
 func (svc fleetCommsService) extendAsyncCtx(method string) (context.Context, context.CancelFunc) {
 	traceId := uuid.NewString()
 	return context.WithCancel(context.WithValue(context.WithValue(svc.asyncContext, "routine", method), "trace-id", traceId))
@@ -788,6 +1169,11 @@ func (svc fleetCommsService) Start() error {
 	if err := svc.agentPubSub.Subscribe(fmt.Sprintf("channels.*.%s", LogTopic), svc.handleMsgFromAgent); err != nil {
 		return err
 	}
+	if svc.heartbeatRelay != nil {
+		if err := svc.heartbeatRelay.Subscribe(svc.asyncContext, svc.heartbeats.Publish); err != nil {
+			return err
+		}
+	}
 	svc.logger.Info("subscribed to agent channels")
 	return nil
 }
@@ -6,9 +6,15 @@ package fleet
 
 const CurrentRPCSchemaVersion = "1.0"
 
+// MinRPCSchemaVersion is the oldest RPC schema version accepted by agents, older known versions are upconverted
+const MinRPCSchemaVersion = "1.0"
+
+// RPC is the envelope of the messages between core and the agents. RequestID correlates an RPC from core with the
+// logs, acks and heartbeats of the agent handling it
 type RPC struct {
 	SchemaVersion string      `json:"schema_version"`
 	Func          string      `json:"func"`
+	RequestID     string      `json:"request_id,omitempty"`
 	Payload       interface{} `json:"payload"`
 }
 
@@ -19,6 +25,7 @@ const GroupMembershipRPCFunc = "group_membership"
 type GroupMembershipRPC struct {
 	SchemaVersion string                    `json:"schema_version"`
 	Func          string                    `json:"func"`
+	RequestID     string                    `json:"request_id,omitempty"`
 	Payload       GroupMembershipRPCPayload `json:"payload"`
 }
 
@@ -38,6 +45,7 @@ const AgentPolicyRPCFunc = "agent_policy"
 type AgentPolicyRPC struct {
 	SchemaVersion string                  `json:"schema_version"`
 	Func          string                  `json:"func"`
+	RequestID     string                  `json:"request_id,omitempty"`
 	Payload       []AgentPolicyRPCPayload `json:"payload"`
 	FullList      bool                    `json:"full_list"`
 }
@@ -52,6 +60,8 @@ type AgentPolicyRPCPayload struct {
 	Format       string      `json:"format"`
 	Version      int32       `json:"version"`
 	Data         interface{} `json:"data"`
+	// DependsOn lists the policies of the same batch which must be applied before this one
+	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 const GroupRemovedRPCFunc = "group_removed"
@@ -59,6 +69,7 @@ const GroupRemovedRPCFunc = "group_removed"
 type GroupRemovedRPC struct {
 	SchemaVersion string                 `json:"schema_version"`
 	Func          string                 `json:"func"`
+	RequestID     string                 `json:"request_id,omitempty"`
 	Payload       GroupRemovedRPCPayload `json:"payload"`
 }
 
@@ -73,6 +84,7 @@ const DatasetRemovedRPCFunc = "dataset_removed"
 type DatasetRemovedRPC struct {
 	SchemaVersion string                   `json:"schema_version"`
 	Func          string                   `json:"func"`
+	RequestID     string                   `json:"request_id,omitempty"`
 	Payload       DatasetRemovedRPCPayload `json:"payload"`
 }
 
@@ -90,6 +102,7 @@ type AgentStopRPCPayload struct {
 type AgentStopRPC struct {
 	SchemaVersion string              `json:"schema_version"`
 	Func          string              `json:"func"`
+	RequestID     string              `json:"request_id,omitempty"`
 	Payload       AgentStopRPCPayload `json:"payload"`
 }
 
@@ -103,9 +116,72 @@ type AgentResetRPCPayload struct {
 type AgentResetRPC struct {
 	SchemaVersion string               `json:"schema_version"`
 	Func          string               `json:"func"`
+	RequestID     string               `json:"request_id,omitempty"`
 	Payload       AgentResetRPCPayload `json:"payload"`
 }
 
+const AgentBackendResetRPCFunc = "agent_backend_reset"
+
+type AgentBackendResetRPCPayload struct {
+	Backend string `json:"backend"`
+	Reason  string `json:"reason"`
+}
+
+type AgentBackendResetRPC struct {
+	SchemaVersion string                      `json:"schema_version"`
+	Func          string                      `json:"func"`
+	RequestID     string                      `json:"request_id,omitempty"`
+	Payload       AgentBackendResetRPCPayload `json:"payload"`
+}
+
+// AgentMaintenanceEnterRPCFunc makes the agent remove the policies of all its backends and report the maintenance
+// state on its heartbeats, AgentMaintenanceExitRPCFunc makes it request its policies again
+const AgentMaintenanceEnterRPCFunc = "agent_maintenance_enter"
+const AgentMaintenanceExitRPCFunc = "agent_maintenance_exit"
+
+type AgentMaintenanceRPCPayload struct {
+	Reason string `json:"reason"`
+}
+
+type AgentMaintenanceRPC struct {
+	SchemaVersion string                     `json:"schema_version"`
+	Func          string                     `json:"func"`
+	RequestID     string                     `json:"request_id,omitempty"`
+	Payload       AgentMaintenanceRPCPayload `json:"payload"`
+}
+
+// AgentUpdateRPCFunc makes the agent report whether a version of itself or of one of its backends is available
+// and, when the agent opted in the self-update, switch to it and restart. The result is reported on the heartbeat
+const AgentUpdateRPCFunc = "agent_update"
+
+// AgentUpdateTargetAgent is the update target of the agent itself, any other target names a backend
+const AgentUpdateTargetAgent = "agent"
+
+type AgentUpdateRPCPayload struct {
+	Target  string `json:"target"`
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+type AgentUpdateRPC struct {
+	SchemaVersion string                `json:"schema_version"`
+	Func          string                `json:"func"`
+	RequestID     string                `json:"request_id,omitempty"`
+	Payload       AgentUpdateRPCPayload `json:"payload"`
+}
+
+const AgentPolicyInventoryReqRPCFunc = "agent_policy_inventory_req"
+
+type AgentPolicyInventoryReqRPCPayload struct {
+	// empty
+}
+
+const AgentEffectiveConfigReqRPCFunc = "agent_effective_config_req"
+
+type AgentEffectiveConfigReqRPCPayload struct {
+	// empty
+}
+
 // Edge -> Core
 
 const GroupMembershipReqRPCFunc = "group_membership_req"
@@ -125,6 +201,7 @@ const AgentMetricsRPCFunc = "agent_metrics"
 type AgentMetricsRPC struct {
 	SchemaVersion string                   `json:"schema_version"`
 	Func          string                   `json:"func"`
+	RequestID     string                   `json:"request_id,omitempty"`
 	Payload       []AgentMetricsRPCPayload `json:"payload"`
 }
 
@@ -136,3 +213,67 @@ type AgentMetricsRPCPayload struct {
 	BEVersion  string   `json:"be_version"`
 	Data       []byte   `json:"data"`
 }
+
+const AgentPolicyInventoryRPCFunc = "agent_policy_inventory"
+
+type AgentPolicyInventoryRPC struct {
+	SchemaVersion string                           `json:"schema_version"`
+	Func          string                           `json:"func"`
+	RequestID     string                           `json:"request_id,omitempty"`
+	Payload       []AgentPolicyInventoryRPCPayload `json:"payload"`
+}
+
+type AgentPolicyInventoryRPCPayload struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Backend    string `json:"backend"`
+	Version    int32  `json:"version"`
+	State      string `json:"state"`
+	BackendErr string `json:"backend_err,omitempty"`
+}
+
+const AgentEffectiveConfigRPCFunc = "agent_effective_config"
+
+// AgentEffectiveConfigRPC carries the configuration an agent is running, once its config file and the
+// provisioning from core are merged, so it can be compared against the intended one
+type AgentEffectiveConfigRPC struct {
+	SchemaVersion string                         `json:"schema_version"`
+	Func          string                         `json:"func"`
+	RequestID     string                         `json:"request_id,omitempty"`
+	Payload       AgentEffectiveConfigRPCPayload `json:"payload"`
+}
+
+type AgentEffectiveConfigRPCPayload struct {
+	Version string `json:"version"`
+	// Config is the resolved agent configuration without its backends and credentials
+	Config   map[string]interface{}           `json:"config"`
+	Backends map[string]EffectiveBackendInfo  `json:"backends"`
+	Policies []AgentPolicyInventoryRPCPayload `json:"policies"`
+}
+
+type EffectiveBackendInfo struct {
+	Version string            `json:"version"`
+	State   string            `json:"state"`
+	Config  map[string]string `json:"config"`
+	// Data holds the backend capabilities, such as the taps it discovered
+	Data map[string]interface{} `json:"data"`
+}
+
+const DeadLetterRPCFunc = "dead_letter"
+
+// DeadLetterRPC carries an RPC from core the agent could not decode or does not support, so core can detect
+// agents running an older version
+type DeadLetterRPC struct {
+	SchemaVersion string               `json:"schema_version"`
+	Func          string               `json:"func"`
+	RequestID     string               `json:"request_id,omitempty"`
+	Payload       DeadLetterRPCPayload `json:"payload"`
+}
+
+type DeadLetterRPCPayload struct {
+	Topic string `json:"topic"`
+	// Func is the func of the RPC, empty when the message could not be decoded
+	Func    string `json:"func,omitempty"`
+	Error   string `json:"error"`
+	Message []byte `json:"message"`
+}
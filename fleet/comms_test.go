@@ -53,7 +53,7 @@ func newFleetService(auth mainflux.AuthServiceClient, url string, agentGroupRepo
 	mfsdk := mfsdk.NewSDK(config)
 	pktvisor.Register(auth, agentRepo)
 	aDone := make(chan bool)
-	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, aDone)
+	return fleet.NewFleetService(logger, auth, agentRepo, agentGroupRepo, agentComms, mfsdk, "", aDone)
 }
 
 func newPoliciesService(auth mainflux.AuthServiceClient) policies.Service {
@@ -111,7 +111,7 @@ func newCommsService(agentGroupRepo fleet.AgentGroupRepository, agentRepo fleet.
 		log.Fatalf("Failed to create PubSub %v", err)
 	}
 
-	return fleet.NewFleetCommsService(logger, policyClient, agentRepo, agentGroupRepo, agentPubSub)
+	return fleet.NewFleetCommsService(logger, policyClient, agentRepo, agentGroupRepo, agentPubSub, nil, nil)
 }
 
 func TestNotifyGroupNewDataset(t *testing.T) {
@@ -298,6 +298,30 @@ func TestNotifyGroupRemoval(t *testing.T) {
 	}
 }
 
+func TestPreviewGroupRemoval(t *testing.T) {
+	agentGroupRepo := flmocks.NewAgentGroupRepository()
+	agentRepo := flmocks.NewAgentRepositoryMock()
+
+	commsSVC := newCommsService(agentGroupRepo, agentRepo)
+
+	thingsServer := newThingsServer(newThingsService(users))
+	fleetSVC := newFleetService(users, thingsServer.URL, agentGroupRepo, agentRepo)
+
+	group, err := createAgentGroup(t, "group5", fleetSVC)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	agent, err := createAgent(t, "agent5", fleetSVC)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	preview, err := commsSVC.PreviewGroupRemoval(context.Background(), group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, group.ID, preview.AgentGroupID)
+	require.Len(t, preview.Agents, 1)
+	assert.Equal(t, agent.MFThingID, preview.Agents[0].MFThingID)
+
+	_, err = agentGroupRepo.RetrieveByID(context.Background(), group.ID, group.MFOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("agent group should not be removed by a preview: %s", err))
+}
+
 func TestNotifyGroupPolicyUpdate(t *testing.T) {
 	agentGroupRepo := flmocks.NewAgentGroupRepository()
 	agentRepo := flmocks.NewAgentRepositoryMock()
@@ -43,6 +43,10 @@ type Capabilities struct {
 	OrbAgent      OrbAgentInfo           `json:"orb_agent"`
 	AgentTags     map[string]string      `json:"agent_tags"`
 	Backends      map[string]BackendInfo `json:"backends"`
+	// RPCFuncs lists the RPC funcs from core the agent handles, it is omitted by the agents older than the field
+	RPCFuncs []string `json:"rpc_funcs,omitempty"`
+	// Signals lists the telemetry signals the agent exports, it is omitted by the agents older than the field
+	Signals []string `json:"signals,omitempty"`
 }
 
 const CurrentHeartbeatSchemaVersion = "1.0"
@@ -54,6 +58,15 @@ type BackendStateInfo struct {
 	LastError         string    `json:"last_error,omitempty"`
 	LastRestartTS     time.Time `json:"last_restart_ts,omitempty"`
 	LastRestartReason string    `json:"last_restart_reason,omitempty"`
+	ResetResult       string    `json:"reset_result,omitempty"`
+}
+
+// AgentUpdateResult is the outcome of an agent update RPC, reported once on the following heartbeat
+type AgentUpdateResult struct {
+	Target    string `json:"target"`
+	Version   string `json:"version"`
+	Available bool   `json:"available"`
+	Result    string `json:"result"`
 }
 
 type PolicyStateInfo struct {
@@ -65,6 +78,8 @@ type PolicyStateInfo struct {
 	LastScrapeBytes int64     `json:"last_scrape_bytes,omitempty"`
 	LastScrapeTS    time.Time `json:"last_scrape_ts,omitempty"`
 	Backend         string    `json:"backend,omitempty"`
+	// RequestID is the id of the RPC from core which applied the current state of the policy
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type GroupStateInfo struct {
@@ -79,4 +94,11 @@ type Heartbeat struct {
 	BackendState  map[string]BackendStateInfo `json:"backend_state"`
 	PolicyState   map[string]PolicyStateInfo  `json:"policy_state"`
 	GroupState    map[string]GroupStateInfo   `json:"group_state"`
+	// RequestID is the id of the last RPC from core handled by the agent since its previous heartbeat
+	RequestID string `json:"request_id,omitempty"`
+	// ConfigHash is the hex encoded SHA-256 of the config applied by the agent: its backends config and
+	// capabilities and the versions of its policies. A change without a push from core tells a drift
+	ConfigHash string `json:"config_hash,omitempty"`
+	// UpdateResult is the outcome of the last agent update RPC handled since the previous heartbeat
+	UpdateResult *AgentUpdateResult `json:"update_result,omitempty"`
 }
@@ -0,0 +1,152 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package fleet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+)
+
+// HeartbeatSubscriptionBuffer is the number of heartbeat events held for a subscriber not keeping up, the
+// subscription is closed with ErrHeartbeatSubscriberLagging once it is full
+const HeartbeatSubscriptionBuffer = 256
+
+const (
+	// HeartbeatEventHeartbeat a heartbeat of an agent keeping its state
+	HeartbeatEventHeartbeat = "heartbeat"
+	// HeartbeatEventStateChange a heartbeat of an agent changing its state, such as going offline
+	HeartbeatEventStateChange = "state_change"
+)
+
+// ErrHeartbeatSubscriberLagging indicates the subscriber did not consume the heartbeat events as fast as they
+// arrived, it has to subscribe again and catch up on the agents state
+var ErrHeartbeatSubscriberLagging = errors.New("heartbeat subscriber is not keeping up with the events")
+
+// AgentHeartbeatEvent is a heartbeat received from an agent on the message bus, PreviousState is the state of the
+// agent before it
+type AgentHeartbeatEvent struct {
+	Type          string
+	AgentID       string
+	OwnerID       string
+	ChannelID     string
+	State         State
+	PreviousState State
+	Timestamp     time.Time
+}
+
+// HeartbeatRelay shares the heartbeat events between the fleet replicas, as the agents heartbeats are consumed
+// by a queue group each replica only sees part of them
+type HeartbeatRelay interface {
+	// Publish sends the event to every replica
+	Publish(event AgentHeartbeatEvent) error
+	// Subscribe hands the events published by every replica, this one included, to deliver until ctx is done
+	Subscribe(ctx context.Context, deliver func(AgentHeartbeatEvent)) error
+}
+
+// HeartbeatSubscription receives the heartbeat events of the agents of an owner until it is closed
+type HeartbeatSubscription struct {
+	ownerID  string
+	agentIDs map[string]bool
+	events   chan AgentHeartbeatEvent
+	broker   *HeartbeatBroker
+	err      error
+	closed   bool
+}
+
+// Events is closed along with the subscription, Err then tells why
+func (s *HeartbeatSubscription) Events() <-chan AgentHeartbeatEvent {
+	return s.events
+}
+
+// Err returns the reason the broker closed the subscription, nil while it is open or when the subscriber closed it
+func (s *HeartbeatSubscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription
+func (s *HeartbeatSubscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s, nil)
+}
+
+func (s *HeartbeatSubscription) matches(event AgentHeartbeatEvent) bool {
+	if event.OwnerID != s.ownerID {
+		return false
+	}
+	return len(s.agentIDs) == 0 || s.agentIDs[event.AgentID]
+}
+
+// HeartbeatBroker fans the heartbeats consumed by fleet out to the subscribers of the agents owners. A subscriber
+// never blocks the others, the one which falls behind is dropped
+type HeartbeatBroker struct {
+	mu     sync.Mutex
+	buffer int
+	subs   map[*HeartbeatSubscription]struct{}
+}
+
+func NewHeartbeatBroker(buffer int) *HeartbeatBroker {
+	if buffer <= 0 {
+		buffer = HeartbeatSubscriptionBuffer
+	}
+	return &HeartbeatBroker{buffer: buffer, subs: make(map[*HeartbeatSubscription]struct{})}
+}
+
+// Subscribe returns a subscription to the heartbeats of the agents of the owner, limited to the agentIDs when set
+func (b *HeartbeatBroker) Subscribe(ownerID string, agentIDs []string) *HeartbeatSubscription {
+	sub := &HeartbeatSubscription{
+		ownerID:  ownerID,
+		agentIDs: make(map[string]bool, len(agentIDs)),
+		events:   make(chan AgentHeartbeatEvent, b.buffer),
+		broker:   b,
+	}
+	for _, id := range agentIDs {
+		sub.agentIDs[id] = true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// HasSubscribers reports whether any subscription is open, so the heartbeats are only enriched for the broker
+// when someone listens
+func (b *HeartbeatBroker) HasSubscribers() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs) > 0
+}
+
+// Publish hands the event to the matching subscriptions without waiting on them
+func (b *HeartbeatBroker) Publish(event AgentHeartbeatEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(sub, ErrHeartbeatSubscriberLagging)
+		}
+	}
+}
+
+// remove closes the subscription, the caller holds the lock
+func (b *HeartbeatBroker) remove(sub *HeartbeatSubscription, err error) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	sub.err = err
+	delete(b.subs, sub)
+	close(sub.events)
+}
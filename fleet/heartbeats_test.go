@@ -0,0 +1,100 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package fleet_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHeartbeatBroker(t *testing.T) {
+	broker := fleet.NewHeartbeatBroker(2)
+	assert.False(t, broker.HasSubscribers())
+
+	all := broker.Subscribe("owner-1", nil)
+	one := broker.Subscribe("owner-1", []string{"agent-2"})
+	slow := broker.Subscribe("owner-1", nil)
+	assert.True(t, broker.HasSubscribers())
+
+	broker.Publish(fleet.AgentHeartbeatEvent{OwnerID: "owner-1", AgentID: "agent-1", State: fleet.Online})
+	broker.Publish(fleet.AgentHeartbeatEvent{OwnerID: "owner-2", AgentID: "agent-2", State: fleet.Online})
+	event := <-all.Events()
+	assert.Equal(t, "agent-1", event.AgentID)
+	assert.Len(t, one.Events(), 0, "only the heartbeats of the subscribed agents of the owner must be received")
+
+	broker.Publish(fleet.AgentHeartbeatEvent{OwnerID: "owner-1", AgentID: "agent-2", Type: fleet.HeartbeatEventStateChange, State: fleet.Offline})
+	event = <-one.Events()
+	assert.Equal(t, fleet.Offline, event.State)
+	<-all.Events()
+
+	// the slow subscriber holds 2 events, the next one drops it without holding the others back
+	broker.Publish(fleet.AgentHeartbeatEvent{OwnerID: "owner-1", AgentID: "agent-3", State: fleet.Online})
+	require.Len(t, slow.Events(), 2)
+	<-slow.Events()
+	<-slow.Events()
+	_, open := <-slow.Events()
+	assert.False(t, open)
+	assert.ErrorIs(t, slow.Err(), fleet.ErrHeartbeatSubscriberLagging)
+	assert.Len(t, all.Events(), 1)
+
+	all.Close()
+	one.Close()
+	one.Close()
+	assert.NoError(t, all.Err())
+	assert.False(t, broker.HasSubscribers())
+}
+
+// recordingRelay keeps the relayed heartbeat events
+type recordingRelay struct {
+	events []fleet.AgentHeartbeatEvent
+}
+
+func (r *recordingRelay) Publish(event fleet.AgentHeartbeatEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingRelay) Subscribe(_ context.Context, _ func(fleet.AgentHeartbeatEvent)) error {
+	return nil
+}
+
+func TestPublishStateChanges(t *testing.T) {
+	now := time.Now()
+	stale := []fleet.Agent{
+		{MFThingID: "agent-1", MFOwnerID: "owner-1", MFChannelID: "channel-1", State: fleet.Online},
+		{MFThingID: "agent-2", MFOwnerID: "owner-2", MFChannelID: "channel-2", State: fleet.Online},
+	}
+
+	comms := fleet.NewFleetCommsService(zap.NewNop(), nil, nil, nil, nil, nil, nil)
+	comms.PublishStateChanges(stale, fleet.Stale, now)
+	sub := comms.SubscribeHeartbeats("owner-1", nil)
+	defer sub.Close()
+	comms.PublishStateChanges(stale, fleet.Stale, now)
+	event := <-sub.Events()
+	assert.Equal(t, fleet.AgentHeartbeatEvent{
+		Type:          fleet.HeartbeatEventStateChange,
+		AgentID:       "agent-1",
+		OwnerID:       "owner-1",
+		ChannelID:     "channel-1",
+		State:         fleet.Stale,
+		PreviousState: fleet.Online,
+		Timestamp:     now,
+	}, event)
+	assert.Len(t, sub.Events(), 0, "only the agents of the owner must be received")
+
+	// the replicas without subscribers relay the events to the subscribers of the others
+	relay := &recordingRelay{}
+	comms = fleet.NewFleetCommsService(zap.NewNop(), nil, nil, nil, nil, nil, relay)
+	comms.PublishStateChanges(stale, fleet.Stale, now)
+	require.Len(t, relay.events, 2)
+	assert.Equal(t, "agent-2", relay.events[1].AgentID)
+	assert.Equal(t, fleet.Stale, relay.events[1].State)
+}
@@ -41,6 +41,14 @@ func (c commsMetricsMiddleware) Stop() error {
 	return c.svc.Stop()
 }
 
+func (c commsMetricsMiddleware) SubscribeHeartbeats(ownerID string, agentIDs []string) *HeartbeatSubscription {
+	return c.svc.SubscribeHeartbeats(ownerID, agentIDs)
+}
+
+func (c commsMetricsMiddleware) PublishStateChanges(previous []Agent, state State, ts time.Time) {
+	c.svc.PublishStateChanges(previous, state, ts)
+}
+
 func (c commsMetricsMiddleware) NotifyAgentNewGroupMembership(ctx context.Context, a Agent, ag AgentGroup) error {
 	defer func(begin time.Time) {
 		labels := []string{
@@ -150,6 +158,24 @@ func (c commsMetricsMiddleware) NotifyGroupRemoval(ctx context.Context, ag Agent
 	return c.svc.NotifyGroupRemoval(ctx, ag)
 }
 
+func (c commsMetricsMiddleware) PreviewGroupRemoval(ctx context.Context, ag AgentGroup) (GroupRemovalPreview, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "PreviewGroupRemoval",
+			"agent_id", "",
+			"agent_name", "",
+			"group_id", ag.ID,
+			"group_name", ag.Name.String(),
+			"owner_id", ag.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.PreviewGroupRemoval(ctx, ag)
+}
+
 func (c commsMetricsMiddleware) NotifyGroupPolicyRemoval(ctx context.Context, ag AgentGroup, policyID string, policyName string, backend string) error {
 	defer func(begin time.Time) {
 		labels := []string{
@@ -222,6 +248,96 @@ func (c commsMetricsMiddleware) NotifyAgentReset(ctx context.Context, agent Agen
 	return c.svc.NotifyAgentReset(ctx, agent, fullReset, reason)
 }
 
+func (c commsMetricsMiddleware) NotifyAgentBackendReset(ctx context.Context, agent Agent, backend string, reason string) error {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "NotifyAgentBackendReset",
+			"agent_id", agent.MFThingID,
+			"agent_name", agent.Name.String(),
+			"group_id", "",
+			"group_name", "",
+			"owner_id", agent.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.NotifyAgentBackendReset(ctx, agent, backend, reason)
+}
+
+func (c commsMetricsMiddleware) NotifyAgentMaintenance(ctx context.Context, agent Agent, enable bool, reason string) error {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "NotifyAgentMaintenance",
+			"agent_id", agent.MFThingID,
+			"agent_name", agent.Name.String(),
+			"group_id", "",
+			"group_name", "",
+			"owner_id", agent.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.NotifyAgentMaintenance(ctx, agent, enable, reason)
+}
+
+func (c commsMetricsMiddleware) NotifyAgentUpdate(ctx context.Context, agent Agent, target string, version string, reason string) error {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "NotifyAgentUpdate",
+			"agent_id", agent.MFThingID,
+			"agent_name", agent.Name.String(),
+			"group_id", "",
+			"group_name", "",
+			"owner_id", agent.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.NotifyAgentUpdate(ctx, agent, target, version, reason)
+}
+
+func (c commsMetricsMiddleware) NotifyAgentPolicyInventoryReq(ctx context.Context, agent Agent) error {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "NotifyAgentPolicyInventoryReq",
+			"agent_id", agent.MFThingID,
+			"agent_name", agent.Name.String(),
+			"group_id", "",
+			"group_name", "",
+			"owner_id", agent.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.NotifyAgentPolicyInventoryReq(ctx, agent)
+}
+
+func (c commsMetricsMiddleware) NotifyAgentEffectiveConfigReq(ctx context.Context, agent Agent) error {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "NotifyAgentEffectiveConfigReq",
+			"agent_id", agent.MFThingID,
+			"agent_name", agent.Name.String(),
+			"group_id", "",
+			"group_name", "",
+			"owner_id", agent.MFOwnerID,
+		}
+
+		c.requestCounter.With(labels...).Add(1)
+		c.requestLatency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+	return c.svc.NotifyAgentEffectiveConfigReq(ctx, agent)
+}
+
 func CommsMetricsMiddleware(svc AgentCommsService, counter metrics.Counter, latency metrics.Histogram) AgentCommsService {
 	return &commsMetricsMiddleware{
 		requestCounter: counter,
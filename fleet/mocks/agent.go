@@ -15,8 +15,8 @@ type agentRepositoryMock struct {
 	agentsMock map[string]fleet.Agent
 }
 
-func (a agentRepositoryMock) SetStaleStatus(_ context.Context, _ time.Duration) (int64, error) {
-	return 0, nil
+func (a agentRepositoryMock) SetStaleStatus(_ context.Context, _ time.Duration) ([]fleet.Agent, error) {
+	return nil, nil
 }
 
 func (a agentRepositoryMock) RetrieveAgentInfoByChannelID(_ context.Context, channelID string) (fleet.Agent, error) {
@@ -28,6 +28,18 @@ func (a agentRepositoryMock) RetrieveAgentInfoByChannelID(_ context.Context, cha
 	return fleet.Agent{}, fleet.ErrNotFound
 }
 
+func (a agentRepositoryMock) RetrieveAgentInfoByChannelIDs(_ context.Context, channelIDs []string) ([]fleet.Agent, error) {
+	var agents []fleet.Agent
+	for _, channelID := range channelIDs {
+		for _, ag := range a.agentsMock {
+			if ag.MFChannelID == channelID {
+				agents = append(agents, ag)
+			}
+		}
+	}
+	return agents, nil
+}
+
 func (a agentRepositoryMock) RetrieveAgentMetadataByOwner(_ context.Context, _ string) ([]types.Metadata, error) {
 	var taps []types.Metadata
 	return taps, nil
@@ -116,6 +128,35 @@ func (a agentRepositoryMock) RetrieveAll(_ context.Context, owner string, pm fle
 	return pageAgentGroup, nil
 }
 
+func (a agentRepositoryMock) RetrieveAllAfterID(_ context.Context, owner string, afterID string, limit uint64, tags types.Tags) ([]fleet.Agent, error) {
+	var agents []fleet.Agent
+	for _, v := range a.agentsMock {
+		if v.MFOwnerID != owner || v.MFThingID <= afterID || !matchTags(v, tags) {
+			continue
+		}
+		agents = append(agents, v)
+	}
+
+	agents = sortAgents(fleet.PageMetadata{Order: "id", Dir: "asc"}, agents)
+	if uint64(len(agents)) > limit {
+		agents = agents[:limit]
+	}
+	return agents, nil
+}
+
+func matchTags(agent fleet.Agent, tags types.Tags) bool {
+	for k, v := range tags {
+		if agent.AgentTags[k] == v {
+			continue
+		}
+		if agent.OrbTags != nil && (*agent.OrbTags)[k] == v {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
 func (a agentRepositoryMock) RetrieveAllByAgentGroupID(_ context.Context, owner string, agentGroupID string, _ bool) ([]fleet.Agent, error) {
 	if agentGroupID == "" || owner == "" {
 		return nil, errors.ErrMalformedEntity
@@ -12,6 +12,8 @@ import (
 	"context"
 	"github.com/orb-community/orb/fleet/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ pb.FleetServiceClient = (*fleetGrpcClientMock)(nil)
@@ -34,6 +36,26 @@ func (g fleetGrpcClientMock) RetrieveAgentGroup(ctx context.Context, in *pb.Agen
 	return &pb.AgentGroupRes{}, nil
 }
 
+func (g fleetGrpcClientMock) ListAgents(ctx context.Context, in *pb.ListAgentsReq, opts ...grpc.CallOption) (*pb.ListAgentsRes, error) {
+	return &pb.ListAgentsRes{}, nil
+}
+
+func (g fleetGrpcClientMock) RetrieveAgentInfoByChannelIDs(ctx context.Context, in *pb.AgentInfoByChannelIDsReq, opts ...grpc.CallOption) (*pb.AgentInfoByChannelIDsRes, error) {
+	return &pb.AgentInfoByChannelIDsRes{Agents: map[string]*pb.AgentInfoRes{}}, nil
+}
+
+func (g fleetGrpcClientMock) RetrieveAgentPolicies(ctx context.Context, in *pb.AgentByIDReq, opts ...grpc.CallOption) (*pb.AgentPoliciesRes, error) {
+	return &pb.AgentPoliciesRes{}, nil
+}
+
+func (g fleetGrpcClientMock) RetrieveAgentCapabilities(ctx context.Context, in *pb.AgentCapabilitiesReq, opts ...grpc.CallOption) (*pb.AgentCapabilitiesRes, error) {
+	return &pb.AgentCapabilitiesRes{}, nil
+}
+
+func (g fleetGrpcClientMock) StreamAgentHeartbeats(ctx context.Context, in *pb.AgentHeartbeatsReq, opts ...grpc.CallOption) (pb.FleetService_StreamAgentHeartbeatsClient, error) {
+	return nil, status.Error(codes.Unimplemented, "heartbeat streams are not mocked")
+}
+
 func NewClient() pb.FleetServiceClient {
 	return &fleetGrpcClientMock{}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/orb-community/orb/fleet"
 	"reflect"
+	"time"
 )
 
 var _ fleet.AgentCommsService = (*agentCommsServiceMock)(nil)
@@ -12,6 +13,21 @@ type agentCommsServiceMock struct {
 	aGroupRepoMock fleet.AgentGroupRepository
 	aRepoMock      fleet.AgentRepository
 	commsMock      map[string][]fleet.Agent
+	heartbeats     *fleet.HeartbeatBroker
+}
+
+func (ac agentCommsServiceMock) SubscribeHeartbeats(ownerID string, agentIDs []string) *fleet.HeartbeatSubscription {
+	return ac.heartbeats.Subscribe(ownerID, agentIDs)
+}
+
+func (ac agentCommsServiceMock) PublishStateChanges(_ []fleet.Agent, _ fleet.State, _ time.Time) {
+}
+
+// PublishHeartbeat hands a heartbeat event to the subscribers of the comms service mock, as if it was consumed
+func PublishHeartbeat(comms fleet.AgentCommsService, event fleet.AgentHeartbeatEvent) {
+	if mock, ok := comms.(*agentCommsServiceMock); ok {
+		mock.heartbeats.Publish(event)
+	}
 }
 
 func (ac agentCommsServiceMock) NotifyGroupDatasetEdit(_ context.Context, _ fleet.AgentGroup, _, _, _ string, _ bool) error {
@@ -22,6 +38,26 @@ func (ac agentCommsServiceMock) NotifyAgentReset(_ context.Context, _ fleet.Agen
 	return nil
 }
 
+func (ac agentCommsServiceMock) NotifyAgentBackendReset(_ context.Context, _ fleet.Agent, _ string, _ string) error {
+	return nil
+}
+
+func (ac agentCommsServiceMock) NotifyAgentMaintenance(_ context.Context, _ fleet.Agent, _ bool, _ string) error {
+	return nil
+}
+
+func (ac agentCommsServiceMock) NotifyAgentUpdate(_ context.Context, _ fleet.Agent, _ string, _ string, _ string) error {
+	return nil
+}
+
+func (ac agentCommsServiceMock) NotifyAgentPolicyInventoryReq(_ context.Context, _ fleet.Agent) error {
+	return nil
+}
+
+func (ac agentCommsServiceMock) NotifyAgentEffectiveConfigReq(_ context.Context, _ fleet.Agent) error {
+	return nil
+}
+
 func (ac agentCommsServiceMock) NotifyAgentStop(_ context.Context, _ fleet.Agent, _ string) error {
 	return nil
 }
@@ -43,6 +79,7 @@ func NewFleetCommService(agentRepo fleet.AgentRepository, agentGroupRepo fleet.A
 		aRepoMock:      agentRepo,
 		aGroupRepoMock: agentGroupRepo,
 		commsMock:      make(map[string][]fleet.Agent),
+		heartbeats:     fleet.NewHeartbeatBroker(fleet.HeartbeatSubscriptionBuffer),
 	}
 }
 
@@ -105,3 +142,15 @@ func (ac agentCommsServiceMock) InactivateDatasetByAgentGroup(_ context.Context,
 func (ac agentCommsServiceMock) NotifyGroupRemoval(_ context.Context, _ fleet.AgentGroup) error {
 	return nil
 }
+
+func (ac agentCommsServiceMock) PreviewGroupRemoval(ctx context.Context, ag fleet.AgentGroup) (fleet.GroupRemovalPreview, error) {
+	agents, err := ac.aRepoMock.RetrieveAllByAgentGroupID(ctx, ag.MFOwnerID, ag.ID, false)
+	if err != nil {
+		return fleet.GroupRemovalPreview{}, err
+	}
+	return fleet.GroupRemovalPreview{
+		AgentGroupID: ag.ID,
+		Agents:       agents,
+		Policies:     []fleet.GroupRemovalPolicy{},
+	}, nil
+}
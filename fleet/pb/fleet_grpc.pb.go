@@ -26,6 +26,11 @@ type FleetServiceClient interface {
 	RetrieveAgentGroup(ctx context.Context, in *AgentGroupByIDReq, opts ...grpc.CallOption) (*AgentGroupRes, error)
 	RetrieveOwnerByChannelID(ctx context.Context, in *OwnerByChannelIDReq, opts ...grpc.CallOption) (*OwnerRes, error)
 	RetrieveAgentInfoByChannelID(ctx context.Context, in *AgentInfoByChannelIDReq, opts ...grpc.CallOption) (*AgentInfoRes, error)
+	ListAgents(ctx context.Context, in *ListAgentsReq, opts ...grpc.CallOption) (*ListAgentsRes, error)
+	RetrieveAgentInfoByChannelIDs(ctx context.Context, in *AgentInfoByChannelIDsReq, opts ...grpc.CallOption) (*AgentInfoByChannelIDsRes, error)
+	RetrieveAgentPolicies(ctx context.Context, in *AgentByIDReq, opts ...grpc.CallOption) (*AgentPoliciesRes, error)
+	RetrieveAgentCapabilities(ctx context.Context, in *AgentCapabilitiesReq, opts ...grpc.CallOption) (*AgentCapabilitiesRes, error)
+	StreamAgentHeartbeats(ctx context.Context, in *AgentHeartbeatsReq, opts ...grpc.CallOption) (FleetService_StreamAgentHeartbeatsClient, error)
 }
 
 type fleetServiceClient struct {
@@ -72,6 +77,74 @@ func (c *fleetServiceClient) RetrieveAgentInfoByChannelID(ctx context.Context, i
 	return out, nil
 }
 
+func (c *fleetServiceClient) ListAgents(ctx context.Context, in *ListAgentsReq, opts ...grpc.CallOption) (*ListAgentsRes, error) {
+	out := new(ListAgentsRes)
+	err := c.cc.Invoke(ctx, "/fleet.FleetService/ListAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) RetrieveAgentInfoByChannelIDs(ctx context.Context, in *AgentInfoByChannelIDsReq, opts ...grpc.CallOption) (*AgentInfoByChannelIDsRes, error) {
+	out := new(AgentInfoByChannelIDsRes)
+	err := c.cc.Invoke(ctx, "/fleet.FleetService/RetrieveAgentInfoByChannelIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) RetrieveAgentPolicies(ctx context.Context, in *AgentByIDReq, opts ...grpc.CallOption) (*AgentPoliciesRes, error) {
+	out := new(AgentPoliciesRes)
+	err := c.cc.Invoke(ctx, "/fleet.FleetService/RetrieveAgentPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) RetrieveAgentCapabilities(ctx context.Context, in *AgentCapabilitiesReq, opts ...grpc.CallOption) (*AgentCapabilitiesRes, error) {
+	out := new(AgentCapabilitiesRes)
+	err := c.cc.Invoke(ctx, "/fleet.FleetService/RetrieveAgentCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) StreamAgentHeartbeats(ctx context.Context, in *AgentHeartbeatsReq, opts ...grpc.CallOption) (FleetService_StreamAgentHeartbeatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[0], "/fleet.FleetService/StreamAgentHeartbeats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fleetServiceStreamAgentHeartbeatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FleetService_StreamAgentHeartbeatsClient interface {
+	Recv() (*AgentHeartbeatEvent, error)
+	grpc.ClientStream
+}
+
+type fleetServiceStreamAgentHeartbeatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *fleetServiceStreamAgentHeartbeatsClient) Recv() (*AgentHeartbeatEvent, error) {
+	m := new(AgentHeartbeatEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // FleetServiceServer is the server API for FleetService service.
 // All implementations must embed UnimplementedFleetServiceServer
 // for forward compatibility
@@ -80,6 +153,11 @@ type FleetServiceServer interface {
 	RetrieveAgentGroup(context.Context, *AgentGroupByIDReq) (*AgentGroupRes, error)
 	RetrieveOwnerByChannelID(context.Context, *OwnerByChannelIDReq) (*OwnerRes, error)
 	RetrieveAgentInfoByChannelID(context.Context, *AgentInfoByChannelIDReq) (*AgentInfoRes, error)
+	ListAgents(context.Context, *ListAgentsReq) (*ListAgentsRes, error)
+	RetrieveAgentInfoByChannelIDs(context.Context, *AgentInfoByChannelIDsReq) (*AgentInfoByChannelIDsRes, error)
+	RetrieveAgentPolicies(context.Context, *AgentByIDReq) (*AgentPoliciesRes, error)
+	RetrieveAgentCapabilities(context.Context, *AgentCapabilitiesReq) (*AgentCapabilitiesRes, error)
+	StreamAgentHeartbeats(*AgentHeartbeatsReq, FleetService_StreamAgentHeartbeatsServer) error
 	mustEmbedUnimplementedFleetServiceServer()
 }
 
@@ -99,6 +177,21 @@ func (UnimplementedFleetServiceServer) RetrieveOwnerByChannelID(context.Context,
 func (UnimplementedFleetServiceServer) RetrieveAgentInfoByChannelID(context.Context, *AgentInfoByChannelIDReq) (*AgentInfoRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveAgentInfoByChannelID not implemented")
 }
+func (UnimplementedFleetServiceServer) ListAgents(context.Context, *ListAgentsReq) (*ListAgentsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedFleetServiceServer) RetrieveAgentInfoByChannelIDs(context.Context, *AgentInfoByChannelIDsReq) (*AgentInfoByChannelIDsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveAgentInfoByChannelIDs not implemented")
+}
+func (UnimplementedFleetServiceServer) RetrieveAgentPolicies(context.Context, *AgentByIDReq) (*AgentPoliciesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveAgentPolicies not implemented")
+}
+func (UnimplementedFleetServiceServer) RetrieveAgentCapabilities(context.Context, *AgentCapabilitiesReq) (*AgentCapabilitiesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveAgentCapabilities not implemented")
+}
+func (UnimplementedFleetServiceServer) StreamAgentHeartbeats(*AgentHeartbeatsReq, FleetService_StreamAgentHeartbeatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAgentHeartbeats not implemented")
+}
 func (UnimplementedFleetServiceServer) mustEmbedUnimplementedFleetServiceServer() {}
 
 // UnsafeFleetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -184,6 +277,99 @@ func _FleetService_RetrieveAgentInfoByChannelID_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FleetService_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fleet.FleetService/ListAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListAgents(ctx, req.(*ListAgentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_RetrieveAgentInfoByChannelIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentInfoByChannelIDsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).RetrieveAgentInfoByChannelIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fleet.FleetService/RetrieveAgentInfoByChannelIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).RetrieveAgentInfoByChannelIDs(ctx, req.(*AgentInfoByChannelIDsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_RetrieveAgentPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentByIDReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).RetrieveAgentPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fleet.FleetService/RetrieveAgentPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).RetrieveAgentPolicies(ctx, req.(*AgentByIDReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_RetrieveAgentCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCapabilitiesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).RetrieveAgentCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fleet.FleetService/RetrieveAgentCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).RetrieveAgentCapabilities(ctx, req.(*AgentCapabilitiesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_StreamAgentHeartbeats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AgentHeartbeatsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).StreamAgentHeartbeats(m, &fleetServiceStreamAgentHeartbeatsServer{stream})
+}
+
+type FleetService_StreamAgentHeartbeatsServer interface {
+	Send(*AgentHeartbeatEvent) error
+	grpc.ServerStream
+}
+
+type fleetServiceStreamAgentHeartbeatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *fleetServiceStreamAgentHeartbeatsServer) Send(m *AgentHeartbeatEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // FleetService_ServiceDesc is the grpc.ServiceDesc for FleetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,7 +393,29 @@ var FleetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveAgentInfoByChannelID",
 			Handler:    _FleetService_RetrieveAgentInfoByChannelID_Handler,
 		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _FleetService_ListAgents_Handler,
+		},
+		{
+			MethodName: "RetrieveAgentInfoByChannelIDs",
+			Handler:    _FleetService_RetrieveAgentInfoByChannelIDs_Handler,
+		},
+		{
+			MethodName: "RetrieveAgentPolicies",
+			Handler:    _FleetService_RetrieveAgentPolicies_Handler,
+		},
+		{
+			MethodName: "RetrieveAgentCapabilities",
+			Handler:    _FleetService_RetrieveAgentCapabilities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAgentHeartbeats",
+			Handler:       _FleetService_StreamAgentHeartbeats_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "fleet/pb/fleet.proto",
 }
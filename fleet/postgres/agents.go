@@ -215,6 +215,61 @@ func (r agentRepository) RetrieveAll(ctx context.Context, owner string, pm fleet
 	return page, nil
 }
 
+func (r agentRepository) RetrieveAllAfterID(ctx context.Context, owner string, afterID string, limit uint64, tags types.Tags) ([]fleet.Agent, error) {
+	t := []byte("{}")
+	tmq := ""
+	if len(tags) > 0 {
+		b, err := json.Marshal(tags)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		t = b
+		tmq = ` AND coalesce(agent_tags || orb_tags, agent_tags, orb_tags) @> :tags`
+	}
+	aq := ""
+	if afterID != "" {
+		aq = ` AND mf_thing_id > :after_id`
+	}
+
+	q := fmt.Sprintf(`SELECT mf_thing_id, name, mf_owner_id, mf_channel_id, ts_created, orb_tags, agent_tags, agent_metadata, state, last_hb_data, ts_last_hb
+				FROM agents
+				WHERE mf_owner_id = :mf_owner_id%s%s
+				ORDER BY mf_thing_id LIMIT :limit;`, aq, tmq)
+	params := map[string]interface{}{
+		"mf_owner_id": owner,
+		"after_id":    afterID,
+		"limit":       limit,
+		"tags":        t,
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && db.ErrInvalid == pqErr.Code.Name() {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []fleet.Agent
+	for rows.Next() {
+		dbth := dbAgent{MFOwnerID: owner}
+		if err := rows.StructScan(&dbth); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+
+		th, err := toAgent(dbth)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrViewEntity, err)
+		}
+
+		items = append(items, th)
+	}
+
+	return items, nil
+}
+
 func (r agentRepository) UpdateDataByIDWithChannel(ctx context.Context, agent fleet.Agent) error {
 	stateColumn, stateValue := getStateParam(agent.State.String())
 	q := fmt.Sprintf(`UPDATE agents SET (agent_tags, agent_metadata %s)         
@@ -481,34 +536,77 @@ func (r agentRepository) RetrieveAgentInfoByChannelID(ctx context.Context, chann
 	return toAgent(ownerScan)
 }
 
-func (r agentRepository) SetStaleStatus(ctx context.Context, duration time.Duration) (int64, error) {
+func (r agentRepository) RetrieveAgentInfoByChannelIDs(ctx context.Context, channelIDs []string) ([]fleet.Agent, error) {
+	q := `select mf_owner_id, name, agent_tags, orb_tags, mf_thing_id, mf_channel_id from agents where mf_channel_id = any(:mf_channel_ids)`
 
-	q := `UPDATE agents SET state = :state WHERE state <> 'stale' AND state <> 'offline' AND ts_last_hb <= now() - :duration * interval '1 seconds';`
+	params := map[string]interface{}{
+		"mf_channel_ids": pq.Array(channelIDs),
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var agents []fleet.Agent
+	for rows.Next() {
+		dbth := dbAgent{}
+		if err := rows.StructScan(&dbth); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		th, err := toAgent(dbth)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		agents = append(agents, th)
+	}
+	return agents, nil
+}
+
+func (r agentRepository) SetStaleStatus(ctx context.Context, duration time.Duration) ([]fleet.Agent, error) {
+
+	// the previous state is read from the locked rows, RETURNING only sees the updated ones
+	q := `UPDATE agents SET state = :state FROM (
+			SELECT mf_thing_id, state FROM agents
+			WHERE state <> 'stale' AND state <> 'offline' AND ts_last_hb <= now() - :duration * interval '1 seconds'
+			FOR UPDATE
+		) previous
+		WHERE agents.mf_thing_id = previous.mf_thing_id
+		RETURNING agents.mf_owner_id, agents.name, agents.mf_thing_id, agents.mf_channel_id, previous.state;`
 
 	params := map[string]interface{}{
 		"duration": duration.Seconds(),
 		"state":    fleet.Stale,
 	}
-	res, err := r.db.NamedExecContext(ctx, q, params)
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if ok {
 			switch pqErr.Code.Name() {
 			case db.ErrInvalid, db.ErrTruncation:
-				return 0, errors.Wrap(errors.ErrMalformedEntity, err)
+				return nil, errors.Wrap(errors.ErrMalformedEntity, err)
 			case db.ErrDuplicate:
-				return 0, errors.Wrap(errors.ErrConflict, err)
+				return nil, errors.Wrap(errors.ErrConflict, err)
 			}
 		}
-		return 0, errors.Wrap(db.ErrUpdateDB, err)
+		return nil, errors.Wrap(db.ErrUpdateDB, err)
 	}
+	defer rows.Close()
 
-	cnt, errdb := res.RowsAffected()
-	if errdb != nil {
-		return 0, errors.Wrap(errors.ErrUpdateEntity, errdb)
+	var agents []fleet.Agent
+	for rows.Next() {
+		dbth := dbAgent{}
+		if err := rows.StructScan(&dbth); err != nil {
+			return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+		th, err := toAgent(dbth)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+		agents = append(agents, th)
 	}
-
-	return cnt, nil
+	return agents, nil
 }
 
 type dbAgent struct {
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -547,6 +548,93 @@ func TestMultiAgentRetrieval(t *testing.T) {
 	}
 }
 
+func TestMultiAgentRetrievalAfterID(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	agentRepo := postgres.NewAgentRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	tags := types.Tags{"node_type": "dns"}
+	subTags := types.Tags{"region": "EU"}
+
+	n := uint64(10)
+	var ids []string
+	for i := uint64(0); i < n; i++ {
+		thID, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chID, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		th := fleet.Agent{
+			MFOwnerID:   oID.String(),
+			MFThingID:   thID.String(),
+			MFChannelID: chID.String(),
+		}
+
+		th.Name, err = types.NewIdentifier(fmt.Sprintf("keyset-agent-%d", i))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		th.AgentTags = tags
+		th.OrbTags = &subTags
+
+		err = agentRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		ids = append(ids, thID.String())
+	}
+	sort.Strings(ids)
+
+	cases := map[string]struct {
+		owner   string
+		afterID string
+		limit   uint64
+		tags    types.Tags
+		size    int
+	}{
+		"retrieve first page of agents": {
+			owner: oID.String(),
+			limit: n / 2,
+			size:  int(n / 2),
+		},
+		"retrieve agents after an id": {
+			owner:   oID.String(),
+			afterID: ids[n-4],
+			limit:   n,
+			size:    3,
+		},
+		"retrieve agents after the last id": {
+			owner:   oID.String(),
+			afterID: ids[n-1],
+			limit:   n,
+			size:    0,
+		},
+		"retrieve agents with agent and orb tags": {
+			owner: oID.String(),
+			limit: n,
+			tags:  types.Tags{"node_type": "dns", "region": "EU"},
+			size:  int(n),
+		},
+		"retrieve agents with non-existing tags": {
+			owner: oID.String(),
+			limit: n,
+			tags:  types.Tags{"field": "value1"},
+			size:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			agents, err := agentRepo.RetrieveAllAfterID(context.Background(), tc.owner, tc.afterID, tc.limit, tc.tags)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", desc, err))
+			assert.Equal(t, tc.size, len(agents), fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, len(agents)))
+			for i := 1; i < len(agents); i++ {
+				assert.Less(t, agents[i-1].MFThingID, agents[i].MFThingID, fmt.Sprintf("%s: agents are not sorted by id\n", desc))
+			}
+			if len(agents) > 0 && tc.afterID != "" {
+				assert.Greater(t, agents[0].MFThingID, tc.afterID, fmt.Sprintf("%s: expected agents after %s\n", desc, tc.afterID))
+			}
+		})
+	}
+}
+
 func TestAgentUpdate(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	agentRepo := postgres.NewAgentRepository(dbMiddleware, logger)
@@ -1034,6 +1122,76 @@ func TestRetrieveAgentInfoByChannelID(t *testing.T) {
 	}
 }
 
+func TestRetrieveAgentInfoByChannelIDs(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	agentRepo := postgres.NewAgentRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	missingID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var channels []string
+	for i := 0; i < 2; i++ {
+		thID, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		chID, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		nameID, err := types.NewIdentifier(fmt.Sprintf("bulkagent%d", i))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		agent := fleet.Agent{
+			Name:        nameID,
+			MFThingID:   thID.String(),
+			MFOwnerID:   oID.String(),
+			MFChannelID: chID.String(),
+			OrbTags:     &types.Tags{"testkey": "testvalue"},
+			AgentTags:   types.Tags{"testkey": "testvalue"},
+		}
+
+		err = agentRepo.Save(context.Background(), agent)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		channels = append(channels, chID.String())
+	}
+
+	cases := map[string]struct {
+		channelIDs []string
+		size       int
+		err        error
+	}{
+		"retrieve agents info by existing channelIDs": {
+			channelIDs: channels,
+			size:       2,
+			err:        nil,
+		},
+		"retrieve agents info omitting non-existent channelIDs": {
+			channelIDs: []string{channels[0], missingID.String()},
+			size:       1,
+			err:        nil,
+		},
+		"retrieve agents info by non-existent channelIDs": {
+			channelIDs: []string{missingID.String()},
+			size:       0,
+			err:        nil,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			agents, err := agentRepo.RetrieveAgentInfoByChannelIDs(context.Background(), tc.channelIDs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+			assert.Equal(t, tc.size, len(agents), fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, len(agents)))
+			for _, ag := range agents {
+				assert.Equal(t, oID.String(), ag.MFOwnerID, fmt.Sprintf("%s: expected %s got %s\n", desc, oID.String(), ag.MFOwnerID))
+				assert.Contains(t, tc.channelIDs, ag.MFChannelID, fmt.Sprintf("%s: unexpected channel %s\n", desc, ag.MFChannelID))
+			}
+		})
+	}
+}
+
 func testSortAgents(t *testing.T, pm fleet.PageMetadata, ths []fleet.Agent) {
 	switch pm.Order {
 	case "name":
@@ -1216,8 +1374,13 @@ func TestSetAgentStale(t *testing.T) {
 			require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 			time.Sleep(2 * time.Second)
 
-			_, err := agentRepo.SetStaleStatus(context.Background(), tc.duration)
+			stale, err := agentRepo.SetStaleStatus(context.Background(), tc.duration)
 			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			if tc.state == fleet.Stale {
+				require.Len(t, stale, 1, fmt.Sprintf("%s: expected the agent changed to stale", desc))
+				assert.Equal(t, tc.agent.MFThingID, stale[0].MFThingID, desc)
+				assert.Equal(t, fleet.Online, stale[0].State, fmt.Sprintf("%s: expected the state before going stale", desc))
+			}
 			agent, err := agentRepo.RetrieveByID(context.Background(), tc.owner, tc.agent.MFThingID)
 			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 			require.Equal(t, tc.state, agent.State, fmt.Sprintf("%s: expected %s got %s", desc, tc.state.String(), agent.State.String()))
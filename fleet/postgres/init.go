@@ -117,6 +117,23 @@ func migrateDB(db *sqlx.DB) error {
 					  AND (agent_groups.tags <@ coalesce(agents.agent_tags || agents.orb_tags, agents.agent_tags, agents.orb_tags))`,
 				},
 			},
+			{
+				Id: "fleet_3",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS agents_mf_owner_id_mf_thing_id_idx ON agents (mf_owner_id, mf_thing_id)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS agents_mf_owner_id_mf_thing_id_idx",
+				},
+			},
+			{
+				Id: "fleet_4",
+				Up: []string{
+					`ALTER TYPE agent_state ADD VALUE IF NOT EXISTS 'maintenance'`,
+				},
+				// enum values cannot be added inside a transaction on older postgres versions
+				DisableTransactionUp: true,
+			},
 		},
 	}
 
@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/orb-community/orb/fleet"
+	"go.uber.org/zap"
+)
+
+// heartbeatRelayChannel is the pub/sub channel the fleet replicas share the heartbeat events on, unlike a stream
+// every subscribed replica receives each event and nothing is kept for the replicas not listening
+const heartbeatRelayChannel = "orb.fleet.heartbeats"
+
+var _ fleet.HeartbeatRelay = (*heartbeatRelay)(nil)
+
+type heartbeatRelay struct {
+	client *redis.Client
+	logger *zap.Logger
+}
+
+// NewHeartbeatRelay shares the heartbeat events between the fleet replicas over redis pub/sub
+func NewHeartbeatRelay(client *redis.Client, logger *zap.Logger) fleet.HeartbeatRelay {
+	return heartbeatRelay{client: client, logger: logger}
+}
+
+func (r heartbeatRelay) Publish(event fleet.AgentHeartbeatEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(context.Background(), heartbeatRelayChannel, payload).Err()
+}
+
+func (r heartbeatRelay) Subscribe(ctx context.Context, deliver func(fleet.AgentHeartbeatEvent)) error {
+	pubsub := r.client.Subscribe(ctx, heartbeatRelayChannel)
+	// waits for the subscription to be confirmed, so no event published after Subscribe returns is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event fleet.AgentHeartbeatEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					r.logger.Warn("failed to decode relayed heartbeat event", zap.Error(err))
+					continue
+				}
+				deliver(event)
+			}
+		}
+	}()
+	return nil
+}
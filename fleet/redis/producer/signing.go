@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package producer
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/orb-community/orb/fleet"
+)
+
+const (
+	// signingNoncePrefix prefixes the keys of the nonces, each expires with the accepted window of its message
+	signingNoncePrefix = "orb.fleet.nonce."
+	// signingSignersKey is the set of the agents that already sent a valid signed message
+	signingSignersKey = "orb.fleet.signers"
+)
+
+var _ fleet.SigningStore = (*signingStore)(nil)
+
+type signingStore struct {
+	client *redis.Client
+}
+
+// NewSigningStore shares the nonces of the signed messages and the signing agents between the fleet replicas
+// over redis
+func NewSigningStore(client *redis.Client) fleet.SigningStore {
+	return signingStore{client: client}
+}
+
+func (s signingStore) UseNonce(nonce string, expires time.Time, now time.Time) (bool, error) {
+	ttl := expires.Sub(now)
+	if ttl <= 0 {
+		return true, nil
+	}
+	return s.client.SetNX(context.Background(), signingNoncePrefix+nonce, 1, ttl).Result()
+}
+
+func (s signingStore) AddSigner(thingID string) error {
+	return s.client.SAdd(context.Background(), signingSignersKey, thingID).Err()
+}
+
+func (s signingStore) IsSigner(thingID string) (bool, error) {
+	return s.client.SIsMember(context.Background(), signingSignersKey, thingID).Result()
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"github.com/go-redis/redis/v8"
 	"github.com/orb-community/orb/fleet"
+	"github.com/orb-community/orb/pkg/types"
 	"go.uber.org/zap"
 )
 
@@ -32,10 +33,46 @@ func (es eventStore) ViewAgentMatchingGroupsByIDInternal(ctx context.Context, ag
 	return es.svc.ViewAgentMatchingGroupsByIDInternal(ctx, agentID, ownerID)
 }
 
+func (es eventStore) ListAgentsInternal(ctx context.Context, ownerID string, pageToken string, pageSize uint64, tags types.Tags) ([]fleet.Agent, string, error) {
+	return es.svc.ListAgentsInternal(ctx, ownerID, pageToken, pageSize, tags)
+}
+
 func (es eventStore) ResetAgent(ct context.Context, token string, agentID string) error {
 	return es.svc.ResetAgent(ct, token, agentID)
 }
 
+func (es eventStore) ResetAgentBackend(ctx context.Context, token string, agentID string, backendName string) error {
+	return es.svc.ResetAgentBackend(ctx, token, agentID, backendName)
+}
+
+func (es eventStore) SetAgentMaintenance(ctx context.Context, token string, agentID string, enable bool) error {
+	return es.svc.SetAgentMaintenance(ctx, token, agentID, enable)
+}
+
+func (es eventStore) UpdateAgentVersion(ctx context.Context, token string, agentID string, target string, version string) error {
+	return es.svc.UpdateAgentVersion(ctx, token, agentID, target, version)
+}
+
+func (es eventStore) RequestAgentPolicyInventory(ctx context.Context, token string, agentID string) error {
+	return es.svc.RequestAgentPolicyInventory(ctx, token, agentID)
+}
+
+func (es eventStore) RequestAgentEffectiveConfig(ctx context.Context, token string, agentID string) error {
+	return es.svc.RequestAgentEffectiveConfig(ctx, token, agentID)
+}
+
+func (es eventStore) ViewAgentsInfoByChannelIDsInternal(ctx context.Context, channelIDs []string) (map[string]fleet.Agent, error) {
+	return es.svc.ViewAgentsInfoByChannelIDsInternal(ctx, channelIDs)
+}
+
+func (es eventStore) SubscribeAgentHeartbeatsInternal(ctx context.Context, ownerID string, agentIDs []string) (*fleet.HeartbeatSubscription, error) {
+	return es.svc.SubscribeAgentHeartbeatsInternal(ctx, ownerID, agentIDs)
+}
+
+func (es eventStore) ViewAgentCapabilitiesByChannelIDInternal(ctx context.Context, ownerID string, channelID string) (fleet.Agent, error) {
+	return es.svc.ViewAgentCapabilitiesByChannelIDInternal(ctx, ownerID, channelID)
+}
+
 func (es eventStore) ViewAgentInfoByChannelIDInternal(ctx context.Context, channelID string) (fleet.Agent, error) {
 	return es.svc.ViewAgentInfoByChannelIDInternal(ctx, channelID)
 }
@@ -118,6 +155,10 @@ func (es eventStore) RemoveAgentGroup(ctx context.Context, token string, groupID
 
 }
 
+func (es eventStore) PreviewAgentGroupRemoval(ctx context.Context, token string, groupID string) (fleet.GroupRemovalPreview, error) {
+	return es.svc.PreviewAgentGroupRemoval(ctx, token, groupID)
+}
+
 func (es eventStore) ValidateAgentGroup(ctx context.Context, token string, s fleet.AgentGroup) (fleet.AgentGroup, error) {
 	return es.svc.ValidateAgentGroup(ctx, token, s)
 }
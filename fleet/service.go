@@ -48,6 +48,8 @@ type fleetService struct {
 	agentGroupRepository AgentGroupRepository
 	// Agent Comms
 	agentComms AgentCommsService
+	// secret the signing key of each agent is derived from, handed out along with its credentials
+	signingSecret string
 
 	aTicker *time.Ticker
 	aDone   chan bool
@@ -97,7 +99,7 @@ func (svc fleetService) thing(token, id string, name string, md map[string]inter
 	return thing, nil
 }
 
-func NewFleetService(logger *zap.Logger, auth mainflux.AuthServiceClient, agentRepo AgentRepository, agentGroupRepository AgentGroupRepository, agentComms AgentCommsService, mfsdk mfsdk.SDK, aDone chan bool) Service {
+func NewFleetService(logger *zap.Logger, auth mainflux.AuthServiceClient, agentRepo AgentRepository, agentGroupRepository AgentGroupRepository, agentComms AgentCommsService, mfsdk mfsdk.SDK, signingSecret string, aDone chan bool) Service {
 
 	aTicker := time.NewTicker(HeartbeatFreq)
 
@@ -108,6 +110,7 @@ func NewFleetService(logger *zap.Logger, auth mainflux.AuthServiceClient, agentR
 		agentGroupRepository: agentGroupRepository,
 		agentComms:           agentComms,
 		mfsdk:                mfsdk,
+		signingSecret:        signingSecret,
 		aTicker:              aTicker,
 		aDone:                aDone,
 	}
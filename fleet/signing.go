@@ -0,0 +1,210 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package fleet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrMessageUnsigned a message without signature was received while signatures are required
+	ErrMessageUnsigned = errors.New("message is not signed")
+	// ErrMessageSignature a message signature did not match its payload
+	ErrMessageSignature = errors.New("invalid message signature")
+	// ErrMessageExpired a signed message timestamp was too far from the current time
+	ErrMessageExpired = errors.New("message timestamp out of the accepted window")
+	// ErrMessageReplayed a signed message nonce was already seen within the accepted window
+	ErrMessageReplayed = errors.New("message nonce already used")
+)
+
+// DefaultMessageMaxSkew is the accepted distance between the timestamp of a signed message and the time it is verified
+const DefaultMessageMaxSkew = 5 * time.Minute
+
+// SignedMessage wraps the payload published by an agent with a HMAC-SHA256 of the timestamp, the nonce and the payload
+type SignedMessage struct {
+	Payload   json.RawMessage `json:"payload"`
+	Timestamp int64           `json:"ts"`
+	Nonce     string          `json:"nonce"`
+	MAC       string          `json:"mac"`
+}
+
+// AgentSigningKey derives the key an agent signs its messages with from the control plane secret and the
+// agent thing id, so the secret itself is never handed to the agents
+func AgentSigningKey(secret string, thingID string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(thingID))
+	return mac.Sum(nil)
+}
+
+func messageMAC(key []byte, ts int64, nonce string, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strconv.FormatInt(ts, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write([]byte(nonce))
+	mac.Write([]byte{'.'})
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignMessage wraps a JSON payload in a SignedMessage stamped with the given time and a random nonce
+func SignMessage(key []byte, payload []byte, now time.Time) ([]byte, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	msg := SignedMessage{
+		Payload:   payload,
+		Timestamp: now.Unix(),
+		Nonce:     hex.EncodeToString(nonce),
+	}
+	msg.MAC = messageMAC(key, msg.Timestamp, msg.Nonce, payload)
+	return json.Marshal(msg)
+}
+
+// SigningStore remembers the nonces of the signed messages and the agents that sign their messages. The fleet
+// replicas consume the agent messages as a queue group, so they must share it to catch a message replayed to
+// another replica, or an agent whose signature is stripped after a restart
+type SigningStore interface {
+	// UseNonce records the nonce until expires, it returns false when the nonce is already recorded
+	UseNonce(nonce string, expires time.Time, now time.Time) (bool, error)
+	// AddSigner records that the agent thingID signs its messages
+	AddSigner(thingID string) error
+	// IsSigner reports whether the agent thingID already sent a valid signed message
+	IsSigner(thingID string) (bool, error)
+}
+
+// MessageVerifier checks the signed messages published by the agents, and the nonces are remembered for the
+// accepted window to reject replays. Unsigned messages are passed through unless signatures are required or the
+// agent already sent a signed message, so stripping the signature of its messages does not downgrade a signing
+// agent
+type MessageVerifier struct {
+	secret  string
+	require bool
+	maxSkew time.Duration
+	store   SigningStore
+}
+
+// NewMessageVerifier returns the verifier of the messages signed with keys derived from secret, a zero maxSkew
+// uses DefaultMessageMaxSkew. A nil store keeps the nonces and the signers in the memory of this process, which
+// only protects a single replica
+func NewMessageVerifier(secret string, require bool, maxSkew time.Duration, store SigningStore) *MessageVerifier {
+	if maxSkew <= 0 {
+		maxSkew = DefaultMessageMaxSkew
+	}
+	if store == nil {
+		store = NewMemorySigningStore(maxSkew)
+	}
+	return &MessageVerifier{
+		secret:  secret,
+		require: require,
+		maxSkew: maxSkew,
+		store:   store,
+	}
+}
+
+// Open returns the payload of a message published by the agent thingID, checking its signature when it is signed.
+// Signed messages are unwrapped without being checked when no secret is set
+func (v *MessageVerifier) Open(thingID string, data []byte, now time.Time) ([]byte, error) {
+	var msg SignedMessage
+	if err := json.Unmarshal(data, &msg); err != nil || msg.MAC == "" {
+		if v == nil {
+			return data, nil
+		}
+		if v.require {
+			return nil, ErrMessageUnsigned
+		}
+		signer, err := v.store.IsSigner(thingID)
+		if err != nil {
+			return nil, err
+		}
+		if signer {
+			return nil, ErrMessageUnsigned
+		}
+		return data, nil
+	}
+	if v == nil || v.secret == "" {
+		return msg.Payload, nil
+	}
+	expected := messageMAC(AgentSigningKey(v.secret, thingID), msg.Timestamp, msg.Nonce, msg.Payload)
+	if !hmac.Equal([]byte(expected), []byte(msg.MAC)) {
+		return nil, ErrMessageSignature
+	}
+	ts := time.Unix(msg.Timestamp, 0)
+	if ts.Before(now.Add(-v.maxSkew)) || ts.After(now.Add(v.maxSkew)) {
+		return nil, ErrMessageExpired
+	}
+	fresh, err := v.store.UseNonce(thingID+"/"+msg.Nonce, ts.Add(v.maxSkew), now)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrMessageReplayed
+	}
+	if err := v.store.AddSigner(thingID); err != nil {
+		return nil, err
+	}
+	return msg.Payload, nil
+}
+
+var _ SigningStore = (*memorySigningStore)(nil)
+
+type memorySigningStore struct {
+	sweepEvery time.Duration
+
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	signers   map[string]struct{}
+	lastSweep time.Time
+}
+
+// NewMemorySigningStore keeps the nonces and the signers in the memory of this process, the expired nonces are
+// swept once per sweepEvery
+func NewMemorySigningStore(sweepEvery time.Duration) SigningStore {
+	return &memorySigningStore{
+		sweepEvery: sweepEvery,
+		nonces:     make(map[string]time.Time),
+		signers:    make(map[string]struct{}),
+	}
+}
+
+func (s *memorySigningStore) UseNonce(nonce string, expires time.Time, now time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) > s.sweepEvery {
+		for n, exp := range s.nonces {
+			if now.After(exp) {
+				delete(s.nonces, n)
+			}
+		}
+		s.lastSweep = now
+	}
+	if exp, ok := s.nonces[nonce]; ok && !now.After(exp) {
+		return false, nil
+	}
+	s.nonces[nonce] = expires
+	return true, nil
+}
+
+func (s *memorySigningStore) AddSigner(thingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signers[thingID] = struct{}{}
+	return nil
+}
+
+func (s *memorySigningStore) IsSigner(thingID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.signers[thingID]
+	return ok, nil
+}
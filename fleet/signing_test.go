@@ -0,0 +1,137 @@
+package fleet_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageVerifier(t *testing.T) {
+	const secret = "signing-secret"
+	const thingID = "2dd9ba7e-3f5d-4c7f-9a6b-7d3f0f4b6c1e"
+	payload := []byte(`{"schema_version":"1.0","state":"online"}`)
+	now := time.Now()
+
+	sign := func(key []byte, at time.Time) []byte {
+		signed, err := fleet.SignMessage(key, payload, at)
+		require.NoError(t, err)
+		return signed
+	}
+	key := fleet.AgentSigningKey(secret, thingID)
+	tampered := func() []byte {
+		var msg fleet.SignedMessage
+		require.NoError(t, json.Unmarshal(sign(key, now), &msg))
+		msg.Payload = []byte(`{"schema_version":"1.0","state":"offline"}`)
+		data, err := json.Marshal(msg)
+		require.NoError(t, err)
+		return data
+	}()
+
+	cases := map[string]struct {
+		verifier *fleet.MessageVerifier
+		data     []byte
+		err      error
+	}{
+		"signed message": {
+			verifier: fleet.NewMessageVerifier(secret, true, 0, nil),
+			data:     sign(key, now),
+		},
+		"unsigned message accepted": {
+			verifier: fleet.NewMessageVerifier(secret, false, 0, nil),
+			data:     payload,
+		},
+		"unsigned message required": {
+			verifier: fleet.NewMessageVerifier(secret, true, 0, nil),
+			data:     payload,
+			err:      fleet.ErrMessageUnsigned,
+		},
+		"signed with the key of another agent": {
+			verifier: fleet.NewMessageVerifier(secret, true, 0, nil),
+			data:     sign(fleet.AgentSigningKey(secret, "another-agent"), now),
+			err:      fleet.ErrMessageSignature,
+		},
+		"tampered payload": {
+			verifier: fleet.NewMessageVerifier(secret, true, 0, nil),
+			data:     tampered,
+			err:      fleet.ErrMessageSignature,
+		},
+		"expired message": {
+			verifier: fleet.NewMessageVerifier(secret, true, time.Minute, nil),
+			data:     sign(key, now.Add(-2*time.Minute)),
+			err:      fleet.ErrMessageExpired,
+		},
+		"signed message without secret": {
+			verifier: nil,
+			data:     sign(key, now),
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			got, err := tc.verifier.Open(thingID, tc.data, now)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, string(payload), string(got))
+		})
+	}
+}
+
+func TestMessageVerifierReplay(t *testing.T) {
+	key := fleet.AgentSigningKey("signing-secret", "agent")
+	now := time.Now()
+	signed, err := fleet.SignMessage(key, []byte(`{"state":"online"}`), now)
+	require.NoError(t, err)
+
+	verifier := fleet.NewMessageVerifier("signing-secret", true, time.Minute, nil)
+	_, err = verifier.Open("agent", signed, now)
+	require.NoError(t, err)
+	_, err = verifier.Open("agent", signed, now.Add(time.Second))
+	assert.ErrorIs(t, err, fleet.ErrMessageReplayed)
+	// once the window is over the replay is rejected as expired
+	_, err = verifier.Open("agent", signed, now.Add(2*time.Minute))
+	assert.ErrorIs(t, err, fleet.ErrMessageExpired)
+}
+
+func TestMessageVerifierDowngrade(t *testing.T) {
+	key := fleet.AgentSigningKey("signing-secret", "agent")
+	payload := []byte(`{"state":"online"}`)
+	now := time.Now()
+	signed, err := fleet.SignMessage(key, payload, now)
+	require.NoError(t, err)
+
+	verifier := fleet.NewMessageVerifier("signing-secret", false, time.Minute, nil)
+	_, err = verifier.Open("agent", payload, now)
+	require.NoError(t, err, "unsigned messages are accepted before the agent signs")
+	_, err = verifier.Open("agent", signed, now)
+	require.NoError(t, err)
+	_, err = verifier.Open("agent", payload, now)
+	assert.ErrorIs(t, err, fleet.ErrMessageUnsigned)
+	_, err = verifier.Open("another-agent", payload, now)
+	assert.NoError(t, err)
+}
+
+func TestMessageVerifierSharedStore(t *testing.T) {
+	key := fleet.AgentSigningKey("signing-secret", "agent")
+	payload := []byte(`{"state":"online"}`)
+	now := time.Now()
+	signed, err := fleet.SignMessage(key, payload, now)
+	require.NoError(t, err)
+
+	// two replicas sharing their store, as the fleet replicas do over redis
+	store := fleet.NewMemorySigningStore(time.Minute)
+	replica := fleet.NewMessageVerifier("signing-secret", false, time.Minute, store)
+	another := fleet.NewMessageVerifier("signing-secret", false, time.Minute, store)
+	_, err = replica.Open("agent", signed, now)
+	require.NoError(t, err)
+	_, err = another.Open("agent", signed, now)
+	assert.ErrorIs(t, err, fleet.ErrMessageReplayed)
+	_, err = another.Open("agent", payload, now)
+	assert.ErrorIs(t, err, fleet.ErrMessageUnsigned)
+}
@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ReadUintQuery reads the value of uint64 http query parameters for a given key
@@ -142,3 +143,32 @@ func prepareStringToUnmarshal(vals []string) string {
 	s = strings.Replace(s, "}", "", -1)
 	return fmt.Sprintf("{ %s }", s)
 }
+
+// ReadDurationQuery reads the value of duration http query parameters for a given key,
+// besides the time.ParseDuration units a "d" suffix is accepted for days, e.g. 30d
+func ReadDurationQuery(r *http.Request, key string, def time.Duration) (time.Duration, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errors.ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	dval := vals[0]
+	if days, ok := strings.CutSuffix(dval, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return 0, errors.ErrInvalidQueryParams
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	val, err := time.ParseDuration(dval)
+	if err != nil || val < 0 {
+		return 0, errors.ErrInvalidQueryParams
+	}
+
+	return val, nil
+}
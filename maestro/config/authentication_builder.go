@@ -2,9 +2,13 @@ package config
 
 import (
 	"github.com/orb-community/orb/maestro/password"
+	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
 	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+	"github.com/orb-community/orb/sinks/authentication_type/clientcert"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
+	"github.com/orb-community/orb/sinks/authentication_type/serviceaccount"
 )
 
 const AuthenticationKey = "authentication"
@@ -25,11 +29,32 @@ func GetAuthService(authType string, service password.EncryptionService) AuthBui
 		return &BearerTokenAuthBuilder{
 			encryptionService: service,
 		}
+	case clientcert.AuthType:
+		return &ClientCertAuthBuilder{
+			encryptionService: service,
+		}
+	case serviceaccount.AuthType:
+		return &ServiceAccountAuthBuilder{
+			encryptionService: service,
+		}
+	case multiauth.AuthType:
+		return &MultiAuthBuilder{
+			encryptionService: service,
+		}
 	}
 
 	return nil
 }
 
+// GetAuthTypeFromConfig returns the authentication type name of a sink config
+func GetAuthTypeFromConfig(config types.Metadata) (string, bool) {
+	if multiauth.IsMultiAuth(config) {
+		return multiauth.AuthType, true
+	}
+	authType, ok := config.GetSubMetadata(AuthenticationKey)["type"].(string)
+	return authType, ok
+}
+
 type BasicAuthBuilder struct {
 	encryptionService password.EncryptionService
 }
@@ -118,3 +143,170 @@ func (b *BearerTokenAuthBuilder) EncodeAuth(config types.Metadata) (types.Metada
 
 	return config, nil
 }
+
+// ClientCertAuthBuilder does not need an extension, the certificate is set on the exporter tls settings
+type ClientCertAuthBuilder struct {
+	encryptionService password.EncryptionService
+}
+
+func (b *ClientCertAuthBuilder) GetExtensionsFromMetadata(_ types.Metadata) (Extensions, string) {
+	return Extensions{}, ""
+}
+
+func (b *ClientCertAuthBuilder) DecodeAuth(config types.Metadata) (types.Metadata, error) {
+	authCfg := config.GetSubMetadata(AuthenticationKey)
+	key := authCfg[clientcert.KeyConfigFeature].(string)
+	decodedKey, err := b.encryptionService.DecodePassword(key)
+	if err != nil {
+		return nil, err
+	}
+	authCfg[clientcert.KeyConfigFeature] = decodedKey
+	config[AuthenticationKey] = authCfg
+	return config, nil
+}
+
+func (b *ClientCertAuthBuilder) EncodeAuth(config types.Metadata) (types.Metadata, error) {
+	authCfg := config.GetSubMetadata(AuthenticationKey)
+	key := authCfg[clientcert.KeyConfigFeature].(string)
+	encodedKey, err := b.encryptionService.EncodePassword(key)
+	if err != nil {
+		return nil, err
+	}
+	authCfg[clientcert.KeyConfigFeature] = encodedKey
+	config[AuthenticationKey] = authCfg
+	return config, nil
+}
+
+// GetTLSFromMetadata returns the exporter tls settings when the sink authenticates with a client certificate
+func GetTLSFromMetadata(config types.Metadata) *TLSClientSetting {
+	var blocks []types.Metadata
+	if multiauth.IsMultiAuth(config) {
+		blocks, _ = multiauth.Blocks(config[AuthenticationKey])
+	} else if authCfg := config.GetSubMetadata(AuthenticationKey); authCfg != nil {
+		blocks = []types.Metadata{authCfg}
+	}
+	for _, block := range blocks {
+		if block["type"] != clientcert.AuthType {
+			continue
+		}
+		cert, _ := block[clientcert.CertConfigFeature].(string)
+		key, _ := block[clientcert.KeyConfigFeature].(string)
+		ca, _ := block[clientcert.CAConfigFeature].(string)
+		return &TLSClientSetting{
+			CAPem:   ca,
+			CertPem: cert,
+			KeyPem:  key,
+		}
+	}
+	return nil
+}
+
+// ServiceAccountAuthBuilder does not need an extension, the key is mounted as the credentials of the collector
+type ServiceAccountAuthBuilder struct {
+	encryptionService password.EncryptionService
+}
+
+func (b *ServiceAccountAuthBuilder) GetExtensionsFromMetadata(_ types.Metadata) (Extensions, string) {
+	return Extensions{}, ""
+}
+
+func (b *ServiceAccountAuthBuilder) DecodeAuth(config types.Metadata) (types.Metadata, error) {
+	authCfg := config.GetSubMetadata(AuthenticationKey)
+	key := authCfg[serviceaccount.KeyConfigFeature].(string)
+	decodedKey, err := b.encryptionService.DecodePassword(key)
+	if err != nil {
+		return nil, err
+	}
+	authCfg[serviceaccount.KeyConfigFeature] = decodedKey
+	config[AuthenticationKey] = authCfg
+	return config, nil
+}
+
+func (b *ServiceAccountAuthBuilder) EncodeAuth(config types.Metadata) (types.Metadata, error) {
+	authCfg := config.GetSubMetadata(AuthenticationKey)
+	key := authCfg[serviceaccount.KeyConfigFeature].(string)
+	encodedKey, err := b.encryptionService.EncodePassword(key)
+	if err != nil {
+		return nil, err
+	}
+	authCfg[serviceaccount.KeyConfigFeature] = encodedKey
+	config[AuthenticationKey] = authCfg
+	return config, nil
+}
+
+// GetServiceAccountKeyFromMetadata returns the service account key of the sink, empty when it does not
+// authenticate with a service account
+func GetServiceAccountKeyFromMetadata(config types.Metadata) string {
+	var blocks []types.Metadata
+	if multiauth.IsMultiAuth(config) {
+		blocks, _ = multiauth.Blocks(config[AuthenticationKey])
+	} else if authCfg := config.GetSubMetadata(AuthenticationKey); authCfg != nil {
+		blocks = []types.Metadata{authCfg}
+	}
+	for _, block := range blocks {
+		if block["type"] != serviceaccount.AuthType {
+			continue
+		}
+		key, _ := block[serviceaccount.KeyConfigFeature].(string)
+		return key
+	}
+	return ""
+}
+
+// MultiAuthBuilder applies every authentication block of a sink, each one with its own builder
+type MultiAuthBuilder struct {
+	encryptionService password.EncryptionService
+}
+
+// GetAllExtensionsFromMetadata returns the extensions of every block and the names of the authenticators
+func (b *MultiAuthBuilder) GetAllExtensionsFromMetadata(c types.Metadata) (Extensions, []string, error) {
+	var extensions Extensions
+	var names []string
+	_, err := b.forEachBlock(c, func(builder AuthBuilderService, blockConfig types.Metadata) (types.Metadata, error) {
+		blockExtensions, name := builder.GetExtensionsFromMetadata(blockConfig)
+		extensions.merge(blockExtensions)
+		if name != "" {
+			names = append(names, name)
+		}
+		return blockConfig, nil
+	})
+	return extensions, names, err
+}
+
+func (b *MultiAuthBuilder) GetExtensionsFromMetadata(c types.Metadata) (Extensions, string) {
+	extensions, names, err := b.GetAllExtensionsFromMetadata(c)
+	if err != nil || len(names) == 0 {
+		return extensions, ""
+	}
+	return extensions, names[0]
+}
+
+func (b *MultiAuthBuilder) DecodeAuth(config types.Metadata) (types.Metadata, error) {
+	return b.forEachBlock(config, AuthBuilderService.DecodeAuth)
+}
+
+func (b *MultiAuthBuilder) EncodeAuth(config types.Metadata) (types.Metadata, error) {
+	return b.forEachBlock(config, AuthBuilderService.EncodeAuth)
+}
+
+func (b *MultiAuthBuilder) forEachBlock(config types.Metadata, apply func(AuthBuilderService, types.Metadata) (types.Metadata, error)) (types.Metadata, error) {
+	blocks, err := multiauth.Blocks(config[AuthenticationKey])
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		authType, _ := block["type"].(string)
+		builder := GetAuthService(authType, b.encryptionService)
+		if builder == nil || authType == multiauth.AuthType {
+			return nil, errors.New("invalid authentication type")
+		}
+		blockConfig, err := apply(builder, types.Metadata{AuthenticationKey: block})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, blockConfig.GetSubMetadata(AuthenticationKey))
+	}
+	config[AuthenticationKey] = result
+	return config, nil
+}
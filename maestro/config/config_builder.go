@@ -2,10 +2,14 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/sinks/backend"
+	"github.com/orb-community/orb/sinks/backend/spool"
 	"gopkg.in/yaml.v2"
 )
 
@@ -362,13 +366,163 @@ func (c *configBuilder) BuildDeploymentConfig(deployment *DeploymentRequest) (st
 		return "", errors.Wrap(errors.New(fmt.Sprintf("failed to build YAML, sink: %s", deployment.SinkID)), err)
 	}
 	manifest = strings.Replace(manifest, "SINK_CONFIG", config, -1)
+	if deployment.Backend == spool.Name {
+		spoolCfg, err := spool.FromMetadata(deployment.Config.GetSubMetadata(spool.ExporterConfigFeature))
+		if err != nil {
+			return "", errors.Wrap(errors.New(fmt.Sprintf("failed to build YAML, sink: %s", deployment.SinkID)), err)
+		}
+		if manifest, err = withSpoolVolume(manifest, spoolCfg.MaxSize); err != nil {
+			return "", err
+		}
+	}
+	if key := GetServiceAccountKeyFromMetadata(deployment.Config); key != "" {
+		return withServiceAccountKey(manifest, key)
+	}
 	return manifest, nil
 }
 
+const (
+	// gcpCredentialsFile is the key of the collector ConfigMap holding the service account key of the sink
+	gcpCredentialsFile = "gcp-credentials.json"
+	gcpCredentialsPath = "/etc/otelcol-contrib/" + gcpCredentialsFile
+	// spoolVolume is the volume of the collector of a store-and-forward sink its exporter queue is kept on
+	spoolVolume    = "spool"
+	spoolDirectory = "/var/lib/otelcol/spool"
+	// spoolStorage is the file storage extension of the exporter queue of a store-and-forward sink
+	spoolStorage = "file_storage/spool"
+	// spoolRequestSize is the size assumed for each write queued by the exporter of a store-and-forward sink,
+	// the queue is bounded to the writes fitting in its volume
+	spoolRequestSize = 1 << 20
+)
+
+// spoolQueueSize bounds the exporter queue of a store-and-forward sink so it stays within its volume of size
+// bytes, of which a tenth is left to the file storage overhead. The kubelet evicts the collector pod once the
+// volume outgrows its size limit, along with the queue. A smaller configured queue size is kept
+func spoolQueueSize(size int64, configured int) int {
+	fits := int(size / 10 * 9 / spoolRequestSize)
+	if fits < 1 {
+		fits = 1
+	}
+	if configured > 0 && configured < fits {
+		return configured
+	}
+	return fits
+}
+
+// withServiceAccountKey adds the service account key to the collector ConfigMap and mounts it as the application
+// default credentials of the collector, which the googlecloud exporter authenticates with
+func withServiceAccountKey(manifest string, key string) (string, error) {
+	var list map[string]interface{}
+	if err := json.Unmarshal([]byte(manifest), &list); err != nil {
+		return "", errors.Wrap(errors.New("failed to parse the collector manifest"), err)
+	}
+	items, _ := list["items"].([]interface{})
+	for _, item := range items {
+		object, _ := item.(map[string]interface{})
+		switch object["kind"] {
+		case "ConfigMap":
+			data, _ := object["data"].(map[string]interface{})
+			if data == nil {
+				return "", errors.New("collector ConfigMap without data")
+			}
+			data[gcpCredentialsFile] = key
+		case "Deployment":
+			container, err := collectorContainer(object)
+			if err != nil {
+				return "", err
+			}
+			mounts, _ := container["volumeMounts"].([]interface{})
+			container["volumeMounts"] = append(mounts, map[string]interface{}{
+				"name":      "data",
+				"readOnly":  true,
+				"mountPath": gcpCredentialsPath,
+				"subPath":   gcpCredentialsFile,
+			})
+			env, _ := container["env"].([]interface{})
+			container["env"] = append(env, map[string]interface{}{
+				"name":  "GOOGLE_APPLICATION_CREDENTIALS",
+				"value": gcpCredentialsPath,
+			})
+		}
+	}
+	withKey, err := json.Marshal(list)
+	if err != nil {
+		return "", err
+	}
+	return string(withKey), nil
+}
+
+// withSpoolVolume mounts a volume bounded to size on the spool directory of the collector, which keeps the queue
+// of its exporter there, bounded by spoolQueueSize. The queue outlives the restarts of the collector container,
+// not the ones of its pod
+func withSpoolVolume(manifest string, size int64) (string, error) {
+	var list map[string]interface{}
+	if err := json.Unmarshal([]byte(manifest), &list); err != nil {
+		return "", errors.Wrap(errors.New("failed to parse the collector manifest"), err)
+	}
+	items, _ := list["items"].([]interface{})
+	for _, item := range items {
+		object, _ := item.(map[string]interface{})
+		if object["kind"] != "Deployment" {
+			continue
+		}
+		container, err := collectorContainer(object)
+		if err != nil {
+			return "", err
+		}
+		mounts, _ := container["volumeMounts"].([]interface{})
+		container["volumeMounts"] = append(mounts, map[string]interface{}{
+			"name":      spoolVolume,
+			"mountPath": spoolDirectory,
+		})
+		spec, _ := object["spec"].(map[string]interface{})
+		template, _ := spec["template"].(map[string]interface{})
+		podSpec, _ := template["spec"].(map[string]interface{})
+		volumes, _ := podSpec["volumes"].([]interface{})
+		podSpec["volumes"] = append(volumes, map[string]interface{}{
+			"name":     spoolVolume,
+			"emptyDir": map[string]interface{}{"sizeLimit": strconv.FormatInt(size, 10)},
+		})
+	}
+	withVolume, err := json.Marshal(list)
+	if err != nil {
+		return "", err
+	}
+	return string(withVolume), nil
+}
+
+// collectorContainer returns the otel-collector container of the collector Deployment
+func collectorContainer(deployment map[string]interface{}) (map[string]interface{}, error) {
+	spec, _ := deployment["spec"].(map[string]interface{})
+	template, _ := spec["template"].(map[string]interface{})
+	podSpec, _ := template["spec"].(map[string]interface{})
+	containers, _ := podSpec["containers"].([]interface{})
+	for _, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok && container["name"] == "otel-collector" {
+			return container, nil
+		}
+	}
+	return nil, errors.New("collector Deployment without the otel-collector container")
+}
+
 // ReturnConfigYamlFromSink this is the main method, which will generate the YAML file from the
 func (c *configBuilder) ReturnConfigYamlFromSink(_ context.Context, kafkaUrlConfig string, deployment *DeploymentRequest) (string, error) {
-	authType := deployment.Config.GetSubMetadata(AuthenticationKey)["type"]
-	authTypeStr, ok := authType.(string)
+	backendName, sinkConfig := deployment.Backend, deployment.Config
+	// the collector of a store-and-forward sink queues its data on disk, its exporter writes to the inner backend
+	spooled := backendName == spool.Name
+	var spoolCfg spool.Config
+	if spooled {
+		var err error
+		if spoolCfg, err = spool.FromMetadata(sinkConfig.GetSubMetadata(spool.ExporterConfigFeature)); err != nil {
+			return "", err
+		}
+		name, innerConfig, ok := spool.InnerSinkConfig(sinkConfig)
+		if !ok {
+			return "", errors.New("spool sink without inner backend")
+		}
+		backendName, sinkConfig = name, innerConfig
+	}
+	authTypeStr, ok := GetAuthTypeFromConfig(sinkConfig)
 	if !ok {
 		return "", errors.New("failed to create config invalid authentication type")
 	}
@@ -377,47 +531,107 @@ func (c *configBuilder) ReturnConfigYamlFromSink(_ context.Context, kafkaUrlConf
 	if authBuilder == nil {
 		return "", errors.New("invalid authentication type")
 	}
-	exporterBuilder := FromStrategy(deployment.Backend)
+	exporterBuilder := FromStrategy(backendName)
 	if exporterBuilder == nil {
 		return "", errors.New("invalid backend")
 	}
-	extensions, extensionName := authBuilder.GetExtensionsFromMetadata(deployment.Config)
-	exporters, exporterName := exporterBuilder.GetExportersFromMetadata(deployment.Config, extensionName)
+	var extensions Extensions
+	var extensionNames []string
+	if multiAuthBuilder, ok := authBuilder.(*MultiAuthBuilder); ok {
+		var err error
+		extensions, extensionNames, err = multiAuthBuilder.GetAllExtensionsFromMetadata(sinkConfig)
+		if err != nil {
+			return "", err
+		}
+		if len(extensionNames) > 1 {
+			return "", errors.New("exporters support a single authenticator extension")
+		}
+	} else {
+		var extensionName string
+		extensions, extensionName = authBuilder.GetExtensionsFromMetadata(sinkConfig)
+		if extensionName != "" {
+			extensionNames = []string{extensionName}
+		}
+	}
+	var extensionName string
+	if len(extensionNames) > 0 {
+		extensionName = extensionNames[0]
+	}
+	exporters, exporterName := exporterBuilder.GetExportersFromMetadata(sinkConfig, extensionName)
 	if exporterName == "" {
 		return "", errors.New("failed to build exporter")
 	}
+	tlsSetting := withTLSMinVersion(withExporterCA(GetTLSFromMetadata(sinkConfig), sinkConfig), c.tlsMinVersion)
+	if tlsSetting != nil {
+		exporters.setTLS(tlsSetting)
+	}
+	if settings := c.httpClient(); settings != nil {
+		exporters.setHTTPClient(settings)
+	}
+	queue := c.sendingQueue()
+	if spooled {
+		if queue == nil {
+			queue = &SendingQueue{Enabled: true}
+		}
+		queue.Storage = spoolStorage
+		queue.QueueSize = spoolQueueSize(spoolCfg.MaxSize, queue.QueueSize)
+	}
+	if queue != nil {
+		if err := exporters.setSendingQueue(queue); err != nil {
+			if spooled {
+				return "", errors.Wrap(errors.New("exporter of the spool sink can not queue its data on disk"), err)
+			}
+			return "", errors.Wrap(errors.New("failed to set the exporter sending queue"), err)
+		}
+	}
+	if spooled {
+		extensions.FileStorage = &FileStorageExtension{Directory: spoolDirectory}
+		extensionNames = append(extensionNames, spoolStorage)
+	}
 
 	// Add prometheus extension for metrics
 	extensions.PProf = &PProfExtension{
 		Endpoint: "0.0.0.0:1888",
 	}
 	serviceConfig := ServiceConfig{
-		Extensions: []string{"pprof", extensionName},
-		Pipelines: struct {
-			Metrics struct {
-				Receivers  []string `json:"receivers" yaml:"receivers"`
-				Processors []string `json:"processors,omitempty" yaml:"processors,omitempty"`
-				Exporters  []string `json:"exporters" yaml:"exporters"`
-			} `json:"metrics" yaml:"metrics"`
-		}{
-			Metrics: struct {
-				Receivers  []string `json:"receivers" yaml:"receivers"`
-				Processors []string `json:"processors,omitempty" yaml:"processors,omitempty"`
-				Exporters  []string `json:"exporters" yaml:"exporters"`
-			}{
+		Extensions: append([]string{"pprof"}, extensionNames...),
+		Pipelines: Pipelines{
+			Metrics: Pipeline{
 				Receivers: []string{"kafka"},
 				Exporters: []string{exporterName},
 			},
 		},
 	}
-	config := OtelConfigFile{
-		Receivers: Receivers{
-			Kafka: KafkaReceiver{
-				Brokers:         []string{kafkaUrlConfig},
-				Topic:           fmt.Sprintf("otlp_metrics-%s", deployment.SinkID),
-				ProtocolVersion: "2.0.0",
-			},
+	receivers := Receivers{
+		Kafka: KafkaReceiver{
+			Brokers:         []string{kafkaUrlConfig},
+			Topic:           fmt.Sprintf("otlp_metrics-%s", deployment.SinkID),
+			ProtocolVersion: "2.0.0",
 		},
+	}
+	if hasSignal(exporterBuilder.Signals(), backend.SignalTraces) {
+		// the traces of the sink are published by the sinker to their own topic
+		receivers.KafkaTraces = &KafkaReceiver{
+			Brokers:         []string{kafkaUrlConfig},
+			Topic:           fmt.Sprintf("otlp_traces-%s", deployment.SinkID),
+			ProtocolVersion: "2.0.0",
+		}
+		serviceConfig.Pipelines.Traces = &Pipeline{
+			Receivers: []string{"kafka/traces"},
+			Exporters: []string{exporterName},
+		}
+	}
+	var processors *Processors
+	if batch := GetBatchFromMetadata(sinkConfig); batch != nil {
+		processors = &Processors{Batch: batch}
+		serviceConfig.Pipelines.Metrics.Processors = []string{"batch"}
+		if serviceConfig.Pipelines.Traces != nil {
+			serviceConfig.Pipelines.Traces.Processors = []string{"batch"}
+		}
+	}
+	config := OtelConfigFile{
+		Receivers:  receivers,
+		Processors: processors,
 		Extensions: &extensions,
 		Exporters:  exporters,
 		Service:    serviceConfig,
@@ -431,3 +645,12 @@ func (c *configBuilder) ReturnConfigYamlFromSink(_ context.Context, kafkaUrlConf
 	s = strings.ReplaceAll(s, "\n", `\n`)
 	return s, nil
 }
+
+func hasSignal(signals []string, signal string) bool {
+	for _, s := range signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,73 @@
 package config
 
-import "github.com/orb-community/orb/pkg/types"
+import (
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"github.com/orb-community/orb/sinks/backend/gcm"
+	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
+)
+
+const (
+	// DefaultBatchSize is the batch size used when a sink only sets the flush interval
+	DefaultBatchSize = 8192
+	// DefaultFlushInterval is the flush interval used when a sink only sets the batch size
+	DefaultFlushInterval = "200ms"
+)
 
 type ExporterConfigService interface {
 	GetExportersFromMetadata(config types.Metadata, authenticationExtensionName string) (Exporters, string)
+	// Signals lists the signals the exporter gets a pipeline for
+	Signals() []string
+}
+
+// GetBatchFromMetadata returns the batch processor for the sink when its exporter sets the batch size or the
+// flush interval, the unset one falls back to the global default
+func GetBatchFromMetadata(config types.Metadata) *BatchProcessor {
+	exporterSubMeta := config.GetSubMetadata("exporter")
+	if exporterSubMeta == nil {
+		return nil
+	}
+	size, sizeOk := backend.BatchSize(exporterSubMeta[backend.BatchSizeConfigFeature])
+	interval, intervalOk := exporterSubMeta[backend.FlushIntervalConfigFeature].(string)
+	if !sizeOk && !intervalOk {
+		return nil
+	}
+	batch := &BatchProcessor{SendBatchSize: DefaultBatchSize, Timeout: DefaultFlushInterval}
+	if sizeOk && size > 0 {
+		batch.SendBatchSize = size
+	}
+	if intervalOk && interval != "" {
+		batch.Timeout = interval
+	}
+	return batch
+}
+
+// withExporterCA sets the CA of the exporter tls settings on the collector ones, so only the connections of
+// the sink trust it. The CA of a client certificate authentication takes precedence
+func withExporterCA(tlsSetting *TLSClientSetting, config types.Metadata) *TLSClientSetting {
+	ca := backend.TLSCA(config.GetSubMetadata("exporter"))
+	if ca == "" {
+		return tlsSetting
+	}
+	if tlsSetting == nil {
+		return &TLSClientSetting{CAPem: ca}
+	}
+	if tlsSetting.CAPem == "" {
+		tlsSetting.CAPem = ca
+	}
+	return tlsSetting
+}
+
+// withTLSMinVersion sets the lowest TLS version the exporter accepts from the remote end of the sink
+func withTLSMinVersion(tlsSetting *TLSClientSetting, minVersion string) *TLSClientSetting {
+	if minVersion == "" {
+		return tlsSetting
+	}
+	if tlsSetting == nil {
+		tlsSetting = &TLSClientSetting{}
+	}
+	tlsSetting.MinVersion = minVersion
+	return tlsSetting
 }
 
 func FromStrategy(backend string) ExporterConfigService {
@@ -12,6 +76,8 @@ func FromStrategy(backend string) ExporterConfigService {
 		return &PrometheusExporterConfig{}
 	case "otlphttp":
 		return &OTLPHTTPExporterBuilder{}
+	case "gcm":
+		return &GoogleCloudExporterBuilder{}
 	}
 
 	return nil
@@ -20,6 +86,10 @@ func FromStrategy(backend string) ExporterConfigService {
 type PrometheusExporterConfig struct {
 }
 
+func (p *PrometheusExporterConfig) Signals() []string {
+	return []string{backend.SignalMetrics}
+}
+
 func (p *PrometheusExporterConfig) GetExportersFromMetadata(config types.Metadata, authenticationExtensionName string) (Exporters, string) {
 	exporterSubMeta := config.GetSubMetadata("exporter")
 	if exporterSubMeta == nil {
@@ -34,14 +104,14 @@ func (p *PrometheusExporterConfig) GetExportersFromMetadata(config types.Metadat
 		return Exporters{
 			PrometheusRemoteWrite: &PrometheusRemoteWriteExporterConfig{
 				Endpoint: endpointCfg,
-				Auth:     Auth{Authenticator: authenticationExtensionName},
+				Auth:     newAuth(authenticationExtensionName),
 			},
 		}, "prometheusremotewrite"
 	}
 	return Exporters{
 		PrometheusRemoteWrite: &PrometheusRemoteWriteExporterConfig{
 			Endpoint: endpointCfg,
-			Auth:     Auth{Authenticator: authenticationExtensionName},
+			Auth:     newAuth(authenticationExtensionName),
 			Headers:  customHeaders.(map[string]interface{}),
 		},
 	}, "prometheusremotewrite"
@@ -50,24 +120,82 @@ func (p *PrometheusExporterConfig) GetExportersFromMetadata(config types.Metadat
 type OTLPHTTPExporterBuilder struct {
 }
 
+func (O *OTLPHTTPExporterBuilder) Signals() []string {
+	return []string{backend.SignalMetrics, backend.SignalTraces}
+}
+
 func (O *OTLPHTTPExporterBuilder) GetExportersFromMetadata(config types.Metadata, authenticationExtensionName string) (Exporters, string) {
 	exporterSubMeta := config.GetSubMetadata("exporter")
 	endpointCfg := exporterSubMeta["endpoint"].(string)
+	encoding := otlpEncoding(exporterSubMeta)
+	compression := otlpCompression(exporterSubMeta)
 	customHeaders, ok := exporterSubMeta["headers"]
 	if !ok || customHeaders == nil {
 		return Exporters{
 			OTLPExporter: &OTLPExporterConfig{
-				Endpoint: endpointCfg,
-				Auth:     Auth{Authenticator: authenticationExtensionName},
+				Endpoint:    endpointCfg,
+				Encoding:    encoding,
+				Compression: compression,
+				Auth:        newAuth(authenticationExtensionName),
 			},
 		}, "otlphttp"
 	} else {
 		return Exporters{
 			OTLPExporter: &OTLPExporterConfig{
-				Endpoint: endpointCfg,
-				Auth:     Auth{Authenticator: authenticationExtensionName},
-				Headers:  customHeaders.(map[string]interface{}),
+				Endpoint:    endpointCfg,
+				Encoding:    encoding,
+				Compression: compression,
+				Auth:        newAuth(authenticationExtensionName),
+				Headers:     customHeaders.(map[string]interface{}),
 			},
 		}, "otlphttp"
 	}
 }
+
+// otlpEncoding maps the sink encoding to the collector otlphttp exporter one, which sets the Content-Type
+// and the serialization of the payloads. Sinks without encoding keep the collector default, protobuf
+func otlpEncoding(exporterSubMeta types.Metadata) string {
+	switch exporterSubMeta[otlphttpexporter.EncodingConfigFeature] {
+	case otlphttpexporter.EncodingProtobuf:
+		return "proto"
+	case otlphttpexporter.EncodingJSON:
+		return "json"
+	default:
+		return ""
+	}
+}
+
+// otlpCompression maps the sink compression to the collector otlphttp exporter one, which compresses the
+// payloads and sets the Content-Encoding. Sinks without compression keep the collector default
+func otlpCompression(exporterSubMeta types.Metadata) string {
+	switch exporterSubMeta[otlphttpexporter.CompressionConfigFeature] {
+	case otlphttpexporter.CompressionNone:
+		return "none"
+	case otlphttpexporter.CompressionGzip:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// GoogleCloudExporterBuilder maps the metrics to Cloud Monitoring time series, the service account key of the
+// sink is handed to the collector as its application default credentials
+type GoogleCloudExporterBuilder struct {
+}
+
+func (g *GoogleCloudExporterBuilder) Signals() []string {
+	return []string{backend.SignalMetrics}
+}
+
+func (g *GoogleCloudExporterBuilder) GetExportersFromMetadata(config types.Metadata, _ string) (Exporters, string) {
+	exporterSubMeta := config.GetSubMetadata("exporter")
+	projectID, ok := exporterSubMeta[gcm.ProjectIDConfigFeature].(string)
+	if !ok || projectID == "" {
+		return Exporters{}, ""
+	}
+	exporter := &GoogleCloudExporterConfig{Project: projectID}
+	if prefix, ok := exporterSubMeta[gcm.MetricPrefixConfigFeature].(string); ok && prefix != "" {
+		exporter.Metric = &GoogleCloudMetricConfig{Prefix: prefix}
+	}
+	return Exporters{GoogleCloud: exporter}, "googlecloud"
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/orb-community/orb/maestro/password"
 	"github.com/orb-community/orb/pkg/types"
 	"go.uber.org/zap"
@@ -18,14 +20,55 @@ type DeploymentRequest struct {
 	Status  string
 }
 
+// ExporterQueue bounds the concurrent writes of the collector exporter of each sink, the writes beyond
+// NumConsumers are queued up to QueueSize and dropped once the queue is full. A NumConsumers of zero keeps the
+// collector defaults
+type ExporterQueue struct {
+	NumConsumers int
+	QueueSize    int
+}
+
+// ConnectionPool bounds the keep-alive connections the collector exporter of each sink keeps to its remote end, the
+// ones idle for longer than IdleConnTimeout are closed. A MaxIdleConns of zero keeps the collector defaults
+type ConnectionPool struct {
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+}
+
 type configBuilder struct {
 	logger            *zap.Logger
 	kafkaUrl          string
 	encryptionService password.EncryptionService
+	queue             ExporterQueue
+	pool              ConnectionPool
+	tlsMinVersion     string
 }
 
 var _ ConfigBuilder = (*configBuilder)(nil)
 
-func NewConfigBuilder(logger *zap.Logger, kafkaUrl string, encryptionService password.EncryptionService) ConfigBuilder {
-	return &configBuilder{logger: logger, kafkaUrl: kafkaUrl, encryptionService: encryptionService}
+func NewConfigBuilder(logger *zap.Logger, kafkaUrl string, encryptionService password.EncryptionService, queue ExporterQueue,
+	pool ConnectionPool, tlsMinVersion string) ConfigBuilder {
+	return &configBuilder{logger: logger, kafkaUrl: kafkaUrl, encryptionService: encryptionService, queue: queue, pool: pool,
+		tlsMinVersion: tlsMinVersion}
+}
+
+// httpClient returns the connection pool settings of the sink exporter, nil to keep the collector defaults. A sink
+// writes to a single remote, so the pool is bounded per host as well
+func (c *configBuilder) httpClient() *HTTPClientSettings {
+	if c.pool.MaxIdleConns <= 0 {
+		return nil
+	}
+	settings := &HTTPClientSettings{MaxIdleConns: c.pool.MaxIdleConns, MaxIdleConnsPerHost: c.pool.MaxIdleConns}
+	if c.pool.IdleConnTimeout > 0 {
+		settings.IdleConnTimeout = c.pool.IdleConnTimeout.String()
+	}
+	return settings
+}
+
+// sendingQueue returns the queue bounding the writes of the sink exporter, nil to keep the collector defaults
+func (c *configBuilder) sendingQueue() *SendingQueue {
+	if c.queue.NumConsumers <= 0 {
+		return nil
+	}
+	return &SendingQueue{Enabled: true, NumConsumers: c.queue.NumConsumers, QueueSize: c.queue.QueueSize}
 }
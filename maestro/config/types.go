@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"time"
 
+	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
 )
 
@@ -66,7 +67,8 @@ type OtelConfigFile struct {
 
 // Receivers will receive only with Kafka for now
 type Receivers struct {
-	Kafka KafkaReceiver `json:"kafka" yaml:"kafka"`
+	Kafka       KafkaReceiver  `json:"kafka" yaml:"kafka"`
+	KafkaTraces *KafkaReceiver `json:"kafka/traces,omitempty" yaml:"kafka/traces,omitempty"`
 }
 
 type KafkaReceiver struct {
@@ -76,6 +78,12 @@ type KafkaReceiver struct {
 }
 
 type Processors struct {
+	Batch *BatchProcessor `json:"batch,omitempty" yaml:"batch,omitempty"`
+}
+
+type BatchProcessor struct {
+	SendBatchSize int    `json:"send_batch_size" yaml:"send_batch_size"`
+	Timeout       string `json:"timeout" yaml:"timeout"`
 }
 
 type Extensions struct {
@@ -85,6 +93,18 @@ type Extensions struct {
 	// Exporters Authentication
 	BasicAuth  *BasicAuthenticationExtension `json:"basicauth/exporter,omitempty" yaml:"basicauth/exporter,omitempty" :"basic_auth"`
 	BearerAuth *BearerTokenAuthExtension     `json:"bearertokenauth/withscheme,omitempty" yaml:"bearertokenauth/withscheme,omitempty"`
+	// Exporters Queue Storage
+	FileStorage *FileStorageExtension `json:"file_storage/spool,omitempty" yaml:"file_storage/spool,omitempty"`
+}
+
+// merge sets the extensions configured in other
+func (e *Extensions) merge(other Extensions) {
+	if other.BasicAuth != nil {
+		e.BasicAuth = other.BasicAuth
+	}
+	if other.BearerAuth != nil {
+		e.BearerAuth = other.BearerAuth
+	}
 }
 
 type HealthCheckExtension struct {
@@ -121,10 +141,58 @@ type BearerTokenAuthExtension struct {
 	Token  string `json:"token" yaml:"token"`
 }
 
+type FileStorageExtension struct {
+	Directory string `json:"directory" yaml:"directory"`
+}
+
 type Exporters struct {
 	PrometheusRemoteWrite *PrometheusRemoteWriteExporterConfig `json:"prometheusremotewrite,omitempty" yaml:"prometheusremotewrite,omitempty"`
 	OTLPExporter          *OTLPExporterConfig                  `json:"otlphttp,omitempty" yaml:"otlphttp,omitempty"`
 	LoggingExporter       *LoggingExporterConfig               `json:"logging,omitempty" yaml:"logging,omitempty"`
+	GoogleCloud           *GoogleCloudExporterConfig           `json:"googlecloud,omitempty" yaml:"googlecloud,omitempty"`
+}
+
+// setSendingQueue sets the sending queue on the configured exporter, the prometheusremotewrite one only queues in
+// memory. It returns an error when the exporter can not queue as requested
+func (e *Exporters) setSendingQueue(queue *SendingQueue) error {
+	switch {
+	case e.OTLPExporter != nil:
+		e.OTLPExporter.SendingQueue = queue
+	case e.GoogleCloud != nil:
+		e.GoogleCloud.SendingQueue = queue
+	case e.PrometheusRemoteWrite != nil:
+		if queue.Storage != "" {
+			return errors.New("prometheusremotewrite exporter only queues in memory")
+		}
+		e.PrometheusRemoteWrite.RemoteWriteQueue = &RemoteWriteQueue{
+			Enabled:      queue.Enabled,
+			QueueSize:    queue.QueueSize,
+			NumConsumers: queue.NumConsumers,
+		}
+	default:
+		return errors.New("exporter has no sending queue")
+	}
+	return nil
+}
+
+// setHTTPClient sets the connection pool settings on the configured exporter, the googlecloud one writes over gRPC
+func (e *Exporters) setHTTPClient(settings *HTTPClientSettings) {
+	if e.PrometheusRemoteWrite != nil {
+		e.PrometheusRemoteWrite.HTTPClientSettings = *settings
+	}
+	if e.OTLPExporter != nil {
+		e.OTLPExporter.HTTPClientSettings = *settings
+	}
+}
+
+// setTLS sets the client certificate, CA and minimum version settings on the configured exporter
+func (e *Exporters) setTLS(tls *TLSClientSetting) {
+	if e.PrometheusRemoteWrite != nil {
+		e.PrometheusRemoteWrite.TLS = tls
+	}
+	if e.OTLPExporter != nil {
+		e.OTLPExporter.TLS = tls
+	}
 }
 
 type LoggingExporterConfig struct {
@@ -134,32 +202,91 @@ type LoggingExporterConfig struct {
 }
 
 type OTLPExporterConfig struct {
-	Endpoint string                 `json:"endpoint" yaml:"endpoint"`
-	Headers  map[string]interface{} `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Auth     struct {
-		Authenticator string `json:"authenticator" yaml:"authenticator"`
-	}
+	Endpoint           string                 `json:"endpoint" yaml:"endpoint"`
+	Encoding           string                 `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Compression        string                 `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Headers            map[string]interface{} `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Auth               *Auth                  `json:"auth,omitempty" yaml:"auth,omitempty"`
+	TLS                *TLSClientSetting      `json:"tls,omitempty" yaml:"tls,omitempty"`
+	SendingQueue       *SendingQueue          `json:"sending_queue,omitempty" yaml:"sending_queue,omitempty"`
+	HTTPClientSettings `yaml:",inline"`
+}
+
+// HTTPClientSettings bounds the keep-alive connections of an HTTP exporter
+type HTTPClientSettings struct {
+	MaxIdleConns        int    `json:"max_idle_conns,omitempty" yaml:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost int    `json:"max_idle_conns_per_host,omitempty" yaml:"max_idle_conns_per_host,omitempty"`
+	IdleConnTimeout     string `json:"idle_conn_timeout,omitempty" yaml:"idle_conn_timeout,omitempty"`
+}
+
+// SendingQueue is the queue of the exporter writes, kept in the storage extension when it is set. NumConsumers
+// bounds the concurrent writes, the writes beyond QueueSize are dropped
+type SendingQueue struct {
+	Enabled      bool   `json:"enabled" yaml:"enabled"`
+	NumConsumers int    `json:"num_consumers,omitempty" yaml:"num_consumers,omitempty"`
+	QueueSize    int    `json:"queue_size,omitempty" yaml:"queue_size,omitempty"`
+	Storage      string `json:"storage,omitempty" yaml:"storage,omitempty"`
+}
+
+// RemoteWriteQueue is the in-memory queue of the prometheusremotewrite exporter writes
+type RemoteWriteQueue struct {
+	Enabled      bool `json:"enabled" yaml:"enabled"`
+	QueueSize    int  `json:"queue_size,omitempty" yaml:"queue_size,omitempty"`
+	NumConsumers int  `json:"num_consumers,omitempty" yaml:"num_consumers,omitempty"`
 }
 
 type Auth struct {
 	Authenticator string `json:"authenticator" yaml:"authenticator"`
 }
 
-type PrometheusRemoteWriteExporterConfig struct {
-	Endpoint string                 `json:"endpoint" yaml:"endpoint"`
-	Headers  map[string]interface{} `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Auth     struct {
-		Authenticator string `json:"authenticator" yaml:"authenticator"`
+// newAuth returns the exporter auth for the given authenticator extension, if any
+func newAuth(authenticationExtensionName string) *Auth {
+	if authenticationExtensionName == "" {
+		return nil
 	}
+	return &Auth{Authenticator: authenticationExtensionName}
+}
+
+type TLSClientSetting struct {
+	CAPem      string `json:"ca_pem,omitempty" yaml:"ca_pem,omitempty"`
+	CertPem    string `json:"cert_pem,omitempty" yaml:"cert_pem,omitempty"`
+	KeyPem     string `json:"key_pem,omitempty" yaml:"key_pem,omitempty"`
+	MinVersion string `json:"min_version,omitempty" yaml:"min_version,omitempty"`
+}
+
+type PrometheusRemoteWriteExporterConfig struct {
+	Endpoint           string                 `json:"endpoint" yaml:"endpoint"`
+	Headers            map[string]interface{} `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Auth               *Auth                  `json:"auth,omitempty" yaml:"auth,omitempty"`
+	TLS                *TLSClientSetting      `json:"tls,omitempty" yaml:"tls,omitempty"`
+	RemoteWriteQueue   *RemoteWriteQueue      `json:"remote_write_queue,omitempty" yaml:"remote_write_queue,omitempty"`
+	HTTPClientSettings `yaml:",inline"`
+}
+
+// GoogleCloudExporterConfig writes the metrics as Cloud Monitoring time series of the project, the exporter
+// authenticates with the application default credentials of the collector
+type GoogleCloudExporterConfig struct {
+	Project      string                   `json:"project" yaml:"project"`
+	Metric       *GoogleCloudMetricConfig `json:"metric,omitempty" yaml:"metric,omitempty"`
+	SendingQueue *SendingQueue            `json:"sending_queue,omitempty" yaml:"sending_queue,omitempty"`
+}
+
+type GoogleCloudMetricConfig struct {
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
 }
 
 type ServiceConfig struct {
-	Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
-	Pipelines  struct {
-		Metrics struct {
-			Receivers  []string `json:"receivers" yaml:"receivers"`
-			Processors []string `json:"processors,omitempty" yaml:"processors,omitempty"`
-			Exporters  []string `json:"exporters" yaml:"exporters"`
-		} `json:"metrics" yaml:"metrics"`
-	} `json:"pipelines" yaml:"pipelines"`
+	Extensions []string  `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	Pipelines  Pipelines `json:"pipelines" yaml:"pipelines"`
+}
+
+type Pipelines struct {
+	Metrics Pipeline  `json:"metrics" yaml:"metrics"`
+	Traces  *Pipeline `json:"traces,omitempty" yaml:"traces,omitempty"`
+}
+
+type Pipeline struct {
+	Receivers  []string `json:"receivers" yaml:"receivers"`
+	Processors []string `json:"processors,omitempty" yaml:"processors,omitempty"`
+	Exporters  []string `json:"exporters" yaml:"exporters"`
 }
@@ -46,10 +46,11 @@ type deploymentService struct {
 var _ Service = (*deploymentService)(nil)
 
 func NewDeploymentService(logger *zap.Logger, repository Repository, kafkaUrl string, encryptionKey string,
-	maestroProducer producer.Producer, kubecontrol kubecontrol.Service) Service {
+	maestroProducer producer.Producer, kubecontrol kubecontrol.Service, queue config.ExporterQueue, pool config.ConnectionPool,
+	tlsMinVersion string) Service {
 	namedLogger := logger.Named("deployment-service")
 	es := password.NewEncryptionService(logger, encryptionKey)
-	cb := config.NewConfigBuilder(namedLogger, kafkaUrl, es)
+	cb := config.NewConfigBuilder(namedLogger, kafkaUrl, es, queue, pool, tlsMinVersion)
 	return &deploymentService{logger: namedLogger,
 		dbRepository:      repository,
 		configBuilder:     cb,
@@ -94,7 +95,7 @@ func (d *deploymentService) encodeConfig(deployment *Deployment) (types.Metadata
 	if authType == nil {
 		return nil, errors.New("deployment do not have authentication information")
 	}
-	value := authType.GetSubMetadata(AuthenticationKey)["type"].(string)
+	value, _ := config.GetAuthTypeFromConfig(authType)
 	authBuilder := d.getAuthBuilder(value)
 	if authBuilder == nil {
 		return nil, errors.New("deployment do not have authentication information")
@@ -111,8 +112,11 @@ func (d *deploymentService) GetDeployment(ctx context.Context, ownerID string, s
 	if authType == nil {
 		return nil, "", errors.New("deployment do not have authentication information")
 	}
-	value := authType.GetSubMetadata(AuthenticationKey)["type"].(string)
+	value, _ := config.GetAuthTypeFromConfig(authType)
 	authBuilder := d.getAuthBuilder(value)
+	if authBuilder == nil {
+		return nil, "", errors.New("deployment do not have authentication information")
+	}
 	decodedDeployment, err := authBuilder.DecodeAuth(deployment.GetConfig())
 	if err != nil {
 		return nil, "", err
@@ -28,13 +28,17 @@ const (
 	namespace       = "otelcollectors"
 )
 
-func NewMonitorService(logger *zap.Logger, sinksClient *sinkspb.SinkServiceClient, mp producer.Producer, kubecontrol *kubecontrol.Service, deploySvc deployment.Service) Service {
+// NewMonitorService returns the monitor of the sink collectors, a sink is set to error after errorThreshold
+// consecutive failed checks of its collector logs
+func NewMonitorService(logger *zap.Logger, sinksClient *sinkspb.SinkServiceClient, mp producer.Producer, kubecontrol *kubecontrol.Service, deploySvc deployment.Service, errorThreshold int) Service {
 	return &monitorService{
 		logger:          logger,
 		sinksClient:     *sinksClient,
 		maestroProducer: mp,
 		kubecontrol:     *kubecontrol,
 		deploymentSvc:   deploySvc,
+		errorThreshold:  errorThreshold,
+		errorCounts:     make(map[string]int),
 	}
 }
 
@@ -49,6 +53,10 @@ type monitorService struct {
 	maestroProducer producer.Producer
 	deploymentSvc   deployment.Service
 	kubecontrol     kubecontrol.Service
+
+	// errorCounts holds the consecutive failed checks of each sink, it is only used by the monitor routine
+	errorThreshold int
+	errorCounts    map[string]int
 }
 
 func (svc *monitorService) Start(ctx context.Context, cancelFunc context.CancelFunc) error {
@@ -159,6 +167,8 @@ func (svc *monitorService) monitorSinks(ctx context.Context) {
 		return
 	}
 	svc.logger.Info("reading logs from collectors", zap.Int("collectors_length", len(sinksRes.Sinks)))
+	monitored := make(map[string]bool, len(runningCollectors))
+	defer svc.pruneErrorCounts(monitored)
 	for _, collector := range runningCollectors {
 		var sink *sinkspb.SinkRes
 		for _, sinkRes := range sinksRes.Sinks {
@@ -186,6 +196,7 @@ func (svc *monitorService) monitorSinks(ctx context.Context) {
 		}
 		data.SinkID = sink.Id
 		data.OwnerID = sink.OwnerID
+		monitored[sink.Id] = true
 		var logsErr error
 		var status string
 		logs, err := svc.getPodLogs(ctx, collector)
@@ -202,6 +213,7 @@ func (svc *monitorService) monitorSinks(ctx context.Context) {
 		if logsErr != nil {
 			logErrMsg = logsErr.Error()
 		}
+		status = svc.applyErrorThreshold(sink.Id, status, logsErr)
 
 		//set the new sink status if changed during checks
 		if sink.GetState() != status && status != "" {
@@ -229,6 +241,35 @@ func (svc *monitorService) monitorSinks(ctx context.Context) {
 	}
 }
 
+// applyErrorThreshold counts the consecutive error checks of a sink, an error status is only returned once the
+// threshold is reached, before that the sink keeps its state. Any other status clears the count
+func (svc *monitorService) applyErrorThreshold(sinkID string, status string, logsErr error) string {
+	if status != "error" {
+		delete(svc.errorCounts, sinkID)
+		return status
+	}
+	svc.errorCounts[sinkID]++
+	count := svc.errorCounts[sinkID]
+	if count < svc.errorThreshold {
+		svc.logger.Warn("sink collector check failed, keeping the sink state until the error threshold",
+			zap.String("SinkID", sinkID),
+			zap.Int("error_count", count),
+			zap.Int("error_threshold", svc.errorThreshold),
+			zap.Error(logsErr))
+		return ""
+	}
+	return status
+}
+
+// pruneErrorCounts removes the error counts of the sinks no longer monitored
+func (svc *monitorService) pruneErrorCounts(monitored map[string]bool) {
+	for sinkID := range svc.errorCounts {
+		if !monitored[sinkID] {
+			delete(svc.errorCounts, sinkID)
+		}
+	}
+}
+
 // analyzeLogs, will check for errors in exporter, and will return as follows
 // for errors 429 will send a "warning" state, plus message of too many requests
 // for any other errors, will add error and message
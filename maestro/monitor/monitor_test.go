@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestApplyErrorThreshold(t *testing.T) {
+	svc := &monitorService{logger: zap.NewNop(), errorThreshold: 3, errorCounts: make(map[string]int)}
+	logsErr := errors.New("error: remote write returned HTTP status 502 Bad Gateway")
+
+	steps := []struct {
+		desc     string
+		status   string
+		expected string
+		count    int
+	}{
+		{desc: "first error keeps the state", status: "error", expected: "", count: 1},
+		{desc: "second error keeps the state", status: "error", expected: "", count: 2},
+		{desc: "success clears the count", status: "active", expected: "active", count: 0},
+		{desc: "error after success keeps the state", status: "error", expected: "", count: 1},
+		{desc: "second consecutive error keeps the state", status: "error", expected: "", count: 2},
+		{desc: "error at the threshold sets the error", status: "error", expected: "error", count: 3},
+		{desc: "error above the threshold keeps the error", status: "error", expected: "error", count: 4},
+		{desc: "single success clears the error", status: "active", expected: "active", count: 0},
+	}
+
+	for _, step := range steps {
+		got := svc.applyErrorThreshold("sink-1", step.status, logsErr)
+		assert.Equal(t, step.expected, got, step.desc)
+		assert.Equal(t, step.count, svc.errorCounts["sink-1"], step.desc)
+	}
+
+	svc.errorCounts["sink-2"] = 2
+	svc.pruneErrorCounts(map[string]bool{"sink-1": true})
+	_, found := svc.errorCounts["sink-2"]
+	assert.False(t, found, "expected the count of the sink no longer monitored to be removed")
+}
+
+func TestApplyErrorThresholdDisabled(t *testing.T) {
+	svc := &monitorService{logger: zap.NewNop(), errorThreshold: 1, errorCounts: make(map[string]int)}
+
+	got := svc.applyErrorThreshold("sink-1", "error", errors.New("sink configuration error"))
+	assert.Equal(t, "error", got, "expected the first error to set the error with a threshold of one")
+}
@@ -98,6 +98,9 @@ func (ls *sinksListenerService) ReceiveMessage(ctx context.Context, msg redis.XM
 				ls.redisClient.XAck(ctx, redis2.StreamSinks, redis2.GroupMaestro, msg.ID)
 			}
 		}()
+	case redis2.SinkSnapshot:
+		// maestro keeps the deployments on its own database, snapshots are for consumers bootstrapping from the stream
+		ls.redisClient.XAck(ctx, redis2.StreamSinks, redis2.GroupMaestro, msg.ID)
 	case <-ctx.Done():
 		return errors.New("stopped listening to sinks, due to context cancellation")
 	}
@@ -14,6 +14,7 @@ import (
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
+	maestroconfig "github.com/orb-community/orb/maestro/config"
 	"github.com/orb-community/orb/maestro/deployment"
 	"github.com/orb-community/orb/maestro/kubecontrol"
 	"github.com/orb-community/orb/maestro/monitor"
@@ -48,13 +49,20 @@ type maestroService struct {
 }
 
 func NewMaestroService(logger *zap.Logger, streamRedisClient *redis.Client, sinkerRedisClient *redis.Client,
-	sinksGrpcClient sinkspb.SinkServiceClient, otelCfg config.OtelConfig, db *sqlx.DB, svcCfg config.BaseSvcConfig) Service {
+	sinksGrpcClient sinkspb.SinkServiceClient, otelCfg config.OtelConfig, db *sqlx.DB, svcCfg config.BaseSvcConfig,
+	sinkErrorCfg config.SinkErrorConfig, sinkWriteCfg config.SinkWriteConfig, sinkConnPoolCfg config.SinkConnPoolConfig,
+	tlsCfg config.TLSConfig) Service {
 	kubectr := kubecontrol.NewService(logger)
 	repo := deployment.NewRepositoryService(db, logger)
 	maestroProducer := producer.NewMaestroProducer(logger, streamRedisClient)
-	deploymentService := deployment.NewDeploymentService(logger, repo, otelCfg.KafkaUrl, svcCfg.EncryptionKey, maestroProducer, kubectr)
+	// the collector exporter of each sink bounds its concurrent writes and its keep-alive connections to the remote end,
+	// and accepts no TLS version below the configured minimum
+	queue := maestroconfig.ExporterQueue{NumConsumers: sinkWriteCfg.MaxInFlight, QueueSize: sinkWriteCfg.MaxQueued}
+	pool := maestroconfig.ConnectionPool{MaxIdleConns: sinkConnPoolCfg.MaxIdleConns, IdleConnTimeout: sinkConnPoolCfg.IdleConnTimeout}
+	deploymentService := deployment.NewDeploymentService(logger, repo, otelCfg.KafkaUrl, svcCfg.EncryptionKey, maestroProducer, kubectr,
+		queue, pool, tlsCfg.MinVersion)
 	ps := producer.NewMaestroProducer(logger, streamRedisClient)
-	monitorService := monitor.NewMonitorService(logger, &sinksGrpcClient, ps, &kubectr, deploymentService)
+	monitorService := monitor.NewMonitorService(logger, &sinksGrpcClient, ps, &kubectr, deploymentService, sinkErrorCfg.Threshold)
 	eventService := service.NewEventService(logger, deploymentService, &sinksGrpcClient)
 	eventService = service.NewTracingService(logger, eventService,
 		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
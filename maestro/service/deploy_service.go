@@ -107,6 +107,11 @@ func (d *eventService) HandleSinkDelete(ctx context.Context, event maestroredis.
 }
 
 func (d *eventService) HandleSinkActivity(ctx context.Context, event maestroredis.SinkerUpdateEvent) error {
+	if event.State == "circuit_open" {
+		// the sinker stopped writing to the sink for a cooldown, the collector is kept running
+		d.logger.Info("sink circuit is open", zap.String("sink-id", event.SinkID))
+		return d.deploymentService.UpdateStatus(ctx, event.OwnerID, event.SinkID, "circuit_open", "")
+	}
 	if event.State != "active" {
 		d.logger.Error("trying to deploy sink that is not active", zap.String("sink-id", event.SinkID),
 			zap.String("status", event.State))
@@ -167,6 +172,9 @@ func (d *eventService) HandleSinkActivity(ctx context.Context, event maestroredi
 			return err
 		}
 		return nil
+	} else if deploymentEntry.LastStatus == "circuit_open" {
+		d.logger.Info("sink circuit is closed", zap.String("sink-id", event.SinkID))
+		return d.deploymentService.UpdateStatus(ctx, event.OwnerID, event.SinkID, "active", "")
 	} else {
 		d.logger.Warn("collector is already running, skipping", zap.String("last_status", deploymentEntry.LastStatus))
 		return nil
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"github.com/orb-community/orb/maestro/config"
 	"github.com/orb-community/orb/maestro/deployment"
 	"github.com/orb-community/orb/maestro/redis"
 	"github.com/orb-community/orb/pkg/types"
@@ -49,7 +50,7 @@ func TestEventService_HandleSinkActivity(t *testing.T) {
 	}
 	logger := zap.NewNop()
 	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092",
-		"MY_SECRET", NewTestProducer(logger), NewTestKubeCtr(logger))
+		"MY_SECRET", NewTestProducer(logger), NewTestKubeCtr(logger), config.ExporterQueue{}, config.ConnectionPool{}, "")
 	d := NewEventService(logger, deploymentService, nil)
 	err := d.HandleSinkCreate(context.Background(), redis.SinksUpdateEvent{
 		SinkID:  "sink22",
@@ -115,7 +116,7 @@ func TestEventService_HandleSinkIdle(t *testing.T) {
 	}
 	logger := zap.NewNop()
 	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger),
-		NewTestKubeCtr(logger))
+		NewTestKubeCtr(logger), config.ExporterQueue{}, config.ConnectionPool{}, "")
 	v := NewSinksPb(logger)
 	d := NewEventService(logger, deploymentService, &v)
 	err := d.HandleSinkCreate(context.Background(), redis.SinksUpdateEvent{
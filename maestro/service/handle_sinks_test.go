@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"github.com/orb-community/orb/maestro/config"
 	"github.com/orb-community/orb/maestro/deployment"
 	"github.com/orb-community/orb/maestro/redis"
 	"github.com/orb-community/orb/pkg/types"
@@ -58,7 +59,7 @@ func Test_eventService_HandleSinkCreate(t *testing.T) {
 		},
 	}
 	logger := zap.NewNop()
-	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger), nil)
+	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger), nil, config.ExporterQueue{}, config.ConnectionPool{}, "")
 	d := NewEventService(logger, deploymentService, nil)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -127,7 +128,7 @@ func TestEventService_HandleSinkUpdate(t *testing.T) {
 	}
 	logger := zap.NewNop()
 	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger),
-		NewTestKubeCtr(logger))
+		NewTestKubeCtr(logger), config.ExporterQueue{}, config.ConnectionPool{}, "")
 	v := NewSinksPb(logger)
 	d := NewEventService(logger, deploymentService, &v)
 	for _, tt := range tests {
@@ -194,7 +195,7 @@ func TestEventService_HandleSinkDelete(t *testing.T) {
 		},
 	}
 	logger := zap.NewNop()
-	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger), nil)
+	deploymentService := deployment.NewDeploymentService(logger, NewFakeRepository(logger), "kafka:9092", "MY_SECRET", NewTestProducer(logger), nil, config.ExporterQueue{}, config.ConnectionPool{}, "")
 	d := NewEventService(logger, deploymentService, nil)
 	err := d.HandleSinkCreate(context.Background(), redis.SinksUpdateEvent{
 		SinkID:  "sink2-1",
@@ -5,6 +5,7 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"time"
 
@@ -33,6 +34,11 @@ type NatsConfig struct {
 type OtelConfig struct {
 	Enable   string `mapstructure:"enable"`
 	KafkaUrl string `mapstructure:"kafka_url"`
+	KafkaTLS string `mapstructure:"kafka_tls"`
+}
+
+type TLSConfig struct {
+	MinVersion string `mapstructure:"min_version"`
 }
 
 type CacheConfig struct {
@@ -50,6 +56,9 @@ type EsConfig struct {
 	Pass     string `mapstructure:"pass"`
 	DB       string `mapstructure:"db"`
 	Consumer string `mapstructure:"consumer"`
+	// StreamLen is the max length the published streams are trimmed to, approximately unless StreamApprox is false
+	StreamLen    int64 `mapstructure:"stream_len"`
+	StreamApprox bool  `mapstructure:"stream_approx"`
 }
 
 type JaegerConfig struct {
@@ -57,7 +66,95 @@ type JaegerConfig struct {
 }
 
 type EncryptionKey struct {
-	Key string `mapstructure:"key"`
+	Key      string `mapstructure:"key"`
+	PerOwner bool   `mapstructure:"per_owner"`
+}
+
+// SecretStoreConfig sets the external secret manager keeping the sink secrets, an empty Type keeps them
+// encrypted in the database. Type is either "vault", for a KV v2 engine, or "aws", for AWS Secrets Manager
+type SecretStoreConfig struct {
+	Type         string `mapstructure:"type"`
+	VaultAddress string `mapstructure:"vault_address"`
+	VaultToken   string `mapstructure:"vault_token"`
+	VaultMount   string `mapstructure:"vault_mount"`
+	AWSRegion    string `mapstructure:"aws_region"`
+	// Prefix is the path, or the secret name prefix, the secrets are created under
+	Prefix string `mapstructure:"prefix"`
+}
+
+type BackendsConfig struct {
+	Enabled       string `mapstructure:"enabled"`
+	SecretHeaders string `mapstructure:"secret_headers"`
+	// RequireHTTPS rejects the sinks exporting to plaintext http endpoints, unless AllowInsecureOverride lets a
+	// sink opt out with allow_insecure
+	RequireHTTPS          bool `mapstructure:"require_https"`
+	AllowInsecureOverride bool `mapstructure:"allow_insecure_override"`
+	// NonStable is the comma separated list of the backends which are not stable the sinks can be created on
+	NonStable string `mapstructure:"non_stable"`
+	// NamePattern is the regex the sink names must match, on top of being identifiers, the default allows any
+	// identifier
+	NamePattern string `mapstructure:"name_pattern"`
+	// ProbeAllowPrivate lets the live probe of the sinks reach the loopback, private and link-local addresses
+	ProbeAllowPrivate bool `mapstructure:"probe_allow_private"`
+}
+
+// TagLimitsConfig bounds the tags written to a sink, a zero limit is not enforced
+type TagLimitsConfig struct {
+	MaxKeys        int `mapstructure:"max_keys"`
+	MaxKeyLength   int `mapstructure:"max_key_length"`
+	MaxValueLength int `mapstructure:"max_value_length"`
+}
+
+// SinkErrorConfig sets how many consecutive failed checks of a sink collector are needed before the sink
+// is set to error, a threshold of one or less sets it on the first failure
+type SinkErrorConfig struct {
+	Threshold int `mapstructure:"threshold"`
+}
+
+// SinkWriteConfig bounds the concurrent writes to each sink, the writes beyond MaxInFlight are queued up to MaxQueued
+// and dropped once the queue of the sink is full. The sinker bounds its writes to the Kafka fan-out with it, maestro
+// the writes of the collector exporter of the sink to its remote end
+type SinkWriteConfig struct {
+	MaxInFlight int `mapstructure:"max_in_flight"`
+	MaxQueued   int `mapstructure:"max_queued"`
+}
+
+// SinkConnPoolConfig bounds the keep-alive connections the collector exporter of each sink keeps open to its remote
+// end, the connections idle for longer than IdleConnTimeout are closed. A MaxIdleConns of zero keeps the defaults
+type SinkConnPoolConfig struct {
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	IdleConnTimeout time.Duration `mapstructure:"idle_conn_timeout"`
+}
+
+// EventOutboxConfig enables the outbox keeping the events which could not be published to the event stream,
+// they are replayed on every replay interval
+type EventOutboxConfig struct {
+	Enable         bool          `mapstructure:"enable"`
+	ReplayInterval time.Duration `mapstructure:"replay_interval"`
+}
+
+// DeleteProtectionConfig enables the refusal to delete, unless forced, the sinks which datasets still route to
+type DeleteProtectionConfig struct {
+	Enable bool `mapstructure:"enable"`
+}
+
+// MessageSigningConfig sets the verification of the messages signed by the agents, the key of each agent is
+// derived from Secret and its thing id and is returned along with the agent credentials on creation. Unsigned
+// messages are rejected when Require is set, otherwise only those of the agents that already signed a message
+type MessageSigningConfig struct {
+	Secret  string        `mapstructure:"secret"`
+	Require bool          `mapstructure:"require"`
+	MaxSkew time.Duration `mapstructure:"max_skew"`
+}
+
+// RateLimitConfig holds the per-owner token bucket of each HTTP endpoint class, a rate of zero disables the limit
+type RateLimitConfig struct {
+	ReadRate      float64 `mapstructure:"read_rate"`
+	ReadBurst     int     `mapstructure:"read_burst"`
+	WriteRate     float64 `mapstructure:"write_rate"`
+	WriteBurst    int     `mapstructure:"write_burst"`
+	ValidateRate  float64 `mapstructure:"validate_rate"`
+	ValidateBurst int     `mapstructure:"validate_burst"`
 }
 
 type BaseSvcConfig struct {
@@ -78,6 +175,7 @@ type PostgresConfig struct {
 	SSLCert     string `mapstructure:"ssl_cert"`
 	SSLKey      string `mapstructure:"ssl_key"`
 	SSLRootCert string `mapstructure:"ssl_root_cert"`
+	ReplicaURL  string `mapstructure:"replica_url"`
 }
 
 func LoadMFSDKConfig(prefix string) MFSDKConfig {
@@ -117,6 +215,7 @@ func LoadOtelConfig(prefix string) OtelConfig {
 
 	cfg.SetDefault("enable", "false")
 	cfg.SetDefault("kafka_url", "kafka1:19092")
+	cfg.SetDefault("kafka_tls", "false")
 	cfg.AllowEmptyEnv(true)
 	cfg.AutomaticEnv()
 	var nC OtelConfig
@@ -139,6 +238,7 @@ func LoadPostgresConfig(prefix string, db string) PostgresConfig {
 	cfg.SetDefault("ssl_cert", "")
 	cfg.SetDefault("ssl_key", "")
 	cfg.SetDefault("ssl_root_cert", "")
+	cfg.SetDefault("replica_url", "")
 
 	cfg.AutomaticEnv()
 	cfg.AllowEmptyEnv(true)
@@ -152,12 +252,123 @@ func LoadEncryptionKey(prefix string) EncryptionKey {
 	cfg := viper.New()
 	cfg.SetEnvPrefix(fmt.Sprintf("%s_secret", prefix))
 	cfg.SetDefault("key", "orb")
+	cfg.SetDefault("per_owner", false)
 	cfg.AutomaticEnv()
 	var eK EncryptionKey
 	cfg.Unmarshal(&eK)
 	return eK
 }
 
+func LoadSecretStoreConfig(prefix string) SecretStoreConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_secret_store", prefix))
+	cfg.SetDefault("type", "")
+	cfg.SetDefault("vault_address", "http://localhost:8200")
+	cfg.SetDefault("vault_token", "")
+	cfg.SetDefault("vault_mount", "secret")
+	cfg.SetDefault("aws_region", "us-east-1")
+	cfg.SetDefault("prefix", "orb/sinks")
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var sC SecretStoreConfig
+	cfg.Unmarshal(&sC)
+	return sC
+}
+
+// LoadBackendsConfig loads the comma separated list of enabled sink backends, an empty list enables all of them
+func LoadBackendsConfig(prefix string) BackendsConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_backends", prefix))
+	cfg.SetDefault("enabled", "")
+	cfg.SetDefault("secret_headers", "")
+	cfg.SetDefault("require_https", false)
+	cfg.SetDefault("allow_insecure_override", true)
+	cfg.SetDefault("non_stable", "")
+	cfg.SetDefault("name_pattern", "")
+	cfg.SetDefault("probe_allow_private", false)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var bC BackendsConfig
+	cfg.Unmarshal(&bC)
+	return bC
+}
+
+func LoadTagLimitsConfig(prefix string) TagLimitsConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_tag_limits", prefix))
+	cfg.SetDefault("max_keys", 50)
+	cfg.SetDefault("max_key_length", 128)
+	cfg.SetDefault("max_value_length", 256)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var tC TagLimitsConfig
+	cfg.Unmarshal(&tC)
+	return tC
+}
+
+func LoadSinkErrorConfig(prefix string) SinkErrorConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_sink_error", prefix))
+	cfg.SetDefault("threshold", 3)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var eC SinkErrorConfig
+	cfg.Unmarshal(&eC)
+	return eC
+}
+
+func LoadEventOutboxConfig(prefix string) EventOutboxConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_es_outbox", prefix))
+	cfg.SetDefault("enable", false)
+	cfg.SetDefault("replay_interval", "30s")
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var oC EventOutboxConfig
+	cfg.Unmarshal(&oC)
+	return oC
+}
+
+func LoadDeleteProtectionConfig(prefix string) DeleteProtectionConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_delete_protection", prefix))
+	cfg.SetDefault("enable", false)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var dC DeleteProtectionConfig
+	cfg.Unmarshal(&dC)
+	return dC
+}
+
+func LoadMessageSigningConfig(prefix string) MessageSigningConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_message_signing", prefix))
+	cfg.SetDefault("secret", "")
+	cfg.SetDefault("require", false)
+	cfg.SetDefault("max_skew", "5m")
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var sC MessageSigningConfig
+	cfg.Unmarshal(&sC)
+	return sC
+}
+
+func LoadRateLimitConfig(prefix string) RateLimitConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_rate_limit", prefix))
+	cfg.SetDefault("read_rate", 50)
+	cfg.SetDefault("read_burst", 100)
+	cfg.SetDefault("write_rate", 10)
+	cfg.SetDefault("write_burst", 20)
+	cfg.SetDefault("validate_rate", 5)
+	cfg.SetDefault("validate_burst", 10)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var rC RateLimitConfig
+	cfg.Unmarshal(&rC)
+	return rC
+}
+
 func LoadJaegerConfig(prefix string) JaegerConfig {
 
 	cfg := viper.New()
@@ -196,6 +407,8 @@ func LoadEsConfig(prefix string) EsConfig {
 	cfg.SetDefault("pass", "")
 	cfg.SetDefault("db", "0")
 	cfg.SetDefault("consumer", fmt.Sprintf("%s-es-consumer", prefix))
+	cfg.SetDefault("stream_len", 1000)
+	cfg.SetDefault("stream_approx", true)
 
 	cfg.AllowEmptyEnv(true)
 	cfg.AutomaticEnv()
@@ -249,3 +462,53 @@ func LoadInMemoryCacheConfig(prefix string) InMemoryCacheConfig {
 	cfg.Unmarshal(&icC)
 	return icC
 }
+
+func LoadSinkWriteConfig(prefix string) SinkWriteConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_sink_write", prefix))
+	cfg.SetDefault("max_in_flight", 4)
+	cfg.SetDefault("max_queued", 64)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var swC SinkWriteConfig
+	cfg.Unmarshal(&swC)
+	return swC
+}
+
+func LoadSinkConnPoolConfig(prefix string) SinkConnPoolConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_sink_conn_pool", prefix))
+	cfg.SetDefault("max_idle_conns", 8)
+	cfg.SetDefault("idle_conn_timeout", 90*time.Second)
+	cfg.AllowEmptyEnv(true)
+	cfg.AutomaticEnv()
+	var cpC SinkConnPoolConfig
+	cfg.Unmarshal(&cpC)
+	return cpC
+}
+
+func LoadTLSConfig(prefix string) TLSConfig {
+	cfg := viper.New()
+	cfg.SetEnvPrefix(fmt.Sprintf("%s_tls", prefix))
+	cfg.SetDefault("min_version", DefaultTLSMinVersion)
+	cfg.AutomaticEnv()
+	var tC TLSConfig
+	cfg.Unmarshal(&tC)
+	return tC
+}
+
+// DefaultTLSMinVersion is the lowest TLS version accepted on outbound connections unless configured otherwise
+const DefaultTLSMinVersion = "1.2"
+
+// ParseTLSMinVersion converts a TLS version such as "1.2" or "1.3" to its crypto/tls constant,
+// an empty version falls back to DefaultTLSMinVersion
+func ParseTLSMinVersion(version string) (uint16, error) {
+	switch version {
+	case "", DefaultTLSMinVersion:
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("invalid TLS minimum version %q, supported versions are 1.2 and 1.3", version)
+	}
+}
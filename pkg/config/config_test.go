@@ -0,0 +1,34 @@
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTLSMinVersion(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		want    uint16
+		err     bool
+	}{
+		"default when empty": {version: "", want: tls.VersionTLS12},
+		"tls 1.2":            {version: "1.2", want: tls.VersionTLS12},
+		"tls 1.3":            {version: "1.3", want: tls.VersionTLS13},
+		"tls 1.1 rejected":   {version: "1.1", err: true},
+		"invalid version":    {version: "tls12", err: true},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			got, err := ParseTLSMinVersion(tc.version)
+			if tc.err {
+				assert.Error(t, err, "expected an error for version %q", tc.version)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
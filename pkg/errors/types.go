@@ -30,6 +30,12 @@ var (
 	// ErrInvalidBackend indicates a malformed entity specification on backend field
 	ErrInvalidBackend = New("malformed entity specification. backend field is invalid")
 
+	// ErrBackendNotEnabled indicates that the backend is not enabled on this deployment
+	ErrBackendNotEnabled = New("backend not enabled")
+
+	// ErrBackendNotStable indicates that the backend is not stable and this deployment did not opt in it
+	ErrBackendNotStable = New("backend not stable")
+
 	// ErrConfigFieldNotFound indicates that configuration field was not found
 	ErrConfigFieldNotFound = New("malformed entity specification. configuration field is expected")
 
@@ -42,6 +48,9 @@ var (
 	// ErrInvalidEndpoint indicates that endpoint field is not valid
 	ErrInvalidEndpoint = New("malformed entity specification. endpoint field is invalid")
 
+	// ErrInsecureEndpoint indicates that the endpoint of the exporter is not https while the deployment requires it
+	ErrInsecureEndpoint = New("malformed entity specification. endpoint must use https")
+
 	// ErrAuthFieldNotFound indicates that authentication field was not found on configuration field
 	ErrAuthFieldNotFound = New("malformed entity specification. authentication fields are expected on configuration field")
 
@@ -75,6 +84,24 @@ var (
 	// ErrAuthInvalidUsernameType indicates invalid username key on authentication field
 	ErrAuthInvalidUsernameType = New("malformed entity specification. username key on authentication field is invalid")
 
+	// ErrAuthCertificateNotFound indicates that the client certificate or key was not found
+	ErrAuthCertificateNotFound = New("malformed entity specification. cert and key keys are expected on authentication field")
+
+	// ErrAuthInvalidCertificate indicates invalid client certificate, key or ca on authentication field
+	ErrAuthInvalidCertificate = New("malformed entity specification. certificate on authentication field is invalid")
+
+	// ErrAuthServiceAccountKeyNotFound indicates that the service account key was not found
+	ErrAuthServiceAccountKeyNotFound = New("malformed entity specification. key is expected on authentication field")
+
+	// ErrAuthInvalidServiceAccountKey indicates the service account key on authentication field is not a valid JSON key
+	ErrAuthInvalidServiceAccountKey = New("malformed entity specification. service account key on authentication field is invalid")
+
+	// ErrProjectIDNotFound indicates that project id field was not found
+	ErrProjectIDNotFound = New("malformed entity specification. project id is expected on exporter field")
+
+	// ErrInvalidProjectID indicates that project id field is invalid
+	ErrInvalidProjectID = New("malformed entity specification. project id is invalid")
+
 	// ErrRemoteHostNotFound indicates that remote host field was not found
 	ErrRemoteHostNotFound = New("malformed entity specification. remote host is expected on exporter field")
 
@@ -25,9 +25,11 @@ var _ pb.PolicyServiceClient = (*grpcClient)(nil)
 type grpcClient struct {
 	timeout                  time.Duration
 	retrievePolicy           endpoint.Endpoint
+	retrievePolicies         endpoint.Endpoint
 	retrievePoliciesByGroups endpoint.Endpoint
 	retrieveDataset          endpoint.Endpoint
 	retrieveDatasetsByGroups endpoint.Endpoint
+	retrieveDatasetsBySink   endpoint.Endpoint
 }
 
 func (client grpcClient) RetrieveDatasetsByGroups(ctx context.Context, in *pb.DatasetsByGroupsReq, opts ...grpc.CallOption) (*pb.DatasetsRes, error) {
@@ -52,6 +54,27 @@ func (client grpcClient) RetrieveDatasetsByGroups(ctx context.Context, in *pb.Da
 
 }
 
+func (client grpcClient) RetrieveDatasetsBySink(ctx context.Context, in *pb.DatasetsBySinkReq, _ ...grpc.CallOption) (*pb.DatasetsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	ar := accessDatasetsBySinkReq{
+		sinkID:  in.SinkID,
+		ownerID: in.OwnerID,
+	}
+	res, err := client.retrieveDatasetsBySink(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(datasetListRes)
+	dsList := make([]*pb.DatasetRes, len(ir.datasets))
+	for i, ds := range ir.datasets {
+		dsList[i] = &pb.DatasetRes{Id: ds.id, SinkIds: ds.sinkIDs, PolicyId: ds.policyID, AgentGroupId: ds.agentGroupID}
+	}
+	return &pb.DatasetsRes{DatasetList: dsList}, nil
+}
+
 func (client grpcClient) RetrievePolicy(ctx context.Context, in *pb.PolicyByIDReq, opts ...grpc.CallOption) (*pb.PolicyRes, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
@@ -69,6 +92,27 @@ func (client grpcClient) RetrievePolicy(ctx context.Context, in *pb.PolicyByIDRe
 	return &pb.PolicyRes{Id: ir.id, Name: ir.name, Data: ir.data, Backend: ir.backend, Format: ir.format, Version: ir.version}, nil
 }
 
+func (client grpcClient) RetrievePolicies(ctx context.Context, in *pb.PoliciesByIDsReq, _ ...grpc.CallOption) (*pb.PoliciesRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	ar := accessByIDsReq{
+		PolicyIDs: in.PolicyIDs,
+		OwnerID:   in.OwnerID,
+	}
+	res, err := client.retrievePolicies(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(policyListRes)
+	plist := make([]*pb.PolicyRes, len(ir.policies))
+	for i, p := range ir.policies {
+		plist[i] = &pb.PolicyRes{Id: p.id, Name: p.name, Data: p.data, Backend: p.backend, Format: p.format, Version: p.version}
+	}
+	return &pb.PoliciesRes{Policies: plist}, nil
+}
+
 func (client grpcClient) RetrievePoliciesByGroups(ctx context.Context, in *pb.PoliciesByGroupsReq, _ ...grpc.CallOption) (*pb.PolicyInDSListRes, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
@@ -95,6 +139,7 @@ func (client grpcClient) RetrievePoliciesByGroups(ctx context.Context, in *pb.Po
 			DatasetId:    p.datasetID,
 			AgentGroupId: p.agentGroupID,
 			Format:       p.format,
+			DependsOn:    p.dependsOn,
 		}
 	}
 	return &pb.PolicyInDSListRes{Policies: plist}, nil
@@ -135,6 +180,14 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodePolicyResponse,
 			pb.PolicyRes{},
 		).Endpoint()),
+		retrievePolicies: kitot.TraceClient(tracer, "retrieve_policies")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrievePolicies",
+			encodeRetrievePoliciesRequest,
+			decodePoliciesResponse,
+			pb.PoliciesRes{},
+		).Endpoint()),
 		retrievePoliciesByGroups: kitot.TraceClient(tracer, "retrieve_policies_by_groups")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -151,6 +204,22 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeDatasetResponse,
 			pb.DatasetRes{},
 		).Endpoint()),
+		retrieveDatasetsByGroups: kitot.TraceClient(tracer, "retrieve_datasets_by_groups")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveDatasetsByGroups",
+			encodeRetrieveDatasetsByGroupsRequest,
+			decodeDatasetListResponse,
+			pb.DatasetsRes{},
+		).Endpoint()),
+		retrieveDatasetsBySink: kitot.TraceClient(tracer, "retrieve_datasets_by_sink")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveDatasetsBySink",
+			encodeRetrieveDatasetsBySinkRequest,
+			decodeDatasetListResponse,
+			pb.DatasetsRes{},
+		).Endpoint()),
 	}
 }
 
@@ -159,6 +228,11 @@ func encodeRetrievePolicyRequest(_ context.Context, grpcReq interface{}) (interf
 	return &pb.PolicyByIDReq{PolicyID: req.PolicyID, OwnerID: req.OwnerID}, nil
 }
 
+func encodeRetrievePoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessByIDsReq)
+	return &pb.PoliciesByIDsReq{PolicyIDs: req.PolicyIDs, OwnerID: req.OwnerID}, nil
+}
+
 func encodeRetrievePoliciesByGroupsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByGroupIDReq)
 	return &pb.PoliciesByGroupsReq{GroupIDs: req.GroupIDs, OwnerID: req.OwnerID}, nil
@@ -171,11 +245,31 @@ func encodeRetrieveDatasetRequest(_ context.Context, grpcReq interface{}) (inter
 		OwnerID:   req.ownerID,
 	}, nil
 }
+
+func encodeRetrieveDatasetsByGroupsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessByGroupIDReq)
+	return &pb.DatasetsByGroupsReq{GroupIDs: req.GroupIDs, OwnerID: req.OwnerID}, nil
+}
+
+func encodeRetrieveDatasetsBySinkRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessDatasetsBySinkReq)
+	return &pb.DatasetsBySinkReq{SinkID: req.sinkID, OwnerID: req.ownerID}, nil
+}
+
 func decodePolicyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*pb.PolicyRes)
 	return policyRes{id: res.GetId(), name: res.GetName(), data: res.GetData(), version: res.GetVersion(), backend: res.GetBackend(), format: res.GetFormat()}, nil
 }
 
+func decodePoliciesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.PoliciesRes)
+	policies := make([]policyRes, len(res.GetPolicies()))
+	for i, p := range res.GetPolicies() {
+		policies[i] = policyRes{id: p.GetId(), name: p.GetName(), data: p.GetData(), version: p.GetVersion(), backend: p.GetBackend(), format: p.GetFormat()}
+	}
+	return policyListRes{policies: policies}, nil
+}
+
 func decodeDatasetResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*pb.DatasetRes)
 	return datasetRes{
@@ -198,7 +292,22 @@ func decodePolicyListResponse(_ context.Context, grpcRes interface{}) (interface
 			datasetID:    p.GetDatasetId(),
 			agentGroupID: p.GetAgentGroupId(),
 			format:       p.GetFormat(),
+			dependsOn:    p.GetDependsOn(),
 		}
 	}
 	return policyInDSListRes{policies: policies}, nil
 }
+
+func decodeDatasetListResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*pb.DatasetsRes)
+	datasets := make([]datasetRes, len(res.GetDatasetList()))
+	for i, ds := range res.GetDatasetList() {
+		datasets[i] = datasetRes{
+			id:           ds.GetId(),
+			agentGroupID: ds.GetAgentGroupId(),
+			policyID:     ds.GetPolicyId(),
+			sinkIDs:      ds.GetSinkIds(),
+		}
+	}
+	return datasetListRes{datasets: datasets}, nil
+}
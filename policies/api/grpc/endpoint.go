@@ -43,6 +43,37 @@ func retrievePolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 	}
 }
 
+func retrievePoliciesEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(accessByIDsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		plist, err := svc.ListPoliciesByIDsInternal(ctx, req.PolicyIDs, req.OwnerID)
+		if err != nil {
+			return policyListRes{}, err
+		}
+		policies := make([]policyRes, len(plist))
+		for i, policy := range plist {
+			data, format, err := extractData(policy)
+			if err != nil {
+				return policyListRes{}, err
+			}
+			policies[i] = policyRes{
+				id:      policy.ID,
+				name:    policy.Name.String(),
+				format:  format,
+				backend: policy.Backend,
+				version: policy.Version,
+				data:    data,
+			}
+		}
+
+		return policyListRes{policies: policies}, nil
+	}
+}
+
 func extractData(policy policies.Policy) (data []byte, format string, err error) {
 	if policy.Format == "yaml" {
 		data, err = yaml.Marshal(policy.Policy)
@@ -83,6 +114,7 @@ func retrievePoliciesByGroupsEndpoint(svc policies.Service) endpoint.Endpoint {
 				data:         data,
 				datasetID:    policy.DatasetID,
 				agentGroupID: policy.AgentGroupID,
+				dependsOn:    policy.DependsOn,
 			}
 		}
 
@@ -133,3 +165,28 @@ func retrieveDatasetsByGroupsEndpoint(svc policies.Service) endpoint.Endpoint {
 		return datasetListRes{datasets: datasets}, nil
 	}
 }
+
+func retrieveDatasetsBySinkEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(accessDatasetsBySinkReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		dsList, err := svc.ListDatasetsBySinkIDInternal(ctx, req.sinkID, req.ownerID)
+		if err != nil {
+			return datasetListRes{}, err
+		}
+		datasets := make([]datasetRes, len(dsList))
+		for i, ds := range dsList {
+			datasets[i] = datasetRes{
+				id:           ds.ID,
+				agentGroupID: ds.AgentGroupID,
+				sinkIDs:      *ds.SinkIDs,
+				policyID:     ds.PolicyID,
+			}
+		}
+
+		return datasetListRes{datasets: datasets}, nil
+	}
+}
@@ -11,6 +11,7 @@ package grpc_test
 import (
 	"context"
 	"fmt"
+	"github.com/gofrs/uuid"
 	"github.com/orb-community/orb/policies/pb"
 	"testing"
 	"time"
@@ -95,5 +96,141 @@ func TestRetrievePoliciesByGroups(t *testing.T) {
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.results, len(plist.Policies), fmt.Sprintf("%s: expected %d got %d", desc, tc.results, len(plist.Policies)))
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+		for _, p := range plist.Policies {
+			assert.Equal(t, policy.DependsOn, p.DependsOn, fmt.Sprintf("%s: expected dependencies %v got %v", desc, policy.DependsOn, p.DependsOn))
+		}
+	}
+}
+
+func TestRetrieveDatasetsBySink(t *testing.T) {
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := policiesgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	unusedID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	otherOwnerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		sinkID  string
+		ownerID string
+		results int
+		code    codes.Code
+	}{
+		"retrieve datasets by used sink": {
+			sinkID:  (*dataset.SinkIDs)[0],
+			ownerID: dataset.MFOwnerID,
+			results: 1,
+			code:    codes.OK,
+		},
+		"retrieve datasets by unused sink": {
+			sinkID:  unusedID.String(),
+			ownerID: dataset.MFOwnerID,
+			results: 0,
+			code:    codes.OK,
+		},
+		"retrieve datasets by sink of another owner": {
+			sinkID:  (*dataset.SinkIDs)[0],
+			ownerID: otherOwnerID.String(),
+			results: 0,
+			code:    codes.OK,
+		},
+		"retrieve datasets by sink without owner": {
+			sinkID:  (*dataset.SinkIDs)[0],
+			ownerID: "",
+			results: 0,
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			dsList, err := cli.RetrieveDatasetsBySink(ctx, &pb.DatasetsBySinkReq{
+				SinkID:  tc.sinkID,
+				OwnerID: tc.ownerID,
+			})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.results, len(dsList.GetDatasetList()), fmt.Sprintf("%s: expected %d got %d", desc, tc.results, len(dsList.GetDatasetList())))
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+			for _, ds := range dsList.GetDatasetList() {
+				assert.Equal(t, dataset.PolicyID, ds.GetPolicyId(), fmt.Sprintf("%s: expected policy %s got %s", desc, dataset.PolicyID, ds.GetPolicyId()))
+			}
+		})
+	}
+}
+
+func TestRetrievePolicies(t *testing.T) {
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := policiesgrpc.NewClient(mocktracer.New(), conn, time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	missingID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	otherOwnerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		ids     []string
+		ownerID string
+		results int
+		code    codes.Code
+	}{
+		"retrieve existing policies by ids": {
+			ids:     []string{policy.ID},
+			ownerID: policy.MFOwnerID,
+			results: 1,
+			code:    codes.OK,
+		},
+		"retrieve policies by ids omitting the missing ones": {
+			ids:     []string{policy.ID, missingID.String()},
+			ownerID: policy.MFOwnerID,
+			results: 1,
+			code:    codes.OK,
+		},
+		"retrieve policies by ids of another owner": {
+			ids:     []string{policy.ID},
+			ownerID: otherOwnerID.String(),
+			results: 0,
+			code:    codes.OK,
+		},
+		"retrieve policies without ids": {
+			ids:     []string{},
+			ownerID: policy.MFOwnerID,
+			results: 0,
+			code:    codes.InvalidArgument,
+		},
+		"retrieve policies by ids without owner": {
+			ids:     []string{policy.ID},
+			ownerID: "",
+			results: 0,
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			plist, err := cli.RetrievePolicies(ctx, &pb.PoliciesByIDsReq{
+				PolicyIDs: tc.ids,
+				OwnerID:   tc.ownerID,
+			})
+			e, ok := status.FromError(err)
+			assert.True(t, ok, "OK expected to be true")
+			assert.Equal(t, tc.results, len(plist.GetPolicies()), fmt.Sprintf("%s: expected %d got %d", desc, tc.results, len(plist.GetPolicies())))
+			assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+			for _, p := range plist.GetPolicies() {
+				assert.Equal(t, policy.ID, p.GetId(), fmt.Sprintf("%s: expected policy %s got %s", desc, policy.ID, p.GetId()))
+				assert.Equal(t, policy.Name.String(), p.GetName(), fmt.Sprintf("%s: expected name %s got %s", desc, policy.Name.String(), p.GetName()))
+			}
+		})
 	}
 }
@@ -23,6 +23,19 @@ func (req accessByIDReq) validate() error {
 	return nil
 }
 
+type accessByIDsReq struct {
+	PolicyIDs []string
+	OwnerID   string
+}
+
+func (req accessByIDsReq) validate() error {
+	if len(req.PolicyIDs) == 0 || req.OwnerID == "" {
+		return policies.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type accessByGroupIDReq struct {
 	GroupIDs []string
 	OwnerID  string
@@ -47,3 +60,15 @@ func (req accessDatasetByIDReq) validate() error {
 	}
 	return nil
 }
+
+type accessDatasetsBySinkReq struct {
+	sinkID  string
+	ownerID string
+}
+
+func (req accessDatasetsBySinkReq) validate() error {
+	if req.sinkID == "" || req.ownerID == "" {
+		return policies.ErrMalformedEntity
+	}
+	return nil
+}
@@ -17,6 +17,10 @@ type policyRes struct {
 	format  string
 }
 
+type policyListRes struct {
+	policies []policyRes
+}
+
 type policyInDSRes struct {
 	id           string
 	name         string
@@ -26,6 +30,7 @@ type policyInDSRes struct {
 	datasetID    string
 	agentGroupID string
 	format       string
+	dependsOn    []string
 }
 
 type policyInDSListRes struct {
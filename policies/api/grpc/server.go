@@ -26,9 +26,11 @@ var _ pb.PolicyServiceServer = (*grpcServer)(nil)
 type grpcServer struct {
 	pb.UnimplementedPolicyServiceServer
 	retrievePolicy           kitgrpc.Handler
+	retrievePolicies         kitgrpc.Handler
 	retrievePoliciesByGroups kitgrpc.Handler
 	retrieveDataset          kitgrpc.Handler
 	retrieveDatasetsByGroups kitgrpc.Handler
+	retrieveDatasetsBySink   kitgrpc.Handler
 }
 
 // NewServer returns new PolicyServiceServer instance.
@@ -39,6 +41,11 @@ func NewServer(tracer opentracing.Tracer, svc policies.Service) pb.PolicyService
 			decodeRetrievePolicyRequest,
 			encodePolicyResponse,
 		),
+		retrievePolicies: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_policies")(retrievePoliciesEndpoint(svc)),
+			decodeRetrievePoliciesRequest,
+			encodePolicyListResponse,
+		),
 		retrievePoliciesByGroups: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "retrieve_policies_by_groups")(retrievePoliciesByGroupsEndpoint(svc)),
 			decodeRetrievePoliciesByGroupRequest,
@@ -54,6 +61,11 @@ func NewServer(tracer opentracing.Tracer, svc policies.Service) pb.PolicyService
 			decodeRetrieveDatasetsByGroupRequest,
 			encodeDatasetListResponse,
 		),
+		retrieveDatasetsBySink: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_datasets_by_sink")(retrieveDatasetsBySinkEndpoint(svc)),
+			decodeRetrieveDatasetsBySinkRequest,
+			encodeDatasetListResponse,
+		),
 	}
 }
 
@@ -75,6 +87,15 @@ func (gs *grpcServer) RetrievePolicy(ctx context.Context, req *pb.PolicyByIDReq)
 	return res.(*pb.PolicyRes), nil
 }
 
+func (gs *grpcServer) RetrievePolicies(ctx context.Context, req *pb.PoliciesByIDsReq) (*pb.PoliciesRes, error) {
+	_, res, err := gs.retrievePolicies.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.PoliciesRes), nil
+}
+
 func (gs *grpcServer) RetrieveDataset(ctx context.Context, req *pb.DatasetByIDReq) (*pb.DatasetRes, error) {
 	_, res, err := gs.retrieveDataset.ServeGRPC(ctx, req)
 	if err != nil {
@@ -93,11 +114,25 @@ func (gs *grpcServer) RetrieveDatasetsByGroups(ctx context.Context, req *pb.Data
 	return res.(*pb.DatasetsRes), nil
 }
 
+func (gs *grpcServer) RetrieveDatasetsBySink(ctx context.Context, req *pb.DatasetsBySinkReq) (*pb.DatasetsRes, error) {
+	_, res, err := gs.retrieveDatasetsBySink.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*pb.DatasetsRes), nil
+}
+
 func decodeRetrievePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*pb.PolicyByIDReq)
 	return accessByIDReq{PolicyID: req.PolicyID, OwnerID: req.OwnerID}, nil
 }
 
+func decodeRetrievePoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.PoliciesByIDsReq)
+	return accessByIDsReq{PolicyIDs: req.PolicyIDs, OwnerID: req.OwnerID}, nil
+}
+
 func decodeRetrievePoliciesByGroupRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*pb.PoliciesByGroupsReq)
 	return accessByGroupIDReq{GroupIDs: req.GroupIDs, OwnerID: req.OwnerID}, nil
@@ -116,6 +151,11 @@ func decodeRetrieveDatasetsByGroupRequest(_ context.Context, grpcReq interface{}
 	return accessByGroupIDReq{GroupIDs: req.GroupIDs, OwnerID: req.OwnerID}, nil
 }
 
+func decodeRetrieveDatasetsBySinkRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DatasetsBySinkReq)
+	return accessDatasetsBySinkReq{sinkID: req.SinkID, ownerID: req.OwnerID}, nil
+}
+
 func encodePolicyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(policyRes)
 	return &pb.PolicyRes{
@@ -128,6 +168,22 @@ func encodePolicyResponse(_ context.Context, grpcRes interface{}) (interface{},
 	}, nil
 }
 
+func encodePolicyListResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(policyListRes)
+	plist := make([]*pb.PolicyRes, len(res.policies))
+	for i, p := range res.policies {
+		plist[i] = &pb.PolicyRes{
+			Id:      p.id,
+			Name:    p.name,
+			Backend: p.backend,
+			Version: p.version,
+			Data:    p.data,
+			Format:  p.format,
+		}
+	}
+	return &pb.PoliciesRes{Policies: plist}, nil
+}
+
 func encodePolicyInDSListResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(policyInDSListRes)
 	plist := make([]*pb.PolicyInDSRes, len(res.policies))
@@ -142,6 +198,7 @@ func encodePolicyInDSListResponse(_ context.Context, grpcRes interface{}) (inter
 			DatasetId:    p.datasetID,
 			AgentGroupId: p.agentGroupID,
 			Format:       p.format,
+			DependsOn:    p.dependsOn,
 		}
 	}
 	return &pb.PolicyInDSListRes{Policies: plist}, nil
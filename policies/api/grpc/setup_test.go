@@ -65,20 +65,24 @@ func newService(tokens map[string]string) policies.Service {
 	oID, _ := uuid.NewV4()
 	pname, _ := types.NewIdentifier("testpolicy")
 
+	dependencyID, _ := uuid.NewV4()
 	policy = policies.Policy{
 		Name:      pname,
 		MFOwnerID: oID.String(),
+		DependsOn: []string{dependencyID.String()},
 	}
 	policyid, _ := repo.SavePolicy(context.Background(), policy)
 	policy.ID = policyid
 
 	gID, _ := uuid.NewV4()
 	gname, _ := types.NewIdentifier("testdataset")
+	sID, _ := uuid.NewV4()
 	dataset = policies.Dataset{
 		Name:         gname,
 		MFOwnerID:    oID.String(),
 		AgentGroupID: gID.String(),
 		PolicyID:     policyid,
+		SinkIDs:      &[]string{sID.String()},
 	}
 	datasetid, _ := repo.SaveDataset(context.Background(), dataset)
 	dataset.ID = datasetid
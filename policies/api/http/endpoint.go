@@ -39,6 +39,7 @@ func addPolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 			OrbTags:       req.Tags,
 			PolicyData:    req.PolicyData,
 			Format:        req.Format,
+			DependsOn:     req.DependsOn,
 		}
 
 		saved, err := svc.AddPolicy(ctx, req.token, policy)
@@ -59,6 +60,7 @@ func addPolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 			Policy:        saved.Policy,
 			Format:        saved.Format,
 			PolicyData:    saved.PolicyData,
+			DependsOn:     saved.DependsOn,
 			created:       true,
 		}
 
@@ -90,6 +92,7 @@ func viewPolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 			LastModified:  policy.LastModified,
 			PolicyData:    policy.PolicyData,
 			Format:        policy.Format,
+			DependsOn:     policy.DependsOn,
 			Created:       policy.Created,
 		}
 		return res, nil
@@ -159,6 +162,7 @@ func editPoliciyEndpoint(svc policies.Service) endpoint.Endpoint {
 			Policy:      req.Policy,
 			PolicyData:  req.PolicyData,
 			Format:      req.Format,
+			DependsOn:   req.DependsOn,
 		}
 
 		res, err := svc.EditPolicy(ctx, req.token, plcy)
@@ -174,6 +178,7 @@ func editPoliciyEndpoint(svc policies.Service) endpoint.Endpoint {
 			Policy:      res.Policy,
 			Format:      res.Format,
 			PolicyData:  res.PolicyData,
+			DependsOn:   res.DependsOn,
 			Version:     res.Version,
 		}
 
@@ -213,6 +218,7 @@ func addDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			PolicyID:     req.PolicyID,
 			SinkIDs:      &req.SinkIDs,
 			Tags:         req.Tags,
+			Type:         req.Type,
 		}
 
 		saved, err := svc.AddDataset(ctx, req.token, d)
@@ -230,6 +236,7 @@ func addDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			Metadata:     saved.Metadata,
 			TsCreated:    saved.Created,
 			Tags:         saved.Tags,
+			Type:         saved.Type,
 			created:      true,
 		}
 
@@ -274,6 +281,7 @@ func editDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			Metadata:     ds.Metadata,
 			TsCreated:    ds.Created,
 			Tags:         ds.Tags,
+			Type:         ds.Type,
 		}
 
 		return res, nil
@@ -321,6 +329,39 @@ func validatePolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 	}
 }
 
+func checkPolicyEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(checkPolicyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		name, _ := types.NewIdentifier(req.Name)
+		policy := policies.Policy{
+			Name:       name,
+			Backend:    req.Backend,
+			Policy:     req.Policy,
+			Format:     req.Format,
+			PolicyData: req.PolicyData,
+		}
+
+		issues, err := svc.CheckPolicy(ctx, req.token, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		res := checkPolicyRes{
+			Valid:  len(issues) == 0,
+			Errors: make([]policyIssueRes, len(issues)),
+		}
+		for i, issue := range issues {
+			res.Errors[i] = policyIssueRes{Field: issue.Field, Message: issue.Message}
+		}
+
+		return res, nil
+	}
+}
+
 func removeDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(viewResourceReq)
@@ -352,6 +393,7 @@ func validateDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			PolicyID:     req.PolicyID,
 			SinkIDs:      &req.SinkIDs,
 			Tags:         req.Tags,
+			Type:         req.Type,
 		}
 
 		validated, err := svc.ValidateDataset(ctx, req.token, d)
@@ -366,6 +408,7 @@ func validateDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			AgentGroupID: validated.AgentGroupID,
 			PolicyID:     validated.PolicyID,
 			SinkIDs:      *validated.SinkIDs,
+			Type:         validated.Type,
 		}
 
 		return res, nil
@@ -391,6 +434,7 @@ func viewDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 			AgentGroupID: dataset.AgentGroupID,
 			Valid:        dataset.Valid,
 			TsCreated:    dataset.Created,
+			Type:         dataset.Type,
 		}
 		if dataset.SinkIDs != nil {
 			res.SinkIDs = *dataset.SinkIDs
@@ -432,6 +476,7 @@ func listDatasetEndpoint(svc policies.Service) endpoint.Endpoint {
 				TsCreated:    dataset.Created,
 				Valid:        dataset.Valid,
 				Tags:         dataset.Tags,
+				Type:         dataset.Type,
 			}
 			if dataset.SinkIDs != nil {
 				view.SinkIDs = *dataset.SinkIDs
@@ -652,6 +652,90 @@ func TestValidatePolicy(t *testing.T) {
 
 }
 
+func TestCheckPolicy(t *testing.T) {
+	var (
+		contentType  = "application/json"
+		validYaml    = `{"name": "mypktvisorpolicyyaml-3", "backend": "pktvisor", "format": "yaml", "policy_data": "handlers:\n  modules:\n    default_dns:\n      type: dns\ninput:\n  input_type: pcap\n  tap: default_pcap\nkind: collection"}`
+		missingTap   = `{"backend": "pktvisor", "format": "yaml", "policy_data": "handlers:\n  modules:\n    default_dns:\n      type: dns\ninput:\n  input_type: pcap\nkind: collection"}`
+		bothPolicies = `{"backend": "pktvisor", "policy": {"kind": "collection"}, "format": "yaml", "policy_data": "kind: collection"}`
+	)
+	type issue struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	cli := newClientServer(t)
+
+	cases := map[string]struct {
+		req         string
+		contentType string
+		auth        string
+		status      int
+		valid       bool
+		errors      []issue
+	}{
+		"check a valid policy": {
+			req:         validYaml,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+			valid:       true,
+			errors:      []issue{},
+		},
+		"check a policy missing its tap": {
+			req:         missingTap,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+			valid:       false,
+			errors:      []issue{{Field: "policy.input.tap", Message: "a tap or a tap_selector is required"}},
+		},
+		"check a policy set both in json and yaml": {
+			req:         bothPolicies,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		"check a policy with a invalid token": {
+			req:         validYaml,
+			contentType: contentType,
+			auth:        invalidToken,
+			status:      http.StatusUnauthorized,
+		},
+		"check a policy with a empty content type": {
+			req:         validYaml,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      cli.server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/policies/agent/check", cli.server.URL),
+				contentType: tc.contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			require.Nil(t, err, "%s: Unexpected error: %s", desc, err)
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", desc, tc.status, res.StatusCode))
+			if tc.status != http.StatusOK {
+				return
+			}
+			var body struct {
+				Valid  bool    `json:"valid"`
+				Errors []issue `json:"errors"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, tc.valid, body.Valid, desc)
+			assert.Equal(t, tc.errors, body.Errors, desc)
+		})
+	}
+}
+
 func TestCreatePolicy(t *testing.T) {
 	cli := newClientServer(t)
 	defer cli.server.Close()
@@ -32,6 +32,20 @@ func (l loggingMiddleware) ListDatasetsByGroupIDInternal(ctx context.Context, gr
 	return l.svc.ListDatasetsByGroupIDInternal(ctx, groupIDs, ownerID)
 }
 
+func (l loggingMiddleware) ListDatasetsBySinkIDInternal(ctx context.Context, sinkID string, ownerID string) (_ []policies.Dataset, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: list_datasets_by_sink_id_internal",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: list_datasets_by_sink_id_internal",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ListDatasetsBySinkIDInternal(ctx, sinkID, ownerID)
+}
+
 func (l loggingMiddleware) RemoveAllDatasetsByPolicyIDInternal(ctx context.Context, token string, policyID string) (err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -200,6 +214,20 @@ func (l loggingMiddleware) ViewPolicyByIDInternal(ctx context.Context, policyID
 	return l.svc.ViewPolicyByIDInternal(ctx, policyID, ownerID)
 }
 
+func (l loggingMiddleware) ListPoliciesByIDsInternal(ctx context.Context, policyIDs []string, ownerID string) (_ []policies.Policy, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: list_policies_by_ids_internal",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: list_policies_by_ids_internal",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ListPoliciesByIDsInternal(ctx, policyIDs, ownerID)
+}
+
 func (l loggingMiddleware) ListPoliciesByGroupIDInternal(ctx context.Context, groupIDs []string, ownerID string) (_ []policies.PolicyInDataset, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -256,6 +284,21 @@ func (l loggingMiddleware) ValidatePolicy(ctx context.Context, token string, p p
 	return l.svc.ValidatePolicy(ctx, token, p)
 }
 
+func (l loggingMiddleware) CheckPolicy(ctx context.Context, token string, p policies.Policy) (issues []policies.PolicyIssue, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: check_policy",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: check_policy",
+				zap.Int("issues", len(issues)),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CheckPolicy(ctx, token, p)
+}
+
 func (l loggingMiddleware) ValidateDataset(ctx context.Context, token string, d policies.Dataset) (_ policies.Dataset, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
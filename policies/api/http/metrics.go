@@ -39,6 +39,23 @@ func (m metricsMiddleware) ListDatasetsByGroupIDInternal(ctx context.Context, gr
 	return m.svc.ListDatasetsByGroupIDInternal(ctx, groupIDs, ownerID)
 }
 
+func (m metricsMiddleware) ListDatasetsBySinkIDInternal(ctx context.Context, sinkID string, ownerID string) ([]policies.Dataset, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "listDatasetsBySinkIDInternal",
+			"owner_id", ownerID,
+			"policy_id", "",
+			"dataset_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ListDatasetsBySinkIDInternal(ctx, sinkID, ownerID)
+}
+
 func (m metricsMiddleware) RemoveAllDatasetsByPolicyIDInternal(ctx context.Context, token string, policyID string) error {
 	ownerID, err := m.identify(token)
 	if err != nil {
@@ -283,6 +300,23 @@ func (m metricsMiddleware) ViewPolicyByIDInternal(ctx context.Context, policyID
 	return m.svc.ViewPolicyByIDInternal(ctx, policyID, ownerID)
 }
 
+func (m metricsMiddleware) ListPoliciesByIDsInternal(ctx context.Context, policyIDs []string, ownerID string) ([]policies.Policy, error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "listPoliciesByIDsInternal",
+			"owner_id", ownerID,
+			"policy_id", "",
+			"dataset_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ListPoliciesByIDsInternal(ctx, policyIDs, ownerID)
+}
+
 func (m metricsMiddleware) AddDataset(ctx context.Context, token string, d policies.Dataset) (dataset policies.Dataset, _ error) {
 	ownerID, err := m.identify(token)
 	if err != nil {
@@ -344,6 +378,28 @@ func (m metricsMiddleware) InactivateDatasetByGroupID(ctx context.Context, group
 	return m.svc.InactivateDatasetByGroupID(ctx, groupID, ownerID)
 }
 
+func (m metricsMiddleware) CheckPolicy(ctx context.Context, token string, p policies.Policy) ([]policies.PolicyIssue, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "checkPolicy",
+			"owner_id", ownerID,
+			"policy_id", "",
+			"dataset_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.CheckPolicy(ctx, token, p)
+}
+
 func (m metricsMiddleware) ValidatePolicy(ctx context.Context, token string, p policies.Policy) (policies.Policy, error) {
 	ownerID, err := m.identify(token)
 	if err != nil {
@@ -32,6 +32,7 @@ type addPolicyReq struct {
 	Format        string         `json:"format,omitempty"`
 	PolicyData    string         `json:"policy_data,omitempty"`
 	Description   string         `json:"description"`
+	DependsOn     []string       `json:"depends_on,omitempty"`
 	token         string
 }
 
@@ -70,6 +71,36 @@ func (req *addPolicyReq) validate() error {
 	return nil
 }
 
+type checkPolicyReq struct {
+	Name       string         `json:"name,omitempty"`
+	Backend    string         `json:"backend"`
+	Policy     types.Metadata `json:"policy,omitempty"`
+	Format     string         `json:"format,omitempty"`
+	PolicyData string         `json:"policy_data,omitempty"`
+	token      string
+}
+
+func (req *checkPolicyReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.Backend == "" {
+		return errors.ErrMalformedEntity
+	}
+	if req.Policy == nil && req.PolicyData == "" {
+		return errors.ErrMalformedEntity
+	}
+	if req.Policy != nil && req.PolicyData != "" {
+		return errors.ErrMalformedEntity
+	}
+	if req.Name != "" {
+		if _, err := types.NewIdentifier(req.Name); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	return nil
+}
+
 type viewResourceReq struct {
 	token string
 	id    string
@@ -94,6 +125,7 @@ type updatePolicyReq struct {
 	Format      string         `json:"format,omitempty"`
 	Policy      types.Metadata `json:"policy,omitempty"`
 	PolicyData  string         `json:"policy_data,omitempty"`
+	DependsOn   []string       `json:"depends_on,omitempty"`
 }
 
 func (req updatePolicyReq) validate() error {
@@ -102,7 +134,7 @@ func (req updatePolicyReq) validate() error {
 		return errors.ErrUnauthorizedAccess
 	}
 
-	if (req.Name == "" && req.Description == nil && req.Tags == nil) && (req.PolicyData == "" && req.Policy == nil) {
+	if (req.Name == "" && req.Description == nil && req.Tags == nil && req.DependsOn == nil) && (req.PolicyData == "" && req.Policy == nil) {
 		return errors.ErrMalformedEntity
 	}
 
@@ -115,6 +147,7 @@ type addDatasetReq struct {
 	PolicyID     string     `json:"agent_policy_id"`
 	SinkIDs      []string   `json:"sink_ids"`
 	Tags         types.Tags `json:"tags"`
+	Type         string     `json:"type,omitempty"`
 	token        string
 }
 
@@ -132,6 +165,12 @@ func (req addDatasetReq) validate() error {
 		return errors.Wrap(errors.ErrMalformedEntity, err)
 	}
 
+	switch req.Type {
+	case "", policies.DatasetTypeMetrics, policies.DatasetTypeLogs, policies.DatasetTypeTraces:
+	default:
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("invalid dataset type"))
+	}
+
 	return nil
 }
 
@@ -20,6 +20,7 @@ type policyRes struct {
 	Policy        types.Metadata `json:"policy,omitempty"`
 	Format        string         `json:"format,omitempty"`
 	PolicyData    string         `json:"policy_data,omitempty"`
+	DependsOn     []string       `json:"depends_on,omitempty"`
 	Version       int32          `json:"version"`
 	Created       time.Time      `json:"ts_created"`
 	LastModified  time.Time      `json:"ts_last_modified"`
@@ -50,6 +51,7 @@ type policyUpdateRes struct {
 	Policy      types.Metadata `json:"policy,omitempty"`
 	Format      string         `json:"format,omitempty"`
 	PolicyData  string         `json:"policy_data,omitempty"`
+	DependsOn   []string       `json:"depends_on,omitempty"`
 	Version     int32          `json:"version,omitempty"`
 }
 
@@ -118,6 +120,28 @@ func (s policyValidateRes) Empty() bool {
 	return false
 }
 
+type policyIssueRes struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type checkPolicyRes struct {
+	Valid  bool             `json:"valid"`
+	Errors []policyIssueRes `json:"errors"`
+}
+
+func (s checkPolicyRes) Code() int {
+	return http.StatusOK
+}
+
+func (s checkPolicyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (s checkPolicyRes) Empty() bool {
+	return false
+}
+
 type validateDatasetRes struct {
 	Name         string
 	AgentGroupID string
@@ -125,6 +149,7 @@ type validateDatasetRes struct {
 	SinkIDs      []string
 	Valid        bool
 	Tags         types.Tags
+	Type         string
 }
 
 func (s validateDatasetRes) Code() int {
@@ -149,6 +174,7 @@ type datasetRes struct {
 	Metadata     types.Metadata `json:"metadata"`
 	TsCreated    time.Time      `json:"ts_created"`
 	Tags         types.Tags     `json:"tags"`
+	Type         string         `json:"type,omitempty"`
 	created      bool
 }
 
@@ -79,6 +79,11 @@ func MakeHandler(tracer opentracing.Tracer, svcName string, svc policies.Service
 		decodeAddPolicyRequest,
 		types.EncodeResponse,
 		opts...))
+	r.Post("/policies/agent/check", kithttp.NewServer(
+		kitot.TraceServer(tracer, "check_policy")(checkPolicyEndpoint(svc)),
+		decodeCheckPolicyRequest,
+		types.EncodeResponse,
+		opts...))
 
 	r.Post("/policies/dataset", kithttp.NewServer(
 		kitot.TraceServer(tracer, "add_dataset")(addDatasetEndpoint(svc)),
@@ -130,6 +135,18 @@ func decodeAddPolicyRequest(_ context.Context, r *http.Request) (interface{}, er
 	return req, nil
 }
 
+func decodeCheckPolicyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := checkPolicyReq{token: parseJwt(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	return req, nil
+}
+
 func decodeAddDatasetRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
 		return nil, errors.ErrUnsupportedContentType
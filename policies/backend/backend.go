@@ -4,7 +4,12 @@
 
 package backend
 
-import "github.com/orb-community/orb/pkg/types"
+import (
+	"sort"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/types"
+)
 
 type Backend interface {
 	SupportsFormat(format string) bool
@@ -12,17 +17,43 @@ type Backend interface {
 	Validate(policy types.Metadata) error
 }
 
+// ValidationError is a problem found by a backend on a policy, Field is the dotted path of the offending field
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors gathers every problem found by a backend on a policy, so all of them are reported at once
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
 var registry = make(map[string]Backend)
 
 func Register(name string, b Backend) {
 	registry[name] = b
 }
 
+// GetList returns the names of the registered backends in alphabetical order
 func GetList() []string {
 	keys := make([]string, 0, len(registry))
 	for k := range registry {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
@@ -6,6 +6,8 @@ package pktvisor
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/ghodss/yaml"
 	"github.com/orb-community/orb/pkg/types"
@@ -17,8 +19,45 @@ var _ backend.Backend = (*pktvisorBackend)(nil)
 type pktvisorBackend struct {
 }
 
+// Validate checks the collection policy schema, the input must set its type and tap and every handler
+// module its type
 func (p pktvisorBackend) Validate(policy types.Metadata) error {
-	// todo finish validation
+	var errs backend.ValidationErrors
+	if kind, _ := policy["kind"].(string); kind != collectionKind {
+		errs = append(errs, backend.ValidationError{Field: "kind", Message: fmt.Sprintf("must be %q", collectionKind)})
+	}
+
+	input := policy.GetSubMetadata("input")
+	if input == nil {
+		errs = append(errs, backend.ValidationError{Field: "input", Message: "is required"})
+	} else {
+		if inputType, _ := input["input_type"].(string); inputType == "" {
+			errs = append(errs, backend.ValidationError{Field: "input.input_type", Message: "is required"})
+		}
+		if tap, _ := input["tap"].(string); tap == "" && input["tap_selector"] == nil {
+			errs = append(errs, backend.ValidationError{Field: "input.tap", Message: "a tap or a tap_selector is required"})
+		}
+	}
+
+	handlers := policy.GetSubMetadata("handlers")
+	if handlers == nil {
+		errs = append(errs, backend.ValidationError{Field: "handlers", Message: "is required"})
+	} else {
+		modules := handlers.GetSubMetadata("modules")
+		if modules == nil {
+			errs = append(errs, backend.ValidationError{Field: "handlers.modules", Message: "is required"})
+		}
+		for _, name := range sortedKeys(modules) {
+			module := modules.GetSubMetadata(name)
+			if moduleType, _ := module["type"].(string); moduleType == "" {
+				errs = append(errs, backend.ValidationError{Field: "handlers.modules." + name + ".type", Message: "is required"})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -59,6 +98,15 @@ func (p pktvisorBackend) SupportsFormat(format string) bool {
 	return false
 }
 
+func sortedKeys(m types.Metadata) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func Register() bool {
 	backend.Register("pktvisor", &pktvisorBackend{})
 	return true
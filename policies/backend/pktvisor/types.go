@@ -8,6 +8,9 @@ import "github.com/orb-community/orb/pkg/types"
 
 const CurrentSchemaVersion = "1.0"
 
+// collectionKind is the only kind of policy the agents apply
+const collectionKind = "collection"
+
 type collectionPolicy struct {
 	Handlers types.Metadata `json:"handlers"`
 	Input    types.Metadata `json:"input"`
@@ -0,0 +1,71 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package promscrape
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/policies/backend"
+	"gopkg.in/yaml.v3"
+)
+
+var _ backend.Backend = (*promScrapeBackend)(nil)
+
+type promScrapeBackend struct {
+}
+
+// Validate requires an http(s) target and a positive interval, given as a duration such as "30s" or in seconds
+func (p promScrapeBackend) Validate(policy types.Metadata) error {
+	target, ok := policy["target"].(string)
+	if !ok || target == "" {
+		return errors.New("a scrape target is required")
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid scrape target %q, an http(s) URL is expected", target)
+	}
+	interval, ok := policy["interval"]
+	if !ok {
+		return errors.New("a scrape interval is required")
+	}
+	var d time.Duration
+	switch v := interval.(type) {
+	case string:
+		if d, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid scrape interval %q", v)
+		}
+	case int:
+		d = time.Duration(v) * time.Second
+	case float64:
+		d = time.Duration(v * float64(time.Second))
+	default:
+		return fmt.Errorf("invalid scrape interval %v", interval)
+	}
+	if d <= 0 {
+		return errors.New("the scrape interval must be positive")
+	}
+	return nil
+}
+
+func (p promScrapeBackend) ConvertFromFormat(format string, policy string) (metadata types.Metadata, err error) {
+	if !p.SupportsFormat(format) {
+		return nil, errors.New("unsupported format")
+	}
+	err = yaml.Unmarshal([]byte(policy), &metadata)
+	return
+}
+
+func (p promScrapeBackend) SupportsFormat(format string) bool {
+	return format == "yaml"
+}
+
+func Register() bool {
+	backend.Register("prometheus_scrape", &promScrapeBackend{})
+	return true
+}
@@ -36,6 +36,22 @@ func (m *mockPoliciesRepository) RetrieveDatasetsByGroupID(ctx context.Context,
 	return datasetList, nil
 }
 
+func (m *mockPoliciesRepository) RetrieveDatasetsBySinkID(ctx context.Context, sinkID string, ownerID string) ([]policies.Dataset, error) {
+	datasetList := make([]policies.Dataset, 0)
+	for _, d := range m.ddb {
+		if d.MFOwnerID == ownerID && d.SinkIDs != nil {
+			for _, id := range *d.SinkIDs {
+				if id == sinkID {
+					datasetList = append(datasetList, d)
+					break
+				}
+			}
+		}
+	}
+
+	return datasetList, nil
+}
+
 func (m *mockPoliciesRepository) ActivateDatasetByID(ctx context.Context, datasetID string, ownerID string) error {
 	for _, ds := range m.ddb {
 		if ds.MFOwnerID == ownerID {
@@ -246,6 +262,25 @@ func (m *mockPoliciesRepository) RetrievePolicyByID(ctx context.Context, policyI
 	return policies.Policy{}, policies.ErrNotFound
 }
 
+func (m *mockPoliciesRepository) RetrievePoliciesByIDs(ctx context.Context, policyIDs []string, ownerID string) ([]policies.Policy, error) {
+	if len(policyIDs) == 0 || ownerID == "" {
+		return nil, errors.ErrMalformedEntity
+	}
+
+	seen := make(map[string]bool, len(policyIDs))
+	policyList := make([]policies.Policy, 0)
+	for _, id := range policyIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if p, ok := m.pdb[id]; ok && p.MFOwnerID == ownerID {
+			policyList = append(policyList, p)
+		}
+	}
+	return policyList, nil
+}
+
 func (m *mockPoliciesRepository) RetrievePoliciesByGroupID(ctx context.Context, groupIDs []string, ownerID string) (ret []policies.PolicyInDataset, err error) {
 	if len(groupIDs) == 0 || ownerID == "" {
 		return nil, errors.ErrMalformedEntity
@@ -130,6 +130,61 @@ func (x *DatasetsByGroupsReq) GetOwnerID() string {
 	return ""
 }
 
+type DatasetsBySinkReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SinkID  string `protobuf:"bytes,1,opt,name=sinkID,proto3" json:"sinkID,omitempty"`
+	OwnerID string `protobuf:"bytes,2,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
+}
+
+func (x *DatasetsBySinkReq) Reset() {
+	*x = DatasetsBySinkReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_policies_pb_policies_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetsBySinkReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetsBySinkReq) ProtoMessage() {}
+
+func (x *DatasetsBySinkReq) ProtoReflect() protoreflect.Message {
+	mi := &file_policies_pb_policies_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetsBySinkReq.ProtoReflect.Descriptor instead.
+func (*DatasetsBySinkReq) Descriptor() ([]byte, []int) {
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DatasetsBySinkReq) GetSinkID() string {
+	if x != nil {
+		return x.SinkID
+	}
+	return ""
+}
+
+func (x *DatasetsBySinkReq) GetOwnerID() string {
+	if x != nil {
+		return x.OwnerID
+	}
+	return ""
+}
+
 type PoliciesByGroupsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -142,7 +197,7 @@ type PoliciesByGroupsReq struct {
 func (x *PoliciesByGroupsReq) Reset() {
 	*x = PoliciesByGroupsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[2]
+		mi := &file_policies_pb_policies_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -155,7 +210,7 @@ func (x *PoliciesByGroupsReq) String() string {
 func (*PoliciesByGroupsReq) ProtoMessage() {}
 
 func (x *PoliciesByGroupsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[2]
+	mi := &file_policies_pb_policies_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +223,7 @@ func (x *PoliciesByGroupsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoliciesByGroupsReq.ProtoReflect.Descriptor instead.
 func (*PoliciesByGroupsReq) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{2}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PoliciesByGroupsReq) GetGroupIDs() []string {
@@ -197,7 +252,7 @@ type DatasetByIDReq struct {
 func (x *DatasetByIDReq) Reset() {
 	*x = DatasetByIDReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[3]
+		mi := &file_policies_pb_policies_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -210,7 +265,7 @@ func (x *DatasetByIDReq) String() string {
 func (*DatasetByIDReq) ProtoMessage() {}
 
 func (x *DatasetByIDReq) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[3]
+	mi := &file_policies_pb_policies_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +278,7 @@ func (x *DatasetByIDReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatasetByIDReq.ProtoReflect.Descriptor instead.
 func (*DatasetByIDReq) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{3}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DatasetByIDReq) GetDatasetID() string {
@@ -256,7 +311,7 @@ type PolicyRes struct {
 func (x *PolicyRes) Reset() {
 	*x = PolicyRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[4]
+		mi := &file_policies_pb_policies_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -269,7 +324,7 @@ func (x *PolicyRes) String() string {
 func (*PolicyRes) ProtoMessage() {}
 
 func (x *PolicyRes) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[4]
+	mi := &file_policies_pb_policies_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +337,7 @@ func (x *PolicyRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyRes.ProtoReflect.Descriptor instead.
 func (*PolicyRes) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{4}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PolicyRes) GetId() string {
@@ -332,20 +387,21 @@ type PolicyInDSRes struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Backend      string `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
-	Version      int32  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
-	Data         []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
-	DatasetId    string `protobuf:"bytes,6,opt,name=dataset_id,json=datasetId,proto3" json:"dataset_id,omitempty"`
-	AgentGroupId string `protobuf:"bytes,7,opt,name=agent_group_id,json=agentGroupId,proto3" json:"agent_group_id,omitempty"`
-	Format       string `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Backend      string   `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
+	Version      int32    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Data         []byte   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	DatasetId    string   `protobuf:"bytes,6,opt,name=dataset_id,json=datasetId,proto3" json:"dataset_id,omitempty"`
+	AgentGroupId string   `protobuf:"bytes,7,opt,name=agent_group_id,json=agentGroupId,proto3" json:"agent_group_id,omitempty"`
+	Format       string   `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	DependsOn    []string `protobuf:"bytes,9,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
 }
 
 func (x *PolicyInDSRes) Reset() {
 	*x = PolicyInDSRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[5]
+		mi := &file_policies_pb_policies_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -358,7 +414,7 @@ func (x *PolicyInDSRes) String() string {
 func (*PolicyInDSRes) ProtoMessage() {}
 
 func (x *PolicyInDSRes) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[5]
+	mi := &file_policies_pb_policies_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +427,7 @@ func (x *PolicyInDSRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyInDSRes.ProtoReflect.Descriptor instead.
 func (*PolicyInDSRes) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{5}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PolicyInDSRes) GetId() string {
@@ -430,6 +486,13 @@ func (x *PolicyInDSRes) GetFormat() string {
 	return ""
 }
 
+func (x *PolicyInDSRes) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
 type PolicyInDSListRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -441,7 +504,7 @@ type PolicyInDSListRes struct {
 func (x *PolicyInDSListRes) Reset() {
 	*x = PolicyInDSListRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[6]
+		mi := &file_policies_pb_policies_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -454,7 +517,7 @@ func (x *PolicyInDSListRes) String() string {
 func (*PolicyInDSListRes) ProtoMessage() {}
 
 func (x *PolicyInDSListRes) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[6]
+	mi := &file_policies_pb_policies_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +530,7 @@ func (x *PolicyInDSListRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyInDSListRes.ProtoReflect.Descriptor instead.
 func (*PolicyInDSListRes) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{6}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PolicyInDSListRes) GetPolicies() []*PolicyInDSRes {
@@ -491,7 +554,7 @@ type DatasetRes struct {
 func (x *DatasetRes) Reset() {
 	*x = DatasetRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[7]
+		mi := &file_policies_pb_policies_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -504,7 +567,7 @@ func (x *DatasetRes) String() string {
 func (*DatasetRes) ProtoMessage() {}
 
 func (x *DatasetRes) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[7]
+	mi := &file_policies_pb_policies_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +580,7 @@ func (x *DatasetRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatasetRes.ProtoReflect.Descriptor instead.
 func (*DatasetRes) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{7}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DatasetRes) GetId() string {
@@ -559,7 +622,7 @@ type DatasetsRes struct {
 func (x *DatasetsRes) Reset() {
 	*x = DatasetsRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_policies_pb_policies_proto_msgTypes[8]
+		mi := &file_policies_pb_policies_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -572,7 +635,7 @@ func (x *DatasetsRes) String() string {
 func (*DatasetsRes) ProtoMessage() {}
 
 func (x *DatasetsRes) ProtoReflect() protoreflect.Message {
-	mi := &file_policies_pb_policies_proto_msgTypes[8]
+	mi := &file_policies_pb_policies_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +648,7 @@ func (x *DatasetsRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatasetsRes.ProtoReflect.Descriptor instead.
 func (*DatasetsRes) Descriptor() ([]byte, []int) {
-	return file_policies_pb_policies_proto_rawDescGZIP(), []int{8}
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DatasetsRes) GetDatasetList() []*DatasetRes {
@@ -595,6 +658,108 @@ func (x *DatasetsRes) GetDatasetList() []*DatasetRes {
 	return nil
 }
 
+type PoliciesByIDsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PolicyIDs []string `protobuf:"bytes,1,rep,name=policyIDs,proto3" json:"policyIDs,omitempty"`
+	OwnerID   string   `protobuf:"bytes,2,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
+}
+
+func (x *PoliciesByIDsReq) Reset() {
+	*x = PoliciesByIDsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_policies_pb_policies_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoliciesByIDsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoliciesByIDsReq) ProtoMessage() {}
+
+func (x *PoliciesByIDsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_policies_pb_policies_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoliciesByIDsReq.ProtoReflect.Descriptor instead.
+func (*PoliciesByIDsReq) Descriptor() ([]byte, []int) {
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PoliciesByIDsReq) GetPolicyIDs() []string {
+	if x != nil {
+		return x.PolicyIDs
+	}
+	return nil
+}
+
+func (x *PoliciesByIDsReq) GetOwnerID() string {
+	if x != nil {
+		return x.OwnerID
+	}
+	return ""
+}
+
+type PoliciesRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policies []*PolicyRes `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+}
+
+func (x *PoliciesRes) Reset() {
+	*x = PoliciesRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_policies_pb_policies_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoliciesRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoliciesRes) ProtoMessage() {}
+
+func (x *PoliciesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_policies_pb_policies_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoliciesRes.ProtoReflect.Descriptor instead.
+func (*PoliciesRes) Descriptor() ([]byte, []int) {
+	return file_policies_pb_policies_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PoliciesRes) GetPolicies() []*PolicyRes {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
 var File_policies_pb_policies_proto protoreflect.FileDescriptor
 
 var file_policies_pb_policies_proto_rawDesc = []byte{
@@ -609,78 +774,103 @@ var file_policies_pb_policies_proto_rawDesc = []byte{
 	0x73, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x73,
 	0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x4b, 0x0a, 0x13, 0x50, 0x6f,
-	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x48, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x73,
-	0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x65, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x65, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x45, 0x0a, 0x11, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x42, 0x79, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
 	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49,
-	0x44, 0x22, 0x8f, 0x01, 0x0a, 0x09, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x74, 0x22, 0xd8, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e,
-	0x44, 0x53, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63,
-	0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b,
-	0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x49, 0x64,
-	0x12, 0x24, 0x0a, 0x0e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
-	0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x48,
-	0x0a, 0x11, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e, 0x44, 0x53, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73,
-	0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e, 0x44, 0x53, 0x52, 0x65, 0x73, 0x52, 0x08,
-	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x22, 0x7a, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61,
-	0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x6e,
-	0x6b, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x69, 0x6e,
-	0x6b, 0x49, 0x64, 0x73, 0x22, 0x45, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x4c, 0x69,
-	0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63,
-	0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x52, 0x0b,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x32, 0xc4, 0x02, 0x0a, 0x0d,
-	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x40, 0x0a,
-	0x0e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
-	0x17, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63,
-	0x79, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63,
-	0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12,
-	0x58, 0x0a, 0x18, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63,
-	0x69, 0x65, 0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x6f,
-	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x42,
-	0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x70, 0x6f, 0x6c,
-	0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e, 0x44, 0x53,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x18, 0x2e, 0x70,
-	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x42,
-	0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65,
-	0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x52,
-	0x0a, 0x18, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
-	0x74, 0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x6f, 0x6c,
-	0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x42, 0x79,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x6f, 0x6c, 0x69,
-	0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2f, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x22, 0x4b, 0x0a, 0x13, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x42, 0x79, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x44, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x48,
+	0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x8f, 0x01, 0x0a, 0x09, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0xf7, 0x01, 0x0a, 0x0d, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e, 0x44, 0x53, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73,
+	0x5f, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x73, 0x4f, 0x6e, 0x22, 0x48, 0x0a, 0x11, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e,
+	0x44, 0x53, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e, 0x44,
+	0x53, 0x52, 0x65, 0x73, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x22, 0x7a,
+	0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x69, 0x6e, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x6b, 0x49, 0x64, 0x73, 0x22, 0x45, 0x0a, 0x0b, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x22, 0x4a, 0x0a, 0x10, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x42, 0x79, 0x49,
+	0x44, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49,
+	0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x49, 0x44, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x22, 0x3e, 0x0a,
+	0x0b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x08,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x65, 0x73, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x32, 0xdd, 0x03,
+	0x0a, 0x0d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x40, 0x0a, 0x0e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x22,
+	0x00, 0x12, 0x58, 0x0a, 0x18, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1d, 0x2e,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65,
+	0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x6e,
+	0x44, 0x53, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x52,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x18,
+	0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00,
+	0x12, 0x52, 0x0a, 0x18, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x73, 0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1d, 0x2e, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73,
+	0x42, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x16, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x42, 0x79, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x1b,
+	0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x73, 0x42, 0x79, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x10, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x42, 0x79, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2e,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x0d, 0x5a,
+	0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -695,34 +885,42 @@ func file_policies_pb_policies_proto_rawDescGZIP() []byte {
 	return file_policies_pb_policies_proto_rawDescData
 }
 
-var file_policies_pb_policies_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_policies_pb_policies_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_policies_pb_policies_proto_goTypes = []interface{}{
 	(*PolicyByIDReq)(nil),       // 0: policies.PolicyByIDReq
 	(*DatasetsByGroupsReq)(nil), // 1: policies.DatasetsByGroupsReq
-	(*PoliciesByGroupsReq)(nil), // 2: policies.PoliciesByGroupsReq
-	(*DatasetByIDReq)(nil),      // 3: policies.DatasetByIDReq
-	(*PolicyRes)(nil),           // 4: policies.PolicyRes
-	(*PolicyInDSRes)(nil),       // 5: policies.PolicyInDSRes
-	(*PolicyInDSListRes)(nil),   // 6: policies.PolicyInDSListRes
-	(*DatasetRes)(nil),          // 7: policies.DatasetRes
-	(*DatasetsRes)(nil),         // 8: policies.DatasetsRes
+	(*DatasetsBySinkReq)(nil),   // 2: policies.DatasetsBySinkReq
+	(*PoliciesByGroupsReq)(nil), // 3: policies.PoliciesByGroupsReq
+	(*DatasetByIDReq)(nil),      // 4: policies.DatasetByIDReq
+	(*PolicyRes)(nil),           // 5: policies.PolicyRes
+	(*PolicyInDSRes)(nil),       // 6: policies.PolicyInDSRes
+	(*PolicyInDSListRes)(nil),   // 7: policies.PolicyInDSListRes
+	(*DatasetRes)(nil),          // 8: policies.DatasetRes
+	(*DatasetsRes)(nil),         // 9: policies.DatasetsRes
+	(*PoliciesByIDsReq)(nil),    // 10: policies.PoliciesByIDsReq
+	(*PoliciesRes)(nil),         // 11: policies.PoliciesRes
 }
 var file_policies_pb_policies_proto_depIdxs = []int32{
-	5, // 0: policies.PolicyInDSListRes.policies:type_name -> policies.PolicyInDSRes
-	7, // 1: policies.DatasetsRes.datasetList:type_name -> policies.DatasetRes
-	0, // 2: policies.PolicyService.RetrievePolicy:input_type -> policies.PolicyByIDReq
-	2, // 3: policies.PolicyService.RetrievePoliciesByGroups:input_type -> policies.PoliciesByGroupsReq
-	3, // 4: policies.PolicyService.RetrieveDataset:input_type -> policies.DatasetByIDReq
-	1, // 5: policies.PolicyService.RetrieveDatasetsByGroups:input_type -> policies.DatasetsByGroupsReq
-	4, // 6: policies.PolicyService.RetrievePolicy:output_type -> policies.PolicyRes
-	6, // 7: policies.PolicyService.RetrievePoliciesByGroups:output_type -> policies.PolicyInDSListRes
-	7, // 8: policies.PolicyService.RetrieveDataset:output_type -> policies.DatasetRes
-	8, // 9: policies.PolicyService.RetrieveDatasetsByGroups:output_type -> policies.DatasetsRes
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6,  // 0: policies.PolicyInDSListRes.policies:type_name -> policies.PolicyInDSRes
+	8,  // 1: policies.DatasetsRes.datasetList:type_name -> policies.DatasetRes
+	5,  // 2: policies.PoliciesRes.policies:type_name -> policies.PolicyRes
+	0,  // 3: policies.PolicyService.RetrievePolicy:input_type -> policies.PolicyByIDReq
+	3,  // 4: policies.PolicyService.RetrievePoliciesByGroups:input_type -> policies.PoliciesByGroupsReq
+	4,  // 5: policies.PolicyService.RetrieveDataset:input_type -> policies.DatasetByIDReq
+	1,  // 6: policies.PolicyService.RetrieveDatasetsByGroups:input_type -> policies.DatasetsByGroupsReq
+	2,  // 7: policies.PolicyService.RetrieveDatasetsBySink:input_type -> policies.DatasetsBySinkReq
+	10, // 8: policies.PolicyService.RetrievePolicies:input_type -> policies.PoliciesByIDsReq
+	5,  // 9: policies.PolicyService.RetrievePolicy:output_type -> policies.PolicyRes
+	7,  // 10: policies.PolicyService.RetrievePoliciesByGroups:output_type -> policies.PolicyInDSListRes
+	8,  // 11: policies.PolicyService.RetrieveDataset:output_type -> policies.DatasetRes
+	9,  // 12: policies.PolicyService.RetrieveDatasetsByGroups:output_type -> policies.DatasetsRes
+	9,  // 13: policies.PolicyService.RetrieveDatasetsBySink:output_type -> policies.DatasetsRes
+	11, // 14: policies.PolicyService.RetrievePolicies:output_type -> policies.PoliciesRes
+	9,  // [9:15] is the sub-list for method output_type
+	3,  // [3:9] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_policies_pb_policies_proto_init() }
@@ -756,7 +954,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoliciesByGroupsReq); i {
+			switch v := v.(*DatasetsBySinkReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -768,7 +966,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DatasetByIDReq); i {
+			switch v := v.(*PoliciesByGroupsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -780,7 +978,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PolicyRes); i {
+			switch v := v.(*DatasetByIDReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -792,7 +990,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PolicyInDSRes); i {
+			switch v := v.(*PolicyRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -804,7 +1002,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PolicyInDSListRes); i {
+			switch v := v.(*PolicyInDSRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -816,7 +1014,7 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DatasetRes); i {
+			switch v := v.(*PolicyInDSListRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -828,6 +1026,18 @@ func file_policies_pb_policies_proto_init() {
 			}
 		}
 		file_policies_pb_policies_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_policies_pb_policies_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DatasetsRes); i {
 			case 0:
 				return &v.state
@@ -839,6 +1049,30 @@ func file_policies_pb_policies_proto_init() {
 				return nil
 			}
 		}
+		file_policies_pb_policies_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoliciesByIDsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_policies_pb_policies_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoliciesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -846,7 +1080,7 @@ func file_policies_pb_policies_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_policies_pb_policies_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
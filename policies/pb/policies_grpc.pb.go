@@ -26,6 +26,8 @@ type PolicyServiceClient interface {
 	RetrievePoliciesByGroups(ctx context.Context, in *PoliciesByGroupsReq, opts ...grpc.CallOption) (*PolicyInDSListRes, error)
 	RetrieveDataset(ctx context.Context, in *DatasetByIDReq, opts ...grpc.CallOption) (*DatasetRes, error)
 	RetrieveDatasetsByGroups(ctx context.Context, in *DatasetsByGroupsReq, opts ...grpc.CallOption) (*DatasetsRes, error)
+	RetrieveDatasetsBySink(ctx context.Context, in *DatasetsBySinkReq, opts ...grpc.CallOption) (*DatasetsRes, error)
+	RetrievePolicies(ctx context.Context, in *PoliciesByIDsReq, opts ...grpc.CallOption) (*PoliciesRes, error)
 }
 
 type policyServiceClient struct {
@@ -72,6 +74,24 @@ func (c *policyServiceClient) RetrieveDatasetsByGroups(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *policyServiceClient) RetrieveDatasetsBySink(ctx context.Context, in *DatasetsBySinkReq, opts ...grpc.CallOption) (*DatasetsRes, error) {
+	out := new(DatasetsRes)
+	err := c.cc.Invoke(ctx, "/policies.PolicyService/RetrieveDatasetsBySink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyServiceClient) RetrievePolicies(ctx context.Context, in *PoliciesByIDsReq, opts ...grpc.CallOption) (*PoliciesRes, error) {
+	out := new(PoliciesRes)
+	err := c.cc.Invoke(ctx, "/policies.PolicyService/RetrievePolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PolicyServiceServer is the server API for PolicyService service.
 // All implementations must embed UnimplementedPolicyServiceServer
 // for forward compatibility
@@ -80,6 +100,8 @@ type PolicyServiceServer interface {
 	RetrievePoliciesByGroups(context.Context, *PoliciesByGroupsReq) (*PolicyInDSListRes, error)
 	RetrieveDataset(context.Context, *DatasetByIDReq) (*DatasetRes, error)
 	RetrieveDatasetsByGroups(context.Context, *DatasetsByGroupsReq) (*DatasetsRes, error)
+	RetrieveDatasetsBySink(context.Context, *DatasetsBySinkReq) (*DatasetsRes, error)
+	RetrievePolicies(context.Context, *PoliciesByIDsReq) (*PoliciesRes, error)
 	mustEmbedUnimplementedPolicyServiceServer()
 }
 
@@ -99,6 +121,12 @@ func (UnimplementedPolicyServiceServer) RetrieveDataset(context.Context, *Datase
 func (UnimplementedPolicyServiceServer) RetrieveDatasetsByGroups(context.Context, *DatasetsByGroupsReq) (*DatasetsRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveDatasetsByGroups not implemented")
 }
+func (UnimplementedPolicyServiceServer) RetrieveDatasetsBySink(context.Context, *DatasetsBySinkReq) (*DatasetsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveDatasetsBySink not implemented")
+}
+func (UnimplementedPolicyServiceServer) RetrievePolicies(context.Context, *PoliciesByIDsReq) (*PoliciesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrievePolicies not implemented")
+}
 func (UnimplementedPolicyServiceServer) mustEmbedUnimplementedPolicyServiceServer() {}
 
 // UnsafePolicyServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -184,6 +212,42 @@ func _PolicyService_RetrieveDatasetsByGroups_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyService_RetrieveDatasetsBySink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DatasetsBySinkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).RetrieveDatasetsBySink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/policies.PolicyService/RetrieveDatasetsBySink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).RetrieveDatasetsBySink(ctx, req.(*DatasetsBySinkReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyService_RetrievePolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoliciesByIDsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).RetrievePolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/policies.PolicyService/RetrievePolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).RetrievePolicies(ctx, req.(*PoliciesByIDsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PolicyService_ServiceDesc is the grpc.ServiceDesc for PolicyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +271,14 @@ var PolicyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveDatasetsByGroups",
 			Handler:    _PolicyService_RetrieveDatasetsByGroups_Handler,
 		},
+		{
+			MethodName: "RetrieveDatasetsBySink",
+			Handler:    _PolicyService_RetrieveDatasetsBySink_Handler,
+		},
+		{
+			MethodName: "RetrievePolicies",
+			Handler:    _PolicyService_RetrievePolicies_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "policies/pb/policies.proto",
@@ -23,10 +23,26 @@ type Policy struct {
 	Policy        types.Metadata
 	PolicyData    string
 	Format        string
-	Created       time.Time
-	LastModified  time.Time
+	// DependsOn lists the policies applied before this one when an agent receives them in the same batch
+	DependsOn    []string
+	Created      time.Time
+	LastModified time.Time
+}
+
+// PolicyIssue is a problem found on a policy checked without saving it, Field is the dotted path of the
+// offending field when known
+type PolicyIssue struct {
+	Field   string
+	Message string
 }
 
+// The signal types of a dataset, matching the signals accepted by the sinks
+const (
+	DatasetTypeMetrics = "metrics"
+	DatasetTypeLogs    = "logs"
+	DatasetTypeTraces  = "traces"
+)
+
 type Dataset struct {
 	ID           string
 	Name         types.Identifier
@@ -38,6 +54,8 @@ type Dataset struct {
 	Created      time.Time
 	Tags         types.Tags
 	SinkIDs      *[]string
+	// Type is the signal routed by the dataset, metrics when not set
+	Type string
 }
 
 type PolicyInDataset struct {
@@ -69,6 +87,9 @@ type Service interface {
 	// ViewPolicyByIDInternal gRPC version of retrieving policy by id with no token
 	ViewPolicyByIDInternal(ctx context.Context, policyID string, ownerID string) (Policy, error)
 
+	// ListPoliciesByIDsInternal gRPC version of retrieving the policies by ids with no token, the ids not found are omitted
+	ListPoliciesByIDsInternal(ctx context.Context, policyIDs []string, ownerID string) ([]Policy, error)
+
 	// ListPoliciesByGroupIDInternal gRPC version of retrieving list of policies belonging to specified agent group with no token
 	ListPoliciesByGroupIDInternal(ctx context.Context, groupIDs []string, ownerID string) ([]PolicyInDataset, error)
 
@@ -90,6 +111,10 @@ type Service interface {
 	// ValidatePolicy validates an agent Policy without saving
 	ValidatePolicy(ctx context.Context, token string, p Policy) (Policy, error)
 
+	// CheckPolicy runs an agent Policy through the schema validator of its backend without saving it, reporting
+	// every issue found along with a name already used by another policy of the owner
+	CheckPolicy(ctx context.Context, token string, p Policy) ([]PolicyIssue, error)
+
 	// EditDataset edit a existing dataset by id with a valid token
 	EditDataset(ctx context.Context, token string, ds Dataset) (Dataset, error)
 	// RemoveDataset remove a dataset by id with a valid token
@@ -124,6 +149,9 @@ type Service interface {
 
 	// ListDatasetsByGroupIDInternal gRPC version of retrieving list of datasets belonging to specified agent group with no token
 	ListDatasetsByGroupIDInternal(ctx context.Context, groupIDs []string, ownerID string) ([]Dataset, error)
+
+	// ListDatasetsBySinkIDInternal gRPC version of retrieving list of datasets routing to the specified sink with no token
+	ListDatasetsBySinkIDInternal(ctx context.Context, sinkID string, ownerID string) ([]Dataset, error)
 }
 
 type Repository interface {
@@ -134,6 +162,9 @@ type Repository interface {
 	// RetrievePolicyByID Retrieve policy by id
 	RetrievePolicyByID(ctx context.Context, policyID string, ownerID string) (Policy, error)
 
+	// RetrievePoliciesByIDs Retrieve the policies by ids owned by the specified user, skipping the ids not found
+	RetrievePoliciesByIDs(ctx context.Context, policyIDs []string, ownerID string) ([]Policy, error)
+
 	// RetrievePoliciesByGroupID Retrieve policy list by group id
 	RetrievePoliciesByGroupID(ctx context.Context, groupIDs []string, ownerID string) ([]PolicyInDataset, error)
 
@@ -188,4 +219,7 @@ type Repository interface {
 
 	// RetrieveDatasetsByGroupID Retrieve dataset list by group id
 	RetrieveDatasetsByGroupID(ctx context.Context, groupIDs []string, ownerID string) ([]Dataset, error)
+
+	// RetrieveDatasetsBySinkID Retrieve dataset list routing to the sink id
+	RetrieveDatasetsBySinkID(ctx context.Context, sinkID string, ownerID string) ([]Dataset, error)
 }
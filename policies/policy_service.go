@@ -10,7 +10,9 @@ package policies
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
+	"strings"
 
 	"google.golang.org/grpc/status"
 
@@ -33,6 +35,7 @@ var (
 	ErrNotFound                = errors.New("non-existent entity")
 	ErrUnauthorizedAccess      = errors.New("missing or invalid credentials provided")
 	ErrNotifyAgentGroupChannel = errors.New("failed to notify agent group channel")
+	ErrIncompatibleSink        = errors.New("sink does not accept the dataset type")
 )
 
 func (s policiesService) ListDatasetsByGroupIDInternal(ctx context.Context, groupIDs []string, ownerID string) ([]Dataset, error) {
@@ -42,6 +45,13 @@ func (s policiesService) ListDatasetsByGroupIDInternal(ctx context.Context, grou
 	return s.repo.RetrieveDatasetsByGroupID(ctx, groupIDs, ownerID)
 }
 
+func (s policiesService) ListDatasetsBySinkIDInternal(ctx context.Context, sinkID string, ownerID string) ([]Dataset, error) {
+	if sinkID == "" || ownerID == "" {
+		return nil, ErrMalformedEntity
+	}
+	return s.repo.RetrieveDatasetsBySinkID(ctx, sinkID, ownerID)
+}
+
 func (s policiesService) ListPolicies(ctx context.Context, token string, pm PageMetadata) (Page, error) {
 	ownerID, err := s.identify(token)
 	if err != nil {
@@ -64,6 +74,13 @@ func (s policiesService) ViewPolicyByIDInternal(ctx context.Context, policyID st
 	return s.repo.RetrievePolicyByID(ctx, policyID, ownerID)
 }
 
+func (s policiesService) ListPoliciesByIDsInternal(ctx context.Context, policyIDs []string, ownerID string) ([]Policy, error) {
+	if len(policyIDs) == 0 || ownerID == "" {
+		return nil, ErrMalformedEntity
+	}
+	return s.repo.RetrievePoliciesByIDs(ctx, policyIDs, ownerID)
+}
+
 func (s policiesService) AddDataset(ctx context.Context, token string, d Dataset) (Dataset, error) {
 	mfOwnerID, err := s.identify(token)
 	if err != nil {
@@ -72,6 +89,14 @@ func (s policiesService) AddDataset(ctx context.Context, token string, d Dataset
 
 	d.MFOwnerID = mfOwnerID
 
+	// every sink accepts metrics, the sinks of the other types must support them
+	if d.Type != "" && d.Type != DatasetTypeMetrics && d.SinkIDs != nil {
+		err = s.validateDatasetSink(ctx, d.MFOwnerID, d.Type, *d.SinkIDs)
+		if err != nil {
+			return Dataset{}, errors.Wrap(ErrCreateDataset, err)
+		}
+	}
+
 	id, err := s.repo.SaveDataset(ctx, d)
 	if err != nil {
 		return Dataset{}, errors.Wrap(ErrCreateDataset, err)
@@ -167,6 +192,15 @@ func (s policiesService) EditPolicy(ctx context.Context, token string, pol Polic
 		pol.OrbTags = currentPol.OrbTags
 	}
 
+	if pol.DependsOn == nil {
+		pol.DependsOn = currentPol.DependsOn
+	}
+	for _, dep := range pol.DependsOn {
+		if dep == pol.ID {
+			return Policy{}, errors.Wrap(ErrMalformedEntity, errors.New("a policy can not depend on itself"))
+		}
+	}
+
 	pol.Version++
 	err = s.repo.UpdatePolicy(ctx, ownerID, pol)
 	if err != nil {
@@ -288,6 +322,79 @@ func (s policiesService) ValidatePolicy(ctx context.Context, token string, p Pol
 	return p, nil
 }
 
+func (s policiesService) CheckPolicy(ctx context.Context, token string, p Policy) ([]PolicyIssue, error) {
+	mfOwnerID, err := s.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := checkPolicyBackend(&p)
+	if p.Name.String() != "" {
+		taken, err := s.policyNameTaken(ctx, mfOwnerID, p.Name.String())
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			issues = append(issues, PolicyIssue{Field: "name", Message: "policy with name already defined"})
+		}
+	}
+	return issues, nil
+}
+
+// checkPolicyBackend runs the policy through its backend as validatePolicyBackend does, but reports every
+// schema issue the backend found instead of the first error
+func checkPolicyBackend(p *Policy) []PolicyIssue {
+	if !backend.HaveBackend(p.Backend) {
+		return []PolicyIssue{{Field: "backend", Message: fmt.Sprintf("unsupported backend: '%s'", p.Backend)}}
+	}
+	be := backend.GetBackend(p.Backend)
+	if p.Policy == nil {
+		if !be.SupportsFormat(p.Format) {
+			return []PolicyIssue{{Field: "format",
+				Message: fmt.Sprintf("unsupported policy format '%s' for given backend '%s'", p.Format, p.Backend)}}
+		}
+		policy, err := be.ConvertFromFormat(p.Format, p.PolicyData)
+		if err != nil {
+			return []PolicyIssue{{Field: "policy_data", Message: err.Error()}}
+		}
+		p.Policy = policy
+	}
+
+	err := be.Validate(p.Policy)
+	if err == nil {
+		return nil
+	}
+	var validationErrs backend.ValidationErrors
+	if !goerrors.As(err, &validationErrs) {
+		return []PolicyIssue{{Field: "policy", Message: err.Error()}}
+	}
+	issues := make([]PolicyIssue, len(validationErrs))
+	for i, validationErr := range validationErrs {
+		issues[i] = PolicyIssue{Field: "policy." + validationErr.Field, Message: validationErr.Message}
+	}
+	return issues
+}
+
+// policyNameTaken pages through the policies matching the name, the name filter being a partial match
+func (s policiesService) policyNameTaken(ctx context.Context, ownerID string, name string) (bool, error) {
+	pm := PageMetadata{Name: name, Limit: 100}
+	for {
+		page, err := s.repo.RetrieveAll(ctx, ownerID, pm)
+		if err != nil {
+			return false, err
+		}
+		for _, policy := range page.Policies {
+			if policy.Name.String() == name {
+				return true, nil
+			}
+		}
+		pm.Offset += pm.Limit
+		if len(page.Policies) == 0 || pm.Offset >= page.Total {
+			return false, nil
+		}
+	}
+}
+
 func (s policiesService) EditDataset(ctx context.Context, token string, ds Dataset) (Dataset, error) {
 	mfOwnerID, err := s.identify(token)
 	if err != nil {
@@ -312,7 +419,9 @@ func (s policiesService) EditDataset(ctx context.Context, token string, ds Datas
 		ds.SinkIDs = currentDataset.SinkIDs
 	}
 
-	err = s.validateDatasetSink(ctx, ds.MFOwnerID, *ds.SinkIDs)
+	// the type of a dataset cannot change, the sinks are checked against the stored one
+	ds.Type = currentDataset.Type
+	err = s.validateDatasetSink(ctx, ds.MFOwnerID, ds.Type, *ds.SinkIDs)
 	if err != nil {
 		return Dataset{}, err
 	}
@@ -361,7 +470,7 @@ func (s policiesService) ValidateDataset(ctx context.Context, token string, d Da
 
 	d.MFOwnerID = mfOwnerID
 
-	err = s.validateDatasetSink(ctx, d.MFOwnerID, *d.SinkIDs)
+	err = s.validateDatasetSink(ctx, d.MFOwnerID, d.Type, *d.SinkIDs)
 	if err != nil {
 		return Dataset{}, err
 	}
@@ -413,7 +522,8 @@ func (s policiesService) InactivateDatasetByIDInternal(ctx context.Context, owne
 	return nil
 }
 
-func (s policiesService) validateDatasetSink(ctx context.Context, ownerID string, sinkIDs []string) error {
+// validateDatasetSink checks the sinks exist and accept the signal type of the dataset
+func (s policiesService) validateDatasetSink(ctx context.Context, ownerID string, datasetType string, sinkIDs []string) error {
 
 	if len(sinkIDs) == 0 {
 		return errors.Wrap(errors.ErrMalformedEntity, errors.New("empty sink IDs"))
@@ -424,17 +534,38 @@ func (s policiesService) validateDatasetSink(ctx context.Context, ownerID string
 			return errors.Wrap(errors.New("invalid sink id"), errors.ErrMalformedEntity)
 		}
 
-		_, err = s.sinksGrpcClient.RetrieveSink(ctx, &sinkpb.SinkByIDReq{
+		sink, err := s.sinksGrpcClient.RetrieveSink(ctx, &sinkpb.SinkByIDReq{
 			SinkID:  sinkID,
 			OwnerID: ownerID,
 		})
 		if err != nil {
 			return errors.Wrap(errors.New("sink id does not exist"), err)
 		}
+		if !acceptsSignal(sink.GetSignals(), datasetType) {
+			return errors.Wrap(ErrIncompatibleSink, errors.Wrap(errors.ErrMalformedEntity,
+				errors.New(fmt.Sprintf("sink %s only accepts %s", sinkID, strings.Join(sink.GetSignals(), ", ")))))
+		}
 	}
 	return nil
 }
 
+// acceptsSignal reports whether a sink accepting the given signals can receive the dataset type, the sinks
+// not reporting their signals are accepted
+func acceptsSignal(signals []string, datasetType string) bool {
+	if len(signals) == 0 {
+		return true
+	}
+	if datasetType == "" {
+		datasetType = DatasetTypeMetrics
+	}
+	for _, signal := range signals {
+		if signal == datasetType {
+			return true
+		}
+	}
+	return false
+}
+
 func (s policiesService) validateDatasetPolicy(ctx context.Context, ownerID string, policyID string) error {
 	_, err := uuid.FromString(policyID)
 	if err != nil {
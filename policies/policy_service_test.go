@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"strings"
 	"testing"
 	"time"
 )
@@ -199,6 +200,7 @@ func TestEditPolicy(t *testing.T) {
 	policyTestDescriptionAttribute := createPolicy(t, svc, "policyDescription")
 	policyTestTagsAttribute := createPolicy(t, svc, "policyTags")
 	policyTestTagsAttributeDeletion := createPolicy(t, svc, "policyTags2")
+	policyTestDependsOnAttribute := createPolicy(t, svc, "policyDependsOn")
 
 	nameID, err := types.NewIdentifier("new-policy")
 	require.Nil(t, err, fmt.Sprintf("Unexpected error: %s", err))
@@ -366,6 +368,28 @@ func TestEditPolicy(t *testing.T) {
 			token: token,
 			err:   nil,
 		},
+		"update the dependencies of an existing policy": {
+			policy: policies.Policy{
+				ID:        policyTestDependsOnAttribute.ID,
+				DependsOn: []string{policy.ID},
+			},
+			expectedPolicy: policies.Policy{
+				Name:        policyTestDependsOnAttribute.Name,
+				OrbTags:     policyTestDependsOnAttribute.OrbTags,
+				Description: policyTestDependsOnAttribute.Description,
+				DependsOn:   []string{policy.ID},
+			},
+			token: token,
+			err:   nil,
+		},
+		"update a policy to depend on itself": {
+			policy: policies.Policy{
+				ID:        policyTestTagsAttribute.ID,
+				DependsOn: []string{policyTestTagsAttribute.ID},
+			},
+			token: token,
+			err:   policies.ErrMalformedEntity,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -375,6 +399,7 @@ func TestEditPolicy(t *testing.T) {
 				assert.Equal(t, tc.expectedPolicy.Name.String(), res.Name.String(), fmt.Sprintf("%s: expected name %s got %s", desc, tc.expectedPolicy.Name.String(), res.Name.String()))
 				assert.Equal(t, *tc.expectedPolicy.Description, *res.Description, fmt.Sprintf("%s: expected description %s got %s", desc, *tc.expectedPolicy.Description, *res.Description))
 				assert.Equal(t, tc.expectedPolicy.OrbTags, res.OrbTags, fmt.Sprintf("%s: expected tags %s got %s", desc, tc.expectedPolicy.OrbTags, res.OrbTags))
+				assert.Equal(t, tc.expectedPolicy.DependsOn, res.DependsOn, fmt.Sprintf("%s: expected dependencies %v got %v", desc, tc.expectedPolicy.DependsOn, res.DependsOn))
 			}
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %d got %d", desc, tc.err, err))
 		})
@@ -463,6 +488,104 @@ func TestValidatePolicy(t *testing.T) {
 
 }
 
+func TestValidatePrometheusScrapePolicy(t *testing.T) {
+	var nameID, _ = types.NewIdentifier("my-scrape-policy")
+
+	users := flmocks.NewAuthService(map[string]string{token: email})
+	svc := newService(users)
+
+	cases := map[string]struct {
+		policyData string
+		err        error
+	}{
+		"validate a scrape policy": {
+			policyData: "target: http://localhost:9100/metrics\ninterval: 30s",
+			err:        nil,
+		},
+		"validate a scrape policy with the interval in seconds": {
+			policyData: "target: http://localhost:9100/metrics\ninterval: 30",
+			err:        nil,
+		},
+		"validate a scrape policy without target": {
+			policyData: "interval: 30s",
+			err:        policies.ErrCreatePolicy,
+		},
+		"validate a scrape policy without interval": {
+			policyData: "target: http://localhost:9100/metrics",
+			err:        policies.ErrCreatePolicy,
+		},
+		"validate a scrape policy with a zero interval": {
+			policyData: "target: http://localhost:9100/metrics\ninterval: 0s",
+			err:        policies.ErrCreatePolicy,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			policy := policies.Policy{
+				Name:       nameID,
+				Backend:    "prometheus_scrape",
+				Format:     format,
+				PolicyData: tc.policyData,
+			}
+			_, err := svc.ValidatePolicy(context.Background(), token, policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+		})
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	users := flmocks.NewAuthService(map[string]string{token: email})
+	svc := newService(users)
+
+	existing := createPolicy(t, svc, "existing-policy")
+	freeName, _ := types.NewIdentifier("free-policy")
+
+	cases := map[string]struct {
+		policy policies.Policy
+		token  string
+		issues []policies.PolicyIssue
+		err    error
+	}{
+		"check a valid policy": {
+			policy: policies.Policy{Name: freeName, Backend: "pktvisor", Format: format, PolicyData: policy_data},
+			token:  token,
+		},
+		"check a policy with a name already defined": {
+			policy: policies.Policy{Name: existing.Name, Backend: "pktvisor", Format: format, PolicyData: policy_data},
+			token:  token,
+			issues: []policies.PolicyIssue{{Field: "name", Message: "policy with name already defined"}},
+		},
+		"check a policy reporting every schema issue": {
+			policy: policies.Policy{Backend: "pktvisor", Format: format,
+				PolicyData: "kind: collection\ninput:\n  input_type: pcap\nhandlers:\n  modules:\n    default_dns:\n      config: {}"},
+			token: token,
+			issues: []policies.PolicyIssue{
+				{Field: "policy.input.tap", Message: "a tap or a tap_selector is required"},
+				{Field: "policy.handlers.modules.default_dns.type", Message: "is required"},
+			},
+		},
+		"check a policy with a unsupported backend": {
+			policy: policies.Policy{Backend: "unknown", Format: format, PolicyData: policy_data},
+			token:  token,
+			issues: []policies.PolicyIssue{{Field: "backend", Message: "unsupported backend: 'unknown'"}},
+		},
+		"check a policy with a invalid token": {
+			policy: policies.Policy{Backend: "pktvisor", Format: format, PolicyData: policy_data},
+			token:  invalidToken,
+			err:    policies.ErrUnauthorizedAccess,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			issues, err := svc.CheckPolicy(context.Background(), tc.token, tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+			assert.Equal(t, tc.issues, issues, fmt.Sprintf("%s: unexpected issues", desc))
+		})
+	}
+}
+
 func TestCreatePolicy(t *testing.T) {
 	users := flmocks.NewAuthService(map[string]string{token: email})
 	svc := newService(users)
@@ -791,6 +914,59 @@ func TestValidateDataset(t *testing.T) {
 	}
 }
 
+func TestDatasetSignalType(t *testing.T) {
+	users := flmocks.NewAuthService(map[string]string{token: email})
+	promSinkID := "f5b2d342-211d-a9ab-1233-63199a3fc16f"
+	otlpSinkID := "03679425-aa69-4574-bf62-e0fe71b80939"
+	sinkClient := sinkmocks.NewClientWithSignals(map[string][]string{
+		promSinkID: {"metrics"},
+		otlpSinkID: {"metrics", "logs", "traces"},
+	})
+	svc := policies.New(zap.NewNop(), users, plmocks.NewPoliciesRepository(), flmocks.NewClient(), sinkClient)
+
+	policy := createPolicy(t, svc, "policy")
+	cases := map[string]struct {
+		datasetType string
+		sinkIDs     []string
+		err         error
+	}{
+		"metrics dataset to a metrics only sink": {
+			datasetType: policies.DatasetTypeMetrics,
+			sinkIDs:     []string{promSinkID},
+		},
+		"dataset without type to a metrics only sink": {
+			sinkIDs: []string{promSinkID},
+		},
+		"traces dataset to an otlp sink": {
+			datasetType: policies.DatasetTypeTraces,
+			sinkIDs:     []string{otlpSinkID},
+		},
+		"traces dataset to a metrics only sink": {
+			datasetType: policies.DatasetTypeTraces,
+			sinkIDs:     []string{otlpSinkID, promSinkID},
+			err:         policies.ErrIncompatibleSink,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			nameID, err := types.NewIdentifier(strings.ReplaceAll(desc, " ", "-"))
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			dataset := policies.Dataset{
+				Name:         nameID,
+				AgentGroupID: "8fd6d12d-6a26-5d85-dc35-f9ba8f4d93db",
+				PolicyID:     policy.ID,
+				SinkIDs:      &tc.sinkIDs,
+				Type:         tc.datasetType,
+			}
+			_, err = svc.ValidateDataset(context.Background(), token, dataset)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+			_, err = svc.AddDataset(context.Background(), token, dataset)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+		})
+	}
+}
+
 func TestRetrieveDatasetByID(t *testing.T) {
 	users := flmocks.NewAuthService(map[string]string{token: email})
 	svc := newService(users)
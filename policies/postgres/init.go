@@ -105,6 +105,20 @@ func migrateDB(db *sqlx.DB) error {
 					format TEXT NOT NULL DEFAULT ''`,
 				},
 			},
+			{
+				Id: "policies_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS datasets ADD COLUMN IF NOT EXISTS
+					signal_type VARCHAR(32) NOT NULL DEFAULT ''`,
+				},
+			},
+			{
+				Id: "policies_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS agent_policies ADD COLUMN IF NOT EXISTS
+					depends_on TEXT[] NOT NULL DEFAULT '{}'`,
+				},
+			},
 		},
 	}
 
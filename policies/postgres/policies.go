@@ -70,6 +70,44 @@ func (r policiesRepository) RetrieveDatasetsByGroupID(ctx context.Context, group
 	return items, nil
 }
 
+func (r policiesRepository) RetrieveDatasetsBySinkID(ctx context.Context, sinkID string, ownerID string) ([]policies.Dataset, error) {
+	q := `SELECT id, agent_group_id, sink_ids, agent_policy_id
+			FROM datasets
+			WHERE valid = TRUE AND :sink_id = ANY(sink_ids) AND mf_owner_id = :mf_owner_id`
+
+	if sinkID == "" || ownerID == "" {
+		return nil, errors.ErrMalformedEntity
+	}
+
+	params := map[string]interface{}{
+		"sink_id":     sinkID,
+		"mf_owner_id": ownerID,
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && pqErr.Code.Name() == db.ErrInvalid {
+			return nil, errors.Wrap(policies.ErrMalformedEntity, err)
+		}
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := make([]policies.Dataset, 0)
+	for rows.Next() {
+		dbth := dbDataset{MFOwnerID: ownerID}
+		if err := rows.StructScan(&dbth); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+
+		th := toDataset(dbth)
+		items = append(items, policies.Dataset{ID: th.ID, PolicyID: th.PolicyID, SinkIDs: th.SinkIDs, AgentGroupID: th.AgentGroupID})
+	}
+
+	return items, nil
+}
+
 func (r policiesRepository) DeletePolicy(ctx context.Context, ownerID string, policyID string) error {
 	if ownerID == "" || policyID == "" {
 		return policies.ErrMalformedEntity
@@ -89,7 +127,7 @@ func (r policiesRepository) DeletePolicy(ctx context.Context, ownerID string, po
 }
 
 func (r policiesRepository) UpdatePolicy(ctx context.Context, owner string, plcy policies.Policy) error {
-	q := `UPDATE agent_policies SET name = :name, description = :description, orb_tags = :orb_tags, policy = :policy, version = :version, ts_last_modified = CURRENT_TIMESTAMP, policy_data = :policy_data WHERE mf_owner_id = :mf_owner_id AND id = :id;`
+	q := `UPDATE agent_policies SET name = :name, description = :description, orb_tags = :orb_tags, policy = :policy, version = :version, ts_last_modified = CURRENT_TIMESTAMP, policy_data = :policy_data, depends_on = :depends_on WHERE mf_owner_id = :mf_owner_id AND id = :id;`
 	plcyDB, err := toDBPolicy(plcy)
 	if err != nil {
 		return errors.Wrap(policies.ErrUpdateEntity, err)
@@ -185,7 +223,7 @@ func (r policiesRepository) RetrieveAll(ctx context.Context, owner string, pm po
 func (r policiesRepository) RetrievePoliciesByGroupID(ctx context.Context, groupIDs []string, ownerID string) ([]policies.PolicyInDataset, error) {
 
 	q := `SELECT agent_policies.id AS id, datasets.id AS dataset_id, agent_policies.name AS name, 
-             agent_group_id, agent_policies.mf_owner_id, orb_tags, backend, version, policy, format, agent_policies.ts_created
+             agent_group_id, agent_policies.mf_owner_id, orb_tags, backend, version, policy, format, depends_on, agent_policies.ts_created
 			FROM agent_policies, datasets
 			WHERE agent_policies.id = datasets.agent_policy_id AND agent_policies.mf_owner_id = datasets.mf_owner_id AND valid = TRUE AND
 				agent_group_id IN (?) AND agent_policies.mf_owner_id = ?`
@@ -223,7 +261,7 @@ func (r policiesRepository) RetrievePoliciesByGroupID(ctx context.Context, group
 }
 
 func (r policiesRepository) RetrievePolicyByID(ctx context.Context, policyID string, ownerID string) (policies.Policy, error) {
-	q := `SELECT id, name, description, mf_owner_id, orb_tags, backend, version, policy, ts_created, schema_version, ts_last_modified, policy_data, format 
+	q := `SELECT id, name, description, mf_owner_id, orb_tags, backend, version, policy, ts_created, schema_version, ts_last_modified, policy_data, format, depends_on 
 			FROM agent_policies WHERE id = $1 AND mf_owner_id = $2`
 
 	if policyID == "" || ownerID == "" {
@@ -240,6 +278,43 @@ func (r policiesRepository) RetrievePolicyByID(ctx context.Context, policyID str
 	return toPolicy(dbp), nil
 }
 
+func (r policiesRepository) RetrievePoliciesByIDs(ctx context.Context, policyIDs []string, ownerID string) ([]policies.Policy, error) {
+	q := `SELECT id, name, description, mf_owner_id, orb_tags, backend, version, policy, ts_created, schema_version, ts_last_modified, policy_data, format, depends_on
+			FROM agent_policies WHERE id IN (?) AND mf_owner_id = ?`
+
+	if len(policyIDs) == 0 || ownerID == "" {
+		return nil, errors.ErrMalformedEntity
+	}
+
+	query, args, err := sqlx.In(q, policyIDs, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = r.db.Rebind(query)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && pqErr.Code.Name() == db.ErrInvalid {
+			return nil, errors.Wrap(policies.ErrMalformedEntity, err)
+		}
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := make([]policies.Policy, 0)
+	for rows.Next() {
+		var dbp dbPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		items = append(items, toPolicy(dbp))
+	}
+
+	return items, nil
+}
+
 func (r policiesRepository) UpdateDataset(ctx context.Context, ownerID string, ds policies.Dataset) error {
 	q := `UPDATE datasets SET tags = :tags, sink_ids = :sink_ids, name = :name WHERE mf_owner_id = :mf_owner_id AND id = :id;`
 
@@ -309,8 +384,8 @@ func (r policiesRepository) DeleteDataset(ctx context.Context, ownerID string, d
 
 func (r policiesRepository) SaveDataset(ctx context.Context, dataset policies.Dataset) (string, error) {
 
-	q := `INSERT INTO datasets (name, mf_owner_id, metadata, valid, agent_group_id, agent_policy_id, sink_ids, tags)         
-			  VALUES (:name, :mf_owner_id, :metadata, :valid, :agent_group_id, :agent_policy_id, :sink_ids_str, :tags) RETURNING id`
+	q := `INSERT INTO datasets (name, mf_owner_id, metadata, valid, agent_group_id, agent_policy_id, sink_ids, tags, signal_type)         
+			  VALUES (:name, :mf_owner_id, :metadata, :valid, :agent_group_id, :agent_policy_id, :sink_ids_str, :tags, :signal_type) RETURNING id`
 
 	if !dataset.Name.IsValid() || dataset.MFOwnerID == "" {
 		return "", errors.ErrMalformedEntity
@@ -400,8 +475,8 @@ func (r policiesRepository) InactivateDatasetByPolicyID(ctx context.Context, pol
 
 func (r policiesRepository) SavePolicy(ctx context.Context, policy policies.Policy) (string, error) {
 
-	q := `INSERT INTO agent_policies (name, mf_owner_id, backend, schema_version, policy, orb_tags, description, policy_data, format)         
-			  VALUES (:name, :mf_owner_id, :backend, :schema_version, :policy, :orb_tags, :description, :policy_data, :format) RETURNING id`
+	q := `INSERT INTO agent_policies (name, mf_owner_id, backend, schema_version, policy, orb_tags, description, policy_data, format, depends_on)         
+			  VALUES (:name, :mf_owner_id, :backend, :schema_version, :policy, :orb_tags, :description, :policy_data, :format, :depends_on) RETURNING id`
 
 	if !policy.Name.IsValid() || policy.MFOwnerID == "" {
 		return "", errors.ErrMalformedEntity
@@ -438,7 +513,7 @@ func (r policiesRepository) SavePolicy(ctx context.Context, policy policies.Poli
 
 func (r policiesRepository) RetrieveDatasetsByPolicyID(ctx context.Context, policyID string, ownerID string) ([]policies.Dataset, error) {
 
-	q := `SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, ts_created 
+	q := `SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, signal_type, ts_created 
 			FROM datasets
 			WHERE agent_policy_id = ? AND mf_owner_id = ?`
 
@@ -474,7 +549,7 @@ func (r policiesRepository) RetrieveDatasetsByPolicyID(ctx context.Context, poli
 }
 
 func (r policiesRepository) RetrieveDatasetByID(ctx context.Context, datasetID string, ownerID string) (policies.Dataset, error) {
-	q := `SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, ts_created FROM datasets WHERE id = $1 AND mf_owner_id = $2`
+	q := `SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, signal_type, ts_created FROM datasets WHERE id = $1 AND mf_owner_id = $2`
 
 	if datasetID == "" || ownerID == "" {
 		return policies.Dataset{}, errors.ErrMalformedEntity
@@ -496,7 +571,7 @@ func (r policiesRepository) RetrieveAllDatasetsByOwner(ctx context.Context, owne
 	orderQuery := getOrderQuery(pm.Order)
 	dirQuery := getDirQuery(pm.Dir)
 
-	q := fmt.Sprintf(`SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, tags, ts_created 
+	q := fmt.Sprintf(`SELECT id, name, mf_owner_id, valid, agent_group_id, agent_policy_id, sink_ids, metadata, signal_type, tags, ts_created 
 			FROM datasets
 			WHERE mf_owner_id = :mf_owner_id %s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, nameQuery, orderQuery, dirQuery)
 
@@ -706,6 +781,7 @@ type dbPolicy struct {
 	Policy        db.Metadata      `db:"policy"`
 	PolicyData    string           `db:"policy_data"`
 	Format        string           `db:"format"`
+	DependsOn     pq.StringArray   `db:"depends_on"`
 	Version       int32            `db:"version"`
 	Created       time.Time        `db:"ts_created"`
 	DataSetID     string           `db:"dataset_id"`
@@ -740,6 +816,8 @@ func toDBPolicy(policy policies.Policy) (dbPolicy, error) {
 		Policy:        db.Metadata(policy.Policy),
 		PolicyData:    policy.PolicyData,
 		Format:        policy.Format,
+		// a nil array would be stored as NULL
+		DependsOn: append(pq.StringArray{}, policy.DependsOn...),
 	}, nil
 
 }
@@ -756,6 +834,7 @@ type dbDataset struct {
 	Tags         db.Tags          `db:"tags"`
 	SinkIDs      pq.StringArray   `db:"sink_ids"`
 	SinksIDsStr  interface{}      `db:"sink_ids_str"`
+	SignalType   string           `db:"signal_type"`
 }
 
 func toDBDataset(dataset policies.Dataset) (dbDataset, error) {
@@ -773,6 +852,7 @@ func toDBDataset(dataset policies.Dataset) (dbDataset, error) {
 		Metadata:    db.Metadata(dataset.Metadata),
 		Tags:        db.Tags(dataset.Tags),
 		SinksIDsStr: pq.Array(dataset.SinkIDs),
+		SignalType:  dataset.Type,
 	}
 
 	d.Valid = true
@@ -813,6 +893,7 @@ func toPolicy(dba dbPolicy) policies.Policy {
 		LastModified:  dba.LastModified,
 		PolicyData:    dba.PolicyData,
 		Format:        dba.Format,
+		DependsOn:     dba.DependsOn,
 	}
 
 	return policy
@@ -831,6 +912,7 @@ func toDataset(dba dbDataset) policies.Dataset {
 		Metadata:     types.Metadata(dba.Metadata),
 		Created:      dba.TsCreated,
 		Tags:         types.Tags(dba.Tags),
+		Type:         dba.SignalType,
 	}
 
 	return dataset
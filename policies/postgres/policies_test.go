@@ -144,6 +144,80 @@ func TestAgentPolicyDataRetrieve(t *testing.T) {
 	}
 }
 
+func TestPoliciesRetrieveByIDs(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPoliciesRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	missingID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var ids []string
+	for _, name := range []string{"mypolicy-ids-1", "mypolicy-ids-2"} {
+		nameID, err := types.NewIdentifier(name)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		id, err := repo.SavePolicy(context.Background(), policies.Policy{
+			Name:      nameID,
+			MFOwnerID: oID.String(),
+			Backend:   "pktvisor",
+			Policy:    types.Metadata{"pkey1": "pvalue1"},
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		ids = append(ids, id)
+	}
+
+	cases := map[string]struct {
+		policyIDs []string
+		ownerID   string
+		results   int
+		err       error
+	}{
+		"retrieve existing policies by IDs": {
+			policyIDs: ids,
+			ownerID:   oID.String(),
+			results:   2,
+			err:       nil,
+		},
+		"retrieve policies by IDs omitting the missing ones": {
+			policyIDs: []string{ids[0], missingID.String()},
+			ownerID:   oID.String(),
+			results:   1,
+			err:       nil,
+		},
+		"retrieve policies by IDs of another owner": {
+			policyIDs: ids,
+			ownerID:   otherID.String(),
+			results:   0,
+			err:       nil,
+		},
+		"retrieve policies by IDs with empty owner": {
+			policyIDs: ids,
+			ownerID:   "",
+			err:       errors.ErrMalformedEntity,
+		},
+		"retrieve policies with empty IDs": {
+			policyIDs: []string{},
+			ownerID:   oID.String(),
+			err:       errors.ErrMalformedEntity,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			plist, err := repo.RetrievePoliciesByIDs(context.Background(), tc.policyIDs, tc.ownerID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+			assert.Equal(t, tc.results, len(plist), fmt.Sprintf("%s: expected %d got %d\n", desc, tc.results, len(plist)))
+			for _, p := range plist {
+				assert.Equal(t, "pktvisor", p.Backend, fmt.Sprintf("%s: expected backend %s got %s\n", desc, "pktvisor", p.Backend))
+				assert.Equal(t, types.Metadata{"pkey1": "pvalue1"}, p.Policy, fmt.Sprintf("%s: expected %s got %s\n", desc, types.Metadata{"pkey1": "pvalue1"}, p.Policy))
+			}
+		})
+	}
+}
+
 func TestMultiPolicyRetrieval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	repo := postgres.NewPoliciesRepository(dbMiddleware, logger)
@@ -40,6 +40,10 @@ func (e eventStore) ListDatasetsByGroupIDInternal(ctx context.Context, groupIDs
 	return e.svc.ListDatasetsByGroupIDInternal(ctx, groupIDs, ownerID)
 }
 
+func (e eventStore) ListDatasetsBySinkIDInternal(ctx context.Context, sinkID string, ownerID string) ([]policies.Dataset, error) {
+	return e.svc.ListDatasetsBySinkIDInternal(ctx, sinkID, ownerID)
+}
+
 func (e eventStore) ViewDatasetByIDInternal(ctx context.Context, ownerID string, datasetID string) (policies.Dataset, error) {
 	return e.svc.ViewDatasetByIDInternal(ctx, ownerID, datasetID)
 }
@@ -235,6 +239,10 @@ func (e eventStore) ViewPolicyByIDInternal(ctx context.Context, policyID string,
 	return e.svc.ViewPolicyByIDInternal(ctx, policyID, ownerID)
 }
 
+func (e eventStore) ListPoliciesByIDsInternal(ctx context.Context, policyIDs []string, ownerID string) ([]policies.Policy, error) {
+	return e.svc.ListPoliciesByIDsInternal(ctx, policyIDs, ownerID)
+}
+
 func (e eventStore) ListPoliciesByGroupIDInternal(ctx context.Context, groupIDs []string, ownerID string) ([]policies.PolicyInDataset, error) {
 	return e.svc.ListPoliciesByGroupIDInternal(ctx, groupIDs, ownerID)
 }
@@ -272,6 +280,10 @@ func (e eventStore) InactivateDatasetByGroupID(ctx context.Context, groupID stri
 	return e.svc.InactivateDatasetByGroupID(ctx, groupID, ownerID)
 }
 
+func (e eventStore) CheckPolicy(ctx context.Context, token string, p policies.Policy) ([]policies.PolicyIssue, error) {
+	return e.svc.CheckPolicy(ctx, token, p)
+}
+
 func (e eventStore) ValidatePolicy(ctx context.Context, token string, p policies.Policy) (policies.Policy, error) {
 	return e.svc.ValidatePolicy(ctx, token, p)
 }
@@ -0,0 +1,92 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/orb-community/orb/sinker/redis/producer"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const cacheReadTimeout = 5 * time.Second
+
+var sinksDesc = stdprometheus.NewDesc(
+	"orb_sinker_sinks",
+	"Number of sinks in the sinker cache by state",
+	[]string{"state"}, nil,
+)
+
+// cacheState returns the state of a cached sink, sinks without activity within the default expiration are idle
+func cacheState(key producer.SinkerKey, now time.Time) string {
+	if now.Sub(key.LastActivity) > producer.DefaultExpiration {
+		return "idle"
+	}
+	return "active"
+}
+
+type cacheCollector struct {
+	logger *zap.Logger
+	keys   producer.SinkerKeyService
+}
+
+// NewCacheCollector returns a prometheus collector counting the sinks in the sinker cache by state on each scrape
+func NewCacheCollector(logger *zap.Logger, keys producer.SinkerKeyService) stdprometheus.Collector {
+	return &cacheCollector{logger: logger, keys: keys}
+}
+
+func (c *cacheCollector) Describe(ch chan<- *stdprometheus.Desc) {
+	ch <- sinksDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- stdprometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheReadTimeout)
+	defer cancel()
+	entries, err := c.keys.GetAllSinkerKeys(ctx)
+	if err != nil {
+		c.logger.Error("failed to read sinker cache for metrics", zap.Error(err))
+		return
+	}
+	counts := map[string]int{"active": 0, "idle": 0}
+	now := time.Now()
+	for _, entry := range entries {
+		counts[cacheState(entry, now)]++
+	}
+	for state, count := range counts {
+		ch <- stdprometheus.MustNewConstMetric(sinksDesc, stdprometheus.GaugeValue, float64(count), state)
+	}
+}
+
+type cacheEntryRes struct {
+	producer.SinkerKey
+	State string `json:"state"`
+}
+
+// CacheHandler dumps the sinker cache entries as JSON, entries only hold sink activity so there is no secret to redact
+func CacheHandler(logger *zap.Logger, keys producer.SinkerKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), cacheReadTimeout)
+		defer cancel()
+		entries, err := keys.GetAllSinkerKeys(ctx)
+		if err != nil {
+			logger.Error("failed to read sinker cache", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		now := time.Now()
+		res := make([]cacheEntryRes, 0, len(entries))
+		for _, entry := range entries {
+			res = append(res, cacheEntryRes{SinkerKey: entry, State: cacheState(entry, now)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"sinks": res}); err != nil {
+			logger.Error("failed to encode sinker cache", zap.Error(err))
+		}
+	}
+}
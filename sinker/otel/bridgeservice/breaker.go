@@ -0,0 +1,160 @@
+package bridgeservice
+
+import (
+	"sync"
+	"time"
+)
+
+// SinkStateCircuitOpen is the sink activity state published while writes to the sink are suspended
+const SinkStateCircuitOpen = "circuit_open"
+
+const (
+	// DefaultBreakerMaxErrors is the number of consecutive write errors that opens the circuit of a sink
+	DefaultBreakerMaxErrors = 5
+	// DefaultBreakerCooldown is how long writes to a sink are skipped before a single batch is retried
+	DefaultBreakerCooldown = time.Minute
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type sinkBreaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// remoteFailed is set while the collector of the sink reports its exports failing, a write reaching the
+	// fan-out does not close the circuit then
+	remoteFailed bool
+}
+
+// circuitBreakers keeps one circuit breaker per sink
+type circuitBreakers struct {
+	mu          sync.Mutex
+	maxFailures int
+	cooldown    time.Duration
+	sinks       map[string]*sinkBreaker
+	now         func() time.Time
+}
+
+func newCircuitBreakers(maxFailures int, cooldown time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+		sinks:       make(map[string]*sinkBreaker),
+		now:         time.Now,
+	}
+}
+
+// allow reports whether a write to the sink should be attempted, once the cooldown of an open
+// circuit elapses a single batch is let through to probe the sink
+func (c *circuitBreakers) allow(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.sinks[key]
+	if !ok {
+		return true
+	}
+	switch b.state {
+	case breakerOpen:
+		if c.now().Sub(b.openedAt) < c.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// a probe batch is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// release gives back the probe of a half-open circuit which was not written, as the write was dropped or skipped,
+// so the next batch probes the sink instead of the circuit staying half-open
+func (c *circuitBreakers) release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.sinks[key]; ok && b.state == breakerHalfOpen {
+		// the cooldown already elapsed since openedAt, the next allow lets a batch through
+		b.state = breakerOpen
+	}
+}
+
+// isClosed reports whether writes to the sink are flowing normally
+func (c *circuitBreakers) isClosed(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.sinks[key]
+	return !ok || b.state == breakerClosed
+}
+
+// record registers the result of a write to the sink, it returns whether the circuit was opened
+// or closed by this result
+func (c *circuitBreakers) record(key string, err error) (opened bool, closed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.sinks[key]
+	if err == nil {
+		if !ok {
+			return false, false
+		}
+		if b.remoteFailed {
+			// the probe reached the fan-out, the circuit closes once the collector reports the sink active
+			b.state = breakerOpen
+			b.openedAt = c.now()
+			return false, false
+		}
+		delete(c.sinks, key)
+		return false, b.state != breakerClosed
+	}
+	if !ok {
+		b = &sinkBreaker{}
+		c.sinks[key] = b
+	}
+	switch b.state {
+	case breakerHalfOpen:
+		b.state = breakerOpen
+		b.openedAt = c.now()
+		return false, false
+	case breakerOpen:
+		return false, false
+	}
+	b.failures++
+	if b.failures >= c.maxFailures {
+		b.state = breakerOpen
+		b.openedAt = c.now()
+		return true, false
+	}
+	return false, false
+}
+
+// remoteState registers the state of the sink reported by its collector, a failing sink opens its circuit until
+// the collector reports it healthy again. It returns whether the circuit was opened or closed by this state
+func (c *circuitBreakers) remoteState(key string, failed bool) (opened bool, closed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.sinks[key]
+	if !failed {
+		if !ok {
+			return false, false
+		}
+		delete(c.sinks, key)
+		return false, b.state != breakerClosed
+	}
+	if !ok {
+		b = &sinkBreaker{}
+		c.sinks[key] = b
+	}
+	b.remoteFailed = true
+	if b.state != breakerClosed {
+		return false, false
+	}
+	b.state = breakerOpen
+	b.openedAt = c.now()
+	return true, false
+}
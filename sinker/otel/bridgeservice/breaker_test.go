@@ -0,0 +1,132 @@
+package bridgeservice
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/sinks"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	now := time.Now()
+	breakers := newCircuitBreakers(3, time.Minute)
+	breakers.now = func() time.Time { return now }
+	writeErr := errors.New("remote unavailable")
+	key := "owner-sink"
+
+	for i := 0; i < 2; i++ {
+		opened, _ := breakers.record(key, writeErr)
+		assert.False(t, opened, "circuit should stay closed before reaching the max errors")
+		assert.True(t, breakers.allow(key), "writes should be allowed while the circuit is closed")
+	}
+
+	opened, _ := breakers.record(key, writeErr)
+	assert.True(t, opened, "circuit should open after the max consecutive errors")
+	assert.False(t, breakers.isClosed(key))
+	assert.False(t, breakers.allow(key), "writes should be skipped during the cooldown")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breakers.allow(key), "a single batch should be retried after the cooldown")
+	assert.False(t, breakers.allow(key), "only one batch should be retried while half-open")
+
+	opened, closed := breakers.record(key, writeErr)
+	assert.False(t, opened, "a failed retry should not notify the circuit as opened again")
+	assert.False(t, closed)
+	assert.False(t, breakers.allow(key), "a failed retry should restart the cooldown")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breakers.allow(key))
+	_, closed = breakers.record(key, nil)
+	assert.True(t, closed, "a successful retry should close the circuit")
+	assert.True(t, breakers.isClosed(key))
+	assert.True(t, breakers.allow(key))
+
+	_, closed = breakers.record(key, nil)
+	assert.False(t, closed, "a success on a closed circuit is not a transition")
+}
+
+func TestCircuitBreakersRelease(t *testing.T) {
+	now := time.Now()
+	breakers := newCircuitBreakers(1, time.Minute)
+	breakers.now = func() time.Time { return now }
+	key := "owner-sink"
+
+	breakers.release(key)
+	assert.True(t, breakers.allow(key), "releasing a sink without circuit should be a no-op")
+
+	breakers.record(key, errors.New("remote unavailable"))
+	now = now.Add(time.Minute)
+	assert.True(t, breakers.allow(key), "a single batch should be retried after the cooldown")
+	assert.False(t, breakers.allow(key))
+
+	breakers.release(key)
+	assert.True(t, breakers.allow(key), "a released probe should let the next batch probe the sink")
+	assert.False(t, breakers.isClosed(key), "a released probe should not close the circuit")
+}
+
+func TestCircuitBreakersRemoteState(t *testing.T) {
+	now := time.Now()
+	breakers := newCircuitBreakers(5, time.Minute)
+	breakers.now = func() time.Time { return now }
+	key := "owner-sink"
+
+	_, closed := breakers.remoteState(key, false)
+	assert.False(t, closed, "an active sink without circuit is not a transition")
+
+	opened, _ := breakers.remoteState(key, true)
+	assert.True(t, opened, "a sink reported in error should open its circuit")
+	assert.False(t, breakers.allow(key), "writes should be skipped during the cooldown")
+	opened, _ = breakers.remoteState(key, true)
+	assert.False(t, opened, "an error on an open circuit is not a transition")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breakers.allow(key), "a single batch should probe the sink after the cooldown")
+	_, closed = breakers.record(key, nil)
+	assert.False(t, closed, "a write reaching the fan-out should not close the circuit of a sink in error")
+	assert.False(t, breakers.allow(key), "the cooldown should restart after the probe")
+
+	_, closed = breakers.remoteState(key, false)
+	assert.True(t, closed, "a sink reported active should close its circuit")
+	assert.True(t, breakers.isClosed(key))
+	assert.True(t, breakers.allow(key))
+}
+
+func TestReportSinkState(t *testing.T) {
+	bs := SinkerOtelBridgeService{
+		logger:        zap.NewNop(),
+		inMemoryCache: *cache.New(time.Minute, time.Minute),
+		breakers:      newCircuitBreakers(5, time.Minute),
+	}
+
+	bs.ReportSinkState("owner", "sink", sinks.Warning)
+	assert.True(t, bs.AllowSinkWrite("owner", "sink"), "a warning should not open the circuit")
+	bs.ReportSinkState("owner", "sink", sinks.Error)
+	assert.False(t, bs.AllowSinkWrite("owner", "sink"), "an error should open the circuit")
+	bs.ReportSinkState("owner", "sink", sinks.Active)
+	assert.True(t, bs.AllowSinkWrite("owner", "sink"), "an active sink should close the circuit")
+}
+
+func TestSubmitSinkWriteReleasesDroppedProbe(t *testing.T) {
+	now := time.Now()
+	bs := SinkerOtelBridgeService{
+		logger:      zap.NewNop(),
+		breakers:    newCircuitBreakers(1, time.Minute),
+		writeLimits: newWriteLimiters(1, 0),
+	}
+	bs.breakers.now = func() time.Time { return now }
+	bs.breakers.record("owner-sink", errors.New("remote unavailable"))
+	now = now.Add(time.Minute)
+
+	release := make(chan struct{})
+	defer close(release)
+	require.True(t, bs.SubmitSinkWrite("owner", "sink", func() { <-release }))
+
+	require.True(t, bs.AllowSinkWrite("owner", "sink"))
+	assert.False(t, bs.SubmitSinkWrite("owner", "sink", func() {}), "write above the limit should be dropped")
+	assert.True(t, bs.AllowSinkWrite("owner", "sink"), "a dropped probe should not keep the circuit half-open")
+}
@@ -2,21 +2,31 @@ package bridgeservice
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/orb-community/orb/sinker/redis/producer"
+	"github.com/orb-community/orb/sinks"
 	sinkspb "github.com/orb-community/orb/sinks/pb"
 	"sort"
 	"time"
 
 	"github.com/go-kit/kit/metrics"
 	fleetpb "github.com/orb-community/orb/fleet/pb"
+	"github.com/orb-community/orb/pkg/config"
+	"github.com/orb-community/orb/pkg/types"
 	policiespb "github.com/orb-community/orb/policies/pb"
+	"github.com/orb-community/orb/sinks/backend"
+	"github.com/orb-community/orb/sinks/backend/gcm"
+	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
+	"github.com/orb-community/orb/sinks/backend/prometheus"
+	"github.com/orb-community/orb/sinks/backend/spool"
 	"github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
 )
 
 type BridgeService interface {
 	ExtractAgent(ctx context.Context, channelID string) (*fleetpb.AgentInfoRes, error)
+	ExtractAgents(ctx context.Context, channelIDs []string) (map[string]*fleetpb.AgentInfoRes, error)
 	GetPolicyName(ctx context.Context, policyId, ownerId string) (*policiespb.PolicyRes, error)
 	GetDataSetsFromAgentGroups(ctx context.Context, mfOwnerId string, agentGroupIds []string) (map[string]string, error)
 	NotifyActiveSink(ctx context.Context, mfOwnerId, sinkId, state, message string) error
@@ -29,7 +39,11 @@ func NewBridgeService(logger *zap.Logger,
 	sinkActivity producer.SinkActivityProducer,
 	policiesClient policiespb.PolicyServiceClient,
 	sinksClient sinkspb.SinkServiceClient,
-	fleetClient fleetpb.FleetServiceClient, messageInputCounter metrics.Counter) SinkerOtelBridgeService {
+	fleetClient fleetpb.FleetServiceClient, messageInputCounter metrics.Counter, cacheCounter metrics.Counter,
+	writeCfg config.SinkWriteConfig, droppedWritesCounter metrics.Counter) SinkerOtelBridgeService {
+	otlphttpexporter.Register()
+	prometheus.Register()
+	gcm.Register()
 	return SinkerOtelBridgeService{
 		defaultCacheExpiration: defaultCacheExpiration,
 		inMemoryCache:          *cache.New(defaultCacheExpiration, defaultCacheExpiration*2),
@@ -39,6 +53,10 @@ func NewBridgeService(logger *zap.Logger,
 		fleetClient:            fleetClient,
 		sinksClient:            sinksClient,
 		messageInputCounter:    messageInputCounter,
+		cacheCounter:           cacheCounter,
+		breakers:               newCircuitBreakers(DefaultBreakerMaxErrors, DefaultBreakerCooldown),
+		writeLimits:            newWriteLimiters(writeCfg.MaxInFlight, writeCfg.MaxQueued),
+		droppedWritesCounter:   droppedWritesCounter,
 	}
 }
 
@@ -51,6 +69,12 @@ type SinkerOtelBridgeService struct {
 	fleetClient            fleetpb.FleetServiceClient
 	sinksClient            sinkspb.SinkServiceClient
 	messageInputCounter    metrics.Counter
+	// cacheCounter counts the cache lookups by result, nil disables it
+	cacheCounter metrics.Counter
+	breakers     *circuitBreakers
+	writeLimits  *writeLimiters
+	// droppedWritesCounter counts the writes dropped by sink as its queue was full, nil disables it
+	droppedWritesCounter metrics.Counter
 }
 
 // IncrementMessageCounter add to our metrics the number of messages received
@@ -67,6 +91,10 @@ func (bs *SinkerOtelBridgeService) IncrementMessageCounter(publisher, subtopic,
 
 // NotifyActiveSink notify the sinker that a sink is active
 func (bs *SinkerOtelBridgeService) NotifyActiveSink(ctx context.Context, mfOwnerId, sinkId, size string) error {
+	if !bs.breakers.isClosed(fmt.Sprintf("%s-%s", mfOwnerId, sinkId)) {
+		// the sink is notified as active again when its circuit closes
+		return nil
+	}
 	cacheKey := fmt.Sprintf("active_sink-%s-%s", mfOwnerId, sinkId)
 	_, found := bs.inMemoryCache.Get(cacheKey)
 	if !found {
@@ -92,6 +120,84 @@ func (bs *SinkerOtelBridgeService) NotifyActiveSink(ctx context.Context, mfOwner
 	return nil
 }
 
+// AllowSinkWrite reports whether data should be written to the sink, false while its circuit is open
+func (bs *SinkerOtelBridgeService) AllowSinkWrite(mfOwnerId, sinkId string) bool {
+	return bs.breakers.allow(fmt.Sprintf("%s-%s", mfOwnerId, sinkId))
+}
+
+// ReleaseSinkWrite gives back a write allowed by AllowSinkWrite which is not submitted to the sink, so a probe of
+// its circuit is not held by a write which never reports
+func (bs *SinkerOtelBridgeService) ReleaseSinkWrite(mfOwnerId, sinkId string) {
+	bs.breakers.release(fmt.Sprintf("%s-%s", mfOwnerId, sinkId))
+}
+
+// ReportSinkWrite records the result of a write to the Kafka fan-out of the sink, opening its circuit after
+// consecutive errors and closing it on the first success, both transitions are notified as sink activity. The
+// result of the exports to the remote end is reported by ReportSinkState
+func (bs *SinkerOtelBridgeService) ReportSinkWrite(ctx context.Context, mfOwnerId, sinkId string, writeErr error) {
+	opened, closed := bs.breakers.record(fmt.Sprintf("%s-%s", mfOwnerId, sinkId), writeErr)
+	cacheKey := fmt.Sprintf("active_sink-%s-%s", mfOwnerId, sinkId)
+	switch {
+	case opened:
+		bs.logger.Warn("opening sink circuit after consecutive write errors", zap.String("sink_id", sinkId),
+			zap.String("owner_id", mfOwnerId), zap.Duration("cooldown", bs.breakers.cooldown), zap.Error(writeErr))
+		event := producer.SinkActivityEvent{
+			OwnerID:   mfOwnerId,
+			SinkID:    sinkId,
+			State:     SinkStateCircuitOpen,
+			Size:      "0",
+			Timestamp: time.Now(),
+		}
+		if err := bs.sinkerActivitySvc.PublishSinkActivity(ctx, event); err != nil {
+			bs.logger.Error("error publishing sink activity", zap.Error(err))
+		}
+	case closed:
+		bs.logger.Info("closing sink circuit", zap.String("sink_id", sinkId), zap.String("owner_id", mfOwnerId))
+		bs.inMemoryCache.Delete(cacheKey)
+		_ = bs.NotifyActiveSink(ctx, mfOwnerId, sinkId, "0")
+	}
+}
+
+// ReportSinkState applies the state of the sink reported from the exports of its collector to the circuit of the
+// sink. An error state opens the circuit, which then only probes the sink once per cooldown, until an active
+// state closes it. The sink already shows the error, so opening the circuit is not notified as sink activity
+func (bs *SinkerOtelBridgeService) ReportSinkState(mfOwnerId, sinkId string, state sinks.State) {
+	var failed bool
+	switch state {
+	case sinks.Error:
+		failed = true
+	case sinks.Active:
+		failed = false
+	default:
+		return
+	}
+	opened, closed := bs.breakers.remoteState(fmt.Sprintf("%s-%s", mfOwnerId, sinkId), failed)
+	switch {
+	case opened:
+		bs.logger.Warn("opening sink circuit as its collector reports export errors", zap.String("sink_id", sinkId),
+			zap.String("owner_id", mfOwnerId), zap.Duration("cooldown", bs.breakers.cooldown))
+	case closed:
+		bs.logger.Info("closing sink circuit as its collector reports the sink active", zap.String("sink_id", sinkId),
+			zap.String("owner_id", mfOwnerId))
+		bs.inMemoryCache.Delete(fmt.Sprintf("active_sink-%s-%s", mfOwnerId, sinkId))
+	}
+}
+
+// SubmitSinkWrite runs the write of the sink to the Kafka fan-out within its concurrency limit, the collector of the
+// sink bounds the writes to its remote end. The writes above the limit are queued and dropped once the queue of the
+// sink is full. It returns false when the write was dropped, which releases it
+func (bs *SinkerOtelBridgeService) SubmitSinkWrite(mfOwnerId, sinkId string, write func()) bool {
+	if bs.writeLimits.submit(fmt.Sprintf("%s-%s", mfOwnerId, sinkId), write) {
+		return true
+	}
+	bs.ReleaseSinkWrite(mfOwnerId, sinkId)
+	bs.logger.Warn("sink write queue is full, dropping write", zap.String("sink_id", sinkId), zap.String("owner_id", mfOwnerId))
+	if bs.droppedWritesCounter != nil {
+		bs.droppedWritesCounter.With("owner_id", mfOwnerId, "sink_id", sinkId).Add(1)
+	}
+	return false
+}
+
 // ExtractAgent retrieve agent info from fleet, or cache
 func (bs *SinkerOtelBridgeService) ExtractAgent(ctx context.Context, channelID string) (*fleetpb.AgentInfoRes, error) {
 	cacheKey := fmt.Sprintf("agent-%s", channelID)
@@ -107,6 +213,38 @@ func (bs *SinkerOtelBridgeService) ExtractAgent(ctx context.Context, channelID s
 	return value.(*fleetpb.AgentInfoRes), nil
 }
 
+// ExtractAgents retrieve the agents info of a batch of channels from cache, fetching the missing ones
+// from fleet in a single call, unknown channels are omitted from the result
+func (bs *SinkerOtelBridgeService) ExtractAgents(ctx context.Context, channelIDs []string) (map[string]*fleetpb.AgentInfoRes, error) {
+	agents := make(map[string]*fleetpb.AgentInfoRes, len(channelIDs))
+	seen := make(map[string]bool, len(channelIDs))
+	var missing []string
+	for _, channelID := range channelIDs {
+		if seen[channelID] {
+			continue
+		}
+		seen[channelID] = true
+		value, found := bs.inMemoryCache.Get(fmt.Sprintf("agent-%s", channelID))
+		if !found {
+			missing = append(missing, channelID)
+			continue
+		}
+		agents[channelID] = value.(*fleetpb.AgentInfoRes)
+	}
+	if len(missing) == 0 {
+		return agents, nil
+	}
+	res, err := bs.fleetClient.RetrieveAgentInfoByChannelIDs(ctx, &fleetpb.AgentInfoByChannelIDsReq{Channels: missing})
+	if err != nil {
+		return nil, err
+	}
+	for channelID, agentPb := range res.GetAgents() {
+		bs.inMemoryCache.Set(fmt.Sprintf("agent-%s", channelID), agentPb, cache.DefaultExpiration)
+		agents[channelID] = agentPb
+	}
+	return agents, nil
+}
+
 // GetPolicyName retrieve policy info from policies service, or cache.
 func (bs *SinkerOtelBridgeService) GetPolicyName(ctx context.Context, policyId, ownerID string) (*policiespb.PolicyRes, error) {
 	cacheKey := fmt.Sprintf("policy-%s", policyId)
@@ -122,6 +260,86 @@ func (bs *SinkerOtelBridgeService) GetPolicyName(ctx context.Context, policyId,
 	return value.(*policiespb.PolicyRes), nil
 }
 
+// GetSinkBackend retrieve the backend of a sink from sinks service, or cache, nil when the backend is unknown. The
+// backend of a store-and-forward sink is its inner one, the labels are written as the collector exporter accepts them
+func (bs *SinkerOtelBridgeService) GetSinkBackend(ctx context.Context, mfOwnerId, sinkId string) (backend.Backend, error) {
+	cacheKey := fmt.Sprintf("sink_backend-%s-%s", mfOwnerId, sinkId)
+	value, found := bs.inMemoryCache.Get(cacheKey)
+	if !found {
+		sinkRes, err := bs.sinksClient.RetrieveSink(ctx, &sinkspb.SinkByIDReq{
+			SinkID:  sinkId,
+			OwnerID: mfOwnerId,
+		})
+		if err != nil {
+			bs.logger.Info("unable to retrieve the sink backend from sinks", zap.String("sink_id", sinkId))
+			return nil, err
+		}
+		value = sinkRes.Backend
+		if sinkRes.Backend == spool.Name {
+			var config types.Metadata
+			if err := json.Unmarshal(sinkRes.Config, &config); err != nil {
+				return nil, err
+			}
+			name, _, _ := spool.InnerSinkConfig(config)
+			value = name
+		}
+		bs.inMemoryCache.Set(cacheKey, value, cache.DefaultExpiration)
+	}
+	return backend.GetBackend(value.(string)), nil
+}
+
+// GetSinkRelabelRules retrieves the metric relabeling rules of the sink from sinks service, or cache, none when it
+// has no rules. The rules of a store-and-forward sink are the ones of its inner exporter
+func (bs *SinkerOtelBridgeService) GetSinkRelabelRules(ctx context.Context, mfOwnerId, sinkId string) ([]backend.RelabelRule, error) {
+	cacheKey := fmt.Sprintf("sink_relabel-%s-%s", mfOwnerId, sinkId)
+	value, found := bs.inMemoryCache.Get(cacheKey)
+	if !found {
+		sinkRes, err := bs.sinksClient.RetrieveSink(ctx, &sinkspb.SinkByIDReq{
+			SinkID:  sinkId,
+			OwnerID: mfOwnerId,
+		})
+		if err != nil {
+			bs.logger.Info("unable to retrieve the sink relabel rules from sinks", zap.String("sink_id", sinkId))
+			return nil, err
+		}
+		var config types.Metadata
+		if err := json.Unmarshal(sinkRes.Config, &config); err != nil {
+			return nil, err
+		}
+		if sinkRes.Backend == spool.Name {
+			if _, inner, ok := spool.InnerSinkConfig(config); ok {
+				config = inner
+			}
+		}
+		rules, err := backend.ParseRelabelRules(config.GetSubMetadata("exporter"))
+		if err != nil {
+			return nil, err
+		}
+		value = rules
+		bs.inMemoryCache.Set(cacheKey, value, cache.DefaultExpiration)
+	}
+	return value.([]backend.RelabelRule), nil
+}
+
+// GetSinkSignals retrieves the signals accepted by the sink from sinks service, or cache
+func (bs *SinkerOtelBridgeService) GetSinkSignals(ctx context.Context, mfOwnerId, sinkId string) ([]string, error) {
+	cacheKey := fmt.Sprintf("sink_signals-%s-%s", mfOwnerId, sinkId)
+	value, found := bs.inMemoryCache.Get(cacheKey)
+	if !found {
+		sinkRes, err := bs.sinksClient.RetrieveSink(ctx, &sinkspb.SinkByIDReq{
+			SinkID:  sinkId,
+			OwnerID: mfOwnerId,
+		})
+		if err != nil {
+			bs.logger.Info("unable to retrieve the sink signals from sinks", zap.String("sink_id", sinkId))
+			return nil, err
+		}
+		value = sinkRes.GetSignals()
+		bs.inMemoryCache.Set(cacheKey, value, cache.DefaultExpiration)
+	}
+	return value.([]string), nil
+}
+
 // GetSinkIdsFromDatasetIDs retrieve sink_ids from datasets from policies service, or cache
 func (bs *SinkerOtelBridgeService) GetSinkIdsFromDatasetIDs(ctx context.Context, mfOwnerId string, datasetIDs []string) (map[string]string, error) {
 	// Here needs to retrieve datasets
@@ -0,0 +1,116 @@
+package bridgeservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	fleetpb "github.com/orb-community/orb/fleet/pb"
+	policiespb "github.com/orb-community/orb/policies/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// agentGroupDatasetsTTL is kept short as the datasets of a group change along with the policies applied to it
+const agentGroupDatasetsTTL = 30 * time.Second
+
+// AgentGroupsError is returned when the datasets of agent groups could not be retrieved. NotFound is set when
+// the groups do not exist anymore, so there is nothing to retry, otherwise the services could not be reached
+type AgentGroupsError struct {
+	GroupIDs []string
+	NotFound bool
+	Err      error
+}
+
+func (e *AgentGroupsError) Error() string {
+	if e.NotFound {
+		return fmt.Sprintf("agent groups not found %v: %v", e.GroupIDs, e.Err)
+	}
+	return fmt.Sprintf("failed to retrieve the datasets of agent groups %v: %v", e.GroupIDs, e.Err)
+}
+
+func (e *AgentGroupsError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether the same request may succeed later
+func (e *AgentGroupsError) Retryable() bool {
+	return !e.NotFound
+}
+
+// GetDataSetsFromAgentGroups retrieves the datasets of the agent groups from policies service, or cache, keyed
+// by dataset id with the agent group id as value. The groups without any dataset are checked on fleet so
+// the removed ones are reported as a not found AgentGroupsError
+func (bs *SinkerOtelBridgeService) GetDataSetsFromAgentGroups(ctx context.Context, mfOwnerId string, agentGroupIds []string) (map[string]string, error) {
+	datasets := make(map[string]string)
+	if len(agentGroupIds) == 0 {
+		return datasets, nil
+	}
+	groupIDs := uniqueSorted(agentGroupIds)
+	cacheKey := fmt.Sprintf("ag_datasets-%s-%s", mfOwnerId, strings.Join(groupIDs, ","))
+	if value, found := bs.inMemoryCache.Get(cacheKey); found {
+		bs.countCacheLookup("GetDataSetsFromAgentGroups", true)
+		return value.(map[string]string), nil
+	}
+	bs.countCacheLookup("GetDataSetsFromAgentGroups", false)
+
+	res, err := bs.policiesClient.RetrieveDatasetsByGroups(ctx, &policiespb.DatasetsByGroupsReq{
+		GroupIDs: groupIDs,
+		OwnerID:  mfOwnerId,
+	})
+	if err != nil {
+		bs.logger.Info("unable to retrieve datasets of agent groups from policies", zap.Strings("agent_group_ids", groupIDs), zap.Error(err))
+		return nil, &AgentGroupsError{GroupIDs: groupIDs, NotFound: status.Code(err) == codes.NotFound, Err: err}
+	}
+	withDatasets := make(map[string]bool, len(groupIDs))
+	for _, dataset := range res.GetDatasetList() {
+		datasets[dataset.GetId()] = dataset.GetAgentGroupId()
+		withDatasets[dataset.GetAgentGroupId()] = true
+	}
+	var notFound []string
+	for _, groupID := range groupIDs {
+		if withDatasets[groupID] {
+			continue
+		}
+		_, err := bs.fleetClient.RetrieveAgentGroup(ctx, &fleetpb.AgentGroupByIDReq{AgentGroupID: groupID, OwnerID: mfOwnerId})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			notFound = append(notFound, groupID)
+		case err != nil:
+			bs.logger.Info("unable to retrieve agent group from fleet", zap.String("agent_group_id", groupID), zap.Error(err))
+			return nil, &AgentGroupsError{GroupIDs: []string{groupID}, Err: err}
+		}
+	}
+	if len(notFound) > 0 {
+		return nil, &AgentGroupsError{GroupIDs: notFound, NotFound: true, Err: fmt.Errorf("agent groups removed from owner %s", mfOwnerId)}
+	}
+	bs.inMemoryCache.Set(cacheKey, datasets, agentGroupDatasetsTTL)
+	return datasets, nil
+}
+
+func (bs *SinkerOtelBridgeService) countCacheLookup(method string, hit bool) {
+	if bs.cacheCounter == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	bs.cacheCounter.With("method", method, "result", result).Add(1)
+}
+
+func uniqueSorted(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,125 @@
+package bridgeservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	fleetpb "github.com/orb-community/orb/fleet/pb"
+	policiespb "github.com/orb-community/orb/policies/pb"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type datasetsPoliciesClient struct {
+	policiespb.PolicyServiceClient
+	calls    int
+	datasets []*policiespb.DatasetRes
+	err      error
+}
+
+func (c *datasetsPoliciesClient) RetrieveDatasetsByGroups(_ context.Context, _ *policiespb.DatasetsByGroupsReq, _ ...grpc.CallOption) (*policiespb.DatasetsRes, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &policiespb.DatasetsRes{DatasetList: c.datasets}, nil
+}
+
+type groupsFleetClient struct {
+	fleetpb.FleetServiceClient
+	groups map[string]bool
+	err    error
+}
+
+func (c *groupsFleetClient) RetrieveAgentGroup(_ context.Context, req *fleetpb.AgentGroupByIDReq, _ ...grpc.CallOption) (*fleetpb.AgentGroupRes, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if !c.groups[req.AgentGroupID] {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &fleetpb.AgentGroupRes{Id: req.AgentGroupID}, nil
+}
+
+// lookupCounter counts the cache lookups by result
+type lookupCounter struct {
+	results map[string]float64
+	result  string
+}
+
+func (c *lookupCounter) With(labelValues ...string) metrics.Counter {
+	return &lookupCounter{results: c.results, result: labelValues[len(labelValues)-1]}
+}
+
+func (c *lookupCounter) Add(delta float64) {
+	c.results[c.result] += delta
+}
+
+func TestGetDataSetsFromAgentGroups(t *testing.T) {
+	newBridge := func(policiesClient *datasetsPoliciesClient, fleetClient *groupsFleetClient) (*SinkerOtelBridgeService, *lookupCounter) {
+		counter := &lookupCounter{results: make(map[string]float64)}
+		return &SinkerOtelBridgeService{
+			inMemoryCache:  *cache.New(time.Minute, time.Minute),
+			logger:         zap.NewNop(),
+			policiesClient: policiesClient,
+			fleetClient:    fleetClient,
+			cacheCounter:   counter,
+		}, counter
+	}
+
+	t.Run("empty group list", func(t *testing.T) {
+		policiesClient := &datasetsPoliciesClient{}
+		bs, _ := newBridge(policiesClient, &groupsFleetClient{})
+		datasets, err := bs.GetDataSetsFromAgentGroups(context.Background(), "owner", nil)
+		require.NoError(t, err)
+		assert.Empty(t, datasets)
+		assert.Equal(t, 0, policiesClient.calls)
+	})
+
+	t.Run("cached by sorted group ids", func(t *testing.T) {
+		policiesClient := &datasetsPoliciesClient{datasets: []*policiespb.DatasetRes{
+			{Id: "ds-1", AgentGroupId: "group-a"},
+			{Id: "ds-2", AgentGroupId: "group-b"},
+		}}
+		bs, counter := newBridge(policiesClient, &groupsFleetClient{})
+		datasets, err := bs.GetDataSetsFromAgentGroups(context.Background(), "owner", []string{"group-b", "group-a"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ds-1": "group-a", "ds-2": "group-b"}, datasets)
+
+		datasets, err = bs.GetDataSetsFromAgentGroups(context.Background(), "owner", []string{"group-a", "group-b", "group-a"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ds-1": "group-a", "ds-2": "group-b"}, datasets)
+		assert.Equal(t, 1, policiesClient.calls)
+		assert.Equal(t, map[string]float64{"miss": 1, "hit": 1}, counter.results)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		policiesClient := &datasetsPoliciesClient{datasets: []*policiespb.DatasetRes{{Id: "ds-1", AgentGroupId: "group-a"}}}
+		bs, _ := newBridge(policiesClient, &groupsFleetClient{groups: map[string]bool{"group-c": true}})
+		_, err := bs.GetDataSetsFromAgentGroups(context.Background(), "owner", []string{"group-a", "group-b", "group-c"})
+		var groupsErr *AgentGroupsError
+		require.True(t, errors.As(err, &groupsErr))
+		assert.True(t, groupsErr.NotFound)
+		assert.False(t, groupsErr.Retryable())
+		assert.Equal(t, []string{"group-b"}, groupsErr.GroupIDs)
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+		bs, _ := newBridge(&datasetsPoliciesClient{err: unavailable}, &groupsFleetClient{})
+		_, err := bs.GetDataSetsFromAgentGroups(context.Background(), "owner", []string{"group-a"})
+		var groupsErr *AgentGroupsError
+		require.True(t, errors.As(err, &groupsErr))
+		assert.False(t, groupsErr.NotFound)
+		assert.True(t, groupsErr.Retryable())
+		assert.ErrorIs(t, err, unavailable)
+	})
+}
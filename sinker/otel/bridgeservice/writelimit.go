@@ -0,0 +1,78 @@
+package bridgeservice
+
+import "sync"
+
+// DefaultSinkMaxInFlight is the number of concurrent writes to a sink when none is configured
+const DefaultSinkMaxInFlight = 4
+
+type sinkWrites struct {
+	inFlight int
+	queue    []func()
+}
+
+// writeLimiters bounds the concurrent writes of each sink to the Kafka fan-out, so a sink piling up batches does not
+// tie up the goroutines writing the healthy ones. The writes beyond the limit are queued up to maxQueued per sink
+type writeLimiters struct {
+	mu          sync.Mutex
+	maxInFlight int
+	maxQueued   int
+	sinks       map[string]*sinkWrites
+}
+
+func newWriteLimiters(maxInFlight int, maxQueued int) *writeLimiters {
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultSinkMaxInFlight
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+	return &writeLimiters{
+		maxInFlight: maxInFlight,
+		maxQueued:   maxQueued,
+		sinks:       make(map[string]*sinkWrites),
+	}
+}
+
+// submit starts the write when the sink is below its concurrency limit and queues it otherwise, it returns
+// false when the queue of the sink is full and the write was dropped
+func (l *writeLimiters) submit(key string, write func()) bool {
+	l.mu.Lock()
+	s, ok := l.sinks[key]
+	if !ok {
+		s = &sinkWrites{}
+		l.sinks[key] = s
+	}
+	if s.inFlight < l.maxInFlight {
+		s.inFlight++
+		l.mu.Unlock()
+		go l.run(key, s, write)
+		return true
+	}
+	if len(s.queue) >= l.maxQueued {
+		l.mu.Unlock()
+		return false
+	}
+	s.queue = append(s.queue, write)
+	l.mu.Unlock()
+	return true
+}
+
+// run executes the write and then the queued writes of the sink in order, until its queue is empty
+func (l *writeLimiters) run(key string, s *sinkWrites, write func()) {
+	for write != nil {
+		write()
+		l.mu.Lock()
+		if len(s.queue) > 0 {
+			write = s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+		} else {
+			write = nil
+			s.inFlight--
+			if s.inFlight == 0 {
+				delete(l.sinks, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
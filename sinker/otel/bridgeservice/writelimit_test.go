@@ -0,0 +1,49 @@
+package bridgeservice
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLimiters(t *testing.T) {
+	limiters := newWriteLimiters(2, 1)
+	release := make(chan struct{})
+	var running, done atomic.Int32
+	var wg sync.WaitGroup
+	slowWrite := func() {
+		running.Add(1)
+		<-release
+		done.Add(1)
+		wg.Done()
+	}
+
+	wg.Add(3)
+	assert.True(t, limiters.submit("owner-slow", slowWrite))
+	assert.True(t, limiters.submit("owner-slow", slowWrite))
+	assert.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+	assert.True(t, limiters.submit("owner-slow", slowWrite), "write above the limit should be queued")
+	assert.False(t, limiters.submit("owner-slow", slowWrite), "write above the queue size should be dropped")
+
+	// the other sinks are not held by the slow one
+	healthy := make(chan struct{})
+	assert.True(t, limiters.submit("owner-healthy", func() { close(healthy) }))
+	select {
+	case <-healthy:
+	case <-time.After(time.Second):
+		t.Fatal("write to the healthy sink was blocked by the slow sink")
+	}
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(3), done.Load())
+	require.Eventually(t, func() bool {
+		limiters.mu.Lock()
+		defer limiters.mu.Unlock()
+		return len(limiters.sinks) == 0
+	}, time.Second, time.Millisecond, "idle sinks should be forgotten")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/orb-community/orb/sinker/otel/orbreceiver"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
@@ -19,7 +20,16 @@ import (
 	"go.uber.org/zap"
 )
 
-func StartOtelMetricsComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
+// kafkaTLS returns the TLS settings of the connection to the Kafka brokers, nil to connect in plaintext when no
+// minimum version is given
+func kafkaTLS(minVersion string) *configtls.TLSClientSetting {
+	if minVersion == "" {
+		return nil
+	}
+	return &configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{MinVersion: minVersion}}
+}
+
+func StartOtelMetricsComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, kafkaTLSMinVersion string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
 	otelContext, otelCancelFunc := context.WithCancel(ctx)
 
 	log := logger.Sugar()
@@ -37,6 +47,7 @@ func StartOtelMetricsComponents(ctx context.Context, bridgeService *bridgeservic
 	}
 	expCfg := exporterFactory.CreateDefaultConfig().(*kafkaexporter.Config)
 	expCfg.Brokers = []string{kafkaUrl}
+	expCfg.Authentication.TLS = kafkaTLS(kafkaTLSMinVersion)
 	expCfg.Topic = "otlp_metrics"
 	exporter, err := exporterFactory.CreateMetricsExporter(exporterCtx, exporterCreateSettings, expCfg)
 	if err != nil {
@@ -107,7 +118,7 @@ func StartOtelMetricsComponents(ctx context.Context, bridgeService *bridgeservic
 	return otelCancelFunc, nil
 }
 
-func StartOtelLogsComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
+func StartOtelLogsComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, kafkaTLSMinVersion string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
 	otelContext, otelCancelFunc := context.WithCancel(ctx)
 
 	log := logger.Sugar()
@@ -125,6 +136,7 @@ func StartOtelLogsComponents(ctx context.Context, bridgeService *bridgeservice.S
 	}
 	expCfg := exporterFactory.CreateDefaultConfig().(*kafkaexporter.Config)
 	expCfg.Brokers = []string{kafkaUrl}
+	expCfg.Authentication.TLS = kafkaTLS(kafkaTLSMinVersion)
 	expCfg.Topic = "otlp_logs"
 	exporter, err := exporterFactory.CreateLogsExporter(exporterCtx, exporterCreateSettings, expCfg)
 	if err != nil {
@@ -195,13 +207,13 @@ func StartOtelLogsComponents(ctx context.Context, bridgeService *bridgeservice.S
 	return otelCancelFunc, nil
 }
 
-func StartOtelTracesComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
+func StartOtelTracesComponents(ctx context.Context, bridgeService *bridgeservice.SinkerOtelBridgeService, logger *zap.Logger, kafkaUrl string, kafkaTLSMinVersion string, pubSub mfnats.PubSub) (context.CancelFunc, error) {
 	otelContext, otelCancelFunc := context.WithCancel(ctx)
 
 	log := logger.Sugar()
 	log.Info("Starting to create Otel Traces Components in routine: ", ctx.Value("routine"))
 	exporterFactory := kafkaexporter.NewFactory()
-	exporterCtx := context.WithValue(otelContext, "component", "kafkaexporter")
+	exporterCtx := context.WithValue(otelContext, "component", "kafkaexportertraces")
 	exporterCreateSettings := exporter.CreateSettings{
 		TelemetrySettings: component.TelemetrySettings{
 			Logger:         logger,
@@ -213,6 +225,7 @@ func StartOtelTracesComponents(ctx context.Context, bridgeService *bridgeservice
 	}
 	expCfg := exporterFactory.CreateDefaultConfig().(*kafkaexporter.Config)
 	expCfg.Brokers = []string{kafkaUrl}
+	expCfg.Authentication.TLS = kafkaTLS(kafkaTLSMinVersion)
 	expCfg.Topic = "otlp_traces"
 	exporter, err := exporterFactory.CreateTracesExporter(exporterCtx, exporterCreateSettings, expCfg)
 	if err != nil {
@@ -229,7 +242,7 @@ func StartOtelTracesComponents(ctx context.Context, bridgeService *bridgeservice
 		return nil, err
 	}
 	transformFactory := transformprocessor.NewFactory()
-	transformCtx := context.WithValue(otelContext, "component", "transformprocessor")
+	transformCtx := context.WithValue(otelContext, "component", "transformprocessortraces")
 	log.Info("start to create traces component", zap.Any("component", transformCtx.Value("component")))
 	transformCfg := transformFactory.CreateDefaultConfig().(*transformprocessor.Config)
 	transformSet := processor.CreateSettings{
@@ -250,7 +263,7 @@ func StartOtelTracesComponents(ctx context.Context, bridgeService *bridgeservice
 	log.Info("created kafka traces exporter successfully")
 	// receiver Factory
 	orbReceiverFactory := orbreceiver.NewFactory()
-	receiverCtx := context.WithValue(otelContext, "component", "orbreceiver")
+	receiverCtx := context.WithValue(otelContext, "component", "orbreceivertraces")
 	receiverCfg := orbReceiverFactory.CreateDefaultConfig().(*orbreceiver.Config)
 	receiverCfg.Logger = logger
 	receiverCfg.PubSub = pubSub
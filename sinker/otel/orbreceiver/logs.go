@@ -109,24 +109,31 @@ func (r *OrbReceiver) ProccessLogsContext(scope plog.ScopeLogs, channel string,
 	attributeCtx = context.WithValue(attributeCtx, "agent_groups", agentPb.AgentGroupIDs)
 	attributeCtx = context.WithValue(attributeCtx, "agent_ownerID", agentPb.OwnerID)
 	for sinkId := range sinkIds {
+		if !r.cfg.SinkerService.AllowSinkWrite(agentPb.OwnerID, sinkId) {
+			r.cfg.Logger.Debug("sink circuit is open, skipping sink", zap.String("sink-id", sinkId))
+			continue
+		}
 		if err != nil {
 			r.cfg.Logger.Error("error notifying logs sink active, changing state, skipping sink", zap.String("sink-id", sinkId), zap.Error(err))
+			r.cfg.SinkerService.ReleaseSinkWrite(agentPb.OwnerID, sinkId)
 			continue
 		}
-		attributeCtx = context.WithValue(attributeCtx, "sink_id", sinkId)
+		sinkCtx := context.WithValue(attributeCtx, "sink_id", sinkId)
 		lr := plog.NewLogs()
 		scope.CopyTo(lr.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty())
 		lr.ResourceLogs().At(0).Resource().Attributes().PutStr("service.name", agentPb.AgentName)
 		lr.ResourceLogs().At(0).Resource().Attributes().PutStr("service.instance.id", polID)
 		request := plogotlp.NewExportRequestFromLogs(lr)
-		_, err = r.exportLogs(attributeCtx, request)
-		if err != nil {
-			r.cfg.Logger.Error("error during logs export, skipping sink", zap.Error(err))
-			_ = r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, "0")
-			continue
-		} else {
-			_ = r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, strconv.Itoa(size))
-		}
+		r.cfg.SinkerService.SubmitSinkWrite(agentPb.OwnerID, sinkId, func() {
+			_, err := r.exportLogs(sinkCtx, request)
+			r.cfg.SinkerService.ReportSinkWrite(r.ctx, agentPb.OwnerID, sinkId, err)
+			if err != nil {
+				r.cfg.Logger.Error("error during logs export, skipping sink", zap.Error(err))
+				_ = r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, "0")
+			} else {
+				_ = r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, strconv.Itoa(size))
+			}
+		})
 	}
 }
 
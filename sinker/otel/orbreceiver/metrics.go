@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/orb-community/orb/sinks/backend"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -19,6 +20,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// agentSelfMetricsScope names the scope of the operational metrics the agents forward about themselves
+const agentSelfMetricsScope = "orb_agent"
+
 type internalMetricsReceiver struct {
 	pmetricotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Metrics
@@ -99,6 +103,10 @@ func (r *OrbReceiver) ProccessMetricsContext(scope pmetric.ScopeMetrics, channel
 
 	r.injectScopeMetricsAttribute(scope, "agent", agentPb.AgentName)
 	r.injectScopeMetricsAttribute(scope, "policy_id", polID)
+	// the operational metrics of the agents carry their agent id, the owner makes them aggregatable by tenant
+	if scope.Scope().Name() == agentSelfMetricsScope {
+		r.injectScopeMetricsAttribute(scope, "owner_id", agentPb.OwnerID)
+	}
 
 	scope = r.replaceScopeMetricsTimestamp(scope, pcommon.NewTimestampFromTime(time.Now()))
 	sinkIds, err := r.sinkerService.GetSinkIdsFromDatasetIDs(execCtx, agentPb.OwnerID, datasetIDs)
@@ -114,21 +122,44 @@ func (r *OrbReceiver) ProccessMetricsContext(scope pmetric.ScopeMetrics, channel
 	attributeCtx = context.WithValue(attributeCtx, "agent_ownerID", agentPb.OwnerID)
 
 	for sinkId := range sinkIds {
+		if !r.cfg.SinkerService.AllowSinkWrite(agentPb.OwnerID, sinkId) {
+			r.cfg.Logger.Debug("sink circuit is open, skipping sink", zap.String("sink-id", sinkId))
+			continue
+		}
 		err := r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, strconv.Itoa(size))
 		if err != nil {
 			r.cfg.Logger.Error("error notifying metrics sink active, changing state, skipping sink", zap.String("sink-id", sinkId), zap.Error(err))
 		}
-		attributeCtx = context.WithValue(attributeCtx, "sink_id", sinkId)
+		sinkCtx := context.WithValue(attributeCtx, "sink_id", sinkId)
 		mr := pmetric.NewMetrics()
 		scope.CopyTo(mr.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty())
+		rules, err := r.cfg.SinkerService.GetSinkRelabelRules(execCtx, agentPb.OwnerID, sinkId)
+		if err != nil {
+			r.cfg.Logger.Warn("error retrieving sink relabel rules, writing labels as they are", zap.String("sink-id", sinkId), zap.Error(err))
+		} else if len(rules) > 0 {
+			relabelScopeMetrics(mr.ResourceMetrics().At(0).ScopeMetrics().At(0), rules)
+			if mr.DataPointCount() == 0 {
+				r.cfg.Logger.Debug("all data points dropped by the sink relabel rules, skipping sink", zap.String("sink-id", sinkId))
+				r.cfg.SinkerService.ReleaseSinkWrite(agentPb.OwnerID, sinkId)
+				continue
+			}
+		}
+		sinkBackend, err := r.cfg.SinkerService.GetSinkBackend(execCtx, agentPb.OwnerID, sinkId)
+		if err != nil {
+			r.cfg.Logger.Warn("error retrieving sink backend, writing labels as they are", zap.String("sink-id", sinkId), zap.Error(err))
+		} else if sinkBackend != nil {
+			r.normalizeScopeMetricsLabels(mr.ResourceMetrics().At(0).ScopeMetrics().At(0), sinkBackend)
+		}
 		mr.ResourceMetrics().At(0).Resource().Attributes().PutStr("service.name", agentPb.AgentName)
 		mr.ResourceMetrics().At(0).Resource().Attributes().PutStr("service.instance.id", polID)
 		request := pmetricotlp.NewExportRequestFromMetrics(mr)
-		_, err = r.exportMetrics(attributeCtx, request)
-		if err != nil {
-			r.cfg.Logger.Error("error during metrics export, skipping sink", zap.Error(err))
-			continue
-		}
+		r.cfg.SinkerService.SubmitSinkWrite(agentPb.OwnerID, sinkId, func() {
+			_, err := r.exportMetrics(sinkCtx, request)
+			r.cfg.SinkerService.ReportSinkWrite(r.ctx, agentPb.OwnerID, sinkId, err)
+			if err != nil {
+				r.cfg.Logger.Error("error during metrics export, skipping sink", zap.Error(err))
+			}
+		})
 	}
 }
 
@@ -166,6 +197,128 @@ func (r *OrbReceiver) injectScopeMetricsAttribute(metricsScope pmetric.ScopeMetr
 	return metricsScope
 }
 
+// normalize the labels of all ScopeMetrics metrics to the naming rules of the sink backend
+func (r *OrbReceiver) normalizeScopeMetricsLabels(metricsScope pmetric.ScopeMetrics, be backend.Backend) pmetric.ScopeMetrics {
+	metrics := metricsScope.Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metricItem := metrics.At(i)
+
+		switch metricItem.Type() {
+		case pmetric.MetricTypeExponentialHistogram:
+			for i := 0; i < metricItem.ExponentialHistogram().DataPoints().Len(); i++ {
+				normalizeAttributes(metricItem.ExponentialHistogram().DataPoints().At(i).Attributes(), be)
+			}
+		case pmetric.MetricTypeGauge:
+			for i := 0; i < metricItem.Gauge().DataPoints().Len(); i++ {
+				normalizeAttributes(metricItem.Gauge().DataPoints().At(i).Attributes(), be)
+			}
+		case pmetric.MetricTypeHistogram:
+			for i := 0; i < metricItem.Histogram().DataPoints().Len(); i++ {
+				normalizeAttributes(metricItem.Histogram().DataPoints().At(i).Attributes(), be)
+			}
+		case pmetric.MetricTypeSum:
+			for i := 0; i < metricItem.Sum().DataPoints().Len(); i++ {
+				normalizeAttributes(metricItem.Sum().DataPoints().At(i).Attributes(), be)
+			}
+		case pmetric.MetricTypeSummary:
+			for i := 0; i < metricItem.Summary().DataPoints().Len(); i++ {
+				normalizeAttributes(metricItem.Summary().DataPoints().At(i).Attributes(), be)
+			}
+		default:
+			continue
+		}
+	}
+	return metricsScope
+}
+
+// relabelScopeMetrics applies the relabeling rules of the sink to the labels of all ScopeMetrics metrics, removing
+// the data points the rules drop and the metrics left without data points
+func relabelScopeMetrics(metricsScope pmetric.ScopeMetrics, rules []backend.RelabelRule) {
+	metricsScope.Metrics().RemoveIf(func(metricItem pmetric.Metric) bool {
+		name := metricItem.Name()
+		switch metricItem.Type() {
+		case pmetric.MetricTypeExponentialHistogram:
+			metricItem.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+				return !relabelAttributes(dp.Attributes(), name, rules)
+			})
+			return metricItem.ExponentialHistogram().DataPoints().Len() == 0
+		case pmetric.MetricTypeGauge:
+			metricItem.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+				return !relabelAttributes(dp.Attributes(), name, rules)
+			})
+			return metricItem.Gauge().DataPoints().Len() == 0
+		case pmetric.MetricTypeHistogram:
+			metricItem.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+				return !relabelAttributes(dp.Attributes(), name, rules)
+			})
+			return metricItem.Histogram().DataPoints().Len() == 0
+		case pmetric.MetricTypeSum:
+			metricItem.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+				return !relabelAttributes(dp.Attributes(), name, rules)
+			})
+			return metricItem.Sum().DataPoints().Len() == 0
+		case pmetric.MetricTypeSummary:
+			metricItem.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+				return !relabelAttributes(dp.Attributes(), name, rules)
+			})
+			return metricItem.Summary().DataPoints().Len() == 0
+		default:
+			return false
+		}
+	})
+}
+
+// relabelAttributes applies the relabeling rules to the attributes of a data point, false when it is dropped
+func relabelAttributes(attributes pcommon.Map, metricName string, rules []backend.RelabelRule) bool {
+	labels := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		labels[k] = v.AsString()
+		return true
+	})
+	relabeled, keep := backend.Relabel(rules, metricName, labels)
+	if !keep {
+		return false
+	}
+	if sameLabels(labels, relabeled) {
+		// keep the attribute value types when nothing was relabeled
+		return true
+	}
+	attributes.Clear()
+	for k, v := range relabeled {
+		attributes.PutStr(k, v)
+	}
+	return true
+}
+
+func normalizeAttributes(attributes pcommon.Map, be backend.Backend) {
+	labels := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		labels[k] = v.AsString()
+		return true
+	})
+	normalized := be.NormalizeLabels(labels)
+	if sameLabels(labels, normalized) {
+		// keep the attribute value types when nothing was renamed
+		return
+	}
+	attributes.Clear()
+	for k, v := range normalized {
+		attributes.PutStr(k, v)
+	}
+}
+
+func sameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
 // replace ScopeMetrics metrics timestamp
 func (r *OrbReceiver) replaceScopeMetricsTimestamp(metricsScope pmetric.ScopeMetrics, ts pcommon.Timestamp) pmetric.ScopeMetrics {
 	metricsList := metricsScope.Metrics()
@@ -92,6 +92,21 @@ func (r *OrbReceiver) DecompressBrotli(data []byte) []byte {
 	return []byte(s)
 }
 
+// sinkAcceptsSignal reports whether the sink accepts the signal, the sinks whose signals cannot be
+// retrieved are written to as before
+func (r *OrbReceiver) sinkAcceptsSignal(ctx context.Context, ownerID, sinkID, signal string) bool {
+	signals, err := r.sinkerService.GetSinkSignals(ctx, ownerID, sinkID)
+	if err != nil || len(signals) == 0 {
+		return true
+	}
+	for _, s := range signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *OrbReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return component.ErrNilNextConsumer
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/orb-community/orb/sinks/backend"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -110,22 +111,33 @@ func (r *OrbReceiver) ProccessTracesContext(scope ptrace.ScopeSpans, channel str
 	attributeCtx = context.WithValue(attributeCtx, "agent_ownerID", agentPb.OwnerID)
 
 	for sinkId := range sinkIds {
+		if !r.sinkAcceptsSignal(execCtx, agentPb.OwnerID, sinkId, backend.SignalTraces) {
+			r.cfg.Logger.Debug("sink does not accept traces, skipping sink", zap.String("sink-id", sinkId))
+			continue
+		}
+		if !r.cfg.SinkerService.AllowSinkWrite(agentPb.OwnerID, sinkId) {
+			r.cfg.Logger.Debug("sink circuit is open, skipping sink", zap.String("sink-id", sinkId))
+			continue
+		}
 		err := r.cfg.SinkerService.NotifyActiveSink(r.ctx, agentPb.OwnerID, sinkId, strconv.Itoa(size))
 		if err != nil {
 			r.cfg.Logger.Error("error notifying sink active, changing state, skipping sink", zap.String("sink-id", sinkId), zap.Error(err))
+			r.cfg.SinkerService.ReleaseSinkWrite(agentPb.OwnerID, sinkId)
 			continue
 		}
-		attributeCtx = context.WithValue(attributeCtx, "sink_id", sinkId)
+		sinkCtx := context.WithValue(attributeCtx, "sink_id", sinkId)
 		lr := ptrace.NewTraces()
 		scope.CopyTo(lr.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty())
 		lr.ResourceSpans().At(0).Resource().Attributes().PutStr("service.name", agentPb.AgentName)
 		lr.ResourceSpans().At(0).Resource().Attributes().PutStr("service.instance.id", polID)
 		request := ptraceotlp.NewExportRequestFromTraces(lr)
-		_, err = r.exportTraces(attributeCtx, request)
-		if err != nil {
-			r.cfg.Logger.Error("error during export, skipping sink", zap.Error(err))
-			continue
-		}
+		r.cfg.SinkerService.SubmitSinkWrite(agentPb.OwnerID, sinkId, func() {
+			_, err := r.exportTraces(sinkCtx, request)
+			r.cfg.SinkerService.ReportSinkWrite(r.ctx, agentPb.OwnerID, sinkId, err)
+			if err != nil {
+				r.cfg.Logger.Error("error during export, skipping sink", zap.Error(err))
+			}
+		})
 	}
 }
 
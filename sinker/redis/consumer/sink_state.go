@@ -0,0 +1,66 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/orb-community/orb/sinks"
+	sinksconsumer "github.com/orb-community/orb/sinks/redis/consumer"
+	"go.uber.org/zap"
+)
+
+const (
+	// sinkStateBlock is how long a read of the sink state stream waits for new changes
+	sinkStateBlock = 5 * time.Second
+	// sinkStateRetry is the pause before reading the sink state stream again after a failed read
+	sinkStateRetry = time.Second
+)
+
+type SinkStateListener interface {
+	// SubscribeToSinkStates Listen to the sink state changes published by sinks, async
+	SubscribeToSinkStates(ctx context.Context) error
+}
+
+type sinkStateListener struct {
+	logger  *zap.Logger
+	stream  sinks.StateChangeStream
+	handler func(change sinks.StateChange)
+}
+
+// NewSinkStateListener reads the states set on the sinks from the exports of their collectors, sinks publishes
+// them on the event store shared with the sinker
+func NewSinkStateListener(l *zap.Logger, streamClient *redis.Client, handler func(change sinks.StateChange)) SinkStateListener {
+	logger := l.Named("sink_state_listener")
+	return &sinkStateListener{logger: logger, stream: sinksconsumer.NewStateChangeStream(streamClient), handler: handler}
+}
+
+// SubscribeToSinkStates to be used to follow the sink state changes from the newest one
+func (s *sinkStateListener) SubscribeToSinkStates(ctx context.Context) error {
+	after, err := s.stream.Latest(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for ctx.Err() == nil {
+			changes, err := s.stream.Read(ctx, after, sinkStateBlock)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				s.logger.Warn("failed to read the sink state stream", zap.Error(err))
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(sinkStateRetry):
+				}
+				continue
+			}
+			for _, change := range changes {
+				after = change.ID
+				s.handler(change)
+			}
+		}
+	}()
+	return nil
+}
@@ -9,10 +9,10 @@ import (
 )
 
 type SinkerKey struct {
-	OwnerID      string
-	SinkID       string
-	Size         string
-	LastActivity time.Time
+	OwnerID      string    `json:"owner_id"`
+	SinkID       string    `json:"sink_id"`
+	Size         string    `json:"size"`
+	LastActivity time.Time `json:"last_activity"`
 }
 
 func (s *SinkerKey) Encode() map[string]interface{} {
@@ -24,8 +24,20 @@ func (s *SinkerKey) Encode() map[string]interface{} {
 	}
 }
 
+func decodeSinkerKey(values map[string]string) SinkerKey {
+	key := SinkerKey{
+		OwnerID: values["owner_id"],
+		SinkID:  values["sink_id"],
+		Size:    values["size"],
+	}
+	key.LastActivity, _ = time.Parse(time.RFC3339, values["last_activity"])
+	return key
+}
+
 const DefaultExpiration = 5 * time.Minute
 
+const sinkerKeyPattern = "orb.sinker.key-*"
+
 type SinkerKeyService interface {
 	// AddNewSinkerKey Add New Sinker Key with default Expiration of 5 minutes
 	AddNewSinkerKey(ctx context.Context, key SinkerKey) error
@@ -33,6 +45,8 @@ type SinkerKeyService interface {
 	RenewSinkerKey(ctx context.Context, key SinkerKey) error
 	// RenewSinkerKeyInternal Increment Expiration of Sinker Key
 	RenewSinkerKeyInternal(ctx context.Context, sink SinkerKey, expiration time.Duration) error
+	// GetAllSinkerKeys Retrieve every Sinker Key that has not expired yet
+	GetAllSinkerKeys(ctx context.Context) ([]SinkerKey, error)
 }
 
 type sinkerKeyService struct {
@@ -84,3 +98,25 @@ func (s *sinkerKeyService) AddNewSinkerKey(ctx context.Context, sink SinkerKey)
 	}
 	return nil
 }
+
+func (s *sinkerKeyService) GetAllSinkerKeys(ctx context.Context) ([]SinkerKey, error) {
+	var keys []SinkerKey
+	iter := s.cacheRepository.Scan(ctx, 0, sinkerKeyPattern, 0).Iterator()
+	for iter.Next(ctx) {
+		values, err := s.cacheRepository.HGetAll(ctx, iter.Val()).Result()
+		if err != nil {
+			s.logger.Error("error retrieving sinker key", zap.String("key", iter.Val()), zap.Error(err))
+			return nil, err
+		}
+		// the key may have expired between the scan and the read
+		if len(values) == 0 {
+			continue
+		}
+		keys = append(keys, decodeSinkerKey(values))
+	}
+	if err := iter.Err(); err != nil {
+		s.logger.Error("error scanning sinker keys", zap.Error(err))
+		return nil, err
+	}
+	return keys, nil
+}
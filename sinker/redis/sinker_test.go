@@ -58,3 +58,29 @@ func TestSinkActivityStoreAndMessage(t *testing.T) {
 	}
 	logger.Debug("debugging breakpoint")
 }
+
+func TestGetAllSinkerKeys(t *testing.T) {
+	sinkTTLSvc := producer.NewSinkerKeyService(logger, redisClient)
+	keys := []producer.SinkerKey{
+		{OwnerID: "3", SinkID: "1", Size: "40", LastActivity: time.Now().Truncate(time.Second)},
+		{OwnerID: "3", SinkID: "2", Size: "12", LastActivity: time.Now().Truncate(time.Second)},
+	}
+	for _, key := range keys {
+		err := sinkTTLSvc.AddNewSinkerKey(context.Background(), key)
+		require.NoError(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	got, err := sinkTTLSvc.GetAllSinkerKeys(context.Background())
+	require.NoError(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for _, key := range keys {
+		found := false
+		for _, g := range got {
+			if g.OwnerID == key.OwnerID && g.SinkID == key.SinkID {
+				found = true
+				require.Equal(t, key.Size, g.Size)
+				require.True(t, key.LastActivity.Equal(g.LastActivity), fmt.Sprintf("expected last activity %s got %s", key.LastActivity, g.LastActivity))
+			}
+		}
+		require.True(t, found, fmt.Sprintf("sinker key %s:%s not found", key.OwnerID, key.SinkID))
+	}
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/go-redis/redis/v8"
 	mfnats "github.com/mainflux/mainflux/pkg/messaging/nats"
 	fleetpb "github.com/orb-community/orb/fleet/pb"
+	"github.com/orb-community/orb/pkg/config"
 	policiespb "github.com/orb-community/orb/policies/pb"
 	"github.com/orb-community/orb/sinker/otel"
 	"github.com/orb-community/orb/sinker/otel/bridgeservice"
+	"github.com/orb-community/orb/sinks"
 	sinkspb "github.com/orb-community/orb/sinks/pb"
 	"go.uber.org/zap"
 )
@@ -39,7 +41,10 @@ type SinkerService struct {
 	otel                   bool
 	otelMetricsCancelFunct context.CancelFunc
 	otelLogsCancelFunct    context.CancelFunc
+	otelTracesCancelFunct  context.CancelFunc
 	otelKafkaUrl           string
+	// TLS minimum version of the connection to Kafka, plaintext when empty
+	otelKafkaTLSMinVersion string
 
 	inMemoryCacheExpiration time.Duration
 	streamClient            *redis.Client
@@ -59,8 +64,14 @@ type SinkerService struct {
 	requestCounter metrics.Counter
 
 	messageInputCounter metrics.Counter
-	cancelAsyncContext  context.CancelFunc
-	asyncContext        context.Context
+	cacheCounter        metrics.Counter
+
+	// sinkWriteCfg bounds the concurrent writes to each sink, droppedWritesCounter counts the writes dropped
+	sinkWriteCfg         config.SinkWriteConfig
+	droppedWritesCounter metrics.Counter
+
+	cancelAsyncContext context.CancelFunc
+	asyncContext       context.Context
 }
 
 func (svc SinkerService) Start() error {
@@ -97,16 +108,32 @@ func (svc SinkerService) startOtel(ctx context.Context) error {
 		var err error
 
 		bridgeService := bridgeservice.NewBridgeService(svc.logger, svc.inMemoryCacheExpiration, svc.sinkActivitySvc,
-			svc.policiesClient, svc.sinksClient, svc.fleetClient, svc.messageInputCounter)
-		svc.otelMetricsCancelFunct, err = otel.StartOtelMetricsComponents(ctx, &bridgeService, svc.logger, svc.otelKafkaUrl, svc.pubSub)
+			svc.policiesClient, svc.sinksClient, svc.fleetClient, svc.messageInputCounter, svc.cacheCounter,
+			svc.sinkWriteCfg, svc.droppedWritesCounter)
+		// the circuits of the sinks follow the result of the exports reported from their collectors
+		sinkStateListener := consumer.NewSinkStateListener(svc.logger, svc.streamClient, func(change sinks.StateChange) {
+			bridgeService.ReportSinkState(change.OwnerID, change.SinkID, change.State)
+		})
+		if err = sinkStateListener.SubscribeToSinkStates(ctx); err != nil {
+			svc.logger.Error("error during SubscribeToSinkStates", zap.Error(err))
+			return err
+		}
+		svc.otelMetricsCancelFunct, err = otel.StartOtelMetricsComponents(ctx, &bridgeService, svc.logger, svc.otelKafkaUrl, svc.otelKafkaTLSMinVersion, svc.pubSub)
 
 		// starting Otel Logs components
-		svc.otelLogsCancelFunct, err = otel.StartOtelLogsComponents(ctx, &bridgeService, svc.logger, svc.otelKafkaUrl, svc.pubSub)
+		svc.otelLogsCancelFunct, err = otel.StartOtelLogsComponents(ctx, &bridgeService, svc.logger, svc.otelKafkaUrl, svc.otelKafkaTLSMinVersion, svc.pubSub)
 
 		if err != nil {
 			svc.logger.Error("error during StartOtelComponents", zap.Error(err))
 			return err
 		}
+
+		// starting Otel Traces components
+		svc.otelTracesCancelFunct, err = otel.StartOtelTracesComponents(ctx, &bridgeService, svc.logger, svc.otelKafkaUrl, svc.otelKafkaTLSMinVersion, svc.pubSub)
+		if err != nil {
+			svc.logger.Error("error during StartOtelTracesComponents", zap.Error(err))
+			return err
+		}
 	}
 	return nil
 }
@@ -135,11 +162,15 @@ func New(logger *zap.Logger,
 	fleetClient fleetpb.FleetServiceClient,
 	sinksClient sinkspb.SinkServiceClient,
 	otelKafkaUrl string,
+	otelKafkaTLSMinVersion string,
 	enableOtel bool,
 	requestGauge metrics.Gauge,
 	requestCounter metrics.Counter,
 	inputCounter metrics.Counter,
+	cacheCounter metrics.Counter,
 	defaultCacheExpiration time.Duration,
+	sinkWriteCfg config.SinkWriteConfig,
+	droppedWritesCounter metrics.Counter,
 ) Service {
 	return &SinkerService{
 		inMemoryCacheExpiration: defaultCacheExpiration,
@@ -153,7 +184,11 @@ func New(logger *zap.Logger,
 		requestGauge:            requestGauge,
 		requestCounter:          requestCounter,
 		messageInputCounter:     inputCounter,
+		cacheCounter:            cacheCounter,
+		sinkWriteCfg:            sinkWriteCfg,
+		droppedWritesCounter:    droppedWritesCounter,
 		otel:                    enableOtel,
 		otelKafkaUrl:            otelKafkaUrl,
+		otelKafkaTLSMinVersion:  otelKafkaTLSMinVersion,
 	}
 }
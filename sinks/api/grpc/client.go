@@ -55,6 +55,7 @@ func (client grpcClient) RetrieveSinks(ctx context.Context, in *pb.SinksFilterRe
 			Error:       sinkResponse.error,
 			Backend:     sinkResponse.backend,
 			Config:      sinkResponse.config,
+			Signals:     sinkResponse.signals,
 		}
 	}
 	return &pb.SinksRes{Sinks: sinkList}, nil
@@ -84,6 +85,7 @@ func (client grpcClient) RetrieveSink(ctx context.Context, in *pb.SinkByIDReq, _
 		Error:       ir.error,
 		Backend:     ir.backend,
 		Config:      ir.config,
+		Signals:     ir.signals,
 	}, nil
 }
 
@@ -131,6 +133,7 @@ func decodeSinksResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 			error:       sink.Error,
 			backend:     sink.Backend,
 			config:      sink.Config,
+			signals:     sink.Signals,
 		}
 	}
 	return sinksRes{sinks: sinkList}, nil
@@ -152,5 +155,6 @@ func decodeSinkResponse(_ context.Context, grpcRes interface{}) (interface{}, er
 		error:       res.GetError(),
 		backend:     res.GetBackend(),
 		config:      res.GetConfig(),
+		signals:     res.GetSignals(),
 	}, nil
 }
@@ -40,6 +40,7 @@ func retrieveSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			error:       sink.Error,
 			backend:     sink.Backend,
 			config:      configData,
+			signals:     sink.AcceptedSignals(),
 		}
 		return res, err
 	}
@@ -89,5 +90,6 @@ func buildSinkResponse(sink sinks.Sink) (sinkRes, error) {
 		error:       sink.Error,
 		backend:     sink.Backend,
 		config:      configData,
+		signals:     sink.AcceptedSignals(),
 	}, nil
 }
@@ -18,6 +18,7 @@ type sinkRes struct {
 	error       string
 	backend     string
 	config      []byte
+	signals     []string
 }
 
 type sinksRes struct {
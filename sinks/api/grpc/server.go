@@ -84,6 +84,7 @@ func encodeSinksResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 			Error:       sink.error,
 			Backend:     sink.backend,
 			Config:      sink.config,
+			Signals:     sink.signals,
 		}
 	}
 	return &pb.SinksRes{
@@ -107,6 +108,7 @@ func encodeSinkResponse(_ context.Context, grpcRes interface{}) (interface{}, er
 		Error:       res.error,
 		Backend:     res.backend,
 		Config:      res.config,
+		Signals:     res.signals,
 	}, nil
 }
 
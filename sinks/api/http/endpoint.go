@@ -32,6 +32,12 @@ func omitSecretInformation(configSvc *sinks.Configuration, inputSink sinks.Sink)
 	inputSink.Config.RemoveKeys([]string{"opentelemetry"})
 	returnSink = inputSink
 	authMeta := a.(types.Metadata)
+	authMeta, err = sinks.ApplySecretHeaders(configSvc.Exporter, authMeta, func(string) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		return sinks.Sink{}, err
+	}
 	returnSink.Config = authMeta
 	if inputSink.Format == "yaml" {
 		configData, newErr := configSvc.Authentication.ConfigToFormat(inputSink.Format, authMeta)
@@ -88,7 +94,7 @@ func addEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			return nil, err
 		}
 		var exporterConfig types.Metadata
-		var authConfig types.Metadata
+		var authConfig interface{}
 		var configSvc *sinks.Configuration
 		if len(req.Format) > 0 && req.Format == "yaml" {
 			if len(req.ConfigData) > 0 {
@@ -121,6 +127,7 @@ func addEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 		sink := sinks.Sink{
 			Name:        nID,
 			Backend:     req.Backend,
+			Type:        req.Type,
 			Config:      config,
 			Description: &req.Description,
 			Tags:        req.Tags,
@@ -128,7 +135,15 @@ func addEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			Format:      req.Format,
 			Created:     time.Now(),
 		}
-		saved, err := svc.CreateSink(ctx, req.token, sink)
+		if req.ValidateOnly {
+			return validateAddRequest(ctx, svc, req, configSvc, sink)
+		}
+		var saved sinks.Sink
+		if req.onBehalfOf != "" {
+			saved, err = svc.CreateSinkOnBehalfOf(ctx, req.token, req.onBehalfOf, sink)
+		} else {
+			saved, err = svc.CreateSink(ctx, req.token, sink)
+		}
 		if err != nil {
 			svc.GetLogger().Error("received error on creating sink", zap.Error(err))
 			return nil, err
@@ -147,17 +162,54 @@ func addEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			State:       saved.State.String(),
 			Error:       saved.Error,
 			Backend:     saved.Backend,
+			Type:        saved.Type,
 			Config:      omittedSink.Config,
 			ConfigData:  omittedSink.ConfigData,
 			Format:      saved.Format,
 			TsCreated:   saved.Created,
 			created:     true,
+
+			CredentialsUpdatedAt: saved.CredentialsUpdatedAt,
 		}
 
 		return res, nil
 	}
 }
 
+// validateAddRequest checks the sink of an add request without saving it, probing its remote end when deep is set
+func validateAddRequest(ctx context.Context, svc sinks.SinkService, req addReq, configSvc *sinks.Configuration, sink sinks.Sink) (interface{}, error) {
+	validated, err := svc.ValidateSink(ctx, req.token, sink)
+	if err != nil {
+		svc.GetLogger().Error("received error on validating sink", zap.Error(err))
+		return nil, err
+	}
+
+	var probe *probeRes
+	if req.Deep {
+		probe = &probeRes{Success: true}
+		if err := svc.ProbeSink(ctx, req.token, validated); err != nil {
+			probe = &probeRes{Success: false, Error: err.Error()}
+		}
+	}
+
+	omittedSink, err := omitSecretInformation(configSvc, validated)
+	if err != nil {
+		svc.GetLogger().Error("sink was validated, but got error in the response build", zap.Error(err))
+		return nil, err
+	}
+	res := validateSinkRes{
+		Name:        validated.Name.String(),
+		Description: *validated.Description,
+		Tags:        validated.Tags,
+		State:       validated.State.String(),
+		Backend:     validated.Backend,
+		Type:        validated.Type,
+		Config:      omittedSink.Config,
+		Probe:       probe,
+	}
+	return res, nil
+}
+
 func updateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(updateSinkReq)
@@ -182,6 +234,9 @@ func updateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 		if req.Format != "" {
 			currentSink.Format = req.Format
 		}
+		if req.Type != "" {
+			currentSink.Type = req.Type
+		}
 		if req.Description != nil {
 			currentSink.Description = req.Description
 		}
@@ -195,7 +250,7 @@ func updateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 		}
 		var configSvc *sinks.Configuration
 		var exporterConfig types.Metadata
-		var authConfig types.Metadata
+		var authConfig interface{}
 
 		// Update the config if either req.Config or req.ConfigData is populated
 		if req.Config != nil || req.ConfigData != "" {
@@ -254,12 +309,161 @@ func updateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			State:       sinkEdited.State.String(),
 			Error:       sinkEdited.Error,
 			Backend:     sinkEdited.Backend,
+			Type:        sinkEdited.Type,
+			Config:      omittedSink.Config,
+			ConfigData:  omittedSink.ConfigData,
+			Format:      sinkEdited.Format,
+			created:     false,
+		}
+
+		return res, nil
+	}
+}
+
+func patchSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(patchSinkReq)
+		if err := req.validate(); err != nil {
+			svc.GetLogger().Error("error validating request", zap.Error(err))
+			return nil, err
+		}
+
+		sink := sinks.Sink{
+			ID:          req.id,
+			Description: req.Description,
+			Tags:        req.Tags,
+		}
+		if req.Name != "" {
+			// already validated along with the request
+			sink.Name, _ = types.NewIdentifier(req.Name)
+		}
+
+		sinkEdited, err := svc.PatchSink(ctx, req.token, sink)
+		if err != nil {
+			svc.GetLogger().Error("error on patching sink", zap.String("sinkID", req.id), zap.Error(err))
+			return nil, err
+		}
+
+		authType, _ := authentication_type.GetAuthType(sinkEdited.GetAuthenticationTypeName())
+		configSvc := &sinks.Configuration{
+			Authentication: authType,
+			Exporter:       backend.GetBackend(sinkEdited.Backend),
+		}
+		omittedSink, err := omitSecretInformation(configSvc, sinkEdited)
+		if err != nil {
+			svc.GetLogger().Error("sink was patched, but got error in the response build", zap.Error(err))
+			return nil, err
+		}
+
+		res := sinkRes{
+			ID:          sinkEdited.ID,
+			Name:        sinkEdited.Name.String(),
+			Description: *sinkEdited.Description,
+			Tags:        sinkEdited.Tags,
+			State:       sinkEdited.State.String(),
+			Error:       sinkEdited.Error,
+			Backend:     sinkEdited.Backend,
+			Type:        sinkEdited.Type,
 			Config:      omittedSink.Config,
 			ConfigData:  omittedSink.ConfigData,
 			Format:      sinkEdited.Format,
+			TsCreated:   sinkEdited.Created,
 			created:     false,
 		}
+		return res, nil
+	}
+}
+
+func cloneSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(cloneSinkReq)
+		if err := req.validate(); err != nil {
+			svc.GetLogger().Error("error validating request", zap.Error(err))
+			return nil, err
+		}
+
+		// already validated along with the request
+		nID, _ := types.NewIdentifier(req.Name)
+		saved, err := svc.CloneSink(ctx, req.token, req.id, sinks.Sink{Name: nID, Tags: req.Tags})
+		if err != nil {
+			svc.GetLogger().Error("error on cloning sink", zap.String("sinkID", req.id), zap.Error(err))
+			return nil, err
+		}
+
+		authType, _ := authentication_type.GetAuthType(saved.GetAuthenticationTypeName())
+		configSvc := &sinks.Configuration{
+			Authentication: authType,
+			Exporter:       backend.GetBackend(saved.Backend),
+		}
+		omittedSink, err := omitSecretInformation(configSvc, saved)
+		if err != nil {
+			svc.GetLogger().Error("sink was cloned, but got error in the response build", zap.Error(err))
+			return nil, err
+		}
+
+		res := sinkRes{
+			ID:          saved.ID,
+			Name:        saved.Name.String(),
+			Description: *saved.Description,
+			Tags:        saved.Tags,
+			State:       saved.State.String(),
+			Error:       saved.Error,
+			Backend:     saved.Backend,
+			Type:        saved.Type,
+			Config:      omittedSink.Config,
+			ConfigData:  omittedSink.ConfigData,
+			Format:      saved.Format,
+			TsCreated:   saved.Created,
+			created:     true,
+
+			CredentialsUpdatedAt: saved.CredentialsUpdatedAt,
+		}
+		return res, nil
+	}
+}
+
+func rotateCredentialsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(rotateCredentialsReq)
+		if err := req.validate(); err != nil {
+			svc.GetLogger().Error("error validating request", zap.Error(err))
+			return nil, err
+		}
+
+		sinkEdited, err := svc.RotateSinkCredentials(ctx, req.token, req.id, req.Authentication)
+		if err != nil {
+			svc.GetLogger().Error("error on rotating sink credentials", zap.String("sinkID", req.id), zap.Error(err))
+			return nil, err
+		}
+
+		authType, _ := authentication_type.GetAuthType(sinkEdited.GetAuthenticationTypeName())
+		configSvc := &sinks.Configuration{
+			Authentication: authType,
+			Exporter:       backend.GetBackend(sinkEdited.Backend),
+		}
+		omittedSink, err := omitSecretInformation(configSvc, sinkEdited)
+		if err != nil {
+			svc.GetLogger().Error("sink credentials were rotated, but got error in the response build", zap.Error(err))
+			return nil, err
+		}
+
+		res := sinkRes{
+			ID:          sinkEdited.ID,
+			Name:        sinkEdited.Name.String(),
+			Description: *sinkEdited.Description,
+			Tags:        sinkEdited.Tags,
+			State:       sinkEdited.State.String(),
+			Error:       sinkEdited.Error,
+			Backend:     sinkEdited.Backend,
+			Type:        sinkEdited.Type,
+			Config:      omittedSink.Config,
+			ConfigData:  omittedSink.ConfigData,
+			Format:      sinkEdited.Format,
+			TsCreated:   sinkEdited.Created,
+			created:     false,
 
+			CredentialsUpdatedAt: sinkEdited.CredentialsUpdatedAt,
+		}
 		return res, nil
 	}
 }
@@ -305,10 +509,13 @@ func listSinksEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 				State:      sink.State.String(),
 				Error:      sink.Error,
 				Backend:    sink.Backend,
+				Type:       sink.Type,
 				Config:     responseSink.Config,
 				ConfigData: responseSink.ConfigData,
 				Format:     sink.Format,
 				TsCreated:  sink.Created,
+
+				CredentialsUpdatedAt: sink.CredentialsUpdatedAt,
 			}
 			if sink.Description != nil {
 				view.Description = *sink.Description
@@ -376,11 +583,18 @@ func listBackendsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			if err != nil {
 				return nil, err
 			}
+			if req.signal != "" && !backend.SupportsSignal(b, req.signal) {
+				continue
+			}
+			if req.maturity != "" && b.Maturity() != req.maturity {
+				continue
+			}
 			completeBackends = append(completeBackends, b.Metadata())
 		}
 
 		res := sinksBackendsRes{
-			Backends: completeBackends,
+			Backends:    completeBackends,
+			NamePattern: sinks.NamePattern(),
 		}
 
 		return res, nil
@@ -430,10 +644,13 @@ func viewSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			State:       sink.State.String(),
 			Error:       sink.Error,
 			Backend:     sink.Backend,
+			Type:        sink.Type,
 			Config:      responseSink.Config,
 			ConfigData:  responseSink.ConfigData,
 			Format:      sink.Format,
 			TsCreated:   sink.Created,
+
+			CredentialsUpdatedAt: sink.CredentialsUpdatedAt,
 		}
 		if sink.Description != nil {
 			res.Description = *sink.Description
@@ -443,6 +660,281 @@ func viewSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 	}
 }
 
+func countSinksEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(countSinksReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		counts, err := svc.CountSinks(ctx, req.token, req.tags)
+		if err != nil {
+			return nil, err
+		}
+
+		return sinksCountRes{
+			Total:     counts.Total,
+			ByState:   counts.ByState,
+			ByBackend: counts.ByBackend,
+		}, nil
+	}
+}
+
+func resyncSinksEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(resyncReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sks, err := svc.ResyncSinks(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+		return resyncRes{Sinks: len(sks)}, nil
+	}
+}
+
+func setOwnerDefaultTagsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ownerDefaultTagsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tags, err := svc.SetOwnerDefaultTags(ctx, req.token, req.ownerID, req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		return ownerDefaultTagsRes{OwnerID: req.ownerID, Tags: tags}, nil
+	}
+}
+
+func revalidateBackendSinksEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		report, err := svc.RevalidateBackendSinks(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+		return revalidationRes{Backend: report.Backend, Checked: report.Checked, Failures: report.Failures}, nil
+	}
+}
+
+func viewOwnerDefaultTagsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ownerDefaultTagsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tags, err := svc.ViewOwnerDefaultTags(ctx, req.token, req.ownerID)
+		if err != nil {
+			return nil, err
+		}
+		return ownerDefaultTagsRes{OwnerID: req.ownerID, Tags: tags}, nil
+	}
+}
+
+func saveSinkTemplateEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(sinkTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		// already validated along with the request
+		nID, _ := types.NewIdentifier(req.name)
+		saved, err := svc.SaveSinkTemplate(ctx, req.token, sinks.SinkTemplate{
+			Name:        nID,
+			Description: req.Description,
+			Backend:     req.Backend,
+			Type:        req.Type,
+			Config:      req.Config,
+			Tags:        req.Tags,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return toSinkTemplateRes(saved), nil
+	}
+}
+
+func listSinkTemplatesEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(listSinkTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		templates, err := svc.ListSinkTemplates(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+		res := sinkTemplatesRes{Templates: make([]sinkTemplateRes, 0, len(templates))}
+		for _, template := range templates {
+			res.Templates = append(res.Templates, toSinkTemplateRes(template))
+		}
+		return res, nil
+	}
+}
+
+func removeSinkTemplateEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveSinkTemplate(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return removeRes{}, nil
+	}
+}
+
+func createSinkFromTemplateEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(createFromTemplateReq)
+		if err := req.validate(); err != nil {
+			svc.GetLogger().Error("error validating request", zap.Error(err))
+			return nil, err
+		}
+
+		// already validated along with the request
+		nID, _ := types.NewIdentifier(req.Name)
+		saved, err := svc.CreateSinkFromTemplate(ctx, req.token, req.template, sinks.Sink{
+			Name:        nID,
+			Description: req.Description,
+			Config:      req.Config,
+			Tags:        req.Tags,
+		})
+		if err != nil {
+			svc.GetLogger().Error("error on creating sink from template", zap.String("template", req.template), zap.Error(err))
+			return nil, err
+		}
+
+		authType, _ := authentication_type.GetAuthType(saved.GetAuthenticationTypeName())
+		configSvc := &sinks.Configuration{
+			Authentication: authType,
+			Exporter:       backend.GetBackend(saved.Backend),
+		}
+		omittedSink, err := omitSecretInformation(configSvc, saved)
+		if err != nil {
+			svc.GetLogger().Error("sink was created from template, but got error in the response build", zap.Error(err))
+			return nil, err
+		}
+
+		res := sinkRes{
+			ID:          saved.ID,
+			Name:        saved.Name.String(),
+			Description: *saved.Description,
+			Tags:        saved.Tags,
+			State:       saved.State.String(),
+			Error:       saved.Error,
+			Backend:     saved.Backend,
+			Type:        saved.Type,
+			Config:      omittedSink.Config,
+			ConfigData:  omittedSink.ConfigData,
+			Format:      saved.Format,
+			TsCreated:   saved.Created,
+			created:     true,
+
+			CredentialsUpdatedAt: saved.CredentialsUpdatedAt,
+		}
+		return res, nil
+	}
+}
+
+func toSinkTemplateRes(template sinks.SinkTemplate) sinkTemplateRes {
+	res := sinkTemplateRes{
+		Name:        template.Name.String(),
+		Description: template.Description,
+		Backend:     template.Backend,
+		Type:        template.Type,
+		Config:      template.Config,
+		Tags:        template.Tags,
+		TsCreated:   template.Created,
+		TsUpdated:   template.Updated,
+	}
+	if res.Config == nil {
+		res.Config = types.Metadata{}
+	}
+	if res.Tags == nil {
+		res.Tags = types.Tags{}
+	}
+	return res
+}
+
+func readinessEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (response interface{}, err error) {
+		failing := svc.CheckReadiness(ctx)
+		if len(failing) > 0 {
+			return readinessRes{Status: "unavailable", Failing: failing}, nil
+		}
+		return readinessRes{Status: "ok"}, nil
+	}
+}
+
+func listSinkStateEventsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		events, err := svc.ListSinkStateEvents(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := sinkStateEventsRes{Events: []sinkStateEventRes{}}
+		for _, event := range events {
+			res.Events = append(res.Events, sinkStateEventRes{
+				Timestamp: event.Timestamp,
+				OldState:  event.OldState.String(),
+				NewState:  event.NewState.String(),
+				Message:   event.Message,
+			})
+		}
+		return res, nil
+	}
+}
+
+func diffSinkRevisionsEndpoint(svc sinks.SinkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(diffRevisionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		diff, err := svc.DiffSinkRevisions(ctx, req.token, req.id, req.from, req.to)
+		if err != nil {
+			return nil, err
+		}
+
+		res := configDiffRes{
+			SinkID:  diff.SinkID,
+			From:    configRevisionRes{Revision: diff.From.Revision, Timestamp: diff.From.Timestamp},
+			To:      configRevisionRes{Revision: diff.To.Revision, Timestamp: diff.To.Timestamp},
+			Changes: []configChangeRes{},
+		}
+		for _, change := range diff.Changes {
+			res.Changes = append(res.Changes, configChangeRes{
+				Field: change.Field,
+				Op:    change.Op,
+				From:  change.From,
+				To:    change.To,
+			})
+		}
+		return res, nil
+	}
+}
+
 func deleteSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(deleteSinkReq)
@@ -452,7 +944,7 @@ func deleteSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.DeleteSink(ctx, req.token, req.id); err != nil {
+		if err := svc.DeleteSink(ctx, req.token, req.id, req.force); err != nil {
 			return nil, err
 		}
 
@@ -475,6 +967,7 @@ func validateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 		sink := sinks.Sink{
 			Name:        nID,
 			Backend:     req.Backend,
+			Type:        req.Type,
 			Config:      req.Config,
 			Description: &req.Description,
 			Tags:        req.Tags,
@@ -493,6 +986,7 @@ func validateSinkEndpoint(svc sinks.SinkService) endpoint.Endpoint {
 			State:       validated.State.String(),
 			Error:       validated.Error,
 			Backend:     validated.Backend,
+			Type:        validated.Type,
 			Config:      validated.Config,
 		}
 
@@ -9,26 +9,35 @@
 package http
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/gofrs/uuid"
+	"github.com/mainflux/mainflux"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/orb-community/orb/pkg/config"
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/backend"
 	skmocks "github.com/orb-community/orb/sinks/mocks"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -108,6 +117,11 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService(tokens map[string]string) sinks.SinkService {
+	svc, _ := newServiceWithRepository(tokens)
+	return svc
+}
+
+func newServiceWithRepository(tokens map[string]string) (sinks.SinkService, sinks.SinkRepository) {
 	logger := zap.NewNop()
 	auth := skmocks.NewAuthService(tokens)
 	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
@@ -119,11 +133,11 @@ func newService(tokens map[string]string) sinks.SinkService {
 
 	sdk := mfsdk.NewSDK(config)
 
-	return sinks.NewSinkService(logger, auth, sinkRepo, sdk, pwdSvc)
+	return sinks.NewSinkService(logger, auth, sinkRepo, sdk, pwdSvc, nil, sinks.TagLimits{}, nil, nil), sinkRepo
 }
 
 func newServer(svc sinks.SinkService) *httptest.Server {
-	mux := MakeHandler(mocktracer.New(), "sinks", svc)
+	mux := MakeHandler(mocktracer.New(), "sinks", svc, nil, nil)
 	return httptest.NewServer(mux)
 }
 
@@ -276,6 +290,24 @@ func TestCreateSinks(t *testing.T) {
 		},
 	})
 
+	jsonInvalidRelabelRegex := toJSON(addReq{
+		Name:    "sinkRelabel",
+		Backend: "prometheus",
+		Config: types.Metadata{
+			"exporter": types.Metadata{
+				"remote_host": "https://orb.community/",
+				"relabel": []interface{}{
+					map[string]interface{}{"action": "drop", "source_labels": []interface{}{"job"}, "regex": "(unclosed"},
+				},
+			},
+			"authentication": types.Metadata{
+				"type":     "basicauth",
+				"username": "test",
+				"password": "test",
+			},
+		},
+	})
+
 	cases := map[string]struct {
 		req         string
 		contentType string
@@ -311,6 +343,13 @@ func TestCreateSinks(t *testing.T) {
 			status:      http.StatusBadRequest,
 			location:    "/sinks",
 		},
+		"add sink with an invalid relabel regex": {
+			req:         jsonInvalidRelabelRegex,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "/sinks",
+		},
 		"add a sink with a invalid token": {
 			req:         validJson,
 			contentType: contentType,
@@ -394,6 +433,518 @@ func TestCreateSinks(t *testing.T) {
 
 }
 
+func TestCreateSinkOnBehalfOf(t *testing.T) {
+	adminToken := "admin-token"
+	adminEmail := "admin@example.com"
+	ownerToken := "owner-token"
+	ownerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthServiceWithAdmins(map[string]string{token: email, adminToken: adminEmail, ownerToken: ownerID.String()}, []string{adminEmail})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"})
+	service := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), sdk, pwdSvc, nil, sinks.TagLimits{}, nil, nil)
+	server := newServer(service)
+	defer server.Close()
+
+	cases := map[string]struct {
+		auth       string
+		onBehalfOf string
+		status     int
+	}{
+		"create sink on behalf of an owner with admin token": {
+			auth:       adminToken,
+			onBehalfOf: ownerID.String(),
+			status:     http.StatusCreated,
+		},
+		"create sink on behalf of an owner with non-admin token": {
+			auth:       token,
+			onBehalfOf: ownerID.String(),
+			status:     http.StatusForbidden,
+		},
+		"create sink on behalf of an invalid owner id": {
+			auth:       adminToken,
+			onBehalfOf: "not-an-id",
+			status:     http.StatusBadRequest,
+		},
+		"create sink on behalf of an owner with invalid token": {
+			auth:       invalidToken,
+			onBehalfOf: ownerID.String(),
+			status:     http.StatusUnauthorized,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sinks", server.URL), strings.NewReader(validJson))
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.auth))
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set(onBehalfOfHeader, tc.onBehalfOf)
+			res, err := server.Client().Do(req)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+
+	page, err := service.ListSinks(context.Background(), ownerToken, sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Sinks, 1, "sink should be created under the owner")
+	assert.Equal(t, ownerID.String(), page.Sinks[0].MFOwnerID)
+
+	page, err = service.ListSinks(context.Background(), adminToken, sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, page.Sinks, 0, "sink should not be created under the admin")
+}
+
+func TestResyncSinks(t *testing.T) {
+	adminToken := "admin-token"
+	adminEmail := "admin@example.com"
+
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthServiceWithAdmins(map[string]string{token: email, adminToken: adminEmail}, []string{adminEmail})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"})
+	service := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), sdk, pwdSvc, nil, sinks.TagLimits{}, nil, nil)
+	server := newServer(service)
+	defer server.Close()
+
+	for _, name := range []string{"resync-sink-1", "resync-sink-2"} {
+		nameID, _ := types.NewIdentifier(name)
+		sink := sinks.Sink{
+			Name:    nameID,
+			Backend: "prometheus",
+			Config: map[string]interface{}{
+				"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+				"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+			},
+		}
+		_, err := service.CreateSink(context.Background(), token, sink)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	// cases run in order, the second admin resync falls within the resync interval
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "resync sinks with non-admin token",
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "resync sinks with invalid token",
+			auth:   invalidToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "resync sinks with admin token",
+			auth:   adminToken,
+			status: http.StatusAccepted,
+			res:    toJSON(resyncRes{Sinks: 2}),
+		},
+		{
+			desc:   "resync sinks again within the resync interval",
+			auth:   adminToken,
+			status: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: server.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/sinks/resync", server.URL),
+				token:  fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.res != "" {
+				body, err := io.ReadAll(res.Body)
+				require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+				assert.Equal(t, tc.res, strings.TrimSpace(string(body)))
+			}
+		})
+	}
+}
+
+func TestOwnerDefaultTags(t *testing.T) {
+	adminToken := "admin-token"
+	adminEmail := "admin@example.com"
+	ownerToken := "owner-token"
+	ownerID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthServiceWithAdmins(map[string]string{token: email, adminToken: adminEmail, ownerToken: ownerID.String()}, []string{adminEmail})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"})
+	service := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), sdk, pwdSvc, nil, sinks.TagLimits{}, nil, nil)
+	server := newServer(service)
+	defer server.Close()
+
+	defaults := types.Tags{"managed_by": "orb", "cost_center": "cc-42", "cloud": "gcp"}
+	url := fmt.Sprintf("%s/sinks/owners/%s/default-tags", server.URL, ownerID.String())
+
+	// cases run in order, the defaults are read back once set
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		auth   string
+		body   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "set owner default tags with non-admin token",
+			method: http.MethodPut,
+			url:    url,
+			auth:   token,
+			body:   toJSON(map[string]interface{}{"tags": defaults}),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "set owner default tags with invalid token",
+			method: http.MethodPut,
+			url:    url,
+			auth:   invalidToken,
+			body:   toJSON(map[string]interface{}{"tags": defaults}),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "set default tags of an invalid owner id",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("%s/sinks/owners/not-an-id/default-tags", server.URL),
+			auth:   adminToken,
+			body:   toJSON(map[string]interface{}{"tags": defaults}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "view owner default tags before they are set",
+			method: http.MethodGet,
+			url:    url,
+			auth:   adminToken,
+			status: http.StatusOK,
+			res:    toJSON(ownerDefaultTagsRes{OwnerID: ownerID.String(), Tags: types.Tags{}}),
+		},
+		{
+			desc:   "set owner default tags with admin token",
+			method: http.MethodPut,
+			url:    url,
+			auth:   adminToken,
+			body:   toJSON(map[string]interface{}{"tags": defaults}),
+			status: http.StatusOK,
+			res:    toJSON(ownerDefaultTagsRes{OwnerID: ownerID.String(), Tags: defaults}),
+		},
+		{
+			desc:   "view owner default tags with non-admin token",
+			method: http.MethodGet,
+			url:    url,
+			auth:   ownerToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view owner default tags with admin token",
+			method: http.MethodGet,
+			url:    url,
+			auth:   adminToken,
+			status: http.StatusOK,
+			res:    toJSON(ownerDefaultTagsRes{OwnerID: ownerID.String(), Tags: defaults}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      tc.method,
+				url:         tc.url,
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.body),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.res != "" {
+				body, err := io.ReadAll(res.Body)
+				require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+				assert.Equal(t, tc.res, strings.TrimSpace(string(body)))
+			}
+		})
+	}
+
+	// sinks created on behalf of the owner carry the defaults, the sink tags win on conflict
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sinks", server.URL), strings.NewReader(validJson))
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", adminToken))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(onBehalfOfHeader, ownerID.String())
+	res, err := server.Client().Do(req)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	page, err := service.ListSinks(context.Background(), ownerToken, sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Sinks, 1)
+	assert.Equal(t, types.Tags{"managed_by": "orb", "cost_center": "cc-42", "cloud": "aws"}, page.Sinks[0].Tags)
+}
+
+func TestSinkTemplates(t *testing.T) {
+	adminToken := "admin-token"
+	adminEmail := "admin@example.com"
+
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthServiceWithAdmins(map[string]string{token: email, adminToken: adminEmail}, []string{adminEmail})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"})
+	service := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), sdk, pwdSvc, nil, sinks.TagLimits{}, nil, nil)
+	server := newServer(service)
+	defer server.Close()
+
+	template := toJSON(map[string]interface{}{
+		"description": "Standard internal Prometheus",
+		"backend":     "prometheus",
+		"config": map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://prometheus.internal/api/v1/write"},
+			"authentication": map[string]interface{}{"type": "basicauth"},
+		},
+		"tags": map[string]string{"team": "platform"},
+	})
+	fromTemplate := toJSON(map[string]interface{}{
+		"name":   "my-prom-sink",
+		"config": map[string]interface{}{"authentication": map[string]interface{}{"username": "dbuser", "password": "dbpass"}},
+		"tags":   map[string]string{"cloud": "aws"},
+	})
+	templateURL := fmt.Sprintf("%s/sinks/templates/internal-prometheus", server.URL)
+	fromTemplateURL := fmt.Sprintf("%s/sinks/from-template/internal-prometheus", server.URL)
+
+	// cases run in order, the sinks are created from the template saved first
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		auth   string
+		body   string
+		status int
+	}{
+		{
+			desc:   "save a template with a non-admin token",
+			method: http.MethodPut,
+			url:    templateURL,
+			auth:   token,
+			body:   template,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "save a template with credentials",
+			method: http.MethodPut,
+			url:    templateURL,
+			auth:   adminToken,
+			body:   toJSON(map[string]interface{}{"backend": "prometheus", "config": map[string]interface{}{"authentication": map[string]interface{}{"type": "basicauth", "password": "dbpass"}}}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "save a template with an invalid name",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("%s/sinks/templates/%s", server.URL, strings.Repeat("a", 1025)),
+			auth:   adminToken,
+			body:   template,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "save a template with an admin token",
+			method: http.MethodPut,
+			url:    templateURL,
+			auth:   adminToken,
+			body:   template,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list the templates",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/sinks/templates", server.URL),
+			auth:   token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "create a sink from a template",
+			method: http.MethodPost,
+			url:    fromTemplateURL,
+			auth:   token,
+			body:   fromTemplate,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "create a sink from a template with the name of an existing sink",
+			method: http.MethodPost,
+			url:    fromTemplateURL,
+			auth:   token,
+			body:   fromTemplate,
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "create a sink from a template without a name",
+			method: http.MethodPost,
+			url:    fromTemplateURL,
+			auth:   token,
+			body:   toJSON(map[string]interface{}{"tags": map[string]string{"cloud": "aws"}}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create a sink from a template with an invalid token",
+			method: http.MethodPost,
+			url:    fromTemplateURL,
+			auth:   invalidToken,
+			body:   fromTemplate,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove a template with a non-admin token",
+			method: http.MethodDelete,
+			url:    templateURL,
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove a template with an admin token",
+			method: http.MethodDelete,
+			url:    templateURL,
+			auth:   adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "create a sink from a removed template",
+			method: http.MethodPost,
+			url:    fromTemplateURL,
+			auth:   token,
+			body:   toJSON(map[string]interface{}{"name": "my-other-sink"}),
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      tc.method,
+				url:         tc.url,
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.body),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		})
+	}
+
+	page, err := service.ListSinks(context.Background(), token, sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Sinks, 1)
+	assert.Equal(t, types.Tags{"team": "platform", "cloud": "aws"}, page.Sinks[0].Tags)
+	assert.Equal(t, "https://prometheus.internal/api/v1/write", page.Sinks[0].Config.GetSubMetadata("exporter")["remote_host"])
+}
+
+func TestCreateSinkValidateOnly(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+
+	// the remote listens on the loopback, which the probe refuses by default
+	backend.SetProbeAllowPrivate(true)
+	defer backend.SetProbeAllowPrivate(false)
+
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "dbuser" || password != "dbpass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer remote.Close()
+
+	sinkReq := func(password string, validateOnly bool, deep bool) string {
+		return toJSON(addReq{
+			Name:    "validated-sink",
+			Backend: "prometheus",
+			Config: types.Metadata{
+				"exporter":       types.Metadata{"remote_host": remote.URL},
+				"authentication": types.Metadata{"type": "basicauth", "username": "dbuser", "password": password},
+			},
+			ValidateOnly: validateOnly,
+			Deep:         deep,
+		})
+	}
+
+	cases := map[string]struct {
+		req    string
+		status int
+		probe  *probeRes
+	}{
+		"validate sink without probe": {
+			req:    sinkReq("dbpass", true, false),
+			status: http.StatusOK,
+			probe:  nil,
+		},
+		"validate sink with probe accepted by the remote": {
+			req:    sinkReq("dbpass", true, true),
+			status: http.StatusOK,
+			probe:  &probeRes{Success: true},
+		},
+		"validate sink with probe rejected by the remote": {
+			req:    sinkReq("wrong", true, true),
+			status: http.StatusOK,
+			probe:  &probeRes{Success: false},
+		},
+		"probe sink without validate only": {
+			req:    sinkReq("dbpass", false, true),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/sinks", server.URL),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", token),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if tc.status != http.StatusOK {
+				return
+			}
+			var body validateSinkRes
+			err = json.NewDecoder(res.Body).Decode(&body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+			assert.Empty(t, body.ID, fmt.Sprintf("%s: expected sink not to be saved", desc))
+			if tc.probe == nil {
+				assert.Nil(t, body.Probe, fmt.Sprintf("%s: expected no probe result", desc))
+				return
+			}
+			require.NotNil(t, body.Probe, fmt.Sprintf("%s: expected a probe result", desc))
+			assert.Equal(t, tc.probe.Success, body.Probe.Success, fmt.Sprintf("%s: expected probe success %t got %t", desc, tc.probe.Success, body.Probe.Success))
+		})
+	}
+
+	page, err := service.ListSinks(context.Background(), token, sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, page.Sinks, "expected validated sinks not to be saved")
+}
+
 func TestUpdateSink(t *testing.T) {
 	service := newService(map[string]string{token: email})
 	server := newServer(service)
@@ -656,6 +1207,12 @@ func TestListSinks(t *testing.T) {
 
 		data = append(data, sk)
 	}
+	for _, sk := range data[:3] {
+		err := svc.ChangeSinkStateInternal(context.Background(), sk.ID, "remote write failed: 401 Unauthorized", sk.MFOwnerID, sinks.Error)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	err := svc.ChangeSinkStateInternal(context.Background(), data[3].ID, "401 unauthorized", data[3].MFOwnerID, sinks.Active)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	sinkURL := fmt.Sprintf("%s/sinks", server.URL)
 
@@ -809,6 +1366,54 @@ func TestListSinks(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d&tags={\"%s\":\"%s\"}", sinkURL, 0, 5, "test", "test"),
 			total:  0,
 		},
+		"get a list of sinks filtered by exact tag": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&tags={\"%s\":\"%s\"}", sinkURL, 0, 5, "cloud", "aws"),
+			total:  5,
+		},
+		"get a list of sinks filtered by tag prefix": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&tags={\"%s\":\"%s\"}", sinkURL, 0, 5, "cloud", "aw*"),
+			total:  5,
+		},
+		"get a list of sinks filtered by invalid tag selector": {
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&tags={\"%s\":\"%s\"}", sinkURL, 0, 5, "cloud", "a*s"),
+			total:  0,
+		},
+		"get a list of sinks with credentials older than 30 days": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&credentials_older_than=%s", sinkURL, 0, 5, "30d"),
+			total:  0,
+		},
+		"get a list of sinks with credentials older than a nanosecond": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&credentials_older_than=%s", sinkURL, 0, 5, "1ns"),
+			total:  5,
+		},
+		"get a list of sinks with invalid credentials age": {
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&credentials_older_than=%s", sinkURL, 0, 5, "month"),
+			total:  0,
+		},
+		"get a list of sinks in error with a error message substring": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&error_contains=%s", sinkURL, 0, 20, "UNAUTHORIZED"),
+			total:  3,
+		},
+		"get a list of sinks in error with a unknown error message substring": {
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&error_contains=%s", sinkURL, 0, 20, "timeout"),
+			total:  0,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -857,7 +1462,7 @@ func TestAuthenticationTypesEndpoints(t *testing.T) {
 				err = json.Unmarshal(body, &authResponse)
 				require.NoError(t, err, "must not error")
 				require.NotNil(t, authResponse, "response must not be nil")
-				require.Equal(t, 2, len(authResponse.AuthenticationTypes), "must contain basicauth and bearertokenauth")
+				require.Equal(t, 5, len(authResponse.AuthenticationTypes), "must contain basicauth, bearertokenauth, clientcert, serviceaccount and multiauth")
 			},
 		},
 		"view authentication type basicauth": {
@@ -1052,11 +1657,740 @@ func TestViewBackends(t *testing.T) {
 
 }
 
-func TestViewSink(t *testing.T) {
+func TestViewBackendsNamePattern(t *testing.T) {
 	service := newService(map[string]string{token: email})
 	server := newServer(service)
 	defer server.Close()
-	nameID, _ := types.NewIdentifier("my-sink")
+
+	require.Nil(t, sinks.SetNamePattern("^sre-[a-z0-9-]+$"))
+	defer sinks.SetNamePattern("")
+
+	req := testRequest{
+		client: server.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/features/sinks", server.URL),
+		token:  fmt.Sprintf("Bearer %s", token),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var response sinksBackendsRes
+	err = json.NewDecoder(res.Body).Decode(&response)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "^sre-[a-z0-9-]+$", response.NamePattern)
+
+	cases := map[string]struct {
+		name   string
+		status int
+	}{
+		"add sink matching the name pattern": {
+			name:   "sre-prom-sink",
+			status: http.StatusCreated,
+		},
+		"add sink not matching the name pattern": {
+			name:   "prom-sink",
+			status: http.StatusBadRequest,
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/sinks", server.URL),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", token),
+				body: strings.NewReader(toJSON(addReq{
+					Name:    tc.name,
+					Backend: "prometheus",
+					Config: types.Metadata{
+						"exporter":       types.Metadata{"remote_host": "https://orb.community/"},
+						"authentication": types.Metadata{"type": "basicauth", "username": "test", "password": "test"},
+					},
+				})),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		})
+	}
+}
+
+func TestViewBackendsBySignal(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+
+	cases := map[string]struct {
+		signal   string
+		status   int
+		backends []string
+	}{
+		"backends accepting metrics": {
+			signal:   "metrics",
+			status:   http.StatusOK,
+			backends: []string{"gcm", "otlphttp", "prometheus", "spool"},
+		},
+		"backends accepting traces": {
+			signal:   "traces",
+			status:   http.StatusOK,
+			backends: []string{"otlphttp", "spool"},
+		},
+		"backends accepting an unknown signal": {
+			signal: "profiles",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: server.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/features/sinks?signal=%s", server.URL, tc.signal),
+				token:  fmt.Sprintf("Bearer %s", token),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if res.StatusCode != http.StatusOK {
+				return
+			}
+			var response sinksBackendsRes
+			err = json.NewDecoder(res.Body).Decode(&response)
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			var names []string
+			for _, backendObj := range response.Backends {
+				names = append(names, backendObj.(map[string]interface{})["backend"].(string))
+			}
+			assert.ElementsMatch(t, tc.backends, names, fmt.Sprintf("%s: unexpected backends", desc))
+		})
+	}
+}
+
+func TestViewBackendsByMaturity(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+
+	cases := map[string]struct {
+		query    string
+		status   int
+		backends map[string]string
+	}{
+		"all backends with their maturity": {
+			status:   http.StatusOK,
+			backends: map[string]string{"gcm": "experimental", "otlphttp": "stable", "prometheus": "stable", "spool": "experimental"},
+		},
+		"stable backends": {
+			query:    "?maturity=stable",
+			status:   http.StatusOK,
+			backends: map[string]string{"otlphttp": "stable", "prometheus": "stable"},
+		},
+		"experimental backends accepting metrics": {
+			query:    "?maturity=experimental&signal=metrics",
+			status:   http.StatusOK,
+			backends: map[string]string{"gcm": "experimental", "spool": "experimental"},
+		},
+		"backends of an unknown maturity": {
+			query:  "?maturity=alpha",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client: server.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/features/sinks%s", server.URL, tc.query),
+				token:  fmt.Sprintf("Bearer %s", token),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if res.StatusCode != http.StatusOK {
+				return
+			}
+			var response sinksBackendsRes
+			err = json.NewDecoder(res.Body).Decode(&response)
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			backends := make(map[string]string)
+			for _, backendObj := range response.Backends {
+				b := backendObj.(map[string]interface{})
+				backends[b["backend"].(string)] = b["maturity"].(string)
+			}
+			assert.Equal(t, tc.backends, backends, fmt.Sprintf("%s: unexpected backends", desc))
+		})
+	}
+}
+
+func TestViewSink(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+	nameID, _ := types.NewIdentifier("my-sink")
+	description := "An example prometheus sink"
+	sink := sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		Backend:     "prometheus",
+		Config: map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+		Tags: map[string]string{"cloud": "aws"},
+	}
+	sk, err := service.CreateSink(context.Background(), token, sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	sinkBE := backend.GetBackend("prometheus")
+	sinkAuthType, _ := authentication_type.GetAuthType("basicauth")
+	cfg := sinks.Configuration{
+		Exporter:       sinkBE,
+		Authentication: sinkAuthType,
+	}
+	omittedSink, _ := omitSecretInformation(&cfg, sk)
+	require.NoError(t, err, "error during omitting secrets")
+	data := toJSON(sinkRes{
+		ID:          sk.ID,
+		Name:        sk.Name.String(),
+		Description: *sk.Description,
+		Backend:     sk.Backend,
+		Type:        sk.Type,
+		Config:      omittedSink.Config,
+		ConfigData:  omittedSink.ConfigData,
+		Format:      "json",
+		Tags:        sk.Tags,
+		State:       sk.State.String(),
+		Error:       sk.Error,
+		TsCreated:   sk.Created,
+
+		CredentialsUpdatedAt: sk.CredentialsUpdatedAt,
+	})
+
+	cases := map[string]struct {
+		id          string
+		contentType string
+		auth        string
+		status      int
+		res         string
+	}{
+		"view existing sink": {
+			id:          sk.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+			res:         data,
+		},
+		"view non-existing sink": {
+			id:          "logstash",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+			res:         notFoundRes,
+		},
+		"view backend by passing invalid token": {
+			id:          sink.ID,
+			contentType: contentType,
+			auth:        "blah",
+			status:      http.StatusUnauthorized,
+			res:         unauthRes,
+		},
+		"view backend by passing empty token": {
+			id:          sink.ID,
+			contentType: contentType,
+			auth:        "",
+			status:      http.StatusUnauthorized,
+			res:         unauthRes,
+		},
+		"view backend by passing invalid id": {
+			id:          "invalid",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+			res:         notFoundRes,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodGet,
+				contentType: tc.contentType,
+				url:         fmt.Sprintf("%s/sinks/%s", server.URL, tc.id),
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			data := strings.Trim(string(body), "\n")
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", desc, tc.res, data))
+		})
+	}
+
+}
+
+func TestViewSinkWithUnavailableBackend(t *testing.T) {
+	svc, sinkRepo := newServiceWithRepository(map[string]string{token: email})
+	server := newServer(svc)
+	defer server.Close()
+
+	// saved directly on the repository, as the backend was removed after the sink was created
+	nameID, _ := types.NewIdentifier("my-removed-backend-sink")
+	description := "A sink of a removed backend"
+	id, err := sinkRepo.Save(context.Background(), sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		MFOwnerID:   email,
+		Backend:     "removed-backend",
+		Config: types.Metadata{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	req := testRequest{
+		client: server.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/sinks/%s", server.URL, id),
+		token:  fmt.Sprintf("Bearer %s", token),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	var body types.ErrorRes
+	err = json.NewDecoder(res.Body).Decode(&body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, sinks.ErrBackendUnavailable.Error(), body.Err)
+}
+
+func TestListSinkStateEvents(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+	nameID, _ := types.NewIdentifier("my-sink")
+	description := "An example prometheus sink"
+	sink := sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		Backend:     "prometheus",
+		Config: map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+		Tags: map[string]string{"cloud": "aws"},
+	}
+	sk, err := service.CreateSink(context.Background(), token, sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = service.ChangeSinkStateInternal(context.Background(), sk.ID, "", sk.MFOwnerID, sinks.Active)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = service.ChangeSinkStateInternal(context.Background(), sk.ID, "failed to export metrics", sk.MFOwnerID, sinks.Error)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	events, err := service.ListSinkStateEvents(context.Background(), token, sk.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	data := toJSON(sinkStateEventsRes{
+		Events: []sinkStateEventRes{
+			{
+				Timestamp: events[0].Timestamp,
+				OldState:  sinks.Active.String(),
+				NewState:  sinks.Error.String(),
+				Message:   "failed to export metrics",
+			},
+			{
+				Timestamp: events[1].Timestamp,
+				OldState:  sinks.Unknown.String(),
+				NewState:  sinks.Active.String(),
+			},
+		},
+	})
+
+	cases := map[string]struct {
+		id     string
+		auth   string
+		status int
+		res    string
+	}{
+		"list events of existing sink": {
+			id:     sk.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    data,
+		},
+		"list events of non-existing sink": {
+			id:     "logstash",
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		"list events by passing invalid token": {
+			id:     sk.ID,
+			auth:   "blah",
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+		"list events by passing empty token": {
+			id:     sk.ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodGet,
+				contentType: contentType,
+				url:         fmt.Sprintf("%s/sinks/%s/events", server.URL, tc.id),
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			data := strings.Trim(string(body), "\n")
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", desc, tc.res, data))
+		})
+	}
+}
+
+func TestCountSinks(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+	description := "An example prometheus sink"
+	for i, tags := range []types.Tags{{"cloud": "aws"}, {"cloud": "gcp"}, {"cloud": "aws", "region": "eu"}} {
+		nameID, _ := types.NewIdentifier(fmt.Sprintf("my-sink-%d", i))
+		sk, err := service.CreateSink(context.Background(), token, sinks.Sink{
+			Name:        nameID,
+			Description: &description,
+			Backend:     "prometheus",
+			Config: map[string]interface{}{
+				"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+				"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+			},
+			Tags: tags,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if i == 0 {
+			err = service.ChangeSinkStateInternal(context.Background(), sk.ID, "", sk.MFOwnerID, sinks.Active)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}
+	}
+
+	cases := map[string]struct {
+		auth   string
+		query  string
+		status int
+		res    string
+	}{
+		"count all sinks": {
+			auth:   token,
+			status: http.StatusOK,
+			res: toJSON(sinksCountRes{
+				Total:     3,
+				ByState:   map[string]uint64{sinks.Active.String(): 1, sinks.Unknown.String(): 2},
+				ByBackend: map[string]uint64{"prometheus": 3},
+			}),
+		},
+		"count sinks filtered by tags": {
+			auth:   token,
+			query:  fmt.Sprintf("?tags=%s", url.QueryEscape(`{"cloud":"aws"}`)),
+			status: http.StatusOK,
+			res: toJSON(sinksCountRes{
+				Total:     2,
+				ByState:   map[string]uint64{sinks.Active.String(): 1, sinks.Unknown.String(): 1},
+				ByBackend: map[string]uint64{"prometheus": 2},
+			}),
+		},
+		"count sinks filtered by tags without matches": {
+			auth:   token,
+			query:  fmt.Sprintf("?tags=%s", url.QueryEscape(`{"cloud":"azure"}`)),
+			status: http.StatusOK,
+			res: toJSON(sinksCountRes{
+				ByState:   map[string]uint64{},
+				ByBackend: map[string]uint64{},
+			}),
+		},
+		"count sinks with invalid tags": {
+			auth:   token,
+			query:  "?tags=invalid",
+			status: http.StatusBadRequest,
+		},
+		"count sinks by passing invalid token": {
+			auth:   "blah",
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+		"count sinks by passing empty token": {
+			auth:   "",
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodGet,
+				contentType: contentType,
+				url:         fmt.Sprintf("%s/sinks/count%s", server.URL, tc.query),
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if tc.res == "" {
+				return
+			}
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			data := strings.Trim(string(body), "\n")
+			assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", desc, tc.res, data))
+		})
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthService(map[string]string{token: email})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"})
+	misconfigured := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), sdk, pwdSvc, []string{"prometheus", "unregistered"}, sinks.TagLimits{}, nil, nil)
+
+	cases := map[string]struct {
+		svc    sinks.SinkService
+		status int
+		res    string
+	}{
+		"ready service": {
+			svc:    newService(map[string]string{token: email}),
+			status: http.StatusOK,
+			res:    toJSON(readinessRes{Status: "ok"}),
+		},
+		"service with a listed backend that does not resolve": {
+			svc:    misconfigured,
+			status: http.StatusServiceUnavailable,
+			res: toJSON(readinessRes{
+				Status:  "unavailable",
+				Failing: map[string]string{"backend:unregistered": "backend is listed but does not resolve"},
+			}),
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			server := newServer(tc.svc)
+			defer server.Close()
+			req := testRequest{
+				client: server.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/healthz", server.URL),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			data := strings.Trim(string(body), "\n")
+			assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", desc, tc.res, data))
+		})
+	}
+}
+
+func TestRotateSinkCredentials(t *testing.T) {
+	nameID, _ := types.NewIdentifier("my-sink")
+	description := "An example prometheus sink"
+	sink := sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		Backend:     "prometheus",
+		Config: map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+		Tags: map[string]string{"cloud": "aws"},
+	}
+	svc := newService(map[string]string{token: email})
+	server := newServer(svc)
+	defer server.Close()
+	sk, err := svc.CreateSink(context.Background(), token, sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id          string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		"rotate credentials of existing sink": {
+			id:          sk.ID,
+			req:         `{"authentication": {"password": "newpass"}}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		"rotate credentials of non-existent sink": {
+			id:          wrongID.String(),
+			req:         `{"authentication": {"password": "newpass"}}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		"rotate credentials with invalid token": {
+			id:          sk.ID,
+			req:         `{"authentication": {"password": "newpass"}}`,
+			contentType: contentType,
+			auth:        invalidToken,
+			status:      http.StatusUnauthorized,
+		},
+		"rotate credentials without authentication": {
+			id:          sk.ID,
+			req:         `{}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		"rotate credentials with invalid content type": {
+			id:          sk.ID,
+			req:         `{"authentication": {"password": "newpass"}}`,
+			contentType: "application/xml",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/sinks/%s/credentials", server.URL, tc.id),
+				contentType: tc.contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if res.StatusCode == http.StatusOK {
+				var body sinkRes
+				err = json.NewDecoder(res.Body).Decode(&body)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+				auth := body.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+				assert.Equal(t, "", auth["password"], fmt.Sprintf("%s: password should be omitted", desc))
+				assert.Equal(t, "dbuser", auth["username"], fmt.Sprintf("%s: username should be kept", desc))
+			}
+		})
+	}
+}
+
+func TestPatchSink(t *testing.T) {
+	nameID, _ := types.NewIdentifier("my-sink")
+	description := "An example prometheus sink"
+	sink := sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		Backend:     "prometheus",
+		Config: map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+		Tags: map[string]string{"cloud": "aws"},
+	}
+	svc := newService(map[string]string{token: email})
+	server := newServer(svc)
+	defer server.Close()
+	sk, err := svc.CreateSink(context.Background(), token, sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id          string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		"patch description of existing sink": {
+			id:          sk.ID,
+			req:         `{"description": "A fixed description"}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		"patch non-existent sink": {
+			id:          wrongID.String(),
+			req:         `{"description": "A fixed description"}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		"patch sink with invalid token": {
+			id:          sk.ID,
+			req:         `{"description": "A fixed description"}`,
+			contentType: contentType,
+			auth:        invalidToken,
+			status:      http.StatusUnauthorized,
+		},
+		"patch sink without fields": {
+			id:          sk.ID,
+			req:         `{}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		"patch sink with invalid name": {
+			id:          sk.ID,
+			req:         `{"name": "my sink!"}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		"patch sink with invalid content type": {
+			id:          sk.ID,
+			req:         `{"description": "A fixed description"}`,
+			contentType: "application/xml",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPatch,
+				url:         fmt.Sprintf("%s/sinks/%s", server.URL, tc.id),
+				contentType: tc.contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if res.StatusCode == http.StatusOK {
+				var body sinkRes
+				err = json.NewDecoder(res.Body).Decode(&body)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+				assert.Equal(t, "A fixed description", body.Description, fmt.Sprintf("%s: unexpected description", desc))
+				assert.Equal(t, "my-sink", body.Name, fmt.Sprintf("%s: name should be kept", desc))
+				auth := body.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+				assert.Equal(t, "", auth["password"], fmt.Sprintf("%s: password should be omitted", desc))
+				assert.Equal(t, "dbuser", auth["username"], fmt.Sprintf("%s: username should be kept", desc))
+			}
+		})
+	}
+}
+
+func TestCloneSink(t *testing.T) {
+	nameID, _ := types.NewIdentifier("my-sink")
 	description := "An example prometheus sink"
 	sink := sinks.Sink{
 		Name:        nameID,
@@ -1068,93 +2402,89 @@ func TestViewSink(t *testing.T) {
 		},
 		Tags: map[string]string{"cloud": "aws"},
 	}
-	sk, err := service.CreateSink(context.Background(), token, sink)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	sinkBE := backend.GetBackend("prometheus")
-	sinkAuthType, _ := authentication_type.GetAuthType("basicauth")
-	cfg := sinks.Configuration{
-		Exporter:       sinkBE,
-		Authentication: sinkAuthType,
-	}
-	omittedSink, _ := omitSecretInformation(&cfg, sink)
-	require.NoError(t, err, "error during omitting secrets")
-	data := toJSON(sinkRes{
-		ID:          sk.ID,
-		Name:        sk.Name.String(),
-		Description: *sk.Description,
-		Backend:     sk.Backend,
-		Config:      omittedSink.Config,
-		ConfigData:  omittedSink.ConfigData,
-		Format:      "json",
-		Tags:        sk.Tags,
-		State:       sk.State.String(),
-		Error:       sk.Error,
-		TsCreated:   sk.Created,
-	})
+	svc := newService(map[string]string{token: email})
+	server := newServer(svc)
+	defer server.Close()
+	sk, err := svc.CreateSink(context.Background(), token, sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		id          string
+		req         string
 		contentType string
 		auth        string
 		status      int
-		res         string
 	}{
-		"view existing sink": {
+		"clone existing sink": {
 			id:          sk.ID,
+			req:         `{"name": "my-cloned-sink", "tags": {"cloud": "gcp"}}`,
 			contentType: contentType,
 			auth:        token,
-			status:      http.StatusOK,
-			res:         data,
+			status:      http.StatusCreated,
 		},
-		"view non-existing sink": {
-			id:          "logstash",
+		"clone sink with conflicting name": {
+			id:          sk.ID,
+			req:         `{"name": "my-sink"}`,
 			contentType: contentType,
 			auth:        token,
-			status:      http.StatusNotFound,
-			res:         notFoundRes,
+			status:      http.StatusConflict,
 		},
-		"view backend by passing invalid token": {
-			id:          sink.ID,
+		"clone non-existent sink": {
+			id:          wrongID.String(),
+			req:         `{"name": "my-orphan-sink"}`,
 			contentType: contentType,
-			auth:        "blah",
-			status:      http.StatusUnauthorized,
-			res:         unauthRes,
+			auth:        token,
+			status:      http.StatusNotFound,
 		},
-		"view backend by passing empty token": {
-			id:          sink.ID,
+		"clone sink with invalid token": {
+			id:          sk.ID,
+			req:         `{"name": "my-unauthorized-sink"}`,
 			contentType: contentType,
-			auth:        "",
+			auth:        invalidToken,
 			status:      http.StatusUnauthorized,
-			res:         unauthRes,
 		},
-		"view backend by passing invalid id": {
-			id:          "invalid",
+		"clone sink without name": {
+			id:          sk.ID,
+			req:         `{"tags": {"cloud": "gcp"}}`,
 			contentType: contentType,
 			auth:        token,
-			status:      http.StatusNotFound,
-			res:         notFoundRes,
+			status:      http.StatusBadRequest,
+		},
+		"clone sink with invalid content type": {
+			id:          sk.ID,
+			req:         `{"name": "my-xml-sink"}`,
+			contentType: "application/xml",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
 		},
 	}
-
 	for desc, tc := range cases {
 		t.Run(desc, func(t *testing.T) {
 			req := testRequest{
 				client:      server.Client(),
-				method:      http.MethodGet,
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/sinks/%s/clone", server.URL, tc.id),
 				contentType: tc.contentType,
-				url:         fmt.Sprintf("%s/sinks/%s", server.URL, tc.id),
 				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(tc.req),
 			}
 			res, err := req.make()
-			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
-			body, err := io.ReadAll(res.Body)
-			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
-			data := strings.Trim(string(body), "\n")
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
-			assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", desc, tc.res, data))
+			if res.StatusCode == http.StatusCreated {
+				var body sinkRes
+				err = json.NewDecoder(res.Body).Decode(&body)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+				assert.NotEqual(t, sk.ID, body.ID, fmt.Sprintf("%s: clone must be a new sink", desc))
+				assert.Equal(t, "my-cloned-sink", body.Name, fmt.Sprintf("%s: unexpected name", desc))
+				assert.Equal(t, types.Tags{"cloud": "gcp"}, body.Tags, fmt.Sprintf("%s: unexpected tags", desc))
+				assert.Equal(t, description, body.Description, fmt.Sprintf("%s: description should be copied", desc))
+				auth := body.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+				assert.Equal(t, "", auth["password"], fmt.Sprintf("%s: password should be omitted", desc))
+				assert.Equal(t, "dbuser", auth["username"], fmt.Sprintf("%s: username should be copied", desc))
+			}
 		})
 	}
-
 }
 
 func TestDeleteSink(t *testing.T) {
@@ -1233,6 +2563,69 @@ func TestDeleteSink(t *testing.T) {
 	}
 }
 
+func TestDeleteSinkInUse(t *testing.T) {
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthService(map[string]string{token: email})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	datasets := make(map[string][]string)
+	svc := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"}), pwdSvc, nil, sinks.TagLimits{}, nil, skmocks.NewPoliciesServiceClient(datasets, nil))
+	server := newServer(svc)
+	defer server.Close()
+
+	nameID, _ := types.NewIdentifier("my-sink")
+	sk, err := svc.CreateSink(context.Background(), token, sinks.Sink{
+		Name:    nameID,
+		Backend: "prometheus",
+		Config: map[string]interface{}{
+			"exporter":       map[string]interface{}{"remote_host": "https://orb.community/"},
+			"authentication": map[string]interface{}{"type": "basicauth", "username": "dbuser", "password": "dbpass"},
+		},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	datasets[sk.ID] = []string{"dataset-1"}
+
+	cases := []struct {
+		desc     string
+		query    string
+		status   int
+		datasets []string
+	}{
+		{
+			desc:     "delete sink in use",
+			status:   http.StatusConflict,
+			datasets: []string{"dataset-1"},
+		},
+		{
+			desc:   "delete sink in use with invalid force",
+			query:  "?force=maybe",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "force delete sink in use",
+			query:  "?force=true",
+			status: http.StatusNoContent,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: server.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/sinks/%s%s", server.URL, sk.ID, tc.query),
+				token:  fmt.Sprintf("Bearer %s", token),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.datasets != nil {
+				var body sinkInUseRes
+				require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, tc.datasets, body.Datasets, fmt.Sprintf("%s: expected dependent datasets %v got %v", tc.desc, tc.datasets, body.Datasets))
+			}
+		})
+	}
+}
+
 func TestValidateSink(t *testing.T) {
 	service := newService(map[string]string{token: email})
 	server := newServer(service)
@@ -1331,3 +2724,364 @@ func TestValidateSink(t *testing.T) {
 		})
 	}
 }
+
+func TestSinkMisplacedConfig(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+
+	var exporterInAuth = `{"name": "my-prom-sink", "backend": "prometheus", "config": {"authentication": {"type": "basicauth", "username": "dbuser", "password": "dbpass", "remote_host": "https://orb.community/"}}}`
+	var authInExporter = `{"name": "my-prom-sink", "backend": "prometheus", "config": {"exporter": {"remote_host": "https://orb.community/", "username": "dbuser"}, "authentication": {"type": "basicauth", "password": "dbpass"}}}`
+	var authInExporterYaml = `{"name": "my-prom-sink", "backend": "prometheus", "format": "yaml", "config_data": "exporter:\n  remote_host: https://orb.community/\n  type: basicauth\n"}`
+
+	cases := map[string]struct {
+		req      string
+		location string
+		status   int
+		message  string
+	}{
+		"validate a sink with the remote host under the authentication": {
+			req:      exporterInAuth,
+			location: "/sinks/validate",
+			status:   http.StatusBadRequest,
+			message:  "remote_host belongs under config.exporter, not config.authentication",
+		},
+		"validate a sink with the username under the exporter": {
+			req:      authInExporter,
+			location: "/sinks/validate",
+			status:   http.StatusBadRequest,
+			message:  "username belongs under config.authentication, not config.exporter",
+		},
+		"create a yaml sink with the authentication type under the exporter": {
+			req:      authInExporterYaml,
+			location: "/sinks",
+			status:   http.StatusBadRequest,
+			message:  "type belongs under config.authentication, not config.exporter",
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s%s", server.URL, tc.location),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", token),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			var body types.ErrorRes
+			err = json.NewDecoder(res.Body).Decode(&body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.message, body.Err, fmt.Sprintf("%s: expected message %s got %s", desc, tc.message, body.Err))
+		})
+	}
+}
+
+func TestSinkSignalType(t *testing.T) {
+	service := newService(map[string]string{token: email})
+	server := newServer(service)
+	defer server.Close()
+
+	otlpSink := func(name string, signalType string) string {
+		return toJSON(addReq{
+			Name:    name,
+			Backend: "otlphttp",
+			Type:    signalType,
+			Config: types.Metadata{
+				"exporter":       types.Metadata{"endpoint": "localhost:4318"},
+				"authentication": types.Metadata{"type": "basicauth", "username": "test", "password": "test"},
+			},
+		})
+	}
+	promSink := func(name string, signalType string) string {
+		return toJSON(addReq{
+			Name:    name,
+			Backend: "prometheus",
+			Type:    signalType,
+			Config: types.Metadata{
+				"exporter":       types.Metadata{"remote_host": "https://orb.community/"},
+				"authentication": types.Metadata{"type": "basicauth", "username": "test", "password": "test"},
+			},
+		})
+	}
+
+	cases := map[string]struct {
+		req      string
+		status   int
+		wantType string
+	}{
+		"create a prometheus sink without type defaults to metrics": {
+			req:      promSink("prom-default", ""),
+			status:   http.StatusCreated,
+			wantType: backend.SignalMetrics,
+		},
+		"create a prometheus sink with a logs type": {
+			req:    promSink("prom-logs", backend.SignalLogs),
+			status: http.StatusBadRequest,
+		},
+		"create an otlp sink with a traces type": {
+			req:      otlpSink("otlp-traces", backend.SignalTraces),
+			status:   http.StatusCreated,
+			wantType: backend.SignalTraces,
+		},
+		"create an otlp sink without type": {
+			req:    otlpSink("otlp-untyped", ""),
+			status: http.StatusCreated,
+		},
+		"create an otlp sink with an unknown type": {
+			req:    otlpSink("otlp-profiles", "profiles"),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/sinks", server.URL),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", token),
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			require.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+			if tc.status != http.StatusCreated {
+				return
+			}
+			var created sinkRes
+			err = json.NewDecoder(res.Body).Decode(&created)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.wantType, created.Type, fmt.Sprintf("%s: expected type %s got %s", desc, tc.wantType, created.Type))
+
+			view := testRequest{
+				client: server.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/sinks/%s", server.URL, created.ID),
+				token:  fmt.Sprintf("Bearer %s", token),
+			}
+			res, err = view.make()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			var viewed sinkRes
+			err = json.NewDecoder(res.Body).Decode(&viewed)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, tc.wantType, viewed.Type, fmt.Sprintf("%s: expected viewed type %s got %s", desc, tc.wantType, viewed.Type))
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	otherToken := "other-token"
+	tokens := map[string]string{token: email, otherToken: "other@example.com"}
+	service := newService(tokens)
+	limiter := NewRateLimiter(skmocks.NewAuthService(tokens), config.RateLimitConfig{
+		ReadRate:      100,
+		ReadBurst:     100,
+		ValidateRate:  0.01,
+		ValidateBurst: 1,
+	})
+	server := httptest.NewServer(MakeHandler(mocktracer.New(), "sinks", service, limiter, nil))
+	defer server.Close()
+
+	// cases run in order, each owner has a single validate request in its bucket
+	cases := []struct {
+		desc       string
+		auth       string
+		method     string
+		location   string
+		status     int
+		retryAfter bool
+	}{
+		{
+			desc:     "validate a sink within the owner limit",
+			auth:     token,
+			method:   http.MethodPost,
+			location: "/sinks/validate",
+			status:   http.StatusOK,
+		},
+		{
+			desc:       "validate a sink over the owner limit",
+			auth:       token,
+			method:     http.MethodPost,
+			location:   "/sinks/validate",
+			status:     http.StatusTooManyRequests,
+			retryAfter: true,
+		},
+		{
+			desc:     "validate a sink as another owner",
+			auth:     otherToken,
+			method:   http.MethodPost,
+			location: "/sinks/validate",
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list sinks with a separate read limit",
+			auth:     token,
+			method:   http.MethodGet,
+			location: "/sinks",
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "validate a sink with an invalid token",
+			auth:     invalidToken,
+			method:   http.MethodPost,
+			location: "/sinks/validate",
+			status:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      server.Client(),
+				method:      tc.method,
+				url:         fmt.Sprintf("%s%s", server.URL, tc.location),
+				contentType: contentType,
+				token:       fmt.Sprintf("Bearer %s", tc.auth),
+				body:        strings.NewReader(validJson),
+			}
+			res, err := req.make()
+			require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.retryAfter, res.Header.Get("Retry-After") != "", fmt.Sprintf("%s: unexpected Retry-After header", tc.desc))
+		})
+	}
+}
+
+// countingAuth counts the tokens identified with the auth service
+type countingAuth struct {
+	mainflux.AuthServiceClient
+	calls int
+}
+
+func (a *countingAuth) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	a.calls++
+	return a.AuthServiceClient.Identify(ctx, in, opts...)
+}
+
+func TestRateLimiterOwnerCache(t *testing.T) {
+	auth := &countingAuth{AuthServiceClient: skmocks.NewAuthService(map[string]string{token: email})}
+	limiter := NewRateLimiter(auth, config.RateLimitConfig{ReadRate: 1, ReadBurst: 1})
+	req := httptest.NewRequest(http.MethodGet, "/sinks", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	now := time.Now()
+
+	for _, at := range []time.Time{now, now.Add(ownerCacheTTL / 2)} {
+		owner, ok := limiter.identify(req, at)
+		require.True(t, ok)
+		assert.Equal(t, email, owner)
+	}
+	assert.Equal(t, 1, auth.calls, "expected the owner to be cached")
+
+	_, ok := limiter.identify(req, now.Add(ownerCacheTTL))
+	require.True(t, ok)
+	assert.Equal(t, 2, auth.calls, "expected the expired owner to be identified again")
+
+	invalid := httptest.NewRequest(http.MethodGet, "/sinks", nil)
+	invalid.Header.Set("Authorization", fmt.Sprintf("Bearer %s", invalidToken))
+	_, ok = limiter.identify(invalid, now)
+	assert.False(t, ok)
+	assert.NotContains(t, limiter.owners, invalidToken, "expected the rejected token not to be cached")
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	limiter := NewRateLimiter(skmocks.NewAuthService(map[string]string{}), config.RateLimitConfig{ReadRate: 1, ReadBurst: 2})
+	now := time.Now()
+	limiter.owners[token] = cachedOwner{id: email, expires: now.Add(ownerCacheTTL)}
+
+	require.True(t, limiter.bucket(readClass, "idle-owner", now).AllowN(now, 1))
+	require.True(t, limiter.bucket(readClass, "busy-owner", now).AllowN(now, 1))
+
+	// the idle owner bucket refills before the sweep, the busy owner one is drained again
+	later := now.Add(sweepInterval + time.Second)
+	require.True(t, limiter.buckets[readClass]["busy-owner"].AllowN(later, 2))
+	limiter.bucket(readClass, "busy-owner", later)
+	assert.NotContains(t, limiter.buckets[readClass], "idle-owner", "expected the refilled bucket to be dropped")
+	assert.Contains(t, limiter.buckets[readClass], "busy-owner", "expected the drained bucket to be kept")
+	assert.NotContains(t, limiter.owners, token, "expected the expired owner to be dropped")
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	requests := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "http_requests_total"}, []string{"method", "route", "code"})
+	latency := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method", "route"})
+	httpMetrics := NewHTTPMetrics(kitprometheus.NewCounter(requests), kitprometheus.NewHistogram(latency))
+	server := httptest.NewServer(MakeHandler(mocktracer.New(), "sinks", newService(map[string]string{token: email}), nil, httpMetrics))
+	defer server.Close()
+
+	for _, id := range []string{wrongID.String(), "other-id"} {
+		req := testRequest{
+			client: server.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/sinks/%s", server.URL, id),
+			token:  fmt.Sprintf("Bearer %s", token),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		res.Body.Close()
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(requests.WithLabelValues(http.MethodGet, "/sinks/:id", "404")), "requests must be labeled by route pattern")
+	assert.Equal(t, 1, testutil.CollectAndCount(latency), "latency must be labeled by route pattern")
+}
+
+func TestStreamSinkStates(t *testing.T) {
+	logger := zap.NewNop()
+	auth := skmocks.NewAuthService(map[string]string{token: email})
+	pwdSvc := authentication_type.NewPasswordService(logger, "_testing_string_")
+	stream := skmocks.NewStateChangeStream(
+		sinks.StateChange{ID: "1-0", SinkID: "sink-1", OwnerID: email, State: sinks.Active},
+		sinks.StateChange{ID: "2-0", SinkID: "sink-2", OwnerID: "other@example.com", State: sinks.Error},
+		sinks.StateChange{ID: "3-0", SinkID: "sink-1", OwnerID: email, State: sinks.Error, Message: "remote write failed"},
+	)
+	service := sinks.NewSinkService(logger, auth, skmocks.NewSinkRepository(pwdSvc), mfsdk.NewSDK(mfsdk.Config{ThingsURL: "localhost"}), pwdSvc, nil, sinks.TagLimits{}, stream, nil)
+	// the metrics recorder wraps the response writer, the stream must still be flushed through it
+	requests := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "http_requests_total"}, []string{"method", "route", "code"})
+	latency := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method", "route"})
+	server := httptest.NewServer(MakeHandler(mocktracer.New(), "sinks", service, nil, NewHTTPMetrics(kitprometheus.NewCounter(requests), kitprometheus.NewHistogram(latency))))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/sinks/events/stream", server.URL), nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Last-Event-ID", "1-0")
+	res, err := server.Client().Do(req)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode, "expected the stream to be opened")
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(res.Body)
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if line == "\n" {
+			break
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	require.Len(t, lines, 3, fmt.Sprintf("expected a single event got %v", lines))
+	assert.Equal(t, "id: 3-0", lines[0], "expected the owner change after the last event id")
+	assert.Equal(t, "event: sink_state", lines[1])
+	var data stateChangeRes
+	require.Nil(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &data))
+	assert.Equal(t, "sink-1", data.SinkID)
+	assert.Equal(t, sinks.Error.String(), data.State)
+	assert.Equal(t, "remote write failed", data.Message)
+
+	unauthorized := testRequest{
+		client: server.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/sinks/events/stream", server.URL),
+		token:  fmt.Sprintf("Bearer %s", invalidToken),
+	}
+	res, err = unauthorized.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode, "expected the stream to require valid credentials")
+}
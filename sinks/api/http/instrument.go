@@ -0,0 +1,64 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-zoo/bone"
+)
+
+// HTTPMetrics counts and times the requests served by the sinks HTTP API. The requests are labeled by
+// the matched route pattern, so the sink ids in the paths do not create new series
+type HTTPMetrics struct {
+	requests metrics.Counter
+	latency  metrics.Histogram
+}
+
+// NewHTTPMetrics expects the counter labeled by method, route and code, and the latency in seconds
+// labeled by method and route
+func NewHTTPMetrics(requests metrics.Counter, latency metrics.Histogram) *HTTPMetrics {
+	return &HTTPMetrics{requests: requests, latency: latency}
+}
+
+// instrument wraps the router, it is a no-op when no metrics are set
+func (hm *HTTPMetrics) instrument(r *bone.Mux) http.Handler {
+	if hm == nil {
+		return r
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		begin := time.Now()
+		route := r.GetRequestRoute(req)
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		r.ServeHTTP(rec, req)
+		hm.requests.With("method", req.Method, "route", route, "code", strconv.Itoa(rec.code)).Add(1)
+		hm.latency.With("method", req.Method, "route", route).Observe(time.Since(begin).Seconds())
+	})
+}
+
+// statusRecorder keeps the status code written to the response
+type statusRecorder struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if !s.wroteHeader {
+		s.code = code
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets the streamed responses through the recorder
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
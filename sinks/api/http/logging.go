@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/backend"
@@ -63,6 +64,37 @@ func (l loggingMiddleware) CreateSink(ctx context.Context, token string, s sinks
 	return l.svc.CreateSink(ctx, token, s)
 }
 
+func (l loggingMiddleware) CreateSinkOnBehalfOf(ctx context.Context, token string, ownerID string, s sinks.Sink) (_ sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: create_sink_on_behalf_of",
+				zap.String("owner_id", ownerID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: create_sink_on_behalf_of",
+				zap.String("owner_id", ownerID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CreateSinkOnBehalfOf(ctx, token, ownerID, s)
+}
+
+func (l loggingMiddleware) ResyncSinks(ctx context.Context, token string) (sks []sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: resync_sinks",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Info("method call: resync_sinks",
+				zap.Int("sinks", len(sks)),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ResyncSinks(ctx, token)
+}
+
 func (l loggingMiddleware) UpdateSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -77,6 +109,118 @@ func (l loggingMiddleware) UpdateSink(ctx context.Context, token string, s sinks
 	return l.svc.UpdateSink(ctx, token, s)
 }
 
+func (l loggingMiddleware) RotateSinkCredentials(ctx context.Context, token string, sinkID string, credentials types.Metadata) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: rotate_sink_credentials",
+				zap.String("sink_id", sinkID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: rotate_sink_credentials",
+				zap.String("sink_id", sinkID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.RotateSinkCredentials(ctx, token, sinkID, credentials)
+}
+
+func (l loggingMiddleware) PatchSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: patch_sink",
+				zap.String("sink_id", s.ID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: patch_sink",
+				zap.String("sink_id", s.ID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.PatchSink(ctx, token, s)
+}
+
+func (l loggingMiddleware) CloneSink(ctx context.Context, token string, sinkID string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: clone_sink",
+				zap.String("source_sink_id", sinkID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: clone_sink",
+				zap.String("source_sink_id", sinkID),
+				zap.String("sink_id", sink.ID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CloneSink(ctx, token, sinkID, s)
+}
+
+func (l loggingMiddleware) CreateSinkFromTemplate(ctx context.Context, token string, name string, overrides sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: create_sink_from_template",
+				zap.String("template", name),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: create_sink_from_template",
+				zap.String("template", name),
+				zap.String("sink_id", sink.ID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CreateSinkFromTemplate(ctx, token, name, overrides)
+}
+
+func (l loggingMiddleware) SaveSinkTemplate(ctx context.Context, token string, template sinks.SinkTemplate) (_ sinks.SinkTemplate, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: save_sink_template",
+				zap.String("template", template.Name.String()),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Info("method call: save_sink_template",
+				zap.String("template", template.Name.String()),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.SaveSinkTemplate(ctx, token, template)
+}
+
+func (l loggingMiddleware) ListSinkTemplates(ctx context.Context, token string) (_ []sinks.SinkTemplate, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: list_sink_templates",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: list_sink_templates",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ListSinkTemplates(ctx, token)
+}
+
+func (l loggingMiddleware) RemoveSinkTemplate(ctx context.Context, token string, name string) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: remove_sink_template",
+				zap.String("template", name),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Info("method call: remove_sink_template",
+				zap.String("template", name),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.RemoveSinkTemplate(ctx, token, name)
+}
+
 func (l loggingMiddleware) UpdateSinkInternal(ctx context.Context, s sinks.Sink) (sink sinks.Sink, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -147,6 +291,126 @@ func (l loggingMiddleware) ViewSink(ctx context.Context, token string, key strin
 	return l.svc.ViewSink(ctx, token, key)
 }
 
+func (l loggingMiddleware) CountSinks(ctx context.Context, token string, tags types.Tags) (_ sinks.Counts, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: count_sinks",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: count_sinks",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CountSinks(ctx, token, tags)
+}
+
+func (l loggingMiddleware) CheckReadiness(ctx context.Context) (failing map[string]string) {
+	defer func(begin time.Time) {
+		if len(failing) > 0 {
+			l.logger.Warn("method call: check_readiness",
+				zap.Any("failing", failing),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: check_readiness",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.CheckReadiness(ctx)
+}
+
+func (l loggingMiddleware) ListSinkStateEvents(ctx context.Context, token string, sinkID string) (_ []sinks.StateEvent, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: list_sink_state_events",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: list_sink_state_events",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ListSinkStateEvents(ctx, token, sinkID)
+}
+
+func (l loggingMiddleware) DiffSinkRevisions(ctx context.Context, token string, sinkID string, from uint64, to uint64) (_ sinks.ConfigDiff, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: diff_sink_revisions",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: diff_sink_revisions",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.DiffSinkRevisions(ctx, token, sinkID, from, to)
+}
+
+func (l loggingMiddleware) WatchSinkStates(ctx context.Context, token string, lastEventID string) (_ <-chan sinks.StateChange, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: watch_sink_states",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: watch_sink_states",
+				zap.String("last_event_id", lastEventID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.WatchSinkStates(ctx, token, lastEventID)
+}
+
+func (l loggingMiddleware) SetOwnerDefaultTags(ctx context.Context, token string, ownerID string, tags types.Tags) (_ types.Tags, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: set_owner_default_tags",
+				zap.String("owner_id", ownerID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Info("method call: set_owner_default_tags",
+				zap.String("owner_id", ownerID),
+				zap.Int("tags", len(tags)),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.SetOwnerDefaultTags(ctx, token, ownerID, tags)
+}
+
+func (l loggingMiddleware) RevalidateBackendSinks(ctx context.Context, token string, backendName string) (_ sinks.RevalidationReport, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: revalidate_backend_sinks",
+				zap.String("backend", backendName),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: revalidate_backend_sinks",
+				zap.String("backend", backendName),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.RevalidateBackendSinks(ctx, token, backendName)
+}
+
+func (l loggingMiddleware) ViewOwnerDefaultTags(ctx context.Context, token string, ownerID string) (_ types.Tags, err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: view_owner_default_tags",
+				zap.String("owner_id", ownerID),
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: view_owner_default_tags",
+				zap.String("owner_id", ownerID),
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ViewOwnerDefaultTags(ctx, token, ownerID)
+}
+
 func (l loggingMiddleware) ViewSinkInternal(ctx context.Context, ownerID string, key string) (_ sinks.Sink, err error) {
 	defer func(begin time.Time) {
 		if err != nil {
@@ -161,18 +425,20 @@ func (l loggingMiddleware) ViewSinkInternal(ctx context.Context, ownerID string,
 	return l.svc.ViewSinkInternal(ctx, ownerID, key)
 }
 
-func (l loggingMiddleware) DeleteSink(ctx context.Context, token string, key string) (err error) {
+func (l loggingMiddleware) DeleteSink(ctx context.Context, token string, key string, force bool) (err error) {
 	defer func(begin time.Time) {
 		if err != nil {
 			l.logger.Warn("method call: delete_sink",
+				zap.Bool("force", force),
 				zap.Error(err),
 				zap.Duration("duration", time.Since(begin)))
 		} else {
 			l.logger.Debug("method call: delete_sink",
+				zap.Bool("force", force),
 				zap.Duration("duration", time.Since(begin)))
 		}
 	}(time.Now())
-	return l.svc.DeleteSink(ctx, token, key)
+	return l.svc.DeleteSink(ctx, token, key, force)
 }
 
 func (l loggingMiddleware) ValidateSink(ctx context.Context, token string, s sinks.Sink) (_ sinks.Sink, err error) {
@@ -189,6 +455,20 @@ func (l loggingMiddleware) ValidateSink(ctx context.Context, token string, s sin
 	return l.svc.ValidateSink(ctx, token, s)
 }
 
+func (l loggingMiddleware) ProbeSink(ctx context.Context, token string, s sinks.Sink) (err error) {
+	defer func(begin time.Time) {
+		if err != nil {
+			l.logger.Warn("method call: probe_sink",
+				zap.Error(err),
+				zap.Duration("duration", time.Since(begin)))
+		} else {
+			l.logger.Debug("method call: probe_sink",
+				zap.Duration("duration", time.Since(begin)))
+		}
+	}(time.Now())
+	return l.svc.ProbeSink(ctx, token, s)
+}
+
 func (l loggingMiddleware) ListAuthenticationTypes(ctx context.Context, token string) ([]authentication_type.AuthenticationTypeConfig, error) {
 	return l.svc.ListAuthenticationTypes(ctx, token)
 }
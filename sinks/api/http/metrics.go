@@ -11,6 +11,7 @@ import (
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux"
 	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/backend"
@@ -76,6 +77,47 @@ func (m metricsMiddleware) CreateSink(ctx context.Context, token string, s sinks
 	return m.svc.CreateSink(ctx, token, s)
 }
 
+func (m metricsMiddleware) CreateSinkOnBehalfOf(ctx context.Context, token string, ownerID string, s sinks.Sink) (sink sinks.Sink, _ error) {
+	if _, err := m.identify(token); err != nil {
+		return sinks.Sink{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "createSinkOnBehalfOf",
+			"owner_id", ownerID,
+			"sink_id", sink.ID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.CreateSinkOnBehalfOf(ctx, token, ownerID, s)
+}
+
+func (m metricsMiddleware) ResyncSinks(ctx context.Context, token string) ([]sinks.Sink, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "resyncSinks",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ResyncSinks(ctx, token)
+}
+
 func (m metricsMiddleware) UpdateSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
 	defer func(begin time.Time) {
 		labels := []string{
@@ -92,6 +134,133 @@ func (m metricsMiddleware) UpdateSink(ctx context.Context, token string, s sinks
 	return m.svc.UpdateSink(ctx, token, s)
 }
 
+func (m metricsMiddleware) RotateSinkCredentials(ctx context.Context, token string, sinkID string, credentials types.Metadata) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "rotateSinkCredentials",
+			"owner_id", sink.MFOwnerID,
+			"sink_id", sinkID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.RotateSinkCredentials(ctx, token, sinkID, credentials)
+}
+
+func (m metricsMiddleware) PatchSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "patchSink",
+			"owner_id", sink.MFOwnerID,
+			"sink_id", s.ID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.PatchSink(ctx, token, s)
+}
+
+func (m metricsMiddleware) CloneSink(ctx context.Context, token string, sinkID string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "cloneSink",
+			"owner_id", sink.MFOwnerID,
+			"sink_id", sink.ID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.CloneSink(ctx, token, sinkID, s)
+}
+
+func (m metricsMiddleware) CreateSinkFromTemplate(ctx context.Context, token string, name string, overrides sinks.Sink) (sink sinks.Sink, err error) {
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "createSinkFromTemplate",
+			"owner_id", sink.MFOwnerID,
+			"sink_id", sink.ID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.CreateSinkFromTemplate(ctx, token, name, overrides)
+}
+
+func (m metricsMiddleware) SaveSinkTemplate(ctx context.Context, token string, template sinks.SinkTemplate) (sinks.SinkTemplate, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return sinks.SinkTemplate{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "saveSinkTemplate",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.SaveSinkTemplate(ctx, token, template)
+}
+
+func (m metricsMiddleware) ListSinkTemplates(ctx context.Context, token string) ([]sinks.SinkTemplate, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "listSinkTemplates",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ListSinkTemplates(ctx, token)
+}
+
+func (m metricsMiddleware) RemoveSinkTemplate(ctx context.Context, token string, name string) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "removeSinkTemplate",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.RemoveSinkTemplate(ctx, token, name)
+}
+
 func (m metricsMiddleware) UpdateSinkInternal(ctx context.Context, s sinks.Sink) (sink sinks.Sink, err error) {
 
 	return m.svc.UpdateSinkInternal(ctx, s)
@@ -181,6 +350,151 @@ func (m metricsMiddleware) ViewSink(ctx context.Context, token string, key strin
 	return m.svc.ViewSink(ctx, token, key)
 }
 
+func (m metricsMiddleware) CountSinks(ctx context.Context, token string, tags types.Tags) (sinks.Counts, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return sinks.Counts{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "countSinks",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.CountSinks(ctx, token, tags)
+}
+
+func (m metricsMiddleware) WatchSinkStates(ctx context.Context, token string, lastEventID string) (<-chan sinks.StateChange, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "watchSinkStates",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.WatchSinkStates(ctx, token, lastEventID)
+}
+
+func (m metricsMiddleware) DiffSinkRevisions(ctx context.Context, token string, sinkID string, from uint64, to uint64) (sinks.ConfigDiff, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return sinks.ConfigDiff{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "diffSinkRevisions",
+			"owner_id", ownerID,
+			"sink_id", sinkID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.DiffSinkRevisions(ctx, token, sinkID, from, to)
+}
+
+func (m metricsMiddleware) ListSinkStateEvents(ctx context.Context, token string, sinkID string) ([]sinks.StateEvent, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "listSinkStateEvents",
+			"owner_id", ownerID,
+			"sink_id", sinkID,
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ListSinkStateEvents(ctx, token, sinkID)
+}
+
+func (m metricsMiddleware) SetOwnerDefaultTags(ctx context.Context, token string, ownerID string, tags types.Tags) (types.Tags, error) {
+	if _, err := m.identify(token); err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "setOwnerDefaultTags",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.SetOwnerDefaultTags(ctx, token, ownerID, tags)
+}
+
+func (m metricsMiddleware) RevalidateBackendSinks(ctx context.Context, token string, backendName string) (sinks.RevalidationReport, error) {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return sinks.RevalidationReport{}, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "revalidateBackendSinks",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.RevalidateBackendSinks(ctx, token, backendName)
+}
+
+func (m metricsMiddleware) ViewOwnerDefaultTags(ctx context.Context, token string, ownerID string) (types.Tags, error) {
+	if _, err := m.identify(token); err != nil {
+		return nil, err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "viewOwnerDefaultTags",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ViewOwnerDefaultTags(ctx, token, ownerID)
+}
+
 func (m metricsMiddleware) ViewSinkInternal(ctx context.Context, ownerID string, key string) (sinks.Sink, error) {
 	defer func(begin time.Time) {
 		labels := []string{
@@ -197,7 +511,7 @@ func (m metricsMiddleware) ViewSinkInternal(ctx context.Context, ownerID string,
 	return m.svc.ViewSinkInternal(ctx, ownerID, key)
 }
 
-func (m metricsMiddleware) DeleteSink(ctx context.Context, token string, id string) (err error) {
+func (m metricsMiddleware) DeleteSink(ctx context.Context, token string, id string, force bool) (err error) {
 	ownerID, err := m.identify(token)
 	if err != nil {
 		return err
@@ -215,7 +529,7 @@ func (m metricsMiddleware) DeleteSink(ctx context.Context, token string, id stri
 
 	}(time.Now())
 
-	return m.svc.DeleteSink(ctx, token, id)
+	return m.svc.DeleteSink(ctx, token, id, force)
 }
 
 func (m metricsMiddleware) ValidateSink(ctx context.Context, token string, s sinks.Sink) (sinks.Sink, error) {
@@ -239,6 +553,27 @@ func (m metricsMiddleware) ValidateSink(ctx context.Context, token string, s sin
 	return m.svc.ValidateSink(ctx, token, s)
 }
 
+func (m metricsMiddleware) ProbeSink(ctx context.Context, token string, s sinks.Sink) error {
+	ownerID, err := m.identify(token)
+	if err != nil {
+		return err
+	}
+
+	defer func(begin time.Time) {
+		labels := []string{
+			"method", "probeSink",
+			"owner_id", ownerID,
+			"sink_id", "",
+		}
+
+		m.counter.With(labels...).Add(1)
+		m.latency.With(labels...).Observe(float64(time.Since(begin).Microseconds()))
+
+	}(time.Now())
+
+	return m.svc.ProbeSink(ctx, token, s)
+}
+
 func (m metricsMiddleware) identify(token string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -251,6 +586,10 @@ func (m metricsMiddleware) identify(token string) (string, error) {
 	return res.GetId(), nil
 }
 
+func (m metricsMiddleware) CheckReadiness(ctx context.Context) map[string]string {
+	return m.svc.CheckReadiness(ctx)
+}
+
 func (m metricsMiddleware) GetLogger() *zap.Logger {
 	return m.svc.GetLogger()
 }
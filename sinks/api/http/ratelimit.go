@@ -0,0 +1,176 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/orb-community/orb/pkg/config"
+	"github.com/orb-community/orb/pkg/types"
+	"golang.org/x/time/rate"
+)
+
+// endpointClass groups the endpoints sharing a rate limit
+type endpointClass int
+
+const (
+	readClass endpointClass = iota
+	writeClass
+	validateClass
+)
+
+const (
+	// ownerCacheTTL is how long the owner of a token is reused before it is identified again
+	ownerCacheTTL = time.Minute
+	// sweepInterval is how often the expired owners and the idle buckets are dropped
+	sweepInterval = time.Minute
+)
+
+type bucketLimit struct {
+	rate  rate.Limit
+	burst int
+}
+
+type cachedOwner struct {
+	id      string
+	expires time.Time
+}
+
+// RateLimiter limits the requests of each owner with a token bucket per endpoint class. The owner of each token
+// is cached for ownerCacheTTL, and the buckets refilled since their last request are dropped as a new bucket
+// would be the same
+type RateLimiter struct {
+	auth      mainflux.AuthServiceClient
+	limits    map[endpointClass]bucketLimit
+	mu        sync.Mutex
+	owners    map[string]cachedOwner
+	buckets   map[endpointClass]map[string]*rate.Limiter
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a per-owner rate limiter, owners are identified with the auth service
+func NewRateLimiter(auth mainflux.AuthServiceClient, cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		auth: auth,
+		limits: map[endpointClass]bucketLimit{
+			readClass:     newBucketLimit(cfg.ReadRate, cfg.ReadBurst),
+			writeClass:    newBucketLimit(cfg.WriteRate, cfg.WriteBurst),
+			validateClass: newBucketLimit(cfg.ValidateRate, cfg.ValidateBurst),
+		},
+		owners:  make(map[string]cachedOwner),
+		buckets: make(map[endpointClass]map[string]*rate.Limiter),
+	}
+}
+
+// newBucketLimit lets at least one request through per bucket, a burst of zero would reject them all
+func newBucketLimit(perSecond float64, burst int) bucketLimit {
+	if burst < 1 {
+		burst = 1
+	}
+	return bucketLimit{rate: rate.Limit(perSecond), burst: burst}
+}
+
+// limit wraps the handler with the rate limit of the endpoint class, a nil limiter lets every request through
+func (rl *RateLimiter) limit(class endpointClass, next http.Handler) http.Handler {
+	if rl == nil || rl.limits[class].rate <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		ownerID, ok := rl.identify(r, now)
+		if !ok {
+			// unauthenticated requests are rejected by the endpoint itself
+			next.ServeHTTP(w, r)
+			return
+		}
+		reservation := rl.bucket(class, ownerID, now).ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.Cancel()
+			writeRateLimited(w, delay)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// identify returns the owner of the request token, from the cache while it has not expired. The tokens the auth
+// service rejects are not cached
+func (rl *RateLimiter) identify(r *http.Request, now time.Time) (string, bool) {
+	token := parseJwt(r)
+	if token == "" {
+		return "", false
+	}
+	rl.mu.Lock()
+	owner, ok := rl.owners[token]
+	rl.mu.Unlock()
+	if ok && now.Before(owner.expires) {
+		return owner.id, true
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+	defer cancel()
+	res, err := rl.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", false
+	}
+	rl.mu.Lock()
+	rl.owners[token] = cachedOwner{id: res.GetId(), expires: now.Add(ownerCacheTTL)}
+	rl.mu.Unlock()
+	return res.GetId(), true
+}
+
+func (rl *RateLimiter) bucket(class endpointClass, ownerID string, now time.Time) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if now.Sub(rl.lastSweep) > sweepInterval {
+		rl.sweep(now)
+	}
+	owners, ok := rl.buckets[class]
+	if !ok {
+		owners = make(map[string]*rate.Limiter)
+		rl.buckets[class] = owners
+	}
+	limiter, ok := owners[ownerID]
+	if !ok {
+		limit := rl.limits[class]
+		limiter = rate.NewLimiter(limit.rate, limit.burst)
+		owners[ownerID] = limiter
+	}
+	return limiter
+}
+
+// sweep drops the expired owners and the full buckets, it must be called with the lock held
+func (rl *RateLimiter) sweep(now time.Time) {
+	for token, owner := range rl.owners {
+		if !now.Before(owner.expires) {
+			delete(rl.owners, token)
+		}
+	}
+	for class, owners := range rl.buckets {
+		for ownerID, limiter := range owners {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(owners, ownerID)
+			}
+		}
+		if len(owners) == 0 {
+			delete(rl.buckets, class)
+		}
+	}
+	rl.lastSweep = now
+}
+
+// writeRateLimited answers 429 with the seconds to wait before the next request is allowed
+func writeRateLimited(w http.ResponseWriter, delay time.Duration) {
+	w.Header().Set("Content-Type", types.ContentType)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(types.ErrorRes{Err: "too many requests, retry later"})
+}
@@ -9,11 +9,13 @@
 package http
 
 import (
+	"github.com/gofrs/uuid"
 	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
 	"github.com/orb-community/orb/sinks/backend"
 	"gopkg.in/yaml.v3"
 )
@@ -30,15 +32,21 @@ const (
 type addReq struct {
 	Name        string         `json:"name,omitempty"`
 	Backend     string         `json:"backend,omitempty"`
+	Type        string         `json:"type,omitempty"`
 	Config      types.Metadata `json:"config,omitempty"`
 	Format      string         `json:"format,omitempty"`
 	ConfigData  string         `json:"config_data,omitempty"`
 	Description string         `json:"description,omitempty"`
 	Tags        types.Tags     `json:"tags,omitempty"`
-	token       string
+	// ValidateOnly checks the sink without saving it, Deep adds a live probe of the remote end to that check
+	ValidateOnly bool `json:"validate_only,omitempty"`
+	Deep         bool `json:"deep,omitempty"`
+	token        string
+	// onBehalfOf is the owner the sink is created under, set by admin provisioning
+	onBehalfOf string
 }
 
-func GetConfigurationAndMetadataFromMeta(backendName string, config types.Metadata) (configSvc *sinks.Configuration, exporter types.Metadata, authentication types.Metadata, err error) {
+func GetConfigurationAndMetadataFromMeta(backendName string, config types.Metadata) (configSvc *sinks.Configuration, exporter types.Metadata, authentication interface{}, err error) {
 
 	if !backend.HaveBackend(backendName) {
 		return nil, nil, nil, errors.Wrap(errors.ErrInvalidBackend, errors.New("invalid backend"))
@@ -47,6 +55,9 @@ func GetConfigurationAndMetadataFromMeta(backendName string, config types.Metada
 	if config == nil {
 		return nil, nil, nil, errors.Wrap(errors.ErrConfigFieldNotFound, errors.New("backend must not be nil"))
 	}
+	if err = sinks.ValidateConfigPlacement(backend.GetBackend(backendName), config); err != nil {
+		return
+	}
 
 	configSvc = &sinks.Configuration{
 		Exporter: backend.GetBackend(backendName),
@@ -55,16 +66,23 @@ func GetConfigurationAndMetadataFromMeta(backendName string, config types.Metada
 	if exporter == nil {
 		return nil, nil, nil, errors.Wrap(errors.ErrExporterFieldNotFound, errors.New("exporter field must not be nil"))
 	}
-	err = configSvc.Exporter.ValidateConfiguration(exporter)
+	err = sinks.ValidateExporter(configSvc.Exporter, exporter)
 	if err != nil {
 		return
 	}
 
-	authentication = config.GetSubMetadata(authentication_type.AuthenticationKey)
-	if authentication == nil {
+	if multiauth.IsMultiAuth(config) {
+		configSvc.Authentication, _ = authentication_type.GetAuthType(multiauth.AuthType)
+		authentication = config[authentication_type.AuthenticationKey]
+		err = configSvc.Authentication.ValidateConfiguration("object", authentication)
+		return
+	}
+	authMeta := config.GetSubMetadata(authentication_type.AuthenticationKey)
+	if authMeta == nil {
 		return nil, nil, nil, errors.Wrap(errors.ErrAuthFieldNotFound, errors.New("authentication field must not be nil"))
 	}
-	authtype, ok := authentication["type"]
+	authentication = authMeta
+	authtype, ok := authMeta["type"]
 	if !ok {
 		authtype = basicauth.AuthType
 	}
@@ -84,7 +102,7 @@ func GetConfigurationAndMetadataFromMeta(backendName string, config types.Metada
 	return
 }
 
-func GetConfigurationAndMetadataFromYaml(backendName string, config string) (configSvc *sinks.Configuration, exporter types.Metadata, authentication types.Metadata, err error) {
+func GetConfigurationAndMetadataFromYaml(backendName string, config string) (configSvc *sinks.Configuration, exporter types.Metadata, authentication interface{}, err error) {
 
 	if !backend.HaveBackend(backendName) {
 		return nil, nil, nil, errors.Wrap(errors.ErrInvalidBackend, errors.New("invalid backend"))
@@ -98,20 +116,30 @@ func GetConfigurationAndMetadataFromYaml(backendName string, config string) (con
 	if err != nil {
 		return
 	}
+	if err = sinks.ValidateConfigPlacement(configSvc.Exporter, configStr); err != nil {
+		return
+	}
 	exporter = configStr.GetSubMetadata("exporter")
 	if exporter == nil {
 		return nil, nil, nil, errors.New("malformed entity specification. exporter field is expected on configuration field")
 	}
-	err = configSvc.Exporter.ValidateConfiguration(exporter)
+	err = sinks.ValidateExporter(configSvc.Exporter, exporter)
 	if err != nil {
 		return
 	}
 
-	authentication = configStr.GetSubMetadata(authentication_type.AuthenticationKey)
-	if authentication == nil {
+	if multiauth.IsMultiAuth(configStr) {
+		configSvc.Authentication, _ = authentication_type.GetAuthType(multiauth.AuthType)
+		authentication = configStr[authentication_type.AuthenticationKey]
+		err = configSvc.Authentication.ValidateConfiguration("object", authentication)
+		return
+	}
+	authMeta := configStr.GetSubMetadata(authentication_type.AuthenticationKey)
+	if authMeta == nil {
 		return nil, nil, nil, errors.New("malformed entity specification. authentication fields are expected on configuration field")
 	}
-	authtype, ok := authentication["type"]
+	authentication = authMeta
+	authtype, ok := authMeta["type"]
 	if !ok {
 		authtype = basicauth.AuthType
 	}
@@ -145,6 +173,16 @@ func (req addReq) validate() (err error) {
 	if err != nil {
 		return errors.Wrap(errors.ErrConflict, errors.New("identifier duplicated"))
 	}
+
+	if req.Deep && !req.ValidateOnly {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("deep is only allowed along with validate_only"))
+	}
+
+	if req.onBehalfOf != "" {
+		if _, err := uuid.FromString(req.onBehalfOf); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("invalid owner id to act on behalf of"))
+		}
+	}
 	return nil
 }
 
@@ -152,6 +190,7 @@ type updateSinkReq struct {
 	Name        string         `json:"name,omitempty"`
 	Config      types.Metadata `json:"config,omitempty"`
 	Backend     string         `json:"backend,omitempty"`
+	Type        string         `json:"type,omitempty"`
 	Format      string         `json:"format,omitempty"`
 	ConfigData  string         `json:"config_data,omitempty"`
 	Description *string        `json:"description,omitempty"`
@@ -176,6 +215,81 @@ func (req updateSinkReq) validate() error {
 	return nil
 }
 
+type patchSinkReq struct {
+	Name        string     `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Tags        types.Tags `json:"tags,omitempty"`
+	id          string
+	token       string
+}
+
+func (req patchSinkReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return errors.ErrMalformedEntity
+	}
+
+	if req.Name == "" && req.Description == nil && req.Tags == nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("one of name, description or tags is required"))
+	}
+
+	if req.Name != "" {
+		if _, err := types.NewIdentifier(req.Name); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return nil
+}
+
+type cloneSinkReq struct {
+	Name  string     `json:"name"`
+	Tags  types.Tags `json:"tags,omitempty"`
+	id    string
+	token string
+}
+
+func (req cloneSinkReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.Name == "" {
+		return errors.ErrMalformedEntity
+	}
+
+	if _, err := types.NewIdentifier(req.Name); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+type rotateCredentialsReq struct {
+	Authentication types.Metadata `json:"authentication,omitempty"`
+	id             string
+	token          string
+}
+
+func (req rotateCredentialsReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return errors.ErrMalformedEntity
+	}
+
+	if len(req.Authentication) == 0 {
+		return errors.Wrap(errors.ErrAuthFieldNotFound, errors.New("authentication field must not be empty"))
+	}
+
+	return nil
+}
+
 type viewResourceReq struct {
 	token string
 	id    string
@@ -191,6 +305,35 @@ func (req viewResourceReq) validate() error {
 	return nil
 }
 
+type diffRevisionsReq struct {
+	token string
+	id    string
+	from  uint64
+	to    uint64
+}
+
+func (req diffRevisionsReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
+
+type countSinksReq struct {
+	token string
+	tags  types.Tags
+}
+
+func (req countSinksReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
 type listResourcesReq struct {
 	token        string
 	pageMetadata sinks.PageMetadata
@@ -223,17 +366,64 @@ func (req *listResourcesReq) validate() error {
 		return errors.ErrMalformedEntity
 	}
 
+	if _, _, err := sinks.SplitTagSelectors(req.pageMetadata.Tags); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 type listBackendsReq struct {
-	token string
+	token    string
+	signal   string
+	maturity string
 }
 
 func (req *listBackendsReq) validate() error {
 	if req.token == "" {
 		return errors.ErrUnauthorizedAccess
 	}
+	if req.signal != "" && !backend.IsValidSignal(req.signal) {
+		return errors.Wrap(errors.ErrInvalidQueryParams, errors.New("unknown signal "+req.signal))
+	}
+	if req.maturity != "" && !backend.IsValidMaturity(req.maturity) {
+		return errors.Wrap(errors.ErrInvalidQueryParams, errors.New("unknown maturity "+req.maturity))
+	}
+	return nil
+}
+
+type resyncReq struct {
+	token string
+}
+
+func (req *resyncReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type ownerDefaultTagsReq struct {
+	Tags    types.Tags `json:"tags"`
+	ownerID string
+	token   string
+}
+
+func (req *ownerDefaultTagsReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+
+	if _, err := uuid.FromString(req.ownerID); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("invalid owner id"))
+	}
+
+	for key := range req.Tags {
+		if key == "" {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("empty tag key"))
+		}
+	}
+
 	return nil
 }
 
@@ -251,6 +441,7 @@ func (req *listAuthTypesReq) validate() error {
 type deleteSinkReq struct {
 	token string
 	id    string
+	force bool
 }
 
 func (req deleteSinkReq) validate() error {
@@ -268,6 +459,7 @@ func (req deleteSinkReq) validate() error {
 type validateReq struct {
 	Name        string         `json:"name,omitempty"`
 	Backend     string         `json:"backend,omitempty"`
+	Type        string         `json:"type,omitempty"`
 	Config      types.Metadata `json:"config,omitempty"`
 	Description string         `json:"description,omitempty"`
 	Tags        types.Tags     `json:"tags,omitempty"`
@@ -290,3 +482,59 @@ func (req validateReq) validate() error {
 
 	return nil
 }
+
+type sinkTemplateReq struct {
+	Description string         `json:"description,omitempty"`
+	Backend     string         `json:"backend"`
+	Type        string         `json:"type,omitempty"`
+	Config      types.Metadata `json:"config,omitempty"`
+	Tags        types.Tags     `json:"tags,omitempty"`
+	name        string
+	token       string
+}
+
+func (req sinkTemplateReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if _, err := types.NewIdentifier(req.name); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if req.Backend == "" {
+		return errors.Wrap(errors.ErrBackendNotFound, errors.New("backend not found"))
+	}
+	return nil
+}
+
+type listSinkTemplatesReq struct {
+	token string
+}
+
+func (req listSinkTemplatesReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type createFromTemplateReq struct {
+	Name        string         `json:"name"`
+	Description *string        `json:"description,omitempty"`
+	Config      types.Metadata `json:"config,omitempty"`
+	Tags        types.Tags     `json:"tags,omitempty"`
+	template    string
+	token       string
+}
+
+func (req createFromTemplateReq) validate() error {
+	if req.token == "" {
+		return errors.ErrUnauthorizedAccess
+	}
+	if req.template == "" || req.Name == "" {
+		return errors.ErrMalformedEntity
+	}
+	if _, err := types.NewIdentifier(req.Name); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package http
 
 import (
 	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"net/http"
 	"time"
@@ -19,11 +20,14 @@ type sinkRes struct {
 	State       string         `json:"state,omitempty"`
 	Error       string         `json:"error,omitempty"`
 	Backend     string         `json:"backend,omitempty"`
+	Type        string         `json:"type,omitempty"`
 	Config      types.Metadata `json:"config,omitempty"`
 	Format      string         `json:"format,omitempty"`
 	ConfigData  string         `json:"config_data,omitempty"`
 	TsCreated   time.Time      `json:"ts_created,omitempty"`
-	created     bool
+	// CredentialsUpdatedAt only exposes when the credentials were last set, never the credentials themselves
+	CredentialsUpdatedAt time.Time `json:"credentials_updated_at,omitempty"`
+	created              bool
 }
 
 func (s sinkRes) Code() int {
@@ -59,6 +63,149 @@ func (res sinksPagesRes) Empty() bool {
 	return false
 }
 
+type sinkStateEventRes struct {
+	Timestamp time.Time `json:"timestamp"`
+	OldState  string    `json:"old_state"`
+	NewState  string    `json:"new_state"`
+	Message   string    `json:"message,omitempty"`
+}
+
+type sinkStateEventsRes struct {
+	Events []sinkStateEventRes `json:"events"`
+}
+
+func (res sinkStateEventsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sinkStateEventsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sinkStateEventsRes) Empty() bool {
+	return false
+}
+
+type configRevisionRes struct {
+	Revision  uint64    `json:"revision"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type configChangeRes struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"`
+	From  interface{} `json:"from,omitempty"`
+	To    interface{} `json:"to,omitempty"`
+}
+
+type configDiffRes struct {
+	SinkID  string            `json:"sink_id"`
+	From    configRevisionRes `json:"from"`
+	To      configRevisionRes `json:"to"`
+	Changes []configChangeRes `json:"changes"`
+}
+
+func (res configDiffRes) Code() int {
+	return http.StatusOK
+}
+
+func (res configDiffRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res configDiffRes) Empty() bool {
+	return false
+}
+
+type sinksCountRes struct {
+	Total     uint64            `json:"total"`
+	ByState   map[string]uint64 `json:"state"`
+	ByBackend map[string]uint64 `json:"backend"`
+}
+
+func (res sinksCountRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sinksCountRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sinksCountRes) Empty() bool {
+	return false
+}
+
+type readinessRes struct {
+	Status  string            `json:"status"`
+	Failing map[string]string `json:"failing,omitempty"`
+}
+
+func (res readinessRes) Code() int {
+	if len(res.Failing) > 0 {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+func (res readinessRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res readinessRes) Empty() bool {
+	return false
+}
+
+type resyncRes struct {
+	Sinks int `json:"sinks"`
+}
+
+func (res resyncRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res resyncRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res resyncRes) Empty() bool {
+	return false
+}
+
+type ownerDefaultTagsRes struct {
+	OwnerID string     `json:"owner_id"`
+	Tags    types.Tags `json:"tags"`
+}
+
+func (res ownerDefaultTagsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ownerDefaultTagsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ownerDefaultTagsRes) Empty() bool {
+	return false
+}
+
+type revalidationRes struct {
+	Backend  string                    `json:"backend"`
+	Checked  int                       `json:"checked"`
+	Failures []sinks.ValidationFailure `json:"failures"`
+}
+
+func (res revalidationRes) Code() int {
+	return http.StatusOK
+}
+
+func (res revalidationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revalidationRes) Empty() bool {
+	return false
+}
+
 type pageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
@@ -100,7 +247,8 @@ func (s sinkAuthTypesRes) Empty() bool {
 }
 
 type sinksBackendsRes struct {
-	Backends []interface{} `json:"backends,omitempty"`
+	Backends    []interface{} `json:"backends,omitempty"`
+	NamePattern string        `json:"name_pattern"`
 }
 
 func (s sinksBackendsRes) Code() int {
@@ -157,7 +305,14 @@ type validateSinkRes struct {
 	State       string         `json:"state,omitempty"`
 	Error       string         `json:"error,omitempty"`
 	Backend     string         `json:"backend,omitempty"`
+	Type        string         `json:"type,omitempty"`
 	Config      types.Metadata `json:"config,omitempty"`
+	Probe       *probeRes      `json:"probe,omitempty"`
+}
+
+type probeRes struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
 
 func (s validateSinkRes) Code() int {
@@ -171,3 +326,48 @@ func (s validateSinkRes) Headers() map[string]string {
 func (s validateSinkRes) Empty() bool {
 	return false
 }
+
+type sinkTemplateRes struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Backend     string         `json:"backend"`
+	Type        string         `json:"type,omitempty"`
+	Config      types.Metadata `json:"config"`
+	Tags        types.Tags     `json:"tags"`
+	TsCreated   time.Time      `json:"ts_created"`
+	TsUpdated   time.Time      `json:"ts_updated"`
+}
+
+func (res sinkTemplateRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sinkTemplateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sinkTemplateRes) Empty() bool {
+	return false
+}
+
+type sinkTemplatesRes struct {
+	Templates []sinkTemplateRes `json:"templates"`
+}
+
+func (res sinkTemplatesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sinkTemplatesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sinkTemplatesRes) Empty() bool {
+	return false
+}
+
+// sinkInUseRes is the error body of a refused sink deletion, listing the datasets still using the sink
+type sinkInUseRes struct {
+	Err      string   `json:"error"`
+	Datasets []string `json:"datasets"`
+}
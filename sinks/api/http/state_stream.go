@@ -0,0 +1,85 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/orb-community/orb/sinks"
+)
+
+// stateStreamKeepAlive is the interval of the comments written to keep idle connections open through proxies
+const stateStreamKeepAlive = 15 * time.Second
+
+// stateChangeEvent is the name of the server-sent events carrying a sink state change
+const stateChangeEvent = "sink_state"
+
+type stateChangeRes struct {
+	SinkID    string    `json:"sink_id"`
+	State     string    `json:"state"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"ts_created"`
+}
+
+// streamSinkStatesHandler pushes the state changes of the owner sinks as server-sent events. The event ids are
+// the stream positions, so a reconnecting client sending Last-Event-ID resumes without missing changes
+func streamSinkStatesHandler(svc sinks.SinkService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("last_event_id")
+		}
+		changes, err := svc.WatchSinkStates(r.Context(), parseJwt(r), lastEventID)
+		if err != nil {
+			encodeError(r.Context(), err, w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		keepAlive := time.NewTicker(stateStreamKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepAlive.C:
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(stateChangeRes{
+					SinkID:    change.SinkID,
+					State:     change.State.String(),
+					Message:   change.Message,
+					Timestamp: change.Timestamp,
+				})
+				if err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.ID, stateChangeEvent, data); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+}
@@ -32,80 +32,185 @@ const (
 	dirKey      = "dir"
 	metadataKey = "metadata"
 	tagsKey     = "tags"
+	credsAgeKey = "credentials_older_than"
+	errorKey    = "error_contains"
+	signalKey   = "signal"
+	maturityKey = "maturity"
+	fromKey     = "from"
+	toKey       = "to"
+	forceKey    = "force"
 	defOffset   = 0
 	defLimit    = 10
 )
 
-func MakeHandler(tracer opentracing.Tracer, svcName string, svc sinks.SinkService) http.Handler {
+// onBehalfOfHeader lets an admin token create a sink under another owner
+const onBehalfOfHeader = "X-Orb-On-Behalf-Of"
+
+// MakeHandler returns the sinks HTTP API, the limiter applies the per-owner rate limits and the metrics
+// instrument the requests, both can be nil
+func MakeHandler(tracer opentracing.Tracer, svcName string, svc sinks.SinkService, limiter *RateLimiter, httpMetrics *HTTPMetrics) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
 	r := bone.New()
-	r.Post("/sinks", kithttp.NewServer(
+	r.Post("/sinks", limiter.limit(writeClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_sink")(addEndpoint(svc)),
 		decodeAddRequest,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Put("/sinks/:id", kithttp.NewServer(
+	)))
+	r.Put("/sinks/:id", limiter.limit(writeClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "edit_sink")(updateSinkEndpoint(svc)),
 		decodeEditRequest,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/sinks", kithttp.NewServer(
+	)))
+	r.Patch("/sinks/:id", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "patch_sink")(patchSinkEndpoint(svc)),
+		decodePatchRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	// registered ahead of the /sinks/:id routes, so a template name is never taken for a sink id
+	r.Post("/sinks/from-template/:name", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_sink_from_template")(createSinkFromTemplateEndpoint(svc)),
+		decodeCreateFromTemplateRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/sinks/templates", limiter.limit(readClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_sink_templates")(listSinkTemplatesEndpoint(svc)),
+		decodeListTemplatesRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Put("/sinks/templates/:name", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "save_sink_template")(saveSinkTemplateEndpoint(svc)),
+		decodeSaveTemplateRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Delete("/sinks/templates/:name", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_sink_template")(removeSinkTemplateEndpoint(svc)),
+		decodeRemoveTemplateRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Post("/sinks/:id/credentials", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "rotate_sink_credentials")(rotateCredentialsEndpoint(svc)),
+		decodeRotateCredentialsRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Post("/sinks/:id/clone", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "clone_sink")(cloneSinkEndpoint(svc)),
+		decodeCloneRequest,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/sinks", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_sinks")(listSinksEndpoint(svc)),
 		decodeList,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Post("/sinks/validate", kithttp.NewServer(
+	)))
+	r.Post("/sinks/resync", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "resync_sinks")(resyncSinksEndpoint(svc)),
+		decodeResync,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Put("/sinks/owners/:id/default-tags", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "set_owner_default_tags")(setOwnerDefaultTagsEndpoint(svc)),
+		decodeSetOwnerDefaultTags,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/sinks/owners/:id/default-tags", limiter.limit(readClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_owner_default_tags")(viewOwnerDefaultTagsEndpoint(svc)),
+		decodeViewOwnerDefaultTags,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Post("/sinks/validate", limiter.limit(validateClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "validate_sink")(validateSinkEndpoint(svc)),
 		decodeValidateRequest,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/features/sinks", kithttp.NewServer(
+	)))
+	r.Get("/features/sinks", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_backends")(listBackendsEndpoint(svc)),
-		decodeListBackends,
+		decodeListSinkBackends,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/features/sinks/:id", kithttp.NewServer(
+	)))
+	r.Post("/features/sinks/:id/revalidate", limiter.limit(writeClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "revalidate_backend_sinks")(revalidateBackendSinksEndpoint(svc)),
+		decodeView,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/features/sinks/:id", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_backend")(viewBackendEndpoint(svc)),
 		decodeView,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/features/authenticationtypes", kithttp.NewServer(
+	)))
+	r.Get("/features/authenticationtypes", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_authentication_types")(listAuthenticationTypes(svc)),
 		decodeListBackends,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/features/authenticationtypes/:id", kithttp.NewServer(
+	)))
+	r.Get("/features/authenticationtypes/:id", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_authentication_type")(viewAuthenticationType(svc)),
 		decodeView,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Get("/sinks/:id", kithttp.NewServer(
+	)))
+	r.Get("/sinks/count", limiter.limit(readClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "count_sinks")(countSinksEndpoint(svc)),
+		decodeCount,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/sinks/:id", limiter.limit(readClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_sink")(viewSinkEndpoint(svc)),
 		decodeView,
 		types.EncodeResponse,
 		opts...,
-	))
-	r.Delete("/sinks/:id", kithttp.NewServer(
+	)))
+	r.Get("/sinks/events/stream", limiter.limit(readClass, streamSinkStatesHandler(svc)))
+	r.Get("/sinks/:id/events", limiter.limit(readClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_sink_state_events")(listSinkStateEventsEndpoint(svc)),
+		decodeView,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Get("/sinks/:id/diff", limiter.limit(readClass, kithttp.NewServer(
+		kitot.TraceServer(tracer, "diff_sink_revisions")(diffSinkRevisionsEndpoint(svc)),
+		decodeDiffRevisions,
+		types.EncodeResponse,
+		opts...,
+	)))
+	r.Delete("/sinks/:id", limiter.limit(writeClass, kithttp.NewServer(
 		kitot.TraceServer(tracer, "delete_sink")(deleteSinkEndpoint(svc)),
 		decodeDeleteRequest,
 		types.EncodeResponse,
 		opts...,
+	)))
+
+	r.Get("/healthz", kithttp.NewServer(
+		readinessEndpoint(svc),
+		decodeReadiness,
+		types.EncodeResponse,
+		opts...,
 	))
 
 	r.GetFunc("/version", buildinfo.Version(svcName))
 	r.Handle("/metrics", promhttp.Handler())
 
-	return r
+	return httpMetrics.instrument(r)
 }
 
 func decodeAddRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -113,7 +218,7 @@ func decodeAddRequest(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, errors.ErrUnsupportedContentType
 	}
 
-	req := addReq{token: parseJwt(r)}
+	req := addReq{token: parseJwt(r), onBehalfOf: r.Header.Get(onBehalfOfHeader)}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
 	}
@@ -136,6 +241,97 @@ func decodeEditRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodePatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := patchSinkReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeCloneRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := cloneSinkReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeCreateFromTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := createFromTemplateReq{
+		token:    parseJwt(r),
+		template: bone.GetValue(r, "name"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeSaveTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := sinkTemplateReq{
+		token: parseJwt(r),
+		name:  bone.GetValue(r, "name"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return listSinkTemplatesReq{token: parseJwt(r)}, nil
+}
+
+func decodeRemoveTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewResourceReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "name"),
+	}, nil
+}
+
+func decodeRotateCredentialsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := rotateCredentialsReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
 	req := viewResourceReq{
 		token: parseJwt(r),
@@ -144,11 +340,72 @@ func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeDiffRevisions(_ context.Context, r *http.Request) (interface{}, error) {
+	from, err := httputil.ReadUintQuery(r, fromKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	to, err := httputil.ReadUintQuery(r, toKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	return diffRevisionsReq{
+		token: parseJwt(r),
+		id:    bone.GetValue(r, "id"),
+		from:  from,
+		to:    to,
+	}, nil
+}
+
+func decodeResync(_ context.Context, r *http.Request) (interface{}, error) {
+	return resyncReq{token: parseJwt(r)}, nil
+}
+
+func decodeSetOwnerDefaultTags(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	req := ownerDefaultTagsReq{
+		token:   parseJwt(r),
+		ownerID: bone.GetValue(r, "id"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeViewOwnerDefaultTags(_ context.Context, r *http.Request) (interface{}, error) {
+	return ownerDefaultTagsReq{
+		token:   parseJwt(r),
+		ownerID: bone.GetValue(r, "id"),
+	}, nil
+}
+
+func decodeReadiness(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
 func decodeListBackends(_ context.Context, r *http.Request) (interface{}, error) {
 	req := listBackendsReq{token: parseJwt(r)}
 	return req, nil
 }
 
+func decodeListSinkBackends(_ context.Context, r *http.Request) (interface{}, error) {
+	signal, err := httputil.ReadStringQuery(r, signalKey, "")
+	if err != nil {
+		return nil, err
+	}
+	maturity, err := httputil.ReadStringQuery(r, maturityKey, "")
+	if err != nil {
+		return nil, err
+	}
+	req := listBackendsReq{token: parseJwt(r), signal: signal, maturity: maturity}
+	return req, nil
+}
+
 func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -185,6 +442,16 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	ca, err := httputil.ReadDurationQuery(r, credsAgeKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := httputil.ReadStringQuery(r, errorKey, "")
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: parseJwt(r),
 		pageMetadata: sinks.PageMetadata{
@@ -195,16 +462,38 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			Dir:      d,
 			Metadata: m,
 			Tags:     t,
+
+			CredentialsOlderThan: ca,
+			ErrorContains:        e,
 		},
 	}
 
 	return req, nil
 }
 
+func decodeCount(_ context.Context, r *http.Request) (interface{}, error) {
+	t, err := httputil.ReadTagQuery(r, tagsKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req := countSinksReq{
+		token: parseJwt(r),
+		tags:  t,
+	}
+
+	return req, nil
+}
+
 func decodeDeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	force, err := httputil.ReadBoolQuery(r, forceKey, false)
+	if err != nil {
+		return nil, err
+	}
 	req := deleteSinkReq{
 		token: parseJwt(r),
 		id:    bone.GetValue(r, "id"),
+		force: force,
 	}
 
 	return req, nil
@@ -230,6 +519,28 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		switch {
 		case errors.Contains(errorVal, errors.ErrUnauthorizedAccess):
 			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, sinks.ErrForbidden):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, sinks.ErrResyncRateLimited):
+			w.WriteHeader(http.StatusTooManyRequests)
+		case errors.Contains(errorVal, sinks.ErrConfigMisplaced):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, sinks.ErrInvalidSignalType):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, sinks.ErrTemplateCredentials):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, sinks.ErrUnsupportedAuthType):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, sinks.ErrTagLimitExceeded):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, sinks.ErrStateStreamUnavailable):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case errors.Contains(errorVal, sinks.ErrBackendUnavailable):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case errors.Contains(errorVal, sinks.ErrSinkInUse):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, sinks.ErrDependencyCheck):
+			w.WriteHeader(http.StatusServiceUnavailable)
 
 		case errors.Contains(errorVal, errors.ErrInvalidQueryParams):
 			w.WriteHeader(http.StatusBadRequest)
@@ -238,6 +549,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 		case errors.Contains(errorVal, errors.ErrInvalidEndpoint):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrInsecureEndpoint):
+			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrEndpointNotFound):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrBackendNotFound):
@@ -258,10 +571,22 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrAuthInvalidSchemeType):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrAuthCertificateNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrAuthInvalidCertificate):
+			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrAuthSchemeNotFound):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrAuthInvalidType):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrAuthServiceAccountKeyNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrAuthInvalidServiceAccountKey):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrProjectIDNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrInvalidProjectID):
+			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrRemoteHostNotFound):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrAuthFieldNotFound):
@@ -272,6 +597,10 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrInvalidBackend):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrBackendNotEnabled):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errors.ErrBackendNotStable):
+			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrEntityNameNotFound):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, errors.ErrMalformedEntity):
@@ -293,8 +622,19 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 		}
-		if errorVal.Msg() != "" {
-			if err := json.NewEncoder(w).Encode(types.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		msg := errorVal.Msg()
+		if datasets, ok := sinks.DependentDatasets(errorVal); ok {
+			if err := json.NewEncoder(w).Encode(sinkInUseRes{Err: msg, Datasets: datasets}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+		// the hint on where a misplaced field belongs is more useful than the operation that failed
+		if hint, ok := sinks.MisplacedConfigHint(errorVal); ok {
+			msg = hint
+		}
+		if msg != "" {
+			if err := json.NewEncoder(w).Encode(types.ErrorRes{Err: msg}); err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 			}
 		}
@@ -6,8 +6,10 @@ type AuthenticationType interface {
 	ValidateConfiguration(inputFormat string, input interface{}) error
 	ConfigToFormat(outputFormat string, input interface{}) (interface{}, error)
 	OmitInformation(outputFormat string, input interface{}) (interface{}, error)
-	EncodeInformation(outputFormat string, input interface{}) (interface{}, error)
-	DecodeInformation(outputFormat string, input interface{}) (interface{}, error)
+	// EncodeInformation encrypts the secrets of the authentication with the key of the owner of the sink
+	EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error)
+	// DecodeInformation decrypts the secrets encrypted by EncodeInformation for the same owner
+	DecodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error)
 }
 
 const AuthenticationKey = "authentication"
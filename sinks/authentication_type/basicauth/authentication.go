@@ -169,7 +169,7 @@ func (a *AuthConfig) OmitInformation(outputFormat string, input interface{}) (in
 	return nil, errors.New("unsupported format")
 }
 
-func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (interface{}, error) {
+func (a *AuthConfig) EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
 	switch input.(type) {
 	case types.Metadata:
 		inputMeta := input.(types.Metadata)
@@ -177,7 +177,7 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 		if _, ok := authMeta[PasswordConfigFeature].(string); !ok {
 			return nil, errors.Wrap(errors.ErrAuthPasswordNotFound, errors.New("password field was not found"))
 		}
-		encoded, err := a.encryptionService.EncodePassword(authMeta[PasswordConfigFeature].(string))
+		encoded, err := a.encryptionService.EncodeOwnerPassword(ownerID, authMeta[PasswordConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -197,7 +197,7 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 		}
 		inputMeta := iia.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
-		encoded, err := a.encryptionService.EncodePassword(authMeta[PasswordConfigFeature].(string))
+		encoded, err := a.encryptionService.EncodeOwnerPassword(ownerID, authMeta[PasswordConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -214,12 +214,12 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 	return nil, errors.New("unsupported format")
 }
 
-func (a *AuthConfig) DecodeInformation(outputFormat string, input interface{}) (interface{}, error) {
+func (a *AuthConfig) DecodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
 	switch input.(type) {
 	case types.Metadata:
 		inputMeta := input.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
-		decoded, err := a.encryptionService.DecodePassword(authMeta[PasswordConfigFeature].(string))
+		decoded, err := a.encryptionService.DecodeOwnerPassword(ownerID, authMeta[PasswordConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -239,7 +239,7 @@ func (a *AuthConfig) DecodeInformation(outputFormat string, input interface{}) (
 		}
 		inputMeta := iia.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
-		decoded, err := a.encryptionService.DecodePassword(authMeta[PasswordConfigFeature].(string))
+		decoded, err := a.encryptionService.DecodeOwnerPassword(ownerID, authMeta[PasswordConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
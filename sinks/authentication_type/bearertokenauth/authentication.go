@@ -164,7 +164,7 @@ func (a *AuthConfig) OmitInformation(outputFormat string, input any) (any, error
 	return nil, errors.New("unsupported format")
 }
 
-func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (interface{}, error) {
+func (a *AuthConfig) EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
 	switch input.(type) {
 	case types.Metadata:
 		inputMeta := input.(types.Metadata)
@@ -174,7 +174,7 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 			return nil, errors.Wrap(errors.ErrAuthTokenNotFound, errors.New("token field was not found"))
 		}
 
-		encoded, err := a.encryptionService.EncodePassword(authMeta[TokenConfigFeature].(string))
+		encoded, err := a.encryptionService.EncodeOwnerPassword(ownerID, authMeta[TokenConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -199,7 +199,7 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 		inputMeta := iia.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
 
-		encoded, err := a.encryptionService.EncodePassword(authMeta[TokenConfigFeature].(string))
+		encoded, err := a.encryptionService.EncodeOwnerPassword(ownerID, authMeta[TokenConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -221,13 +221,13 @@ func (a *AuthConfig) EncodeInformation(outputFormat string, input interface{}) (
 	return nil, errors.New("unsupported format")
 }
 
-func (a *AuthConfig) DecodeInformation(outputFormat string, input any) (any, error) {
+func (a *AuthConfig) DecodeInformation(ownerID string, outputFormat string, input any) (any, error) {
 	switch input.(type) {
 	case types.Metadata:
 		inputMeta := input.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
 
-		decoded, err := a.encryptionService.DecodePassword(authMeta[TokenConfigFeature].(string))
+		decoded, err := a.encryptionService.DecodeOwnerPassword(ownerID, authMeta[TokenConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -253,7 +253,7 @@ func (a *AuthConfig) DecodeInformation(outputFormat string, input any) (any, err
 		inputMeta := iia.(types.Metadata)
 		authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
 
-		decoded, err := a.encryptionService.DecodePassword(authMeta[TokenConfigFeature].(string))
+		decoded, err := a.encryptionService.DecodeOwnerPassword(ownerID, authMeta[TokenConfigFeature].(string))
 		if err != nil {
 			return nil, err
 		}
@@ -183,7 +183,7 @@ func TestAuthConfig_EncodeInformation(t *testing.T) {
 			encryptionService: authentication_type.NewPasswordService(nil, "test"),
 		}
 
-		_, err := a.EncodeInformation("blah", input)
+		_, err := a.EncodeInformation("owner-1", "blah", input)
 		assert.Error(t, err)
 	})
 
@@ -199,7 +199,7 @@ func TestAuthConfig_EncodeInformation(t *testing.T) {
 			encryptionService: authentication_type.NewPasswordService(nil, "test"),
 		}
 
-		_, err := a.EncodeInformation("object", input)
+		_, err := a.EncodeInformation("owner-1", "object", input)
 		require.NoError(t, err)
 	})
 }
@@ -217,7 +217,7 @@ func TestAuthConfig_DecodeInformation(t *testing.T) {
 			encryptionService: authentication_type.NewPasswordService(nil, "test"),
 		}
 
-		_, err := a.DecodeInformation("blah", input)
+		_, err := a.DecodeInformation("owner-1", "blah", input)
 		assert.Error(t, err)
 	})
 
@@ -240,7 +240,7 @@ func TestAuthConfig_DecodeInformation(t *testing.T) {
 			encryptionService: authentication_type.NewPasswordService(nil, "test"),
 		}
 
-		got, err := a.DecodeInformation("object", input)
+		got, err := a.DecodeInformation("owner-1", "object", input)
 		require.NoError(t, err)
 		assert.Equal(t, want, got)
 	})
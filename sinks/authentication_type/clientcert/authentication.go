@@ -0,0 +1,211 @@
+package clientcert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+const (
+	AuthType          = "clientcert"
+	CertConfigFeature = "cert"
+	KeyConfigFeature  = "key"
+	CAConfigFeature   = "ca"
+)
+
+var (
+	features = []authentication_type.ConfigFeature{
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "Client Certificate (PEM)",
+			Name:     CertConfigFeature,
+			Required: true,
+		},
+		{
+			Type:     backend.ConfigFeatureTypePassword,
+			Input:    "text",
+			Title:    "Client Key (PEM)",
+			Name:     KeyConfigFeature,
+			Required: true,
+		},
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "CA Certificate (PEM)",
+			Name:     CAConfigFeature,
+			Required: false,
+		},
+	}
+)
+
+type AuthConfig struct {
+	Cert              *string `json:"cert" yaml:"cert"`
+	Key               *string `json:"key" yaml:"key"`
+	CA                *string `json:"ca,omitempty" yaml:"ca,omitempty"`
+	encryptionService authentication_type.PasswordService
+}
+
+// NewTLSConfig builds the tls.Config used on outgoing connections from the client certificate fields
+func NewTLSConfig(cert string, key string, ca string) (*tls.Config, error) {
+	pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthInvalidCertificate, err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, errors.Wrap(errors.ErrAuthInvalidCertificate, errors.New("invalid authentication ca"))
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+func (a *AuthConfig) Metadata() authentication_type.AuthenticationTypeConfig {
+	return authentication_type.AuthenticationTypeConfig{
+		Type:        AuthType,
+		Description: "Client certificate (mTLS) authentication",
+		Config:      features,
+	}
+}
+
+func (a *AuthConfig) GetFeatureConfig() []authentication_type.ConfigFeature {
+	return features
+}
+
+func (a *AuthConfig) ValidateConfiguration(inputFormat string, input interface{}) error {
+	var cert, key, ca string
+	switch inputFormat {
+	case "object":
+		inputMeta := input.(types.Metadata)
+		for _, field := range []string{CertConfigFeature, KeyConfigFeature} {
+			if _, ok := inputMeta[field]; !ok {
+				return errors.Wrap(errors.ErrAuthCertificateNotFound, errors.New(field+" field was not found"))
+			}
+		}
+		for _, field := range []string{CertConfigFeature, KeyConfigFeature, CAConfigFeature} {
+			value, ok := inputMeta[field]
+			if !ok {
+				continue
+			}
+			if _, ok := value.(string); !ok {
+				return errors.Wrap(errors.ErrAuthInvalidCertificate, errors.New("invalid auth type for field: "+field))
+			}
+		}
+		cert = inputMeta[CertConfigFeature].(string)
+		key = inputMeta[KeyConfigFeature].(string)
+		ca, _ = inputMeta[CAConfigFeature].(string)
+	case "yaml":
+		err := yaml.Unmarshal([]byte(input.(string)), &a)
+		if err != nil {
+			return err
+		}
+		if a.Cert == nil || a.Key == nil {
+			return errors.Wrap(errors.ErrAuthCertificateNotFound, errors.New("cert and key fields were not found"))
+		}
+		cert = *a.Cert
+		key = *a.Key
+		if a.CA != nil {
+			ca = *a.CA
+		}
+	}
+
+	if len(strings.TrimSpace(cert)) == 0 || len(strings.TrimSpace(key)) == 0 {
+		return errors.Wrap(errors.ErrAuthInvalidCertificate, errors.New("invalid authentication cert or key"))
+	}
+	_, err := NewTLSConfig(cert, key, ca)
+	return err
+}
+
+func (a *AuthConfig) ConfigToFormat(outputFormat string, input interface{}) (interface{}, error) {
+	switch input.(type) {
+	case types.Metadata:
+		if outputFormat == "yaml" {
+			retVal, err := yaml.Marshal(input)
+			return string(retVal), err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	case string:
+		if outputFormat == "object" {
+			retVal := make(types.Metadata)
+			val := input.(string)
+			err := yaml.Unmarshal([]byte(val), &retVal)
+			return retVal, err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func (a *AuthConfig) OmitInformation(outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(string) (string, error) {
+		return "", nil
+	})
+}
+
+func (a *AuthConfig) EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(key string) (string, error) {
+		return a.encryptionService.EncodeOwnerPassword(ownerID, key)
+	})
+}
+
+func (a *AuthConfig) DecodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(key string) (string, error) {
+		return a.encryptionService.DecodeOwnerPassword(ownerID, key)
+	})
+}
+
+// changeKey replaces the private key of the authentication field with the result of change
+func (a *AuthConfig) changeKey(outputFormat string, input interface{}, change func(string) (string, error)) (interface{}, error) {
+	var inputMeta types.Metadata
+	switch input.(type) {
+	case types.Metadata:
+		inputMeta = input.(types.Metadata)
+	case string:
+		iia, err := a.ConfigToFormat("object", input)
+		if err != nil {
+			return nil, err
+		}
+		inputMeta = iia.(types.Metadata)
+	default:
+		return nil, errors.New("unsupported format")
+	}
+	authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
+	key, ok := authMeta[KeyConfigFeature].(string)
+	if !ok {
+		return nil, errors.Wrap(errors.ErrAuthCertificateNotFound, errors.New("key field was not found"))
+	}
+	changed, err := change(key)
+	if err != nil {
+		return nil, err
+	}
+	authMeta[KeyConfigFeature] = changed
+	inputMeta[authentication_type.AuthenticationKey] = authMeta
+	if outputFormat == "yaml" {
+		return a.ConfigToFormat("yaml", inputMeta)
+	} else if outputFormat == "object" {
+		return inputMeta, nil
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func Register(encryptionService authentication_type.PasswordService) {
+	clientCert := AuthConfig{
+		encryptionService: encryptionService,
+	}
+	authentication_type.Register(AuthType, &clientCert)
+}
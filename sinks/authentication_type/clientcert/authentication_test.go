@@ -0,0 +1,133 @@
+package clientcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+)
+
+func generateCertificate(t *testing.T) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "orb-sink"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return string(cert), string(key)
+}
+
+func TestAuthConfig_ValidateConfiguration(t *testing.T) {
+	cert, key := generateCertificate(t)
+	_, otherKey := generateCertificate(t)
+	tests := []struct {
+		name    string
+		input   types.Metadata
+		wantErr error
+	}{
+		{
+			name:    "missing_cert",
+			input:   types.Metadata{"key": key},
+			wantErr: errors.ErrAuthCertificateNotFound,
+		},
+		{
+			name:    "missing_key",
+			input:   types.Metadata{"cert": cert},
+			wantErr: errors.ErrAuthCertificateNotFound,
+		},
+		{
+			name:    "invalid_cert_type",
+			input:   types.Metadata{"cert": 1234, "key": key},
+			wantErr: errors.ErrAuthInvalidCertificate,
+		},
+		{
+			name:    "empty_key",
+			input:   types.Metadata{"cert": cert, "key": " "},
+			wantErr: errors.ErrAuthInvalidCertificate,
+		},
+		{
+			name:    "mismatched_key",
+			input:   types.Metadata{"cert": cert, "key": otherKey},
+			wantErr: errors.ErrAuthInvalidCertificate,
+		},
+		{
+			name:    "invalid_ca",
+			input:   types.Metadata{"cert": cert, "key": key, "ca": "not a pem"},
+			wantErr: errors.ErrAuthInvalidCertificate,
+		},
+		{
+			name:    "valid",
+			input:   types.Metadata{"cert": cert, "key": key},
+			wantErr: nil,
+		},
+		{
+			name:    "valid_with_ca",
+			input:   types.Metadata{"cert": cert, "key": key, "ca": cert},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a AuthConfig
+			err := a.ValidateConfiguration("object", tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthConfig_EncodeOmitInformation(t *testing.T) {
+	cert, key := generateCertificate(t)
+	a := AuthConfig{encryptionService: authentication_type.NewPasswordService(zap.NewNop(), "_testing_string_")}
+	config := types.Metadata{
+		authentication_type.AuthenticationKey: types.Metadata{"type": AuthType, "cert": cert, "key": key},
+	}
+
+	encoded, err := a.EncodeInformation("owner-1", "object", config)
+	require.NoError(t, err)
+	encodedMeta := encoded.(types.Metadata)
+	authMeta := encodedMeta.GetSubMetadata(authentication_type.AuthenticationKey)
+	assert.NotEqual(t, key, authMeta["key"])
+	assert.Equal(t, cert, authMeta["cert"])
+
+	decoded, err := a.DecodeInformation("owner-1", "object", encoded)
+	require.NoError(t, err)
+	decodedMeta := decoded.(types.Metadata)
+	authMeta = decodedMeta.GetSubMetadata(authentication_type.AuthenticationKey)
+	assert.Equal(t, key, authMeta["key"])
+
+	omitted, err := a.OmitInformation("object", decoded)
+	require.NoError(t, err)
+	omittedMeta := omitted.(types.Metadata)
+	authMeta = omittedMeta.GetSubMetadata(authentication_type.AuthenticationKey)
+	assert.Equal(t, "", authMeta["key"])
+	assert.Equal(t, cert, authMeta["cert"])
+}
@@ -0,0 +1,222 @@
+package multiauth
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+)
+
+const (
+	AuthType = "multiauth"
+)
+
+var (
+	features = []authentication_type.ConfigFeature{}
+	// Authenticators are the authentication types the collector applies with an authenticator extension,
+	// the exporter of a sink takes a single one
+	Authenticators = []string{basicauth.AuthType, bearertokenauth.AuthType}
+)
+
+// AuthConfig handles an authentication field set as a list of authentication blocks,
+// each block is validated, encrypted and omitted by its own registered type
+type AuthConfig struct {
+}
+
+// IsMultiAuth returns true when the authentication field of the config is a list of blocks
+func IsMultiAuth(config types.Metadata) bool {
+	switch config[authentication_type.AuthenticationKey].(type) {
+	case []interface{}, []types.Metadata, []map[string]interface{}:
+		return true
+	}
+	return false
+}
+
+// Blocks returns every authentication block of a multiple authentication field
+func Blocks(input interface{}) ([]types.Metadata, error) {
+	var blocks []types.Metadata
+	switch input.(type) {
+	case []types.Metadata:
+		blocks = input.([]types.Metadata)
+	case []map[string]interface{}:
+		for _, block := range input.([]map[string]interface{}) {
+			blocks = append(blocks, types.FromMap(block))
+		}
+	case []interface{}:
+		for _, block := range input.([]interface{}) {
+			switch block.(type) {
+			case types.Metadata:
+				blocks = append(blocks, block.(types.Metadata))
+			case map[string]interface{}:
+				blocks = append(blocks, types.FromMap(block.(map[string]interface{})))
+			default:
+				return nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication block"))
+			}
+		}
+	default:
+		return nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("authentication field must be a list"))
+	}
+	return blocks, nil
+}
+
+func (a *AuthConfig) Metadata() authentication_type.AuthenticationTypeConfig {
+	return authentication_type.AuthenticationTypeConfig{
+		Type:        AuthType,
+		Description: "Multiple authentication types applied together, set as a list of authentication blocks",
+		Config:      features,
+	}
+}
+
+func (a *AuthConfig) GetFeatureConfig() []authentication_type.ConfigFeature {
+	return features
+}
+
+func (a *AuthConfig) ValidateConfiguration(inputFormat string, input interface{}) error {
+	var blocks []types.Metadata
+	var err error
+	switch inputFormat {
+	case "object":
+		blocks, err = Blocks(input)
+		if err != nil {
+			return err
+		}
+	case "yaml":
+		var helper []interface{}
+		if err := yaml.Unmarshal([]byte(input.(string)), &helper); err != nil {
+			return err
+		}
+		blocks, err = Blocks(helper)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(blocks) == 0 {
+		return errors.Wrap(errors.ErrAuthFieldNotFound, errors.New("at least one authentication must be set"))
+	}
+	seen := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		authType, err := getBlockAuthType(block)
+		if err != nil {
+			return err
+		}
+		name := block["type"].(string)
+		if seen[name] {
+			return errors.Wrap(errors.ErrAuthInvalidType, errors.New("authentication type set more than once: "+name))
+		}
+		seen[name] = true
+		if err := authType.ValidateConfiguration("object", block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AuthConfig) ConfigToFormat(outputFormat string, input interface{}) (interface{}, error) {
+	switch input.(type) {
+	case types.Metadata:
+		if outputFormat == "yaml" {
+			retVal, err := yaml.Marshal(input)
+			return string(retVal), err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	case string:
+		if outputFormat == "object" {
+			retVal := make(types.Metadata)
+			val := input.(string)
+			err := yaml.Unmarshal([]byte(val), &retVal)
+			return retVal, err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func (a *AuthConfig) OmitInformation(outputFormat string, input interface{}) (interface{}, error) {
+	return a.applyToBlocks(outputFormat, input, func(authType authentication_type.AuthenticationType, block types.Metadata) (interface{}, error) {
+		return authType.OmitInformation("object", block)
+	})
+}
+
+func (a *AuthConfig) EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.applyToBlocks(outputFormat, input, func(authType authentication_type.AuthenticationType, block types.Metadata) (interface{}, error) {
+		return authType.EncodeInformation(ownerID, "object", block)
+	})
+}
+
+func (a *AuthConfig) DecodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.applyToBlocks(outputFormat, input, func(authType authentication_type.AuthenticationType, block types.Metadata) (interface{}, error) {
+		return authType.DecodeInformation(ownerID, "object", block)
+	})
+}
+
+// applyToBlocks calls apply with each block's own authentication type
+func (a *AuthConfig) applyToBlocks(outputFormat string, input interface{},
+	apply func(authentication_type.AuthenticationType, types.Metadata) (interface{}, error)) (interface{}, error) {
+	var inputMeta types.Metadata
+	switch input.(type) {
+	case types.Metadata:
+		inputMeta = input.(types.Metadata)
+	case string:
+		iia, err := a.ConfigToFormat("object", input)
+		if err != nil {
+			return nil, err
+		}
+		inputMeta = iia.(types.Metadata)
+	default:
+		return nil, errors.New("unsupported format")
+	}
+
+	blocks, err := Blocks(inputMeta[authentication_type.AuthenticationKey])
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		authType, err := getBlockAuthType(block)
+		if err != nil {
+			return nil, err
+		}
+		blockResult, err := apply(authType, types.Metadata{authentication_type.AuthenticationKey: block})
+		if err != nil {
+			return nil, err
+		}
+		resultMeta := blockResult.(types.Metadata)
+		result = append(result, resultMeta.GetSubMetadata(authentication_type.AuthenticationKey))
+	}
+	inputMeta[authentication_type.AuthenticationKey] = result
+
+	if outputFormat == "yaml" {
+		return a.ConfigToFormat("yaml", inputMeta)
+	} else if outputFormat == "object" {
+		return inputMeta, nil
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func getBlockAuthType(block types.Metadata) (authentication_type.AuthenticationType, error) {
+	value, ok := block["type"]
+	if !ok {
+		return nil, errors.Wrap(errors.ErrAuthTypeNotFound, errors.New("authentication type not found"))
+	}
+	name, ok := value.(string)
+	if !ok || name == AuthType {
+		return nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication type"))
+	}
+	authType, ok := authentication_type.GetAuthType(name)
+	if !ok {
+		return nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication type"))
+	}
+	return authType, nil
+}
+
+func Register() {
+	multiAuth := AuthConfig{}
+	authentication_type.Register(AuthType, &multiAuth)
+}
@@ -0,0 +1,125 @@
+package multiauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+)
+
+func registerAuthTypes() {
+	passwordService := authentication_type.NewPasswordService(zap.NewNop(), "_testing_string_")
+	basicauth.Register(passwordService)
+	bearertokenauth.Register(passwordService)
+	Register()
+}
+
+func TestAuthConfig_ValidateConfiguration(t *testing.T) {
+	registerAuthTypes()
+	tests := []struct {
+		name    string
+		input   interface{}
+		wantErr error
+	}{
+		{
+			name:    "not_a_list",
+			input:   types.Metadata{"type": "basicauth"},
+			wantErr: errors.ErrAuthInvalidType,
+		},
+		{
+			name:    "empty_list",
+			input:   []interface{}{},
+			wantErr: errors.ErrAuthFieldNotFound,
+		},
+		{
+			name: "missing_type",
+			input: []interface{}{
+				map[string]interface{}{"username": "test-user", "password": "test-password"},
+			},
+			wantErr: errors.ErrAuthTypeNotFound,
+		},
+		{
+			name: "nested_multiauth",
+			input: []interface{}{
+				map[string]interface{}{"type": AuthType},
+			},
+			wantErr: errors.ErrAuthInvalidType,
+		},
+		{
+			name: "duplicated_type",
+			input: []interface{}{
+				map[string]interface{}{"type": "basicauth", "username": "test-user", "password": "test-password"},
+				map[string]interface{}{"type": "basicauth", "username": "other-user", "password": "test-password"},
+			},
+			wantErr: errors.ErrAuthInvalidType,
+		},
+		{
+			name: "invalid_block",
+			input: []interface{}{
+				map[string]interface{}{"type": "basicauth", "username": "test-user"},
+			},
+			wantErr: errors.ErrAuthPasswordNotFound,
+		},
+		{
+			name: "valid",
+			input: []interface{}{
+				map[string]interface{}{"type": "basicauth", "username": "test-user", "password": "test-password"},
+				map[string]interface{}{"type": "bearertokenauth", "scheme": "Bearer", "token": "test-token"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a AuthConfig
+			err := a.ValidateConfiguration("object", tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthConfig_EncodeDecodeInformation(t *testing.T) {
+	registerAuthTypes()
+	var a AuthConfig
+	config := types.Metadata{
+		"exporter": types.Metadata{"remote_host": "https://orb.community/"},
+		authentication_type.AuthenticationKey: []interface{}{
+			map[string]interface{}{"type": "basicauth", "username": "test-user", "password": "test-password"},
+			map[string]interface{}{"type": "bearertokenauth", "scheme": "Bearer", "token": "test-token"},
+		},
+	}
+
+	encoded, err := a.EncodeInformation("owner-1", "object", config)
+	require.NoError(t, err)
+	blocks, err := Blocks(encoded.(types.Metadata)[authentication_type.AuthenticationKey])
+	require.NoError(t, err)
+	assert.NotEqual(t, "test-password", blocks[0]["password"])
+	assert.NotEqual(t, "test-token", blocks[1]["token"])
+
+	decoded, err := a.DecodeInformation("owner-1", "object", encoded)
+	require.NoError(t, err)
+	blocks, err = Blocks(decoded.(types.Metadata)[authentication_type.AuthenticationKey])
+	require.NoError(t, err)
+	assert.Equal(t, "test-password", blocks[0]["password"])
+	assert.Equal(t, "test-token", blocks[1]["token"])
+
+	omitted, err := a.OmitInformation("object", decoded)
+	require.NoError(t, err)
+	blocks, err = Blocks(omitted.(types.Metadata)[authentication_type.AuthenticationKey])
+	require.NoError(t, err)
+	assert.Equal(t, "", blocks[0]["password"])
+	assert.Equal(t, "test-user", blocks[0]["username"])
+	assert.Equal(t, "", blocks[1]["token"])
+}
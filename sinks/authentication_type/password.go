@@ -6,14 +6,33 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/hkdf"
 	"io"
+	"strings"
 )
 
+// ownerKeyInfo binds the keys derived from the master key to their use on sink credentials
+const ownerKeyInfo = "orb-sink-credentials:"
+
+// OwnerKeyPrefix marks the secrets encrypted with the key of their owner, the others were encrypted with the
+// deployment key
+const OwnerKeyPrefix = "ownerkey:"
+
 type PasswordService interface {
 	EncodePassword(plainText string) (string, error)
 	SetKey(newKey string)
 	DecodePassword(cipheredText string) (string, error)
+	// EncodeOwnerPassword encrypts with the key of the owner when per owner keys are enabled,
+	// otherwise with the deployment key as EncodePassword
+	EncodeOwnerPassword(ownerID string, plainText string) (string, error)
+	// DecodeOwnerPassword decrypts with the key of the owner the secrets encrypted with it, the secrets
+	// encrypted before per owner keys were enabled are decrypted with the deployment key
+	DecodeOwnerPassword(ownerID string, cipheredText string) (string, error)
+	// DeleteSecret removes from the secret store the secret of a reference returned by the encode methods, the
+	// encrypted secrets are only kept on the sink config so there is nothing to remove for them
+	DeleteSecret(encoded string) error
 }
 
 func NewPasswordService(logger *zap.Logger, key string) *passwordService {
@@ -24,12 +43,35 @@ func NewPasswordService(logger *zap.Logger, key string) *passwordService {
 	return ps
 }
 
+// NewPerOwnerPasswordService creates a password service deriving a distinct key for each owner from
+// the master key with HKDF, so the credentials of an owner can't be decrypted with the key of another one
+func NewPerOwnerPasswordService(logger *zap.Logger, masterKey string) *passwordService {
+	ps := NewPasswordService(logger, masterKey)
+	ps.perOwner = true
+	return ps
+}
+
+// NewExternalPasswordService creates a password service keeping the secrets in the store, only their
+// references are returned to be saved on the sink config. The secrets encrypted with key before the store
+// was configured are still decrypted
+func NewExternalPasswordService(logger *zap.Logger, key string, store SecretStore) *passwordService {
+	ps := NewPasswordService(logger, key)
+	ps.store = store
+	return ps
+}
+
 type passwordService struct {
-	key    string
+	key      string
+	perOwner bool
+	// store keeps the secrets out of the database when set
+	store  SecretStore
 	logger *zap.Logger
 }
 
 func (ps *passwordService) EncodePassword(plainText string) (string, error) {
+	if ps.store != nil {
+		return ps.storeSecret("", plainText)
+	}
 	cipherText, err := encrypt([]byte(plainText), ps.key)
 	if err != nil {
 		ps.logger.Error("failed to encrypt password", zap.Error(err))
@@ -43,6 +85,9 @@ func (ps *passwordService) SetKey(newKey string) {
 }
 
 func (ps *passwordService) DecodePassword(cipheredText string) (string, error) {
+	if strings.HasPrefix(cipheredText, SecretRefPrefix) {
+		return ps.resolveSecret(cipheredText)
+	}
 	hexedByte, err := hex.DecodeString(cipheredText)
 	if err != nil {
 		ps.logger.Error("failed to decode password", zap.Error(err))
@@ -57,8 +102,121 @@ func (ps *passwordService) DecodePassword(cipheredText string) (string, error) {
 	return string(plainByte), nil
 }
 
+func (ps *passwordService) EncodeOwnerPassword(ownerID string, plainText string) (string, error) {
+	if ps.store != nil {
+		return ps.storeSecret(ownerID, plainText)
+	}
+	if !ps.perOwner {
+		return ps.EncodePassword(plainText)
+	}
+	key, err := deriveOwnerKey(ps.key, ownerID)
+	if err != nil {
+		ps.logger.Error("failed to derive owner key", zap.String("owner_id", ownerID), zap.Error(err))
+		return "", err
+	}
+	cipherText, err := encryptWithKey([]byte(plainText), key)
+	if err != nil {
+		ps.logger.Error("failed to encrypt password", zap.String("owner_id", ownerID), zap.Error(err))
+		return "", err
+	}
+	return OwnerKeyPrefix + cipherText, nil
+}
+
+func (ps *passwordService) DecodeOwnerPassword(ownerID string, cipheredText string) (string, error) {
+	if strings.HasPrefix(cipheredText, SecretRefPrefix) {
+		return ps.resolveSecret(cipheredText)
+	}
+	if !strings.HasPrefix(cipheredText, OwnerKeyPrefix) {
+		return ps.DecodePassword(cipheredText)
+	}
+	hexedByte, err := hex.DecodeString(strings.TrimPrefix(cipheredText, OwnerKeyPrefix))
+	if err != nil {
+		ps.logger.Error("failed to decode password", zap.Error(err))
+		return "", err
+	}
+	key, err := deriveOwnerKey(ps.key, ownerID)
+	if err != nil {
+		ps.logger.Error("failed to derive owner key", zap.String("owner_id", ownerID), zap.Error(err))
+		return "", err
+	}
+	plainByte, err := decryptWithKey(hexedByte, key)
+	if err != nil {
+		ps.logger.Error("failed to decrypt password", zap.String("owner_id", ownerID), zap.Error(err))
+		return "", err
+	}
+
+	return string(plainByte), nil
+}
+
+// IsEncoded reports whether the value is a secret encoded by a PasswordService, as opposed to a plain text one
+func IsEncoded(value string) bool {
+	if strings.HasPrefix(value, SecretRefPrefix) {
+		return true
+	}
+	_, err := hex.DecodeString(strings.TrimPrefix(value, OwnerKeyPrefix))
+	return err == nil
+}
+
+// IsOwnerEncoded reports whether the secret is bound to its owner, encrypted with the key of the owner or kept
+// in the secret store. The other encoded secrets were encrypted with the deployment key
+func IsOwnerEncoded(value string) bool {
+	return strings.HasPrefix(value, OwnerKeyPrefix) || strings.HasPrefix(value, SecretRefPrefix)
+}
+
+func (ps *passwordService) storeSecret(ownerID string, plainText string) (string, error) {
+	ref, err := ps.store.Put(ownerID, plainText)
+	if err != nil {
+		ps.logger.Error("failed to store secret", zap.String("owner_id", ownerID), zap.Error(err))
+		return "", err
+	}
+	return SecretRefPrefix + ref, nil
+}
+
+func (ps *passwordService) DeleteSecret(encoded string) error {
+	if !strings.HasPrefix(encoded, SecretRefPrefix) {
+		return nil
+	}
+	if ps.store == nil {
+		return ErrNoSecretStore
+	}
+	if err := ps.store.Delete(strings.TrimPrefix(encoded, SecretRefPrefix)); err != nil {
+		ps.logger.Error("failed to delete secret", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (ps *passwordService) resolveSecret(ref string) (string, error) {
+	if ps.store == nil {
+		ps.logger.Error("failed to resolve secret reference, no secret store is configured")
+		return "", ErrNoSecretStore
+	}
+	plainText, err := ps.store.Get(strings.TrimPrefix(ref, SecretRefPrefix))
+	if err != nil {
+		ps.logger.Error("failed to resolve secret reference", zap.Error(err))
+		return "", err
+	}
+	return plainText, nil
+}
+
+// deriveOwnerKey derives the AES-256 key of the owner from the master key
+func deriveOwnerKey(masterKey string, ownerID string) ([]byte, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(masterKey), nil, []byte(ownerKeyInfo+ownerID)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 func encrypt(data []byte, passphrase string) (string, error) {
-	block, _ := aes.NewCipher(createHash(passphrase))
+	return encryptWithKey(data, createHash(passphrase))
+}
+
+func encryptWithKey(data []byte, key []byte) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
 		return "", err
@@ -72,7 +230,10 @@ func encrypt(data []byte, passphrase string) (string, error) {
 }
 
 func decrypt(data []byte, passphrase string) ([]byte, error) {
-	key := createHash(passphrase)
+	return decryptWithKey(data, createHash(passphrase))
+}
+
+func decryptWithKey(data []byte, key []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
@@ -82,6 +243,9 @@ func decrypt(data []byte, passphrase string) ([]byte, error) {
 		return nil, err
 	}
 	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("ciphered text is shorter than the nonce")
+	}
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
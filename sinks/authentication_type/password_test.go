@@ -1,6 +1,7 @@
 package authentication_type
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"testing"
@@ -57,3 +58,102 @@ func Test_passwordService_EncodePassword(t *testing.T) {
 		})
 	}
 }
+
+func Test_passwordService_EncodeOwnerPassword(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	const (
+		key    = "eb1bc7f4-2031-41c4-85fa-2ddce3abfc3b"
+		owner  = "2b0d5d0a-6d2c-4c5b-9c0a-3f2e3c1b6b1a"
+		other  = "8a2b6f7e-0c9d-4a43-9d6f-1b2c3d4e5f60"
+		secret = "test"
+	)
+
+	t.Run("per owner keys disabled uses the deployment key", func(t *testing.T) {
+		ps := NewPasswordService(logger, key)
+		encoded, err := ps.EncodeOwnerPassword(owner, secret)
+		assert.NoError(t, err)
+		decoded, err := ps.DecodePassword(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, decoded)
+	})
+
+	t.Run("per owner keys enabled", func(t *testing.T) {
+		ps := NewPerOwnerPasswordService(logger, key)
+		encoded, err := ps.EncodeOwnerPassword(owner, secret)
+		assert.NoError(t, err)
+
+		decoded, err := ps.DecodeOwnerPassword(owner, encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, decoded)
+
+		_, err = ps.DecodeOwnerPassword(other, encoded)
+		assert.Error(t, err, "the key of another owner must not decrypt the password")
+		_, err = ps.DecodePassword(encoded)
+		assert.Error(t, err, "the master key must not decrypt the password directly")
+		assert.True(t, IsEncoded(encoded))
+		assert.True(t, IsOwnerEncoded(encoded))
+	})
+
+	t.Run("secrets encrypted before per owner keys were enabled", func(t *testing.T) {
+		legacy, err := NewPasswordService(logger, key).EncodePassword(secret)
+		assert.NoError(t, err)
+		assert.True(t, IsEncoded(legacy))
+		assert.False(t, IsOwnerEncoded(legacy), "the secret has to be re-encrypted with the key of its owner")
+
+		decoded, err := NewPerOwnerPasswordService(logger, key).DecodeOwnerPassword(owner, legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, decoded)
+	})
+}
+
+// memorySecretStore keeps the secrets in memory
+type memorySecretStore struct {
+	secrets map[string]string
+}
+
+func (s *memorySecretStore) Put(ownerID string, secret string) (string, error) {
+	ref := fmt.Sprintf("%s/%d", ownerID, len(s.secrets))
+	s.secrets[ref] = secret
+	return ref, nil
+}
+
+func (s *memorySecretStore) Get(ref string) (string, error) {
+	secret, ok := s.secrets[ref]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", ref)
+	}
+	return secret, nil
+}
+
+func (s *memorySecretStore) Delete(ref string) error {
+	delete(s.secrets, ref)
+	return nil
+}
+
+func Test_passwordService_SecretStore(t *testing.T) {
+	logger := zap.NewNop()
+	store := &memorySecretStore{secrets: make(map[string]string)}
+	legacy, err := NewPasswordService(logger, "testing").EncodePassword("legacy")
+	assert.NoError(t, err)
+
+	ps := NewExternalPasswordService(logger, "testing", store)
+	ref, err := ps.EncodeOwnerPassword("owner-1", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, SecretRefPrefix+"owner-1/0", ref, "only the reference must be returned")
+	assert.Equal(t, "test", store.secrets["owner-1/0"])
+
+	plain, err := ps.DecodeOwnerPassword("owner-1", ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", plain)
+
+	plain, err = ps.DecodePassword(legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", plain, "secrets encrypted before the store must still be decrypted")
+
+	_, err = NewPasswordService(logger, "testing").DecodePassword(ref)
+	assert.ErrorIs(t, err, ErrNoSecretStore)
+
+	assert.NoError(t, ps.DeleteSecret(legacy), "an encrypted secret has nothing to delete")
+	assert.NoError(t, ps.DeleteSecret(ref))
+	assert.Empty(t, store.secrets, "the secret of the reference should be deleted")
+}
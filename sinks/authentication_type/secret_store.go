@@ -0,0 +1,20 @@
+package authentication_type
+
+import "errors"
+
+// SecretRefPrefix marks the sink config values which are references to a SecretStore, not encrypted secrets
+const SecretRefPrefix = "secretref:"
+
+// ErrNoSecretStore indicates a secret reference was found while no secret store is configured
+var ErrNoSecretStore = errors.New("secret reference found but no secret store is configured")
+
+// SecretStore keeps the sink secrets in an external secret manager, so only references to them are saved
+// in the database
+type SecretStore interface {
+	// Put saves a new secret of the owner, empty for the deployment wide ones, and returns its reference
+	Put(ownerID string, secret string) (string, error)
+	// Get returns the secret of a reference returned by Put
+	Get(ref string) (string, error)
+	// Delete removes the secret of a reference returned by Put, removing a missing secret is not an error
+	Delete(ref string) error
+}
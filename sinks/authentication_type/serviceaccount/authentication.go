@@ -0,0 +1,180 @@
+package serviceaccount
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+const (
+	AuthType         = "serviceaccount"
+	KeyConfigFeature = "key"
+	// keyType is the type of the JSON keys created for Google Cloud service accounts
+	keyType = "service_account"
+)
+
+var (
+	features = []authentication_type.ConfigFeature{
+		{
+			Type:     backend.ConfigFeatureTypePassword,
+			Input:    "text",
+			Title:    "Service Account Key (JSON)",
+			Name:     KeyConfigFeature,
+			Required: true,
+		},
+	}
+)
+
+type AuthConfig struct {
+	Key               *string `json:"key" yaml:"key"`
+	encryptionService authentication_type.PasswordService
+}
+
+// Key holds the fields of a Google Cloud service account JSON key used to authenticate the exporter
+type Key struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ParseKey parses a service account JSON key, checking it carries the account and its private key
+func ParseKey(key string) (Key, error) {
+	var parsed Key
+	if err := json.Unmarshal([]byte(key), &parsed); err != nil {
+		return Key{}, errors.Wrap(errors.ErrAuthInvalidServiceAccountKey, err)
+	}
+	if parsed.Type != keyType {
+		return Key{}, errors.Wrap(errors.ErrAuthInvalidServiceAccountKey, errors.New("key type must be "+keyType))
+	}
+	if strings.TrimSpace(parsed.ClientEmail) == "" || strings.TrimSpace(parsed.PrivateKey) == "" {
+		return Key{}, errors.Wrap(errors.ErrAuthInvalidServiceAccountKey, errors.New("client_email and private_key are expected on the key"))
+	}
+	return parsed, nil
+}
+
+func (a *AuthConfig) Metadata() authentication_type.AuthenticationTypeConfig {
+	return authentication_type.AuthenticationTypeConfig{
+		Type:        AuthType,
+		Description: "Google Cloud service account key authentication",
+		Config:      features,
+	}
+}
+
+func (a *AuthConfig) GetFeatureConfig() []authentication_type.ConfigFeature {
+	return features
+}
+
+func (a *AuthConfig) ValidateConfiguration(inputFormat string, input interface{}) error {
+	var key string
+	switch inputFormat {
+	case "object":
+		value, ok := input.(types.Metadata)[KeyConfigFeature]
+		if !ok {
+			return errors.Wrap(errors.ErrAuthServiceAccountKeyNotFound, errors.New("key field was not found"))
+		}
+		if key, ok = value.(string); !ok {
+			return errors.Wrap(errors.ErrAuthInvalidServiceAccountKey, errors.New("invalid auth type for field: "+KeyConfigFeature))
+		}
+	case "yaml":
+		err := yaml.Unmarshal([]byte(input.(string)), &a)
+		if err != nil {
+			return err
+		}
+		if a.Key == nil {
+			return errors.Wrap(errors.ErrAuthServiceAccountKeyNotFound, errors.New("key field was not found"))
+		}
+		key = *a.Key
+	}
+	_, err := ParseKey(key)
+	return err
+}
+
+func (a *AuthConfig) ConfigToFormat(outputFormat string, input interface{}) (interface{}, error) {
+	switch input.(type) {
+	case types.Metadata:
+		if outputFormat == "yaml" {
+			retVal, err := yaml.Marshal(input)
+			return string(retVal), err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	case string:
+		if outputFormat == "object" {
+			retVal := make(types.Metadata)
+			val := input.(string)
+			err := yaml.Unmarshal([]byte(val), &retVal)
+			return retVal, err
+		} else {
+			return nil, errors.New("unsupported format")
+		}
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func (a *AuthConfig) OmitInformation(outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(string) (string, error) {
+		return "", nil
+	})
+}
+
+func (a *AuthConfig) EncodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(key string) (string, error) {
+		return a.encryptionService.EncodeOwnerPassword(ownerID, key)
+	})
+}
+
+func (a *AuthConfig) DecodeInformation(ownerID string, outputFormat string, input interface{}) (interface{}, error) {
+	return a.changeKey(outputFormat, input, func(key string) (string, error) {
+		return a.encryptionService.DecodeOwnerPassword(ownerID, key)
+	})
+}
+
+// changeKey replaces the service account key of the authentication field with the result of change
+func (a *AuthConfig) changeKey(outputFormat string, input interface{}, change func(string) (string, error)) (interface{}, error) {
+	var inputMeta types.Metadata
+	switch input.(type) {
+	case types.Metadata:
+		inputMeta = input.(types.Metadata)
+	case string:
+		iia, err := a.ConfigToFormat("object", input)
+		if err != nil {
+			return nil, err
+		}
+		inputMeta = iia.(types.Metadata)
+	default:
+		return nil, errors.New("unsupported format")
+	}
+	authMeta := inputMeta.GetSubMetadata(authentication_type.AuthenticationKey)
+	key, ok := authMeta[KeyConfigFeature].(string)
+	if !ok {
+		return nil, errors.Wrap(errors.ErrAuthServiceAccountKeyNotFound, errors.New("key field was not found"))
+	}
+	changed, err := change(key)
+	if err != nil {
+		return nil, err
+	}
+	authMeta[KeyConfigFeature] = changed
+	inputMeta[authentication_type.AuthenticationKey] = authMeta
+	if outputFormat == "yaml" {
+		return a.ConfigToFormat("yaml", inputMeta)
+	} else if outputFormat == "object" {
+		return inputMeta, nil
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func Register(encryptionService authentication_type.PasswordService) {
+	serviceAccount := AuthConfig{
+		encryptionService: encryptionService,
+	}
+	authentication_type.Register(AuthType, &serviceAccount)
+}
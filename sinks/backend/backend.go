@@ -5,6 +5,8 @@
 package backend
 
 import (
+	"sort"
+
 	"github.com/orb-community/orb/pkg/types"
 )
 
@@ -14,22 +16,103 @@ type Backend interface {
 	ValidateConfiguration(config types.Metadata) error
 	ParseConfig(format string, config string) (types.Metadata, error)
 	ConfigToFormat(format string, metadata types.Metadata) (string, error)
+	// SupportedSignals lists the telemetry signal types the backend accepts
+	SupportedSignals() []string
+	// Maturity is the MaturityStable, MaturityBeta or MaturityExperimental level of the backend
+	Maturity() string
+	// SupportedAuthTypes lists the authentication types the backend accepts, each block of a multiple
+	// authentication must be one of them
+	SupportedAuthTypes() []string
+	// NormalizeLabels rewrites the Orb metric labels to names accepted by the backend
+	NormalizeLabels(labels map[string]string) map[string]string
+	// Normalize returns a copy of the exporter config with its known fields in canonical form, so equivalent
+	// configs compare equal. It runs before the validation and the storage of the config
+	Normalize(config types.Metadata) types.Metadata
+}
+
+// SecretHeaders is implemented by the backends whose custom exporter headers can carry secrets
+type SecretHeaders interface {
+	// IsSecretHeader reports whether the value of the custom header is encrypted at rest and omitted on view
+	IsSecretHeader(name string) bool
+}
+
+// Wrapper is implemented by the backends forwarding the data of the sink to an inner backend set on their
+// exporter config
+type Wrapper interface {
+	// Inner returns the inner backend and its exporter config, false when the config does not set a registered one
+	Inner(config types.Metadata) (Backend, types.Metadata, bool)
+}
+
+// DiskQueue is implemented by the backends whose collector exporter can keep its sending queue in a file storage,
+// the ones a store-and-forward sink can wrap
+type DiskQueue interface {
+	// QueuesOnDisk reports whether the collector exporter of the backend accepts a persistent sending queue
+	QueuesOnDisk() bool
+}
+
+// Unwrap returns the backend the data of the sink is written to and its exporter config, which is the inner
+// backend of a Wrapper. Any other backend is returned as it is
+func Unwrap(b Backend, config types.Metadata) (Backend, types.Metadata) {
+	if w, ok := b.(Wrapper); ok {
+		if inner, innerConfig, ok := w.Inner(config); ok {
+			return inner, innerConfig
+		}
+	}
+	return b, config
+}
+
+const SignalMetrics = "metrics"
+const SignalLogs = "logs"
+const SignalTraces = "traces"
+
+// IsValidSignal reports whether signal is one of the known telemetry signal types
+func IsValidSignal(signal string) bool {
+	switch signal {
+	case SignalMetrics, SignalLogs, SignalTraces:
+		return true
+	}
+	return false
+}
+
+// SupportsSignal reports whether the backend accepts the signal
+func SupportsSignal(b Backend, signal string) bool {
+	for _, supported := range b.SupportedSignals() {
+		if supported == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsAuthType reports whether the backend accepts the authentication type
+func SupportsAuthType(b Backend, authType string) bool {
+	for _, supported := range b.SupportedAuthTypes() {
+		if supported == authType {
+			return true
+		}
+	}
+	return false
 }
 
 const ConfigFeatureTypePassword = "password"
 const ConfigFeatureTypeText = "text"
+const ConfigFeatureTypeMap = "map"
 
 type ConfigFeature struct {
-	Type     string `json:"type"`
-	Input    string `json:"input"`
-	Title    string `json:"title"`
-	Name     string `json:"name"`
-	Required bool   `json:"required"`
+	Type     string   `json:"type"`
+	Input    string   `json:"input"`
+	Title    string   `json:"title"`
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
 }
 
 type SinkFeature struct {
 	Backend     string          `json:"backend"`
 	Description string          `json:"description"`
+	Signals     []string        `json:"signals"`
+	Maturity    string          `json:"maturity"`
+	AuthTypes   []string        `json:"auth_types"`
 	Config      []ConfigFeature `json:"config"`
 }
 
@@ -39,11 +122,13 @@ func Register(name string, b Backend) {
 	registry[name] = b
 }
 
+// GetList returns the names of the registered backends in alphabetical order
 func GetList() []string {
 	keys := make([]string, 0, len(registry))
 	for k := range registry {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
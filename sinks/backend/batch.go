@@ -0,0 +1,80 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+const (
+	// BatchSizeConfigFeature is the optional exporter field with the number of data points sent per batch
+	BatchSizeConfigFeature = "batch_size"
+	// FlushIntervalConfigFeature is the optional exporter field with how long a batch waits before being sent
+	FlushIntervalConfigFeature = "flush_interval"
+)
+
+const ConfigFeatureTypeNumber = "number"
+
+// BatchConfigFeatures documents the optional batching fields shared by the exporter configs
+func BatchConfigFeatures() []ConfigFeature {
+	return []ConfigFeature{
+		{
+			Type:     ConfigFeatureTypeNumber,
+			Input:    "number",
+			Title:    "Batch Size",
+			Name:     BatchSizeConfigFeature,
+			Required: false,
+		},
+		{
+			Type:     ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "Flush Interval",
+			Name:     FlushIntervalConfigFeature,
+			Required: false,
+		},
+	}
+}
+
+// ValidateBatchConfig checks the optional batching fields of an exporter config, the batch size must be
+// a positive integer and the flush interval a positive duration such as "5s"
+func ValidateBatchConfig(config types.Metadata) error {
+	if value, ok := config[BatchSizeConfigFeature]; ok {
+		size, ok := BatchSize(value)
+		if !ok || size <= 0 {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("batch_size must be a positive integer"))
+		}
+	}
+	if value, ok := config[FlushIntervalConfigFeature]; ok {
+		str, ok := value.(string)
+		if !ok {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("flush_interval must be a duration"))
+		}
+		interval, err := time.ParseDuration(str)
+		if err != nil || interval <= 0 {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("flush_interval must be a positive duration"))
+		}
+	}
+	return nil
+}
+
+// BatchSize converts a batch size decoded from JSON or YAML to an integer
+func BatchSize(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,89 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package gcm
+
+import (
+	"strings"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"gopkg.in/yaml.v3"
+)
+
+func (b *Backend) ConfigToFormat(format string, metadata types.Metadata) (string, error) {
+	if format == "yaml" {
+		projectID, _ := metadata[ProjectIDConfigFeature].(string)
+		metricPrefix, _ := metadata[MetricPrefixConfigFeature].(string)
+		config, err := yaml.Marshal(Backend{ProjectID: projectID, MetricPrefix: metricPrefix})
+		if err != nil {
+			return "", err
+		}
+		return string(config), nil
+	}
+	return "", errors.New("unsupported format")
+}
+
+func (b *Backend) ParseConfig(format string, config string) (configReturn types.Metadata, err error) {
+	if format == "yaml" {
+		configReturn = make(types.Metadata)
+		err = yaml.Unmarshal([]byte(config), &configReturn)
+		if err != nil {
+			return nil, errors.Wrap(errors.New("failed to parse config YAML"), err)
+		}
+		return
+	}
+	return nil, errors.New("unsupported format")
+}
+
+// Normalize trims the spaces around the project id
+func (b *Backend) Normalize(config types.Metadata) types.Metadata {
+	normalized := types.FromMap(config)
+	if projectID, ok := normalized[ProjectIDConfigFeature].(string); ok {
+		normalized[ProjectIDConfigFeature] = strings.TrimSpace(projectID)
+	}
+	return normalized
+}
+
+func (b *Backend) ValidateConfiguration(config types.Metadata) error {
+	value, ok := config[ProjectIDConfigFeature]
+	if !ok {
+		return errors.ErrProjectIDNotFound
+	}
+	projectID, ok := value.(string)
+	if !ok || projectID == "" || strings.ContainsAny(projectID, " \t\n/") {
+		return errors.ErrInvalidProjectID
+	}
+	if prefix, ok := config[MetricPrefixConfigFeature]; ok {
+		if _, ok := prefix.(string); !ok {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("metric_prefix must be a string"))
+		}
+	}
+	if err := backend.ValidateRelabelConfig(config); err != nil {
+		return err
+	}
+	return backend.ValidateBatchConfig(config)
+}
+
+func (b *Backend) CreateFeatureConfig() []backend.ConfigFeature {
+	configs := []backend.ConfigFeature{
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "Project ID",
+			Name:     ProjectIDConfigFeature,
+			Required: true,
+		},
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "Metric Type Prefix",
+			Name:     MetricPrefixConfigFeature,
+			Required: false,
+		},
+	}
+	configs = append(configs, backend.BatchConfigFeatures()...)
+	return append(configs, backend.RelabelConfigFeatures()...)
+}
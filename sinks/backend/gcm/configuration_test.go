@@ -0,0 +1,90 @@
+package gcm
+
+import (
+	"testing"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackend_ValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  types.Metadata
+		wantErr error
+	}{
+		{
+			name:    "valid configuration",
+			config:  types.Metadata{ProjectIDConfigFeature: "orb-metrics"},
+			wantErr: nil,
+		},
+		{
+			name:    "valid configuration with metric prefix",
+			config:  types.Metadata{ProjectIDConfigFeature: "orb-metrics", MetricPrefixConfigFeature: "custom.googleapis.com/orb"},
+			wantErr: nil,
+		},
+		{
+			name:    "missing project id",
+			config:  types.Metadata{},
+			wantErr: errors.ErrProjectIDNotFound,
+		},
+		{
+			name:    "empty project id",
+			config:  types.Metadata{ProjectIDConfigFeature: ""},
+			wantErr: errors.ErrInvalidProjectID,
+		},
+		{
+			name:    "project id not a string",
+			config:  types.Metadata{ProjectIDConfigFeature: float64(1234)},
+			wantErr: errors.ErrInvalidProjectID,
+		},
+		{
+			name:    "metric prefix not a string",
+			config:  types.Metadata{ProjectIDConfigFeature: "orb-metrics", MetricPrefixConfigFeature: true},
+			wantErr: errors.ErrMalformedEntity,
+		},
+	}
+	b := Backend{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := b.ValidateConfiguration(tt.config)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Contains(err, tt.wantErr), "expected %s got %s", tt.wantErr, err)
+		})
+	}
+}
+
+func TestBackend_ConfigToFormat(t *testing.T) {
+	b := Backend{}
+	yamlConfig, err := b.ConfigToFormat("yaml", types.Metadata{ProjectIDConfigFeature: "orb-metrics"})
+	require.NoError(t, err)
+	assert.Equal(t, "project_id: orb-metrics\n", yamlConfig)
+
+	parsed, err := b.ParseConfig("yaml", yamlConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "orb-metrics", parsed[ProjectIDConfigFeature])
+}
+
+func TestBackend_NormalizeLabels(t *testing.T) {
+	b := Backend{}
+	normalized := b.NormalizeLabels(map[string]string{
+		"agent.name": "edge-1",
+		"1st_policy": "dns",
+		"agent_name": "kept",
+	})
+	assert.Equal(t, map[string]string{"agent_name": "kept", "orb_1st_policy": "dns"}, normalized)
+
+	// of the labels replaced into the same key, the first one in lexical order is kept whatever the map order
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, map[string]string{"pod_name": "a"}, b.NormalizeLabels(map[string]string{
+			"pod.name": "b",
+			"pod-name": "a",
+			"pod/name": "c",
+		}))
+	}
+}
@@ -0,0 +1,102 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package gcm
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/orb-community/orb/sinks/authentication_type/serviceaccount"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+var _ backend.Backend = (*Backend)(nil)
+
+const (
+	ProjectIDConfigFeature    = "project_id"
+	MetricPrefixConfigFeature = "metric_prefix"
+)
+
+// maxLabelLength is the longest label key accepted by Cloud Monitoring
+const maxLabelLength = 100
+
+type Backend struct {
+	ProjectID    string `json:"project_id" yaml:"project_id"`
+	MetricPrefix string `json:"metric_prefix,omitempty" yaml:"metric_prefix,omitempty"`
+}
+
+func (b *Backend) Metadata() interface{} {
+	return backend.SinkFeature{
+		Backend:     "gcm",
+		Description: "Google Cloud Monitoring sink, the metrics are written as time series of the project",
+		Signals:     b.SupportedSignals(),
+		Maturity:    b.Maturity(),
+		AuthTypes:   b.SupportedAuthTypes(),
+		Config:      b.CreateFeatureConfig(),
+	}
+}
+
+func (b *Backend) SupportedSignals() []string {
+	return []string{backend.SignalMetrics}
+}
+
+func (b *Backend) Maturity() string {
+	return backend.MaturityExperimental
+}
+
+func (b *Backend) SupportedAuthTypes() []string {
+	return []string{serviceaccount.AuthType}
+}
+
+// invalidLabelChars matches the characters not allowed on Cloud Monitoring label keys
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// QueuesOnDisk is true, the googlecloud exporter of the collector accepts a persistent sending queue
+func (b *Backend) QueuesOnDisk() bool {
+	return true
+}
+
+// NormalizeLabels replaces the characters not allowed on Cloud Monitoring label keys with underscores, keys not
+// starting with a letter are prefixed with "orb_" and the keys are cut to the maximum length. A label already
+// named as the result of a replacement keeps its value, and of the labels replaced into the same key the first
+// one in lexical order is kept
+func (b *Backend) NormalizeLabels(labels map[string]string) map[string]string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	normalized := make(map[string]string, len(labels))
+	for _, name := range names {
+		value := labels[name]
+		label := invalidLabelChars.ReplaceAllString(name, "_")
+		if label == "" || !isLetter(label[0]) {
+			label = "orb_" + label
+		}
+		if len(label) > maxLabelLength {
+			label = label[:maxLabelLength]
+		}
+		if label != name {
+			if _, ok := labels[label]; ok {
+				continue
+			}
+			if _, ok := normalized[label]; ok {
+				continue
+			}
+		}
+		normalized[label] = strings.ToValidUTF8(value, "")
+	}
+	return normalized
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func Register() bool {
+	backend.Register("gcm", &Backend{})
+	return true
+}
@@ -0,0 +1,43 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import "strings"
+
+const (
+	MaturityStable       = "stable"
+	MaturityBeta         = "beta"
+	MaturityExperimental = "experimental"
+)
+
+// nonStableOptIn holds the backends which are not stable the deployment accepts new sinks of
+var nonStableOptIn = map[string]bool{}
+
+// SetNonStableOptIn sets the backends which are not stable the deployment opted in, the sinks of the other
+// non stable backends cannot be created
+func SetNonStableOptIn(names []string) {
+	optIn := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			optIn[name] = true
+		}
+	}
+	nonStableOptIn = optIn
+}
+
+// IsValidMaturity reports whether maturity is one of the known backend maturity levels
+func IsValidMaturity(maturity string) bool {
+	switch maturity {
+	case MaturityStable, MaturityBeta, MaturityExperimental:
+		return true
+	}
+	return false
+}
+
+// MaturityAllowed reports whether new sinks of the backend can be created, either it is stable or the
+// deployment opted in it
+func MaturityAllowed(name string, b Backend) bool {
+	return b.Maturity() == MaturityStable || nonStableOptIn[name]
+}
@@ -0,0 +1,39 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/types"
+)
+
+// NormalizeURL canonicalizes an exporter URL: surrounding spaces are removed, the scheme and host are lowercased
+// and the trailing slashes of the path are trimmed. A value which is not an absolute URL is only trimmed of spaces,
+// the validation of the backend reports it
+func NormalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	return u.String()
+}
+
+// NormalizeURLFields returns a copy of the exporter config with the string values of the given URL fields normalized
+func NormalizeURLFields(config types.Metadata, fields ...string) types.Metadata {
+	normalized := types.FromMap(config)
+	for _, field := range fields {
+		if value, ok := normalized[field].(string); ok {
+			normalized[field] = NormalizeURL(value)
+		}
+	}
+	return normalized
+}
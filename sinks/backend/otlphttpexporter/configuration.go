@@ -1,10 +1,15 @@
 package otlphttpexporter
 
 import (
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+	"github.com/orb-community/orb/sinks/authentication_type/clientcert"
 	"github.com/orb-community/orb/sinks/backend"
 	"gopkg.in/yaml.v3"
 )
@@ -33,11 +38,54 @@ import (
 const EndpointFieldName = "endpoint"
 const ExporterFieldName = "exporter"
 const CustomHeadersConfigFeature = "headers"
+const EncodingConfigFeature = "encoding"
+const CompressionConfigFeature = "compression"
+
+const (
+	// EncodingProtobuf is the default encoding of the exported OTLP payloads
+	EncodingProtobuf = "protobuf"
+	EncodingJSON     = "json"
+)
+
+var encodings = []string{EncodingProtobuf, EncodingJSON}
+
+const (
+	// CompressionNone sends the exported OTLP payloads uncompressed
+	CompressionNone = "none"
+	// CompressionGzip gzips the exported OTLP payloads and sets the Content-Encoding header
+	CompressionGzip = "gzip"
+)
+
+var compressions = []string{CompressionNone, CompressionGzip}
 
 var invalidCustomHeaders = []string{
 	"Content-Encoding", "Content-Type", "User-Agent", "Authorization",
 }
 
+// DefaultSecretHeaders are the custom headers whose values are encrypted at rest and omitted on view
+var DefaultSecretHeaders = []string{
+	"X-Api-Key", "Api-Key", "X-Auth-Token", "X-Access-Token", "Proxy-Authorization", "Cookie",
+}
+
+var secretHeaders = headerSet(DefaultSecretHeaders)
+
+// SetSecretHeaders replaces the custom headers handled as secrets, names are case insensitive
+func SetSecretHeaders(names []string) {
+	secretHeaders = headerSet(names)
+}
+
+func headerSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			set[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+	return set
+}
+
+var _ backend.RemoteEndpoints = (*OTLPHTTPBackend)(nil)
+
 type OTLPHTTPBackend struct {
 	Endpoint string `yaml:"endpoint"`
 	//TODO will keep TLS until we confirm there is no need for those
@@ -48,10 +96,40 @@ func (b *OTLPHTTPBackend) Metadata() interface{} {
 	return backend.SinkFeature{
 		Backend:     "otlphttp",
 		Description: "OTLP Exporter over HTTP",
+		Signals:     b.SupportedSignals(),
+		Maturity:    b.Maturity(),
+		AuthTypes:   b.SupportedAuthTypes(),
 		Config:      b.CreateFeatureConfig(),
 	}
 }
 
+func (b *OTLPHTTPBackend) SupportedSignals() []string {
+	return []string{backend.SignalMetrics, backend.SignalLogs, backend.SignalTraces}
+}
+
+func (b *OTLPHTTPBackend) Maturity() string {
+	return backend.MaturityStable
+}
+
+func (b *OTLPHTTPBackend) SupportedAuthTypes() []string {
+	return []string{basicauth.AuthType, bearertokenauth.AuthType, clientcert.AuthType}
+}
+
+// IsSecretHeader reports whether the custom header is one of the configured secret headers
+func (b *OTLPHTTPBackend) IsSecretHeader(name string) bool {
+	return secretHeaders[http.CanonicalHeaderKey(name)]
+}
+
+// QueuesOnDisk is true, the otlphttp exporter of the collector accepts a persistent sending queue
+func (b *OTLPHTTPBackend) QueuesOnDisk() bool {
+	return true
+}
+
+// NormalizeLabels keeps the labels as they are, OTLP accepts any attribute name
+func (b *OTLPHTTPBackend) NormalizeLabels(labels map[string]string) map[string]string {
+	return labels
+}
+
 // TODO will keep TLS until we confirm there is no need for those
 type tlsConfig struct {
 	Insecure           *bool   `yaml:"insecure,omitempty"`
@@ -80,32 +158,99 @@ func (b *OTLPHTTPBackend) CreateFeatureConfig() []backend.ConfigFeature {
 		Required: true,
 	}
 
-	configs = append(configs, remoteHost)
+	customHeaders := backend.ConfigFeature{
+		Type:     backend.ConfigFeatureTypeMap,
+		Input:    "map",
+		Title:    "Custom HTTP Headers",
+		Name:     CustomHeadersConfigFeature,
+		Required: false,
+	}
+
+	encoding := backend.ConfigFeature{
+		Type:     backend.ConfigFeatureTypeText,
+		Input:    "select",
+		Title:    "Encoding",
+		Name:     EncodingConfigFeature,
+		Required: false,
+		Options:  encodings,
+	}
+
+	compression := backend.ConfigFeature{
+		Type:     backend.ConfigFeatureTypeText,
+		Input:    "select",
+		Title:    "Compression",
+		Name:     CompressionConfigFeature,
+		Required: false,
+		Options:  compressions,
+	}
+
+	configs = append(configs, remoteHost, customHeaders, encoding, compression)
+	configs = append(configs, backend.TLSConfigFeatures()...)
+	configs = append(configs, backend.BatchConfigFeatures()...)
+	configs = append(configs, backend.RelabelConfigFeatures()...)
 	return configs
 }
 
-func (b *OTLPHTTPBackend) ValidateConfiguration(config types.Metadata) error {
-	if config[EndpointFieldName] == "" {
-		return errors.New("malformed entity specification. endpoint must not be empty")
+// EndpointFields lists endpoint, the OTLP/HTTP URL
+func (b *OTLPHTTPBackend) EndpointFields() []string {
+	return []string{EndpointFieldName}
+}
+
+// Normalize canonicalizes the endpoint URL, see backend.NormalizeURL, and lowercases the encoding and the
+// compression
+func (b *OTLPHTTPBackend) Normalize(config types.Metadata) types.Metadata {
+	normalized := backend.NormalizeURLFields(config, b.EndpointFields()...)
+	for _, field := range []string{EncodingConfigFeature, CompressionConfigFeature} {
+		if value, ok := normalized[field].(string); ok {
+			normalized[field] = strings.ToLower(strings.TrimSpace(value))
+		}
 	}
-	endpointUrl, endpointOk := config[EndpointFieldName]
+	return normalized
+}
+
+func (b *OTLPHTTPBackend) ValidateConfiguration(config types.Metadata) error {
+	endpoint, endpointOk := config[EndpointFieldName]
 	if !endpointOk {
 		return errors.Wrap(errors.ErrEndpointNotFound, errors.New("endpoint not found"))
 	}
-	if _, err := url.ParseRequestURI(endpointUrl.(string)); err != nil {
+	endpointUrl, ok := endpoint.(string)
+	if !ok || endpointUrl == "" {
+		return errors.New("malformed entity specification. endpoint must not be empty")
+	}
+	if _, err := url.ParseRequestURI(endpointUrl); err != nil {
 		return errors.Wrap(errors.ErrInvalidEndpoint, err)
 	}
 	// check for custom http headers
 	customHeaders, customHeadersOk := config[CustomHeadersConfigFeature]
 	if customHeadersOk {
-		headersAsMap := customHeaders.(map[string]interface{})
+		headersAsMap, ok := customHeaders.(map[string]interface{})
+		if !ok {
+			return errors.New("malformed entity specification. headers must map header names to values")
+		}
 		for _, header := range invalidCustomHeaders {
 			if _, ok := headersAsMap[header]; ok {
 				return errors.New("invalid custom headers")
 			}
 		}
+		for name, value := range headersAsMap {
+			if _, ok := value.(string); !ok {
+				return errors.New("malformed entity specification. value of header " + name + " must be a string")
+			}
+		}
+	}
+	if encoding, ok := config[EncodingConfigFeature]; ok && !validEncoding(encoding) {
+		return errors.New("malformed entity specification. encoding must be one of " + strings.Join(encodings, ", "))
 	}
-	return nil
+	if compression, ok := config[CompressionConfigFeature]; ok && !validCompression(compression) {
+		return errors.New("malformed entity specification. compression must be one of " + strings.Join(compressions, ", "))
+	}
+	if err := backend.ValidateTLSConfig(config); err != nil {
+		return err
+	}
+	if err := backend.ValidateRelabelConfig(config); err != nil {
+		return err
+	}
+	return backend.ValidateBatchConfig(config)
 }
 
 func (b *OTLPHTTPBackend) ParseConfig(format string, config string) (retConfig types.Metadata, err error) {
@@ -129,3 +274,21 @@ func (b *OTLPHTTPBackend) ConfigToFormat(format string, metadata types.Metadata)
 		return "", errors.New("format not supported")
 	}
 }
+
+func validEncoding(encoding interface{}) bool {
+	for _, valid := range encodings {
+		if encoding == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func validCompression(compression interface{}) bool {
+	for _, valid := range compressions {
+		if compression == valid {
+			return true
+		}
+	}
+	return false
+}
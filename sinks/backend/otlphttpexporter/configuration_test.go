@@ -0,0 +1,179 @@
+package otlphttpexporter
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateCA(t *testing.T) string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "orb-internal-ca"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageCertSign,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestBackend_ValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  types.Metadata
+		wantErr bool
+	}{
+		{
+			name:    "valid configuration",
+			config:  types.Metadata{EndpointFieldName: "https://acme.com/otlp"},
+			wantErr: false,
+		},
+		{
+			name:    "missing endpoint",
+			config:  types.Metadata{},
+			wantErr: true,
+		},
+		{
+			name:    "empty endpoint",
+			config:  types.Metadata{EndpointFieldName: ""},
+			wantErr: true,
+		},
+		{
+			name:    "endpoint not a string",
+			config:  types.Metadata{EndpointFieldName: []interface{}{"https://acme.com/otlp"}},
+			wantErr: true,
+		},
+		{
+			name: "valid configuration with custom headers",
+			config: types.Metadata{
+				EndpointFieldName:          "https://acme.com/otlp",
+				CustomHeadersConfigFeature: map[string]interface{}{"X-Api-Key": "secret", "X-Tenant": "acme"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reserved custom header",
+			config: types.Metadata{
+				EndpointFieldName:          "https://acme.com/otlp",
+				CustomHeadersConfigFeature: map[string]interface{}{"Authorization": "Bearer token"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom headers not a map",
+			config: types.Metadata{
+				EndpointFieldName:          "https://acme.com/otlp",
+				CustomHeadersConfigFeature: "X-Api-Key: secret",
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom header value not a string",
+			config: types.Metadata{
+				EndpointFieldName:          "https://acme.com/otlp",
+				CustomHeadersConfigFeature: map[string]interface{}{"X-Retries": 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "json encoding",
+			config: types.Metadata{
+				EndpointFieldName:     "https://acme.com/otlp",
+				EncodingConfigFeature: EncodingJSON,
+			},
+			wantErr: false,
+		},
+		{
+			name: "gzip compression with batching",
+			config: types.Metadata{
+				EndpointFieldName:                  "https://acme.com/otlp",
+				CompressionConfigFeature:           CompressionGzip,
+				backend.BatchSizeConfigFeature:     float64(500),
+				backend.FlushIntervalConfigFeature: "1s",
+			},
+			wantErr: false,
+		},
+		{
+			name: "no compression",
+			config: types.Metadata{
+				EndpointFieldName:        "https://acme.com/otlp",
+				CompressionConfigFeature: CompressionNone,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown compression",
+			config: types.Metadata{
+				EndpointFieldName:        "https://acme.com/otlp",
+				CompressionConfigFeature: "zstd",
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom ca",
+			config: types.Metadata{
+				EndpointFieldName:        "https://acme.com/otlp",
+				backend.TLSConfigFeature: map[string]interface{}{backend.TLSCAConfigFeature: generateCA(t)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unparseable ca",
+			config: types.Metadata{
+				EndpointFieldName:        "https://acme.com/otlp",
+				backend.TLSConfigFeature: map[string]interface{}{backend.TLSCAConfigFeature: "not a certificate"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tls not a map",
+			config: types.Metadata{
+				EndpointFieldName:        "https://acme.com/otlp",
+				backend.TLSConfigFeature: "ca",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown encoding",
+			config: types.Metadata{
+				EndpointFieldName:     "https://acme.com/otlp",
+				EncodingConfigFeature: "xml",
+			},
+			wantErr: true,
+		},
+	}
+	b := &OTLPHTTPBackend{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := b.ValidateConfiguration(tt.config)
+			assert.Equal(t, tt.wantErr, err != nil, "ValidateConfiguration() error = %v", err)
+		})
+	}
+}
+
+func TestBackend_IsSecretHeader(t *testing.T) {
+	b := &OTLPHTTPBackend{}
+	assert.True(t, b.IsSecretHeader("x-api-key"), "secret headers must be case insensitive")
+	assert.False(t, b.IsSecretHeader("X-Tenant"))
+
+	SetSecretHeaders([]string{" X-Tenant ", ""})
+	defer SetSecretHeaders(DefaultSecretHeaders)
+	assert.True(t, b.IsSecretHeader("X-Tenant"))
+	assert.False(t, b.IsSecretHeader("X-Api-Key"))
+}
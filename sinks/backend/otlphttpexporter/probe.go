@@ -0,0 +1,80 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package otlphttpexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+)
+
+var _ backend.Prober = (*OTLPHTTPBackend)(nil)
+
+const (
+	probeMetricName = "orb_sink_probe"
+	metricsPath     = "/v1/metrics"
+)
+
+// newProbeMetrics returns a single gauge data point of the probe metric
+func newProbeMetrics(now time.Time) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(probeMetricName)
+	dataPoint := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dataPoint.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	dataPoint.SetIntValue(1)
+	return metrics
+}
+
+// Probe sends an OTLP export request with a synthetic gauge to the metrics path of the endpoint, gzipped when
+// the sink compression is gzip
+func (b *OTLPHTTPBackend) Probe(ctx context.Context, client *http.Client, config types.Metadata, header http.Header) error {
+	endpoint, ok := config[EndpointFieldName].(string)
+	if !ok || endpoint == "" {
+		return errors.Wrap(errors.ErrEndpointNotFound, errors.New("endpoint not found"))
+	}
+	body, err := pmetricotlp.NewExportRequestFromMetrics(newProbeMetrics(time.Now())).MarshalProto()
+	if err != nil {
+		return err
+	}
+	gzipped := config[CompressionConfigFeature] == CompressionGzip
+	if gzipped {
+		if body, err = gzipBody(body); err != nil {
+			return err
+		}
+	}
+	url := strings.TrimSuffix(endpoint, "/") + metricsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidEndpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	if gzipped {
+		req.Header.Set("Content-Encoding", CompressionGzip)
+	}
+	return backend.SendProbe(client, req, config, header)
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
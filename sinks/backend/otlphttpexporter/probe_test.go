@@ -0,0 +1,70 @@
+package otlphttpexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+)
+
+func TestBackend_Probe(t *testing.T) {
+	var encoding string
+	var received pmetricotlp.ExportRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != metricsPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		encoding = r.Header.Get("Content-Encoding")
+		var body io.Reader = r.Body
+		if encoding == CompressionGzip {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = reader
+		}
+		data, _ := io.ReadAll(body)
+		received = pmetricotlp.NewExportRequest()
+		if err := received.UnmarshalProto(data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: time.Second}
+	b := &OTLPHTTPBackend{}
+
+	tests := []struct {
+		name        string
+		compression string
+		encoding    string
+	}{
+		{name: "default compression", encoding: ""},
+		{name: "no compression", compression: CompressionNone, encoding: ""},
+		{name: "gzip compression", compression: CompressionGzip, encoding: CompressionGzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := types.Metadata{EndpointFieldName: server.URL}
+			if tt.compression != "" {
+				config[CompressionConfigFeature] = tt.compression
+			}
+			err := b.Probe(context.Background(), client, config, http.Header{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoding, encoding)
+			assert.Equal(t, probeMetricName, received.Metrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+const (
+	CustomHeadersConfigFeature = "headers"
+	ProbeUserAgent             = "orb-sinks-probe"
+	maxProbeResponseSize       = 512
+)
+
+// ErrProbeFailed indicates that the remote end of a sink did not accept the synthetic metric
+var ErrProbeFailed = errors.New("sink backend did not accept the probe")
+
+// ErrProbeAddressNotAllowed indicates that the endpoint of a sink resolves to an address the probe does not reach
+var ErrProbeAddressNotAllowed = errors.New("sink endpoint resolves to an address the probe is not allowed to reach")
+
+// probeAllowPrivate lets the probe reach the loopback, private, link-local and shared addresses. They are refused
+// by default, a sink config could otherwise make the sinks service send requests to its own network or to the
+// metadata endpoint of its cloud provider
+var probeAllowPrivate = false
+
+// sharedAddressSpace is the carrier-grade NAT range, some cloud providers serve their metadata endpoint on it
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// SetProbeAllowPrivate lets the probe reach the internal addresses, for the deployments whose sinks export to
+// their own network
+func SetProbeAllowPrivate(allow bool) {
+	probeAllowPrivate = allow
+}
+
+// ProbeDialContext dials the connections of the probe, refusing the internal addresses once the host is resolved
+// so neither a host name resolving to one nor a redirect reaches them
+func ProbeDialContext() func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: controlProbeAddress}
+	return dialer.DialContext
+}
+
+func controlProbeAddress(_, address string, _ syscall.RawConn) error {
+	if probeAllowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicAddress(ip) {
+		return errors.Wrap(ErrProbeAddressNotAllowed, errors.New(host))
+	}
+	return nil
+}
+
+// IsPublicAddress reports whether the address is neither loopback, private, link-local, shared, multicast nor
+// unspecified
+func IsPublicAddress(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip[0] == 0 || sharedAddressSpace.Contains(ip) {
+			return false
+		}
+	}
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// Prober is implemented by the backends able to send a synthetic metric to the remote end of a sink,
+// checking both connectivity and that the data format is accepted
+type Prober interface {
+	Probe(ctx context.Context, client *http.Client, config types.Metadata, header http.Header) error
+}
+
+// SendProbe sends the probe request with the authentication and custom headers of the exporter config,
+// any non 2xx response is a failed probe
+func SendProbe(client *http.Client, req *http.Request, config types.Metadata, header http.Header) error {
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if customHeaders, ok := config[CustomHeadersConfigFeature].(map[string]interface{}); ok {
+		for name, value := range customHeaders {
+			req.Header.Set(name, fmt.Sprint(value))
+		}
+	}
+	req.Header.Set("User-Agent", ProbeUserAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(ErrProbeFailed, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxProbeResponseSize))
+		return errors.Wrap(ErrProbeFailed, errors.New(fmt.Sprintf("remote responded with status %d: %s", res.StatusCode, body)))
+	}
+	return nil
+}
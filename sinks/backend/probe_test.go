@@ -0,0 +1,56 @@
+package backend
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.100.100.200": false,
+		"0.0.0.0":         false,
+		"224.0.0.1":       false,
+		"::1":             false,
+		"fd00:ec2::254":   false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for address, public := range cases {
+		assert.Equal(t, public, IsPublicAddress(net.ParseIP(address)), address)
+	}
+}
+
+func TestProbeDialContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	_, err = ProbeDialContext()(context.Background(), "tcp", listener.Addr().String())
+	assert.ErrorContains(t, err, ErrProbeAddressNotAllowed.Error(), "loopback")
+
+	SetProbeAllowPrivate(true)
+	defer SetProbeAllowPrivate(false)
+	conn, err := ProbeDialContext()(context.Background(), "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+}
@@ -45,28 +45,50 @@ func (p *Backend) ParseConfig(format string, config string) (configReturn types.
 	}
 }
 
+// EndpointFields lists remote_host, the prometheus remote write URL
+func (p *Backend) EndpointFields() []string {
+	return []string{RemoteHostURLConfigFeature}
+}
+
+// Normalize canonicalizes the remote_host URL, see backend.NormalizeURL
+func (p *Backend) Normalize(config types.Metadata) types.Metadata {
+	return backend.NormalizeURLFields(config, p.EndpointFields()...)
+}
+
 func (p *Backend) ValidateConfiguration(config types.Metadata) error {
 
-	remoteUrl, remoteHostOk := config[RemoteHostURLConfigFeature]
+	remoteHost, remoteHostOk := config[RemoteHostURLConfigFeature]
 	if !remoteHostOk {
 		return errors.ErrRemoteHostNotFound
 	}
 	// Validate remote_host
-	_, err := url.ParseRequestURI(remoteUrl.(string))
-	if err != nil {
+	remoteUrl, ok := remoteHost.(string)
+	if !ok {
+		return errors.ErrInvalidRemoteHost
+	}
+	if _, err := url.ParseRequestURI(remoteUrl); err != nil {
 		return errors.ErrInvalidRemoteHost
 	}
 	// check for custom http headers
 	customHeaders, customHeadersOk := config[CustomHeadersConfigFeature]
 	if customHeadersOk {
-		headersAsMap := customHeaders.(map[string]interface{})
+		headersAsMap, ok := customHeaders.(map[string]interface{})
+		if !ok {
+			return errors.New("malformed entity specification. headers must map header names to values")
+		}
 		for _, header := range invalidCustomHeaders {
 			if _, ok := headersAsMap[header]; ok {
 				return errors.New("invalid custom headers")
 			}
 		}
 	}
-	return nil
+	if err := backend.ValidateTLSConfig(config); err != nil {
+		return err
+	}
+	if err := backend.ValidateRelabelConfig(config); err != nil {
+		return err
+	}
+	return backend.ValidateBatchConfig(config)
 }
 
 func (p *Backend) CreateFeatureConfig() []backend.ConfigFeature {
@@ -81,5 +103,8 @@ func (p *Backend) CreateFeatureConfig() []backend.ConfigFeature {
 	}
 
 	configs = append(configs, remoteHost)
+	configs = append(configs, backend.TLSConfigFeatures()...)
+	configs = append(configs, backend.BatchConfigFeatures()...)
+	configs = append(configs, backend.RelabelConfigFeatures()...)
 	return configs
 }
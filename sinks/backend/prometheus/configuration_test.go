@@ -2,6 +2,7 @@ package prometheus
 
 import (
 	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -35,6 +36,48 @@ func TestBackend_ValidateConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "host not a string",
+			args: args{
+				config: map[string]interface{}{RemoteHostURLConfigFeature: float64(9090)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom headers not a map",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature: "https://acme.com/prom/push",
+					CustomHeadersConfigFeature: "X-Api-Key: secret",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid relabel rules",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature: "https://acme.com/prom/push",
+					backend.RelabelConfigFeature: []interface{}{
+						map[string]interface{}{"action": "drop", "source_labels": []interface{}{"__name__"}, "regex": "go_.*"},
+						map[string]interface{}{"source_labels": []interface{}{"instance"}, "target_label": "host"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid relabel regex",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature: "https://acme.com/prom/push",
+					backend.RelabelConfigFeature: []interface{}{
+						map[string]interface{}{"action": "keep", "source_labels": []interface{}{"job"}, "regex": "(unclosed"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing host configuration",
 			args: args{
@@ -42,6 +85,37 @@ func TestBackend_ValidateConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid batch configuration",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature:         "https://acme.com/prom/push",
+					backend.BatchSizeConfigFeature:     float64(500),
+					backend.FlushIntervalConfigFeature: "5s",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "non positive batch size",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature:     "https://acme.com/prom/push",
+					backend.BatchSizeConfigFeature: 0,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid flush interval",
+			args: args{
+				config: map[string]interface{}{
+					RemoteHostURLConfigFeature:         "https://acme.com/prom/push",
+					backend.FlushIntervalConfigFeature: "soon",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -122,3 +196,98 @@ func TestBackend_CreateFeatureConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestBackend_SupportedSignals(t *testing.T) {
+	p := &Backend{}
+	require.Equal(t, []string{backend.SignalMetrics}, p.SupportedSignals())
+	feature, ok := p.Metadata().(backend.SinkFeature)
+	require.True(t, ok)
+	require.Equal(t, p.SupportedSignals(), feature.Signals)
+}
+
+func TestBackend_NormalizeLabels(t *testing.T) {
+	p := &Backend{}
+	cases := map[string]struct {
+		labels map[string]string
+		want   map[string]string
+	}{
+		"valid labels are kept": {
+			labels: map[string]string{"agent": "my-agent", "policy_id": "1"},
+			want:   map[string]string{"agent": "my-agent", "policy_id": "1"},
+		},
+		"dots and dashes are replaced": {
+			labels: map[string]string{"service.name": "orb", "k8s-pod": "pod.1"},
+			want:   map[string]string{"service_name": "orb", "k8s_pod": "pod.1"},
+		},
+		"leading digit is prefixed": {
+			labels: map[string]string{"5g.cell": "a"},
+			want:   map[string]string{"_5g_cell": "a"},
+		},
+		"existing label wins over a replaced one": {
+			labels: map[string]string{"region.name": "b", "region_name": "a"},
+			want:   map[string]string{"region_name": "a"},
+		},
+		"first replaced label in lexical order wins": {
+			labels: map[string]string{"region.name": "b", "region-name": "a", "region/name": "c"},
+			want:   map[string]string{"region_name": "a"},
+		},
+		"empty labels": {
+			labels: map[string]string{},
+			want:   map[string]string{},
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			// the labels are read in a random order, the result must not depend on it
+			for i := 0; i < 20; i++ {
+				require.Equal(t, tc.want, p.NormalizeLabels(tc.labels))
+			}
+		})
+	}
+}
+
+func TestBackend_Normalize(t *testing.T) {
+	p := &Backend{}
+	cases := map[string]struct {
+		remoteHost interface{}
+		want       interface{}
+	}{
+		"canonical url is kept": {
+			remoteHost: "https://acme.com/prom/push",
+			want:       "https://acme.com/prom/push",
+		},
+		"trailing slash is trimmed": {
+			remoteHost: "https://acme.com/prom/push/",
+			want:       "https://acme.com/prom/push",
+		},
+		"trailing slash of the host is trimmed": {
+			remoteHost: "https://acme.com/",
+			want:       "https://acme.com",
+		},
+		"scheme and host are lowercased": {
+			remoteHost: "HTTPS://Acme.COM:9090/Prom/Push",
+			want:       "https://acme.com:9090/Prom/Push",
+		},
+		"query is kept": {
+			remoteHost: " https://acme.com/prom/push/?tenant=Orb ",
+			want:       "https://acme.com/prom/push?tenant=Orb",
+		},
+		"invalid url is only trimmed": {
+			remoteHost: " acme.com/prom/push/ ",
+			want:       "acme.com/prom/push/",
+		},
+		"non string url is kept": {
+			remoteHost: float64(1),
+			want:       float64(1),
+		},
+	}
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			config := types.Metadata{RemoteHostURLConfigFeature: tc.remoteHost, CustomHeadersConfigFeature: map[string]interface{}{"X-Tenant": "orb"}}
+			normalized := p.Normalize(config)
+			require.Equal(t, tc.want, normalized[RemoteHostURLConfigFeature])
+			require.Equal(t, config[CustomHeadersConfigFeature], normalized[CustomHeadersConfigFeature])
+			require.Equal(t, tc.remoteHost, config[RemoteHostURLConfigFeature], "the given config must be left untouched")
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var _ backend.Prober = (*Backend)(nil)
+
+const probeMetricName = "orb_sink_probe"
+
+// encodeProbeWriteRequest encodes a remote write WriteRequest holding a single sample of the probe metric,
+// field numbers follow the prometheus prompb definition
+func encodeProbeWriteRequest(now time.Time) []byte {
+	var label []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, "__name__")
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, probeMetricName)
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(1))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(now.UnixMilli()))
+
+	var series []byte
+	series = protowire.AppendTag(series, 1, protowire.BytesType)
+	series = protowire.AppendBytes(series, label)
+	series = protowire.AppendTag(series, 2, protowire.BytesType)
+	series = protowire.AppendBytes(series, sample)
+
+	var request []byte
+	request = protowire.AppendTag(request, 1, protowire.BytesType)
+	request = protowire.AppendBytes(request, series)
+	return request
+}
+
+// Probe sends a remote write request with a synthetic sample to the remote host
+func (p *Backend) Probe(ctx context.Context, client *http.Client, config types.Metadata, header http.Header) error {
+	remoteHost, ok := config[RemoteHostURLConfigFeature].(string)
+	if !ok {
+		return errors.ErrRemoteHostNotFound
+	}
+	body := snappy.Encode(nil, encodeProbeWriteRequest(time.Now()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, remoteHost, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidRemoteHost, err)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	return backend.SendProbe(client, req, config, header)
+}
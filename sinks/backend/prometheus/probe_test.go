@@ -0,0 +1,42 @@
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackend_Probe(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("X-Custom") != "value" ||
+			r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received, _ = snappy.Decode(nil, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	customHeaders := map[string]interface{}{"X-Custom": "value"}
+	client := &http.Client{Timeout: time.Second}
+	var p Backend
+
+	err := p.Probe(context.Background(), client, types.Metadata{RemoteHostURLConfigFeature: server.URL, CustomHeadersConfigFeature: customHeaders}, header)
+	require.NoError(t, err)
+	assert.Contains(t, string(received), probeMetricName)
+
+	err = p.Probe(context.Background(), client, types.Metadata{RemoteHostURLConfigFeature: server.URL}, header)
+	assert.Error(t, err)
+}
@@ -5,10 +5,17 @@
 package prometheus
 
 import (
+	"regexp"
+	"sort"
+
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+	"github.com/orb-community/orb/sinks/authentication_type/clientcert"
 	"github.com/orb-community/orb/sinks/backend"
 )
 
 var _ backend.Backend = (*Backend)(nil)
+var _ backend.RemoteEndpoints = (*Backend)(nil)
 
 const (
 	RemoteHostURLConfigFeature = "remote_host"
@@ -33,10 +40,58 @@ func (p *Backend) Metadata() interface{} {
 	return backend.SinkFeature{
 		Backend:     "prometheus",
 		Description: "Prometheus time series database sink",
+		Signals:     p.SupportedSignals(),
+		Maturity:    p.Maturity(),
+		AuthTypes:   p.SupportedAuthTypes(),
 		Config:      p.CreateFeatureConfig(),
 	}
 }
 
+func (p *Backend) SupportedSignals() []string {
+	return []string{backend.SignalMetrics}
+}
+
+func (p *Backend) Maturity() string {
+	return backend.MaturityStable
+}
+
+func (p *Backend) SupportedAuthTypes() []string {
+	return []string{basicauth.AuthType, bearertokenauth.AuthType, clientcert.AuthType}
+}
+
+// invalidLabelChars matches the characters not allowed on prometheus label names
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// NormalizeLabels replaces the characters not allowed on prometheus label names with underscores,
+// names starting with a digit are prefixed with an underscore. A label already named as the result
+// of a replacement keeps its value, and of the labels replaced into the same name the first one in
+// lexical order is kept
+func (p *Backend) NormalizeLabels(labels map[string]string) map[string]string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	normalized := make(map[string]string, len(labels))
+	for _, name := range names {
+		value := labels[name]
+		label := invalidLabelChars.ReplaceAllString(name, "_")
+		if label != "" && label[0] >= '0' && label[0] <= '9' {
+			label = "_" + label
+		}
+		if label != name {
+			if _, ok := labels[label]; ok {
+				continue
+			}
+			if _, ok := normalized[label]; ok {
+				continue
+			}
+		}
+		normalized[label] = value
+	}
+	return normalized
+}
+
 func Register() bool {
 	backend.Register("prometheus", &Backend{})
 	return true
@@ -0,0 +1,227 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+const (
+	// RelabelConfigFeature is the optional exporter field with the relabeling rules applied by the sinker to the
+	// metric labels before they are written to the sink
+	RelabelConfigFeature = "relabel"
+
+	// RelabelActionKeep drops the data points whose source labels do not match the regex
+	RelabelActionKeep = "keep"
+	// RelabelActionDrop drops the data points whose source labels match the regex
+	RelabelActionDrop = "drop"
+	// RelabelActionReplace sets the target label to the replacement when the source labels match the regex, an
+	// empty result removes the target label
+	RelabelActionReplace = "replace"
+	// RelabelActionLabelmap copies the labels whose name matches the regex to the label named by the replacement
+	RelabelActionLabelmap = "labelmap"
+
+	// RelabelMetricNameLabel holds the metric name in the source labels of a rule
+	RelabelMetricNameLabel = "__name__"
+)
+
+const ConfigFeatureTypeList = "list"
+
+const (
+	defaultRelabelSeparator   = ";"
+	defaultRelabelRegex       = "(.*)"
+	defaultRelabelReplacement = "$1"
+)
+
+// RelabelRule is a Prometheus style relabeling rule, the regex is anchored on both ends
+type RelabelRule struct {
+	Action       string
+	SourceLabels []string
+	Separator    string
+	Regex        *regexp.Regexp
+	TargetLabel  string
+	Replacement  string
+}
+
+// RelabelActions lists the actions supported by the relabeling rules
+func RelabelActions() []string {
+	return []string{RelabelActionKeep, RelabelActionDrop, RelabelActionReplace, RelabelActionLabelmap}
+}
+
+// RelabelConfigFeatures documents the optional relabeling rules shared by the exporter configs of the metrics
+// backends. Each rule has an action among the options, and the source_labels, separator, regex, target_label and
+// replacement fields of the Prometheus relabeling rules
+func RelabelConfigFeatures() []ConfigFeature {
+	return []ConfigFeature{
+		{
+			Type:     ConfigFeatureTypeList,
+			Input:    "list",
+			Title:    "Metric Relabeling Rules",
+			Name:     RelabelConfigFeature,
+			Required: false,
+			Options:  RelabelActions(),
+		},
+	}
+}
+
+// ValidateRelabelConfig checks the optional relabeling rules of an exporter config
+func ValidateRelabelConfig(config types.Metadata) error {
+	_, err := ParseRelabelRules(config)
+	return err
+}
+
+// ParseRelabelRules reads the relabeling rules of an exporter config, in order, none when it has no rules
+func ParseRelabelRules(config types.Metadata) ([]RelabelRule, error) {
+	value, ok := config[RelabelConfigFeature]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("relabel must be a list of rules"))
+	}
+	rules := make([]RelabelRule, 0, len(list))
+	for i, item := range list {
+		rule, err := parseRelabelRule(item)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, errors.New(fmt.Sprintf("relabel rule %d: %s", i, err.Error())))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRelabelRule(item interface{}) (RelabelRule, error) {
+	var fields map[string]interface{}
+	switch v := item.(type) {
+	case types.Metadata:
+		fields = v
+	case map[string]interface{}:
+		fields = v
+	default:
+		return RelabelRule{}, errors.New("rule must be an object")
+	}
+	rule := RelabelRule{Action: RelabelActionReplace, Separator: defaultRelabelSeparator, Replacement: defaultRelabelReplacement}
+	expr := defaultRelabelRegex
+	for key, value := range fields {
+		switch key {
+		case "source_labels":
+			labels, ok := value.([]interface{})
+			if !ok {
+				return RelabelRule{}, errors.New("source_labels must be a list of label names")
+			}
+			for _, label := range labels {
+				name, ok := label.(string)
+				if !ok || name == "" {
+					return RelabelRule{}, errors.New("source_labels must be a list of label names")
+				}
+				rule.SourceLabels = append(rule.SourceLabels, name)
+			}
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return RelabelRule{}, errors.New(key + " must be a string")
+		}
+		switch key {
+		case "action":
+			rule.Action = strings.ToLower(str)
+		case "separator":
+			rule.Separator = str
+		case "regex":
+			expr = str
+		case "target_label":
+			rule.TargetLabel = str
+		case "replacement":
+			rule.Replacement = str
+		default:
+			return RelabelRule{}, errors.New("unknown field " + key)
+		}
+	}
+	regex, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return RelabelRule{}, errors.New("invalid regex: " + err.Error())
+	}
+	rule.Regex = regex
+	switch rule.Action {
+	case RelabelActionKeep, RelabelActionDrop:
+		if len(rule.SourceLabels) == 0 {
+			return RelabelRule{}, errors.New(rule.Action + " requires source_labels")
+		}
+	case RelabelActionReplace:
+		if rule.TargetLabel == "" {
+			return RelabelRule{}, errors.New("replace requires a target_label")
+		}
+	case RelabelActionLabelmap:
+	default:
+		return RelabelRule{}, errors.New(fmt.Sprintf("unsupported action %s, supported actions are %s", rule.Action, strings.Join(RelabelActions(), ", ")))
+	}
+	if rule.TargetLabel == RelabelMetricNameLabel {
+		return RelabelRule{}, errors.New("the metric name can not be relabeled")
+	}
+	return rule, nil
+}
+
+// Relabel applies the rules in order to the labels of a data point of the metric, it returns the new labels and
+// false when the data point is dropped
+func Relabel(rules []RelabelRule, metricName string, labels map[string]string) (map[string]string, bool) {
+	if len(rules) == 0 {
+		return labels, true
+	}
+	relabeled := make(map[string]string, len(labels))
+	for k, v := range labels {
+		relabeled[k] = v
+	}
+	for _, rule := range rules {
+		values := make([]string, 0, len(rule.SourceLabels))
+		for _, name := range rule.SourceLabels {
+			if name == RelabelMetricNameLabel {
+				values = append(values, metricName)
+				continue
+			}
+			values = append(values, relabeled[name])
+		}
+		value := strings.Join(values, rule.Separator)
+		switch rule.Action {
+		case RelabelActionKeep:
+			if !rule.Regex.MatchString(value) {
+				return nil, false
+			}
+		case RelabelActionDrop:
+			if rule.Regex.MatchString(value) {
+				return nil, false
+			}
+		case RelabelActionReplace:
+			match := rule.Regex.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			target := string(rule.Regex.ExpandString(nil, rule.Replacement, value, match))
+			if target == "" {
+				delete(relabeled, rule.TargetLabel)
+			} else {
+				relabeled[rule.TargetLabel] = target
+			}
+		case RelabelActionLabelmap:
+			mapped := make(map[string]string)
+			for name, v := range relabeled {
+				if match := rule.Regex.FindStringSubmatchIndex(name); match != nil {
+					if target := string(rule.Regex.ExpandString(nil, rule.Replacement, name, match)); target != "" {
+						mapped[target] = v
+					}
+				}
+			}
+			for name, v := range mapped {
+				relabeled[name] = v
+			}
+		}
+	}
+	return relabeled, true
+}
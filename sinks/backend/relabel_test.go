@@ -0,0 +1,56 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelabelRules(t *testing.T) {
+	rules, err := ParseRelabelRules(types.Metadata{})
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	cases := map[string]interface{}{
+		"rules not a list":         "drop",
+		"rule not an object":       []interface{}{"drop"},
+		"unknown action":           []interface{}{map[string]interface{}{"action": "hashmod", "source_labels": []interface{}{"job"}}},
+		"invalid regex":            []interface{}{map[string]interface{}{"action": "drop", "source_labels": []interface{}{"job"}, "regex": "a(b"}},
+		"keep without sources":     []interface{}{map[string]interface{}{"action": "keep", "regex": "a"}},
+		"replace without target":   []interface{}{map[string]interface{}{"source_labels": []interface{}{"job"}}},
+		"metric name as target":    []interface{}{map[string]interface{}{"source_labels": []interface{}{"job"}, "target_label": "__name__"}},
+		"source labels not a list": []interface{}{map[string]interface{}{"action": "drop", "source_labels": "job"}},
+		"unknown field":            []interface{}{map[string]interface{}{"action": "labelmap", "modulus": "2"}},
+	}
+	for desc, value := range cases {
+		err := ValidateRelabelConfig(types.Metadata{RelabelConfigFeature: value})
+		assert.True(t, errors.Contains(err, errors.ErrMalformedEntity), "%s: got %v", desc, err)
+	}
+}
+
+func TestRelabel(t *testing.T) {
+	rules, err := ParseRelabelRules(types.Metadata{RelabelConfigFeature: []interface{}{
+		map[string]interface{}{"action": "drop", "source_labels": []interface{}{"__name__"}, "regex": "go_.*"},
+		map[string]interface{}{"action": "keep", "source_labels": []interface{}{"env"}, "regex": "prod|staging"},
+		map[string]interface{}{"source_labels": []interface{}{"instance"}, "regex": "([^:]+):.*", "target_label": "host"},
+		map[string]interface{}{"source_labels": []interface{}{"instance"}, "regex": ".*", "target_label": "instance", "replacement": ""},
+		map[string]interface{}{"action": "labelmap", "regex": "team_(.+)"},
+	}})
+	require.NoError(t, err)
+
+	labels, keep := Relabel(rules, "dns_queries", map[string]string{"env": "prod", "instance": "pktvisor:10853", "team_name": "dns"})
+	require.True(t, keep)
+	assert.Equal(t, map[string]string{"env": "prod", "host": "pktvisor", "team_name": "dns", "name": "dns"}, labels)
+
+	_, keep = Relabel(rules, "go_goroutines", map[string]string{"env": "prod"})
+	assert.False(t, keep, "drop must match the metric name")
+	_, keep = Relabel(rules, "dns_queries", map[string]string{"env": "dev"})
+	assert.False(t, keep, "keep must drop the data points not matching")
+
+	original := map[string]string{"env": "prod"}
+	_, _ = Relabel(rules, "dns_queries", original)
+	assert.Equal(t, map[string]string{"env": "prod"}, original, "the labels must not be modified")
+}
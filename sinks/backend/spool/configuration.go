@@ -0,0 +1,189 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package spool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+	"gopkg.in/yaml.v3"
+)
+
+// sizeUnits are the suffixes accepted on max_size, a size without suffix is in bytes
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// Config is the spool of a sink as its collector queues it
+type Config struct {
+	MaxSize int64
+	Backend string
+}
+
+// FromMetadata reads the spool settings of an exporter config
+func FromMetadata(config types.Metadata) (Config, error) {
+	maxSize, ok := ParseSize(config[MaxSizeConfigFeature])
+	if !ok || maxSize <= 0 {
+		return Config{}, errors.Wrap(errors.ErrMalformedEntity, errors.New("max_size must be a positive size such as 512MiB"))
+	}
+	name, _ := config[BackendConfigFeature].(string)
+	return Config{MaxSize: maxSize, Backend: name}, nil
+}
+
+// ParseSize converts a size decoded from JSON or YAML to bytes, either a number of bytes or a string with one of
+// the B, KB, MB, GB, KiB, MiB or GiB suffixes
+func ParseSize(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case string:
+		v = strings.TrimSpace(v)
+		multiplier := int64(1)
+		for _, unit := range sizeUnits {
+			if strings.HasSuffix(v, unit.suffix) {
+				v = strings.TrimSpace(strings.TrimSuffix(v, unit.suffix))
+				multiplier = unit.bytes
+				break
+			}
+		}
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || size > (1<<62)/multiplier {
+			return 0, false
+		}
+		return size * multiplier, true
+	default:
+		size, ok := backend.BatchSize(value)
+		return int64(size), ok
+	}
+}
+
+// innerExporter returns the exporter config of the inner backend, an empty one when it is not set
+func innerExporter(config types.Metadata) types.Metadata {
+	switch exporter := config[ExporterConfigFeature].(type) {
+	case types.Metadata:
+		return exporter
+	case map[string]interface{}:
+		return exporter
+	}
+	return types.Metadata{}
+}
+
+// Normalize trims the inner backend name and normalizes the exporter config of the inner backend
+func (b *Backend) Normalize(config types.Metadata) types.Metadata {
+	normalized := types.FromMap(config)
+	if name, ok := normalized[BackendConfigFeature].(string); ok {
+		normalized[BackendConfigFeature] = strings.TrimSpace(name)
+	}
+	if inner, exporter, ok := b.Inner(normalized); ok {
+		normalized[ExporterConfigFeature] = inner.Normalize(exporter)
+	}
+	return normalized
+}
+
+// ValidateConfiguration checks the spool settings and validates the exporter config with the inner backend. The
+// custom headers the inner backend handles as secrets are refused, they are only encrypted at the top of the
+// exporter config
+func (b *Backend) ValidateConfiguration(config types.Metadata) error {
+	if _, err := FromMetadata(config); err != nil {
+		return err
+	}
+	name, ok := config[BackendConfigFeature].(string)
+	if !ok || name == "" {
+		return errors.Wrap(errors.ErrBackendNotFound, errors.New("backend of the spooled data not found"))
+	}
+	if name == Name {
+		return errors.Wrap(errors.ErrInvalidBackend, errors.New("a spool can not forward to another spool"))
+	}
+	if be := backend.GetBackend(name); be != nil && !queuesOnDisk(be) {
+		return errors.Wrap(errors.ErrInvalidBackend, errors.New(fmt.Sprintf("backend %s can not queue its data on disk", name)))
+	}
+	inner, exporter, ok := b.Inner(config)
+	if !ok {
+		return errors.Wrap(errors.ErrInvalidBackend, errors.New(fmt.Sprintf("unknown backend %s", name)))
+	}
+	if _, ok := config[ExporterConfigFeature]; !ok {
+		return errors.Wrap(errors.ErrExporterFieldNotFound, errors.New("exporter of the spooled data not found"))
+	}
+	if secrets, ok := inner.(backend.SecretHeaders); ok {
+		if headers, ok := exporter[backend.CustomHeadersConfigFeature].(map[string]interface{}); ok {
+			for header := range headers {
+				if secrets.IsSecretHeader(header) {
+					return errors.Wrap(errors.ErrMalformedEntity, errors.New("secret header "+header+" is not supported on a spooled exporter"))
+				}
+			}
+		}
+	}
+	if err := inner.ValidateConfiguration(exporter); err != nil {
+		return err
+	}
+	return backend.ValidateEndpointScheme(inner, exporter)
+}
+
+func (b *Backend) CreateFeatureConfig() []backend.ConfigFeature {
+	return []backend.ConfigFeature{
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "Maximum Spool Size",
+			Name:     MaxSizeConfigFeature,
+			Required: true,
+		},
+		{
+			Type:     backend.ConfigFeatureTypeText,
+			Input:    "select",
+			Title:    "Backend",
+			Name:     BackendConfigFeature,
+			Required: true,
+			Options:  innerBackends(),
+		},
+		{
+			Type:     backend.ConfigFeatureTypeMap,
+			Input:    "map",
+			Title:    "Backend Exporter",
+			Name:     ExporterConfigFeature,
+			Required: true,
+		},
+	}
+}
+
+func (b *Backend) ParseConfig(format string, config string) (configReturn types.Metadata, err error) {
+	if format == "yaml" {
+		configReturn = make(types.Metadata)
+		err = yaml.Unmarshal([]byte(config), &configReturn)
+		if err != nil {
+			return nil, errors.Wrap(errors.New("failed to parse config YAML"), err)
+		}
+		return
+	}
+	return nil, errors.New("unsupported format")
+}
+
+func (b *Backend) ConfigToFormat(format string, metadata types.Metadata) (string, error) {
+	if format == "yaml" {
+		value, err := yaml.Marshal(metadata)
+		return string(value), err
+	}
+	return "", errors.New("unsupported format")
+}
+
+// InnerSinkConfig returns the inner backend of a spool sink config and a copy of the config with the inner
+// exporter config in place of the spool one, as the collector writing to the inner backend sees it
+func InnerSinkConfig(config types.Metadata) (string, types.Metadata, bool) {
+	exporter := config.GetSubMetadata(ExporterConfigFeature)
+	name, ok := exporter[BackendConfigFeature].(string)
+	if !ok || name == "" || name == Name {
+		return "", nil, false
+	}
+	inner := types.FromMap(config)
+	inner[ExporterConfigFeature] = innerExporter(exporter)
+	return name, inner, true
+}
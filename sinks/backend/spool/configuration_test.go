@@ -0,0 +1,91 @@
+package spool
+
+import (
+	"testing"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
+	"github.com/orb-community/orb/sinks/backend/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfiguration() types.Metadata {
+	return types.Metadata{
+		MaxSizeConfigFeature:  "512MiB",
+		BackendConfigFeature:  "otlphttp",
+		ExporterConfigFeature: map[string]interface{}{otlphttpexporter.EndpointFieldName: "https://acme.com/otlp"},
+	}
+}
+
+func TestBackend_ValidateConfiguration(t *testing.T) {
+	otlphttpexporter.Register()
+	prometheus.Register()
+	Register()
+	be := &Backend{}
+
+	tests := []struct {
+		name   string
+		modify func(config types.Metadata)
+		err    error
+	}{
+		{name: "valid configuration", modify: func(config types.Metadata) {}},
+		{name: "missing max size", modify: func(config types.Metadata) { delete(config, MaxSizeConfigFeature) }, err: errors.ErrMalformedEntity},
+		{name: "invalid max size", modify: func(config types.Metadata) { config[MaxSizeConfigFeature] = "a lot" }, err: errors.ErrMalformedEntity},
+		{name: "missing backend", modify: func(config types.Metadata) { delete(config, BackendConfigFeature) }, err: errors.ErrBackendNotFound},
+		{name: "spool backend", modify: func(config types.Metadata) { config[BackendConfigFeature] = Name }, err: errors.ErrInvalidBackend},
+		{name: "backend without disk queue", modify: func(config types.Metadata) { config[BackendConfigFeature] = "prometheus" }, err: errors.ErrInvalidBackend},
+		{name: "unknown backend", modify: func(config types.Metadata) { config[BackendConfigFeature] = "unknown" }, err: errors.ErrInvalidBackend},
+		{name: "missing exporter", modify: func(config types.Metadata) { delete(config, ExporterConfigFeature) }, err: errors.ErrExporterFieldNotFound},
+		{name: "invalid inner exporter", modify: func(config types.Metadata) {
+			config[ExporterConfigFeature] = map[string]interface{}{otlphttpexporter.EndpointFieldName: "acme.com/otlp"}
+		}, err: errors.ErrInvalidEndpoint},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfiguration()
+			tt.modify(config)
+			err := be.ValidateConfiguration(config)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Contains(err, tt.err), "got %v", err)
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[interface{}]int64{
+		"512":      512,
+		"10B":      10,
+		"2KB":      2000,
+		"2 KiB":    2048,
+		"512MiB":   512 << 20,
+		"1GB":      1000 * 1000 * 1000,
+		float64(7): 7,
+	}
+	for value, want := range tests {
+		size, ok := ParseSize(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, want, size, value)
+	}
+	for _, value := range []interface{}{"", "MiB", "1TB", "-", nil} {
+		_, ok := ParseSize(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestInnerSinkConfig(t *testing.T) {
+	config := types.Metadata{"exporter": validConfiguration(), "opentelemetry": "enabled"}
+	name, inner, ok := InnerSinkConfig(config)
+	require.True(t, ok)
+	assert.Equal(t, "otlphttp", name)
+	assert.Equal(t, "https://acme.com/otlp", inner.GetSubMetadata("exporter")[otlphttpexporter.EndpointFieldName])
+	assert.Equal(t, "enabled", inner["opentelemetry"])
+	assert.Equal(t, "512MiB", config.GetSubMetadata("exporter")[MaxSizeConfigFeature], "the sink config must not be modified")
+
+	_, _, ok = InnerSinkConfig(types.Metadata{"exporter": types.Metadata{BackendConfigFeature: Name}})
+	assert.False(t, ok)
+}
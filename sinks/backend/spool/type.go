@@ -0,0 +1,121 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package spool
+
+import (
+	"sort"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+var _ backend.Backend = (*Backend)(nil)
+var _ backend.Wrapper = (*Backend)(nil)
+
+// Name is the name the store-and-forward backend is registered with
+const Name = "spool"
+
+const (
+	// MaxSizeConfigFeature bounds the disk the collector of the sink queues the data on
+	MaxSizeConfigFeature = "max_size"
+	// BackendConfigFeature is the backend the queued data is forwarded to
+	BackendConfigFeature = "backend"
+	// ExporterConfigFeature is the exporter config of the inner backend
+	ExporterConfigFeature = "exporter"
+)
+
+// Backend queues the data of the sink on the disk of its collector, the exporter of the inner backend retries the
+// queued writes until its remote end accepts them
+type Backend struct {
+	MaxSize  interface{}    `json:"max_size" yaml:"max_size"`
+	Backend  string         `json:"backend" yaml:"backend"`
+	Exporter types.Metadata `json:"exporter" yaml:"exporter"`
+}
+
+func (b *Backend) Metadata() interface{} {
+	return backend.SinkFeature{
+		Backend:     Name,
+		Description: "Store-and-forward sink, the data is queued on disk and forwarded to the inner backend once its remote end is reachable",
+		Signals:     b.SupportedSignals(),
+		Maturity:    b.Maturity(),
+		AuthTypes:   b.SupportedAuthTypes(),
+		Config:      b.CreateFeatureConfig(),
+	}
+}
+
+// SupportedSignals lists the signals of any inner backend, the sink accepts the ones of its own inner backend
+func (b *Backend) SupportedSignals() []string {
+	return innerUnion(func(be backend.Backend) []string {
+		return be.SupportedSignals()
+	})
+}
+
+func (b *Backend) Maturity() string {
+	return backend.MaturityExperimental
+}
+
+// SupportedAuthTypes lists the authentication types of any inner backend, the sink accepts the ones of its own
+// inner backend
+func (b *Backend) SupportedAuthTypes() []string {
+	return innerUnion(func(be backend.Backend) []string {
+		return be.SupportedAuthTypes()
+	})
+}
+
+// NormalizeLabels keeps the labels as they are, the sinker normalizes them for the inner backend
+func (b *Backend) NormalizeLabels(labels map[string]string) map[string]string {
+	return labels
+}
+
+// Inner returns the inner backend of the exporter config with its own exporter config
+func (b *Backend) Inner(config types.Metadata) (backend.Backend, types.Metadata, bool) {
+	name, ok := config[BackendConfigFeature].(string)
+	if !ok || name == Name {
+		return nil, nil, false
+	}
+	inner := backend.GetBackend(name)
+	if inner == nil || !queuesOnDisk(inner) {
+		return nil, nil, false
+	}
+	return inner, innerExporter(config), true
+}
+
+// innerBackends lists the registered backends the queued data can be forwarded to
+func innerBackends() []string {
+	names := make([]string, 0)
+	for _, name := range backend.GetList() {
+		if name != Name && queuesOnDisk(backend.GetBackend(name)) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// queuesOnDisk reports whether the collector exporter of the backend can keep its sending queue on disk
+func queuesOnDisk(be backend.Backend) bool {
+	q, ok := be.(backend.DiskQueue)
+	return ok && q.QueuesOnDisk()
+}
+
+// innerUnion merges the values listed by the registered backends which can be wrapped
+func innerUnion(values func(be backend.Backend) []string) []string {
+	seen := make(map[string]bool)
+	for _, name := range innerBackends() {
+		for _, value := range values(backend.GetBackend(name)) {
+			seen[value] = true
+		}
+	}
+	union := make([]string, 0, len(seen))
+	for value := range seen {
+		union = append(union, value)
+	}
+	sort.Strings(union)
+	return union
+}
+
+func Register() bool {
+	backend.Register(Name, &Backend{})
+	return true
+}
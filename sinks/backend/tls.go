@@ -0,0 +1,79 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"crypto/x509"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+const (
+	// TLSConfigFeature is the optional exporter field with the tls settings of the connections to the remote end
+	TLSConfigFeature = "tls"
+	// TLSCAConfigFeature is the field of the tls settings with the PEM encoded CA trusted for the remote end
+	TLSCAConfigFeature = "ca"
+)
+
+// TLSConfigFeatures documents the optional tls fields shared by the HTTP based exporter configs
+func TLSConfigFeatures() []ConfigFeature {
+	return []ConfigFeature{
+		{
+			Type:     ConfigFeatureTypeText,
+			Input:    "text",
+			Title:    "CA Certificate (PEM)",
+			Name:     TLSConfigFeature + "." + TLSCAConfigFeature,
+			Required: false,
+		},
+	}
+}
+
+// ValidateTLSConfig checks the optional tls fields of an exporter config, the CA must hold at least one
+// PEM encoded certificate
+func ValidateTLSConfig(config types.Metadata) error {
+	value, ok := config[TLSConfigFeature]
+	if !ok {
+		return nil
+	}
+	tlsConfig := config.GetSubMetadata(TLSConfigFeature)
+	if tlsConfig == nil && value != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("tls must be an object"))
+	}
+	if _, ok := tlsConfig[TLSCAConfigFeature]; !ok {
+		return nil
+	}
+	ca, ok := tlsConfig[TLSCAConfigFeature].(string)
+	if !ok {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.New("tls.ca must be a PEM encoded certificate"))
+	}
+	if _, err := certPool(ca); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TLSCA returns the PEM encoded CA of an exporter config, empty when the sink trusts the system roots
+func TLSCA(config types.Metadata) string {
+	ca, _ := config.GetSubMetadata(TLSConfigFeature)[TLSCAConfigFeature].(string)
+	return ca
+}
+
+// RootCAs returns the pool with the CA of an exporter config, nil when the sink trusts the system roots
+func RootCAs(config types.Metadata) (*x509.CertPool, error) {
+	ca := TLSCA(config)
+	if ca == "" {
+		return nil, nil
+	}
+	return certPool(ca)
+}
+
+func certPool(ca string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(ca)) {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("tls.ca is not a valid PEM encoded certificate"))
+	}
+	return pool, nil
+}
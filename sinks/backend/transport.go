@@ -0,0 +1,63 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package backend
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+// AllowInsecureConfigFeature is the optional exporter field letting a sink export to a plaintext http endpoint
+// when the deployment requires https
+const AllowInsecureConfigFeature = "allow_insecure"
+
+// RemoteEndpoints is implemented by the backends exporting over HTTP
+type RemoteEndpoints interface {
+	// EndpointFields lists the exporter fields holding the URLs of the remote end
+	EndpointFields() []string
+}
+
+var (
+	requireHTTPS          = false
+	allowInsecureOverride = true
+)
+
+// SetRequireHTTPS makes the validation reject the exporter endpoints which are not https, allowOverride lets
+// the sinks setting allow_insecure keep a plaintext endpoint
+func SetRequireHTTPS(require bool, allowOverride bool) {
+	requireHTTPS = require
+	allowInsecureOverride = allowOverride
+}
+
+// ValidateEndpointScheme checks the endpoints of an exporter config against the deployment https requirement
+func ValidateEndpointScheme(b Backend, config types.Metadata) error {
+	allowInsecure, ok := config[AllowInsecureConfigFeature]
+	if ok {
+		if _, isBool := allowInsecure.(bool); !isBool {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New(AllowInsecureConfigFeature+" must be a boolean"))
+		}
+	}
+	re, ok := b.(RemoteEndpoints)
+	if !requireHTTPS || !ok {
+		return nil
+	}
+	if allowInsecure == true && allowInsecureOverride {
+		return nil
+	}
+	for _, field := range re.EndpointFields() {
+		endpoint, ok := config[field].(string)
+		if !ok {
+			continue
+		}
+		// an endpoint which cannot be parsed is reported by the validation of the backend
+		if u, err := url.Parse(endpoint); err == nil && !strings.EqualFold(u.Scheme, "https") {
+			return errors.Wrap(errors.ErrInsecureEndpoint, errors.New(field+" must use https"))
+		}
+	}
+	return nil
+}
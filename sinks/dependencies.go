@@ -0,0 +1,65 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/orb-community/orb/pkg/errors"
+	policiespb "github.com/orb-community/orb/policies/pb"
+)
+
+var _ errors.Error = (*SinkInUseError)(nil)
+
+// SinkInUseError is returned when deleting a sink which datasets still route to, the deletion is only done
+// when it is forced
+type SinkInUseError struct {
+	Datasets []string
+}
+
+func (e *SinkInUseError) Error() string {
+	return ErrSinkInUse.Error() + " : " + strings.Join(e.Datasets, ", ")
+}
+
+func (e *SinkInUseError) Msg() string {
+	return ErrSinkInUse.Error()
+}
+
+func (e *SinkInUseError) Err() errors.Error {
+	return nil
+}
+
+// DependentDatasets returns the datasets routing to the sink when the error is a SinkInUseError
+func DependentDatasets(err error) ([]string, bool) {
+	e, ok := err.(errors.Error)
+	for ok && e != nil {
+		if inUse, isInUse := e.(*SinkInUseError); isInUse {
+			return inUse.Datasets, true
+		}
+		e = e.Err()
+	}
+	return nil, false
+}
+
+// checkSinkDependencies fails with a SinkInUseError when datasets of the owner still route to the sink, it is
+// skipped when the service has no policies client
+func (svc sinkService) checkSinkDependencies(ctx context.Context, ownerID string, sinkID string) error {
+	if svc.policiesClient == nil {
+		return nil
+	}
+	res, err := svc.policiesClient.RetrieveDatasetsBySink(ctx, &policiespb.DatasetsBySinkReq{SinkID: sinkID, OwnerID: ownerID})
+	if err != nil {
+		return errors.Wrap(ErrDependencyCheck, err)
+	}
+	if len(res.DatasetList) == 0 {
+		return nil
+	}
+	datasets := make([]string, 0, len(res.DatasetList))
+	for _, dataset := range res.DatasetList {
+		datasets = append(datasets, dataset.Id)
+	}
+	return &SinkInUseError{Datasets: datasets}
+}
@@ -0,0 +1,182 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// OwnerCredentials re-encrypts with the key of their owner the sink credentials encrypted with the deployment
+// key, once per owner keys are enabled. It is not versioned as the keys can be enabled on any release, the sinks
+// already migrated are left untouched so it runs on every start
+type OwnerCredentials struct {
+	logger          *zap.Logger
+	sinkRepo        sinks.SinkRepository
+	passwordService authentication_type.PasswordService
+}
+
+func NewOwnerCredentials(logger *zap.Logger, sinkRepo sinks.SinkRepository, passwordService authentication_type.PasswordService) *OwnerCredentials {
+	return &OwnerCredentials{
+		logger:          logger,
+		sinkRepo:        sinkRepo,
+		passwordService: passwordService,
+	}
+}
+
+func (p *OwnerCredentials) Up(ctx context.Context) (mainErr error) {
+	allSinks, mainErr := p.sinkRepo.SearchAllSinks(ctx, sinks.Filter{})
+	if mainErr != nil {
+		p.logger.Error("could not list sinks", zap.Error(mainErr))
+		return
+	}
+	updated := 0
+	for _, listed := range allSinks {
+		changed, err := p.reencrypt(&listed)
+		if err != nil {
+			p.logger.Error("failed to re-encrypt sink credentials", zap.String("sinkID", listed.ID), zap.Error(err))
+			mainErr = err
+			continue
+		}
+		if !changed {
+			continue
+		}
+		// the listed sinks lack their config data and format, which the update would wipe
+		sink, err := p.sinkRepo.RetrieveById(ctx, listed.ID)
+		if err != nil {
+			p.logger.Error("failed to retrieve sink", zap.String("sinkID", listed.ID), zap.Error(err))
+			mainErr = err
+			continue
+		}
+		if _, err := p.reencrypt(&sink); err != nil {
+			p.logger.Error("failed to re-encrypt sink credentials", zap.String("sinkID", sink.ID), zap.Error(err))
+			mainErr = err
+			continue
+		}
+		if err := p.sinkRepo.Update(ctx, sink); err != nil {
+			p.logger.Error("failed to update sink", zap.String("sinkID", sink.ID), zap.Error(err))
+			mainErr = err
+			continue
+		}
+		updated++
+	}
+	p.logger.Info("owner credentials migration results", zap.Int("total_sinks", len(allSinks)), zap.Int("updated_sinks", updated))
+	return
+}
+
+// reencrypt replaces the config of the sink when one of its secrets is still encrypted with the deployment key
+func (p *OwnerCredentials) reencrypt(sink *sinks.Sink) (bool, error) {
+	authType, ok := authentication_type.GetAuthType(sink.GetAuthenticationTypeName())
+	if !ok || sink.Config == nil {
+		return false, nil
+	}
+	be := backend.GetBackend(sink.Backend)
+	stored, err := copyConfig(sink.Config)
+	if err != nil {
+		return false, err
+	}
+	decoded, err := authType.DecodeInformation(sink.MFOwnerID, "object", stored)
+	if err != nil {
+		return false, err
+	}
+	config := decoded.(types.Metadata)
+	if be != nil {
+		config, err = sinks.ApplySecretHeaders(be, config, func(value string) (string, error) {
+			plain, err := p.passwordService.DecodeOwnerPassword(sink.MFOwnerID, value)
+			if err != nil {
+				// headers saved before being flagged as secrets stay in plain text
+				return value, nil
+			}
+			return plain, nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	if !hasDeploymentKeySecret(sink.Config, config) {
+		return false, nil
+	}
+	encoded, err := authType.EncodeInformation(sink.MFOwnerID, "object", config)
+	if err != nil {
+		return false, err
+	}
+	config = encoded.(types.Metadata)
+	if be != nil {
+		config, err = sinks.ApplySecretHeaders(be, config, func(value string) (string, error) {
+			return p.passwordService.EncodeOwnerPassword(sink.MFOwnerID, value)
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	sink.Config = config
+	if sink.ConfigData != "" {
+		configData, err := yaml.Marshal(config)
+		if err != nil {
+			return false, err
+		}
+		sink.ConfigData = string(configData)
+	}
+	return true, nil
+}
+
+// hasDeploymentKeySecret compares the stored config with the decoded one, the values which differ are the
+// secrets and any of them not bound to the owner needs to be re-encrypted
+func hasDeploymentKeySecret(stored interface{}, decoded interface{}) bool {
+	switch value := stored.(type) {
+	case types.Metadata:
+		return hasDeploymentKeySecret(map[string]interface{}(value), decoded)
+	case map[string]interface{}:
+		decodedMap, ok := configMap(decoded)
+		if !ok {
+			return false
+		}
+		for key, storedValue := range value {
+			if hasDeploymentKeySecret(storedValue, decodedMap[key]) {
+				return true
+			}
+		}
+	case []interface{}:
+		decodedList, ok := decoded.([]interface{})
+		if !ok || len(decodedList) != len(value) {
+			return false
+		}
+		for i := range value {
+			if hasDeploymentKeySecret(value[i], decodedList[i]) {
+				return true
+			}
+		}
+	case string:
+		plain, ok := decoded.(string)
+		return ok && plain != value && !authentication_type.IsOwnerEncoded(value)
+	}
+	return false
+}
+
+func configMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case types.Metadata:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	}
+	return nil, false
+}
+
+// copyConfig deep copies the config, the authentication types decode it in place
+func copyConfig(config types.Metadata) (types.Metadata, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var copied types.Metadata
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,103 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	skmocks "github.com/orb-community/orb/sinks/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOwnerCredentials(t *testing.T) {
+	const (
+		key   = "_testing_string_"
+		owner = "2b0d5d0a-6d2c-4c5b-9c0a-3f2e3c1b6b1a"
+	)
+	logger := zap.NewNop()
+	pwdSvc := authentication_type.NewPerOwnerPasswordService(logger, key)
+	basicauth.Register(pwdSvc)
+	sinkRepo := skmocks.NewSinkRepository(pwdSvc)
+
+	legacy, err := authentication_type.NewPasswordService(logger, key).EncodePassword("s3cret")
+	require.NoError(t, err)
+	nameID, err := types.NewIdentifier("legacy-sink")
+	require.NoError(t, err)
+	id, err := sinkRepo.Save(context.Background(), sinks.Sink{
+		Name:      nameID,
+		MFOwnerID: owner,
+		Backend:   "prometheus",
+		Config: types.Metadata{
+			"exporter":       types.Metadata{"remote_host": "https://orb.community/"},
+			"authentication": types.Metadata{"type": basicauth.AuthType, "username": "dbuser", "password": legacy},
+		},
+	})
+	require.NoError(t, err)
+
+	plan := NewOwnerCredentials(logger, sinkRepo, pwdSvc)
+	require.NoError(t, plan.Up(context.Background()))
+	sink, err := sinkRepo.RetrieveById(context.Background(), id)
+	require.NoError(t, err)
+	auth := sink.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+	migrated := auth["password"].(string)
+	assert.True(t, authentication_type.IsOwnerEncoded(migrated), "the password must be encrypted with the key of the owner")
+	assert.Equal(t, "dbuser", auth["username"])
+	plain, err := pwdSvc.DecodeOwnerPassword(owner, migrated)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plain)
+	_, err = pwdSvc.DecodeOwnerPassword("8a2b6f7e-0c9d-4a43-9d6f-1b2c3d4e5f60", migrated)
+	assert.Error(t, err, "the key of another owner must not decrypt the password")
+
+	require.NoError(t, plan.Up(context.Background()))
+	sink, err = sinkRepo.RetrieveById(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, migrated, sink.Config.GetSubMetadata(authentication_type.AuthenticationKey)["password"], "migrated sinks must be left untouched")
+}
+
+func TestOwnerCredentialsKeepsConfigData(t *testing.T) {
+	const (
+		key   = "_testing_string_"
+		owner = "2b0d5d0a-6d2c-4c5b-9c0a-3f2e3c1b6b1a"
+	)
+	logger := zap.NewNop()
+	pwdSvc := authentication_type.NewPerOwnerPasswordService(logger, key)
+	basicauth.Register(pwdSvc)
+	sinkRepo := skmocks.NewSinkRepository(pwdSvc)
+
+	legacy, err := authentication_type.NewPasswordService(logger, key).EncodePassword("s3cret")
+	require.NoError(t, err)
+	config := types.Metadata{
+		"exporter":       types.Metadata{"remote_host": "https://orb.community/"},
+		"authentication": types.Metadata{"type": basicauth.AuthType, "username": "dbuser", "password": legacy},
+	}
+	configData, err := yaml.Marshal(config)
+	require.NoError(t, err)
+	nameID, err := types.NewIdentifier("legacy-yaml-sink")
+	require.NoError(t, err)
+	id, err := sinkRepo.Save(context.Background(), sinks.Sink{
+		Name:       nameID,
+		MFOwnerID:  owner,
+		Backend:    "prometheus",
+		Config:     config,
+		ConfigData: string(configData),
+		Format:     "yaml",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, NewOwnerCredentials(logger, sinkRepo, pwdSvc).Up(context.Background()))
+	sink, err := sinkRepo.RetrieveById(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", sink.Format)
+	var stored types.Metadata
+	require.NoError(t, yaml.Unmarshal([]byte(sink.ConfigData), &stored))
+	auth := stored.GetSubMetadata(authentication_type.AuthenticationKey)
+	assert.Equal(t, "dbuser", auth["username"])
+	assert.True(t, authentication_type.IsOwnerEncoded(auth["password"].(string)), "the config data must hold the password encrypted with the key of the owner")
+	assert.Equal(t, "https://orb.community/", stored.GetSubMetadata("exporter")["remote_host"])
+}
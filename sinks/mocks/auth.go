@@ -19,11 +19,21 @@ import (
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
-	users map[string]string
+	users  map[string]string
+	admins map[string]bool
 }
 
 func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+	return &authServiceMock{users: users}
+}
+
+// NewAuthServiceWithAdmins creates an auth service mock where the given user ids are admins
+func NewAuthServiceWithAdmins(users map[string]string, admins []string) mainflux.AuthServiceClient {
+	adminSet := make(map[string]bool, len(admins))
+	for _, id := range admins {
+		adminSet[id] = true
+	}
+	return &authServiceMock{users: users, admins: adminSet}
 }
 
 func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
@@ -44,7 +54,10 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 }
 
 func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
-	panic("not implemented")
+	if req.GetObj() == "authorities" && req.GetAct() == "member" && svc.admins[req.GetSub()] {
+		return &mainflux.AuthorizeRes{Authorized: true}, nil
+	}
+	return nil, sinks.ErrForbidden
 }
 
 func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
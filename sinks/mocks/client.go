@@ -16,16 +16,23 @@ import (
 
 var _ pb.SinkServiceClient = (*grpcClient)(nil)
 
-type grpcClient struct{}
+type grpcClient struct {
+	signals map[string][]string
+}
 
 func (client grpcClient) RetrieveSinks(ctx context.Context, in *pb.SinksFilterReq, opts ...grpc.CallOption) (*pb.SinksRes, error) {
 	return &pb.SinksRes{}, nil
 }
 
 func (client grpcClient) RetrieveSink(ctx context.Context, in *pb.SinkByIDReq, opts ...grpc.CallOption) (*pb.SinkRes, error) {
-	return &pb.SinkRes{}, nil
+	return &pb.SinkRes{Id: in.SinkID, Signals: client.signals[in.SinkID]}, nil
 }
 
 func NewClient() pb.SinkServiceClient {
 	return &grpcClient{}
 }
+
+// NewClientWithSignals returns a client whose sinks accept the given signals by sink id
+func NewClientWithSignals(signals map[string][]string) pb.SinkServiceClient {
+	return &grpcClient{signals: signals}
+}
@@ -0,0 +1,38 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/orb-community/orb/policies/pb"
+	"google.golang.org/grpc"
+)
+
+var _ pb.PolicyServiceClient = (*policiesGrpcClientMock)(nil)
+
+// policiesGrpcClientMock only serves the datasets routing to each sink, the other methods are not implemented
+type policiesGrpcClientMock struct {
+	pb.PolicyServiceClient
+	datasets map[string][]string
+	err      error
+}
+
+// NewPoliciesServiceClient returns a policies client mock serving the dataset ids routing to each sink id, or
+// failing every lookup with err when it is set
+func NewPoliciesServiceClient(datasets map[string][]string, err error) pb.PolicyServiceClient {
+	return &policiesGrpcClientMock{datasets: datasets, err: err}
+}
+
+func (c *policiesGrpcClientMock) RetrieveDatasetsBySink(_ context.Context, in *pb.DatasetsBySinkReq, _ ...grpc.CallOption) (*pb.DatasetsRes, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	res := &pb.DatasetsRes{}
+	for _, id := range c.datasets[in.SinkID] {
+		res.DatasetList = append(res.DatasetList, &pb.DatasetRes{Id: id, SinkIds: []string{in.SinkID}})
+	}
+	return res, nil
+}
@@ -17,7 +17,10 @@ import (
 	"github.com/orb-community/orb/sinks"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 var _ sinks.SinkRepository = (*sinkRepositoryMock)(nil)
@@ -28,6 +31,10 @@ type sinkRepositoryMock struct {
 	counter   uint64
 	passSvc   authentication_type.PasswordService
 	sinksMock immutable.Map[string, sinks.Sink]
+	events    map[string][]sinks.StateEvent
+	revisions map[string][]sinks.ConfigRevision
+	tags      map[string]types.Tags
+	templates map[string]sinks.SinkTemplate
 }
 
 func (s *sinkRepositoryMock) GetVersion(_ context.Context) (string, error) {
@@ -38,11 +45,159 @@ func (s *sinkRepositoryMock) UpdateVersion(_ context.Context, _ string) error {
 	return nil
 }
 
-func (s *sinkRepositoryMock) SearchAllSinks(_ context.Context, _ sinks.Filter) ([]sinks.Sink, error) {
-	return nil, nil
+func (s *sinkRepositoryMock) SearchAllSinks(_ context.Context, filter sinks.Filter) ([]sinks.Sink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sks []sinks.Sink
+	itr := s.sinksMock.Iterator()
+	for !itr.Done() {
+		_, v, _ := itr.Next()
+		if filter.Backend != "" && v.Backend != filter.Backend {
+			continue
+		}
+		// pass test code
+		cfg := v.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+		if cfg["password"] == "dbpass" || cfg["password"] == "newpass" {
+			cfg["password"], _ = s.passSvc.EncodePassword(cfg["password"].(string))
+		}
+		// the repository does not list the config data and format of the sinks
+		v.ConfigData = ""
+		v.Format = ""
+		sks = append(sks, v)
+	}
+	return sks, nil
+}
+
+func (s *sinkRepositoryMock) UpdateSinkState(_ context.Context, sinkID string, msg string, ownerID string, state sinks.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sink, ok := s.sinksMock.Get(sinkID); ok && sink.MFOwnerID == ownerID {
+		sink.State = state
+		sink.Error = msg
+		s.sinksMock = *s.sinksMock.Set(sinkID, sink)
+		return nil
+	}
+	return sinks.ErrUpdateEntity
 }
 
-func (s *sinkRepositoryMock) UpdateSinkState(_ context.Context, _ string, _ string, _ string, _ sinks.State) error {
+func (s *sinkRepositoryMock) AddStateEvent(_ context.Context, event sinks.StateEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := append([]sinks.StateEvent{event}, s.events[event.SinkID]...)
+	if len(events) > sinks.MaxStateEvents {
+		events = events[:sinks.MaxStateEvents]
+	}
+	s.events[event.SinkID] = events
+	return nil
+}
+
+func (s *sinkRepositoryMock) RetrieveStateEvents(_ context.Context, sinkID string) ([]sinks.StateEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]sinks.StateEvent{}, s.events[sinkID]...), nil
+}
+
+func (s *sinkRepositoryMock) AddConfigRevision(_ context.Context, revision sinks.ConfigRevision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revision.Revision = 1
+	if current := s.revisions[revision.SinkID]; len(current) > 0 {
+		revision.Revision = current[0].Revision + 1
+	}
+	revisions := append([]sinks.ConfigRevision{revision}, s.revisions[revision.SinkID]...)
+	if len(revisions) > sinks.MaxConfigRevisions {
+		revisions = revisions[:sinks.MaxConfigRevisions]
+	}
+	s.revisions[revision.SinkID] = revisions
+	return nil
+}
+
+func (s *sinkRepositoryMock) RetrieveConfigRevisions(_ context.Context, sinkID string) ([]sinks.ConfigRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]sinks.ConfigRevision{}, s.revisions[sinkID]...), nil
+}
+
+func (s *sinkRepositoryMock) SaveOwnerDefaultTags(_ context.Context, ownerID string, tags types.Tags) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(tags) == 0 {
+		delete(s.tags, ownerID)
+		return nil
+	}
+	saved := make(types.Tags, len(tags))
+	for key, value := range tags {
+		saved[key] = value
+	}
+	s.tags[ownerID] = saved
+	return nil
+}
+
+func (s *sinkRepositoryMock) RetrieveOwnerDefaultTags(_ context.Context, ownerID string) (types.Tags, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tags := make(types.Tags, len(s.tags[ownerID]))
+	for key, value := range s.tags[ownerID] {
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+func (s *sinkRepositoryMock) SaveTemplate(_ context.Context, template sinks.SinkTemplate) (sinks.SinkTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	template.Created = now
+	if current, ok := s.templates[template.Name.String()]; ok {
+		template.Created = current.Created
+	}
+	template.Updated = now
+	s.templates[template.Name.String()] = template
+	return template, nil
+}
+
+func (s *sinkRepositoryMock) RetrieveTemplate(_ context.Context, name string) (sinks.SinkTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template, ok := s.templates[name]
+	if !ok {
+		return sinks.SinkTemplate{}, errors.ErrNotFound
+	}
+	return template, nil
+}
+
+func (s *sinkRepositoryMock) RetrieveAllTemplates(_ context.Context) ([]sinks.SinkTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	templates := make([]sinks.SinkTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name.String() < templates[j].Name.String()
+	})
+	return templates, nil
+}
+
+func (s *sinkRepositoryMock) RemoveTemplate(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.templates[name]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(s.templates, name)
 	return nil
 }
 
@@ -71,6 +226,10 @@ func NewSinkRepository(passSvc authentication_type.PasswordService) sinks.SinkRe
 	return &sinkRepositoryMock{
 		sinksMock: *mocks,
 		passSvc:   passSvc,
+		events:    make(map[string][]sinks.StateEvent),
+		revisions: make(map[string][]sinks.ConfigRevision),
+		tags:      make(map[string]types.Tags),
+		templates: make(map[string]sinks.SinkTemplate),
 	}
 }
 
@@ -105,11 +264,21 @@ func (s *sinkRepositoryMock) Update(_ context.Context, sink sinks.Sink) (err err
 		if sink.MFOwnerID != c.MFOwnerID {
 			return errors.ErrUpdateEntity
 		}
+		itr := s.sinksMock.Iterator()
+		for !itr.Done() {
+			_, v, _ := itr.Next()
+			if v.ID != sink.ID && v.MFOwnerID == sink.MFOwnerID && v.Name == sink.Name {
+				return sinks.ErrConflictSink
+			}
+		}
 		// create a full copy of the Config, because somehow it changes after adding to map
 		configCopy := make(types.Metadata)
 		bkpConfig := sink.Config
 		copyMetadata(configCopy, sink.Config)
 		sink.Config = configCopy
+		if sink.CredentialsUpdatedAt.IsZero() {
+			sink.CredentialsUpdatedAt = c.CredentialsUpdatedAt
+		}
 		s.sinksMock = *s.sinksMock.Set(sink.ID, sink)
 		sink.Config = bkpConfig
 		return nil
@@ -117,13 +286,38 @@ func (s *sinkRepositoryMock) Update(_ context.Context, sink sinks.Sink) (err err
 	return sinks.ErrNotFound
 }
 
+// copyMetadata deep copies the nested objects, the service decrypts the configs it reads in place
 func copyMetadata(dst, src types.Metadata) {
 	dv, sv := reflect.ValueOf(dst), reflect.ValueOf(src)
 	for _, k := range sv.MapKeys() {
-		dv.SetMapIndex(k, sv.MapIndex(k))
+		value := sv.MapIndex(k)
+		if copied := copyValue(value.Interface()); copied != nil {
+			value = reflect.ValueOf(copied)
+		}
+		dv.SetMapIndex(k, value)
 	}
 }
 
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case types.Metadata:
+		copied := make(types.Metadata, len(v))
+		copyMetadata(copied, v)
+		return copied
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		copyMetadata(copied, v)
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i := range v {
+			copied[i] = copyValue(v[i])
+		}
+		return copied
+	}
+	return value
+}
+
 func (s *sinkRepositoryMock) RetrieveAllByOwnerID(_ context.Context, owner string, pm sinks.PageMetadata) (sinks.Page, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -131,6 +325,11 @@ func (s *sinkRepositoryMock) RetrieveAllByOwnerID(_ context.Context, owner strin
 	first := uint64(pm.Offset) + 1
 	last := first + pm.Limit
 
+	exactTags, prefixTags, err := sinks.SplitTagSelectors(pm.Tags)
+	if err != nil {
+		return sinks.Page{}, err
+	}
+
 	var sks []sinks.Sink
 
 	id := uint64(0)
@@ -139,7 +338,8 @@ func (s *sinkRepositoryMock) RetrieveAllByOwnerID(_ context.Context, owner strin
 		_, v, _ := itr.Next()
 		id++
 		if v.MFOwnerID == owner && id >= first && id < last {
-			if reflect.DeepEqual(pm.Tags, v.Tags) || pm.Tags == nil {
+			if matchTags(exactTags, prefixTags, v.Tags) && matchCredentialsAge(pm.CredentialsOlderThan, v) &&
+				matchErrorContains(pm.ErrorContains, v) {
 				sks = append(sks, v)
 			}
 		}
@@ -158,6 +358,67 @@ func (s *sinkRepositoryMock) RetrieveAllByOwnerID(_ context.Context, owner strin
 	return page, nil
 }
 
+func (s *sinkRepositoryMock) Ping(_ context.Context) error {
+	return nil
+}
+
+func (s *sinkRepositoryMock) CountByOwnerID(_ context.Context, owner string, tags types.Tags) (sinks.Counts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exactTags, prefixTags, err := sinks.SplitTagSelectors(tags)
+	if err != nil {
+		return sinks.Counts{}, err
+	}
+
+	counts := sinks.Counts{
+		ByState:   map[string]uint64{},
+		ByBackend: map[string]uint64{},
+	}
+	itr := s.sinksMock.Iterator()
+	for !itr.Done() {
+		_, v, _ := itr.Next()
+		if v.MFOwnerID != owner || !matchTags(exactTags, prefixTags, v.Tags) {
+			continue
+		}
+		counts.Total++
+		counts.ByState[v.State.String()]++
+		counts.ByBackend[v.Backend]++
+	}
+	return counts, nil
+}
+
+// matchCredentialsAge checks that the sink credentials were set before the given age, a zero age matches every sink
+func matchCredentialsAge(olderThan time.Duration, sink sinks.Sink) bool {
+	if olderThan <= 0 {
+		return true
+	}
+	return sink.CredentialsUpdatedAt.Before(time.Now().Add(-olderThan))
+}
+
+func matchErrorContains(substring string, sink sinks.Sink) bool {
+	if substring == "" {
+		return true
+	}
+	return sink.State == sinks.Error && strings.Contains(strings.ToLower(sink.Error), strings.ToLower(substring))
+}
+
+// matchTags checks that tags contains every exact selector and every prefix selector
+func matchTags(exact types.Tags, prefixes types.Tags, tags types.Tags) bool {
+	for key, value := range exact {
+		if tags[key] != value {
+			return false
+		}
+	}
+	for key, prefix := range prefixes {
+		value, ok := tags[key]
+		if !ok || !strings.HasPrefix(value, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *sinkRepositoryMock) RetrieveById(_ context.Context, key string) (sinks.Sink, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/orb-community/orb/sinks"
+)
+
+var _ sinks.StateChangeStream = (*stateStreamMock)(nil)
+
+type stateStreamMock struct {
+	changes []sinks.StateChange
+}
+
+// NewStateChangeStream creates a state stream mock holding the given changes, in stream order
+func NewStateChangeStream(changes ...sinks.StateChange) sinks.StateChangeStream {
+	return &stateStreamMock{changes: changes}
+}
+
+func (s *stateStreamMock) Latest(_ context.Context) (string, error) {
+	if len(s.changes) == 0 {
+		return "0-0", nil
+	}
+	return s.changes[len(s.changes)-1].ID, nil
+}
+
+func (s *stateStreamMock) Read(ctx context.Context, after string, block time.Duration) ([]sinks.StateChange, error) {
+	start := 0
+	for i, change := range s.changes {
+		if change.ID == after {
+			start = i + 1
+		}
+	}
+	if start < len(s.changes) {
+		return s.changes[start:], nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(block):
+		return nil, nil
+	}
+}
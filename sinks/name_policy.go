@@ -0,0 +1,47 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+// DefaultNamePattern is the sink name pattern of the deployments which do not set one, the same as the pattern
+// of the identifiers
+const DefaultNamePattern = "^[a-zA-Z_][a-zA-Z0-9_-]*$"
+
+// namePolicy is the pattern the names of the sinks created or updated must match, on top of being identifiers
+var namePolicy = regexp.MustCompile(DefaultNamePattern)
+
+// SetNamePattern sets the pattern of the sink names of the deployment, an empty pattern restores the default
+func SetNamePattern(pattern string) error {
+	if pattern == "" {
+		pattern = DefaultNamePattern
+	}
+	policy, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid sink name pattern: %w", err)
+	}
+	namePolicy = policy
+	return nil
+}
+
+// NamePattern returns the pattern of the sink names, so the clients can validate them before sending
+func NamePattern() string {
+	return namePolicy.String()
+}
+
+// validateName checks the name against the pattern. It is only called on writes, so the sinks stored before the
+// pattern was set are still viewable
+func validateName(name types.Identifier) error {
+	if !namePolicy.MatchString(name.String()) {
+		return errors.Wrap(errors.ErrMalformedEntity, fmt.Errorf("sink name %q does not match the pattern %s", name.String(), namePolicy.String()))
+	}
+	return nil
+}
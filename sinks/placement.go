@@ -0,0 +1,91 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+const exporterKey = "exporter"
+
+// ErrConfigMisplaced indicates an exporter field was found under the authentication or vice versa,
+// the wrapped error carries the hint on where the field belongs
+var ErrConfigMisplaced = errors.New("misplaced sink config field")
+
+// ValidateConfigPlacement looks for the fields of the exporter placed under the authentication and the
+// fields of the authentication placed under the exporter, so the user gets a hint instead of a missing field error
+func ValidateConfigPlacement(be backend.Backend, config types.Metadata) error {
+	if config == nil {
+		return nil
+	}
+	exporterFields := make(map[string]bool)
+	for _, feature := range be.CreateFeatureConfig() {
+		exporterFields[feature.Name] = true
+	}
+	authFields := map[string]bool{"type": true}
+	for _, authType := range authentication_type.GetList() {
+		for _, feature := range authType.Config {
+			authFields[feature.Name] = true
+		}
+	}
+
+	var authBlocks []types.Metadata
+	if multiauth.IsMultiAuth(config) {
+		// malformed blocks are reported by the authentication validation
+		authBlocks, _ = multiauth.Blocks(config[authentication_type.AuthenticationKey])
+	} else if auth := config.GetSubMetadata(authentication_type.AuthenticationKey); auth != nil {
+		authBlocks = []types.Metadata{auth}
+	}
+	for _, auth := range authBlocks {
+		if field, ok := misplacedField(auth, exporterFields, authFields); ok {
+			return misplacedError(field, exporterKey, authentication_type.AuthenticationKey)
+		}
+	}
+
+	if exporter := config.GetSubMetadata(exporterKey); exporter != nil {
+		if field, ok := misplacedField(exporter, authFields, exporterFields); ok {
+			return misplacedError(field, authentication_type.AuthenticationKey, exporterKey)
+		}
+	}
+	return nil
+}
+
+// misplacedField returns the first field of the block, in name order, that only belongs to the other block
+func misplacedField(block types.Metadata, otherFields, ownFields map[string]bool) (string, bool) {
+	keys := make([]string, 0, len(block))
+	for key := range block {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if otherFields[key] && !ownFields[key] {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func misplacedError(field, belongs, found string) error {
+	return errors.Wrap(ErrConfigMisplaced, errors.New(fmt.Sprintf("%s belongs under config.%s, not config.%s", field, belongs, found)))
+}
+
+// MisplacedConfigHint returns the hint on where the misplaced field belongs when the error is a ErrConfigMisplaced
+func MisplacedConfigHint(err error) (string, bool) {
+	e, ok := err.(errors.Error)
+	for ok && e != nil {
+		if e.Msg() == ErrConfigMisplaced.Msg() && e.Err() != nil {
+			return e.Err().Msg(), true
+		}
+		e = e.Err()
+	}
+	return "", false
+}
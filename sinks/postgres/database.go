@@ -53,7 +53,7 @@ func (dm database) NamedQueryContext(ctx context.Context, query string, args int
 
 func (dm database) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	addSpanTags(ctx, query)
-	return dm.db.GetContext(ctx, dest, query, args)
+	return dm.db.GetContext(ctx, dest, query, args...)
 }
 
 func (dm database) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
@@ -35,6 +35,22 @@ func Connect(cfg config.PostgresConfig) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// ConnectReplica creates a connection to a read-only replica of the PostgreSQL instance,
+// migrations are left to the primary connection.
+func ConnectReplica(url string) (*sqlx.DB, error) {
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
 func migrateDB(db *sqlx.DB) error {
 	migrations := &migrate.MemoryMigrationSource{
 		Migrations: []*migrate.Migration{
@@ -103,6 +119,114 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TYPE public.sinks_state DROP VALUE IF EXISTS 'provisioning_error';`,
 				},
 			},
+			{
+				Id: "sinks_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sink_state_events (
+						id          BIGSERIAL PRIMARY KEY,
+						sink_id     UUID NOT NULL REFERENCES sinks (id) ON DELETE CASCADE,
+						old_state   sinks_state NOT NULL,
+						new_state   sinks_state NOT NULL,
+						message     TEXT,
+						ts_created  TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX ON sink_state_events (sink_id, id DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE sink_state_events",
+				},
+			},
+			{
+				Id: "sinks_6",
+				Up: []string{
+					`ALTER TABLE sinks ADD COLUMN IF NOT EXISTS credentials_updated_at TIMESTAMPTZ`,
+					`UPDATE sinks SET credentials_updated_at = ts_created WHERE credentials_updated_at IS NULL`,
+					`ALTER TABLE sinks ALTER COLUMN credentials_updated_at SET DEFAULT CURRENT_TIMESTAMP`,
+					`ALTER TABLE sinks ALTER COLUMN credentials_updated_at SET NOT NULL`,
+				},
+				Down: []string{
+					"ALTER TABLE sinks DROP COLUMN credentials_updated_at",
+				},
+			},
+			{
+				Id: "sinks_7",
+				Up: []string{
+					`ALTER TYPE public.sinks_state ADD VALUE IF NOT EXISTS 'circuit_open';`,
+				},
+				Down: []string{
+					`ALTER TYPE public.sinks_state DROP VALUE IF EXISTS 'circuit_open';`,
+				},
+			},
+			{
+				Id: "sinks_8",
+				Up: []string{
+					`ALTER TABLE sinks ADD COLUMN IF NOT EXISTS signal_type VARCHAR(32) NOT NULL DEFAULT ''`,
+					`UPDATE sinks SET signal_type = 'metrics' WHERE backend = 'prometheus'`,
+				},
+				Down: []string{
+					"ALTER TABLE sinks DROP COLUMN signal_type",
+				},
+			},
+			{
+				Id: "sinks_9",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sink_owner_defaults (
+						mf_owner_id  UUID PRIMARY KEY,
+						tags         JSONB NOT NULL DEFAULT '{}',
+						ts_updated   TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE sink_owner_defaults",
+				},
+			},
+			{
+				Id: "sinks_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sink_event_outbox (
+						id          BIGSERIAL PRIMARY KEY,
+						stream      TEXT NOT NULL,
+						payload     JSONB NOT NULL DEFAULT '{}',
+						ts_created  TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE sink_event_outbox",
+				},
+			},
+			{
+				Id: "sinks_11",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sink_templates (
+						name         VARCHAR(1024) PRIMARY KEY,
+						description  TEXT NOT NULL DEFAULT '',
+						backend      TEXT NOT NULL,
+						signal_type  VARCHAR(32) NOT NULL DEFAULT '',
+						metadata     JSONB NOT NULL DEFAULT '{}',
+						tags         JSONB NOT NULL DEFAULT '{}',
+						ts_created   TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+						ts_updated   TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE sink_templates",
+				},
+			},
+			{
+				Id: "sinks_12",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sink_config_revisions (
+						sink_id     UUID NOT NULL REFERENCES sinks (id) ON DELETE CASCADE,
+						revision    BIGINT NOT NULL,
+						config      JSONB NOT NULL DEFAULT '{}',
+						ts_created  TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+						PRIMARY KEY (sink_id, revision)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE sink_config_revisions",
+				},
+			},
 		},
 	}
 
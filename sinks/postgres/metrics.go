@@ -0,0 +1,73 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ Database = (*metricsDatabase)(nil)
+
+type metricsDatabase struct {
+	db       Database
+	counter  metrics.Counter
+	duration metrics.Histogram
+}
+
+// NewDatabaseMetricsMiddleware counts the database operations, labeled by operation and status (ok or error),
+// and observes their duration in seconds, labeled by operation. The duration of the queries returning rows
+// does not include the iteration of the rows
+func NewDatabaseMetricsMiddleware(db Database, counter metrics.Counter, duration metrics.Histogram) Database {
+	return &metricsDatabase{db: db, counter: counter, duration: duration}
+}
+
+func (m metricsDatabase) observe(operation string, begin time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.counter.With("operation", operation, "status", status).Add(1)
+	m.duration.With("operation", operation).Observe(time.Since(begin).Seconds())
+}
+
+func (m metricsDatabase) NamedExecContext(ctx context.Context, query string, args interface{}) (res sql.Result, err error) {
+	defer func(begin time.Time) {
+		m.observe("exec", begin, err)
+	}(time.Now())
+	return m.db.NamedExecContext(ctx, query, args)
+}
+
+func (m metricsDatabase) QueryRowxContext(ctx context.Context, query string, args ...interface{}) (row *sqlx.Row) {
+	defer func(begin time.Time) {
+		m.observe("query_row", begin, row.Err())
+	}(time.Now())
+	return m.db.QueryRowxContext(ctx, query, args...)
+}
+
+func (m metricsDatabase) NamedQueryContext(ctx context.Context, query string, args interface{}) (rows *sqlx.Rows, err error) {
+	defer func(begin time.Time) {
+		m.observe("query", begin, err)
+	}(time.Now())
+	return m.db.NamedQueryContext(ctx, query, args)
+}
+
+func (m metricsDatabase) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	defer func(begin time.Time) {
+		m.observe("get", begin, err)
+	}(time.Now())
+	return m.db.GetContext(ctx, dest, query, args...)
+}
+
+func (m metricsDatabase) BeginTxx(ctx context.Context, opts *sql.TxOptions) (tx *sqlx.Tx, err error) {
+	defer func(begin time.Time) {
+		m.observe("begin", begin, err)
+	}(time.Now())
+	return m.db.BeginTxx(ctx, opts)
+}
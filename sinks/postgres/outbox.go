@@ -0,0 +1,160 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/orb-community/orb/pkg/db"
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/sinks"
+	"github.com/orb-community/orb/sinks/authentication_type"
+)
+
+var _ sinks.EventOutbox = (*eventOutbox)(nil)
+
+// outboxSecretValues are the event values holding the decrypted sink config, kept encrypted in the outbox
+var outboxSecretValues = []string{"config"}
+
+type eventOutbox struct {
+	db              Database
+	passwordService authentication_type.PasswordService
+}
+
+// NewEventOutbox instantiates the outbox keeping the sinks events which could not be published, the sink configs
+// of the events are encrypted with the password service
+func NewEventOutbox(db Database, passwordService authentication_type.PasswordService) sinks.EventOutbox {
+	return &eventOutbox{db: db, passwordService: passwordService}
+}
+
+func (o eventOutbox) SaveEvent(ctx context.Context, stream string, values map[string]interface{}) error {
+	q := `INSERT INTO sink_event_outbox (stream, payload, ts_created) VALUES (:stream, :payload, :ts_created)`
+	payload, err := o.encodePayload(values)
+	if err != nil {
+		return errors.Wrap(db.ErrSaveDB, err)
+	}
+	params := map[string]interface{}{
+		"stream":     stream,
+		"payload":    payload,
+		"ts_created": time.Now(),
+	}
+
+	if _, err := o.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(db.ErrSaveDB, err)
+	}
+
+	return nil
+}
+
+func (o eventOutbox) RetrievePendingEvents(ctx context.Context, limit uint64) ([]sinks.OutboxEvent, error) {
+	q := `SELECT id, stream, payload, ts_created FROM sink_event_outbox ORDER BY id LIMIT :limit`
+	params := map[string]interface{}{
+		"limit": limit,
+	}
+
+	rows, err := o.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	events := make([]sinks.OutboxEvent, 0)
+	for rows.Next() {
+		dbe := dbOutboxEvent{}
+		if err := rows.StructScan(&dbe); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		events = append(events, sinks.OutboxEvent{
+			ID:      dbe.ID,
+			Stream:  dbe.Stream,
+			Values:  o.decodePayload(dbe.Payload),
+			Created: dbe.Created,
+		})
+	}
+
+	return events, nil
+}
+
+func (o eventOutbox) RemoveEvent(ctx context.Context, id int64) error {
+	q := `DELETE FROM sink_event_outbox WHERE id = :id`
+	if _, err := o.db.NamedExecContext(ctx, q, map[string]interface{}{"id": id}); err != nil {
+		return errors.Wrap(sinks.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbOutboxEvent struct {
+	ID      int64       `db:"id"`
+	Stream  string      `db:"stream"`
+	Payload db.Metadata `db:"payload"`
+	Created time.Time   `db:"ts_created"`
+}
+
+// encodePayload converts the values to the strings the stream keeps, so they are not changed by the JSON
+// encoding of the payload, a []byte would be read back as base64. The sink config is encrypted
+func (o eventOutbox) encodePayload(values map[string]interface{}) (db.Metadata, error) {
+	payload := make(db.Metadata, len(values))
+	for key, value := range values {
+		payload[key] = streamValue(value)
+	}
+	for _, key := range outboxSecretValues {
+		plain, ok := payload[key].(string)
+		if !ok {
+			continue
+		}
+		encrypted, err := o.passwordService.EncodePassword(plain)
+		if err != nil {
+			return nil, err
+		}
+		payload[key] = encrypted
+	}
+	return payload, nil
+}
+
+// decodePayload returns the values of a saved event, decrypting the sink config. A config which does not decrypt
+// was saved before the outbox encrypted them and is returned as saved
+func (o eventOutbox) decodePayload(payload db.Metadata) map[string]interface{} {
+	values := make(map[string]interface{}, len(payload))
+	for key, value := range payload {
+		values[key] = value
+	}
+	for _, key := range outboxSecretValues {
+		encrypted, ok := values[key].(string)
+		if !ok {
+			continue
+		}
+		if plain, err := o.passwordService.DecodePassword(encrypted); err == nil {
+			values[key] = plain
+		}
+	}
+	return values
+}
+
+// streamValue formats a value as the redis client does when adding it to a stream
+func streamValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEventOutbox(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	outbox := postgres.NewEventOutbox(dbMiddleware, authentication_type.NewPasswordService(zap.NewNop(), "_testing_string_"))
+
+	ctx := context.Background()
+	stream := "orb.sinks"
+	first := map[string]interface{}{"operation": "sinks.create", "sink_id": "first"}
+	second := map[string]interface{}{"operation": "sinks.remove", "sink_id": "second"}
+
+	require.Nil(t, outbox.SaveEvent(ctx, stream, first))
+	require.Nil(t, outbox.SaveEvent(ctx, stream, second))
+
+	events, err := outbox.RetrievePendingEvents(ctx, 1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, events, 1, "limit should bound the pending events")
+	assert.Equal(t, stream, events[0].Stream)
+	assert.Equal(t, first, events[0].Values, "pending events should be retrieved oldest first")
+
+	require.Nil(t, outbox.RemoveEvent(ctx, events[0].ID))
+
+	events, err = outbox.RetrievePendingEvents(ctx, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, events, 1, "removed event should not be pending")
+	assert.Equal(t, second, events[0].Values)
+
+	require.Nil(t, outbox.RemoveEvent(ctx, events[0].ID))
+}
+
+func TestEventOutboxConfig(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	outbox := postgres.NewEventOutbox(dbMiddleware, authentication_type.NewPasswordService(zap.NewNop(), "_testing_string_"))
+
+	ctx := context.Background()
+	config := []byte(`{"authentication":{"type":"basicauth","username":"dbuser","password":"dbpass"}}`)
+	require.Nil(t, outbox.SaveEvent(ctx, "orb.sinks", map[string]interface{}{
+		"operation": "sinks.create",
+		"sink_id":   "with-config",
+		"config":    config,
+		"timestamp": int64(1700000000),
+	}))
+
+	var payload string
+	require.Nil(t, db.GetContext(ctx, &payload, `SELECT payload::text FROM sink_event_outbox ORDER BY id DESC LIMIT 1`))
+	assert.NotContains(t, payload, "dbpass", "the sink config must be kept encrypted")
+
+	events, err := outbox.RetrievePendingEvents(ctx, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]interface{}{
+		"operation": "sinks.create",
+		"sink_id":   "with-config",
+		"config":    string(config),
+		"timestamp": "1700000000",
+	}, events[0].Values, "the values should be read back as the stream keeps them")
+
+	require.Nil(t, outbox.RemoveEvent(ctx, events[0].ID))
+}
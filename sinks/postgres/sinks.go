@@ -21,6 +21,7 @@ import (
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
 	"go.uber.org/zap"
+	"sort"
 	"strings"
 	"time"
 )
@@ -28,8 +29,18 @@ import (
 var _ sinks.SinkRepository = (*sinksRepository)(nil)
 
 type sinksRepository struct {
-	db     Database
-	logger *zap.Logger
+	db      Database
+	replica Database
+	logger  *zap.Logger
+}
+
+// reader returns the connection serving the reads made with the context, the read replica is only
+// used when one is configured and the caller tolerates stale reads
+func (s sinksRepository) reader(ctx context.Context) Database {
+	if s.replica != nil && sinks.StaleReadsAllowed(ctx) {
+		return s.replica
+	}
+	return s.db
 }
 
 func (s sinksRepository) UpdateVersion(ctx context.Context, incomingVersion string) error {
@@ -82,12 +93,20 @@ func (s sinksRepository) GetVersion(ctx context.Context) (string, error) {
 }
 
 func (s sinksRepository) SearchAllSinks(ctx context.Context, filter sinks.Filter) ([]sinks.Sink, error) {
-	q := `SELECT id, name, mf_owner_id, description, tags, state, coalesce(error, '') as error, backend, metadata, ts_created FROM sinks`
+	q := `SELECT id, name, mf_owner_id, description, tags, state, coalesce(error, '') as error, backend, metadata, ts_created, credentials_updated_at, signal_type FROM sinks`
 	params := map[string]interface{}{}
-	if (filter != sinks.Filter{} && filter.StateFilter != "") {
-		q += `WHERE state == :state`
+	var where []string
+	if filter.StateFilter != "" {
+		where = append(where, `state = :state`)
 		params["state"] = filter.StateFilter
 	}
+	if filter.Backend != "" {
+		where = append(where, `backend = :backend`)
+		params["backend"] = filter.Backend
+	}
+	if len(where) > 0 {
+		q += ` WHERE ` + strings.Join(where, " AND ")
+	}
 
 	rows, err := s.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -117,8 +136,8 @@ func (s sinksRepository) SearchAllSinks(ctx context.Context, filter sinks.Filter
 }
 
 func (s sinksRepository) Save(ctx context.Context, sink sinks.Sink) (string, error) {
-	q := `INSERT INTO sinks (name, mf_owner_id, metadata, config_data, format, description, backend, tags, state, error)         
-			  VALUES (:name, :mf_owner_id, :metadata, :config_data, :format, :description, :backend, :tags, :state, :error) RETURNING id`
+	q := `INSERT INTO sinks (name, mf_owner_id, metadata, config_data, format, description, backend, tags, state, error, credentials_updated_at, signal_type)         
+			  VALUES (:name, :mf_owner_id, :metadata, :config_data, :format, :description, :backend, :tags, :state, :error, COALESCE(:credentials_updated_at, CURRENT_TIMESTAMP), :signal_type) RETURNING id`
 
 	if !sink.Name.IsValid() || sink.MFOwnerID == "" {
 		return "", errors.ErrMalformedEntity
@@ -160,7 +179,9 @@ func (s sinksRepository) Update(ctx context.Context, sink sinks.Sink) error {
 			    metadata = :metadata,  
 			    config_data = :config_data, 
 			    format = :format, 
-			    name = :name 
+			    name = :name, 
+			    signal_type = :signal_type, 
+			    credentials_updated_at = COALESCE(:credentials_updated_at, credentials_updated_at) 
 			WHERE mf_owner_id = :mf_owner_id 
 			  AND id = :id;`
 
@@ -202,12 +223,22 @@ func (s sinksRepository) RetrieveAllByOwnerID(ctx context.Context, owner string,
 	if err != nil {
 		return sinks.Page{}, errors.Wrap(errors.ErrSelectEntity, err)
 	}
-	tags, tagsQuery, err := getTagsQuery(pm.Tags)
+	exactTags, prefixTags, err := sinks.SplitTagSelectors(pm.Tags)
+	if err != nil {
+		return sinks.Page{}, err
+	}
+	tags, tagsQuery, err := getTagsQuery(exactTags)
 	if err != nil {
 		return sinks.Page{}, errors.Wrap(errors.ErrSelectEntity, err)
 	}
+	prefixParams, prefixQuery := getTagPrefixQuery(prefixTags)
+	tagsQuery += prefixQuery
+	credentialsBefore, credentialsQuery := getCredentialsAgeQuery(pm.CredentialsOlderThan)
+	tagsQuery += credentialsQuery
+	errorContains, errorQuery := getErrorContainsQuery(pm.ErrorContains)
+	tagsQuery += errorQuery
 
-	q := fmt.Sprintf(`SELECT id, name, mf_owner_id, description, tags, state, coalesce(error, '') as error, backend, metadata, config_data, format, ts_created
+	q := fmt.Sprintf(`SELECT id, name, mf_owner_id, description, tags, state, coalesce(error, '') as error, backend, metadata, config_data, format, ts_created, credentials_updated_at, signal_type
 								FROM sinks 
 								WHERE mf_owner_id = :mf_owner_id %s%s%s 
 								ORDER BY %s %s LIMIT :limit OFFSET :offset;`,
@@ -220,7 +251,16 @@ func (s sinksRepository) RetrieveAllByOwnerID(ctx context.Context, owner string,
 		"metadata":    metadata,
 		"tags":        tags,
 	}
-	rows, err := s.db.NamedQueryContext(ctx, q, params)
+	if credentialsQuery != "" {
+		params["credentials_before"] = credentialsBefore
+	}
+	if errorQuery != "" {
+		params["error_contains"] = errorContains
+	}
+	for k, v := range prefixParams {
+		params[k] = v
+	}
+	rows, err := s.reader(ctx).NamedQueryContext(ctx, q, params)
 	if err != nil {
 		return sinks.Page{}, errors.Wrap(errors.ErrSelectEntity, err)
 	}
@@ -243,7 +283,7 @@ func (s sinksRepository) RetrieveAllByOwnerID(ctx context.Context, owner string,
 
 	count := fmt.Sprintf(`SELECT COUNT(*) FROM sinks WHERE mf_owner_id = :mf_owner_id %s%s%s`, tagsQuery, metadataQuery, nameQuery)
 
-	total, err := total(ctx, s.db, count, params)
+	total, err := total(ctx, s.reader(ctx), count, params)
 	if err != nil {
 		return sinks.Page{}, errors.Wrap(errors.ErrSelectEntity, err)
 	}
@@ -262,14 +302,81 @@ func (s sinksRepository) RetrieveAllByOwnerID(ctx context.Context, owner string,
 	return page, nil
 }
 
+func (s sinksRepository) CountByOwnerID(ctx context.Context, owner string, tags types.Tags) (sinks.Counts, error) {
+	exactTags, prefixTags, err := sinks.SplitTagSelectors(tags)
+	if err != nil {
+		return sinks.Counts{}, err
+	}
+	exact, tagsQuery, err := getTagsQuery(exactTags)
+	if err != nil {
+		return sinks.Counts{}, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	prefixParams, prefixQuery := getTagPrefixQuery(prefixTags)
+	tagsQuery += prefixQuery
+
+	// a single pass over the owner sinks, GROUPING tells apart the rows of the state and backend groups
+	q := fmt.Sprintf(`SELECT GROUPING(state) AS by_backend, coalesce(state::text, '') AS state, coalesce(backend, '') AS backend, COUNT(*) AS count
+								FROM sinks
+								WHERE mf_owner_id = :mf_owner_id %s
+								GROUP BY GROUPING SETS ((state), (backend));`, tagsQuery)
+	params := map[string]interface{}{
+		"mf_owner_id": owner,
+		"tags":        exact,
+	}
+	for k, v := range prefixParams {
+		params[k] = v
+	}
+	rows, err := s.reader(ctx).NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return sinks.Counts{}, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	counts := sinks.Counts{
+		ByState:   map[string]uint64{},
+		ByBackend: map[string]uint64{},
+	}
+	for rows.Next() {
+		var group struct {
+			ByBackend int    `db:"by_backend"`
+			State     string `db:"state"`
+			Backend   string `db:"backend"`
+			Count     uint64 `db:"count"`
+		}
+		if err := rows.StructScan(&group); err != nil {
+			return sinks.Counts{}, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		if group.ByBackend == 1 {
+			counts.ByBackend[group.Backend] = group.Count
+			continue
+		}
+		counts.ByState[group.State] = group.Count
+		counts.Total += group.Count
+	}
+	return counts, nil
+}
+
+func (s sinksRepository) Ping(ctx context.Context) error {
+	var one int
+	if err := s.db.QueryRowxContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return err
+	}
+	if s.replica != nil {
+		if err := s.replica.QueryRowxContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			return errors.Wrap(errors.New("read replica"), err)
+		}
+	}
+	return nil
+}
+
 func (s sinksRepository) RetrieveById(ctx context.Context, id string) (sinks.Sink, error) {
 
-	q := `SELECT id, name, mf_owner_id, description, tags, backend, metadata, format, config_data, ts_created, state, coalesce(error, '') as error
+	q := `SELECT id, name, mf_owner_id, description, tags, backend, metadata, format, config_data, ts_created, credentials_updated_at, signal_type, state, coalesce(error, '') as error
 			FROM sinks where id = $1`
 
 	dba := dbSink{}
 
-	if err := s.db.QueryRowxContext(ctx, q, id).StructScan(&dba); err != nil {
+	if err := s.reader(ctx).QueryRowxContext(ctx, q, id).StructScan(&dba); err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if err == sql.ErrNoRows || ok && db.ErrInvalid == pqErr.Code.Name() {
 			return sinks.Sink{}, errors.Wrap(errors.ErrNotFound, err)
@@ -282,7 +389,7 @@ func (s sinksRepository) RetrieveById(ctx context.Context, id string) (sinks.Sin
 
 func (s sinksRepository) RetrieveByOwnerAndId(ctx context.Context, ownerID string, id string) (sinks.Sink, error) {
 
-	q := `SELECT id, name, mf_owner_id, description, tags, backend, metadata, format, config_data, ts_created, state, coalesce(error, '') as error
+	q := `SELECT id, name, mf_owner_id, description, tags, backend, metadata, format, config_data, ts_created, credentials_updated_at, signal_type, state, coalesce(error, '') as error
 			FROM sinks where id = $1 and mf_owner_id = $2`
 
 	if ownerID == "" || id == "" {
@@ -291,7 +398,7 @@ func (s sinksRepository) RetrieveByOwnerAndId(ctx context.Context, ownerID strin
 
 	dba := dbSink{}
 
-	if err := s.db.QueryRowxContext(ctx, q, id, ownerID).StructScan(&dba); err != nil {
+	if err := s.reader(ctx).QueryRowxContext(ctx, q, id, ownerID).StructScan(&dba); err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if err == sql.ErrNoRows || ok && db.ErrInvalid == pqErr.Code.Name() {
 			return sinks.Sink{}, errors.Wrap(errors.ErrNotFound, err)
@@ -343,6 +450,297 @@ func (s sinksRepository) UpdateSinkState(ctx context.Context, sinkID string, msg
 	return nil
 }
 
+func (s sinksRepository) AddStateEvent(ctx context.Context, event sinks.StateEvent) error {
+	// the delete does not see the inserted event, so it keeps one less
+	q := `WITH inserted AS (
+			INSERT INTO sink_state_events (sink_id, old_state, new_state, message, ts_created)
+			VALUES (:sink_id, :old_state, :new_state, :message, :ts_created)
+		)
+		DELETE FROM sink_state_events WHERE sink_id = :sink_id AND id NOT IN (
+			SELECT id FROM sink_state_events WHERE sink_id = :sink_id ORDER BY id DESC LIMIT :keep
+		)`
+	params := map[string]interface{}{
+		"sink_id":    event.SinkID,
+		"old_state":  event.OldState,
+		"new_state":  event.NewState,
+		"message":    event.Message,
+		"ts_created": event.Timestamp,
+		"keep":       sinks.MaxStateEvents - 1,
+	}
+
+	if _, err := s.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(db.ErrSaveDB, err)
+	}
+
+	return nil
+}
+
+func (s sinksRepository) RetrieveStateEvents(ctx context.Context, sinkID string) ([]sinks.StateEvent, error) {
+	q := `SELECT sink_id, old_state, new_state, message, ts_created FROM sink_state_events
+		WHERE sink_id = :sink_id ORDER BY id DESC LIMIT :limit`
+	params := map[string]interface{}{
+		"sink_id": sinkID,
+		"limit":   sinks.MaxStateEvents,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	events := make([]sinks.StateEvent, 0)
+	for rows.Next() {
+		dbe := dbStateEvent{}
+		if err := rows.StructScan(&dbe); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		events = append(events, sinks.StateEvent{
+			SinkID:    dbe.SinkID,
+			Timestamp: dbe.Created,
+			OldState:  dbe.OldState,
+			NewState:  dbe.NewState,
+			Message:   dbe.Message.String,
+		})
+	}
+
+	return events, nil
+}
+
+// configRevisionAttempts bounds the retries of a config revision numbered concurrently with another one
+const configRevisionAttempts = 3
+
+func (s sinksRepository) AddConfigRevision(ctx context.Context, revision sinks.ConfigRevision) error {
+	// the delete does not see the inserted revision, so it keeps one less. Two revisions of a sink added at once
+	// get the same number, the primary key rejects the last one which is numbered again
+	q := `WITH inserted AS (
+			INSERT INTO sink_config_revisions (sink_id, revision, config, ts_created)
+			SELECT :sink_id, COALESCE(MAX(revision), 0) + 1, :config, :ts_created
+			FROM sink_config_revisions WHERE sink_id = :sink_id
+		)
+		DELETE FROM sink_config_revisions WHERE sink_id = :sink_id AND revision NOT IN (
+			SELECT revision FROM sink_config_revisions WHERE sink_id = :sink_id ORDER BY revision DESC LIMIT :keep
+		)`
+	params := map[string]interface{}{
+		"sink_id":    revision.SinkID,
+		"config":     db.Metadata(revision.Config),
+		"ts_created": revision.Timestamp,
+		"keep":       sinks.MaxConfigRevisions - 1,
+	}
+
+	var err error
+	for attempt := 0; attempt < configRevisionAttempts; attempt++ {
+		if _, err = s.db.NamedExecContext(ctx, q, params); err == nil {
+			return nil
+		}
+		if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code.Name() != db.ErrDuplicate {
+			break
+		}
+	}
+	return errors.Wrap(db.ErrSaveDB, err)
+}
+
+func (s sinksRepository) RetrieveConfigRevisions(ctx context.Context, sinkID string) ([]sinks.ConfigRevision, error) {
+	q := `SELECT sink_id, revision, config, ts_created FROM sink_config_revisions
+		WHERE sink_id = :sink_id ORDER BY revision DESC LIMIT :limit`
+	params := map[string]interface{}{
+		"sink_id": sinkID,
+		"limit":   sinks.MaxConfigRevisions,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	revisions := make([]sinks.ConfigRevision, 0)
+	for rows.Next() {
+		dbr := dbConfigRevision{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		revisions = append(revisions, sinks.ConfigRevision{
+			SinkID:    dbr.SinkID,
+			Revision:  dbr.Revision,
+			Timestamp: dbr.Created,
+			Config:    types.Metadata(dbr.Config),
+		})
+	}
+
+	return revisions, nil
+}
+
+func (s sinksRepository) SaveOwnerDefaultTags(ctx context.Context, ownerID string, tags types.Tags) error {
+	if len(tags) == 0 {
+		q := `DELETE FROM sink_owner_defaults WHERE mf_owner_id = :mf_owner_id`
+		if _, err := s.db.NamedExecContext(ctx, q, map[string]interface{}{"mf_owner_id": ownerID}); err != nil {
+			return errors.Wrap(sinks.ErrRemoveEntity, err)
+		}
+		return nil
+	}
+
+	q := `INSERT INTO sink_owner_defaults (mf_owner_id, tags, ts_updated)
+		VALUES (:mf_owner_id, :tags, :ts_updated)
+		ON CONFLICT (mf_owner_id) DO UPDATE SET tags = EXCLUDED.tags, ts_updated = EXCLUDED.ts_updated`
+	params := map[string]interface{}{
+		"mf_owner_id": ownerID,
+		"tags":        db.Tags(tags),
+		"ts_updated":  time.Now(),
+	}
+
+	if _, err := s.db.NamedExecContext(ctx, q, params); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && (pqErr.Code.Name() == db.ErrInvalid || pqErr.Code.Name() == db.ErrTruncation) {
+			return errors.Wrap(sinks.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(db.ErrSaveDB, err)
+	}
+
+	return nil
+}
+
+func (s sinksRepository) RetrieveOwnerDefaultTags(ctx context.Context, ownerID string) (types.Tags, error) {
+	q := `SELECT tags FROM sink_owner_defaults WHERE mf_owner_id = $1`
+
+	var tags db.Tags
+	if err := s.db.QueryRowxContext(ctx, q, ownerID).Scan(&tags); err != nil {
+		if err == sql.ErrNoRows {
+			return types.Tags{}, nil
+		}
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+
+	return types.Tags(tags), nil
+}
+
+func (s sinksRepository) SaveTemplate(ctx context.Context, template sinks.SinkTemplate) (sinks.SinkTemplate, error) {
+	q := `INSERT INTO sink_templates (name, description, backend, signal_type, metadata, tags, ts_created, ts_updated)
+		VALUES (:name, :description, :backend, :signal_type, :metadata, :tags, :ts_updated, :ts_updated)
+		ON CONFLICT (name) DO UPDATE SET description = EXCLUDED.description, backend = EXCLUDED.backend,
+			signal_type = EXCLUDED.signal_type, metadata = EXCLUDED.metadata, tags = EXCLUDED.tags, ts_updated = EXCLUDED.ts_updated
+		RETURNING name, description, backend, signal_type, metadata, tags, ts_created, ts_updated`
+
+	if !template.Name.IsValid() {
+		return sinks.SinkTemplate{}, errors.ErrMalformedEntity
+	}
+	params := dbSinkTemplate{
+		Name:        template.Name,
+		Description: template.Description,
+		Backend:     template.Backend,
+		Type:        template.Type,
+		Metadata:    db.Metadata(template.Config),
+		Tags:        db.Tags(template.Tags),
+		Updated:     time.Now(),
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && (pqErr.Code.Name() == db.ErrInvalid || pqErr.Code.Name() == db.ErrTruncation) {
+			return sinks.SinkTemplate{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return sinks.SinkTemplate{}, errors.Wrap(db.ErrSaveDB, err)
+	}
+	defer rows.Close()
+
+	dbt := dbSinkTemplate{}
+	if !rows.Next() {
+		return sinks.SinkTemplate{}, errors.Wrap(db.ErrSaveDB, rows.Err())
+	}
+	if err := rows.StructScan(&dbt); err != nil {
+		return sinks.SinkTemplate{}, errors.Wrap(db.ErrSaveDB, err)
+	}
+	return toSinkTemplate(dbt), nil
+}
+
+func (s sinksRepository) RetrieveTemplate(ctx context.Context, name string) (sinks.SinkTemplate, error) {
+	q := `SELECT name, description, backend, signal_type, metadata, tags, ts_created, ts_updated
+		FROM sink_templates WHERE name = $1`
+
+	dbt := dbSinkTemplate{}
+	if err := s.reader(ctx).QueryRowxContext(ctx, q, name).StructScan(&dbt); err != nil {
+		if err == sql.ErrNoRows {
+			return sinks.SinkTemplate{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return sinks.SinkTemplate{}, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	return toSinkTemplate(dbt), nil
+}
+
+func (s sinksRepository) RetrieveAllTemplates(ctx context.Context) ([]sinks.SinkTemplate, error) {
+	q := `SELECT name, description, backend, signal_type, metadata, tags, ts_created, ts_updated
+		FROM sink_templates ORDER BY name`
+
+	rows, err := s.reader(ctx).NamedQueryContext(ctx, q, map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	templates := make([]sinks.SinkTemplate, 0)
+	for rows.Next() {
+		dbt := dbSinkTemplate{}
+		if err := rows.StructScan(&dbt); err != nil {
+			return nil, errors.Wrap(errors.ErrSelectEntity, err)
+		}
+		templates = append(templates, toSinkTemplate(dbt))
+	}
+	return templates, nil
+}
+
+func (s sinksRepository) RemoveTemplate(ctx context.Context, name string) error {
+	q := `DELETE FROM sink_templates WHERE name = :name`
+
+	res, err := s.db.NamedExecContext(ctx, q, map[string]interface{}{"name": name})
+	if err != nil {
+		return errors.Wrap(sinks.ErrRemoveEntity, err)
+	}
+	if count, err := res.RowsAffected(); err == nil && count == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+type dbSinkTemplate struct {
+	Name        types.Identifier `db:"name"`
+	Description string           `db:"description"`
+	Backend     string           `db:"backend"`
+	Type        string           `db:"signal_type"`
+	Metadata    db.Metadata      `db:"metadata"`
+	Tags        db.Tags          `db:"tags"`
+	Created     time.Time        `db:"ts_created"`
+	Updated     time.Time        `db:"ts_updated"`
+}
+
+func toSinkTemplate(dbt dbSinkTemplate) sinks.SinkTemplate {
+	return sinks.SinkTemplate{
+		Name:        dbt.Name,
+		Description: dbt.Description,
+		Backend:     dbt.Backend,
+		Type:        dbt.Type,
+		Config:      types.Metadata(dbt.Metadata),
+		Tags:        types.Tags(dbt.Tags),
+		Created:     dbt.Created,
+		Updated:     dbt.Updated,
+	}
+}
+
+type dbStateEvent struct {
+	SinkID   string         `db:"sink_id"`
+	OldState sinks.State    `db:"old_state"`
+	NewState sinks.State    `db:"new_state"`
+	Message  sql.NullString `db:"message"`
+	Created  time.Time      `db:"ts_created"`
+}
+
+type dbConfigRevision struct {
+	SinkID   string      `db:"sink_id"`
+	Revision uint64      `db:"revision"`
+	Config   db.Metadata `db:"config"`
+	Created  time.Time   `db:"ts_created"`
+}
+
 type dbSink struct {
 	ID          string           `db:"id"`
 	Name        types.Identifier `db:"name"`
@@ -356,6 +754,9 @@ type dbSink struct {
 	Tags        db.Tags          `db:"tags"`
 	State       sinks.State      `db:"state"`
 	Error       string           `db:"error"`
+	// CredentialsUpdatedAt is nil when unknown, so updates keep the stored value
+	CredentialsUpdatedAt *time.Time `db:"credentials_updated_at"`
+	SignalType           string     `db:"signal_type"`
 }
 
 func toDBSink(sink sinks.Sink) (dbSink, error) {
@@ -373,6 +774,11 @@ func toDBSink(sink sinks.Sink) (dbSink, error) {
 		description = *sink.Description
 	}
 
+	var credentialsUpdatedAt *time.Time
+	if !sink.CredentialsUpdatedAt.IsZero() {
+		credentialsUpdatedAt = &sink.CredentialsUpdatedAt
+	}
+
 	return dbSink{
 		ID:          sink.ID,
 		Name:        sink.Name,
@@ -386,6 +792,9 @@ func toDBSink(sink sinks.Sink) (dbSink, error) {
 		Tags:        db.Tags(sink.Tags),
 		State:       sink.State,
 		Error:       sink.Error,
+
+		CredentialsUpdatedAt: credentialsUpdatedAt,
+		SignalType:           sink.Type,
 	}, nil
 
 }
@@ -414,6 +823,10 @@ func toSink(dba dbSink) (sinks.Sink, error) {
 		Format:      format,
 		Created:     dba.Created,
 		Tags:        types.Tags(dba.Tags),
+		Type:        dba.SignalType,
+	}
+	if dba.CredentialsUpdatedAt != nil {
+		sink.CredentialsUpdatedAt = *dba.CredentialsUpdatedAt
 	}
 	return sink, nil
 }
@@ -427,6 +840,22 @@ func getNameQuery(name string) (string, string) {
 	return name, nameQuey
 }
 
+func getCredentialsAgeQuery(olderThan time.Duration) (time.Time, string) {
+	if olderThan <= 0 {
+		return time.Time{}, ""
+	}
+	return time.Now().Add(-olderThan), ` AND credentials_updated_at < :credentials_before`
+}
+
+// getErrorContainsQuery matches the sinks in error state whose error message contains the substring,
+// the LIKE wildcards of the substring are matched literally
+func getErrorContainsQuery(substring string) (string, string) {
+	if substring == "" {
+		return "", ""
+	}
+	return fmt.Sprintf(`%%%s%%`, likeEscaper.Replace(substring)), ` AND state = 'error' AND error ILIKE :error_contains`
+}
+
 func getOrderQuery(order string) string {
 	switch order {
 	case "name":
@@ -476,6 +905,31 @@ func getTagsQuery(m types.Tags) ([]byte, string, error) {
 	return mb, mq, nil
 }
 
+// likeEscaper escapes the LIKE special characters, so they are matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// getTagPrefixQuery matches each tag value by prefix with LIKE, escaping the LIKE special characters of the prefix
+func getTagPrefixQuery(m types.Tags) (map[string]interface{}, string) {
+	if len(m) == 0 {
+		return nil, ""
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := make(map[string]interface{}, 2*len(m))
+	var mq strings.Builder
+	for i, key := range keys {
+		keyParam := fmt.Sprintf("tag_key_%d", i)
+		valueParam := fmt.Sprintf("tag_prefix_%d", i)
+		mq.WriteString(fmt.Sprintf(` AND tags->>:%s LIKE :%s`, keyParam, valueParam))
+		params[keyParam] = key
+		params[valueParam] = likeEscaper.Replace(m[key]) + "%"
+	}
+	return params, mq.String()
+}
+
 func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
 	rows, err := db.NamedQueryContext(ctx, query, params)
 	if err != nil {
@@ -494,3 +948,9 @@ func total(ctx context.Context, db Database, query string, params interface{}) (
 func NewSinksRepository(db Database, logger *zap.Logger) sinks.SinkRepository {
 	return &sinksRepository{db: db, logger: logger}
 }
+
+// NewSinksRepositoryWithReplica instantiates a sinks repository sending the reads tolerating stale data
+// to the replica, writes and the remaining reads stay on the primary db
+func NewSinksRepositoryWithReplica(db Database, replica Database, logger *zap.Logger) sinks.SinkRepository {
+	return &sinksRepository{db: db, replica: replica, logger: logger}
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks"
@@ -296,6 +297,10 @@ func TestMultiSinkRetrieval(t *testing.T) {
 			Config:      map[string]interface{}{"remote_host": "data", "username": "dbuser"},
 			Tags:        map[string]string{"cloud": "aws"},
 		}
+		if i < 3 {
+			sink.State = sinks.Error
+			sink.Error = "remote write failed: 401 Unauthorized"
+		}
 
 		_, err = sinkRepo.Save(context.Background(), sink)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
@@ -375,6 +380,66 @@ func TestMultiSinkRetrieval(t *testing.T) {
 			},
 			size: n,
 		},
+		"retrieve sinks filtered by tag prefix": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+				Tags:   map[string]string{"cloud": "aw*"},
+			},
+			size: n,
+		},
+		"retrieve sinks filtered by tag prefix with like characters": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  0,
+				Tags:   map[string]string{"cloud": "a_*"},
+			},
+			size: 0,
+		},
+		"retrieve sinks filtered by recent credentials age": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset:               0,
+				Limit:                n,
+				Total:                0,
+				CredentialsOlderThan: 24 * time.Hour,
+			},
+			size: 0,
+		},
+		"retrieve sinks filtered by elapsed credentials age": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset:               0,
+				Limit:                n,
+				Total:                n,
+				CredentialsOlderThan: time.Nanosecond,
+			},
+			size: n,
+		},
+		"retrieve sinks in error filtered by error message substring": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset:        0,
+				Limit:         n,
+				Total:         3,
+				ErrorContains: "UNAUTHORIZED",
+			},
+			size: 3,
+		},
+		"retrieve sinks in error filtered by error message substring with like characters": {
+			owner: oID.String(),
+			pageMetadata: sinks.PageMetadata{
+				Offset:        0,
+				Limit:         n,
+				Total:         0,
+				ErrorContains: "401_",
+			},
+			size: 0,
+		},
 		"retrieve sinks filtered by metadata": {
 			owner: oID.String(),
 			pageMetadata: sinks.PageMetadata{
@@ -402,6 +467,78 @@ func TestMultiSinkRetrieval(t *testing.T) {
 	}
 }
 
+func TestSinkCount(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	sinkRepo := postgres.NewSinksRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	wrongoID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	backends := []string{"prometheus", "prometheus", "otlphttp"}
+	for i, be := range backends {
+		nameID, err := types.NewIdentifier(fmt.Sprintf("my-sink-%d", i))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		sink := sinks.Sink{
+			Name:        nameID,
+			Description: &description,
+			Backend:     be,
+			Created:     time.Now(),
+			MFOwnerID:   oID.String(),
+			Config:      map[string]interface{}{"remote_host": "data", "username": "dbuser"},
+			Tags:        map[string]string{"cloud": be},
+		}
+
+		sinkID, err := sinkRepo.Save(context.Background(), sink)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		if i == 0 {
+			err = sinkRepo.UpdateSinkState(context.Background(), sinkID, "", oID.String(), sinks.Active)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		}
+	}
+
+	cases := map[string]struct {
+		owner  string
+		tags   types.Tags
+		counts sinks.Counts
+	}{
+		"count sinks with existing owner": {
+			owner: oID.String(),
+			counts: sinks.Counts{
+				Total:     3,
+				ByState:   map[string]uint64{sinks.Active.String(): 1, sinks.Unknown.String(): 2},
+				ByBackend: map[string]uint64{"prometheus": 2, "otlphttp": 1},
+			},
+		},
+		"count sinks with existing owner filtered by tags": {
+			owner: oID.String(),
+			tags:  types.Tags{"cloud": "otlp*"},
+			counts: sinks.Counts{
+				Total:     1,
+				ByState:   map[string]uint64{sinks.Unknown.String(): 1},
+				ByBackend: map[string]uint64{"otlphttp": 1},
+			},
+		},
+		"count sinks with non-existing owner": {
+			owner: wrongoID.String(),
+			counts: sinks.Counts{
+				ByState:   map[string]uint64{},
+				ByBackend: map[string]uint64{},
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		t.Run(desc, func(t *testing.T) {
+			counts, err := sinkRepo.CountByOwnerID(context.Background(), tc.owner, tc.tags)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+			assert.Equal(t, tc.counts, counts, fmt.Sprintf("%s: expected %v got %v", desc, tc.counts, counts))
+		})
+	}
+}
+
 func TestSinkRemoval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	sinkRepo := postgres.NewSinksRepository(dbMiddleware, logger)
@@ -613,3 +750,183 @@ func testSortSinks(t *testing.T, pm sinks.PageMetadata, sks []sinks.Sink) {
 		break
 	}
 }
+
+type countingDatabase struct {
+	postgres.Database
+	queries int
+}
+
+func (c *countingDatabase) NamedQueryContext(ctx context.Context, query string, args interface{}) (*sqlx.Rows, error) {
+	c.queries++
+	return c.Database.NamedQueryContext(ctx, query, args)
+}
+
+func (c *countingDatabase) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	c.queries++
+	return c.Database.QueryRowxContext(ctx, query, args...)
+}
+
+func TestSinkReadReplica(t *testing.T) {
+	replica := &countingDatabase{Database: postgres.NewDatabase(db)}
+	sinkRepo := postgres.NewSinksRepositoryWithReplica(postgres.NewDatabase(db), replica, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nameID, err := types.NewIdentifier("my-replica-sink")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	sink := sinks.Sink{
+		Name:        nameID,
+		Description: &description,
+		Backend:     "prometheus",
+		Created:     time.Now(),
+		MFOwnerID:   oID.String(),
+		Config:      map[string]interface{}{"remote_host": "data", "username": "dbuser"},
+		Tags:        map[string]string{"cloud": "aws"},
+	}
+	sinkID, err := sinkRepo.Save(context.Background(), sink)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 0, replica.queries, "writes must stay on the primary")
+
+	_, err = sinkRepo.RetrieveById(context.Background(), sinkID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 0, replica.queries, "reads requiring fresh data must stay on the primary")
+
+	staleCtx := sinks.WithStaleReads(context.Background())
+	_, err = sinkRepo.RetrieveById(staleCtx, sinkID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	page, err := sinkRepo.RetrieveAllByOwnerID(staleCtx, oID.String(), sinks.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, uint64(1), page.Total)
+	_, err = sinkRepo.CountByOwnerID(staleCtx, oID.String(), nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 4, replica.queries, "reads tolerating stale data must be served by the replica")
+}
+
+func TestOwnerDefaultTags(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	sinkRepo := postgres.NewSinksRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	tags, err := sinkRepo.RetrieveOwnerDefaultTags(context.Background(), oID.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, tags, "owner without defaults should have no default tags")
+
+	// cases run in order, each one replaces the defaults saved by the previous one
+	cases := []struct {
+		desc string
+		tags types.Tags
+	}{
+		{
+			desc: "save owner default tags",
+			tags: types.Tags{"managed_by": "orb", "cost_center": "cc-42"},
+		},
+		{
+			desc: "replace owner default tags",
+			tags: types.Tags{"managed_by": "orb"},
+		},
+		{
+			desc: "remove owner default tags",
+			tags: types.Tags{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := sinkRepo.SaveOwnerDefaultTags(context.Background(), oID.String(), tc.tags)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+			tags, err := sinkRepo.RetrieveOwnerDefaultTags(context.Background(), oID.String())
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.tags, tags, tc.desc)
+		})
+	}
+}
+
+func TestSinkTemplates(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	sinkRepo := postgres.NewSinksRepository(dbMiddleware, logger)
+
+	name, err := types.NewIdentifier("internal-prometheus")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = sinkRepo.RetrieveTemplate(context.Background(), name.String())
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s", errors.ErrNotFound, err))
+
+	template := sinks.SinkTemplate{
+		Name:        name,
+		Description: "Standard internal Prometheus",
+		Backend:     "prometheus",
+		Type:        "metrics",
+		Config:      types.Metadata{"exporter": map[string]interface{}{"remote_host": "https://prometheus.internal/api/v1/write"}},
+		Tags:        types.Tags{"team": "platform"},
+	}
+	saved, err := sinkRepo.SaveTemplate(context.Background(), template)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, template.Tags, saved.Tags)
+	assert.False(t, saved.Created.IsZero())
+
+	// saving under the same name replaces the template and keeps its creation time
+	template.Tags = types.Tags{"team": "netops"}
+	replaced, err := sinkRepo.SaveTemplate(context.Background(), template)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, saved.Created.Unix(), replaced.Created.Unix())
+
+	retrieved, err := sinkRepo.RetrieveTemplate(context.Background(), name.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, types.Tags{"team": "netops"}, retrieved.Tags)
+	assert.Equal(t, "https://prometheus.internal/api/v1/write", retrieved.Config.GetSubMetadata("exporter")["remote_host"])
+
+	templates, err := sinkRepo.RetrieveAllTemplates(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, templates, 1)
+
+	err = sinkRepo.RemoveTemplate(context.Background(), name.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = sinkRepo.RemoveTemplate(context.Background(), name.String())
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s", errors.ErrNotFound, err))
+}
+
+func TestAddConfigRevisionConcurrently(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	sinkRepo := postgres.NewSinksRepository(dbMiddleware, logger)
+
+	oID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	nameID, err := types.NewIdentifier("my-sink-revisions")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	sinkID, err := sinkRepo.Save(context.Background(), sinks.Sink{
+		Name:      nameID,
+		Backend:   "prometheus",
+		MFOwnerID: oID.String(),
+		Config:    map[string]interface{}{"remote_host": "data", "username": "dbuser"},
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// the revisions added at once are all kept, each with its own number
+	const added = 3
+	errs := make(chan error, added)
+	for i := 0; i < added; i++ {
+		go func() {
+			errs <- sinkRepo.AddConfigRevision(context.Background(), sinks.ConfigRevision{
+				SinkID:    sinkID,
+				Timestamp: time.Now(),
+				Config:    map[string]interface{}{"remote_host": "data"},
+			})
+		}()
+	}
+	for i := 0; i < added; i++ {
+		assert.Nil(t, <-errs, "unexpected error adding a config revision")
+	}
+
+	revisions, err := sinkRepo.RetrieveConfigRevisions(context.Background(), sinkID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	require.Len(t, revisions, added)
+	for i, revision := range revisions {
+		assert.Equal(t, uint64(added-i), revision.Revision)
+	}
+}
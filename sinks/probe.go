@@ -0,0 +1,108 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+	"github.com/orb-community/orb/sinks/authentication_type/clientcert"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
+	"github.com/orb-community/orb/sinks/backend"
+)
+
+// ProbeTimeout bounds the live probe of a sink
+const ProbeTimeout = 10 * time.Second
+
+// ErrProbeNotSupported indicates that the sink backend can not be probed
+var ErrProbeNotSupported = errors.New("live probe is not supported by the sink backend")
+
+func (svc sinkService) ProbeSink(ctx context.Context, token string, sink Sink) error {
+	_, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+
+	// a wrapping backend is probed through its inner backend, with the inner exporter config
+	be, exporterConfig := backend.Unwrap(backend.GetBackend(sink.Backend), sink.Config.GetSubMetadata("exporter"))
+	prober, ok := be.(backend.Prober)
+	if !ok {
+		return ErrProbeNotSupported
+	}
+	header, tlsConfig, err := probeAuthentication(sink.Config)
+	if err != nil {
+		return err
+	}
+	rootCAs, err := backend.RootCAs(exporterConfig)
+	if err != nil {
+		return err
+	}
+	if rootCAs != nil {
+		// the CA of the exporter is trusted for this sink only, the client certificate one takes precedence
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = rootCAs
+		}
+	}
+	client := &http.Client{
+		Timeout:   ProbeTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, DialContext: backend.ProbeDialContext()},
+	}
+	return prober.Probe(ctx, client, exporterConfig, header)
+}
+
+// probeAuthentication builds the request authentication of the probe out of the not encrypted sink config
+func probeAuthentication(config types.Metadata) (http.Header, *tls.Config, error) {
+	var blocks []types.Metadata
+	if multiauth.IsMultiAuth(config) {
+		var err error
+		blocks, err = multiauth.Blocks(config[authentication_type.AuthenticationKey])
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if authMeta := config.GetSubMetadata(authentication_type.AuthenticationKey); authMeta != nil {
+		blocks = []types.Metadata{authMeta}
+	}
+
+	header := make(http.Header)
+	var tlsConfig *tls.Config
+	for _, block := range blocks {
+		authType, _ := block["type"].(string)
+		switch authType {
+		case "", basicauth.AuthType:
+			username, _ := block[basicauth.UsernameConfigFeature].(string)
+			password, _ := block[basicauth.PasswordConfigFeature].(string)
+			req := http.Request{Header: make(http.Header)}
+			req.SetBasicAuth(username, password)
+			header.Set("Authorization", req.Header.Get("Authorization"))
+		case bearertokenauth.AuthType:
+			scheme, _ := block[bearertokenauth.SchemeConfigFeature].(string)
+			if scheme == "" {
+				scheme = "Bearer"
+			}
+			token, _ := block[bearertokenauth.TokenConfigFeature].(string)
+			header.Set("Authorization", scheme+" "+token)
+		case clientcert.AuthType:
+			cert, _ := block[clientcert.CertConfigFeature].(string)
+			key, _ := block[clientcert.KeyConfigFeature].(string)
+			ca, _ := block[clientcert.CAConfigFeature].(string)
+			var err error
+			tlsConfig, err = clientcert.NewTLSConfig(cert, key, ca)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return header, tlsConfig, nil
+}
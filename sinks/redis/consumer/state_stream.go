@@ -0,0 +1,76 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package consumer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/orb-community/orb/sinks"
+	redis2 "github.com/orb-community/orb/sinks/redis"
+)
+
+// stateStreamCount is the maximum number of state changes returned by a read
+const stateStreamCount = 100
+
+var _ sinks.StateChangeStream = (*stateStream)(nil)
+
+type stateStream struct {
+	client *redis.Client
+}
+
+// NewStateChangeStream reads the sink state changes from the sink state stream, every client reads the whole
+// stream so no consumer group is used
+func NewStateChangeStream(client *redis.Client) sinks.StateChangeStream {
+	return stateStream{client: client}
+}
+
+func (s stateStream) Latest(ctx context.Context) (string, error) {
+	msgs, err := s.client.XRevRangeN(ctx, redis2.StreamSinkStates, "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(msgs) == 0 {
+		return "0-0", nil
+	}
+	return msgs[0].ID, nil
+}
+
+func (s stateStream) Read(ctx context.Context, after string, block time.Duration) ([]sinks.StateChange, error) {
+	streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{redis2.StreamSinkStates, after},
+		Count:   stateStreamCount,
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var changes []sinks.StateChange
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			changes = append(changes, decodeStateChange(msg))
+		}
+	}
+	return changes, nil
+}
+
+func decodeStateChange(msg redis.XMessage) sinks.StateChange {
+	change := sinks.StateChange{
+		ID:      msg.ID,
+		SinkID:  read(msg.Values, "sink_id", ""),
+		OwnerID: read(msg.Values, "owner", ""),
+		State:   sinks.NewStateFromString(read(msg.Values, "state", "")),
+		Message: read(msg.Values, "msg", ""),
+	}
+	if ts, err := strconv.ParseInt(read(msg.Values, "timestamp", ""), 10, 64); err == nil {
+		change.Timestamp = time.Unix(ts, 0)
+	}
+	return change
+}
@@ -12,11 +12,18 @@ const (
 	SinkCreate   = SinkPrefix + "create"
 	SinkDelete   = SinkPrefix + "remove"
 	SinkUpdate   = SinkPrefix + "update"
+	SinkSnapshot = SinkPrefix + "snapshot"
 	StreamSinks  = "orb.sinks"
 	GroupMaestro = "orb.maestro"
 	Exists       = "BUSYGROUP Consumer Group name already exists"
 )
 
+const (
+	// StreamSinkStates carries the sink state changes, apart from orb.sinks as its consumers act on the sink configs
+	StreamSinkStates = "orb.sinks.state"
+	SinkState        = SinkPrefix + "state"
+)
+
 type StateUpdateEvent struct {
 	OwnerID   string
 	SinkID    string
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks"
+	redis2 "github.com/orb-community/orb/sinks/redis"
 )
 
 const (
@@ -20,6 +22,13 @@ const (
 	SinkCreate = SinkPrefix + "create"
 	SinkDelete = SinkPrefix + "remove"
 	SinkUpdate = SinkPrefix + "update"
+	// SinkSnapshot carries the current state of a sink when the inventory is resynced
+	SinkSnapshot = SinkPrefix + "snapshot"
+
+	// CredentialRotationReason marks an update event that only replaced the sink credentials
+	CredentialRotationReason = "credential_rotation"
+	// MetadataUpdateReason marks an update event that only changed the name, description or tags of the sink
+	MetadataUpdateReason = "metadata_update"
 )
 
 type event interface {
@@ -28,8 +37,29 @@ type event interface {
 
 var (
 	_ event = (*createSinkEvent)(nil)
+	_ event = (*snapshotSinkEvent)(nil)
+	_ event = (*stateChangeEvent)(nil)
 )
 
+type stateChangeEvent struct {
+	sinkID    string
+	owner     string
+	state     sinks.State
+	msg       string
+	timestamp time.Time
+}
+
+func (sce stateChangeEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"sink_id":   sce.sinkID,
+		"owner":     sce.owner,
+		"state":     sce.state.String(),
+		"msg":       sce.msg,
+		"timestamp": sce.timestamp.Unix(),
+		"operation": redis2.SinkState,
+	}, nil
+}
+
 type createSinkEvent struct {
 	sinkID    string
 	owner     string
@@ -71,6 +101,7 @@ type updateSinkEvent struct {
 	owner     string
 	config    types.Metadata
 	backend   string
+	reason    string
 	timestamp time.Time
 }
 
@@ -79,13 +110,42 @@ func (cce updateSinkEvent) Encode() (map[string]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"sink_id":   cce.sinkID,
 		"owner":     cce.owner,
 		"config":    config,
 		"backend":   cce.backend,
 		"timestamp": cce.timestamp.Unix(),
 		"operation": SinkUpdate,
-	}, nil
+	}
+	if cce.reason != "" {
+		val["reason"] = cce.reason
+	}
+	return val, nil
 
 }
+
+type snapshotSinkEvent struct {
+	sinkID    string
+	owner     string
+	backend   string
+	config    types.Metadata
+	state     string
+	timestamp time.Time
+}
+
+func (sse snapshotSinkEvent) Encode() (map[string]interface{}, error) {
+	config, err := json.Marshal(sse.config)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"sink_id":   sse.sinkID,
+		"owner":     sse.owner,
+		"backend":   sse.backend,
+		"config":    config,
+		"state":     sse.state,
+		"timestamp": sse.timestamp.Unix(),
+		"operation": SinkSnapshot,
+	}, nil
+}
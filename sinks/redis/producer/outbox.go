@@ -0,0 +1,152 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
+	"github.com/orb-community/orb/sinks"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// publishAttempts bounds the attempts of an event publish, the backoff doubles after each failed one
+	publishAttempts = 3
+	publishBackoff  = 100 * time.Millisecond
+	// outboxReplayBatch is the number of saved events replayed per outbox replay
+	outboxReplayBatch = 100
+
+	// outcomes of the events which could not be published
+	outcomeOutbox  = "outbox"
+	outcomeDropped = "dropped"
+)
+
+var publishFailures metrics.Counter = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	Namespace: "sink",
+	Subsystem: "event_store",
+	Name:      "publish_failure_count",
+	Help:      "Number of events which could not be published to the sinks event stream after the retries.",
+}, []string{"operation", "outcome"})
+
+// errOutboxPending is returned by a replay which could not publish all the saved events
+var errOutboxPending = errors.New("events of the outbox are still pending")
+
+// outboxMu serializes the outbox replays and the publishes following them, so an event is not replayed twice
+// and the saved events reach the stream before the new ones
+var outboxMu sync.Mutex
+
+// publish adds the event to the stream, retrying with a bounded backoff. The events saved to the outbox are
+// published first and, when some are still pending, the event is saved after them so the consumers get the
+// events in order. When the retries are exhausted the event is saved to the outbox for a later replay, the
+// error is only returned when the event is lost
+func (es sinksStreamProducer) publish(ctx context.Context, record *redis.XAddArgs) error {
+	var err error
+	if es.outbox != nil {
+		outboxMu.Lock()
+		defer outboxMu.Unlock()
+		if _, err = replayOutbox(ctx, es.client, es.stream, es.outbox); err != nil && !errors.Is(err, errOutboxPending) {
+			// the outbox can not be read, the event is published as it could not be saved either
+			es.logger.Warn("failed to replay events from the outbox", zap.Error(err))
+			err = nil
+		}
+	}
+	if err == nil {
+		if err = xAddWithRetry(ctx, es.client, record); err == nil {
+			return nil
+		}
+	}
+
+	values, _ := record.Values.(map[string]interface{})
+	operation, _ := values["operation"].(string)
+	if es.outbox != nil {
+		// the event is saved even when the publish failed because the request context is done
+		saveErr := es.outbox.SaveEvent(context.Background(), record.Stream, values)
+		if saveErr == nil {
+			publishFailures.With("operation", operation, "outcome", outcomeOutbox).Add(1)
+			es.logger.Warn("error sending event to sinks event store, saved to the outbox",
+				zap.String("operation", operation), zap.Error(err))
+			return nil
+		}
+		es.logger.Error("error saving event to the outbox", zap.String("operation", operation), zap.Error(saveErr))
+	}
+	publishFailures.With("operation", operation, "outcome", outcomeDropped).Add(1)
+	es.logger.Error("error sending event to sinks event store, event dropped", zap.String("operation", operation), zap.Error(err))
+	return err
+}
+
+func xAddWithRetry(ctx context.Context, client *redis.Client, record *redis.XAddArgs) (err error) {
+	backoff := publishBackoff
+	for attempt := 1; ; attempt++ {
+		if err = client.XAdd(ctx, record).Err(); err == nil || attempt == publishAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// ReplayOutbox publishes the events saved to the outbox on every interval, oldest first, until the context is done.
+// A replay stops at the first event still failing to be published, so the events keep their order
+func ReplayOutbox(ctx context.Context, client *redis.Client, stream StreamConfig, outbox sinks.EventOutbox, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			outboxMu.Lock()
+			replayed, err := replayOutbox(ctx, client, stream, outbox)
+			outboxMu.Unlock()
+			if replayed > 0 {
+				logger.Info("replayed events from the outbox", zap.Int("events", replayed))
+			}
+			if err != nil {
+				logger.Warn("failed to replay events from the outbox", zap.Error(err))
+			}
+		}
+	}
+}
+
+// replayOutbox publishes the saved events in batches until the outbox is empty, it must be called with outboxMu held
+func replayOutbox(ctx context.Context, client *redis.Client, stream StreamConfig, outbox sinks.EventOutbox) (int, error) {
+	replayed := 0
+	for {
+		events, err := outbox.RetrievePendingEvents(ctx, outboxReplayBatch)
+		if err != nil {
+			return replayed, err
+		}
+		for _, event := range events {
+			record := &redis.XAddArgs{
+				Stream: event.Stream,
+				MaxLen: stream.MaxLen,
+				Approx: stream.Approx,
+				Values: event.Values,
+			}
+			if err := client.XAdd(ctx, record).Err(); err != nil {
+				return replayed, fmt.Errorf("%w: %v", errOutboxPending, err)
+			}
+			replayed++
+			if err := outbox.RemoveEvent(ctx, event.ID); err != nil {
+				return replayed, err
+			}
+		}
+		if len(events) < outboxReplayBatch {
+			return replayed, nil
+		}
+	}
+}
@@ -10,8 +10,11 @@ package producer
 
 import (
 	"context"
+	"time"
 
+	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks/authentication_type"
+	redis2 "github.com/orb-community/orb/sinks/redis"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/orb-community/orb/sinks"
@@ -19,10 +22,14 @@ import (
 	"go.uber.org/zap"
 )
 
-const (
-	streamID  = "orb.sinks"
-	streamLen = 1000
-)
+const streamID = "orb.sinks"
+
+// StreamConfig holds the trimming of the sinks event stream, a zero MaxLen keeps the stream untrimmed. Approximate
+// trimming lets redis keep a few more events than MaxLen, which is cheaper than the exact trimming
+type StreamConfig struct {
+	MaxLen int64
+	Approx bool
+}
 
 var _ sinks.SinkService = (*sinksStreamProducer)(nil)
 
@@ -30,6 +37,9 @@ type sinksStreamProducer struct {
 	svc    sinks.SinkService
 	client *redis.Client
 	logger *zap.Logger
+	stream StreamConfig
+	// outbox keeps the events which could not be published, nil when they are dropped
+	outbox sinks.EventOutbox
 }
 
 // ListSinksInternal will only call following service
@@ -37,8 +47,59 @@ func (es sinksStreamProducer) ListSinksInternal(ctx context.Context, filter sink
 	return es.svc.ListSinksInternal(ctx, filter)
 }
 
+// ChangeSinkStateInternal publishes the new state to the sink state stream watched by the clients
 func (es sinksStreamProducer) ChangeSinkStateInternal(ctx context.Context, sinkID string, msg string, ownerID string, state sinks.State) error {
-	return es.svc.ChangeSinkStateInternal(ctx, sinkID, msg, ownerID, state)
+	if err := es.svc.ChangeSinkStateInternal(ctx, sinkID, msg, ownerID, state); err != nil {
+		return err
+	}
+	event := stateChangeEvent{
+		sinkID:    sinkID,
+		owner:     ownerID,
+		state:     state,
+		msg:       msg,
+		timestamp: time.Now(),
+	}
+	encode, _ := event.Encode()
+	record := &redis.XAddArgs{
+		Stream: redis2.StreamSinkStates,
+		MaxLen: es.stream.MaxLen,
+		Approx: es.stream.Approx,
+		Values: encode,
+	}
+	es.publish(ctx, record)
+	return nil
+}
+
+func (es sinksStreamProducer) WatchSinkStates(ctx context.Context, token string, lastEventID string) (<-chan sinks.StateChange, error) {
+	return es.svc.WatchSinkStates(ctx, token, lastEventID)
+}
+
+func (es sinksStreamProducer) CountSinks(ctx context.Context, token string, tags types.Tags) (sinks.Counts, error) {
+	return es.svc.CountSinks(ctx, token, tags)
+}
+
+func (es sinksStreamProducer) CheckReadiness(ctx context.Context) map[string]string {
+	return es.svc.CheckReadiness(ctx)
+}
+
+func (es sinksStreamProducer) ListSinkStateEvents(ctx context.Context, token string, sinkID string) ([]sinks.StateEvent, error) {
+	return es.svc.ListSinkStateEvents(ctx, token, sinkID)
+}
+
+func (es sinksStreamProducer) DiffSinkRevisions(ctx context.Context, token string, sinkID string, from uint64, to uint64) (sinks.ConfigDiff, error) {
+	return es.svc.DiffSinkRevisions(ctx, token, sinkID, from, to)
+}
+
+func (es sinksStreamProducer) SetOwnerDefaultTags(ctx context.Context, token string, ownerID string, tags types.Tags) (types.Tags, error) {
+	return es.svc.SetOwnerDefaultTags(ctx, token, ownerID, tags)
+}
+
+func (es sinksStreamProducer) RevalidateBackendSinks(ctx context.Context, token string, backendName string) (sinks.RevalidationReport, error) {
+	return es.svc.RevalidateBackendSinks(ctx, token, backendName)
+}
+
+func (es sinksStreamProducer) ViewOwnerDefaultTags(ctx context.Context, token string, ownerID string) (types.Tags, error) {
+	return es.svc.ViewOwnerDefaultTags(ctx, token, ownerID)
 }
 
 func (es sinksStreamProducer) ViewSinkInternal(ctx context.Context, ownerID string, key string) (sinks.Sink, error) {
@@ -47,33 +108,111 @@ func (es sinksStreamProducer) ViewSinkInternal(ctx context.Context, ownerID stri
 
 func (es sinksStreamProducer) CreateSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
 	defer func() {
-		event := createSinkEvent{
-			sinkID:  sink.ID,
-			owner:   sink.MFOwnerID,
-			config:  sink.Config,
-			backend: sink.Backend,
+		es.publishCreateSink(ctx, sink)
+	}()
+
+	return es.svc.CreateSink(ctx, token, s)
+}
+
+func (es sinksStreamProducer) CreateSinkOnBehalfOf(ctx context.Context, token string, ownerID string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func() {
+		es.publishCreateSink(ctx, sink)
+	}()
+
+	return es.svc.CreateSinkOnBehalfOf(ctx, token, ownerID, s)
+}
+
+func (es sinksStreamProducer) CloneSink(ctx context.Context, token string, sinkID string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func() {
+		if err != nil {
+			return
+		}
+		es.publishCreateSink(ctx, sink)
+	}()
+
+	return es.svc.CloneSink(ctx, token, sinkID, s)
+}
+
+func (es sinksStreamProducer) CreateSinkFromTemplate(ctx context.Context, token string, name string, overrides sinks.Sink) (sink sinks.Sink, err error) {
+	defer func() {
+		if err != nil {
+			return
+		}
+		es.publishCreateSink(ctx, sink)
+	}()
+
+	return es.svc.CreateSinkFromTemplate(ctx, token, name, overrides)
+}
+
+func (es sinksStreamProducer) SaveSinkTemplate(ctx context.Context, token string, template sinks.SinkTemplate) (sinks.SinkTemplate, error) {
+	return es.svc.SaveSinkTemplate(ctx, token, template)
+}
+
+func (es sinksStreamProducer) ListSinkTemplates(ctx context.Context, token string) ([]sinks.SinkTemplate, error) {
+	return es.svc.ListSinkTemplates(ctx, token)
+}
+
+func (es sinksStreamProducer) RemoveSinkTemplate(ctx context.Context, token string, name string) error {
+	return es.svc.RemoveSinkTemplate(ctx, token, name)
+}
+
+func (es sinksStreamProducer) publishCreateSink(ctx context.Context, sink sinks.Sink) {
+	event := createSinkEvent{
+		sinkID:  sink.ID,
+		owner:   sink.MFOwnerID,
+		config:  sink.Config,
+		backend: sink.Backend,
+	}
+
+	encode, err := event.Encode()
+	if err != nil {
+		es.logger.Error("error encoding object", zap.Error(err))
+	}
+
+	record := &redis.XAddArgs{
+		Stream: streamID,
+		MaxLen: es.stream.MaxLen,
+		Approx: es.stream.Approx,
+		Values: encode,
+	}
+
+	es.publish(ctx, record)
+}
+
+// ResyncSinks publishes a snapshot event per sink, letting the stream consumers rebuild their state
+func (es sinksStreamProducer) ResyncSinks(ctx context.Context, token string) ([]sinks.Sink, error) {
+	sks, err := es.svc.ResyncSinks(ctx, token)
+	if err != nil {
+		return sks, err
+	}
+	for _, sink := range sks {
+		event := snapshotSinkEvent{
+			sinkID:    sink.ID,
+			owner:     sink.MFOwnerID,
+			backend:   sink.Backend,
+			config:    sink.Config,
+			state:     sink.State.String(),
+			timestamp: time.Now(),
 		}
 
 		encode, err := event.Encode()
 		if err != nil {
 			es.logger.Error("error encoding object", zap.Error(err))
+			continue
 		}
 
 		record := &redis.XAddArgs{
 			Stream: streamID,
-			MaxLen: streamLen,
-			Approx: true,
+			MaxLen: es.stream.MaxLen,
+			Approx: es.stream.Approx,
 			Values: encode,
 		}
 
-		err = es.client.XAdd(ctx, record).Err()
-		if err != nil {
-			es.logger.Error("error sending event to sinks event store", zap.Error(err))
+		if err := es.publish(ctx, record); err != nil {
+			return sks, err
 		}
-
-	}()
-
-	return es.svc.CreateSink(ctx, token, s)
+	}
+	return sks, nil
 }
 
 func (es sinksStreamProducer) UpdateSinkInternal(ctx context.Context, s sinks.Sink) (sink sinks.Sink, err error) {
@@ -92,15 +231,12 @@ func (es sinksStreamProducer) UpdateSinkInternal(ctx context.Context, s sinks.Si
 
 		record := &redis.XAddArgs{
 			Stream: streamID,
-			MaxLen: streamLen,
-			Approx: true,
+			MaxLen: es.stream.MaxLen,
+			Approx: es.stream.Approx,
 			Values: encode,
 		}
 
-		err = es.client.XAdd(ctx, record).Err()
-		if err != nil {
-			es.logger.Error("error sending event to sinks event store", zap.Error(err))
-		}
+		es.publish(ctx, record)
 	}()
 	return es.svc.UpdateSinkInternal(ctx, s)
 }
@@ -120,17 +256,74 @@ func (es sinksStreamProducer) UpdateSink(ctx context.Context, token string, s si
 
 		record := &redis.XAddArgs{
 			Stream: streamID,
-			MaxLen: streamLen,
-			Approx: true,
+			MaxLen: es.stream.MaxLen,
+			Approx: es.stream.Approx,
+			Values: encode,
+		}
+
+		es.publish(ctx, record)
+	}()
+	return es.svc.UpdateSink(ctx, token, s)
+}
+
+func (es sinksStreamProducer) RotateSinkCredentials(ctx context.Context, token string, sinkID string, credentials types.Metadata) (sink sinks.Sink, err error) {
+	defer func() {
+		if err != nil {
+			return
+		}
+		event := updateSinkEvent{
+			sinkID:  sink.ID,
+			owner:   sink.MFOwnerID,
+			config:  sink.Config,
+			backend: sink.Backend,
+			reason:  CredentialRotationReason,
+		}
+
+		encode, err := event.Encode()
+		if err != nil {
+			es.logger.Error("error encoding object", zap.Error(err))
+		}
+
+		record := &redis.XAddArgs{
+			Stream: streamID,
+			MaxLen: es.stream.MaxLen,
+			Approx: es.stream.Approx,
 			Values: encode,
 		}
 
-		err = es.client.XAdd(ctx, record).Err()
+		es.publish(ctx, record)
+	}()
+	return es.svc.RotateSinkCredentials(ctx, token, sinkID, credentials)
+}
+
+func (es sinksStreamProducer) PatchSink(ctx context.Context, token string, s sinks.Sink) (sink sinks.Sink, err error) {
+	defer func() {
+		if err != nil {
+			return
+		}
+		event := updateSinkEvent{
+			sinkID:  sink.ID,
+			owner:   sink.MFOwnerID,
+			config:  sink.Config,
+			backend: sink.Backend,
+			reason:  MetadataUpdateReason,
+		}
+
+		encode, err := event.Encode()
 		if err != nil {
-			es.logger.Error("error sending event to sinks event store", zap.Error(err))
+			es.logger.Error("error encoding object", zap.Error(err))
+		}
+
+		record := &redis.XAddArgs{
+			Stream: streamID,
+			MaxLen: es.stream.MaxLen,
+			Approx: es.stream.Approx,
+			Values: encode,
 		}
+
+		es.publish(ctx, record)
 	}()
-	return es.svc.UpdateSink(ctx, token, s)
+	return es.svc.PatchSink(ctx, token, s)
 }
 
 func (es sinksStreamProducer) ListSinks(ctx context.Context, token string, pm sinks.PageMetadata) (sinks.Page, error) {
@@ -161,13 +354,13 @@ func (es sinksStreamProducer) GetLogger() *zap.Logger {
 	return es.logger
 }
 
-func (es sinksStreamProducer) DeleteSink(ctx context.Context, token, id string) (err error) {
+func (es sinksStreamProducer) DeleteSink(ctx context.Context, token, id string, force bool) (err error) {
 	sink, err := es.svc.ViewSink(ctx, token, id)
 	if err != nil {
 		return err
 	}
 
-	if err := es.svc.DeleteSink(ctx, token, id); err != nil {
+	if err := es.svc.DeleteSink(ctx, token, id, force); err != nil {
 		return err
 	}
 
@@ -183,28 +376,31 @@ func (es sinksStreamProducer) DeleteSink(ctx context.Context, token, id string)
 
 	record := &redis.XAddArgs{
 		Stream: streamID,
-		MaxLen: streamLen,
-		Approx: true,
+		MaxLen: es.stream.MaxLen,
+		Approx: es.stream.Approx,
 		Values: encode,
 	}
 
-	err = es.client.XAdd(ctx, record).Err()
-	if err != nil {
-		es.logger.Error("error sending event to sinks event store", zap.Error(err))
-		return err
-	}
-	return nil
+	return es.publish(ctx, record)
 }
 
 func (es sinksStreamProducer) ValidateSink(ctx context.Context, token string, sink sinks.Sink) (sinks.Sink, error) {
 	return es.svc.ValidateSink(ctx, token, sink)
 }
 
+func (es sinksStreamProducer) ProbeSink(ctx context.Context, token string, sink sinks.Sink) error {
+	return es.svc.ProbeSink(ctx, token, sink)
+}
+
 // NewSinkStreamProducerMiddleware returns wrapper around sinks service that sends
-// events to event store.
-func NewSinkStreamProducerMiddleware(svc sinks.SinkService, client *redis.Client) sinks.SinkService {
+// events to event store. The events which still fail to be published after the retries are saved to the
+// outbox when one is given, and dropped otherwise.
+func NewSinkStreamProducerMiddleware(svc sinks.SinkService, client *redis.Client, stream StreamConfig, outbox sinks.EventOutbox, logger *zap.Logger) sinks.SinkService {
 	return sinksStreamProducer{
 		svc:    svc,
 		client: client,
+		logger: logger,
+		stream: stream,
+		outbox: outbox,
 	}
 }
@@ -0,0 +1,173 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+	"go.uber.org/zap"
+)
+
+// MaxConfigRevisions is the number of config revisions kept in the history of each sink
+const MaxConfigRevisions = 10
+
+const (
+	// ConfigFieldAdded a field only set on the newer revision
+	ConfigFieldAdded = "added"
+	// ConfigFieldRemoved a field only set on the older revision
+	ConfigFieldRemoved = "removed"
+	// ConfigFieldChanged a field set on both revisions with different values
+	ConfigFieldChanged = "changed"
+)
+
+// ConfigRevision is a snapshot of the config of a sink, taken on creation and on each update with the secrets
+// redacted. The revisions of a sink are numbered from 1
+type ConfigRevision struct {
+	SinkID    string
+	Revision  uint64
+	Timestamp time.Time
+	Config    types.Metadata
+}
+
+// ConfigChange is a field of the sink config which differs between two revisions, the nested fields are
+// dot separated
+type ConfigChange struct {
+	Field string
+	Op    string
+	From  interface{}
+	To    interface{}
+}
+
+// ConfigDiff lists the changes of the sink config between two revisions, ordered by field
+type ConfigDiff struct {
+	SinkID  string
+	From    ConfigRevision
+	To      ConfigRevision
+	Changes []ConfigChange
+}
+
+// redactedConfig returns a copy of the sink config without its credentials and secret headers
+func redactedConfig(sink Sink) (types.Metadata, error) {
+	config := types.FromMap(sink.Config)
+	config.RemoveKeys([]string{"opentelemetry"})
+	if _, ok := config[authentication_type.AuthenticationKey]; ok {
+		config[authentication_type.AuthenticationKey] = copyAuthentication(config[authentication_type.AuthenticationKey])
+		authType, ok := authentication_type.GetAuthType(sink.GetAuthenticationTypeName())
+		if !ok {
+			return nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication type"))
+		}
+		omitted, err := authType.OmitInformation("object", config)
+		if err != nil {
+			return nil, err
+		}
+		config = omitted.(types.Metadata)
+	}
+	if be := backend.GetBackend(sink.Backend); be != nil {
+		return ApplySecretHeaders(be, config, func(string) (string, error) {
+			return "", nil
+		})
+	}
+	return config, nil
+}
+
+// recordConfigRevision adds the redacted config of the sink to its history, a failure does not fail the change
+func (svc sinkService) recordConfigRevision(ctx context.Context, sink Sink) {
+	config, err := redactedConfig(sink)
+	if err == nil {
+		err = svc.sinkRepo.AddConfigRevision(ctx, ConfigRevision{SinkID: sink.ID, Timestamp: time.Now(), Config: config})
+	}
+	if err != nil {
+		svc.logger.Warn("failed to record sink config revision", zap.String("sink_id", sink.ID), zap.Error(err))
+	}
+}
+
+func (svc sinkService) DiffSinkRevisions(ctx context.Context, token string, sinkID string, from uint64, to uint64) (ConfigDiff, error) {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	if _, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, ownerID, sinkID); err != nil {
+		return ConfigDiff{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+	revisions, err := svc.sinkRepo.RetrieveConfigRevisions(ctx, sinkID)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	if len(revisions) == 0 {
+		return ConfigDiff{}, errors.Wrap(errors.ErrNotFound, errors.New("sink has no config revisions"))
+	}
+	// the latest revision is compared with the previous one by default
+	if to == 0 {
+		to = revisions[0].Revision
+	}
+	if from == 0 && to > 1 {
+		from = to - 1
+	}
+	diff := ConfigDiff{SinkID: sinkID}
+	var foundFrom, foundTo bool
+	for _, revision := range revisions {
+		if revision.Revision == from {
+			diff.From, foundFrom = revision, true
+		}
+		if revision.Revision == to {
+			diff.To, foundTo = revision, true
+		}
+	}
+	if !foundFrom || !foundTo {
+		return ConfigDiff{}, errors.Wrap(errors.ErrNotFound, fmt.Errorf("revisions %d to %d are not in the history of the sink", from, to))
+	}
+	diff.Changes = diffConfig("", diff.From.Config, diff.To.Config)
+	return diff, nil
+}
+
+// diffConfig compares two configs field by field, descending into the nested objects. Any other value,
+// lists included, is compared as a whole
+func diffConfig(prefix string, from map[string]interface{}, to map[string]interface{}) []ConfigChange {
+	changes := make([]ConfigChange, 0)
+	for key, fromValue := range from {
+		field := prefix + key
+		toValue, ok := to[key]
+		if !ok {
+			changes = append(changes, ConfigChange{Field: field, Op: ConfigFieldRemoved, From: fromValue})
+			continue
+		}
+		fromObject, fromIsObject := configObject(fromValue)
+		toObject, toIsObject := configObject(toValue)
+		if fromIsObject && toIsObject {
+			changes = append(changes, diffConfig(field+".", fromObject, toObject)...)
+			continue
+		}
+		if !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, ConfigChange{Field: field, Op: ConfigFieldChanged, From: fromValue, To: toValue})
+		}
+	}
+	for key, toValue := range to {
+		if _, ok := from[key]; !ok {
+			changes = append(changes, ConfigChange{Field: prefix + key, Op: ConfigFieldAdded, To: toValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func configObject(value interface{}) (map[string]interface{}, bool) {
+	switch object := value.(type) {
+	case types.Metadata:
+		return object, true
+	case map[string]interface{}:
+		return object, true
+	}
+	return nil, false
+}
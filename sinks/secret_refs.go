@@ -0,0 +1,60 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"strings"
+
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// secretRefs collects the references to the secret store saved on the config of the sink, it has to be called on
+// the config as stored, before it is decrypted
+func secretRefs(sink Sink) map[string]bool {
+	refs := make(map[string]bool)
+	collectSecretRefs(map[string]interface{}(sink.Config), refs)
+	if sink.ConfigData != "" {
+		var config types.Metadata
+		if err := yaml.Unmarshal([]byte(sink.ConfigData), &config); err == nil {
+			collectSecretRefs(map[string]interface{}(config), refs)
+		}
+	}
+	return refs
+}
+
+func collectSecretRefs(value interface{}, refs map[string]bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, authentication_type.SecretRefPrefix) {
+			refs[v] = true
+		}
+	case types.Metadata:
+		collectSecretRefs(map[string]interface{}(v), refs)
+	case map[string]interface{}:
+		for _, item := range v {
+			collectSecretRefs(item, refs)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectSecretRefs(item, refs)
+		}
+	}
+}
+
+// deleteSecrets removes the secrets of the references which are not kept, so the secrets replaced or left by a
+// failed change do not pile up on the store. A failure only leaves an unused secret behind, so it is logged
+func (svc sinkService) deleteSecrets(refs map[string]bool, kept map[string]bool) {
+	for ref := range refs {
+		if kept[ref] {
+			continue
+		}
+		if err := svc.passwordService.DeleteSecret(ref); err != nil {
+			svc.logger.Warn("failed to delete unused sink secret", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package secretstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/orb-community/orb/sinks/authentication_type"
+)
+
+var _ authentication_type.SecretStore = (*awsStore)(nil)
+
+// secretsManagerAPI is the part of the AWS Secrets Manager client used by the store
+type secretsManagerAPI interface {
+	CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error)
+	GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error)
+	DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error)
+}
+
+// awsStore keeps the secrets on AWS Secrets Manager, named after the prefix and their owner
+type awsStore struct {
+	client secretsManagerAPI
+	prefix string
+}
+
+// NewAWSStore returns the store of the secrets on AWS Secrets Manager in the region, the credentials are taken
+// from the default AWS chain
+func NewAWSStore(region string, prefix string) (authentication_type.SecretStore, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	return &awsStore{client: secretsmanager.New(sess), prefix: prefix}, nil
+}
+
+func (s *awsStore) Put(ownerID string, secret string) (string, error) {
+	name := newRef(s.prefix, ownerID)
+	out, err := s.client.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(secret),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.ARN), nil
+}
+
+func (s *awsStore) Get(ref string) (string, error) {
+	out, err := s.client.GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(ref)})
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", ref)
+	}
+	return *out.SecretString, nil
+}
+
+// Delete schedules the removal of the secret after the default recovery window of AWS Secrets Manager
+func (s *awsStore) Delete(ref string) error {
+	_, err := s.client.DeleteSecret(&secretsmanager.DeleteSecretInput{SecretId: aws.String(ref)})
+	var notFound *secretsmanager.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
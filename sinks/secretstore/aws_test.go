@@ -0,0 +1,56 @@
+package secretstore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecretsManager struct {
+	secrets map[string]string
+}
+
+func (f *fakeSecretsManager) CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+	arn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:" + aws.StringValue(input.Name)
+	f.secrets[arn] = aws.StringValue(input.SecretString)
+	return &secretsmanager.CreateSecretOutput{ARN: aws.String(arn), Name: input.Name}, nil
+}
+
+func (f *fakeSecretsManager) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	secret, ok := f.secrets[aws.StringValue(input.SecretId)]
+	if !ok {
+		return nil, &secretsmanager.ResourceNotFoundException{}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(secret)}, nil
+}
+
+func (f *fakeSecretsManager) DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	if _, ok := f.secrets[aws.StringValue(input.SecretId)]; !ok {
+		return nil, &secretsmanager.ResourceNotFoundException{}
+	}
+	delete(f.secrets, aws.StringValue(input.SecretId))
+	return &secretsmanager.DeleteSecretOutput{ARN: input.SecretId}, nil
+}
+
+func TestAWSStore(t *testing.T) {
+	store := &awsStore{client: &fakeSecretsManager{secrets: make(map[string]string)}, prefix: "orb/sinks"}
+	ref, err := store.Put("", "token")
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(ref, ":secret:orb/sinks/shared/"), ref)
+
+	secret, err := store.Get(ref)
+	require.NoError(t, err)
+	assert.Equal(t, "token", secret)
+
+	_, err = store.Get("unknown")
+	assert.Error(t, err)
+
+	require.NoError(t, store.Delete(ref))
+	_, err = store.Get(ref)
+	assert.Error(t, err, "a deleted secret should not be found")
+	assert.NoError(t, store.Delete(ref), "deleting a missing secret should not fail")
+}
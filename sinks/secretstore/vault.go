@@ -0,0 +1,127 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/orb-community/orb/sinks/authentication_type"
+)
+
+var _ authentication_type.SecretStore = (*vaultStore)(nil)
+
+const vaultTimeout = 10 * time.Second
+
+// vaultStore keeps the secrets on a Vault KV version 2 secrets engine, under the path of their owner
+type vaultStore struct {
+	address string
+	token   string
+	mount   string
+	path    string
+	client  *http.Client
+}
+
+// NewVaultStore returns the store of the secrets on the KV v2 engine mounted at mount on the Vault server,
+// the secrets are written under path
+func NewVaultStore(address, token, mount, path string) authentication_type.SecretStore {
+	return &vaultStore{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		path:    strings.Trim(path, "/"),
+		client:  &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+type vaultSecret struct {
+	Data map[string]string `json:"data"`
+}
+
+func (s *vaultStore) Put(ownerID string, secret string) (string, error) {
+	ref := newRef(s.path, ownerID)
+	body, err := json.Marshal(vaultSecret{Data: map[string]string{"value": secret}})
+	if err != nil {
+		return "", err
+	}
+	res, err := s.do(http.MethodPost, ref, body)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("vault returned %s writing the secret", res.Status)
+	}
+	return ref, nil
+}
+
+func (s *vaultStore) Get(ref string) (string, error) {
+	res, err := s.do(http.MethodGet, ref, nil)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %s reading the secret", res.Status)
+	}
+	var secret struct {
+		Data vaultSecret `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", err
+	}
+	value, ok := secret.Data.Data["value"]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no value", ref)
+	}
+	return value, nil
+}
+
+// Delete removes the metadata of the secret, which removes all its versions
+func (s *vaultStore) Delete(ref string) error {
+	res, err := s.request(http.MethodDelete, "metadata", ref, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("vault returned %s deleting the secret", res.Status)
+	}
+	return nil
+}
+
+func (s *vaultStore) do(method string, ref string, body []byte) (*http.Response, error) {
+	return s.request(method, "data", ref, body)
+}
+
+func (s *vaultStore) request(method string, kind string, ref string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s/%s/%s", s.address, s.mount, kind, ref), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return s.client.Do(req)
+}
+
+// newRef returns a new secret name under the prefix and the owner, the deployment wide secrets are
+// kept under "shared"
+func newRef(prefix string, ownerID string) string {
+	if ownerID == "" {
+		ownerID = "shared"
+	}
+	ref := ownerID + "/" + uuid.Must(uuid.NewV4()).String()
+	if prefix == "" {
+		return ref
+	}
+	return prefix + "/" + ref
+}
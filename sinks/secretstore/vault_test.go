@@ -0,0 +1,68 @@
+package secretstore
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultStore(t *testing.T) {
+	secrets := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			path := strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/")
+			if _, ok := secrets[path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(secrets, path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+		switch r.Method {
+		case http.MethodPost:
+			var body vaultSecret
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			secrets[path] = body.Data["value"]
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			value, ok := secrets[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": vaultSecret{Data: map[string]string{"value": value}}})
+		}
+	}))
+	defer server.Close()
+
+	store := NewVaultStore(server.URL, "vault-token", "secret", "orb/sinks")
+	ref, err := store.Put("owner-1", "dbpass")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ref, "orb/sinks/owner-1/"), ref)
+
+	secret, err := store.Get(ref)
+	require.NoError(t, err)
+	assert.Equal(t, "dbpass", secret)
+
+	_, err = store.Get("orb/sinks/owner-1/unknown")
+	assert.Error(t, err)
+
+	require.NoError(t, store.Delete(ref))
+	_, err = store.Get(ref)
+	assert.Error(t, err, "a deleted secret should not be found")
+	assert.NoError(t, store.Delete(ref), "deleting a missing secret should not fail")
+
+	_, err = NewVaultStore(server.URL, "wrong-token", "secret", "orb/sinks").Put("owner-1", "dbpass")
+	assert.Error(t, err)
+}
@@ -10,6 +10,8 @@ package sinks
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mainflux/mainflux"
@@ -18,11 +20,17 @@ import (
 
 	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
+	policiespb "github.com/orb-community/orb/policies/pb"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
 	"github.com/orb-community/orb/sinks/authentication_type/bearertokenauth"
+	"github.com/orb-community/orb/sinks/authentication_type/clientcert"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
+	"github.com/orb-community/orb/sinks/authentication_type/serviceaccount"
+	"github.com/orb-community/orb/sinks/backend/gcm"
 	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
 	"github.com/orb-community/orb/sinks/backend/prometheus"
+	"github.com/orb-community/orb/sinks/backend/spool"
 )
 
 // PageMetadata contains page metadata that helps navigation
@@ -35,6 +43,38 @@ type PageMetadata struct {
 	Dir      string         `json:"dir,omitempty"`
 	Metadata types.Metadata `json:"metadata,omitempty"`
 	Tags     types.Tags     `json:"tags,omitempty"`
+	// CredentialsOlderThan filters sinks whose credentials were not set for at least this long
+	CredentialsOlderThan time.Duration `json:"credentials_older_than,omitempty"`
+	// ErrorContains filters sinks in error state whose error message contains it, ignoring the case
+	ErrorContains string `json:"error_contains,omitempty"`
+}
+
+// TagWildcard turns a tag filter value into a prefix selector when set as its last character, e.g. team/*
+const TagWildcard = "*"
+
+// SplitTagSelectors separates exact tag filters from prefix ones, the returned prefixes have the wildcard removed
+func SplitTagSelectors(tags types.Tags) (exact types.Tags, prefix types.Tags, err error) {
+	for key, value := range tags {
+		if strings.Contains(key, TagWildcard) {
+			return nil, nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("wildcard is not allowed on tag key: "+key))
+		}
+		trimmed := strings.TrimSuffix(value, TagWildcard)
+		if strings.Contains(trimmed, TagWildcard) {
+			return nil, nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("wildcard is only allowed at the end of tag value: "+value))
+		}
+		if trimmed == value {
+			if exact == nil {
+				exact = make(types.Tags)
+			}
+			exact[key] = value
+			continue
+		}
+		if prefix == nil {
+			prefix = make(types.Tags)
+		}
+		prefix[key] = trimmed
+	}
+	return exact, prefix, nil
 }
 
 var _ SinkService = (*sinkService)(nil)
@@ -48,6 +88,38 @@ type sinkService struct {
 	sinkRepo SinkRepository
 	// passwordService
 	passwordService authentication_type.PasswordService
+	// enabledBackends restricts the backends exposed and accepted, all backends are enabled when empty
+	enabledBackends map[string]bool
+	// tagLimits bounds the tags of the sinks created or updated
+	tagLimits TagLimits
+	// stateStream is read by the clients watching the sink state changes, nil when it is not available
+	stateStream StateChangeStream
+	// resync limits how often the sink inventory can be re-emitted
+	resync *resyncLimiter
+	// policiesClient looks up the datasets using a sink before deleting it, the check is off when it is nil
+	policiesClient policiespb.PolicyServiceClient
+}
+
+// ResyncInterval is the minimum time between two sink inventory resyncs
+const ResyncInterval = time.Minute
+
+// resyncLimiter allows a single sink inventory resync per interval
+type resyncLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+	now      func() time.Time
+}
+
+func (l *resyncLimiter) allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() && now.Sub(l.last) < l.interval {
+		return false
+	}
+	l.last = now
+	return true
 }
 
 func (svc sinkService) identify(token string) (string, error) {
@@ -62,20 +134,61 @@ func (svc sinkService) identify(token string) (string, error) {
 	return res.GetId(), nil
 }
 
+// authorities and member are the auth service object and relation of the admins
+const (
+	authoritiesObject = "authorities"
+	memberRelation    = "member"
+)
+
+func (svc sinkService) authorizeAdmin(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := svc.auth.Authorize(ctx, &mainflux.AuthorizeReq{Sub: userID, Obj: authoritiesObject, Act: memberRelation})
+	if err != nil {
+		return errors.Wrap(ErrForbidden, err)
+	}
+	if !res.GetAuthorized() {
+		return ErrForbidden
+	}
+	return nil
+}
+
 func (svc sinkService) GetLogger() *zap.Logger {
 	return svc.logger
 }
 
-func NewSinkService(logger *zap.Logger, auth mainflux.AuthServiceClient, sinkRepo SinkRepository, mfsdk mfsdk.SDK, passwordService authentication_type.PasswordService) SinkService {
+func NewSinkService(logger *zap.Logger, auth mainflux.AuthServiceClient, sinkRepo SinkRepository, mfsdk mfsdk.SDK, passwordService authentication_type.PasswordService, enabledBackends []string, tagLimits TagLimits, stateStream StateChangeStream, policiesClient policiespb.PolicyServiceClient) SinkService {
 	otlphttpexporter.Register()
 	prometheus.Register()
+	gcm.Register()
+	spool.Register()
 	basicauth.Register(passwordService)
 	bearertokenauth.Register(passwordService)
+	clientcert.Register(passwordService)
+	serviceaccount.Register(passwordService)
+	multiauth.Register()
+	enabled := make(map[string]bool, len(enabledBackends))
+	for _, name := range enabledBackends {
+		if name = strings.TrimSpace(name); name != "" {
+			enabled[name] = true
+		}
+	}
 	return &sinkService{
 		logger:          logger,
 		auth:            auth,
 		sinkRepo:        sinkRepo,
 		mfsdk:           mfsdk,
 		passwordService: passwordService,
+		enabledBackends: enabled,
+		tagLimits:       tagLimits,
+		stateStream:     stateStream,
+		resync:          &resyncLimiter{interval: ResyncInterval, now: time.Now},
+		policiesClient:  policiesClient,
 	}
 }
+
+// backendEnabled checks the backend against the deployment allow-list
+func (svc sinkService) backendEnabled(name string) bool {
+	return len(svc.enabledBackends) == 0 || svc.enabledBackends[name]
+}
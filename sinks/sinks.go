@@ -13,6 +13,7 @@ import (
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
 	"github.com/orb-community/orb/sinks/backend"
 	"go.uber.org/zap"
 )
@@ -45,6 +46,33 @@ var (
 	ErrRemoveEntity = errors.New("failed to remove entity")
 
 	ErrInvalidBackend = errors.New("No available backends")
+
+	// ErrForbidden indicates the credentials are not allowed to act on behalf of another owner
+	ErrForbidden = errors.New("not allowed to act on behalf of another owner")
+
+	// ErrResyncRateLimited indicates a sink inventory resync was already requested within the resync interval
+	ErrResyncRateLimited = errors.New("sink inventory resync requested too often")
+
+	// ErrInvalidSignalType indicates the sink type is not one of the signals supported by its backend
+	ErrInvalidSignalType = errors.New("sink type not supported by the backend")
+
+	// ErrUnsupportedAuthType indicates the sink authentication type is not one of the types supported by its backend
+	ErrUnsupportedAuthType = errors.New("authentication type not supported by the backend")
+
+	// ErrBackendUnavailable indicates the sink references a backend which is no longer registered
+	ErrBackendUnavailable = errors.New("backend no longer available")
+
+	// ErrTagLimitExceeded indicates the sink tags exceed the configured number of keys or key and value lengths
+	ErrTagLimitExceeded = errors.New("sink tags exceed the allowed limits")
+
+	// ErrTemplateCredentials indicates a sink template configuration holds credentials, which are left to the user
+	ErrTemplateCredentials = errors.New("sink templates can not hold credentials")
+
+	// ErrSinkInUse indicates the sink to delete is still used by datasets
+	ErrSinkInUse = errors.New("sink is used by datasets")
+
+	// ErrDependencyCheck indicates the datasets using the sink to delete could not be retrieved
+	ErrDependencyCheck = errors.New("failed to check the datasets using the sink")
 )
 
 const (
@@ -55,6 +83,7 @@ const (
 	Warning
 	Provisioning
 	ProvisioningError
+	CircuitOpen
 )
 
 type State int
@@ -67,12 +96,30 @@ var stateMap = [...]string{
 	"warning",
 	"provisioning",
 	"provisioning_error",
+	"circuit_open",
 }
 
 const MetadataLabelOtel = "opentelemetry"
 
 type Filter struct {
 	StateFilter string
+	// Backend restricts the search to the sinks of a backend when set
+	Backend string
+}
+
+// ValidationFailure is a stored sink which fails the current validation of its backend
+type ValidationFailure struct {
+	SinkID  string `json:"sink_id"`
+	OwnerID string `json:"owner_id"`
+	Name    string `json:"name"`
+	Error   string `json:"error"`
+}
+
+// RevalidationReport lists the stored sinks of a backend failing its current validation
+type RevalidationReport struct {
+	Backend  string              `json:"backend"`
+	Checked  int                 `json:"checked"`
+	Failures []ValidationFailure `json:"failures"`
 }
 
 var stateRevMap = map[string]State{
@@ -83,6 +130,7 @@ var stateRevMap = map[string]State{
 	"warning":            Warning,
 	"provisioning":       Provisioning,
 	"provisioning_error": ProvisioningError,
+	"circuit_open":       CircuitOpen,
 }
 
 func (s State) String() string {
@@ -132,9 +180,28 @@ type Sink struct {
 	State       State
 	Error       string
 	Created     time.Time
+	// Type is the telemetry signal carried by the sink, one of the SupportedSignals of its backend
+	Type string
+	// CredentialsUpdatedAt is the last time the sink credentials were set, on creation or rotation
+	CredentialsUpdatedAt time.Time
+}
+
+// MaxStateEvents is the number of state changes kept in the history of each sink
+const MaxStateEvents = 50
+
+// StateEvent is a state change of a sink
+type StateEvent struct {
+	SinkID    string
+	Timestamp time.Time
+	OldState  State
+	NewState  State
+	Message   string
 }
 
 func (s *Sink) GetAuthenticationTypeName() string {
+	if multiauth.IsMultiAuth(s.Config) {
+		return multiauth.AuthType
+	}
 	authMeta := s.Config.GetSubMetadata("authentication")
 	// Defaults to basicauth
 	if authMeta == nil {
@@ -143,6 +210,18 @@ func (s *Sink) GetAuthenticationTypeName() string {
 	return authMeta["type"].(string)
 }
 
+// AcceptedSignals returns the signal of the sink, or every signal of its backend when the sink type is not set
+func (s *Sink) AcceptedSignals() []string {
+	if s.Type != "" {
+		return []string{s.Type}
+	}
+	be := backend.GetBackend(s.Backend)
+	if be == nil {
+		return nil
+	}
+	return dataBackend(be, s.Config).SupportedSignals()
+}
+
 // Page contains page related metadata as well as list of sinks that
 // belong to this page
 type Page struct {
@@ -150,16 +229,40 @@ type Page struct {
 	Sinks []Sink
 }
 
+// Counts contains the number of sinks of an owner, grouped by state and by backend
+type Counts struct {
+	Total     uint64
+	ByState   map[string]uint64
+	ByBackend map[string]uint64
+}
+
 // SinkService Sink CRUD interface
 type SinkService interface {
 	// CreateSink creates new data sink
 	CreateSink(ctx context.Context, token string, s Sink) (Sink, error)
+	// CreateSinkOnBehalfOf creates new data sink under the given owner, the token must belong to an admin
+	CreateSinkOnBehalfOf(ctx context.Context, token string, ownerID string, s Sink) (Sink, error)
 	// UpdateSink by id
 	UpdateSink(ctx context.Context, token string, s Sink) (Sink, error)
 	// UpdateSinkInternal by id
 	UpdateSinkInternal(ctx context.Context, s Sink) (Sink, error)
+	// RotateSinkCredentials replaces only the authentication section of an existing sink
+	RotateSinkCredentials(ctx context.Context, token string, sinkID string, credentials types.Metadata) (Sink, error)
+	// PatchSink updates only the name, description and tags set in s, the backend configuration is kept as is
+	PatchSink(ctx context.Context, token string, s Sink) (Sink, error)
+	// CloneSink creates a sink named after s with the configuration and credentials of an existing sink,
+	// the tags of s replace the copied ones when set
+	CloneSink(ctx context.Context, token string, sinkID string, s Sink) (Sink, error)
 	// ListSinks retrieves data about sinks
 	ListSinks(ctx context.Context, token string, pm PageMetadata) (Page, error)
+	// CountSinks retrieves the number of sinks grouped by state and by backend, optionally narrowed by tags
+	CountSinks(ctx context.Context, token string, tags types.Tags) (Counts, error)
+	// CheckReadiness verifies the sink backend registry and the database connectivity, it returns the
+	// failing components along with the reason, an empty result means the service is ready
+	CheckReadiness(ctx context.Context) map[string]string
+	// ResyncSinks retrieves every sink so their current state can be re-emitted to the sinks event stream,
+	// the token must belong to an admin and a single resync is allowed per resync interval
+	ResyncSinks(ctx context.Context, token string) ([]Sink, error)
 	// ListSinksInternal retrieves data from sinks filtered by SinksFilter for Services like Maestro, to build DeploymentEntries
 	ListSinksInternal(ctx context.Context, filter Filter) (Page, error)
 	// ListBackends retrieves a list of available backends
@@ -174,16 +277,76 @@ type SinkService interface {
 	ViewSink(ctx context.Context, token string, key string) (Sink, error)
 	// ViewSinkInternal retrieves a sink by id, via GRPC, sends password
 	ViewSinkInternal(ctx context.Context, ownerID string, key string) (Sink, error)
-	// DeleteSink delete a existing sink by id
-	DeleteSink(ctx context.Context, token string, key string) error
+	// DeleteSink delete a existing sink by id, a sink still used by datasets is only deleted when forced
+	DeleteSink(ctx context.Context, token string, key string, force bool) error
 	// ValidateSink validate a sink configuration without saving
 	ValidateSink(ctx context.Context, token string, sink Sink) (Sink, error)
+	// ProbeSink sends a synthetic metric to the remote end of a not encrypted sink configuration,
+	// checking the backend accepts it without saving the sink
+	ProbeSink(ctx context.Context, token string, sink Sink) error
 	// ChangeSinkStateInternal change the sink internal state from new/idle/active
 	ChangeSinkStateInternal(ctx context.Context, sinkID string, msg string, ownerID string, state State) error
+	// ListSinkStateEvents retrieves the recent state changes of a sink, newest first
+	ListSinkStateEvents(ctx context.Context, token string, sinkID string) ([]StateEvent, error)
+	// DiffSinkRevisions compares the redacted config of a sink between two revisions of its history, a zero to
+	// is the latest revision and a zero from is the revision before to
+	DiffSinkRevisions(ctx context.Context, token string, sinkID string, from uint64, to uint64) (ConfigDiff, error)
+	// WatchSinkStates streams the state changes of the owner sinks until the context is done, resuming after
+	// lastEventID when it is set, otherwise starting with the next change
+	WatchSinkStates(ctx context.Context, token string, lastEventID string) (<-chan StateChange, error)
+	// SetOwnerDefaultTags replaces the tags merged into every sink created under the owner, the token must belong to an admin
+	SetOwnerDefaultTags(ctx context.Context, token string, ownerID string, tags types.Tags) (types.Tags, error)
+	// ViewOwnerDefaultTags retrieves the tags merged into every sink created under the owner, the token must belong to an admin
+	ViewOwnerDefaultTags(ctx context.Context, token string, ownerID string) (types.Tags, error)
+	// RevalidateBackendSinks runs the current validation of the backend over all its stored sinks and reports the
+	// ones failing it, leaving the sinks and their state unchanged. The token must belong to an admin
+	RevalidateBackendSinks(ctx context.Context, token string, backendName string) (RevalidationReport, error)
+	// SaveSinkTemplate creates or replaces a named sink preset, the token must belong to an admin
+	SaveSinkTemplate(ctx context.Context, token string, template SinkTemplate) (SinkTemplate, error)
+	// ListSinkTemplates retrieves the sink presets sinks can be created from, ordered by name
+	ListSinkTemplates(ctx context.Context, token string) ([]SinkTemplate, error)
+	// RemoveSinkTemplate removes a sink preset, the token must belong to an admin. The sinks created from it are kept
+	RemoveSinkTemplate(ctx context.Context, token string, name string) error
+	// CreateSinkFromTemplate creates a sink from the named preset, merging the name, description,
+	// configuration and tags of the overrides over the ones of the preset
+	CreateSinkFromTemplate(ctx context.Context, token string, name string, overrides Sink) (Sink, error)
 	// GetLogger gets service logger to log within gokit's packages
 	GetLogger() *zap.Logger
 }
 
+// OutboxEvent is an event of the sinks event stream which could not be published
+type OutboxEvent struct {
+	ID      int64
+	Stream  string
+	Values  map[string]interface{}
+	Created time.Time
+}
+
+// EventOutbox keeps the events which could not be published to the sinks event stream, so they are replayed
+// instead of being lost
+type EventOutbox interface {
+	// SaveEvent persists the values of an event of the stream
+	SaveEvent(ctx context.Context, stream string, values map[string]interface{}) error
+	// RetrievePendingEvents retrieves up to limit saved events, oldest first
+	RetrievePendingEvents(ctx context.Context, limit uint64) ([]OutboxEvent, error)
+	// RemoveEvent removes a saved event once it was published
+	RemoveEvent(ctx context.Context, id int64) error
+}
+
+type staleReadsKey struct{}
+
+// WithStaleReads marks the repository reads made with the returned context as tolerant to
+// replication lag, so they can be served by a read replica
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+// StaleReadsAllowed reports whether the repository reads made with the context can be served by a read replica
+func StaleReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(staleReadsKey{}).(bool)
+	return allowed
+}
+
 type SinkRepository interface {
 	// Save persists the Sink. Successful operation is indicated by non-nil error response.
 	Save(ctx context.Context, sink Sink) (string, error)
@@ -192,6 +355,10 @@ type SinkRepository interface {
 	Update(ctx context.Context, sink Sink) error
 	// RetrieveAllByOwnerID retrieves Sinks by OwnerID
 	RetrieveAllByOwnerID(ctx context.Context, owner string, pm PageMetadata) (Page, error)
+	// CountByOwnerID counts the Sinks of an OwnerID matching the tags, grouped by state and by backend
+	CountByOwnerID(ctx context.Context, owner string, tags types.Tags) (Counts, error)
+	// Ping checks the database connections are usable
+	Ping(ctx context.Context) error
 	// SearchAllSinks search Sinks for internal usage like services
 	SearchAllSinks(ctx context.Context, filter Filter) ([]Sink, error)
 	// RetrieveById retrieves a Sink by ID
@@ -202,6 +369,26 @@ type SinkRepository interface {
 	Remove(ctx context.Context, owner string, key string) error
 	// UpdateSinkState updates sink state like active, idle, new, unknown
 	UpdateSinkState(ctx context.Context, sinkID string, msg string, ownerID string, state State) error
+	// AddStateEvent records a state change of a sink, keeping only the last MaxStateEvents
+	AddStateEvent(ctx context.Context, event StateEvent) error
+	// RetrieveStateEvents retrieves the recorded state changes of a sink, newest first
+	RetrieveStateEvents(ctx context.Context, sinkID string) ([]StateEvent, error)
+	// AddConfigRevision records the config of a sink as its next revision, keeping only the last MaxConfigRevisions
+	AddConfigRevision(ctx context.Context, revision ConfigRevision) error
+	// RetrieveConfigRevisions retrieves the recorded config revisions of a sink, newest first
+	RetrieveConfigRevisions(ctx context.Context, sinkID string) ([]ConfigRevision, error)
+	// SaveOwnerDefaultTags replaces the default tags of an owner, an empty set removes them
+	SaveOwnerDefaultTags(ctx context.Context, ownerID string, tags types.Tags) error
+	// RetrieveOwnerDefaultTags retrieves the default tags of an owner, empty when none were set
+	RetrieveOwnerDefaultTags(ctx context.Context, ownerID string) (types.Tags, error)
+	// SaveTemplate creates or replaces the sink template of the same name
+	SaveTemplate(ctx context.Context, template SinkTemplate) (SinkTemplate, error)
+	// RetrieveTemplate retrieves a sink template by name
+	RetrieveTemplate(ctx context.Context, name string) (SinkTemplate, error)
+	// RetrieveAllTemplates retrieves the sink templates ordered by name
+	RetrieveAllTemplates(ctx context.Context) ([]SinkTemplate, error)
+	// RemoveTemplate removes a sink template by name
+	RemoveTemplate(ctx context.Context, name string) error
 	// GetVersion for migrate service
 	GetVersion(ctx context.Context) (string, error)
 	// UpsertVersion for migrate service
@@ -2,6 +2,7 @@ package sinks
 
 import (
 	"fmt"
+	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks/authentication_type"
 	"github.com/orb-community/orb/sinks/authentication_type/basicauth"
 	"github.com/orb-community/orb/sinks/backend/otlphttpexporter"
@@ -67,6 +68,43 @@ func Test_sinkService_validateBackend(t *testing.T) {
 			wantBe:  reflect.TypeOf(&otlphttpexporter.OTLPHTTPBackend{}),
 			wantErr: nil,
 		},
+		{
+			name: "prometheus with the remote host under the authentication",
+			fields: fields{
+				svc: sinkService{
+					logger: logger,
+				},
+			},
+			args: args{
+				sink: &Sink{
+					Backend:    "prometheus",
+					Config:     nil,
+					Format:     "yaml",
+					ConfigData: "authentication:\n  type: basicauth\n  password: \"password\"\n  username: \"user\"\n  remote_host: \"https://acme.com/api/prom/push\"\n",
+				},
+			},
+			wantBe:  reflect.TypeOf(nil),
+			wantErr: wantMisplacedHint("remote_host belongs under config.exporter, not config.authentication"),
+		},
+		{
+			name: "otlphttp with the password under the exporter",
+			fields: fields{
+				svc: sinkService{
+					logger: logger,
+				},
+			},
+			args: args{
+				sink: &Sink{
+					Backend: "otlphttp",
+					Config: types.Metadata{
+						"exporter":       types.Metadata{"endpoint": "https://acme.com/otlp", "password": "password"},
+						"authentication": types.Metadata{"type": "basicauth", "username": "user"},
+					},
+				},
+			},
+			wantBe:  reflect.TypeOf(nil),
+			wantErr: wantMisplacedHint("password belongs under config.authentication, not config.exporter"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,3 +116,10 @@ func Test_sinkService_validateBackend(t *testing.T) {
 		})
 	}
 }
+
+func wantMisplacedHint(hint string) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+		got, ok := MisplacedConfigHint(err)
+		return assert.True(t, ok, msgAndArgs...) && assert.Equal(t, hint, got, msgAndArgs...)
+	}
+}
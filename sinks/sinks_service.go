@@ -10,12 +10,15 @@ package sinks
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/orb-community/orb/pkg/errors"
 	"github.com/orb-community/orb/pkg/types"
 	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/authentication_type/multiauth"
 	"github.com/orb-community/orb/sinks/backend"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -26,6 +29,7 @@ var (
 	ErrConflictSink               = errors.New("entity already exists")
 	ErrUnsupportedContentTypeSink = errors.New("unsupported content type")
 	ErrValidateSink               = errors.New("failed to validate Sink")
+	ErrRotateCredentials          = errors.New("failed to rotate Sink credentials")
 )
 
 func (svc sinkService) CreateSink(ctx context.Context, token string, sink Sink) (Sink, error) {
@@ -35,13 +39,53 @@ func (svc sinkService) CreateSink(ctx context.Context, token string, sink Sink)
 		return Sink{}, errors.Wrap(ErrCreateSink, err)
 	}
 
+	return svc.createSink(ctx, mfOwnerID, sink)
+}
+
+func (svc sinkService) CreateSinkOnBehalfOf(ctx context.Context, token string, ownerID string, sink Sink) (Sink, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+	svc.logger.Info("creating sink on behalf of owner", zap.String("admin_id", adminID), zap.String("owner_id", ownerID))
+
+	return svc.createSink(ctx, ownerID, sink)
+}
+
+func (svc sinkService) createSink(ctx context.Context, mfOwnerID string, sink Sink) (Sink, error) {
 	sink.MFOwnerID = mfOwnerID
 
+	if !svc.backendEnabled(sink.Backend) {
+		return Sink{}, errors.Wrap(ErrCreateSink, errors.ErrBackendNotEnabled)
+	}
+	if be := backend.GetBackend(sink.Backend); be != nil && !backend.MaturityAllowed(sink.Backend, be) {
+		return Sink{}, errors.Wrap(ErrCreateSink, errors.ErrBackendNotStable)
+	}
+
+	defaultTags, err := svc.sinkRepo.RetrieveOwnerDefaultTags(ctx, mfOwnerID)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+	sink.Tags = mergeDefaultTags(defaultTags, sink.Tags)
+	if err := svc.tagLimits.validate(sink.Tags); err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+	if err := validateName(sink.Name); err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+
 	be, err := svc.validateBackend(&sink)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrCreateSink, err)
 	}
-	at, err := validateAuthType(&sink)
+	sink.Type, err = resolveSignalType(dataBackend(be, sink.Config), sink.Type)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+	at, err := validateAuthType(be, &sink)
 	if err != nil {
 		return Sink{}, err
 	}
@@ -60,11 +104,15 @@ func (svc sinkService) CreateSink(ctx context.Context, token string, sink Sink)
 		return Sink{}, err
 	}
 
+	sink.CredentialsUpdatedAt = time.Now()
+
 	id, err := svc.sinkRepo.Save(ctx, sink)
 	if err != nil {
+		svc.deleteSecrets(secretRefs(sink), nil)
 		return Sink{}, errors.Wrap(ErrCreateSink, err)
 	}
 	sink.ID = id
+	svc.recordConfigRevision(ctx, sink)
 
 	// After creating, decrypt Metadata to send correct information to Redis
 	sink, err = svc.decryptMetadata(cfg, sink)
@@ -74,22 +122,67 @@ func (svc sinkService) CreateSink(ctx context.Context, token string, sink Sink)
 	return sink, nil
 }
 
-func validateAuthType(s *Sink) (authentication_type.AuthenticationType, error) {
-	var authMetadata types.Metadata
+// mergeDefaultTags adds the owner default tags to the tags of a sink, the sink tags win on conflict
+func mergeDefaultTags(defaults types.Tags, tags types.Tags) types.Tags {
+	if len(defaults) == 0 {
+		return tags
+	}
+	merged := make(types.Tags, len(defaults)+len(tags))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+	return merged
+}
+
+func validateAuthType(be backend.Backend, s *Sink) (authentication_type.AuthenticationType, error) {
+	var config types.Metadata
 	if len(s.ConfigData) != 0 {
-		var helper types.Metadata
 		if s.Format == "yaml" {
-			err := yaml.Unmarshal([]byte(s.ConfigData), &helper)
+			err := yaml.Unmarshal([]byte(s.ConfigData), &config)
 			if err != nil {
 				return nil, err
 			}
-			authMetadata = helper.GetSubMetadata(authentication_type.AuthenticationKey)
 		} else {
 			return nil, errors.New("config format not supported")
 		}
 	} else {
-		authMetadata = s.Config.GetSubMetadata(authentication_type.AuthenticationKey)
+		config = s.Config
+	}
+	if be != nil {
+		be = dataBackend(be, config)
+	}
+	if multiauth.IsMultiAuth(config) {
+		authType, _ := authentication_type.GetAuthType(multiauth.AuthType)
+		err := authType.ValidateConfiguration("object", config[authentication_type.AuthenticationKey])
+		if err != nil {
+			return nil, err
+		}
+		blocks, err := multiauth.Blocks(config[authentication_type.AuthenticationKey])
+		if err != nil {
+			return nil, err
+		}
+		var authenticators []string
+		for _, block := range blocks {
+			blockType, _ := block["type"].(string)
+			if err := checkAuthTypeSupported(be, blockType); err != nil {
+				return nil, err
+			}
+			for _, authenticator := range multiauth.Authenticators {
+				if blockType == authenticator {
+					authenticators = append(authenticators, blockType)
+				}
+			}
+		}
+		if len(authenticators) > 1 {
+			return nil, errors.Wrap(ErrMalformedEntity, errors.New(fmt.Sprintf("a sink takes a single authenticator, got %s",
+				strings.Join(authenticators, ", "))))
+		}
+		return authType, nil
 	}
+	authMetadata := config.GetSubMetadata(authentication_type.AuthenticationKey)
 	authTypeStr, ok := authMetadata["type"]
 	if !ok {
 		return nil, errors.Wrap(errors.ErrAuthTypeNotFound, errors.New("authentication type not found"))
@@ -108,14 +201,25 @@ func validateAuthType(s *Sink) (authentication_type.AuthenticationType, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkAuthTypeSupported(be, authTypeStr.(string)); err != nil {
+		return nil, err
+	}
 
 	return authType, nil
 }
 
+// checkAuthTypeSupported rejects the authentication types the backend of the sink does not accept
+func checkAuthTypeSupported(be backend.Backend, authType string) error {
+	if be == nil || backend.SupportsAuthType(be, authType) {
+		return nil
+	}
+	return errors.Wrap(ErrUnsupportedAuthType,
+		errors.New(fmt.Sprintf("type '%s' is not one of %s", authType, strings.Join(be.SupportedAuthTypes(), ", "))))
+}
+
 func (svc sinkService) encryptMetadata(configSvc Configuration, sink Sink) (Sink, error) {
-	var err error
 	if sink.Config != nil {
-		encodeMetadata, err := configSvc.Authentication.EncodeInformation("object", sink.Config)
+		encodeMetadata, err := configSvc.Authentication.EncodeInformation(sink.MFOwnerID, "object", sink.Config)
 		if err != nil {
 			svc.logger.Error("error on parsing encrypted config in data")
 			return sink, err
@@ -123,14 +227,16 @@ func (svc sinkService) encryptMetadata(configSvc Configuration, sink Sink) (Sink
 		sink.Config = encodeMetadata.(types.Metadata)
 	}
 	if sink.ConfigData != "" {
-		encodeMetadata, err := configSvc.Authentication.EncodeInformation("yaml", sink.ConfigData)
+		encodeMetadata, err := configSvc.Authentication.EncodeInformation(sink.MFOwnerID, "yaml", sink.ConfigData)
 		if err != nil {
 			svc.logger.Error("error on parsing encrypted config in data")
 			return sink, err
 		}
 		sink.ConfigData = encodeMetadata.(string)
 	}
-	return sink, err
+	return svc.codeSecretHeaders(configSvc.Exporter, sink, func(value string) (string, error) {
+		return svc.passwordService.EncodeOwnerPassword(sink.MFOwnerID, value)
+	})
 }
 
 func (svc sinkService) ViewAuthenticationType(ctx context.Context, token string, key string) (authentication_type.AuthenticationTypeConfig, error) {
@@ -158,9 +264,8 @@ func (svc sinkService) ListAuthenticationTypes(ctx context.Context, token string
 }
 
 func (svc sinkService) decryptMetadata(configSvc Configuration, sink Sink) (Sink, error) {
-	var err error
 	if sink.Config != nil {
-		decodeMetadata, err := configSvc.Authentication.DecodeInformation("object", sink.Config)
+		decodeMetadata, err := configSvc.Authentication.DecodeInformation(sink.MFOwnerID, "object", sink.Config)
 		if err != nil {
 			svc.logger.Error("error on parsing encrypted config in data")
 			return sink, err
@@ -168,14 +273,97 @@ func (svc sinkService) decryptMetadata(configSvc Configuration, sink Sink) (Sink
 		sink.Config = decodeMetadata.(types.Metadata)
 	}
 	if sink.ConfigData != "" {
-		decodeMetadata, err := configSvc.Authentication.DecodeInformation("yaml", sink.ConfigData)
+		decodeMetadata, err := configSvc.Authentication.DecodeInformation(sink.MFOwnerID, "yaml", sink.ConfigData)
 		if err != nil {
 			svc.logger.Error("error on parsing encrypted config in data")
 			return sink, err
 		}
 		sink.ConfigData = decodeMetadata.(string)
 	}
-	return sink, err
+	return svc.codeSecretHeaders(configSvc.Exporter, sink, func(value string) (string, error) {
+		decoded, err := svc.passwordService.DecodeOwnerPassword(sink.MFOwnerID, value)
+		if err != nil {
+			// headers saved before being flagged as secrets stay in plain text until the sink is updated
+			return value, nil
+		}
+		return decoded, nil
+	})
+}
+
+// codeSecretHeaders encrypts or decrypts the values of the custom exporter headers the backend flags as secrets
+func (svc sinkService) codeSecretHeaders(be backend.Backend, sink Sink, code func(string) (string, error)) (Sink, error) {
+	if _, ok := be.(backend.SecretHeaders); !ok {
+		return sink, nil
+	}
+	if sink.Config != nil {
+		config, err := ApplySecretHeaders(be, sink.Config, code)
+		if err != nil {
+			svc.logger.Error("error on coding secret headers in config", zap.Error(err))
+			return sink, err
+		}
+		sink.Config = config
+	}
+	if sink.ConfigData != "" {
+		var config types.Metadata
+		if err := yaml.Unmarshal([]byte(sink.ConfigData), &config); err != nil {
+			return sink, err
+		}
+		config, err := ApplySecretHeaders(be, config, code)
+		if err != nil {
+			svc.logger.Error("error on coding secret headers in config data", zap.Error(err))
+			return sink, err
+		}
+		configData, err := yaml.Marshal(config)
+		if err != nil {
+			return sink, err
+		}
+		sink.ConfigData = string(configData)
+	}
+	return sink, nil
+}
+
+// ApplySecretHeaders returns a copy of the config where the values of the custom exporter headers flagged
+// as secrets by the backend are replaced with the result of apply
+func ApplySecretHeaders(be backend.Backend, config types.Metadata, apply func(string) (string, error)) (types.Metadata, error) {
+	secrets, ok := be.(backend.SecretHeaders)
+	if !ok {
+		return config, nil
+	}
+	exporter := config.GetSubMetadata("exporter")
+	if exporter == nil {
+		return config, nil
+	}
+	headers, ok := exporter["headers"].(map[string]interface{})
+	if !ok {
+		return config, nil
+	}
+	appliedHeaders := make(map[string]interface{}, len(headers))
+	for name, value := range headers {
+		appliedHeaders[name] = value
+		if !secrets.IsSecretHeader(name) {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("value of header "+name+" must be a string"))
+		}
+		applied, err := apply(str)
+		if err != nil {
+			return nil, err
+		}
+		appliedHeaders[name] = applied
+	}
+	appliedExporter := make(types.Metadata, len(exporter))
+	for key, value := range exporter {
+		appliedExporter[key] = value
+	}
+	appliedExporter["headers"] = appliedHeaders
+	appliedConfig := make(types.Metadata, len(config))
+	for key, value := range config {
+		appliedConfig[key] = value
+	}
+	appliedConfig["exporter"] = appliedExporter
+	return appliedConfig, nil
 }
 
 func (svc sinkService) UpdateSinkInternal(ctx context.Context, sink Sink) (Sink, error) {
@@ -184,6 +372,9 @@ func (svc sinkService) UpdateSinkInternal(ctx context.Context, sink Sink) (Sink,
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 	}
+	previousRefs := secretRefs(currentSink)
+	// the credentials are encrypted with the key of the owner
+	sink.MFOwnerID = currentSink.MFOwnerID
 	var cfg Configuration
 	if sink.Config == nil && sink.ConfigData == "" {
 		// No config sent, keep the previous
@@ -207,7 +398,7 @@ func (svc sinkService) UpdateSinkInternal(ctx context.Context, sink Sink) (Sink,
 		if err != nil {
 			return Sink{}, errors.Wrap(ErrMalformedEntity, err)
 		}
-		at, err := validateAuthType(&sink)
+		at, err := validateAuthType(be, &sink)
 		if err != nil {
 			return Sink{}, errors.Wrap(ErrMalformedEntity, err)
 		}
@@ -238,22 +429,28 @@ func (svc sinkService) UpdateSinkInternal(ctx context.Context, sink Sink) (Sink,
 		sink.Name = currentSink.Name
 	}
 
-	sink.MFOwnerID = currentSink.MFOwnerID
 	if sink.Backend == "" && currentSink.Backend != "" {
 		sink.Backend = currentSink.Backend
 	}
+	if sink.Type, err = updatedSignalType(sink.Type, currentSink); err != nil {
+		return Sink{}, errors.Wrap(ErrMalformedEntity, err)
+	}
 	sink, err = svc.encryptMetadata(cfg, sink)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 	}
+	savedRefs := secretRefs(sink)
 	err = svc.sinkRepo.Update(ctx, sink)
 	if err != nil {
+		svc.deleteSecrets(savedRefs, previousRefs)
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 	}
+	svc.deleteSecrets(previousRefs, savedRefs)
 	sinkEdited, err := svc.sinkRepo.RetrieveById(ctx, sink.ID)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 	}
+	svc.recordConfigRevision(ctx, sinkEdited)
 	sinkEdited, err = svc.decryptMetadata(cfg, sinkEdited)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
@@ -267,11 +464,20 @@ func (svc sinkService) UpdateSink(ctx context.Context, token string, sink Sink)
 	if err != nil {
 		return Sink{}, err
 	}
+	if err := svc.tagLimits.validate(sink.Tags); err != nil {
+		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+	if sink.Name.String() != "" {
+		if err := validateName(sink.Name); err != nil {
+			return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+		}
+	}
 
 	currentSink, err := svc.sinkRepo.RetrieveById(ctx, sink.ID)
 	if err != nil {
 		return Sink{}, err
 	}
+	previousRefs := secretRefs(currentSink)
 
 	authType, _ := authentication_type.GetAuthType(currentSink.GetAuthenticationTypeName())
 	be := backend.GetBackend(currentSink.Backend)
@@ -304,7 +510,7 @@ func (svc sinkService) UpdateSink(ctx context.Context, token string, sink Sink)
 		if err != nil {
 			return Sink{}, errors.Wrap(errors.New("incorrect backend and exporter configuration"), err)
 		}
-		at, err := validateAuthType(&sink)
+		at, err := validateAuthType(be, &sink)
 		if err != nil {
 			return Sink{}, errors.Wrap(errors.New("incorrect authentication configuration"), err)
 		}
@@ -319,8 +525,8 @@ func (svc sinkService) UpdateSink(ctx context.Context, token string, sink Sink)
 		// check if the password is encrypted and decrypt it if it is
 		if existingAuth := sink.Config.GetSubMetadata(authentication_type.AuthenticationKey); existingAuth != nil {
 			if password, ok := existingAuth["password"]; ok {
-				// if the password is encrypted, it will be a hex string
-				if _, err := hex.DecodeString(password.(string)); err == nil {
+				// if the password is encrypted, it will be a hex string, or a reference to the secret store
+				if authentication_type.IsEncoded(password.(string)) {
 					if sink, err = svc.decryptMetadata(cfg, sink); err != nil {
 						return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 					}
@@ -354,18 +560,25 @@ func (svc sinkService) UpdateSink(ctx context.Context, token string, sink Sink)
 	if sink.Backend == "" && currentSink.Backend != "" {
 		sink.Backend = currentSink.Backend
 	}
+	if sink.Type, err = updatedSignalType(sink.Type, currentSink); err != nil {
+		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+	}
 	sink, err = svc.encryptMetadata(cfg, sink)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
 	}
+	savedRefs := secretRefs(sink)
 	err = svc.sinkRepo.Update(ctx, sink)
 	if err != nil {
+		svc.deleteSecrets(savedRefs, previousRefs)
 		return Sink{}, err
 	}
+	svc.deleteSecrets(previousRefs, savedRefs)
 	sinkEdited, err := svc.sinkRepo.RetrieveById(ctx, sink.ID)
 	if err != nil {
 		return Sink{}, err
 	}
+	svc.recordConfigRevision(ctx, sinkEdited)
 	sinkEdited, err = svc.decryptMetadata(cfg, sinkEdited)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
@@ -374,6 +587,164 @@ func (svc sinkService) UpdateSink(ctx context.Context, token string, sink Sink)
 	return sinkEdited, nil
 }
 
+func (svc sinkService) RotateSinkCredentials(ctx context.Context, token string, sinkID string, credentials types.Metadata) (Sink, error) {
+	skOwnerID, err := svc.identify(token)
+	if err != nil {
+		return Sink{}, err
+	}
+
+	currentSink, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, skOwnerID, sinkID)
+	if err != nil {
+		return Sink{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+	previousRefs := secretRefs(currentSink)
+
+	currentAuthType, ok := authentication_type.GetAuthType(currentSink.GetAuthenticationTypeName())
+	if !ok {
+		return Sink{}, errors.Wrap(ErrRotateCredentials, errors.New("sink has an invalid authentication type"))
+	}
+	be := backend.GetBackend(currentSink.Backend)
+	cfg := Configuration{
+		Authentication: currentAuthType,
+		Exporter:       be,
+	}
+
+	// work on a copy of the config, the stored authentication must not be touched until the update
+	config := make(types.Metadata, len(currentSink.Config))
+	for key, value := range currentSink.Config {
+		config[key] = value
+	}
+	config[authentication_type.AuthenticationKey] = copyAuthentication(currentSink.Config[authentication_type.AuthenticationKey])
+	currentSink.Config = config
+
+	// get the decrypted config, so the fields not sent keep their plain value
+	currentSink, err = svc.decryptMetadata(cfg, currentSink)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+	}
+
+	if multiauth.IsMultiAuth(currentSink.Config) {
+		// only the block with the given type is rotated
+		authTypeName, ok := credentials["type"].(string)
+		if !ok {
+			return Sink{}, errors.Wrap(errors.ErrAuthTypeNotFound, errors.New("authentication type is required to rotate one of multiple authentications"))
+		}
+		blocks, err := multiauth.Blocks(currentSink.Config[authentication_type.AuthenticationKey])
+		if err != nil {
+			return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+		}
+		found := false
+		newBlocks := make([]interface{}, 0, len(blocks))
+		for _, block := range blocks {
+			if block["type"] == authTypeName {
+				block, _, err = mergeCredentials(block, credentials)
+				if err != nil {
+					return Sink{}, err
+				}
+				found = true
+			}
+			newBlocks = append(newBlocks, block)
+		}
+		if !found {
+			return Sink{}, errors.Wrap(errors.ErrAuthInvalidType, errors.New("sink has no authentication of type "+authTypeName))
+		}
+		currentSink.Config[authentication_type.AuthenticationKey] = newBlocks
+	} else {
+		newAuth, _, err := mergeCredentials(currentSink.Config.GetSubMetadata(authentication_type.AuthenticationKey), credentials)
+		if err != nil {
+			return Sink{}, err
+		}
+		currentSink.Config[authentication_type.AuthenticationKey] = newAuth
+	}
+	if currentSink.Format == "yaml" {
+		configDataByte, err := yaml.Marshal(currentSink.Config)
+		if err != nil {
+			return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+		}
+		currentSink.ConfigData = string(configDataByte)
+	}
+	// the merged authentication may change its type, so it must be supported by the backend as on an update
+	cfg.Authentication, err = validateAuthType(be, &currentSink)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	currentSink, err = svc.encryptMetadata(cfg, currentSink)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+	}
+	currentSink.CredentialsUpdatedAt = time.Now()
+	savedRefs := secretRefs(currentSink)
+	err = svc.sinkRepo.Update(ctx, currentSink)
+	if err != nil {
+		svc.deleteSecrets(savedRefs, previousRefs)
+		return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+	}
+	svc.deleteSecrets(previousRefs, savedRefs)
+	sinkEdited, err := svc.sinkRepo.RetrieveById(ctx, currentSink.ID)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+	}
+	svc.recordConfigRevision(ctx, sinkEdited)
+	sinkEdited, err = svc.decryptMetadata(cfg, sinkEdited)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrRotateCredentials, err)
+	}
+
+	return sinkEdited, nil
+}
+
+// mergeCredentials replaces the fields of the current authentication with the given credentials,
+// when the authentication type changes the current fields are discarded
+func mergeCredentials(current types.Metadata, credentials types.Metadata) (types.Metadata, authentication_type.AuthenticationType, error) {
+	currentTypeName, _ := current["type"].(string)
+	authTypeName := currentTypeName
+	if value, ok := credentials["type"]; ok {
+		name, ok := value.(string)
+		if !ok {
+			return nil, nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication type"))
+		}
+		authTypeName = name
+	}
+	newAuth := make(types.Metadata)
+	if authTypeName == currentTypeName {
+		for key, value := range current {
+			newAuth[key] = value
+		}
+	}
+	for key, value := range credentials {
+		newAuth[key] = value
+	}
+	newAuth["type"] = authTypeName
+
+	at, ok := authentication_type.GetAuthType(authTypeName)
+	if !ok || authTypeName == multiauth.AuthType {
+		return nil, nil, errors.Wrap(errors.ErrAuthInvalidType, errors.New("invalid authentication type"))
+	}
+	if err := at.ValidateConfiguration("object", newAuth); err != nil {
+		return nil, nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	return newAuth, at, nil
+}
+
+// copyAuthentication copies the authentication field, so decrypting it won't change the original blocks
+func copyAuthentication(value interface{}) interface{} {
+	if blocks, err := multiauth.Blocks(value); err == nil {
+		copied := make([]interface{}, 0, len(blocks))
+		for _, block := range blocks {
+			copied = append(copied, types.FromMap(block))
+		}
+		return copied
+	}
+	switch value.(type) {
+	case types.Metadata:
+		return types.FromMap(value.(types.Metadata))
+	case map[string]interface{}:
+		return types.FromMap(value.(map[string]interface{}))
+	}
+	return value
+}
+
 func removeConfigDataKey(configData string, format string, keys []string) (string, error) {
 	if configData == "" {
 		return "", nil
@@ -404,12 +775,114 @@ func removeConfigDataKey(configData string, format string, keys []string) (strin
 	return "", errors.New("unrecognized format")
 }
 
+func (svc sinkService) PatchSink(ctx context.Context, token string, sink Sink) (Sink, error) {
+	skOwnerID, err := svc.identify(token)
+	if err != nil {
+		return Sink{}, err
+	}
+	if err := svc.tagLimits.validate(sink.Tags); err != nil {
+		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+	if sink.Name.String() != "" {
+		if err := validateName(sink.Name); err != nil {
+			return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+		}
+	}
+
+	// the stored config is written back untouched, so it is kept encrypted
+	currentSink, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, skOwnerID, sink.ID)
+	if err != nil {
+		return Sink{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	if sink.Name.String() != "" {
+		currentSink.Name = sink.Name
+	}
+	if sink.Description != nil {
+		currentSink.Description = sink.Description
+	}
+	if sink.Tags != nil {
+		currentSink.Tags = sink.Tags
+	}
+
+	err = svc.sinkRepo.Update(ctx, currentSink)
+	if err != nil {
+		return Sink{}, err
+	}
+	sinkEdited, err := svc.sinkRepo.RetrieveById(ctx, currentSink.ID)
+	if err != nil {
+		return Sink{}, err
+	}
+
+	authType, _ := authentication_type.GetAuthType(sinkEdited.GetAuthenticationTypeName())
+	cfg := Configuration{
+		Authentication: authType,
+		Exporter:       backend.GetBackend(sinkEdited.Backend),
+	}
+	sinkEdited, err = svc.decryptMetadata(cfg, sinkEdited)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	return sinkEdited, nil
+}
+
+func (svc sinkService) CloneSink(ctx context.Context, token string, sinkID string, sink Sink) (Sink, error) {
+	mfOwnerID, err := svc.identify(token)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+
+	source, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, mfOwnerID, sinkID)
+	if err != nil {
+		return Sink{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+	// the credentials are decrypted here and encrypted again along with the new sink, on a copy so the
+	// source sink is never changed
+	if source.Config != nil {
+		config := types.FromMap(source.Config)
+		config[authentication_type.AuthenticationKey] = copyAuthentication(config[authentication_type.AuthenticationKey])
+		source.Config = config
+	}
+	authType, _ := authentication_type.GetAuthType(source.GetAuthenticationTypeName())
+	cfg := Configuration{
+		Authentication: authType,
+		Exporter:       backend.GetBackend(source.Backend),
+	}
+	source, err = svc.decryptMetadata(cfg, source)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+
+	clone := Sink{
+		Name:        sink.Name,
+		Description: source.Description,
+		Backend:     source.Backend,
+		Type:        source.Type,
+		Config:      source.Config,
+		ConfigData:  source.ConfigData,
+		Format:      source.Format,
+		Tags:        source.Tags,
+		Created:     time.Now(),
+	}
+	if sink.Tags != nil {
+		clone.Tags = sink.Tags
+	}
+	return svc.createSink(ctx, mfOwnerID, clone)
+}
+
 func (svc sinkService) ListBackends(_ context.Context, token string) ([]string, error) {
 	_, err := svc.identify(token)
 	if err != nil {
 		return []string{}, err
 	}
-	return backend.GetList(), nil
+	list := make([]string, 0)
+	for _, name := range backend.GetList() {
+		if svc.backendEnabled(name) {
+			list = append(list, name)
+		}
+	}
+	return list, nil
 }
 
 func (svc sinkService) ViewBackend(_ context.Context, token string, key string) (backend.Backend, error) {
@@ -421,6 +894,9 @@ func (svc sinkService) ViewBackend(_ context.Context, token string, key string)
 	if res == nil {
 		return nil, errors.Wrap(errors.ErrNotFound, err)
 	}
+	if !svc.backendEnabled(key) {
+		return nil, errors.ErrBackendNotEnabled
+	}
 	return res, nil
 }
 
@@ -429,10 +905,14 @@ func (svc sinkService) ViewSink(ctx context.Context, token string, key string) (
 	if err != nil {
 		return Sink{}, err
 	}
-	res, err := svc.sinkRepo.RetrieveById(ctx, key)
+	res, err := svc.sinkRepo.RetrieveById(WithStaleReads(ctx), key)
 	if err != nil {
 		return Sink{}, errors.Wrap(errors.ErrNotFound, err)
 	}
+	// the secrets of the config can not be omitted without the backend, so the sink is not returned at all
+	if backend.GetBackend(res.Backend) == nil {
+		return Sink{}, errors.Wrap(ErrBackendUnavailable, errors.New("backend "+res.Backend+" of sink "+key))
+	}
 	if res.Format == "" {
 		res.Format = "json"
 	}
@@ -485,6 +965,26 @@ func (svc sinkService) ListSinksInternal(ctx context.Context, filter Filter) (si
 	return
 }
 
+func (svc sinkService) ResyncSinks(ctx context.Context, token string) ([]Sink, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return nil, err
+	}
+	if !svc.resync.allow() {
+		return nil, ErrResyncRateLimited
+	}
+	page, err := svc.ListSinksInternal(ctx, Filter{})
+	if err != nil {
+		return nil, err
+	}
+	svc.logger.Info("resyncing sink inventory", zap.String("admin_id", adminID), zap.Int("sinks", len(page.Sinks)))
+
+	return page.Sinks, nil
+}
+
 func (svc sinkService) ListSinks(ctx context.Context, token string, pm PageMetadata) (Page, error) {
 	res, err := svc.identify(token)
 	if err != nil {
@@ -492,16 +992,31 @@ func (svc sinkService) ListSinks(ctx context.Context, token string, pm PageMetad
 		return Page{}, err
 	}
 
-	return svc.sinkRepo.RetrieveAllByOwnerID(ctx, res, pm)
+	return svc.sinkRepo.RetrieveAllByOwnerID(WithStaleReads(ctx), res, pm)
 }
 
-func (svc sinkService) DeleteSink(ctx context.Context, token string, id string) error {
+func (svc sinkService) DeleteSink(ctx context.Context, token string, id string, force bool) error {
 	res, err := svc.identify(token)
 	if err != nil {
 		return err
 	}
 
-	return svc.sinkRepo.Remove(ctx, res, id)
+	if !force {
+		if err := svc.checkSinkDependencies(ctx, res, id); err != nil {
+			return err
+		}
+	}
+
+	// the secrets are only removed along with the sink, a sink not found has none to remove
+	current, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, res, id)
+	if err != nil {
+		return svc.sinkRepo.Remove(ctx, res, id)
+	}
+	if err := svc.sinkRepo.Remove(ctx, res, id); err != nil {
+		return err
+	}
+	svc.deleteSecrets(secretRefs(current), nil)
+	return nil
 }
 
 func (svc sinkService) ValidateSink(ctx context.Context, token string, sink Sink) (Sink, error) {
@@ -513,12 +1028,26 @@ func (svc sinkService) ValidateSink(ctx context.Context, token string, sink Sink
 
 	sink.MFOwnerID = mfOwnerID
 
-	_, err = svc.validateBackend(&sink)
+	if !svc.backendEnabled(sink.Backend) {
+		return Sink{}, errors.Wrap(ErrValidateSink, errors.ErrBackendNotEnabled)
+	}
+	if be := backend.GetBackend(sink.Backend); be != nil && !backend.MaturityAllowed(sink.Backend, be) {
+		return Sink{}, errors.Wrap(ErrValidateSink, errors.ErrBackendNotStable)
+	}
+	if err := validateName(sink.Name); err != nil {
+		return Sink{}, errors.Wrap(ErrValidateSink, err)
+	}
+
+	be, err := svc.validateBackend(&sink)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrValidateSink, err)
+	}
+	sink.Type, err = resolveSignalType(dataBackend(be, sink.Config), sink.Type)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrValidateSink, err)
 	}
 
-	_, err = validateAuthType(&sink)
+	_, err = validateAuthType(be, &sink)
 	if err != nil {
 		return Sink{}, errors.Wrap(ErrValidateSink, err)
 	}
@@ -527,7 +1056,172 @@ func (svc sinkService) ValidateSink(ctx context.Context, token string, sink Sink
 }
 
 func (svc sinkService) ChangeSinkStateInternal(ctx context.Context, sinkID string, msg string, ownerID string, state State) error {
-	return svc.sinkRepo.UpdateSinkState(ctx, sinkID, msg, ownerID, state)
+	current, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, ownerID, sinkID)
+	if err != nil {
+		return err
+	}
+	if err := svc.sinkRepo.UpdateSinkState(ctx, sinkID, msg, ownerID, state); err != nil {
+		return err
+	}
+	if current.State != state {
+		event := StateEvent{
+			SinkID:    sinkID,
+			Timestamp: time.Now(),
+			OldState:  current.State,
+			NewState:  state,
+			Message:   msg,
+		}
+		if err := svc.sinkRepo.AddStateEvent(ctx, event); err != nil {
+			svc.logger.Warn("failed to record sink state change", zap.String("sink_id", sinkID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (svc sinkService) ListSinkStateEvents(ctx context.Context, token string, sinkID string) ([]StateEvent, error) {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := svc.sinkRepo.RetrieveByOwnerAndId(ctx, ownerID, sinkID); err != nil {
+		return nil, errors.Wrap(errors.ErrNotFound, err)
+	}
+	return svc.sinkRepo.RetrieveStateEvents(ctx, sinkID)
+}
+
+func (svc sinkService) SetOwnerDefaultTags(ctx context.Context, token string, ownerID string, tags types.Tags) (types.Tags, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return nil, err
+	}
+	if err := svc.sinkRepo.SaveOwnerDefaultTags(ctx, ownerID, tags); err != nil {
+		return nil, err
+	}
+	svc.logger.Info("owner default sink tags set", zap.String("admin_id", adminID), zap.String("owner_id", ownerID))
+	if tags == nil {
+		tags = types.Tags{}
+	}
+	return tags, nil
+}
+
+func (svc sinkService) ViewOwnerDefaultTags(ctx context.Context, token string, ownerID string) (types.Tags, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return nil, err
+	}
+	return svc.sinkRepo.RetrieveOwnerDefaultTags(ctx, ownerID)
+}
+
+func (svc sinkService) RevalidateBackendSinks(ctx context.Context, token string, backendName string) (RevalidationReport, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return RevalidationReport{}, err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return RevalidationReport{}, err
+	}
+	if !backend.HaveBackend(backendName) {
+		return RevalidationReport{}, errors.Wrap(errors.ErrNotFound, ErrInvalidBackend)
+	}
+
+	sinks, err := svc.sinkRepo.SearchAllSinks(ctx, Filter{Backend: backendName})
+	if err != nil {
+		return RevalidationReport{}, err
+	}
+	report := RevalidationReport{Backend: backendName, Checked: len(sinks), Failures: []ValidationFailure{}}
+	for _, sink := range sinks {
+		// the exporter config is validated as stored, the authentication secrets are encrypted at rest
+		sink.ConfigData = ""
+		if _, err := svc.validateBackend(&sink); err != nil {
+			report.Failures = append(report.Failures, ValidationFailure{
+				SinkID:  sink.ID,
+				OwnerID: sink.MFOwnerID,
+				Name:    sink.Name.String(),
+				Error:   err.Error(),
+			})
+		}
+	}
+	svc.logger.Info("backend sinks revalidated", zap.String("admin_id", adminID), zap.String("backend", backendName),
+		zap.Int("checked", report.Checked), zap.Int("failing", len(report.Failures)))
+	return report, nil
+}
+
+func (svc sinkService) CountSinks(ctx context.Context, token string, tags types.Tags) (Counts, error) {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return Counts{}, err
+	}
+	return svc.sinkRepo.CountByOwnerID(WithStaleReads(ctx), ownerID, tags)
+}
+
+func (svc sinkService) CheckReadiness(ctx context.Context) map[string]string {
+	failing := make(map[string]string)
+
+	names := backend.GetList()
+	for name := range svc.enabledBackends {
+		if !backend.HaveBackend(name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		be := backend.GetBackend(name)
+		if be == nil {
+			failing["backend:"+name] = "backend is listed but does not resolve"
+			continue
+		}
+		if be.Metadata() == nil {
+			failing["backend:"+name] = "backend has no metadata"
+		}
+	}
+
+	if err := svc.sinkRepo.Ping(ctx); err != nil {
+		failing["database"] = err.Error()
+	}
+
+	return failing
+}
+
+// resolveSignalType checks the requested sink type against the signals of the backend, defaulting to the
+// only signal of the backends supporting a single one
+func resolveSignalType(be backend.Backend, signalType string) (string, error) {
+	signals := be.SupportedSignals()
+	if signalType == "" {
+		if len(signals) == 1 {
+			return signals[0], nil
+		}
+		return "", nil
+	}
+	for _, signal := range signals {
+		if signal == signalType {
+			return signalType, nil
+		}
+	}
+	return "", errors.Wrap(ErrInvalidSignalType,
+		errors.New(fmt.Sprintf("type '%s' is not one of %s", signalType, strings.Join(signals, ", "))))
+}
+
+// updatedSignalType keeps the stored type when none is sent, the backend of a sink cannot change on update
+func updatedSignalType(signalType string, currentSink Sink) (string, error) {
+	if signalType == "" {
+		signalType = currentSink.Type
+	}
+	be := backend.GetBackend(currentSink.Backend)
+	if be == nil {
+		return signalType, nil
+	}
+	return resolveSignalType(dataBackend(be, currentSink.Config), signalType)
+}
+
+// dataBackend returns the backend the data of the sink is written to, the inner backend of a wrapping one
+func dataBackend(be backend.Backend, config types.Metadata) backend.Backend {
+	inner, _ := backend.Unwrap(be, config.GetSubMetadata(exporterKey))
+	return inner
 }
 
 func (svc sinkService) validateBackend(sink *Sink) (be backend.Backend, err error) {
@@ -536,21 +1230,53 @@ func (svc sinkService) validateBackend(sink *Sink) (be backend.Backend, err erro
 	}
 	sinkBe := backend.GetBackend(sink.Backend)
 	if len(sink.ConfigData) == 0 {
+		if err := ValidateConfigPlacement(sinkBe, sink.Config); err != nil {
+			return nil, err
+		}
 		config := sink.Config.GetSubMetadata("exporter")
 		if config == nil {
 			return nil, errors.Wrap(ErrInvalidBackend, errors.New("missing exporter configuration"))
 		}
-		return sinkBe, sinkBe.ValidateConfiguration(config)
+		config = normalizeExporter(sinkBe, sink, config)
+		return sinkBe, ValidateExporter(sinkBe, config)
 	} else {
 		parseConfig, err := sinkBe.ParseConfig("yaml", sink.ConfigData)
 		if err != nil {
 			return nil, errors.Wrap(ErrInvalidBackend, err)
 		}
 		sink.Config = parseConfig
+		if err := ValidateConfigPlacement(sinkBe, sink.Config); err != nil {
+			return nil, err
+		}
 		config2 := sink.Config.GetSubMetadata("exporter")
 		if config2 == nil {
 			return nil, errors.Wrap(ErrInvalidBackend, errors.New("missing exporter configuration"))
 		}
-		return sinkBe, sinkBe.ValidateConfiguration(config2)
+		config2 = normalizeExporter(sinkBe, sink, config2)
+		configData, err := yaml.Marshal(sink.Config)
+		if err != nil {
+			return nil, errors.Wrap(ErrMalformedEntity, err)
+		}
+		sink.ConfigData = string(configData)
+		return sinkBe, ValidateExporter(sinkBe, config2)
+	}
+}
+
+// ValidateExporter runs the validation of the backend and the deployment wide checks of the exporter config,
+// both the create and the validate paths go through it
+func ValidateExporter(be backend.Backend, exporter types.Metadata) error {
+	if err := be.ValidateConfiguration(exporter); err != nil {
+		return err
 	}
+	return backend.ValidateEndpointScheme(be, exporter)
+}
+
+// normalizeExporter replaces the exporter of the sink config with its canonical form, the config is copied so
+// the caller's one is left untouched
+func normalizeExporter(be backend.Backend, sink *Sink, exporter types.Metadata) types.Metadata {
+	normalized := be.Normalize(exporter)
+	config := types.FromMap(sink.Config)
+	config["exporter"] = normalized
+	sink.Config = config
+	return normalized
 }
@@ -0,0 +1,107 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrStateStreamUnavailable indicates the service was started without a sink state stream
+var ErrStateStreamUnavailable = errors.New("sink state stream not available")
+
+const (
+	// stateStreamBlock is how long a read of the state stream waits for new changes
+	stateStreamBlock = 5 * time.Second
+	// stateStreamRetry is the pause before reading the state stream again after a failed read
+	stateStreamRetry = time.Second
+)
+
+// StateChange is a sink state update read from the state stream, the ID is the position of the update on the
+// stream which a client resumes from
+type StateChange struct {
+	ID        string
+	SinkID    string
+	OwnerID   string
+	State     State
+	Message   string
+	Timestamp time.Time
+}
+
+// StateChangeStream reads the sink state updates of all owners
+type StateChangeStream interface {
+	// Latest returns the position of the newest update on the stream
+	Latest(ctx context.Context) (string, error)
+	// Read returns the updates published after the position, waiting up to block for new updates
+	Read(ctx context.Context, after string, block time.Duration) ([]StateChange, error)
+}
+
+// validStreamID checks a position has the <milliseconds>-<sequence> format of the stream entry ids
+func validStreamID(id string) bool {
+	ms, seq, found := strings.Cut(id, "-")
+	if _, err := strconv.ParseUint(ms, 10, 64); err != nil {
+		return false
+	}
+	if !found {
+		return true
+	}
+	_, err := strconv.ParseUint(seq, 10, 64)
+	return err == nil
+}
+
+func (svc sinkService) WatchSinkStates(ctx context.Context, token string, lastEventID string) (<-chan StateChange, error) {
+	ownerID, err := svc.identify(token)
+	if err != nil {
+		return nil, err
+	}
+	if svc.stateStream == nil {
+		return nil, ErrStateStreamUnavailable
+	}
+	after := lastEventID
+	if after == "" {
+		if after, err = svc.stateStream.Latest(ctx); err != nil {
+			return nil, err
+		}
+	} else if !validStreamID(after) {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errors.New("invalid last event id"))
+	}
+
+	changes := make(chan StateChange)
+	go func() {
+		defer close(changes)
+		for ctx.Err() == nil {
+			read, err := svc.stateStream.Read(ctx, after, stateStreamBlock)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				svc.logger.Warn("failed to read the sink state stream", zap.String("owner_id", ownerID), zap.Error(err))
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(stateStreamRetry):
+				}
+				continue
+			}
+			for _, change := range read {
+				after = change.ID
+				if change.OwnerID != ownerID {
+					continue
+				}
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
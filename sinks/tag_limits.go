@@ -0,0 +1,36 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"fmt"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+)
+
+// TagLimits bounds the tags written to a sink, a zero limit is not enforced
+type TagLimits struct {
+	MaxKeys        int
+	MaxKeyLength   int
+	MaxValueLength int
+}
+
+// validate checks the tags against the limits. It is only called on writes, so the sinks stored before the
+// limits were set are still viewable
+func (l TagLimits) validate(tags types.Tags) error {
+	if l.MaxKeys > 0 && len(tags) > l.MaxKeys {
+		return errors.Wrap(ErrTagLimitExceeded, fmt.Errorf("%d tags, at most %d are allowed", len(tags), l.MaxKeys))
+	}
+	for key, value := range tags {
+		if l.MaxKeyLength > 0 && len(key) > l.MaxKeyLength {
+			return errors.Wrap(ErrTagLimitExceeded, fmt.Errorf("tag key longer than %d characters", l.MaxKeyLength))
+		}
+		if l.MaxValueLength > 0 && len(value) > l.MaxValueLength {
+			return errors.Wrap(ErrTagLimitExceeded, fmt.Errorf("value of tag %q longer than %d characters", key, l.MaxValueLength))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/. */
+
+package sinks
+
+import (
+	"context"
+	"time"
+
+	"github.com/orb-community/orb/pkg/errors"
+	"github.com/orb-community/orb/pkg/types"
+	"github.com/orb-community/orb/sinks/authentication_type"
+	"github.com/orb-community/orb/sinks/backend"
+	"go.uber.org/zap"
+)
+
+// SinkTemplate is a named sink preset defined by an admin, the sinks created from it merge the overrides
+// of the user over its backend, configuration and tags. A template does not hold credentials, its
+// authentication may only set the authentication type
+type SinkTemplate struct {
+	Name        types.Identifier
+	Description string
+	Backend     string
+	Type        string
+	Config      types.Metadata
+	Tags        types.Tags
+	Created     time.Time
+	Updated     time.Time
+}
+
+// validateTemplateConfig checks the configuration of a template leaves the credentials to the user
+func validateTemplateConfig(config types.Metadata) error {
+	value, ok := config[authentication_type.AuthenticationKey]
+	if !ok || value == nil {
+		return nil
+	}
+	auth := config.GetSubMetadata(authentication_type.AuthenticationKey)
+	if auth == nil {
+		return errors.Wrap(ErrTemplateCredentials, errors.New("authentication must be an object"))
+	}
+	for key := range auth {
+		if key != "type" {
+			return errors.Wrap(ErrTemplateCredentials, errors.New("authentication may only set its type"))
+		}
+	}
+	return nil
+}
+
+// mergeTemplateConfig returns a copy of the template configuration with the overrides merged over it,
+// the nested objects are merged key by key and any other override value replaces the template one
+func mergeTemplateConfig(template types.Metadata, overrides types.Metadata) types.Metadata {
+	merged := make(types.Metadata, len(template)+len(overrides))
+	for key, value := range template {
+		merged[key] = copyTemplateValue(value)
+	}
+	for key, value := range overrides {
+		base, baseIsObject := asObject(merged[key])
+		override, overrideIsObject := asObject(value)
+		if baseIsObject && overrideIsObject {
+			merged[key] = mergeTemplateConfig(base, override)
+			continue
+		}
+		merged[key] = copyTemplateValue(value)
+	}
+	return merged
+}
+
+func copyTemplateValue(value interface{}) interface{} {
+	if object, ok := asObject(value); ok {
+		return mergeTemplateConfig(object, nil)
+	}
+	return value
+}
+
+func asObject(value interface{}) (types.Metadata, bool) {
+	switch v := value.(type) {
+	case types.Metadata:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
+
+func (svc sinkService) SaveSinkTemplate(ctx context.Context, token string, template SinkTemplate) (SinkTemplate, error) {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return SinkTemplate{}, err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return SinkTemplate{}, err
+	}
+
+	if !backend.HaveBackend(template.Backend) {
+		return SinkTemplate{}, ErrInvalidBackend
+	}
+	if !svc.backendEnabled(template.Backend) {
+		return SinkTemplate{}, errors.ErrBackendNotEnabled
+	}
+	template.Type, err = resolveSignalType(backend.GetBackend(template.Backend), template.Type)
+	if err != nil {
+		return SinkTemplate{}, err
+	}
+	if template.Config == nil {
+		template.Config = types.Metadata{}
+	}
+	if err := validateTemplateConfig(template.Config); err != nil {
+		return SinkTemplate{}, err
+	}
+	if err := svc.tagLimits.validate(template.Tags); err != nil {
+		return SinkTemplate{}, err
+	}
+
+	saved, err := svc.sinkRepo.SaveTemplate(ctx, template)
+	if err != nil {
+		return SinkTemplate{}, err
+	}
+	svc.logger.Info("sink template saved", zap.String("admin_id", adminID), zap.String("template", template.Name.String()))
+	return saved, nil
+}
+
+func (svc sinkService) ListSinkTemplates(ctx context.Context, token string) ([]SinkTemplate, error) {
+	if _, err := svc.identify(token); err != nil {
+		return nil, err
+	}
+	return svc.sinkRepo.RetrieveAllTemplates(ctx)
+}
+
+func (svc sinkService) RemoveSinkTemplate(ctx context.Context, token string, name string) error {
+	adminID, err := svc.identify(token)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorizeAdmin(adminID); err != nil {
+		return err
+	}
+	if err := svc.sinkRepo.RemoveTemplate(ctx, name); err != nil {
+		return err
+	}
+	svc.logger.Info("sink template removed", zap.String("admin_id", adminID), zap.String("template", name))
+	return nil
+}
+
+func (svc sinkService) CreateSinkFromTemplate(ctx context.Context, token string, name string, overrides Sink) (Sink, error) {
+	mfOwnerID, err := svc.identify(token)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+
+	template, err := svc.sinkRepo.RetrieveTemplate(ctx, name)
+	if err != nil {
+		return Sink{}, errors.Wrap(ErrCreateSink, err)
+	}
+
+	description := template.Description
+	if overrides.Description != nil {
+		description = *overrides.Description
+	}
+	sink := Sink{
+		Name:        overrides.Name,
+		Description: &description,
+		Backend:     template.Backend,
+		Type:        template.Type,
+		Config:      mergeTemplateConfig(template.Config, overrides.Config),
+		Tags:        mergeDefaultTags(template.Tags, overrides.Tags),
+		Format:      "json",
+		Created:     time.Now(),
+	}
+	return svc.createSink(ctx, mfOwnerID, sink)
+}